@@ -67,6 +67,12 @@ type OperatorNode struct {
 	Value string
 }
 
+// SimilarNode represents the text of a similarity search, e.g. similar:"rental agreement"
+type SimilarNode struct {
+	*Base
+	Value string
+}
+
 // GroupNode represents a collection of many grouped nodes
 type GroupNode struct {
 	*Base
@@ -43,12 +43,12 @@ var g = &grammar{
 					pos: position{line: 19, col: 6, offset: 351},
 					exprs: []any{
 						&actionExpr{
-							pos: position{line: 233, col: 5, offset: 4804},
+							pos: position{line: 243, col: 5, offset: 5085},
 							run: (*parser).callonNodes3,
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 233, col: 5, offset: 4804},
+								pos: position{line: 243, col: 5, offset: 5085},
 								expr: &charClassMatcher{
-									pos:        position{line: 233, col: 5, offset: 4804},
+									pos:        position{line: 243, col: 5, offset: 5085},
 									val:        "[ \\t]",
 									chars:      []rune{' ', '\t'},
 									ignoreCase: false,
@@ -75,21 +75,100 @@ var g = &grammar{
 						name: "GroupNode",
 					},
 					&actionExpr{
-						pos: position{line: 46, col: 5, offset: 1042},
+						pos: position{line: 42, col: 5, offset: 913},
 						run: (*parser).callonNode3,
 						expr: &seqExpr{
-							pos: position{line: 46, col: 5, offset: 1042},
+							pos: position{line: 42, col: 5, offset: 913},
 							exprs: []any{
+								&litMatcher{
+									pos:        position{line: 42, col: 5, offset: 913},
+									val:        "similar",
+									ignoreCase: false,
+									want:       "\"similar\"",
+								},
+								&actionExpr{
+									pos: position{line: 130, col: 5, offset: 3192},
+									run: (*parser).callonNode6,
+									expr: &litMatcher{
+										pos:        position{line: 130, col: 5, offset: 3192},
+										val:        ":",
+										ignoreCase: false,
+										want:       "\":\"",
+									},
+								},
 								&labeledExpr{
-									pos:   position{line: 46, col: 5, offset: 1042},
+									pos:   position{line: 42, col: 33, offset: 941},
+									label: "v",
+									expr: &choiceExpr{
+										pos: position{line: 42, col: 36, offset: 944},
+										alternatives: []any{
+											&actionExpr{
+												pos: position{line: 233, col: 5, offset: 4974},
+												run: (*parser).callonNode10,
+												expr: &seqExpr{
+													pos: position{line: 233, col: 5, offset: 4974},
+													exprs: []any{
+														&litMatcher{
+															pos:        position{line: 233, col: 5, offset: 4974},
+															val:        "\"",
+															ignoreCase: false,
+															want:       "\"\\\"\"",
+														},
+														&labeledExpr{
+															pos:   position{line: 233, col: 9, offset: 4978},
+															label: "v",
+															expr: &zeroOrMoreExpr{
+																pos: position{line: 233, col: 11, offset: 4980},
+																expr: &charClassMatcher{
+																	pos:        position{line: 233, col: 11, offset: 4980},
+																	val:        "[^\"]",
+																	chars:      []rune{'"'},
+																	ignoreCase: false,
+																	inverted:   true,
+																},
+															},
+														},
+														&litMatcher{
+															pos:        position{line: 233, col: 17, offset: 4986},
+															val:        "\"",
+															ignoreCase: false,
+															want:       "\"\\\"\"",
+														},
+													},
+												},
+											},
+											&oneOrMoreExpr{
+												pos: position{line: 42, col: 45, offset: 953},
+												expr: &charClassMatcher{
+													pos:        position{line: 42, col: 45, offset: 953},
+													val:        "[^ ()]",
+													chars:      []rune{' ', '(', ')'},
+													ignoreCase: false,
+													inverted:   true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 56, col: 5, offset: 1323},
+						run: (*parser).callonNode19,
+						expr: &seqExpr{
+							pos: position{line: 56, col: 5, offset: 1323},
+							exprs: []any{
+								&labeledExpr{
+									pos:   position{line: 56, col: 5, offset: 1323},
 									label: "k",
 									expr: &oneOrMoreExpr{
-										pos: position{line: 46, col: 7, offset: 1044},
+										pos: position{line: 56, col: 7, offset: 1325},
 										expr: &actionExpr{
-											pos: position{line: 218, col: 5, offset: 4634},
-											run: (*parser).callonNode7,
+											pos: position{line: 228, col: 5, offset: 4915},
+											run: (*parser).callonNode23,
 											expr: &charClassMatcher{
-												pos:        position{line: 218, col: 5, offset: 4634},
+												pos:        position{line: 228, col: 5, offset: 4915},
 												val:        "[A-Za-z]",
 												ranges:     []rune{'A', 'Z', 'a', 'z'},
 												ignoreCase: false,
@@ -99,23 +178,23 @@ var g = &grammar{
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 46, col: 14, offset: 1051},
+									pos: position{line: 56, col: 14, offset: 1332},
 									alternatives: []any{
 										&actionExpr{
-											pos: position{line: 120, col: 5, offset: 2911},
-											run: (*parser).callonNode10,
+											pos: position{line: 130, col: 5, offset: 3192},
+											run: (*parser).callonNode26,
 											expr: &litMatcher{
-												pos:        position{line: 120, col: 5, offset: 2911},
+												pos:        position{line: 130, col: 5, offset: 3192},
 												val:        ":",
 												ignoreCase: false,
 												want:       "\":\"",
 											},
 										},
 										&actionExpr{
-											pos: position{line: 125, col: 5, offset: 2997},
-											run: (*parser).callonNode12,
+											pos: position{line: 135, col: 5, offset: 3278},
+											run: (*parser).callonNode28,
 											expr: &litMatcher{
-												pos:        position{line: 125, col: 5, offset: 2997},
+												pos:        position{line: 135, col: 5, offset: 3278},
 												val:        "=",
 												ignoreCase: false,
 												want:       "\"=\"",
@@ -124,19 +203,19 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 46, col: 53, offset: 1090},
+									pos:   position{line: 56, col: 53, offset: 1371},
 									label: "v",
 									expr: &choiceExpr{
-										pos: position{line: 46, col: 56, offset: 1093},
+										pos: position{line: 56, col: 56, offset: 1374},
 										alternatives: []any{
 											&litMatcher{
-												pos:        position{line: 46, col: 56, offset: 1093},
+												pos:        position{line: 56, col: 56, offset: 1374},
 												val:        "true",
 												ignoreCase: false,
 												want:       "\"true\"",
 											},
 											&litMatcher{
-												pos:        position{line: 46, col: 65, offset: 1102},
+												pos:        position{line: 56, col: 65, offset: 1383},
 												val:        "false",
 												ignoreCase: false,
 												want:       "\"false\"",
@@ -148,21 +227,21 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 51, col: 5, offset: 1203},
-						run: (*parser).callonNode18,
+						pos: position{line: 61, col: 5, offset: 1484},
+						run: (*parser).callonNode34,
 						expr: &seqExpr{
-							pos: position{line: 51, col: 5, offset: 1203},
+							pos: position{line: 61, col: 5, offset: 1484},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 51, col: 5, offset: 1203},
+									pos:   position{line: 61, col: 5, offset: 1484},
 									label: "k",
 									expr: &oneOrMoreExpr{
-										pos: position{line: 51, col: 7, offset: 1205},
+										pos: position{line: 61, col: 7, offset: 1486},
 										expr: &actionExpr{
-											pos: position{line: 218, col: 5, offset: 4634},
-											run: (*parser).callonNode22,
+											pos: position{line: 228, col: 5, offset: 4915},
+											run: (*parser).callonNode38,
 											expr: &charClassMatcher{
-												pos:        position{line: 218, col: 5, offset: 4634},
+												pos:        position{line: 228, col: 5, offset: 4915},
 												val:        "[A-Za-z]",
 												ranges:     []rune{'A', 'Z', 'a', 'z'},
 												ignoreCase: false,
@@ -172,66 +251,66 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 51, col: 13, offset: 1211},
+									pos:   position{line: 61, col: 13, offset: 1492},
 									label: "o",
 									expr: &choiceExpr{
-										pos: position{line: 52, col: 9, offset: 1223},
+										pos: position{line: 62, col: 9, offset: 1504},
 										alternatives: []any{
 											&actionExpr{
-												pos: position{line: 145, col: 5, offset: 3358},
-												run: (*parser).callonNode26,
+												pos: position{line: 155, col: 5, offset: 3639},
+												run: (*parser).callonNode42,
 												expr: &litMatcher{
-													pos:        position{line: 145, col: 5, offset: 3358},
+													pos:        position{line: 155, col: 5, offset: 3639},
 													val:        ">=",
 													ignoreCase: false,
 													want:       "\">=\"",
 												},
 											},
 											&actionExpr{
-												pos: position{line: 135, col: 5, offset: 3174},
-												run: (*parser).callonNode28,
+												pos: position{line: 145, col: 5, offset: 3455},
+												run: (*parser).callonNode44,
 												expr: &litMatcher{
-													pos:        position{line: 135, col: 5, offset: 3174},
+													pos:        position{line: 145, col: 5, offset: 3455},
 													val:        "<=",
 													ignoreCase: false,
 													want:       "\"<=\"",
 												},
 											},
 											&actionExpr{
-												pos: position{line: 140, col: 5, offset: 3263},
-												run: (*parser).callonNode30,
+												pos: position{line: 150, col: 5, offset: 3544},
+												run: (*parser).callonNode46,
 												expr: &litMatcher{
-													pos:        position{line: 140, col: 5, offset: 3263},
+													pos:        position{line: 150, col: 5, offset: 3544},
 													val:        ">",
 													ignoreCase: false,
 													want:       "\">\"",
 												},
 											},
 											&actionExpr{
-												pos: position{line: 130, col: 5, offset: 3082},
-												run: (*parser).callonNode32,
+												pos: position{line: 140, col: 5, offset: 3363},
+												run: (*parser).callonNode48,
 												expr: &litMatcher{
-													pos:        position{line: 130, col: 5, offset: 3082},
+													pos:        position{line: 140, col: 5, offset: 3363},
 													val:        "<",
 													ignoreCase: false,
 													want:       "\"<\"",
 												},
 											},
 											&actionExpr{
-												pos: position{line: 125, col: 5, offset: 2997},
-												run: (*parser).callonNode34,
+												pos: position{line: 135, col: 5, offset: 3278},
+												run: (*parser).callonNode50,
 												expr: &litMatcher{
-													pos:        position{line: 125, col: 5, offset: 2997},
+													pos:        position{line: 135, col: 5, offset: 3278},
 													val:        "=",
 													ignoreCase: false,
 													want:       "\"=\"",
 												},
 											},
 											&actionExpr{
-												pos: position{line: 120, col: 5, offset: 2911},
-												run: (*parser).callonNode36,
+												pos: position{line: 130, col: 5, offset: 3192},
+												run: (*parser).callonNode52,
 												expr: &litMatcher{
-													pos:        position{line: 120, col: 5, offset: 2911},
+													pos:        position{line: 130, col: 5, offset: 3192},
 													val:        ":",
 													ignoreCase: false,
 													want:       "\":\"",
@@ -241,43 +320,43 @@ var g = &grammar{
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 58, col: 7, offset: 1403},
+									pos: position{line: 68, col: 7, offset: 1684},
 									expr: &litMatcher{
-										pos:        position{line: 58, col: 7, offset: 1403},
+										pos:        position{line: 68, col: 7, offset: 1684},
 										val:        "\"",
 										ignoreCase: false,
 										want:       "\"\\\"\"",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 58, col: 12, offset: 1408},
+									pos:   position{line: 68, col: 12, offset: 1689},
 									label: "v",
 									expr: &choiceExpr{
-										pos: position{line: 59, col: 9, offset: 1420},
+										pos: position{line: 69, col: 9, offset: 1701},
 										alternatives: []any{
 											&actionExpr{
-												pos: position{line: 195, col: 5, offset: 4197},
-												run: (*parser).callonNode42,
+												pos: position{line: 205, col: 5, offset: 4478},
+												run: (*parser).callonNode58,
 												expr: &seqExpr{
-													pos: position{line: 195, col: 5, offset: 4197},
+													pos: position{line: 205, col: 5, offset: 4478},
 													exprs: []any{
 														&actionExpr{
-															pos: position{line: 185, col: 5, offset: 3960},
-															run: (*parser).callonNode44,
+															pos: position{line: 195, col: 5, offset: 4241},
+															run: (*parser).callonNode60,
 															expr: &seqExpr{
-																pos: position{line: 185, col: 5, offset: 3960},
+																pos: position{line: 195, col: 5, offset: 4241},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 155, col: 5, offset: 3560},
-																		run: (*parser).callonNode46,
+																		pos: position{line: 165, col: 5, offset: 3841},
+																		run: (*parser).callonNode62,
 																		expr: &seqExpr{
-																			pos: position{line: 155, col: 5, offset: 3560},
+																			pos: position{line: 165, col: 5, offset: 3841},
 																			exprs: []any{
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode48,
+																					pos: position{line: 238, col: 5, offset: 5034},
+																					run: (*parser).callonNode64,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5034},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -285,10 +364,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode50,
+																					pos: position{line: 238, col: 5, offset: 5034},
+																					run: (*parser).callonNode66,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5034},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -296,10 +375,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode52,
+																					pos: position{line: 238, col: 5, offset: 5034},
+																					run: (*parser).callonNode68,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5034},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -307,10 +386,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode54,
+																					pos: position{line: 238, col: 5, offset: 5034},
+																					run: (*parser).callonNode70,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5034},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -321,22 +400,22 @@ var g = &grammar{
 																		},
 																	},
 																	&litMatcher{
-																		pos:        position{line: 185, col: 14, offset: 3969},
+																		pos:        position{line: 195, col: 14, offset: 4250},
 																		val:        "-",
 																		ignoreCase: false,
 																		want:       "\"-\"",
 																	},
 																	&actionExpr{
-																		pos: position{line: 160, col: 5, offset: 3637},
-																		run: (*parser).callonNode57,
+																		pos: position{line: 170, col: 5, offset: 3918},
+																		run: (*parser).callonNode73,
 																		expr: &seqExpr{
-																			pos: position{line: 160, col: 5, offset: 3637},
+																			pos: position{line: 170, col: 5, offset: 3918},
 																			exprs: []any{
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode59,
+																					pos: position{line: 238, col: 5, offset: 5034},
+																					run: (*parser).callonNode75,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5034},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -344,10 +423,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode61,
+																					pos: position{line: 238, col: 5, offset: 5034},
+																					run: (*parser).callonNode77,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5034},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -358,22 +437,22 @@ var g = &grammar{
 																		},
 																	},
 																	&litMatcher{
-																		pos:        position{line: 185, col: 28, offset: 3983},
+																		pos:        position{line: 195, col: 28, offset: 4264},
 																		val:        "-",
 																		ignoreCase: false,
 																		want:       "\"-\"",
 																	},
 																	&actionExpr{
-																		pos: position{line: 165, col: 5, offset: 3700},
-																		run: (*parser).callonNode64,
+																		pos: position{line: 175, col: 5, offset: 3981},
+																		run: (*parser).callonNode80,
 																		expr: &seqExpr{
-																			pos: position{line: 165, col: 5, offset: 3700},
+																			pos: position{line: 175, col: 5, offset: 3981},
 																			exprs: []any{
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode66,
+																					pos: position{line: 238, col: 5, offset: 5034},
+																					run: (*parser).callonNode82,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5034},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -381,10 +460,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode68,
+																					pos: position{line: 238, col: 5, offset: 5034},
+																					run: (*parser).callonNode84,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5034},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -398,28 +477,28 @@ var g = &grammar{
 															},
 														},
 														&litMatcher{
-															pos:        position{line: 195, col: 14, offset: 4206},
+															pos:        position{line: 205, col: 14, offset: 4487},
 															val:        "T",
 															ignoreCase: false,
 															want:       "\"T\"",
 														},
 														&actionExpr{
-															pos: position{line: 190, col: 5, offset: 4047},
-															run: (*parser).callonNode71,
+															pos: position{line: 200, col: 5, offset: 4328},
+															run: (*parser).callonNode87,
 															expr: &seqExpr{
-																pos: position{line: 190, col: 5, offset: 4047},
+																pos: position{line: 200, col: 5, offset: 4328},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 170, col: 5, offset: 3764},
-																		run: (*parser).callonNode73,
+																		pos: position{line: 180, col: 5, offset: 4045},
+																		run: (*parser).callonNode89,
 																		expr: &seqExpr{
-																			pos: position{line: 170, col: 5, offset: 3764},
+																			pos: position{line: 180, col: 5, offset: 4045},
 																			exprs: []any{
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode75,
+																					pos: position{line: 238, col: 5, offset: 5034},
+																					run: (*parser).callonNode91,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5034},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -427,10 +506,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode77,
+																					pos: position{line: 238, col: 5, offset: 5034},
+																					run: (*parser).callonNode93,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5034},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -441,22 +520,22 @@ var g = &grammar{
 																		},
 																	},
 																	&litMatcher{
-																		pos:        position{line: 190, col: 14, offset: 4056},
+																		pos:        position{line: 200, col: 14, offset: 4337},
 																		val:        ":",
 																		ignoreCase: false,
 																		want:       "\":\"",
 																	},
 																	&actionExpr{
-																		pos: position{line: 175, col: 5, offset: 3830},
-																		run: (*parser).callonNode80,
+																		pos: position{line: 185, col: 5, offset: 4111},
+																		run: (*parser).callonNode96,
 																		expr: &seqExpr{
-																			pos: position{line: 175, col: 5, offset: 3830},
+																			pos: position{line: 185, col: 5, offset: 4111},
 																			exprs: []any{
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode82,
+																					pos: position{line: 238, col: 5, offset: 5034},
+																					run: (*parser).callonNode98,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5034},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -464,10 +543,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode84,
+																					pos: position{line: 238, col: 5, offset: 5034},
+																					run: (*parser).callonNode100,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5034},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -478,22 +557,22 @@ var g = &grammar{
 																		},
 																	},
 																	&litMatcher{
-																		pos:        position{line: 190, col: 29, offset: 4071},
+																		pos:        position{line: 200, col: 29, offset: 4352},
 																		val:        ":",
 																		ignoreCase: false,
 																		want:       "\":\"",
 																	},
 																	&actionExpr{
-																		pos: position{line: 180, col: 5, offset: 3896},
-																		run: (*parser).callonNode87,
+																		pos: position{line: 190, col: 5, offset: 4177},
+																		run: (*parser).callonNode103,
 																		expr: &seqExpr{
-																			pos: position{line: 180, col: 5, offset: 3896},
+																			pos: position{line: 190, col: 5, offset: 4177},
 																			exprs: []any{
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode89,
+																					pos: position{line: 238, col: 5, offset: 5034},
+																					run: (*parser).callonNode105,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5034},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -501,10 +580,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode91,
+																					pos: position{line: 238, col: 5, offset: 5034},
+																					run: (*parser).callonNode107,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5034},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -515,23 +594,23 @@ var g = &grammar{
 																		},
 																	},
 																	&zeroOrOneExpr{
-																		pos: position{line: 190, col: 44, offset: 4086},
+																		pos: position{line: 200, col: 44, offset: 4367},
 																		expr: &seqExpr{
-																			pos: position{line: 190, col: 45, offset: 4087},
+																			pos: position{line: 200, col: 45, offset: 4368},
 																			exprs: []any{
 																				&litMatcher{
-																					pos:        position{line: 190, col: 45, offset: 4087},
+																					pos:        position{line: 200, col: 45, offset: 4368},
 																					val:        ".",
 																					ignoreCase: false,
 																					want:       "\".\"",
 																				},
 																				&oneOrMoreExpr{
-																					pos: position{line: 190, col: 49, offset: 4091},
+																					pos: position{line: 200, col: 49, offset: 4372},
 																					expr: &actionExpr{
-																						pos: position{line: 228, col: 5, offset: 4753},
-																						run: (*parser).callonNode97,
+																						pos: position{line: 238, col: 5, offset: 5034},
+																						run: (*parser).callonNode113,
 																						expr: &charClassMatcher{
-																							pos:        position{line: 228, col: 5, offset: 4753},
+																							pos:        position{line: 238, col: 5, offset: 5034},
 																							val:        "[0-9]",
 																							ranges:     []rune{'0', '9'},
 																							ignoreCase: false,
@@ -543,35 +622,35 @@ var g = &grammar{
 																		},
 																	},
 																	&choiceExpr{
-																		pos: position{line: 190, col: 59, offset: 4101},
+																		pos: position{line: 200, col: 59, offset: 4382},
 																		alternatives: []any{
 																			&litMatcher{
-																				pos:        position{line: 190, col: 59, offset: 4101},
+																				pos:        position{line: 200, col: 59, offset: 4382},
 																				val:        "Z",
 																				ignoreCase: false,
 																				want:       "\"Z\"",
 																			},
 																			&seqExpr{
-																				pos: position{line: 190, col: 65, offset: 4107},
+																				pos: position{line: 200, col: 65, offset: 4388},
 																				exprs: []any{
 																					&charClassMatcher{
-																						pos:        position{line: 190, col: 66, offset: 4108},
+																						pos:        position{line: 200, col: 66, offset: 4389},
 																						val:        "[+-]",
 																						chars:      []rune{'+', '-'},
 																						ignoreCase: false,
 																						inverted:   false,
 																					},
 																					&actionExpr{
-																						pos: position{line: 170, col: 5, offset: 3764},
-																						run: (*parser).callonNode103,
+																						pos: position{line: 180, col: 5, offset: 4045},
+																						run: (*parser).callonNode119,
 																						expr: &seqExpr{
-																							pos: position{line: 170, col: 5, offset: 3764},
+																							pos: position{line: 180, col: 5, offset: 4045},
 																							exprs: []any{
 																								&actionExpr{
-																									pos: position{line: 228, col: 5, offset: 4753},
-																									run: (*parser).callonNode105,
+																									pos: position{line: 238, col: 5, offset: 5034},
+																									run: (*parser).callonNode121,
 																									expr: &charClassMatcher{
-																										pos:        position{line: 228, col: 5, offset: 4753},
+																										pos:        position{line: 238, col: 5, offset: 5034},
 																										val:        "[0-9]",
 																										ranges:     []rune{'0', '9'},
 																										ignoreCase: false,
@@ -579,10 +658,10 @@ var g = &grammar{
 																									},
 																								},
 																								&actionExpr{
-																									pos: position{line: 228, col: 5, offset: 4753},
-																									run: (*parser).callonNode107,
+																									pos: position{line: 238, col: 5, offset: 5034},
+																									run: (*parser).callonNode123,
 																									expr: &charClassMatcher{
-																										pos:        position{line: 228, col: 5, offset: 4753},
+																										pos:        position{line: 238, col: 5, offset: 5034},
 																										val:        "[0-9]",
 																										ranges:     []rune{'0', '9'},
 																										ignoreCase: false,
@@ -593,22 +672,22 @@ var g = &grammar{
 																						},
 																					},
 																					&litMatcher{
-																						pos:        position{line: 190, col: 86, offset: 4128},
+																						pos:        position{line: 200, col: 86, offset: 4409},
 																						val:        ":",
 																						ignoreCase: false,
 																						want:       "\":\"",
 																					},
 																					&actionExpr{
-																						pos: position{line: 175, col: 5, offset: 3830},
-																						run: (*parser).callonNode110,
+																						pos: position{line: 185, col: 5, offset: 4111},
+																						run: (*parser).callonNode126,
 																						expr: &seqExpr{
-																							pos: position{line: 175, col: 5, offset: 3830},
+																							pos: position{line: 185, col: 5, offset: 4111},
 																							exprs: []any{
 																								&actionExpr{
-																									pos: position{line: 228, col: 5, offset: 4753},
-																									run: (*parser).callonNode112,
+																									pos: position{line: 238, col: 5, offset: 5034},
+																									run: (*parser).callonNode128,
 																									expr: &charClassMatcher{
-																										pos:        position{line: 228, col: 5, offset: 4753},
+																										pos:        position{line: 238, col: 5, offset: 5034},
 																										val:        "[0-9]",
 																										ranges:     []rune{'0', '9'},
 																										ignoreCase: false,
@@ -616,10 +695,10 @@ var g = &grammar{
 																									},
 																								},
 																								&actionExpr{
-																									pos: position{line: 228, col: 5, offset: 4753},
-																									run: (*parser).callonNode114,
+																									pos: position{line: 238, col: 5, offset: 5034},
+																									run: (*parser).callonNode130,
 																									expr: &charClassMatcher{
-																										pos:        position{line: 228, col: 5, offset: 4753},
+																										pos:        position{line: 238, col: 5, offset: 5034},
 																										val:        "[0-9]",
 																										ranges:     []rune{'0', '9'},
 																										ignoreCase: false,
@@ -640,22 +719,22 @@ var g = &grammar{
 												},
 											},
 											&actionExpr{
-												pos: position{line: 185, col: 5, offset: 3960},
-												run: (*parser).callonNode116,
+												pos: position{line: 195, col: 5, offset: 4241},
+												run: (*parser).callonNode132,
 												expr: &seqExpr{
-													pos: position{line: 185, col: 5, offset: 3960},
+													pos: position{line: 195, col: 5, offset: 4241},
 													exprs: []any{
 														&actionExpr{
-															pos: position{line: 155, col: 5, offset: 3560},
-															run: (*parser).callonNode118,
+															pos: position{line: 165, col: 5, offset: 3841},
+															run: (*parser).callonNode134,
 															expr: &seqExpr{
-																pos: position{line: 155, col: 5, offset: 3560},
+																pos: position{line: 165, col: 5, offset: 3841},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode120,
+																		pos: position{line: 238, col: 5, offset: 5034},
+																		run: (*parser).callonNode136,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5034},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -663,10 +742,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode122,
+																		pos: position{line: 238, col: 5, offset: 5034},
+																		run: (*parser).callonNode138,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5034},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -674,10 +753,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode124,
+																		pos: position{line: 238, col: 5, offset: 5034},
+																		run: (*parser).callonNode140,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5034},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -685,10 +764,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode126,
+																		pos: position{line: 238, col: 5, offset: 5034},
+																		run: (*parser).callonNode142,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5034},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -699,22 +778,22 @@ var g = &grammar{
 															},
 														},
 														&litMatcher{
-															pos:        position{line: 185, col: 14, offset: 3969},
+															pos:        position{line: 195, col: 14, offset: 4250},
 															val:        "-",
 															ignoreCase: false,
 															want:       "\"-\"",
 														},
 														&actionExpr{
-															pos: position{line: 160, col: 5, offset: 3637},
-															run: (*parser).callonNode129,
+															pos: position{line: 170, col: 5, offset: 3918},
+															run: (*parser).callonNode145,
 															expr: &seqExpr{
-																pos: position{line: 160, col: 5, offset: 3637},
+																pos: position{line: 170, col: 5, offset: 3918},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode131,
+																		pos: position{line: 238, col: 5, offset: 5034},
+																		run: (*parser).callonNode147,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5034},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -722,10 +801,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode133,
+																		pos: position{line: 238, col: 5, offset: 5034},
+																		run: (*parser).callonNode149,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5034},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -736,22 +815,22 @@ var g = &grammar{
 															},
 														},
 														&litMatcher{
-															pos:        position{line: 185, col: 28, offset: 3983},
+															pos:        position{line: 195, col: 28, offset: 4264},
 															val:        "-",
 															ignoreCase: false,
 															want:       "\"-\"",
 														},
 														&actionExpr{
-															pos: position{line: 165, col: 5, offset: 3700},
-															run: (*parser).callonNode136,
+															pos: position{line: 175, col: 5, offset: 3981},
+															run: (*parser).callonNode152,
 															expr: &seqExpr{
-																pos: position{line: 165, col: 5, offset: 3700},
+																pos: position{line: 175, col: 5, offset: 3981},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode138,
+																		pos: position{line: 238, col: 5, offset: 5034},
+																		run: (*parser).callonNode154,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5034},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -759,10 +838,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode140,
+																		pos: position{line: 238, col: 5, offset: 5034},
+																		run: (*parser).callonNode156,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5034},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -776,22 +855,22 @@ var g = &grammar{
 												},
 											},
 											&actionExpr{
-												pos: position{line: 190, col: 5, offset: 4047},
-												run: (*parser).callonNode142,
+												pos: position{line: 200, col: 5, offset: 4328},
+												run: (*parser).callonNode158,
 												expr: &seqExpr{
-													pos: position{line: 190, col: 5, offset: 4047},
+													pos: position{line: 200, col: 5, offset: 4328},
 													exprs: []any{
 														&actionExpr{
-															pos: position{line: 170, col: 5, offset: 3764},
-															run: (*parser).callonNode144,
+															pos: position{line: 180, col: 5, offset: 4045},
+															run: (*parser).callonNode160,
 															expr: &seqExpr{
-																pos: position{line: 170, col: 5, offset: 3764},
+																pos: position{line: 180, col: 5, offset: 4045},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode146,
+																		pos: position{line: 238, col: 5, offset: 5034},
+																		run: (*parser).callonNode162,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5034},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -799,10 +878,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode148,
+																		pos: position{line: 238, col: 5, offset: 5034},
+																		run: (*parser).callonNode164,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5034},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -813,22 +892,22 @@ var g = &grammar{
 															},
 														},
 														&litMatcher{
-															pos:        position{line: 190, col: 14, offset: 4056},
+															pos:        position{line: 200, col: 14, offset: 4337},
 															val:        ":",
 															ignoreCase: false,
 															want:       "\":\"",
 														},
 														&actionExpr{
-															pos: position{line: 175, col: 5, offset: 3830},
-															run: (*parser).callonNode151,
+															pos: position{line: 185, col: 5, offset: 4111},
+															run: (*parser).callonNode167,
 															expr: &seqExpr{
-																pos: position{line: 175, col: 5, offset: 3830},
+																pos: position{line: 185, col: 5, offset: 4111},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode153,
+																		pos: position{line: 238, col: 5, offset: 5034},
+																		run: (*parser).callonNode169,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5034},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -836,10 +915,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode155,
+																		pos: position{line: 238, col: 5, offset: 5034},
+																		run: (*parser).callonNode171,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5034},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -850,22 +929,22 @@ var g = &grammar{
 															},
 														},
 														&litMatcher{
-															pos:        position{line: 190, col: 29, offset: 4071},
+															pos:        position{line: 200, col: 29, offset: 4352},
 															val:        ":",
 															ignoreCase: false,
 															want:       "\":\"",
 														},
 														&actionExpr{
-															pos: position{line: 180, col: 5, offset: 3896},
-															run: (*parser).callonNode158,
+															pos: position{line: 190, col: 5, offset: 4177},
+															run: (*parser).callonNode174,
 															expr: &seqExpr{
-																pos: position{line: 180, col: 5, offset: 3896},
+																pos: position{line: 190, col: 5, offset: 4177},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode160,
+																		pos: position{line: 238, col: 5, offset: 5034},
+																		run: (*parser).callonNode176,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5034},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -873,10 +952,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode162,
+																		pos: position{line: 238, col: 5, offset: 5034},
+																		run: (*parser).callonNode178,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5034},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -887,23 +966,23 @@ var g = &grammar{
 															},
 														},
 														&zeroOrOneExpr{
-															pos: position{line: 190, col: 44, offset: 4086},
+															pos: position{line: 200, col: 44, offset: 4367},
 															expr: &seqExpr{
-																pos: position{line: 190, col: 45, offset: 4087},
+																pos: position{line: 200, col: 45, offset: 4368},
 																exprs: []any{
 																	&litMatcher{
-																		pos:        position{line: 190, col: 45, offset: 4087},
+																		pos:        position{line: 200, col: 45, offset: 4368},
 																		val:        ".",
 																		ignoreCase: false,
 																		want:       "\".\"",
 																	},
 																	&oneOrMoreExpr{
-																		pos: position{line: 190, col: 49, offset: 4091},
+																		pos: position{line: 200, col: 49, offset: 4372},
 																		expr: &actionExpr{
-																			pos: position{line: 228, col: 5, offset: 4753},
-																			run: (*parser).callonNode168,
+																			pos: position{line: 238, col: 5, offset: 5034},
+																			run: (*parser).callonNode184,
 																			expr: &charClassMatcher{
-																				pos:        position{line: 228, col: 5, offset: 4753},
+																				pos:        position{line: 238, col: 5, offset: 5034},
 																				val:        "[0-9]",
 																				ranges:     []rune{'0', '9'},
 																				ignoreCase: false,
@@ -915,35 +994,35 @@ var g = &grammar{
 															},
 														},
 														&choiceExpr{
-															pos: position{line: 190, col: 59, offset: 4101},
+															pos: position{line: 200, col: 59, offset: 4382},
 															alternatives: []any{
 																&litMatcher{
-																	pos:        position{line: 190, col: 59, offset: 4101},
+																	pos:        position{line: 200, col: 59, offset: 4382},
 																	val:        "Z",
 																	ignoreCase: false,
 																	want:       "\"Z\"",
 																},
 																&seqExpr{
-																	pos: position{line: 190, col: 65, offset: 4107},
+																	pos: position{line: 200, col: 65, offset: 4388},
 																	exprs: []any{
 																		&charClassMatcher{
-																			pos:        position{line: 190, col: 66, offset: 4108},
+																			pos:        position{line: 200, col: 66, offset: 4389},
 																			val:        "[+-]",
 																			chars:      []rune{'+', '-'},
 																			ignoreCase: false,
 																			inverted:   false,
 																		},
 																		&actionExpr{
-																			pos: position{line: 170, col: 5, offset: 3764},
-																			run: (*parser).callonNode174,
+																			pos: position{line: 180, col: 5, offset: 4045},
+																			run: (*parser).callonNode190,
 																			expr: &seqExpr{
-																				pos: position{line: 170, col: 5, offset: 3764},
+																				pos: position{line: 180, col: 5, offset: 4045},
 																				exprs: []any{
 																					&actionExpr{
-																						pos: position{line: 228, col: 5, offset: 4753},
-																						run: (*parser).callonNode176,
+																						pos: position{line: 238, col: 5, offset: 5034},
+																						run: (*parser).callonNode192,
 																						expr: &charClassMatcher{
-																							pos:        position{line: 228, col: 5, offset: 4753},
+																							pos:        position{line: 238, col: 5, offset: 5034},
 																							val:        "[0-9]",
 																							ranges:     []rune{'0', '9'},
 																							ignoreCase: false,
@@ -951,10 +1030,10 @@ var g = &grammar{
 																						},
 																					},
 																					&actionExpr{
-																						pos: position{line: 228, col: 5, offset: 4753},
-																						run: (*parser).callonNode178,
+																						pos: position{line: 238, col: 5, offset: 5034},
+																						run: (*parser).callonNode194,
 																						expr: &charClassMatcher{
-																							pos:        position{line: 228, col: 5, offset: 4753},
+																							pos:        position{line: 238, col: 5, offset: 5034},
 																							val:        "[0-9]",
 																							ranges:     []rune{'0', '9'},
 																							ignoreCase: false,
@@ -965,22 +1044,22 @@ var g = &grammar{
 																			},
 																		},
 																		&litMatcher{
-																			pos:        position{line: 190, col: 86, offset: 4128},
+																			pos:        position{line: 200, col: 86, offset: 4409},
 																			val:        ":",
 																			ignoreCase: false,
 																			want:       "\":\"",
 																		},
 																		&actionExpr{
-																			pos: position{line: 175, col: 5, offset: 3830},
-																			run: (*parser).callonNode181,
+																			pos: position{line: 185, col: 5, offset: 4111},
+																			run: (*parser).callonNode197,
 																			expr: &seqExpr{
-																				pos: position{line: 175, col: 5, offset: 3830},
+																				pos: position{line: 185, col: 5, offset: 4111},
 																				exprs: []any{
 																					&actionExpr{
-																						pos: position{line: 228, col: 5, offset: 4753},
-																						run: (*parser).callonNode183,
+																						pos: position{line: 238, col: 5, offset: 5034},
+																						run: (*parser).callonNode199,
 																						expr: &charClassMatcher{
-																							pos:        position{line: 228, col: 5, offset: 4753},
+																							pos:        position{line: 238, col: 5, offset: 5034},
 																							val:        "[0-9]",
 																							ranges:     []rune{'0', '9'},
 																							ignoreCase: false,
@@ -988,10 +1067,10 @@ var g = &grammar{
 																						},
 																					},
 																					&actionExpr{
-																						pos: position{line: 228, col: 5, offset: 4753},
-																						run: (*parser).callonNode185,
+																						pos: position{line: 238, col: 5, offset: 5034},
+																						run: (*parser).callonNode201,
 																						expr: &charClassMatcher{
-																							pos:        position{line: 228, col: 5, offset: 4753},
+																							pos:        position{line: 238, col: 5, offset: 5034},
 																							val:        "[0-9]",
 																							ranges:     []rune{'0', '9'},
 																							ignoreCase: false,
@@ -1012,9 +1091,9 @@ var g = &grammar{
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 62, col: 7, offset: 1473},
+									pos: position{line: 72, col: 7, offset: 1754},
 									expr: &litMatcher{
-										pos:        position{line: 62, col: 7, offset: 1473},
+										pos:        position{line: 72, col: 7, offset: 1754},
 										val:        "\"",
 										ignoreCase: false,
 										want:       "\"\\\"\"",
@@ -1024,21 +1103,21 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 65, col: 5, offset: 1549},
-						run: (*parser).callonNode189,
+						pos: position{line: 75, col: 5, offset: 1830},
+						run: (*parser).callonNode205,
 						expr: &seqExpr{
-							pos: position{line: 65, col: 5, offset: 1549},
+							pos: position{line: 75, col: 5, offset: 1830},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 65, col: 5, offset: 1549},
+									pos:   position{line: 75, col: 5, offset: 1830},
 									label: "k",
 									expr: &oneOrMoreExpr{
-										pos: position{line: 65, col: 7, offset: 1551},
+										pos: position{line: 75, col: 7, offset: 1832},
 										expr: &actionExpr{
-											pos: position{line: 218, col: 5, offset: 4634},
-											run: (*parser).callonNode193,
+											pos: position{line: 228, col: 5, offset: 4915},
+											run: (*parser).callonNode209,
 											expr: &charClassMatcher{
-												pos:        position{line: 218, col: 5, offset: 4634},
+												pos:        position{line: 228, col: 5, offset: 4915},
 												val:        "[A-Za-z]",
 												ranges:     []rune{'A', 'Z', 'a', 'z'},
 												ignoreCase: false,
@@ -1048,23 +1127,23 @@ var g = &grammar{
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 66, col: 9, offset: 1567},
+									pos: position{line: 76, col: 9, offset: 1848},
 									alternatives: []any{
 										&actionExpr{
-											pos: position{line: 125, col: 5, offset: 2997},
-											run: (*parser).callonNode196,
+											pos: position{line: 135, col: 5, offset: 3278},
+											run: (*parser).callonNode212,
 											expr: &litMatcher{
-												pos:        position{line: 125, col: 5, offset: 2997},
+												pos:        position{line: 135, col: 5, offset: 3278},
 												val:        "=",
 												ignoreCase: false,
 												want:       "\"=\"",
 											},
 										},
 										&actionExpr{
-											pos: position{line: 120, col: 5, offset: 2911},
-											run: (*parser).callonNode198,
+											pos: position{line: 130, col: 5, offset: 3192},
+											run: (*parser).callonNode214,
 											expr: &litMatcher{
-												pos:        position{line: 120, col: 5, offset: 2911},
+												pos:        position{line: 130, col: 5, offset: 3192},
 												val:        ":",
 												ignoreCase: false,
 												want:       "\":\"",
@@ -1073,79 +1152,79 @@ var g = &grammar{
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 68, col: 7, offset: 1619},
+									pos: position{line: 78, col: 7, offset: 1900},
 									expr: &litMatcher{
-										pos:        position{line: 68, col: 7, offset: 1619},
+										pos:        position{line: 78, col: 7, offset: 1900},
 										val:        "\"",
 										ignoreCase: false,
 										want:       "\"\\\"\"",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 68, col: 12, offset: 1624},
+									pos:   position{line: 78, col: 12, offset: 1905},
 									label: "v",
 									expr: &choiceExpr{
-										pos: position{line: 200, col: 5, offset: 4285},
+										pos: position{line: 210, col: 5, offset: 4566},
 										alternatives: []any{
 											&litMatcher{
-												pos:        position{line: 200, col: 5, offset: 4285},
+												pos:        position{line: 210, col: 5, offset: 4566},
 												val:        "today",
 												ignoreCase: false,
 												want:       "\"today\"",
 											},
 											&litMatcher{
-												pos:        position{line: 201, col: 5, offset: 4299},
+												pos:        position{line: 211, col: 5, offset: 4580},
 												val:        "yesterday",
 												ignoreCase: false,
 												want:       "\"yesterday\"",
 											},
 											&litMatcher{
-												pos:        position{line: 202, col: 5, offset: 4317},
+												pos:        position{line: 212, col: 5, offset: 4598},
 												val:        "this week",
 												ignoreCase: false,
 												want:       "\"this week\"",
 											},
 											&litMatcher{
-												pos:        position{line: 203, col: 5, offset: 4335},
+												pos:        position{line: 213, col: 5, offset: 4616},
 												val:        "last week",
 												ignoreCase: false,
 												want:       "\"last week\"",
 											},
 											&litMatcher{
-												pos:        position{line: 204, col: 5, offset: 4353},
+												pos:        position{line: 214, col: 5, offset: 4634},
 												val:        "last 7 days",
 												ignoreCase: false,
 												want:       "\"last 7 days\"",
 											},
 											&litMatcher{
-												pos:        position{line: 205, col: 5, offset: 4373},
+												pos:        position{line: 215, col: 5, offset: 4654},
 												val:        "this month",
 												ignoreCase: false,
 												want:       "\"this month\"",
 											},
 											&litMatcher{
-												pos:        position{line: 206, col: 5, offset: 4392},
+												pos:        position{line: 216, col: 5, offset: 4673},
 												val:        "last month",
 												ignoreCase: false,
 												want:       "\"last month\"",
 											},
 											&litMatcher{
-												pos:        position{line: 207, col: 5, offset: 4411},
+												pos:        position{line: 217, col: 5, offset: 4692},
 												val:        "last 30 days",
 												ignoreCase: false,
 												want:       "\"last 30 days\"",
 											},
 											&litMatcher{
-												pos:        position{line: 208, col: 5, offset: 4432},
+												pos:        position{line: 218, col: 5, offset: 4713},
 												val:        "this year",
 												ignoreCase: false,
 												want:       "\"this year\"",
 											},
 											&actionExpr{
-												pos: position{line: 209, col: 5, offset: 4450},
-												run: (*parser).callonNode213,
+												pos: position{line: 219, col: 5, offset: 4731},
+												run: (*parser).callonNode229,
 												expr: &litMatcher{
-													pos:        position{line: 209, col: 5, offset: 4450},
+													pos:        position{line: 219, col: 5, offset: 4731},
 													val:        "last year",
 													ignoreCase: false,
 													want:       "\"last year\"",
@@ -1155,9 +1234,9 @@ var g = &grammar{
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 68, col: 38, offset: 1650},
+									pos: position{line: 78, col: 38, offset: 1931},
 									expr: &litMatcher{
-										pos:        position{line: 68, col: 38, offset: 1650},
+										pos:        position{line: 78, col: 38, offset: 1931},
 										val:        "\"",
 										ignoreCase: false,
 										want:       "\"\\\"\"",
@@ -1167,21 +1246,21 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 73, col: 5, offset: 1769},
-						run: (*parser).callonNode217,
+						pos: position{line: 83, col: 5, offset: 2050},
+						run: (*parser).callonNode233,
 						expr: &seqExpr{
-							pos: position{line: 73, col: 5, offset: 1769},
+							pos: position{line: 83, col: 5, offset: 2050},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 73, col: 5, offset: 1769},
+									pos:   position{line: 83, col: 5, offset: 2050},
 									label: "k",
 									expr: &oneOrMoreExpr{
-										pos: position{line: 73, col: 7, offset: 1771},
+										pos: position{line: 83, col: 7, offset: 2052},
 										expr: &actionExpr{
-											pos: position{line: 218, col: 5, offset: 4634},
-											run: (*parser).callonNode221,
+											pos: position{line: 228, col: 5, offset: 4915},
+											run: (*parser).callonNode237,
 											expr: &charClassMatcher{
-												pos:        position{line: 218, col: 5, offset: 4634},
+												pos:        position{line: 228, col: 5, offset: 4915},
 												val:        "[A-Za-z]",
 												ranges:     []rune{'A', 'Z', 'a', 'z'},
 												ignoreCase: false,
@@ -1191,23 +1270,23 @@ var g = &grammar{
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 73, col: 14, offset: 1778},
+									pos: position{line: 83, col: 14, offset: 2059},
 									alternatives: []any{
 										&actionExpr{
-											pos: position{line: 120, col: 5, offset: 2911},
-											run: (*parser).callonNode224,
+											pos: position{line: 130, col: 5, offset: 3192},
+											run: (*parser).callonNode240,
 											expr: &litMatcher{
-												pos:        position{line: 120, col: 5, offset: 2911},
+												pos:        position{line: 130, col: 5, offset: 3192},
 												val:        ":",
 												ignoreCase: false,
 												want:       "\":\"",
 											},
 										},
 										&actionExpr{
-											pos: position{line: 125, col: 5, offset: 2997},
-											run: (*parser).callonNode226,
+											pos: position{line: 135, col: 5, offset: 3278},
+											run: (*parser).callonNode242,
 											expr: &litMatcher{
-												pos:        position{line: 125, col: 5, offset: 2997},
+												pos:        position{line: 135, col: 5, offset: 3278},
 												val:        "=",
 												ignoreCase: false,
 												want:       "\"=\"",
@@ -1216,30 +1295,30 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 73, col: 53, offset: 1817},
+									pos:   position{line: 83, col: 53, offset: 2098},
 									label: "v",
 									expr: &choiceExpr{
-										pos: position{line: 73, col: 56, offset: 1820},
+										pos: position{line: 83, col: 56, offset: 2101},
 										alternatives: []any{
 											&actionExpr{
-												pos: position{line: 223, col: 5, offset: 4693},
-												run: (*parser).callonNode230,
+												pos: position{line: 233, col: 5, offset: 4974},
+												run: (*parser).callonNode246,
 												expr: &seqExpr{
-													pos: position{line: 223, col: 5, offset: 4693},
+													pos: position{line: 233, col: 5, offset: 4974},
 													exprs: []any{
 														&litMatcher{
-															pos:        position{line: 223, col: 5, offset: 4693},
+															pos:        position{line: 233, col: 5, offset: 4974},
 															val:        "\"",
 															ignoreCase: false,
 															want:       "\"\\\"\"",
 														},
 														&labeledExpr{
-															pos:   position{line: 223, col: 9, offset: 4697},
+															pos:   position{line: 233, col: 9, offset: 4978},
 															label: "v",
 															expr: &zeroOrMoreExpr{
-																pos: position{line: 223, col: 11, offset: 4699},
+																pos: position{line: 233, col: 11, offset: 4980},
 																expr: &charClassMatcher{
-																	pos:        position{line: 223, col: 11, offset: 4699},
+																	pos:        position{line: 233, col: 11, offset: 4980},
 																	val:        "[^\"]",
 																	chars:      []rune{'"'},
 																	ignoreCase: false,
@@ -1248,7 +1327,7 @@ var g = &grammar{
 															},
 														},
 														&litMatcher{
-															pos:        position{line: 223, col: 17, offset: 4705},
+															pos:        position{line: 233, col: 17, offset: 4986},
 															val:        "\"",
 															ignoreCase: false,
 															want:       "\"\\\"\"",
@@ -1257,9 +1336,9 @@ var g = &grammar{
 												},
 											},
 											&oneOrMoreExpr{
-												pos: position{line: 73, col: 65, offset: 1829},
+												pos: position{line: 83, col: 65, offset: 2110},
 												expr: &charClassMatcher{
-													pos:        position{line: 73, col: 65, offset: 1829},
+													pos:        position{line: 83, col: 65, offset: 2110},
 													val:        "[^ ()]",
 													chars:      []rune{' ', '(', ')'},
 													ignoreCase: false,
@@ -1273,19 +1352,19 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 105, col: 5, offset: 2621},
-						run: (*parser).callonNode239,
+						pos: position{line: 115, col: 5, offset: 2902},
+						run: (*parser).callonNode255,
 						expr: &choiceExpr{
-							pos: position{line: 105, col: 6, offset: 2622},
+							pos: position{line: 115, col: 6, offset: 2903},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 105, col: 6, offset: 2622},
+									pos:        position{line: 115, col: 6, offset: 2903},
 									val:        "AND",
 									ignoreCase: false,
 									want:       "\"AND\"",
 								},
 								&litMatcher{
-									pos:        position{line: 105, col: 14, offset: 2630},
+									pos:        position{line: 115, col: 14, offset: 2911},
 									val:        "+",
 									ignoreCase: false,
 									want:       "\"+\"",
@@ -1294,19 +1373,19 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 110, col: 5, offset: 2722},
-						run: (*parser).callonNode243,
+						pos: position{line: 120, col: 5, offset: 3003},
+						run: (*parser).callonNode259,
 						expr: &choiceExpr{
-							pos: position{line: 110, col: 6, offset: 2723},
+							pos: position{line: 120, col: 6, offset: 3004},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 110, col: 6, offset: 2723},
+									pos:        position{line: 120, col: 6, offset: 3004},
 									val:        "NOT",
 									ignoreCase: false,
 									want:       "\"NOT\"",
 								},
 								&litMatcher{
-									pos:        position{line: 110, col: 14, offset: 2731},
+									pos:        position{line: 120, col: 14, offset: 3012},
 									val:        "-",
 									ignoreCase: false,
 									want:       "\"-\"",
@@ -1315,28 +1394,28 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 115, col: 5, offset: 2822},
-						run: (*parser).callonNode247,
+						pos: position{line: 125, col: 5, offset: 3103},
+						run: (*parser).callonNode263,
 						expr: &litMatcher{
-							pos:        position{line: 115, col: 6, offset: 2823},
+							pos:        position{line: 125, col: 6, offset: 3104},
 							val:        "OR",
 							ignoreCase: false,
 							want:       "\"OR\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 86, col: 6, offset: 2109},
-						run: (*parser).callonNode249,
+						pos: position{line: 96, col: 6, offset: 2390},
+						run: (*parser).callonNode265,
 						expr: &seqExpr{
-							pos: position{line: 86, col: 6, offset: 2109},
+							pos: position{line: 96, col: 6, offset: 2390},
 							exprs: []any{
 								&zeroOrOneExpr{
-									pos: position{line: 86, col: 6, offset: 2109},
+									pos: position{line: 96, col: 6, offset: 2390},
 									expr: &actionExpr{
-										pos: position{line: 120, col: 5, offset: 2911},
-										run: (*parser).callonNode252,
+										pos: position{line: 130, col: 5, offset: 3192},
+										run: (*parser).callonNode268,
 										expr: &litMatcher{
-											pos:        position{line: 120, col: 5, offset: 2911},
+											pos:        position{line: 130, col: 5, offset: 3192},
 											val:        ":",
 											ignoreCase: false,
 											want:       "\":\"",
@@ -1344,12 +1423,12 @@ var g = &grammar{
 									},
 								},
 								&actionExpr{
-									pos: position{line: 233, col: 5, offset: 4804},
-									run: (*parser).callonNode254,
+									pos: position{line: 243, col: 5, offset: 5085},
+									run: (*parser).callonNode270,
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 233, col: 5, offset: 4804},
+										pos: position{line: 243, col: 5, offset: 5085},
 										expr: &charClassMatcher{
-											pos:        position{line: 233, col: 5, offset: 4804},
+											pos:        position{line: 243, col: 5, offset: 5085},
 											val:        "[ \\t]",
 											chars:      []rune{' ', '\t'},
 											ignoreCase: false,
@@ -1358,27 +1437,27 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 86, col: 27, offset: 2130},
+									pos:   position{line: 96, col: 27, offset: 2411},
 									label: "v",
 									expr: &actionExpr{
-										pos: position{line: 223, col: 5, offset: 4693},
-										run: (*parser).callonNode258,
+										pos: position{line: 233, col: 5, offset: 4974},
+										run: (*parser).callonNode274,
 										expr: &seqExpr{
-											pos: position{line: 223, col: 5, offset: 4693},
+											pos: position{line: 233, col: 5, offset: 4974},
 											exprs: []any{
 												&litMatcher{
-													pos:        position{line: 223, col: 5, offset: 4693},
+													pos:        position{line: 233, col: 5, offset: 4974},
 													val:        "\"",
 													ignoreCase: false,
 													want:       "\"\\\"\"",
 												},
 												&labeledExpr{
-													pos:   position{line: 223, col: 9, offset: 4697},
+													pos:   position{line: 233, col: 9, offset: 4978},
 													label: "v",
 													expr: &zeroOrMoreExpr{
-														pos: position{line: 223, col: 11, offset: 4699},
+														pos: position{line: 233, col: 11, offset: 4980},
 														expr: &charClassMatcher{
-															pos:        position{line: 223, col: 11, offset: 4699},
+															pos:        position{line: 233, col: 11, offset: 4980},
 															val:        "[^\"]",
 															chars:      []rune{'"'},
 															ignoreCase: false,
@@ -1387,7 +1466,7 @@ var g = &grammar{
 													},
 												},
 												&litMatcher{
-													pos:        position{line: 223, col: 17, offset: 4705},
+													pos:        position{line: 233, col: 17, offset: 4986},
 													val:        "\"",
 													ignoreCase: false,
 													want:       "\"\\\"\"",
@@ -1397,12 +1476,12 @@ var g = &grammar{
 									},
 								},
 								&actionExpr{
-									pos: position{line: 233, col: 5, offset: 4804},
-									run: (*parser).callonNode265,
+									pos: position{line: 243, col: 5, offset: 5085},
+									run: (*parser).callonNode281,
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 233, col: 5, offset: 4804},
+										pos: position{line: 243, col: 5, offset: 5085},
 										expr: &charClassMatcher{
-											pos:        position{line: 233, col: 5, offset: 4804},
+											pos:        position{line: 243, col: 5, offset: 5085},
 											val:        "[ \\t]",
 											chars:      []rune{' ', '\t'},
 											ignoreCase: false,
@@ -1411,12 +1490,12 @@ var g = &grammar{
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 86, col: 38, offset: 2141},
+									pos: position{line: 96, col: 38, offset: 2422},
 									expr: &actionExpr{
-										pos: position{line: 120, col: 5, offset: 2911},
-										run: (*parser).callonNode269,
+										pos: position{line: 130, col: 5, offset: 3192},
+										run: (*parser).callonNode285,
 										expr: &litMatcher{
-											pos:        position{line: 120, col: 5, offset: 2911},
+											pos:        position{line: 130, col: 5, offset: 3192},
 											val:        ":",
 											ignoreCase: false,
 											want:       "\":\"",
@@ -1427,18 +1506,18 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 91, col: 6, offset: 2239},
-						run: (*parser).callonNode271,
+						pos: position{line: 101, col: 6, offset: 2520},
+						run: (*parser).callonNode287,
 						expr: &seqExpr{
-							pos: position{line: 91, col: 6, offset: 2239},
+							pos: position{line: 101, col: 6, offset: 2520},
 							exprs: []any{
 								&zeroOrOneExpr{
-									pos: position{line: 91, col: 6, offset: 2239},
+									pos: position{line: 101, col: 6, offset: 2520},
 									expr: &actionExpr{
-										pos: position{line: 120, col: 5, offset: 2911},
-										run: (*parser).callonNode274,
+										pos: position{line: 130, col: 5, offset: 3192},
+										run: (*parser).callonNode290,
 										expr: &litMatcher{
-											pos:        position{line: 120, col: 5, offset: 2911},
+											pos:        position{line: 130, col: 5, offset: 3192},
 											val:        ":",
 											ignoreCase: false,
 											want:       "\":\"",
@@ -1446,12 +1525,12 @@ var g = &grammar{
 									},
 								},
 								&actionExpr{
-									pos: position{line: 233, col: 5, offset: 4804},
-									run: (*parser).callonNode276,
+									pos: position{line: 243, col: 5, offset: 5085},
+									run: (*parser).callonNode292,
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 233, col: 5, offset: 4804},
+										pos: position{line: 243, col: 5, offset: 5085},
 										expr: &charClassMatcher{
-											pos:        position{line: 233, col: 5, offset: 4804},
+											pos:        position{line: 243, col: 5, offset: 5085},
 											val:        "[ \\t]",
 											chars:      []rune{' ', '\t'},
 											ignoreCase: false,
@@ -1460,12 +1539,12 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 91, col: 27, offset: 2260},
+									pos:   position{line: 101, col: 27, offset: 2541},
 									label: "v",
 									expr: &oneOrMoreExpr{
-										pos: position{line: 91, col: 29, offset: 2262},
+										pos: position{line: 101, col: 29, offset: 2543},
 										expr: &charClassMatcher{
-											pos:        position{line: 91, col: 29, offset: 2262},
+											pos:        position{line: 101, col: 29, offset: 2543},
 											val:        "[^ :()]",
 											chars:      []rune{' ', ':', '(', ')'},
 											ignoreCase: false,
@@ -1474,12 +1553,12 @@ var g = &grammar{
 									},
 								},
 								&actionExpr{
-									pos: position{line: 233, col: 5, offset: 4804},
-									run: (*parser).callonNode282,
+									pos: position{line: 243, col: 5, offset: 5085},
+									run: (*parser).callonNode298,
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 233, col: 5, offset: 4804},
+										pos: position{line: 243, col: 5, offset: 5085},
 										expr: &charClassMatcher{
-											pos:        position{line: 233, col: 5, offset: 4804},
+											pos:        position{line: 243, col: 5, offset: 5085},
 											val:        "[ \\t]",
 											chars:      []rune{' ', '\t'},
 											ignoreCase: false,
@@ -1488,12 +1567,12 @@ var g = &grammar{
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 91, col: 40, offset: 2273},
+									pos: position{line: 101, col: 40, offset: 2554},
 									expr: &actionExpr{
-										pos: position{line: 120, col: 5, offset: 2911},
-										run: (*parser).callonNode286,
+										pos: position{line: 130, col: 5, offset: 3192},
+										run: (*parser).callonNode302,
 										expr: &litMatcher{
-											pos:        position{line: 120, col: 5, offset: 2911},
+											pos:        position{line: 130, col: 5, offset: 3192},
 											val:        ":",
 											ignoreCase: false,
 											want:       "\":\"",
@@ -1508,25 +1587,25 @@ var g = &grammar{
 		},
 		{
 			name: "GroupNode",
-			pos:  position{line: 31, col: 1, offset: 595},
+			pos:  position{line: 32, col: 1, offset: 613},
 			expr: &actionExpr{
-				pos: position{line: 32, col: 5, offset: 612},
+				pos: position{line: 33, col: 5, offset: 630},
 				run: (*parser).callonGroupNode1,
 				expr: &seqExpr{
-					pos: position{line: 32, col: 5, offset: 612},
+					pos: position{line: 33, col: 5, offset: 630},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 32, col: 5, offset: 612},
+							pos:   position{line: 33, col: 5, offset: 630},
 							label: "k",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 32, col: 7, offset: 614},
+								pos: position{line: 33, col: 7, offset: 632},
 								expr: &oneOrMoreExpr{
-									pos: position{line: 32, col: 8, offset: 615},
+									pos: position{line: 33, col: 8, offset: 633},
 									expr: &actionExpr{
-										pos: position{line: 218, col: 5, offset: 4634},
+										pos: position{line: 228, col: 5, offset: 4915},
 										run: (*parser).callonGroupNode6,
 										expr: &charClassMatcher{
-											pos:        position{line: 218, col: 5, offset: 4634},
+											pos:        position{line: 228, col: 5, offset: 4915},
 											val:        "[A-Za-z]",
 											ranges:     []rune{'A', 'Z', 'a', 'z'},
 											ignoreCase: false,
@@ -1537,25 +1616,25 @@ var g = &grammar{
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 32, col: 16, offset: 623},
+							pos: position{line: 33, col: 16, offset: 641},
 							expr: &choiceExpr{
-								pos: position{line: 32, col: 17, offset: 624},
+								pos: position{line: 33, col: 17, offset: 642},
 								alternatives: []any{
 									&actionExpr{
-										pos: position{line: 120, col: 5, offset: 2911},
+										pos: position{line: 130, col: 5, offset: 3192},
 										run: (*parser).callonGroupNode10,
 										expr: &litMatcher{
-											pos:        position{line: 120, col: 5, offset: 2911},
+											pos:        position{line: 130, col: 5, offset: 3192},
 											val:        ":",
 											ignoreCase: false,
 											want:       "\":\"",
 										},
 									},
 									&actionExpr{
-										pos: position{line: 125, col: 5, offset: 2997},
+										pos: position{line: 135, col: 5, offset: 3278},
 										run: (*parser).callonGroupNode12,
 										expr: &litMatcher{
-											pos:        position{line: 125, col: 5, offset: 2997},
+											pos:        position{line: 135, col: 5, offset: 3278},
 											val:        "=",
 											ignoreCase: false,
 											want:       "\"=\"",
@@ -1565,21 +1644,21 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 32, col: 57, offset: 664},
+							pos:        position{line: 33, col: 57, offset: 682},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 32, col: 61, offset: 668},
+							pos:   position{line: 33, col: 61, offset: 686},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 32, col: 63, offset: 670},
+								pos:  position{line: 33, col: 63, offset: 688},
 								name: "Nodes",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 32, col: 69, offset: 676},
+							pos:        position{line: 33, col: 69, offset: 694},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -1613,59 +1692,48 @@ func (p *parser) callonNodes3() (any, error) {
 	return p.cur.onNodes3()
 }
 
-func (c *current) onNode7() (any, error) {
-	return c.text, nil
-
-}
-
-func (p *parser) callonNode7() (any, error) {
-	stack := p.vstack[len(p.vstack)-1]
-	_ = stack
-	return p.cur.onNode7()
-}
-
-func (c *current) onNode10() (any, error) {
+func (c *current) onNode6() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode10() (any, error) {
+func (p *parser) callonNode6() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode10()
+	return p.cur.onNode6()
 }
 
-func (c *current) onNode12() (any, error) {
-	return buildOperatorNode(c.text, c.pos)
+func (c *current) onNode10(v any) (any, error) {
+	return v, nil
 
 }
 
-func (p *parser) callonNode12() (any, error) {
+func (p *parser) callonNode10() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode12()
+	return p.cur.onNode10(stack["v"])
 }
 
-func (c *current) onNode3(k, v any) (any, error) {
-	return buildBooleanNode(k, v, c.text, c.pos)
+func (c *current) onNode3(v any) (any, error) {
+	return buildSimilarNode(v, c.text, c.pos)
 
 }
 
 func (p *parser) callonNode3() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode3(stack["k"], stack["v"])
+	return p.cur.onNode3(stack["v"])
 }
 
-func (c *current) onNode22() (any, error) {
+func (c *current) onNode23() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode22() (any, error) {
+func (p *parser) callonNode23() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode22()
+	return p.cur.onNode23()
 }
 
 func (c *current) onNode26() (any, error) {
@@ -1690,52 +1758,63 @@ func (p *parser) callonNode28() (any, error) {
 	return p.cur.onNode28()
 }
 
-func (c *current) onNode30() (any, error) {
-	return buildOperatorNode(c.text, c.pos)
+func (c *current) onNode19(k, v any) (any, error) {
+	return buildBooleanNode(k, v, c.text, c.pos)
+
+}
 
+func (p *parser) callonNode19() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode19(stack["k"], stack["v"])
 }
 
-func (p *parser) callonNode30() (any, error) {
+func (c *current) onNode38() (any, error) {
+	return c.text, nil
+
+}
+
+func (p *parser) callonNode38() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode30()
+	return p.cur.onNode38()
 }
 
-func (c *current) onNode32() (any, error) {
+func (c *current) onNode42() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode32() (any, error) {
+func (p *parser) callonNode42() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode32()
+	return p.cur.onNode42()
 }
 
-func (c *current) onNode34() (any, error) {
+func (c *current) onNode44() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode34() (any, error) {
+func (p *parser) callonNode44() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode34()
+	return p.cur.onNode44()
 }
 
-func (c *current) onNode36() (any, error) {
+func (c *current) onNode46() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode36() (any, error) {
+func (p *parser) callonNode46() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode36()
+	return p.cur.onNode46()
 }
 
 func (c *current) onNode48() (any, error) {
-	return c.text, nil
+	return buildOperatorNode(c.text, c.pos)
 
 }
 
@@ -1746,7 +1825,7 @@ func (p *parser) callonNode48() (any, error) {
 }
 
 func (c *current) onNode50() (any, error) {
-	return c.text, nil
+	return buildOperatorNode(c.text, c.pos)
 
 }
 
@@ -1757,7 +1836,7 @@ func (p *parser) callonNode50() (any, error) {
 }
 
 func (c *current) onNode52() (any, error) {
-	return c.text, nil
+	return buildOperatorNode(c.text, c.pos)
 
 }
 
@@ -1767,59 +1846,15 @@ func (p *parser) callonNode52() (any, error) {
 	return p.cur.onNode52()
 }
 
-func (c *current) onNode54() (any, error) {
-	return c.text, nil
-
-}
-
-func (p *parser) callonNode54() (any, error) {
-	stack := p.vstack[len(p.vstack)-1]
-	_ = stack
-	return p.cur.onNode54()
-}
-
-func (c *current) onNode46() (any, error) {
-	return c.text, nil
-
-}
-
-func (p *parser) callonNode46() (any, error) {
-	stack := p.vstack[len(p.vstack)-1]
-	_ = stack
-	return p.cur.onNode46()
-}
-
-func (c *current) onNode59() (any, error) {
-	return c.text, nil
-
-}
-
-func (p *parser) callonNode59() (any, error) {
-	stack := p.vstack[len(p.vstack)-1]
-	_ = stack
-	return p.cur.onNode59()
-}
-
-func (c *current) onNode61() (any, error) {
-	return c.text, nil
-
-}
-
-func (p *parser) callonNode61() (any, error) {
-	stack := p.vstack[len(p.vstack)-1]
-	_ = stack
-	return p.cur.onNode61()
-}
-
-func (c *current) onNode57() (any, error) {
+func (c *current) onNode64() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode57() (any, error) {
+func (p *parser) callonNode64() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode57()
+	return p.cur.onNode64()
 }
 
 func (c *current) onNode66() (any, error) {
@@ -1844,26 +1879,26 @@ func (p *parser) callonNode68() (any, error) {
 	return p.cur.onNode68()
 }
 
-func (c *current) onNode64() (any, error) {
+func (c *current) onNode70() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode64() (any, error) {
+func (p *parser) callonNode70() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode64()
+	return p.cur.onNode70()
 }
 
-func (c *current) onNode44() (any, error) {
+func (c *current) onNode62() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode44() (any, error) {
+func (p *parser) callonNode62() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode44()
+	return p.cur.onNode62()
 }
 
 func (c *current) onNode75() (any, error) {
@@ -1932,15 +1967,15 @@ func (p *parser) callonNode80() (any, error) {
 	return p.cur.onNode80()
 }
 
-func (c *current) onNode89() (any, error) {
+func (c *current) onNode60() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode89() (any, error) {
+func (p *parser) callonNode60() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode89()
+	return p.cur.onNode60()
 }
 
 func (c *current) onNode91() (any, error) {
@@ -1954,26 +1989,59 @@ func (p *parser) callonNode91() (any, error) {
 	return p.cur.onNode91()
 }
 
-func (c *current) onNode87() (any, error) {
+func (c *current) onNode93() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode87() (any, error) {
+func (p *parser) callonNode93() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode87()
+	return p.cur.onNode93()
+}
+
+func (c *current) onNode89() (any, error) {
+	return c.text, nil
+
+}
+
+func (p *parser) callonNode89() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode89()
+}
+
+func (c *current) onNode98() (any, error) {
+	return c.text, nil
+
+}
+
+func (p *parser) callonNode98() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode98()
+}
+
+func (c *current) onNode100() (any, error) {
+	return c.text, nil
+
+}
+
+func (p *parser) callonNode100() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode100()
 }
 
-func (c *current) onNode97() (any, error) {
+func (c *current) onNode96() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode97() (any, error) {
+func (p *parser) callonNode96() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode97()
+	return p.cur.onNode96()
 }
 
 func (c *current) onNode105() (any, error) {
@@ -2009,257 +2077,257 @@ func (p *parser) callonNode103() (any, error) {
 	return p.cur.onNode103()
 }
 
-func (c *current) onNode112() (any, error) {
+func (c *current) onNode113() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode112() (any, error) {
+func (p *parser) callonNode113() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode112()
+	return p.cur.onNode113()
 }
 
-func (c *current) onNode114() (any, error) {
+func (c *current) onNode121() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode114() (any, error) {
+func (p *parser) callonNode121() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode114()
+	return p.cur.onNode121()
 }
 
-func (c *current) onNode110() (any, error) {
+func (c *current) onNode123() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode110() (any, error) {
+func (p *parser) callonNode123() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode110()
+	return p.cur.onNode123()
 }
 
-func (c *current) onNode71() (any, error) {
+func (c *current) onNode119() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode71() (any, error) {
+func (p *parser) callonNode119() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode71()
+	return p.cur.onNode119()
 }
 
-func (c *current) onNode42() (any, error) {
+func (c *current) onNode128() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode42() (any, error) {
+func (p *parser) callonNode128() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode42()
+	return p.cur.onNode128()
 }
 
-func (c *current) onNode120() (any, error) {
+func (c *current) onNode130() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode120() (any, error) {
+func (p *parser) callonNode130() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode120()
+	return p.cur.onNode130()
 }
 
-func (c *current) onNode122() (any, error) {
+func (c *current) onNode126() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode122() (any, error) {
+func (p *parser) callonNode126() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode122()
+	return p.cur.onNode126()
 }
 
-func (c *current) onNode124() (any, error) {
+func (c *current) onNode87() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode124() (any, error) {
+func (p *parser) callonNode87() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode124()
+	return p.cur.onNode87()
 }
 
-func (c *current) onNode126() (any, error) {
+func (c *current) onNode58() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode126() (any, error) {
+func (p *parser) callonNode58() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode126()
+	return p.cur.onNode58()
 }
 
-func (c *current) onNode118() (any, error) {
+func (c *current) onNode136() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode118() (any, error) {
+func (p *parser) callonNode136() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode118()
+	return p.cur.onNode136()
 }
 
-func (c *current) onNode131() (any, error) {
+func (c *current) onNode138() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode131() (any, error) {
+func (p *parser) callonNode138() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode131()
+	return p.cur.onNode138()
 }
 
-func (c *current) onNode133() (any, error) {
+func (c *current) onNode140() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode133() (any, error) {
+func (p *parser) callonNode140() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode133()
+	return p.cur.onNode140()
 }
 
-func (c *current) onNode129() (any, error) {
+func (c *current) onNode142() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode129() (any, error) {
+func (p *parser) callonNode142() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode129()
+	return p.cur.onNode142()
 }
 
-func (c *current) onNode138() (any, error) {
+func (c *current) onNode134() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode138() (any, error) {
+func (p *parser) callonNode134() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode138()
+	return p.cur.onNode134()
 }
 
-func (c *current) onNode140() (any, error) {
+func (c *current) onNode147() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode140() (any, error) {
+func (p *parser) callonNode147() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode140()
+	return p.cur.onNode147()
 }
 
-func (c *current) onNode136() (any, error) {
+func (c *current) onNode149() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode136() (any, error) {
+func (p *parser) callonNode149() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode136()
+	return p.cur.onNode149()
 }
 
-func (c *current) onNode116() (any, error) {
+func (c *current) onNode145() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode116() (any, error) {
+func (p *parser) callonNode145() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode116()
+	return p.cur.onNode145()
 }
 
-func (c *current) onNode146() (any, error) {
+func (c *current) onNode154() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode146() (any, error) {
+func (p *parser) callonNode154() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode146()
+	return p.cur.onNode154()
 }
 
-func (c *current) onNode148() (any, error) {
+func (c *current) onNode156() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode148() (any, error) {
+func (p *parser) callonNode156() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode148()
+	return p.cur.onNode156()
 }
 
-func (c *current) onNode144() (any, error) {
+func (c *current) onNode152() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode144() (any, error) {
+func (p *parser) callonNode152() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode144()
+	return p.cur.onNode152()
 }
 
-func (c *current) onNode153() (any, error) {
+func (c *current) onNode132() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode153() (any, error) {
+func (p *parser) callonNode132() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode153()
+	return p.cur.onNode132()
 }
 
-func (c *current) onNode155() (any, error) {
+func (c *current) onNode162() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode155() (any, error) {
+func (p *parser) callonNode162() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode155()
+	return p.cur.onNode162()
 }
 
-func (c *current) onNode151() (any, error) {
+func (c *current) onNode164() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode151() (any, error) {
+func (p *parser) callonNode164() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode151()
+	return p.cur.onNode164()
 }
 
 func (c *current) onNode160() (any, error) {
@@ -2273,37 +2341,37 @@ func (p *parser) callonNode160() (any, error) {
 	return p.cur.onNode160()
 }
 
-func (c *current) onNode162() (any, error) {
+func (c *current) onNode169() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode162() (any, error) {
+func (p *parser) callonNode169() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode162()
+	return p.cur.onNode169()
 }
 
-func (c *current) onNode158() (any, error) {
+func (c *current) onNode171() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode158() (any, error) {
+func (p *parser) callonNode171() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode158()
+	return p.cur.onNode171()
 }
 
-func (c *current) onNode168() (any, error) {
+func (c *current) onNode167() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode168() (any, error) {
+func (p *parser) callonNode167() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode168()
+	return p.cur.onNode167()
 }
 
 func (c *current) onNode176() (any, error) {
@@ -2339,323 +2407,367 @@ func (p *parser) callonNode174() (any, error) {
 	return p.cur.onNode174()
 }
 
-func (c *current) onNode183() (any, error) {
+func (c *current) onNode184() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode183() (any, error) {
+func (p *parser) callonNode184() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode183()
+	return p.cur.onNode184()
 }
 
-func (c *current) onNode185() (any, error) {
+func (c *current) onNode192() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode185() (any, error) {
+func (p *parser) callonNode192() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode185()
+	return p.cur.onNode192()
 }
 
-func (c *current) onNode181() (any, error) {
+func (c *current) onNode194() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode181() (any, error) {
+func (p *parser) callonNode194() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode181()
+	return p.cur.onNode194()
 }
 
-func (c *current) onNode142() (any, error) {
+func (c *current) onNode190() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode142() (any, error) {
+func (p *parser) callonNode190() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode142()
+	return p.cur.onNode190()
+}
+
+func (c *current) onNode199() (any, error) {
+	return c.text, nil
+
+}
+
+func (p *parser) callonNode199() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode199()
 }
 
-func (c *current) onNode18(k, o, v any) (any, error) {
+func (c *current) onNode201() (any, error) {
+	return c.text, nil
+
+}
+
+func (p *parser) callonNode201() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode201()
+}
+
+func (c *current) onNode197() (any, error) {
+	return c.text, nil
+
+}
+
+func (p *parser) callonNode197() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode197()
+}
+
+func (c *current) onNode158() (any, error) {
+	return c.text, nil
+
+}
+
+func (p *parser) callonNode158() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode158()
+}
+
+func (c *current) onNode34(k, o, v any) (any, error) {
 	return buildDateTimeNode(k, o, v, c.text, c.pos)
 
 }
 
-func (p *parser) callonNode18() (any, error) {
+func (p *parser) callonNode34() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode18(stack["k"], stack["o"], stack["v"])
+	return p.cur.onNode34(stack["k"], stack["o"], stack["v"])
 }
 
-func (c *current) onNode193() (any, error) {
+func (c *current) onNode209() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode193() (any, error) {
+func (p *parser) callonNode209() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode193()
+	return p.cur.onNode209()
 }
 
-func (c *current) onNode196() (any, error) {
+func (c *current) onNode212() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode196() (any, error) {
+func (p *parser) callonNode212() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode196()
+	return p.cur.onNode212()
 }
 
-func (c *current) onNode198() (any, error) {
+func (c *current) onNode214() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode198() (any, error) {
+func (p *parser) callonNode214() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode198()
+	return p.cur.onNode214()
 }
 
-func (c *current) onNode213() (any, error) {
+func (c *current) onNode229() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode213() (any, error) {
+func (p *parser) callonNode229() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode213()
+	return p.cur.onNode229()
 }
 
-func (c *current) onNode189(k, v any) (any, error) {
+func (c *current) onNode205(k, v any) (any, error) {
 	return buildNaturalLanguageDateTimeNodes(k, v, c.text, c.pos)
 
 }
 
-func (p *parser) callonNode189() (any, error) {
+func (p *parser) callonNode205() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode189(stack["k"], stack["v"])
+	return p.cur.onNode205(stack["k"], stack["v"])
 }
 
-func (c *current) onNode221() (any, error) {
+func (c *current) onNode237() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode221() (any, error) {
+func (p *parser) callonNode237() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode221()
+	return p.cur.onNode237()
 }
 
-func (c *current) onNode224() (any, error) {
+func (c *current) onNode240() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode224() (any, error) {
+func (p *parser) callonNode240() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode224()
+	return p.cur.onNode240()
 }
 
-func (c *current) onNode226() (any, error) {
+func (c *current) onNode242() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode226() (any, error) {
+func (p *parser) callonNode242() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode226()
+	return p.cur.onNode242()
 }
 
-func (c *current) onNode230(v any) (any, error) {
+func (c *current) onNode246(v any) (any, error) {
 	return v, nil
 
 }
 
-func (p *parser) callonNode230() (any, error) {
+func (p *parser) callonNode246() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode230(stack["v"])
+	return p.cur.onNode246(stack["v"])
 }
 
-func (c *current) onNode217(k, v any) (any, error) {
+func (c *current) onNode233(k, v any) (any, error) {
 	return buildStringNode(k, v, c.text, c.pos)
 
 }
 
-func (p *parser) callonNode217() (any, error) {
+func (p *parser) callonNode233() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode217(stack["k"], stack["v"])
+	return p.cur.onNode233(stack["k"], stack["v"])
 }
 
-func (c *current) onNode239() (any, error) {
+func (c *current) onNode255() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode239() (any, error) {
+func (p *parser) callonNode255() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode239()
+	return p.cur.onNode255()
 }
 
-func (c *current) onNode243() (any, error) {
+func (c *current) onNode259() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode243() (any, error) {
+func (p *parser) callonNode259() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode243()
+	return p.cur.onNode259()
 }
 
-func (c *current) onNode247() (any, error) {
+func (c *current) onNode263() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode247() (any, error) {
+func (p *parser) callonNode263() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode247()
+	return p.cur.onNode263()
 }
 
-func (c *current) onNode252() (any, error) {
+func (c *current) onNode268() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode252() (any, error) {
+func (p *parser) callonNode268() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode252()
+	return p.cur.onNode268()
 }
 
-func (c *current) onNode254() (any, error) {
+func (c *current) onNode270() (any, error) {
 	return nil, nil
 
 }
 
-func (p *parser) callonNode254() (any, error) {
+func (p *parser) callonNode270() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode254()
+	return p.cur.onNode270()
 }
 
-func (c *current) onNode258(v any) (any, error) {
+func (c *current) onNode274(v any) (any, error) {
 	return v, nil
 
 }
 
-func (p *parser) callonNode258() (any, error) {
+func (p *parser) callonNode274() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode258(stack["v"])
+	return p.cur.onNode274(stack["v"])
 }
 
-func (c *current) onNode265(v any) (any, error) {
+func (c *current) onNode281(v any) (any, error) {
 	return nil, nil
 
 }
 
-func (p *parser) callonNode265() (any, error) {
+func (p *parser) callonNode281() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode265(stack["v"])
+	return p.cur.onNode281(stack["v"])
 }
 
-func (c *current) onNode269() (any, error) {
+func (c *current) onNode285() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode269() (any, error) {
+func (p *parser) callonNode285() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode269()
+	return p.cur.onNode285()
 }
 
-func (c *current) onNode249(v any) (any, error) {
+func (c *current) onNode265(v any) (any, error) {
 	return buildStringNode("", v, c.text, c.pos)
 
 }
 
-func (p *parser) callonNode249() (any, error) {
+func (p *parser) callonNode265() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode249(stack["v"])
+	return p.cur.onNode265(stack["v"])
 }
 
-func (c *current) onNode274() (any, error) {
+func (c *current) onNode290() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode274() (any, error) {
+func (p *parser) callonNode290() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode274()
+	return p.cur.onNode290()
 }
 
-func (c *current) onNode276() (any, error) {
+func (c *current) onNode292() (any, error) {
 	return nil, nil
 
 }
 
-func (p *parser) callonNode276() (any, error) {
+func (p *parser) callonNode292() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode276()
+	return p.cur.onNode292()
 }
 
-func (c *current) onNode282(v any) (any, error) {
+func (c *current) onNode298(v any) (any, error) {
 	return nil, nil
 
 }
 
-func (p *parser) callonNode282() (any, error) {
+func (p *parser) callonNode298() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode282(stack["v"])
+	return p.cur.onNode298(stack["v"])
 }
 
-func (c *current) onNode286() (any, error) {
+func (c *current) onNode302() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode286() (any, error) {
+func (p *parser) callonNode302() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode286()
+	return p.cur.onNode302()
 }
 
-func (c *current) onNode271(v any) (any, error) {
+func (c *current) onNode287(v any) (any, error) {
 	return buildStringNode("", v, c.text, c.pos)
 
 }
 
-func (p *parser) callonNode271() (any, error) {
+func (p *parser) callonNode287() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode271(stack["v"])
+	return p.cur.onNode287(stack["v"])
 }
 
 func (c *current) onGroupNode6() (any, error) {
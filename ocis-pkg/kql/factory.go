@@ -158,6 +158,23 @@ func buildBooleanNode(k, v interface{}, text []byte, pos position) (*ast.Boolean
 	}, nil
 }
 
+func buildSimilarNode(v interface{}, text []byte, pos position) (*ast.SimilarNode, error) {
+	b, err := base(text, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := toString(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.SimilarNode{
+		Base:  b,
+		Value: value,
+	}, nil
+}
+
 func buildOperatorNode(text []byte, pos position) (*ast.OperatorNode, error) {
 	b, err := base(text, pos)
 	if err != nil {
@@ -44,6 +44,30 @@ func (b Builder) Build(q string) (*ast.Ast, error) {
 	return f.(*ast.Ast), nil
 }
 
+// TimeRanges are the natural language keywords of the relative time ranges, e.g. mtime:"last week"
+var TimeRanges = []string{
+	"today",
+	"yesterday",
+	"this week",
+	"last week",
+	"last 7 days",
+	"this month",
+	"last month",
+	"last 30 days",
+	"this year",
+	"last year",
+}
+
+// TimeRange returns the start and the end of the relative time range of the given keyword
+func TimeRange(keyword string) (time.Time, time.Time, error) {
+	from, to, err := toTimeRange(keyword)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return *from, *to, nil
+}
+
 // timeNow mirrors time.Now by default, the only reason why this exists
 // is to monkey patch it from the tests. See PatchTimeNow
 var timeNow = time.Now
@@ -2,6 +2,7 @@ package kql_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/ast"
 	"github.com/owncloud/ocis/v2/ocis-pkg/kql"
@@ -52,3 +53,114 @@ func TestNewAST(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeRange(t *testing.T) {
+	kql.PatchTimeNow(func() time.Time {
+		return time.Date(2023, 9, 20, 10, 0, 0, 0, time.UTC)
+	})
+	defer kql.PatchTimeNow(time.Now)
+
+	assert := tAssert.New(t)
+
+	for _, keyword := range kql.TimeRanges {
+		from, to, err := kql.TimeRange(keyword)
+		assert.Nil(err, keyword)
+		assert.True(from.Before(to), keyword)
+	}
+
+	from, to, err := kql.TimeRange("last week")
+	assert.Nil(err)
+	assert.Equal(time.Date(2023, 9, 11, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(time.Date(2023, 9, 17, 23, 59, 59, 999999999, time.UTC), to)
+
+	_, _, err = kql.TimeRange("next week")
+	assert.NotNil(err)
+}
+
+func TestSimilar(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenQuery    string
+		expectedQuery string
+		expectedText  string
+		expectedError error
+	}{
+		{
+			name:         "only term",
+			givenQuery:   `similar:"rental agreement"`,
+			expectedText: "rental agreement",
+		},
+		{
+			name:         "single word",
+			givenQuery:   `similar:lease`,
+			expectedText: "lease",
+		},
+		{
+			name:          "beginning of the query",
+			givenQuery:    `similar:"rental agreement" AND mediatype:document`,
+			expectedQuery: "mediatype:document",
+			expectedText:  "rental agreement",
+		},
+		{
+			name:          "end of the query",
+			givenQuery:    `mediatype:document OR similar:"rental agreement"`,
+			expectedQuery: "mediatype:document",
+			expectedText:  "rental agreement",
+		},
+		{
+			name:          "middle of the query",
+			givenQuery:    `name:*.pdf AND similar:"rental agreement" AND mediatype:document`,
+			expectedQuery: "name:*.pdf AND mediatype:document",
+			expectedText:  "rental agreement",
+		},
+		{
+			name:          "implicit operators",
+			givenQuery:    `name:(*.pdf OR *.docx) similar:lease -tag:archived`,
+			expectedQuery: "name:(*.pdf OR *.docx) -tag:archived",
+			expectedText:  "lease",
+		},
+		{
+			name:          "no similarity search",
+			givenQuery:    `name:*file*`,
+			expectedQuery: "name:*file*",
+		},
+		{
+			name:          "similar as a value",
+			givenQuery:    `tag:similar`,
+			expectedQuery: "tag:similar",
+		},
+		{
+			name:          "negated",
+			givenQuery:    `name:*.pdf NOT similar:lease`,
+			expectedError: &query.InvalidSimilarNodeError{},
+		},
+		{
+			name:          "used twice",
+			givenQuery:    `similar:lease similar:rent`,
+			expectedError: &query.InvalidSimilarNodeError{},
+		},
+		{
+			name:          "within a group",
+			givenQuery:    `(name:*.pdf OR similar:lease)`,
+			expectedError: &query.InvalidSimilarNodeError{},
+		},
+	}
+
+	assert := tAssert.New(t)
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			q, text, err := kql.Similar(tt.givenQuery)
+			if tt.expectedError != nil {
+				assert.IsType(tt.expectedError, err)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tt.expectedQuery, q)
+			assert.Equal(tt.expectedText, text)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package kql
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/ast"
+)
+
+// Similar splits the query into the rest of the query and the text of its similarity search, e.g. similar:"rental agreement".
+// The operator joining the similarity search with the rest of the query is removed as well.
+func Similar(q string) (string, string, error) {
+	if strings.TrimSpace(q) == "" {
+		return q, "", nil
+	}
+
+	a, err := Builder{}.Build(q)
+	if err != nil {
+		return "", "", err
+	}
+
+	i := slices.IndexFunc(a.Nodes, func(n ast.Node) bool {
+		_, ok := n.(*ast.SimilarNode)
+		return ok
+	})
+	if i < 0 {
+		return q, "", nil
+	}
+
+	// the nodes are always joined by an operator, see connectNodes
+	from, to := i, i+1
+	switch {
+	case i > 0:
+		from--
+	case len(a.Nodes) > 1:
+		to++
+	}
+
+	// cut the nodes out of the query to keep the rest as it was written
+	offsets := sourceOffsets(q, a.Nodes)
+	start := offsets[i]
+	if offsets[from] >= 0 {
+		start = offsets[from]
+	}
+	end := len(q)
+	for _, offset := range offsets[to:] {
+		if offset >= 0 {
+			end = offset
+			break
+		}
+	}
+
+	return strings.TrimSpace(q[:start] + q[end:]), a.Nodes[i].(*ast.SimilarNode).Value, nil
+}
+
+// sourceOffsets returns the offset of each node in the query, operators added by connectNodes are not part of the query
+// and have the offset -1.
+func sourceOffsets(q string, nodes []ast.Node) []int {
+	offsets := make([]int, len(nodes))
+	cursor := 0
+	for i, n := range nodes {
+		if op, ok := n.(*ast.OperatorNode); ok && op.Location().Start.Column == 0 {
+			offsets[i] = -1
+			continue
+		}
+		source := strings.TrimSpace(*n.Location().Source)
+		offsets[i] = cursor + strings.Index(q[cursor:], source)
+		cursor = offsets[i] + len(source)
+	}
+	return offsets
+}
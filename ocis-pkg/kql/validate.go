@@ -13,6 +13,21 @@ func validateAst(a *ast.Ast) error {
 			return &query.StartsWithBinaryOperatorError{Node: node}
 		}
 	}
+
+	similar := false
+	for i, n := range a.Nodes {
+		node, ok := n.(*ast.SimilarNode)
+		if !ok {
+			continue
+		}
+		if similar {
+			return &query.InvalidSimilarNodeError{Node: node}
+		}
+		if op, ok := a.Nodes[max(i-1, 0)].(*ast.OperatorNode); ok && op.Value == BoolNOT {
+			return &query.InvalidSimilarNodeError{Node: node}
+		}
+		similar = true
+	}
 	return nil
 }
 
@@ -25,6 +40,12 @@ func validateGroupNode(n *ast.GroupNode) error {
 		}
 	}
 
+	for _, node := range n.Nodes {
+		if node, ok := node.(*ast.SimilarNode); ok {
+			return &query.InvalidSimilarNodeError{Node: node}
+		}
+	}
+
 	if n.Key != "" {
 		for _, node := range n.Nodes {
 			if ast.NodeKey(node) != "" {
@@ -0,0 +1,126 @@
+package kql
+
+import (
+	"fmt"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/ast"
+)
+
+// Emitter converts the nodes of a KQL ast into the queries of a search backend.
+// The walk is shared by the backends, only the queries are backend specific.
+type Emitter[T any] interface {
+	// String returns the query of a string node, group tells if the query matches a group of alternatives.
+	String(n *ast.StringNode) (q T, group bool)
+	// DateTime returns the query of a date time node, nodes without a valid operator are skipped.
+	DateTime(n *ast.DateTimeNode) (q T, ok bool)
+	// Boolean returns the query of a boolean node.
+	Boolean(n *ast.BooleanNode) T
+	// Not negates the query.
+	Not(q T) T
+	// Binary combines two queries with the AND or OR operator.
+	Binary(operator *ast.OperatorNode, left, right T, leftIsGroup bool) T
+}
+
+// Walk walks the KQL ast and combines the queries returned by the emitter into a single query.
+func Walk[T any](e Emitter[T], nodes []ast.Node) (T, error) {
+	q, _, err := walk(e, 0, nodes)
+	return q, err
+}
+
+func walk[T any](e Emitter[T], offset int, nodes []ast.Node) (T, int, error) {
+	var prev, next, zero T
+	var hasPrev, hasNext bool
+	var operator *ast.OperatorNode
+	var isGroup bool
+
+	add := func(q T) {
+		if !hasPrev {
+			prev, hasPrev = q, true
+		} else {
+			next, hasNext = q, true
+		}
+	}
+
+	for i := offset; i < len(nodes); i++ {
+		switch n := nodes[i].(type) {
+		case *ast.StringNode:
+			q, group := e.String(n)
+			if !hasPrev {
+				isGroup = group
+			}
+			add(q)
+		case *ast.DateTimeNode:
+			q, ok := e.DateTime(n)
+			if !ok {
+				continue
+			}
+			add(q)
+		case *ast.BooleanNode:
+			add(e.Boolean(n))
+		case *ast.GroupNode:
+			if n.Key != "" {
+				n = normalizeGroupingProperty(n)
+			}
+			q, _, err := walk(e, 0, n.Nodes)
+			if err != nil {
+				return zero, 0, err
+			}
+			if !hasPrev {
+				isGroup = true
+			}
+			add(q)
+		case *ast.OperatorNode:
+			if n.Value == BoolAND || n.Value == BoolOR {
+				operator = n
+			} else if n.Value == BoolNOT {
+				q, o, err := nextNode(e, i+1, nodes)
+				if err != nil {
+					return zero, 0, err
+				}
+				offset = o
+				// unary in the beginning or the right side of a binary operator
+				add(e.Not(q))
+			}
+		}
+		if hasPrev && hasNext && operator != nil {
+			prev = e.Binary(operator, prev, next, isGroup)
+			isGroup = false
+			operator = nil
+			hasNext = false
+		}
+		if i < offset {
+			i = offset
+		}
+	}
+	if !hasPrev {
+		return zero, 0, fmt.Errorf("can not compile the query")
+	}
+	return prev, offset, nil
+}
+
+func nextNode[T any](e Emitter[T], offset int, nodes []ast.Node) (T, int, error) {
+	if n, ok := nodes[offset].(*ast.GroupNode); ok {
+		gq, _, err := walk(e, 0, n.Nodes)
+		if err != nil {
+			var zero T
+			return zero, 0, err
+		}
+		return gq, offset + 1, nil
+	}
+	if n, ok := nodes[offset].(*ast.OperatorNode); ok {
+		if n.Value == BoolNOT {
+			return walk(e, offset, nodes)
+		}
+	}
+	one := nodes[:offset+1]
+	return walk(e, offset, one)
+}
+
+func normalizeGroupingProperty(group *ast.GroupNode) *ast.GroupNode {
+	for _, n := range group.Nodes {
+		if onode, ok := n.(*ast.StringNode); ok {
+			onode.Key = group.Key
+		}
+	}
+	return group
+}
@@ -0,0 +1,58 @@
+// Package kv provides a key value store with atomic updates, which allows the instances of a service
+// to share counters, sequences and locks without overwriting each others changes.
+package kv
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cs3org/reva/v2/pkg/store"
+)
+
+// ErrConflict is returned by Update if the value kept being changed concurrently
+var ErrConflict = errors.New("kv: the value was changed concurrently")
+
+// _maxRetries is the number of times an update is retried after a concurrent change
+const _maxRetries = 32
+
+// Store is a key value store with atomic updates
+type Store interface {
+	// Get returns the value of the key, it is nil if the key does not exist
+	Get(key string) ([]byte, error)
+	// Update atomically replaces the value of the key with the value returned by f and returns it.
+	// f is called with the current value, which is nil if the key does not exist, and is called again
+	// if the value was changed concurrently. A nil value deletes the key. The value is not changed if f
+	// returns an error, the error is returned as is.
+	Update(key string, f func(value []byte) ([]byte, error)) ([]byte, error)
+}
+
+// Options configure the store
+type Options struct {
+	// Type is the type of the store, only 'nats-js-kv' is shared by all instances
+	Type string
+	// Nodes are the addresses of the nats servers
+	Nodes []string
+	// Bucket is the name of the bucket holding the keys
+	Bucket string
+	// TTL is the time after which keys expire if they are not updated, 0 keeps them forever
+	TTL time.Duration
+	// DisablePersistence keeps the keys in memory only
+	DisablePersistence bool
+	// Username and Password authenticate against the nats servers
+	Username string
+	Password string
+	// EnableTLS, TLSInsecure and TLSRootCACertificate configure the TLS connection to the nats servers,
+	// they are usually the same as for the events broker
+	EnableTLS            bool
+	TLSInsecure          bool
+	TLSRootCACertificate string
+}
+
+// New returns a store of the configured type. All types except 'nats-js-kv' fall back to a memory store,
+// which is only consistent within the instance.
+func New(o Options) Store {
+	if o.Type == store.TypeNatsJSKV {
+		return newNatsStore(o)
+	}
+	return newMemoryStore(o.TTL)
+}
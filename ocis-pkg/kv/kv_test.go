@@ -0,0 +1,242 @@
+package kv
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/stretchr/testify/require"
+)
+
+func stores(t *testing.T) map[string]func() Store {
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	require.NoError(t, err)
+	srv.Start()
+	t.Cleanup(srv.Shutdown)
+	require.True(t, srv.ReadyForConnections(5*time.Second))
+
+	return map[string]func() Store{
+		"memory": func() Store {
+			return New(Options{Type: "memory"})
+		},
+		// every call returns a new store on the same bucket like the instances of a service
+		"nats-js-kv": func() Store {
+			return New(Options{Type: "nats-js-kv", Nodes: []string{srv.ClientURL()}, Bucket: "test"})
+		},
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	for name, newStore := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			s := newStore()
+			key := "counter/" + name + ":with+special chars"
+
+			value, err := s.Get(key)
+			require.NoError(t, err)
+			require.Nil(t, value)
+
+			value, err = s.Update(key, func(value []byte) ([]byte, error) {
+				require.Nil(t, value)
+				return []byte("1"), nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, []byte("1"), value)
+
+			value, err = s.Get(key)
+			require.NoError(t, err)
+			require.Equal(t, []byte("1"), value)
+
+			_, err = s.Update(key, func([]byte) ([]byte, error) { return nil, nil })
+			require.NoError(t, err)
+			value, err = s.Get(key)
+			require.NoError(t, err)
+			require.Nil(t, value)
+
+			// a deleted key can be created again
+			_, err = s.Update(key, func([]byte) ([]byte, error) { return []byte("2"), nil })
+			require.NoError(t, err)
+			value, err = s.Get(key)
+			require.NoError(t, err)
+			require.Equal(t, []byte("2"), value)
+		})
+	}
+}
+
+func TestUpdate_Concurrent(t *testing.T) {
+	for name, newStore := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			shared := newStore()
+			key := "concurrent/" + name
+
+			wg := sync.WaitGroup{}
+			for i := 0; i < 4; i++ {
+				s := shared
+				if name == "nats-js-kv" {
+					s = newStore()
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 5; j++ {
+						_, err := s.Update(key, func(value []byte) ([]byte, error) {
+							n, _ := strconv.Atoi(string(value))
+							return []byte(strconv.Itoa(n + 1)), nil
+						})
+						require.NoError(t, err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			value, err := shared.Get(key)
+			require.NoError(t, err)
+			require.Equal(t, "20", string(value))
+		})
+	}
+}
+
+func TestTryLock(t *testing.T) {
+	for name, newStore := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			a, b := newStore(), newStore()
+			if name == "memory" {
+				b = a
+			}
+
+			unlock, ok, err := TryLock(a, "job/"+name, time.Minute)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			_, ok, err = TryLock(b, "job/"+name, time.Minute)
+			require.NoError(t, err)
+			require.False(t, ok)
+
+			unlock()
+			unlock, ok, err = TryLock(b, "job/"+name, time.Minute)
+			require.NoError(t, err)
+			require.True(t, ok)
+			unlock()
+		})
+	}
+}
+
+func TestTryLock_Expired(t *testing.T) {
+	s := New(Options{Type: "memory"})
+
+	unlockExpired, ok, err := TryLock(s, "job", time.Millisecond)
+	require.NoError(t, err)
+	require.True(t, ok)
+	time.Sleep(2 * time.Millisecond)
+
+	unlock, ok, err := TryLock(s, "job", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// the expired lock must not release the lock taken over
+	unlockExpired()
+	_, ok, err = TryLock(s, "job", time.Minute)
+	require.NoError(t, err)
+	require.False(t, ok)
+	unlock()
+}
+
+func TestLock(t *testing.T) {
+	s := New(Options{Type: "memory"})
+
+	unlock, err := Lock(context.Background(), s, "job", time.Minute)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = Lock(ctx, s, "job", time.Minute)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		unlock()
+	}()
+	unlock, err = Lock(context.Background(), s, "job", time.Minute)
+	require.NoError(t, err)
+	unlock()
+}
+
+func TestMemoryStore_TTL(t *testing.T) {
+	s := newMemoryStore(time.Minute)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	_, err := s.Update("key", func([]byte) ([]byte, error) { return []byte("value"), nil })
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Minute)
+	value, err := s.Get("key")
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestKeepLock(t *testing.T) {
+	s := New(Options{Type: "memory"})
+
+	unlock, err := KeepLock(context.Background(), s, "job", 30*time.Millisecond)
+	require.NoError(t, err)
+
+	// the lock is refreshed beyond its ttl while it is held
+	time.Sleep(100 * time.Millisecond)
+	_, ok, err := TryLock(s, "job", time.Minute)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	unlock()
+	unlock, ok, err = TryLock(s, "job", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	unlock()
+}
+
+func TestLead(t *testing.T) {
+	s := New(Options{Type: "memory"})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var running, maxRunning int
+	var mu sync.Mutex
+	wg := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Lead(ctx, s, "job", 30*time.Millisecond, func(ctx context.Context) {
+				mu.Lock()
+				running++
+				maxRunning = max(maxRunning, running)
+				mu.Unlock()
+				<-ctx.Done()
+				mu.Lock()
+				running--
+				mu.Unlock()
+			})
+		}()
+	}
+
+	// the leader keeps the lock beyond its ttl
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	wg.Wait()
+	require.Equal(t, 1, maxRunning)
+
+	// the lock is released when the leader stops
+	unlock, ok, err := TryLock(s, "job", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	unlock()
+}
@@ -0,0 +1,183 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// _lockPollInterval is the interval Lock tries to acquire a lock in
+const _lockPollInterval = 50 * time.Millisecond
+
+// errLocked is returned by the update of a lock held by someone else
+var errLocked = errors.New("kv: locked")
+
+// lock is the value of a lock key
+type lock struct {
+	Owner   string    `json:"owner"`
+	Expires time.Time `json:"expires"`
+}
+
+// TryLock acquires the lock with the given name unless it is held by someone else, which is reported by ok.
+// The lock is held until the returned function is called or the ttl expired, whatever happens first.
+func TryLock(s Store, name string, ttl time.Duration) (unlock func(), ok bool, err error) {
+	owner := uuid.New().String()
+	ok, err = tryLock(s, name, ttl, owner)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+	return func() { release(s, name, owner) }, true, nil
+}
+
+func tryLock(s Store, name string, ttl time.Duration, owner string) (bool, error) {
+	_, err := s.Update(lockKey(name), func(value []byte) ([]byte, error) {
+		if value != nil {
+			var l lock
+			if err := json.Unmarshal(value, &l); err == nil && l.Owner != owner && time.Now().Before(l.Expires) {
+				return nil, errLocked
+			}
+		}
+		return json.Marshal(lock{Owner: owner, Expires: time.Now().Add(ttl)})
+	})
+	switch {
+	case errors.Is(err, errLocked):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
+
+func release(s Store, name, owner string) {
+	_, _ = s.Update(lockKey(name), func(value []byte) ([]byte, error) {
+		var l lock
+		if err := json.Unmarshal(value, &l); err != nil || l.Owner != owner {
+			// expired and taken over by someone else
+			return nil, errLocked
+		}
+		return nil, nil
+	})
+}
+
+// Lock waits until the lock with the given name is acquired or the context is done.
+// The lock is held until the returned function is called or the ttl expired, whatever happens first.
+func Lock(ctx context.Context, s Store, name string, ttl time.Duration) (unlock func(), err error) {
+	for {
+		unlock, ok, err := TryLock(s, name, ttl)
+		switch {
+		case err != nil:
+			return nil, err
+		case ok:
+			return unlock, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(_lockPollInterval):
+		}
+	}
+}
+
+// KeepLock waits until the lock with the given name is acquired or the context is done, like Lock.
+// The lock is refreshed until the returned function is called, the ttl only releases it if the holder died.
+func KeepLock(ctx context.Context, s Store, name string, ttl time.Duration) (unlock func(), err error) {
+	owner := uuid.New().String()
+	for {
+		ok, err := tryLock(s, name, ttl, owner)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(_lockPollInterval):
+		}
+	}
+
+	stop, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		t := time.NewTicker(ttl / 3)
+		defer t.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-t.C:
+				// errors are ignored, the lock is refreshed again before it expires
+				if ok, err := tryLock(s, name, ttl, owner); !ok && err == nil {
+					// the lock expired and was taken over, there is nothing left to refresh
+					return
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(stop)
+			<-stopped
+			release(s, name, owner)
+		})
+	}, nil
+}
+
+// Lead runs f on a single instance at a time until the context is done. The instances compete for the lock
+// with the given name, the holder runs f with a context which is cancelled when the lock is lost. The lock is
+// refreshed while f runs, another instance takes over after the ttl if the holder died.
+func Lead(ctx context.Context, s Store, name string, ttl time.Duration, f func(ctx context.Context)) {
+	owner := uuid.New().String()
+	t := time.NewTicker(ttl / 3)
+	defer t.Stop()
+	for {
+		if ok, err := tryLock(s, name, ttl, owner); ok && err == nil {
+			lead(ctx, s, name, ttl, owner, t.C, f)
+			release(s, name, owner)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// lead runs f while the lock is refreshed on every tick, f is cancelled when the lock was taken over.
+func lead(ctx context.Context, s Store, name string, ttl time.Duration, owner string, tick <-chan time.Time, f func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f(ctx)
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-tick:
+			// errors are ignored, the lock is refreshed again before it expires
+			if ok, err := tryLock(s, name, ttl, owner); !ok && err == nil {
+				cancel()
+				<-done
+				return
+			}
+		}
+	}
+}
+
+func lockKey(name string) string {
+	return "lock/" + name
+}
@@ -0,0 +1,79 @@
+package kv
+
+import (
+	"sync"
+	"time"
+)
+
+// memoryStore keeps the keys in the memory of the instance
+type memoryStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	purged  time.Time
+}
+
+type memoryEntry struct {
+	value   []byte
+	updated time.Time
+}
+
+func newMemoryStore(ttl time.Duration) *memoryStore {
+	return &memoryStore{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+// Get implements the Store interface
+func (s *memoryStore) Get(key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.get(key), nil
+}
+
+// Update implements the Store interface
+func (s *memoryStore) Update(key string, f func([]byte) ([]byte, error)) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, err := f(s.get(key))
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		delete(s.entries, key)
+		return nil, nil
+	}
+
+	s.entries[key] = memoryEntry{value: value, updated: s.now()}
+	s.purge()
+	return value, nil
+}
+
+// purge removes the expired keys once per ttl
+func (s *memoryStore) purge() {
+	if s.ttl <= 0 || s.now().Sub(s.purged) < s.ttl {
+		return
+	}
+	s.purged = s.now()
+	for key := range s.entries {
+		s.get(key)
+	}
+}
+
+func (s *memoryStore) get(key string) []byte {
+	e, ok := s.entries[key]
+	if !ok {
+		return nil
+	}
+	if s.ttl > 0 && s.now().Sub(e.updated) > s.ttl {
+		delete(s.entries, key)
+		return nil
+	}
+	return e.value
+}
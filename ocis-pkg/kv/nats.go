@@ -0,0 +1,196 @@
+package kv
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/crypto"
+)
+
+// natsStore keeps the keys in a nats key value bucket, concurrent updates are detected by the revision of the key
+type natsStore struct {
+	o Options
+
+	mu     sync.Mutex
+	bucket nats.KeyValue
+}
+
+func newNatsStore(o Options) *natsStore {
+	return &natsStore{o: o}
+}
+
+// Get implements the Store interface
+func (s *natsStore) Get(key string) ([]byte, error) {
+	b, err := s.getBucket()
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := b.Get(encodeKey(key))
+	switch {
+	case errors.Is(err, nats.ErrKeyNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return e.Value(), nil
+}
+
+// Update implements the Store interface
+func (s *natsStore) Update(key string, f func([]byte) ([]byte, error)) ([]byte, error) {
+	b, err := s.getBucket()
+	if err != nil {
+		return nil, err
+	}
+
+	k := encodeKey(key)
+	for i := 0; i < _maxRetries; i++ {
+		var (
+			current  []byte
+			revision uint64
+		)
+		e, err := b.Get(k)
+		switch {
+		case errors.Is(err, nats.ErrKeyNotFound):
+		case err != nil:
+			return nil, err
+		default:
+			current, revision = e.Value(), e.Revision()
+		}
+
+		value, err := f(current)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case value == nil && revision == 0:
+			return nil, nil
+		case value == nil:
+			err = b.Delete(k, nats.LastRevision(revision))
+		case revision == 0:
+			_, err = b.Create(k, value)
+		default:
+			_, err = b.Update(k, value, revision)
+		}
+		if conflict(err) {
+			continue
+		}
+		return value, err
+	}
+	return nil, ErrConflict
+}
+
+// getBucket connects to nats and creates the bucket on first use, the nats servers might not be
+// available yet when the service starts
+func (s *natsStore) getBucket() (nats.KeyValue, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.bucket != nil {
+		return s.bucket, nil
+	}
+
+	nc, err := connect(s.o)
+	if err != nil {
+		return nil, err
+	}
+	js, err := nc.JetStream()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := js.KeyValue(s.o.Bucket)
+	if errors.Is(err, nats.ErrBucketNotFound) {
+		storage := nats.FileStorage
+		if s.o.DisablePersistence {
+			storage = nats.MemoryStorage
+		}
+		b, err = js.CreateKeyValue(&nats.KeyValueConfig{
+			Bucket:  s.o.Bucket,
+			TTL:     s.o.TTL,
+			Storage: storage,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.bucket = b
+	return b, nil
+}
+
+// connKey identifies the connections which can be shared
+type connKey struct {
+	nodes, username, password string
+	enableTLS, tlsInsecure    bool
+	tlsRootCACertificate      string
+}
+
+var (
+	_connsMu sync.Mutex
+	_conns   = make(map[connKey]*nats.Conn)
+)
+
+// connect returns a connection to the nats servers, it is shared by all stores of the process using the same servers
+func connect(o Options) (*nats.Conn, error) {
+	key := connKey{
+		nodes:                strings.Join(o.Nodes, ","),
+		username:             o.Username,
+		password:             o.Password,
+		enableTLS:            o.EnableTLS,
+		tlsInsecure:          o.TLSInsecure,
+		tlsRootCACertificate: o.TLSRootCACertificate,
+	}
+	_connsMu.Lock()
+	defer _connsMu.Unlock()
+
+	if nc, ok := _conns[key]; ok && !nc.IsClosed() {
+		return nc, nil
+	}
+
+	opts := []nats.Option{nats.UserInfo(o.Username, o.Password)}
+	if o.EnableTLS {
+		tlsConf := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: o.TLSInsecure, //nolint:gosec
+		}
+		if o.TLSRootCACertificate != "" {
+			f, err := os.Open(o.TLSRootCACertificate)
+			if err != nil {
+				return nil, err
+			}
+			defer f.Close()
+
+			if tlsConf.RootCAs, err = crypto.NewCertPoolFromPEM(f); err != nil {
+				return nil, err
+			}
+			tlsConf.InsecureSkipVerify = false
+		}
+		opts = append(opts, nats.Secure(tlsConf))
+	}
+
+	nc, err := nats.Connect(strings.Join(o.Nodes, ","), opts...)
+	if err != nil {
+		return nil, err
+	}
+	_conns[key] = nc
+	return nc, nil
+}
+
+// conflict tells if the error was caused by a concurrent change of the key
+func conflict(err error) bool {
+	var apiErr *nats.APIError
+	return errors.Is(err, nats.ErrKeyExists) || (errors.As(err, &apiErr) && apiErr.ErrorCode == nats.JSErrCodeStreamWrongLastSequence)
+}
+
+// encodeKey encodes the key as nats only allows a limited set of characters
+func encodeKey(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
@@ -0,0 +1,447 @@
+package quarantine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	"github.com/cs3org/reva/v2/pkg/rhttp"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// _metadataFile holds the Item next to the quarantined file
+	_metadataFile = "quarantine.json"
+
+	_transferHeader = "X-Reva-Transfer"
+)
+
+// DefaultSpaceID is the id of the project space which is created for the quarantine when no space is configured
+const DefaultSpaceID = "quarantine-0e1c6f8a-5d2b-4b7e-9a43-2f1d8c7b6a50"
+
+var (
+	// ErrNotFound is returned when a quarantine item does not exist
+	ErrNotFound = errors.New("quarantine item not found")
+	// ErrInvalidID is returned when the id of a quarantine item contains other than the allowed characters
+	ErrInvalidID = errors.New("invalid quarantine item id")
+
+	// the ids are used as folder names in the quarantine space and must not contain path elements
+	_validID = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+)
+
+// ValidID checks if the given string is a valid quarantine item id.
+// Only letters, digits, dashes and underscores are allowed.
+func ValidID(id string) bool {
+	return _validID.MatchString(id)
+}
+
+// Item holds the metadata of a quarantined file
+type Item struct {
+	ID         string               `json:"id"`
+	Filename   string               `json:"filename"`
+	Size       uint64               `json:"size"`
+	ResourceID *provider.ResourceId `json:"resourceId"` // the resource the upload was targeted to
+	Location   *provider.ResourceId `json:"location"`   // the folder the file was uploaded to
+	Uploader   *user.UserId         `json:"uploader"`
+	Virus      string               `json:"virus"`
+	Scanner    string               `json:"scanner"`
+	ScanDate   time.Time            `json:"scanDate"`
+}
+
+// New returns a Quarantine keeping its items in the space with the given id.
+// Each item is a folder in the root of the space named by the item id,
+// containing the infected file and its metadata.
+// If no space id is given, a project space with the DefaultSpaceID is created when the first item is added.
+func New(spaceID string, gatewaySelector pool.Selectable[gateway.GatewayAPIClient], serviceAccountID string, serviceAccountSecret string) (*Quarantine, error) {
+	create := spaceID == ""
+	if create {
+		spaceID = DefaultSpaceID
+	}
+
+	rid, err := storagespace.ParseID(spaceID)
+	if err != nil {
+		return nil, err
+	}
+	if rid.GetSpaceId() == "" {
+		return nil, fmt.Errorf("invalid quarantine space id '%s'", spaceID)
+	}
+	if rid.GetOpaqueId() == "" {
+		rid.OpaqueId = rid.GetSpaceId()
+	}
+
+	return &Quarantine{
+		space:                &rid,
+		create:               create,
+		gatewaySelector:      gatewaySelector,
+		serviceAccountID:     serviceAccountID,
+		serviceAccountSecret: serviceAccountSecret,
+		client:               rhttp.GetHTTPClient(rhttp.Insecure(true)),
+	}, nil
+}
+
+// Quarantine manages the infected files moved to the quarantine space
+type Quarantine struct {
+	space                *provider.ResourceId
+	create               bool
+	gatewaySelector      pool.Selectable[gateway.GatewayAPIClient]
+	serviceAccountID     string
+	serviceAccountSecret string
+	client               *http.Client
+
+	// set once the space is known to exist
+	mu      sync.Mutex
+	created bool
+}
+
+// Contains checks if the given resource is located in the quarantine space
+func (q *Quarantine) Contains(rid *provider.ResourceId) bool {
+	return rid.GetSpaceId() == q.space.GetSpaceId()
+}
+
+// Add moves the content of an infected upload to the quarantine.
+// If the location of the item is unknown, it is derived from its resource id.
+func (q *Quarantine) Add(ctx context.Context, item Item, content io.Reader) error {
+	if !ValidID(item.ID) {
+		return ErrInvalidID
+	}
+
+	gwc, ctx, err := q.serviceContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := q.ensureSpace(ctx, gwc); err != nil {
+		return err
+	}
+
+	if item.Location == nil && item.ResourceID != nil {
+		res, err := gwc.Stat(ctx, &provider.StatRequest{Ref: &provider.Reference{ResourceId: item.ResourceID}})
+		if err != nil {
+			return err
+		}
+		if res.GetStatus().GetCode() != rpc.Code_CODE_OK {
+			return fmt.Errorf("could not stat resource: %s", res.GetStatus().GetMessage())
+		}
+		item.Location = res.GetInfo().GetParentId()
+	}
+
+	res, err := gwc.CreateContainer(ctx, &provider.CreateContainerRequest{Ref: q.ref(item.ID)})
+	if err != nil {
+		return err
+	}
+	if res.GetStatus().GetCode() != rpc.Code_CODE_OK {
+		return fmt.Errorf("could not create quarantine folder: %s", res.GetStatus().GetMessage())
+	}
+
+	if err := q.upload(ctx, gwc, q.ref(item.ID, item.Filename), content, item.Size); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	return q.upload(ctx, gwc, q.ref(item.ID, _metadataFile), bytes.NewReader(b), uint64(len(b)))
+}
+
+// List returns all items of the quarantine
+func (q *Quarantine) List(ctx context.Context) ([]Item, error) {
+	gwc, ctx, err := q.serviceContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := gwc.ListContainer(ctx, &provider.ListContainerRequest{Ref: q.ref()})
+	if err != nil {
+		return nil, err
+	}
+	switch res.GetStatus().GetCode() {
+	case rpc.Code_CODE_OK:
+	case rpc.Code_CODE_NOT_FOUND:
+		// the space is created when the first item is added
+		return []Item{}, nil
+	default:
+		return nil, fmt.Errorf("could not list quarantine space: %s", res.GetStatus().GetMessage())
+	}
+
+	items := make([]Item, 0, len(res.GetInfos()))
+	for _, info := range res.GetInfos() {
+		if info.GetType() != provider.ResourceType_RESOURCE_TYPE_CONTAINER {
+			continue
+		}
+
+		item, err := q.get(ctx, gwc, path.Base(info.GetPath()))
+		switch {
+		case errors.Is(err, ErrNotFound), errors.Is(err, ErrInvalidID):
+			// folders without metadata are no quarantine items
+			continue
+		case err != nil:
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// Get returns the quarantine item with the given id
+func (q *Quarantine) Get(ctx context.Context, id string) (Item, error) {
+	gwc, ctx, err := q.serviceContext(ctx)
+	if err != nil {
+		return Item{}, err
+	}
+	return q.get(ctx, gwc, id)
+}
+
+// Release uploads the quarantined file to its original location and removes it from the quarantine.
+// As this is a new upload, the file is scanned again and only delivered if it is clean now.
+func (q *Quarantine) Release(ctx context.Context, id string) error {
+	gwc, ctx, err := q.serviceContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	item, err := q.get(ctx, gwc, id)
+	if err != nil {
+		return err
+	}
+	if item.Location == nil {
+		return fmt.Errorf("original location of quarantine item '%s' is unknown", id)
+	}
+
+	content, err := q.download(ctx, gwc, q.ref(item.ID, item.Filename))
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	ref := &provider.Reference{ResourceId: item.Location, Path: utils.MakeRelativePath(item.Filename)}
+	if err := q.upload(ctx, gwc, ref, content, item.Size); err != nil {
+		return err
+	}
+
+	return q.purge(ctx, gwc, id)
+}
+
+// Purge deletes the quarantine item with the given id, it does not end up in the trash bin
+func (q *Quarantine) Purge(ctx context.Context, id string) error {
+	gwc, ctx, err := q.serviceContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := q.get(ctx, gwc, id); err != nil {
+		return err
+	}
+	return q.purge(ctx, gwc, id)
+}
+
+func (q *Quarantine) purge(ctx context.Context, gwc gateway.GatewayAPIClient, id string) error {
+	res, err := gwc.Delete(ctx, &provider.DeleteRequest{Ref: q.ref(id)})
+	if err != nil {
+		return err
+	}
+	if res.GetStatus().GetCode() != rpc.Code_CODE_OK {
+		return fmt.Errorf("could not delete quarantine item: %s", res.GetStatus().GetMessage())
+	}
+
+	lres, err := gwc.ListRecycle(ctx, &provider.ListRecycleRequest{Ref: q.ref()})
+	if err != nil {
+		return err
+	}
+	if lres.GetStatus().GetCode() != rpc.Code_CODE_OK {
+		return fmt.Errorf("could not list quarantine trash bin: %s", lres.GetStatus().GetMessage())
+	}
+
+	for _, ri := range lres.GetRecycleItems() {
+		if path.Clean("/"+ri.GetRef().GetPath()) != "/"+id {
+			continue
+		}
+
+		pres, err := gwc.PurgeRecycle(ctx, &provider.PurgeRecycleRequest{Ref: q.ref(), Key: ri.GetKey()})
+		if err != nil {
+			return err
+		}
+		if pres.GetStatus().GetCode() != rpc.Code_CODE_OK {
+			return fmt.Errorf("could not purge quarantine item: %s", pres.GetStatus().GetMessage())
+		}
+	}
+
+	return nil
+}
+
+func (q *Quarantine) get(ctx context.Context, gwc gateway.GatewayAPIClient, id string) (Item, error) {
+	var item Item
+	if !ValidID(id) {
+		return item, ErrInvalidID
+	}
+
+	rc, err := q.download(ctx, gwc, q.ref(id, _metadataFile))
+	if err != nil {
+		return item, err
+	}
+	defer rc.Close()
+
+	if err := json.NewDecoder(rc).Decode(&item); err != nil {
+		return item, err
+	}
+	return item, nil
+}
+
+// ensureSpace creates the quarantine space if it was not configured
+func (q *Quarantine) ensureSpace(ctx context.Context, gwc gateway.GatewayAPIClient) error {
+	if !q.create {
+		return nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.created {
+		return nil
+	}
+
+	opaque := utils.AppendPlainToOpaque(nil, "spaceid", q.space.GetSpaceId())
+	opaque = utils.AppendPlainToOpaque(opaque, "spaceAlias", "project/quarantine")
+	opaque = utils.AppendPlainToOpaque(opaque, "description", "Infected files moved to the quarantine by the antivirus service")
+	res, err := gwc.CreateStorageSpace(ctx, &provider.CreateStorageSpaceRequest{
+		Type:   "project",
+		Name:   "Quarantine",
+		Opaque: opaque,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch res.GetStatus().GetCode() {
+	case rpc.Code_CODE_OK, rpc.Code_CODE_ALREADY_EXISTS:
+		// the fixed space id makes sure that concurrent instances do not create multiple spaces
+		q.created = true
+		return nil
+	default:
+		return fmt.Errorf("could not create quarantine space: %s", res.GetStatus().GetMessage())
+	}
+}
+
+// ref returns a reference to the given path in the quarantine space
+func (q *Quarantine) ref(elem ...string) *provider.Reference {
+	return &provider.Reference{ResourceId: q.space, Path: utils.MakeRelativePath(path.Join(elem...))}
+}
+
+func (q *Quarantine) serviceContext(ctx context.Context) (gateway.GatewayAPIClient, context.Context, error) {
+	gwc, err := q.gatewaySelector.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token, err := utils.GetServiceUserToken(ctx, gwc, q.serviceAccountID, q.serviceAccountSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the token is needed for the grpc calls as well as for the data transfers
+	ctx = metadata.AppendToOutgoingContext(ctx, revactx.TokenHeader, token)
+	return gwc, revactx.ContextSetToken(ctx, token), nil
+}
+
+func (q *Quarantine) upload(ctx context.Context, gwc gateway.GatewayAPIClient, ref *provider.Reference, content io.Reader, size uint64) error {
+	res, err := gwc.InitiateFileUpload(ctx, &provider.InitiateFileUploadRequest{
+		Ref:    ref,
+		Opaque: utils.AppendPlainToOpaque(nil, "Upload-Length", strconv.FormatUint(size, 10)),
+	})
+	if err != nil {
+		return err
+	}
+	if res.GetStatus().GetCode() != rpc.Code_CODE_OK {
+		return fmt.Errorf("could not initiate upload: %s", res.GetStatus().GetMessage())
+	}
+
+	var ep, token string
+	for _, p := range res.GetProtocols() {
+		if p.GetProtocol() == "simple" {
+			ep, token = p.GetUploadEndpoint(), p.GetToken()
+		}
+	}
+
+	req, err := rhttp.NewRequest(ctx, http.MethodPut, ep, content)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = int64(size)
+	req.Header.Set(_transferHeader, token)
+
+	hres, err := q.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer hres.Body.Close()
+
+	if hres.StatusCode != http.StatusOK && hres.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected status code from upload %v", hres.StatusCode)
+	}
+	return nil
+}
+
+func (q *Quarantine) download(ctx context.Context, gwc gateway.GatewayAPIClient, ref *provider.Reference) (io.ReadCloser, error) {
+	res, err := gwc.InitiateFileDownload(ctx, &provider.InitiateFileDownloadRequest{Ref: ref})
+	if err != nil {
+		return nil, err
+	}
+
+	switch res.GetStatus().GetCode() {
+	case rpc.Code_CODE_OK:
+	case rpc.Code_CODE_NOT_FOUND:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("could not initiate download: %s", res.GetStatus().GetMessage())
+	}
+
+	var ep, token string
+	for _, p := range res.GetProtocols() {
+		if p.GetProtocol() == "spaces" {
+			ep, token = p.GetDownloadEndpoint(), p.GetToken()
+			break
+		}
+	}
+	if (ep == "" || token == "") && len(res.GetProtocols()) > 0 {
+		ep, token = res.GetProtocols()[0].GetDownloadEndpoint(), res.GetProtocols()[0].GetToken()
+	}
+
+	req, err := rhttp.NewRequest(ctx, http.MethodGet, ep, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(_transferHeader, token)
+
+	hres, err := q.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch hres.StatusCode {
+	case http.StatusOK:
+		return hres.Body, nil
+	case http.StatusNotFound:
+		hres.Body.Close()
+		return nil, ErrNotFound
+	default:
+		hres.Body.Close()
+		return nil, fmt.Errorf("unexpected status code from download %v", hres.StatusCode)
+	}
+}
@@ -0,0 +1,237 @@
+package quarantine_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	cs3mocks "github.com/cs3org/reva/v2/tests/cs3mocks/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/quarantine"
+)
+
+// storage is a minimal in memory storage holding files by their space and path
+type storage struct {
+	sync.Mutex
+	spaces []string
+	files  map[string]string
+	dirs  map[string]bool
+	trash map[string]string
+}
+
+func key(rid *provider.ResourceId, p string) string {
+	return rid.GetSpaceId() + "!" + rid.GetOpaqueId() + path.Clean("/"+p)
+}
+
+func newGateway(t *testing.T, st *storage) pool.Selectable[gateway.GatewayAPIClient] {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		st.Lock()
+		defer st.Unlock()
+
+		k := r.Header.Get("X-Reva-Transfer")
+		switch r.Method {
+		case http.MethodPut:
+			b, _ := io.ReadAll(r.Body)
+			st.files[k] = string(b)
+		case http.MethodGet:
+			c, ok := st.files[k]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = io.WriteString(w, c)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	gwc := &cs3mocks.GatewayAPIClient{}
+	ok := &rpc.Status{Code: rpc.Code_CODE_OK}
+	gwc.On("Authenticate", mock.Anything, mock.Anything).Return(&gateway.AuthenticateResponse{Status: ok, Token: "token"}, nil)
+	gwc.On("Stat", mock.Anything, mock.Anything).Return(&provider.StatResponse{Status: ok, Info: &provider.ResourceInfo{
+		ParentId: &provider.ResourceId{StorageId: "storage", SpaceId: "personal", OpaqueId: "folder"},
+	}}, nil)
+	gwc.On("CreateContainer", mock.Anything, mock.Anything).Return(func(_ context.Context, req *provider.CreateContainerRequest, _ ...grpc.CallOption) (*provider.CreateContainerResponse, error) {
+		st.Lock()
+		defer st.Unlock()
+		st.dirs[key(req.GetRef().GetResourceId(), req.GetRef().GetPath())] = true
+		return &provider.CreateContainerResponse{Status: ok}, nil
+	})
+	gwc.On("InitiateFileUpload", mock.Anything, mock.Anything).Return(func(_ context.Context, req *provider.InitiateFileUploadRequest, _ ...grpc.CallOption) (*gateway.InitiateFileUploadResponse, error) {
+		return &gateway.InitiateFileUploadResponse{Status: ok, Protocols: []*gateway.FileUploadProtocol{
+			{Protocol: "simple", UploadEndpoint: srv.URL, Token: key(req.GetRef().GetResourceId(), req.GetRef().GetPath())},
+		}}, nil
+	})
+	gwc.On("InitiateFileDownload", mock.Anything, mock.Anything).Return(func(_ context.Context, req *provider.InitiateFileDownloadRequest, _ ...grpc.CallOption) (*gateway.InitiateFileDownloadResponse, error) {
+		return &gateway.InitiateFileDownloadResponse{Status: ok, Protocols: []*gateway.FileDownloadProtocol{
+			{Protocol: "spaces", DownloadEndpoint: srv.URL, Token: key(req.GetRef().GetResourceId(), req.GetRef().GetPath())},
+		}}, nil
+	})
+	gwc.On("ListContainer", mock.Anything, mock.Anything).Return(func(_ context.Context, req *provider.ListContainerRequest, _ ...grpc.CallOption) (*provider.ListContainerResponse, error) {
+		st.Lock()
+		defer st.Unlock()
+		var infos []*provider.ResourceInfo
+		for d := range st.dirs {
+			infos = append(infos, &provider.ResourceInfo{Type: provider.ResourceType_RESOURCE_TYPE_CONTAINER, Path: d[strings.Index(d, "/"):]})
+		}
+		return &provider.ListContainerResponse{Status: ok, Infos: infos}, nil
+	})
+	gwc.On("Delete", mock.Anything, mock.Anything).Return(func(_ context.Context, req *provider.DeleteRequest, _ ...grpc.CallOption) (*provider.DeleteResponse, error) {
+		st.Lock()
+		defer st.Unlock()
+		d := key(req.GetRef().GetResourceId(), req.GetRef().GetPath())
+		delete(st.dirs, d)
+		for k := range st.files {
+			if strings.HasPrefix(k, d+"/") {
+				delete(st.files, k)
+			}
+		}
+		st.trash["trash-"+path.Base(d)] = req.GetRef().GetPath()
+		return &provider.DeleteResponse{Status: ok}, nil
+	})
+	gwc.On("ListRecycle", mock.Anything, mock.Anything).Return(func(_ context.Context, _ *provider.ListRecycleRequest, _ ...grpc.CallOption) (*provider.ListRecycleResponse, error) {
+		st.Lock()
+		defer st.Unlock()
+		var items []*provider.RecycleItem
+		for k, p := range st.trash {
+			items = append(items, &provider.RecycleItem{Key: k, Ref: &provider.Reference{Path: p}})
+		}
+		return &provider.ListRecycleResponse{Status: ok, RecycleItems: items}, nil
+	})
+	gwc.On("CreateStorageSpace", mock.Anything, mock.Anything).Return(func(_ context.Context, req *provider.CreateStorageSpaceRequest, _ ...grpc.CallOption) (*provider.CreateStorageSpaceResponse, error) {
+		st.Lock()
+		defer st.Unlock()
+		id := utils.ReadPlainFromOpaque(req.GetOpaque(), "spaceid")
+		if slices.Contains(st.spaces, id) {
+			return &provider.CreateStorageSpaceResponse{Status: &rpc.Status{Code: rpc.Code_CODE_ALREADY_EXISTS}}, nil
+		}
+		st.spaces = append(st.spaces, id)
+		return &provider.CreateStorageSpaceResponse{Status: ok}, nil
+	})
+	gwc.On("PurgeRecycle", mock.Anything, mock.Anything).Return(func(_ context.Context, req *provider.PurgeRecycleRequest, _ ...grpc.CallOption) (*provider.PurgeRecycleResponse, error) {
+		st.Lock()
+		defer st.Unlock()
+		delete(st.trash, req.GetKey())
+		return &provider.PurgeRecycleResponse{Status: ok}, nil
+	})
+
+	return pool.GetSelector[gateway.GatewayAPIClient](
+		"GatewaySelector",
+		"com.owncloud.api.gateway."+uuid.New().String(), // the selectors are cached by their id
+		func(cc grpc.ClientConnInterface) gateway.GatewayAPIClient {
+			return gwc
+		},
+	)
+}
+
+func newStorage() *storage {
+	return &storage{files: map[string]string{}, dirs: map[string]bool{}, trash: map[string]string{}}
+}
+
+func TestQuarantine(t *testing.T) {
+	st := newStorage()
+	q, err := quarantine.New("storage$quarantine", newGateway(t, st), "service-account", "secret")
+	require.NoError(t, err)
+
+	require.True(t, q.Contains(&provider.ResourceId{SpaceId: "quarantine", OpaqueId: "file"}))
+	require.False(t, q.Contains(&provider.ResourceId{SpaceId: "personal", OpaqueId: "file"}))
+
+	item := quarantine.Item{
+		ID:         "upload-1",
+		Filename:   "eicar.com",
+		Size:       5,
+		ResourceID: &provider.ResourceId{StorageId: "storage", SpaceId: "personal", OpaqueId: "file"},
+		Uploader:   &user.UserId{OpaqueId: "einstein"},
+		Virus:      "Eicar-Signature",
+		Scanner:    "clamav",
+		ScanDate:   time.Now().UTC(),
+	}
+	require.NoError(t, q.Add(context.Background(), item, strings.NewReader("virus")))
+	require.Equal(t, "virus", st.files["quarantine!quarantine/upload-1/eicar.com"])
+
+	items, err := q.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, "Eicar-Signature", items[0].Virus)
+	require.Equal(t, "folder", items[0].Location.GetOpaqueId(), "the location is derived from the resource")
+
+	t.Run("release delivers the file to its location", func(t *testing.T) {
+		require.NoError(t, q.Release(context.Background(), "upload-1"))
+		require.Equal(t, "virus", st.files["personal!folder/eicar.com"])
+		require.Empty(t, st.trash)
+
+		_, err := q.Get(context.Background(), "upload-1")
+		require.ErrorIs(t, err, quarantine.ErrNotFound)
+	})
+
+	t.Run("purge removes the item", func(t *testing.T) {
+		item.ID = "upload-2"
+		require.NoError(t, q.Add(context.Background(), item, strings.NewReader("virus")))
+		require.NoError(t, q.Purge(context.Background(), "upload-2"))
+
+		items, err := q.List(context.Background())
+		require.NoError(t, err)
+		require.Empty(t, items)
+		require.Empty(t, st.trash)
+
+		require.ErrorIs(t, q.Purge(context.Background(), "upload-2"), quarantine.ErrNotFound)
+	})
+}
+
+func TestQuarantine_InvalidID(t *testing.T) {
+	st := newStorage()
+	q, err := quarantine.New("storage$quarantine", newGateway(t, st), "service-account", "secret")
+	require.NoError(t, err)
+
+	for _, id := range []string{"", "..", "../personal", "a/b", `a\b`, "%2e%2e"} {
+		require.False(t, quarantine.ValidID(id), id)
+
+		_, err := q.Get(context.Background(), id)
+		require.ErrorIs(t, err, quarantine.ErrInvalidID, id)
+		require.ErrorIs(t, q.Release(context.Background(), id), quarantine.ErrInvalidID, id)
+		require.ErrorIs(t, q.Purge(context.Background(), id), quarantine.ErrInvalidID, id)
+		require.ErrorIs(t, q.Add(context.Background(), quarantine.Item{ID: id}, strings.NewReader("")), quarantine.ErrInvalidID, id)
+	}
+	require.True(t, quarantine.ValidID("9c8f7d6e-upload_1"))
+}
+
+func TestQuarantine_CreatesSpace(t *testing.T) {
+	st := newStorage()
+	q, err := quarantine.New("", newGateway(t, st), "service-account", "secret")
+	require.NoError(t, err)
+	require.True(t, q.Contains(&provider.ResourceId{SpaceId: quarantine.DefaultSpaceID}))
+
+	items, err := q.List(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, items)
+	require.Empty(t, st.spaces, "the space is only created when adding an item")
+
+	item := quarantine.Item{ID: "upload-1", Filename: "eicar.com", Size: 5, Location: &provider.ResourceId{SpaceId: "personal"}}
+	require.NoError(t, q.Add(context.Background(), item, strings.NewReader("virus")))
+	item.ID = "upload-2"
+	require.NoError(t, q.Add(context.Background(), item, strings.NewReader("virus")))
+	require.Equal(t, []string{quarantine.DefaultSpaceID}, st.spaces)
+
+	// another instance finds the existing space
+	q2, err := quarantine.New("", newGateway(t, st), "service-account", "secret")
+	require.NoError(t, err)
+	item.ID = "upload-3"
+	require.NoError(t, q2.Add(context.Background(), item, strings.NewReader("virus")))
+	require.Equal(t, "virus", st.files[quarantine.DefaultSpaceID+"!"+quarantine.DefaultSpaceID+"/upload-3/eicar.com"])
+}
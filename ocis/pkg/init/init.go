@@ -259,6 +259,12 @@ func CreateConfig(insecure, forceOverwrite, diff bool, configPath, adminPassword
 		Activitylog: Activitylog{
 			ServiceAccount: serviceAccount,
 		},
+		Antivirus: Antivirus{
+			ServiceAccount: serviceAccount,
+		},
+		Policies: Policies{
+			ServiceAccount: serviceAccount,
+		},
 	}
 
 	if insecure {
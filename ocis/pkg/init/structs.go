@@ -46,6 +46,8 @@ type OcisConfig struct {
 	AuthService       AuthService           `yaml:"auth_service"`
 	Clientlog         Clientlog             `yaml:"clientlog"`
 	Activitylog       Activitylog           `yaml:"activitylog"`
+	Antivirus         Antivirus             `yaml:"antivirus"`
+	Policies          Policies              `yaml:"policies"`
 }
 
 // Activitylog is the configuration for the activitylog service
@@ -53,6 +55,16 @@ type Activitylog struct {
 	ServiceAccount ServiceAccount `yaml:"service_account"`
 }
 
+// Antivirus is the configuration for the antivirus service
+type Antivirus struct {
+	ServiceAccount ServiceAccount `yaml:"service_account"`
+}
+
+// Policies is the configuration for the policies service
+type Policies struct {
+	ServiceAccount ServiceAccount `yaml:"service_account"`
+}
+
 // App is the configuration for the collaboration service
 type App struct {
 	Insecure bool `yaml:"insecure"`
@@ -24,9 +24,11 @@ const (
 type ThumbnailType int32
 
 const (
-	ThumbnailType_PNG ThumbnailType = 0 // Represents PNG type
-	ThumbnailType_JPG ThumbnailType = 1 // Represents JPG type
-	ThumbnailType_GIF ThumbnailType = 2 // Represents GIF type
+	ThumbnailType_PNG  ThumbnailType = 0 // Represents PNG type
+	ThumbnailType_JPG  ThumbnailType = 1 // Represents JPG type
+	ThumbnailType_GIF  ThumbnailType = 2 // Represents GIF type
+	ThumbnailType_WEBP ThumbnailType = 3 // Represents WEBP type
+	ThumbnailType_AVIF ThumbnailType = 4 // Represents AVIF type
 )
 
 // Enum value maps for ThumbnailType.
@@ -35,11 +37,15 @@ var (
 		0: "PNG",
 		1: "JPG",
 		2: "GIF",
+		3: "WEBP",
+		4: "AVIF",
 	}
 	ThumbnailType_value = map[string]int32{
-		"PNG": 0,
-		"JPG": 1,
-		"GIF": 2,
+		"PNG":  0,
+		"JPG":  1,
+		"GIF":  2,
+		"WEBP": 3,
+		"AVIF": 4,
 	}
 )
 
@@ -234,15 +240,16 @@ var file_ocis_messages_thumbnails_v0_thumbnails_proto_rawDesc = []byte{
 	0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70,
 	0x61, 0x74, 0x68, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61,
 	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x75, 0x74, 0x68,
-	0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2a, 0x2a, 0x0a, 0x0d, 0x54, 0x68, 0x75,
+	0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2a, 0x3e, 0x0a, 0x0d, 0x54, 0x68, 0x75,
 	0x6d, 0x62, 0x6e, 0x61, 0x69, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x4e,
 	0x47, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4a, 0x50, 0x47, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03,
-	0x47, 0x49, 0x46, 0x10, 0x02, 0x42, 0x46, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x77, 0x6e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2f, 0x6f, 0x63, 0x69,
-	0x73, 0x2f, 0x76, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x65,
-	0x6e, 0x2f, 0x6f, 0x63, 0x69, 0x73, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2f,
-	0x74, 0x68, 0x75, 0x6d, 0x62, 0x6e, 0x61, 0x69, 0x6c, 0x73, 0x2f, 0x76, 0x30, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x47, 0x49, 0x46, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x57, 0x45, 0x42, 0x50, 0x10, 0x03, 0x12,
+	0x08, 0x0a, 0x04, 0x41, 0x56, 0x49, 0x46, 0x10, 0x04, 0x42, 0x46, 0x5a, 0x44, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x77, 0x6e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x2f, 0x6f, 0x63, 0x69, 0x73, 0x2f, 0x76, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x67, 0x65,
+	0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x6f, 0x63, 0x69, 0x73, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x73, 0x2f, 0x74, 0x68, 0x75, 0x6d, 0x62, 0x6e, 0x61, 0x69, 0x6c, 0x73, 0x2f, 0x76,
+	0x30, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -25,6 +25,154 @@ func (_m *SearchProviderService) EXPECT() *SearchProviderService_Expecter {
 	return &SearchProviderService_Expecter{mock: &_m.Mock}
 }
 
+// CreateSavedSearch provides a mock function with given fields: ctx, in, opts
+func (_m *SearchProviderService) CreateSavedSearch(ctx context.Context, in *v0.CreateSavedSearchRequest, opts ...client.CallOption) (*v0.CreateSavedSearchResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSavedSearch")
+	}
+
+	var r0 *v0.CreateSavedSearchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v0.CreateSavedSearchRequest, ...client.CallOption) (*v0.CreateSavedSearchResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v0.CreateSavedSearchRequest, ...client.CallOption) *v0.CreateSavedSearchResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v0.CreateSavedSearchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v0.CreateSavedSearchRequest, ...client.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchProviderService_CreateSavedSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSavedSearch'
+type SearchProviderService_CreateSavedSearch_Call struct {
+	*mock.Call
+}
+
+// CreateSavedSearch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *v0.CreateSavedSearchRequest
+//   - opts ...client.CallOption
+func (_e *SearchProviderService_Expecter) CreateSavedSearch(ctx interface{}, in interface{}, opts ...interface{}) *SearchProviderService_CreateSavedSearch_Call {
+	return &SearchProviderService_CreateSavedSearch_Call{Call: _e.mock.On("CreateSavedSearch",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *SearchProviderService_CreateSavedSearch_Call) Run(run func(ctx context.Context, in *v0.CreateSavedSearchRequest, opts ...client.CallOption)) *SearchProviderService_CreateSavedSearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]client.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(client.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*v0.CreateSavedSearchRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *SearchProviderService_CreateSavedSearch_Call) Return(_a0 *v0.CreateSavedSearchResponse, _a1 error) *SearchProviderService_CreateSavedSearch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SearchProviderService_CreateSavedSearch_Call) RunAndReturn(run func(context.Context, *v0.CreateSavedSearchRequest, ...client.CallOption) (*v0.CreateSavedSearchResponse, error)) *SearchProviderService_CreateSavedSearch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSavedSearch provides a mock function with given fields: ctx, in, opts
+func (_m *SearchProviderService) DeleteSavedSearch(ctx context.Context, in *v0.DeleteSavedSearchRequest, opts ...client.CallOption) (*v0.DeleteSavedSearchResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSavedSearch")
+	}
+
+	var r0 *v0.DeleteSavedSearchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v0.DeleteSavedSearchRequest, ...client.CallOption) (*v0.DeleteSavedSearchResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v0.DeleteSavedSearchRequest, ...client.CallOption) *v0.DeleteSavedSearchResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v0.DeleteSavedSearchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v0.DeleteSavedSearchRequest, ...client.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchProviderService_DeleteSavedSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSavedSearch'
+type SearchProviderService_DeleteSavedSearch_Call struct {
+	*mock.Call
+}
+
+// DeleteSavedSearch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *v0.DeleteSavedSearchRequest
+//   - opts ...client.CallOption
+func (_e *SearchProviderService_Expecter) DeleteSavedSearch(ctx interface{}, in interface{}, opts ...interface{}) *SearchProviderService_DeleteSavedSearch_Call {
+	return &SearchProviderService_DeleteSavedSearch_Call{Call: _e.mock.On("DeleteSavedSearch",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *SearchProviderService_DeleteSavedSearch_Call) Run(run func(ctx context.Context, in *v0.DeleteSavedSearchRequest, opts ...client.CallOption)) *SearchProviderService_DeleteSavedSearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]client.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(client.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*v0.DeleteSavedSearchRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *SearchProviderService_DeleteSavedSearch_Call) Return(_a0 *v0.DeleteSavedSearchResponse, _a1 error) *SearchProviderService_DeleteSavedSearch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SearchProviderService_DeleteSavedSearch_Call) RunAndReturn(run func(context.Context, *v0.DeleteSavedSearchRequest, ...client.CallOption) (*v0.DeleteSavedSearchResponse, error)) *SearchProviderService_DeleteSavedSearch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IndexSpace provides a mock function with given fields: ctx, in, opts
 func (_m *SearchProviderService) IndexSpace(ctx context.Context, in *v0.IndexSpaceRequest, opts ...client.CallOption) (*v0.IndexSpaceResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -99,6 +247,154 @@ func (_c *SearchProviderService_IndexSpace_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// ListSavedSearches provides a mock function with given fields: ctx, in, opts
+func (_m *SearchProviderService) ListSavedSearches(ctx context.Context, in *v0.ListSavedSearchesRequest, opts ...client.CallOption) (*v0.ListSavedSearchesResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSavedSearches")
+	}
+
+	var r0 *v0.ListSavedSearchesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v0.ListSavedSearchesRequest, ...client.CallOption) (*v0.ListSavedSearchesResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v0.ListSavedSearchesRequest, ...client.CallOption) *v0.ListSavedSearchesResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v0.ListSavedSearchesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v0.ListSavedSearchesRequest, ...client.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchProviderService_ListSavedSearches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSavedSearches'
+type SearchProviderService_ListSavedSearches_Call struct {
+	*mock.Call
+}
+
+// ListSavedSearches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *v0.ListSavedSearchesRequest
+//   - opts ...client.CallOption
+func (_e *SearchProviderService_Expecter) ListSavedSearches(ctx interface{}, in interface{}, opts ...interface{}) *SearchProviderService_ListSavedSearches_Call {
+	return &SearchProviderService_ListSavedSearches_Call{Call: _e.mock.On("ListSavedSearches",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *SearchProviderService_ListSavedSearches_Call) Run(run func(ctx context.Context, in *v0.ListSavedSearchesRequest, opts ...client.CallOption)) *SearchProviderService_ListSavedSearches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]client.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(client.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*v0.ListSavedSearchesRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *SearchProviderService_ListSavedSearches_Call) Return(_a0 *v0.ListSavedSearchesResponse, _a1 error) *SearchProviderService_ListSavedSearches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SearchProviderService_ListSavedSearches_Call) RunAndReturn(run func(context.Context, *v0.ListSavedSearchesRequest, ...client.CallOption) (*v0.ListSavedSearchesResponse, error)) *SearchProviderService_ListSavedSearches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunSavedSearch provides a mock function with given fields: ctx, in, opts
+func (_m *SearchProviderService) RunSavedSearch(ctx context.Context, in *v0.RunSavedSearchRequest, opts ...client.CallOption) (*v0.SearchResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunSavedSearch")
+	}
+
+	var r0 *v0.SearchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v0.RunSavedSearchRequest, ...client.CallOption) (*v0.SearchResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v0.RunSavedSearchRequest, ...client.CallOption) *v0.SearchResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v0.SearchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v0.RunSavedSearchRequest, ...client.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchProviderService_RunSavedSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunSavedSearch'
+type SearchProviderService_RunSavedSearch_Call struct {
+	*mock.Call
+}
+
+// RunSavedSearch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *v0.RunSavedSearchRequest
+//   - opts ...client.CallOption
+func (_e *SearchProviderService_Expecter) RunSavedSearch(ctx interface{}, in interface{}, opts ...interface{}) *SearchProviderService_RunSavedSearch_Call {
+	return &SearchProviderService_RunSavedSearch_Call{Call: _e.mock.On("RunSavedSearch",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *SearchProviderService_RunSavedSearch_Call) Run(run func(ctx context.Context, in *v0.RunSavedSearchRequest, opts ...client.CallOption)) *SearchProviderService_RunSavedSearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]client.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(client.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*v0.RunSavedSearchRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *SearchProviderService_RunSavedSearch_Call) Return(_a0 *v0.SearchResponse, _a1 error) *SearchProviderService_RunSavedSearch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SearchProviderService_RunSavedSearch_Call) RunAndReturn(run func(context.Context, *v0.RunSavedSearchRequest, ...client.CallOption) (*v0.SearchResponse, error)) *SearchProviderService_RunSavedSearch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Search provides a mock function with given fields: ctx, in, opts
 func (_m *SearchProviderService) Search(ctx context.Context, in *v0.SearchRequest, opts ...client.CallOption) (*v0.SearchResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -47,6 +47,30 @@ func NewSearchProviderEndpoints() []*api.Endpoint {
 			Method:  []string{"POST"},
 			Handler: "rpc",
 		},
+		{
+			Name:    "SearchProvider.CreateSavedSearch",
+			Path:    []string{"/api/v0/search/saved-search-create"},
+			Method:  []string{"POST"},
+			Handler: "rpc",
+		},
+		{
+			Name:    "SearchProvider.ListSavedSearches",
+			Path:    []string{"/api/v0/search/saved-searches-list"},
+			Method:  []string{"POST"},
+			Handler: "rpc",
+		},
+		{
+			Name:    "SearchProvider.DeleteSavedSearch",
+			Path:    []string{"/api/v0/search/saved-search-delete"},
+			Method:  []string{"POST"},
+			Handler: "rpc",
+		},
+		{
+			Name:    "SearchProvider.RunSavedSearch",
+			Path:    []string{"/api/v0/search/saved-search-run"},
+			Method:  []string{"POST"},
+			Handler: "rpc",
+		},
 	}
 }
 
@@ -55,6 +79,10 @@ func NewSearchProviderEndpoints() []*api.Endpoint {
 type SearchProviderService interface {
 	Search(ctx context.Context, in *SearchRequest, opts ...client.CallOption) (*SearchResponse, error)
 	IndexSpace(ctx context.Context, in *IndexSpaceRequest, opts ...client.CallOption) (*IndexSpaceResponse, error)
+	CreateSavedSearch(ctx context.Context, in *CreateSavedSearchRequest, opts ...client.CallOption) (*CreateSavedSearchResponse, error)
+	ListSavedSearches(ctx context.Context, in *ListSavedSearchesRequest, opts ...client.CallOption) (*ListSavedSearchesResponse, error)
+	DeleteSavedSearch(ctx context.Context, in *DeleteSavedSearchRequest, opts ...client.CallOption) (*DeleteSavedSearchResponse, error)
+	RunSavedSearch(ctx context.Context, in *RunSavedSearchRequest, opts ...client.CallOption) (*SearchResponse, error)
 }
 
 type searchProviderService struct {
@@ -89,17 +117,65 @@ func (c *searchProviderService) IndexSpace(ctx context.Context, in *IndexSpaceRe
 	return out, nil
 }
 
+func (c *searchProviderService) CreateSavedSearch(ctx context.Context, in *CreateSavedSearchRequest, opts ...client.CallOption) (*CreateSavedSearchResponse, error) {
+	req := c.c.NewRequest(c.name, "SearchProvider.CreateSavedSearch", in)
+	out := new(CreateSavedSearchResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchProviderService) ListSavedSearches(ctx context.Context, in *ListSavedSearchesRequest, opts ...client.CallOption) (*ListSavedSearchesResponse, error) {
+	req := c.c.NewRequest(c.name, "SearchProvider.ListSavedSearches", in)
+	out := new(ListSavedSearchesResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchProviderService) DeleteSavedSearch(ctx context.Context, in *DeleteSavedSearchRequest, opts ...client.CallOption) (*DeleteSavedSearchResponse, error) {
+	req := c.c.NewRequest(c.name, "SearchProvider.DeleteSavedSearch", in)
+	out := new(DeleteSavedSearchResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchProviderService) RunSavedSearch(ctx context.Context, in *RunSavedSearchRequest, opts ...client.CallOption) (*SearchResponse, error) {
+	req := c.c.NewRequest(c.name, "SearchProvider.RunSavedSearch", in)
+	out := new(SearchResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for SearchProvider service
 
 type SearchProviderHandler interface {
 	Search(context.Context, *SearchRequest, *SearchResponse) error
 	IndexSpace(context.Context, *IndexSpaceRequest, *IndexSpaceResponse) error
+	CreateSavedSearch(context.Context, *CreateSavedSearchRequest, *CreateSavedSearchResponse) error
+	ListSavedSearches(context.Context, *ListSavedSearchesRequest, *ListSavedSearchesResponse) error
+	DeleteSavedSearch(context.Context, *DeleteSavedSearchRequest, *DeleteSavedSearchResponse) error
+	RunSavedSearch(context.Context, *RunSavedSearchRequest, *SearchResponse) error
 }
 
 func RegisterSearchProviderHandler(s server.Server, hdlr SearchProviderHandler, opts ...server.HandlerOption) error {
 	type searchProvider interface {
 		Search(ctx context.Context, in *SearchRequest, out *SearchResponse) error
 		IndexSpace(ctx context.Context, in *IndexSpaceRequest, out *IndexSpaceResponse) error
+		CreateSavedSearch(ctx context.Context, in *CreateSavedSearchRequest, out *CreateSavedSearchResponse) error
+		ListSavedSearches(ctx context.Context, in *ListSavedSearchesRequest, out *ListSavedSearchesResponse) error
+		DeleteSavedSearch(ctx context.Context, in *DeleteSavedSearchRequest, out *DeleteSavedSearchResponse) error
+		RunSavedSearch(ctx context.Context, in *RunSavedSearchRequest, out *SearchResponse) error
 	}
 	type SearchProvider struct {
 		searchProvider
@@ -117,6 +193,30 @@ func RegisterSearchProviderHandler(s server.Server, hdlr SearchProviderHandler,
 		Method:  []string{"POST"},
 		Handler: "rpc",
 	}))
+	opts = append(opts, api.WithEndpoint(&api.Endpoint{
+		Name:    "SearchProvider.CreateSavedSearch",
+		Path:    []string{"/api/v0/search/saved-search-create"},
+		Method:  []string{"POST"},
+		Handler: "rpc",
+	}))
+	opts = append(opts, api.WithEndpoint(&api.Endpoint{
+		Name:    "SearchProvider.ListSavedSearches",
+		Path:    []string{"/api/v0/search/saved-searches-list"},
+		Method:  []string{"POST"},
+		Handler: "rpc",
+	}))
+	opts = append(opts, api.WithEndpoint(&api.Endpoint{
+		Name:    "SearchProvider.DeleteSavedSearch",
+		Path:    []string{"/api/v0/search/saved-search-delete"},
+		Method:  []string{"POST"},
+		Handler: "rpc",
+	}))
+	opts = append(opts, api.WithEndpoint(&api.Endpoint{
+		Name:    "SearchProvider.RunSavedSearch",
+		Path:    []string{"/api/v0/search/saved-search-run"},
+		Method:  []string{"POST"},
+		Handler: "rpc",
+	}))
 	return s.Handle(s.NewHandler(&SearchProvider{h}, opts...))
 }
 
@@ -132,6 +232,22 @@ func (h *searchProviderHandler) IndexSpace(ctx context.Context, in *IndexSpaceRe
 	return h.SearchProviderHandler.IndexSpace(ctx, in, out)
 }
 
+func (h *searchProviderHandler) CreateSavedSearch(ctx context.Context, in *CreateSavedSearchRequest, out *CreateSavedSearchResponse) error {
+	return h.SearchProviderHandler.CreateSavedSearch(ctx, in, out)
+}
+
+func (h *searchProviderHandler) ListSavedSearches(ctx context.Context, in *ListSavedSearchesRequest, out *ListSavedSearchesResponse) error {
+	return h.SearchProviderHandler.ListSavedSearches(ctx, in, out)
+}
+
+func (h *searchProviderHandler) DeleteSavedSearch(ctx context.Context, in *DeleteSavedSearchRequest, out *DeleteSavedSearchResponse) error {
+	return h.SearchProviderHandler.DeleteSavedSearch(ctx, in, out)
+}
+
+func (h *searchProviderHandler) RunSavedSearch(ctx context.Context, in *RunSavedSearchRequest, out *SearchResponse) error {
+	return h.SearchProviderHandler.RunSavedSearch(ctx, in, out)
+}
+
 // Api Endpoints for IndexProvider service
 
 func NewIndexProviderEndpoints() []*api.Endpoint {
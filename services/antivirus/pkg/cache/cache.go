@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go-micro.dev/v4/store"
+
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/scanners"
+)
+
+// New returns a cache for scan results, entries expire after the given ttl
+func New(s store.Store, ttl time.Duration) *Cache {
+	return &Cache{store: s, ttl: ttl}
+}
+
+// Cache holds scan results by the hash of the scanned content.
+// The results are tied to the signature version of the scanner, once it changes previous results are not found anymore.
+type Cache struct {
+	store store.Store
+	ttl   time.Duration
+}
+
+// Get returns the cached result for the given content hash and signature version
+func (c *Cache) Get(version string, hash string) (scanners.Result, bool, error) {
+	var res scanners.Result
+
+	recs, err := c.store.Read(key(version, hash))
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return res, false, nil
+	case err != nil:
+		return res, false, err
+	case len(recs) == 0:
+		return res, false, nil
+	}
+
+	if err := json.Unmarshal(recs[0].Value, &res); err != nil {
+		return res, false, err
+	}
+	return res, true, nil
+}
+
+// Set caches the result for the given content hash and signature version
+func (c *Cache) Set(version string, hash string, res scanners.Result) error {
+	b, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+
+	return c.store.Write(&store.Record{
+		Key:    key(version, hash),
+		Value:  b,
+		Expiry: c.ttl,
+	})
+}
+
+// key derives the store key from the signature version and the content hash.
+// The version is hashed as it may contain characters the store does not support.
+func key(version string, hash string) string {
+	v := sha256.Sum256([]byte(version))
+	return hex.EncodeToString(v[:8]) + "-" + hash
+}
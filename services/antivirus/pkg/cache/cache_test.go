@@ -0,0 +1,46 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go-micro.dev/v4/store"
+
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/cache"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/scanners"
+)
+
+func TestCache(t *testing.T) {
+	c := cache.New(store.NewMemoryStore(), time.Hour)
+
+	_, ok, err := c.Get("v1", "abc")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	want := scanners.Result{Infected: true, Description: "Eicar-Test-Signature", ScanTime: time.Now().UTC()}
+	require.NoError(t, c.Set("v1", "abc", want))
+
+	got, ok, err := c.Get("v1", "abc")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, want.Infected, got.Infected)
+	require.Equal(t, want.Description, got.Description)
+	require.True(t, want.ScanTime.Equal(got.ScanTime))
+
+	t.Run("a signature update invalidates the result", func(t *testing.T) {
+		_, ok, err := c.Get("v2", "abc")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("results expire", func(t *testing.T) {
+		c := cache.New(store.NewMemoryStore(), time.Millisecond)
+		require.NoError(t, c.Set("v1", "abc", want))
+		time.Sleep(5 * time.Millisecond)
+
+		_, ok, err := c.Get("v1", "abc")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
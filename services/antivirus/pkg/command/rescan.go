@@ -0,0 +1,85 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/events/stream"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"github.com/urfave/cli/v2"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/config"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/config/parser"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/rescan"
+)
+
+// Rescan is the entrypoint for the rescan command.
+func Rescan(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:     "rescan",
+		Usage:    "rescan all stored files or show the progress of the current rescan",
+		Category: "rescan",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "status",
+				Aliases: []string{"s"},
+				Usage:   "show the progress of the current or last rescan instead of starting one",
+			},
+		},
+		Before: func(_ *cli.Context) error {
+			return configlog.ReturnFatal(parser.ParseConfig(cfg))
+		},
+		Action: func(c *cli.Context) error {
+			if c.Bool("status") {
+				st, err := rescan.LoadState(rescanStore(cfg))
+				if err != nil {
+					return err
+				}
+				if st == nil {
+					fmt.Println("no rescan has been run yet")
+					return nil
+				}
+
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(st)
+			}
+
+			if !cfg.Rescan.Enabled {
+				return errors.New("rescanning is not enabled, set ANTIVIRUS_RESCAN_ENABLED to true")
+			}
+
+			s, err := stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig(cfg.Events))
+			if err != nil {
+				return err
+			}
+
+			return events.Publish(context.Background(), s, event.RescanRequested{
+				Timestamp: utils.TSNow(),
+			})
+		},
+	}
+}
+
+// rescanStore returns the store the progress of rescans is persisted in. It is updated atomically, so only
+// one instance holds the lease of a rescan. It uses a separate bucket as the expiry of the scan result cache must not apply.
+func rescanStore(cfg *config.Config) kv.Store {
+	return kv.New(kv.Options{
+		Type:     cfg.Store.Store,
+		Nodes:    cfg.Store.Nodes,
+		Bucket:   cfg.Store.Database + "-rescan",
+		Username: cfg.Store.AuthUsername,
+		Password: cfg.Store.AuthPassword,
+		// the store is served by the same nats servers as the events
+		EnableTLS:            cfg.Events.EnableTLS,
+		TLSInsecure:          cfg.Events.TLSInsecure,
+		TLSRootCACertificate: cfg.Events.TLSRootCACertificate,
+	})
+}
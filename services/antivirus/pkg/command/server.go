@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cs3org/reva/v2/pkg/store"
 	"github.com/oklog/run"
 	"github.com/urfave/cli/v2"
+	microstore "go-micro.dev/v4/store"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
@@ -43,12 +45,24 @@ func Server(cfg *config.Config) *cli.Command {
 				return err
 			}
 			{
-				svc, err := service.NewAntivirus(cfg, logger, traceProvider)
+				st := store.Create(
+					store.Store(cfg.Cache.Store),
+					store.TTL(cfg.Cache.TTL),
+					store.DisablePersistence(cfg.Cache.DisablePersistence),
+					microstore.Nodes(cfg.Cache.Nodes...),
+					microstore.Database(cfg.Cache.Database),
+					microstore.Table(cfg.Cache.Table),
+					store.Authentication(cfg.Cache.AuthUsername, cfg.Cache.AuthPassword),
+				)
+
+				svc, err := service.NewAntivirus(cfg, logger, traceProvider, st, rescanStore(cfg))
 				if err != nil {
 					return err
 				}
 
-				gr.Add(svc.Run, func(_ error) {
+				gr.Add(func() error {
+					return svc.Run(ctx)
+				}, func(_ error) {
 					cancel()
 				})
 			}
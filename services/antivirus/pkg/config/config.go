@@ -3,10 +3,14 @@ package config
 import (
 	"context"
 	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
 )
 
 // Config combines all available configuration parts.
 type Config struct {
+	Commons *shared.Commons `yaml:"-"` // don't use this directly as configuration for a service
+
 	File string
 	Log  *Log
 
@@ -16,12 +20,20 @@ type Config struct {
 
 	Tracing *Tracing `yaml:"tracing"`
 
-	InfectedFileHandling string `yaml:"infected-file-handling" env:"ANTIVIRUS_INFECTED_FILE_HANDLING" desc:"Defines the behaviour when a virus has been found. Supported options are: 'delete', 'continue' and 'abort '. Delete will delete the file. Continue will mark the file as infected but continues further processing. Abort will keep the file in the uploads folder for further admin inspection and will not move it to its final destination." introductionVersion:"pre5.0"`
+	InfectedFileHandling string `yaml:"infected-file-handling" env:"ANTIVIRUS_INFECTED_FILE_HANDLING" desc:"Defines the behaviour when a virus has been found. Supported options are: 'delete', 'continue', 'abort' and 'quarantine'. Delete will delete the file. Continue will mark the file as infected but continues further processing. Abort will keep the file in the uploads folder for further admin inspection and will not move it to its final destination. Quarantine will move the file to the quarantine space for review by an admin." introductionVersion:"pre5.0"`
+	QuarantineSpaceID    string `yaml:"quarantine_space_id" env:"OCIS_QUARANTINE_SPACE_ID;ANTIVIRUS_QUARANTINE_SPACE_ID" desc:"The ID of the space infected files are moved to when ANTIVIRUS_INFECTED_FILE_HANDLING is set to 'quarantine'. If not set, a project space named 'Quarantine' is created automatically. See the text description for more details." introductionVersion:"7.1"`
 	Events               Events
 	Workers              int `yaml:"workers" env:"ANTIVIRUS_WORKERS" desc:"The number of concurrent go routines that fetch events from the event queue." introductionVersion:"7.0.0"`
 
 	Scanner     Scanner
-	MaxScanSize string `yaml:"max-scan-size" env:"ANTIVIRUS_MAX_SCAN_SIZE" desc:"The maximum scan size the virus scanner can handle. Only this many bytes of a file will be scanned. 0 means unlimited and is the default. Usable common abbreviations: [KB, KiB, MB, MiB, GB, GiB, TB, TiB, PB, PiB, EB, EiB], example: 2GB." introductionVersion:"pre5.0"`
+	Cache       Cache  `yaml:"cache"`
+	Store       Store  `yaml:"store"`
+	Rescan      Rescan `yaml:"rescan"`
+	MaxScanSize string `yaml:"max-scan-size" env:"ANTIVIRUS_MAX_SCAN_SIZE" desc:"The maximum scan size the virus scanner can handle. Only this many bytes of a file will be scanned. 0 means unlimited and is the default, the yara scanner then scans the first 64MiB. Usable common abbreviations: [KB, KiB, MB, MiB, GB, GiB, TB, TiB, PB, PiB, EB, EiB], example: 2GB." introductionVersion:"pre5.0"`
+
+	RevaGateway    string                `yaml:"reva_gateway" env:"OCIS_REVA_GATEWAY" desc:"CS3 gateway used to access the quarantine space and to rescan stored files." introductionVersion:"7.1"`
+	GRPCClientTLS  *shared.GRPCClientTLS `yaml:"grpc_client_tls"`
+	ServiceAccount ServiceAccount        `yaml:"service_account"`
 
 	Context context.Context `json:"-" yaml:"-"`
 
@@ -60,12 +72,52 @@ type Events struct {
 	AuthPassword         string `yaml:"password" env:"OCIS_EVENTS_AUTH_PASSWORD;ANTIVIRUS_EVENTS_AUTH_PASSWORD" desc:"The password to authenticate with the events broker. The events broker is the ocis service which receives and delivers events between the services." introductionVersion:"5.0"`
 }
 
+// ServiceAccount is the configuration for the used service account
+type ServiceAccount struct {
+	ServiceAccountID     string `yaml:"service_account_id" env:"OCIS_SERVICE_ACCOUNT_ID;ANTIVIRUS_SERVICE_ACCOUNT_ID" desc:"The ID of the service account the service should use. Only needed when ANTIVIRUS_INFECTED_FILE_HANDLING is set to 'quarantine' or ANTIVIRUS_RESCAN_ENABLED is set to true. See the 'auth-service' service description for more details." introductionVersion:"7.1"`
+	ServiceAccountSecret string `yaml:"service_account_secret" env:"OCIS_SERVICE_ACCOUNT_SECRET;ANTIVIRUS_SERVICE_ACCOUNT_SECRET" desc:"The service account secret." introductionVersion:"7.1"`
+}
+
+// Cache configures the cache of the scan results
+type Cache struct {
+	Enabled            bool          `yaml:"enabled" env:"ANTIVIRUS_CACHE_ENABLED" desc:"Enables the cache of scan results by content hash. Files with known content are not scanned again as long as the signature version of the scanner did not change." introductionVersion:"7.1"`
+	Store              string        `yaml:"store" env:"OCIS_CACHE_STORE;ANTIVIRUS_CACHE_STORE" desc:"The type of the cache store. Supported values are: 'memory', 'redis-sentinel', 'nats-js-kv', 'noop'. See the text description for details." introductionVersion:"7.1"`
+	Nodes              []string      `yaml:"nodes" env:"OCIS_CACHE_STORE_NODES;ANTIVIRUS_CACHE_STORE_NODES" desc:"A list of nodes to access the configured store. This has no effect when 'memory' store is configured. Note that the behaviour how nodes are used is dependent on the library of the configured store. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	Database           string        `yaml:"database" env:"OCIS_CACHE_DATABASE" desc:"The database name the configured store should use." introductionVersion:"7.1"`
+	Table              string        `yaml:"table" env:"ANTIVIRUS_CACHE_TABLE" desc:"The database table the store should use." introductionVersion:"7.1"`
+	TTL                time.Duration `yaml:"ttl" env:"OCIS_CACHE_TTL;ANTIVIRUS_CACHE_TTL" desc:"Time to live for cached scan results. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	DisablePersistence bool          `yaml:"disable_persistence" env:"OCIS_CACHE_DISABLE_PERSISTENCE;ANTIVIRUS_CACHE_DISABLE_PERSISTENCE" desc:"Disables persistence of the cache. Only applies when store type 'nats-js-kv' is configured. Defaults to false." introductionVersion:"7.1"`
+	AuthUsername       string        `yaml:"username" env:"OCIS_CACHE_AUTH_USERNAME;ANTIVIRUS_CACHE_AUTH_USERNAME" desc:"The username to authenticate with the cache. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"7.1"`
+	AuthPassword       string        `yaml:"password" env:"OCIS_CACHE_AUTH_PASSWORD;ANTIVIRUS_CACHE_AUTH_PASSWORD" desc:"The password to authenticate with the cache. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"7.1"`
+}
+
+// Store configures the store used to persist the progress of rescans
+type Store struct {
+	Store        string   `yaml:"store" env:"OCIS_PERSISTENT_STORE;ANTIVIRUS_STORE" desc:"The type of the store used to persist the progress of rescans. Supported values are: 'memory' and 'nats-js-kv'. Only 'nats-js-kv' persists the progress and shares it between the instances. See the text description for details." introductionVersion:"7.1"`
+	Nodes        []string `yaml:"nodes" env:"OCIS_PERSISTENT_STORE_NODES;ANTIVIRUS_STORE_NODES" desc:"A list of nodes to access the configured store. This has no effect when 'memory' store is configured. Note that the behaviour how nodes are used is dependent on the library of the configured store. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	Database     string   `yaml:"database" env:"ANTIVIRUS_STORE_DATABASE" desc:"The database name the configured store should use." introductionVersion:"7.1"`
+	Table        string   `yaml:"table" env:"ANTIVIRUS_STORE_TABLE" desc:"The database table the store should use." introductionVersion:"7.1"`
+	AuthUsername string   `yaml:"username" env:"OCIS_PERSISTENT_STORE_AUTH_USERNAME;ANTIVIRUS_STORE_AUTH_USERNAME" desc:"The username to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"7.1"`
+	AuthPassword string   `yaml:"password" env:"OCIS_PERSISTENT_STORE_AUTH_PASSWORD;ANTIVIRUS_STORE_AUTH_PASSWORD" desc:"The password to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"7.1"`
+}
+
+// Rescan configures the rescan of stored files
+type Rescan struct {
+	Enabled       bool          `yaml:"enabled" env:"ANTIVIRUS_RESCAN_ENABLED" desc:"Rescan stored files when the signature version of the scanner changes or when requested via the CLI or the graph API. Requires a service account. See the text description for more details." introductionVersion:"7.1"`
+	CheckInterval time.Duration `yaml:"check_interval" env:"ANTIVIRUS_RESCAN_CHECK_INTERVAL" desc:"The interval to check the signature version of the scanner in. A rescan is started when the version changed. Set to 0 to only rescan on request. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	Throttle      time.Duration `yaml:"throttle" env:"ANTIVIRUS_RESCAN_THROTTLE" desc:"The time to wait after scanning a file to limit the load caused by a rescan. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	RecentFiles   time.Duration `yaml:"recent_files" env:"ANTIVIRUS_RESCAN_RECENT_FILES" desc:"Files modified within this duration before the rescan started are rescanned first, together with shared files. Set to 0 to only prioritize shared files. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+}
+
 // Scanner provides configuration options for the virus scanner
 type Scanner struct {
-	Type string `yaml:"type" env:"ANTIVIRUS_SCANNER_TYPE" desc:"The antivirus scanner to use. Supported values are 'clamav' and 'icap'." introductionVersion:"pre5.0"`
+	Type  string   `yaml:"type" env:"ANTIVIRUS_SCANNER_TYPE" desc:"The antivirus scanner to use. Supported values are 'clamav', 'icap', 'yara', 'blocklist' and 'chain'." introductionVersion:"pre5.0"`
+	Chain []string `yaml:"chain" env:"ANTIVIRUS_SCANNER_CHAIN" desc:"The antivirus scanners to run in order when ANTIVIRUS_SCANNER_TYPE is set to 'chain'. A file is considered infected if any of them finds a virus. Supported values are 'clamav', 'icap', 'yara' and 'blocklist'. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
 
-	ClamAV ClamAV // only if Type == clamav
-	ICAP   ICAP   // only if Type == icap
+	ClamAV    ClamAV    // only if Type == clamav
+	ICAP      ICAP      // only if Type == icap
+	YARA      YARA      // only if Type == yara
+	Blocklist Blocklist // only if Type == blocklist
 }
 
 // ClamAV provides configuration option for clamav
@@ -79,3 +131,13 @@ type ICAP struct {
 	URL     string        `yaml:"url" env:"ANTIVIRUS_ICAP_URL" desc:"URL of the ICAP server." introductionVersion:"pre5.0"`
 	Service string        `yaml:"service" env:"ANTIVIRUS_ICAP_SERVICE" desc:"The name of the ICAP service." introductionVersion:"pre5.0"`
 }
+
+// YARA provides configuration options for the yara scanner
+type YARA struct {
+	RulesDir string `yaml:"rules_dir" env:"ANTIVIRUS_YARA_RULES_DIR" desc:"The directory containing the YARA rules (files ending with '.yar' or '.yara'). Only a subset of the YARA language is supported, see the service documentation for details. If not defined, the root directory derives from $OCIS_BASE_DATA_PATH/antivirus/yara." introductionVersion:"7.1"`
+}
+
+// Blocklist provides configuration options for the blocklist scanner
+type Blocklist struct {
+	File string `yaml:"file" env:"ANTIVIRUS_BLOCKLIST_FILE" desc:"The path to a file containing SHA-256 or MD5 hashes of known malicious files, one per line, optionally followed by a name. Changes to the file are picked up without a restart. If not defined, the path derives from $OCIS_BASE_DATA_PATH/antivirus/blocklist.txt." introductionVersion:"7.1"`
+}
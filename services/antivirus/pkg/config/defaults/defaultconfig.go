@@ -1,8 +1,12 @@
 package defaults
 
 import (
+	"path"
 	"time"
 
+	"github.com/owncloud/ocis/v2/ocis-pkg/config/defaults"
+	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
+	"github.com/owncloud/ocis/v2/ocis-pkg/structs"
 	"github.com/owncloud/ocis/v2/services/antivirus/pkg/config"
 )
 
@@ -28,8 +32,25 @@ func DefaultConfig() *config.Config {
 			Endpoint: "127.0.0.1:9233",
 			Cluster:  "ocis-cluster",
 		},
+		Cache: config.Cache{
+			Store:    "memory",
+			Nodes:    []string{"127.0.0.1:9233"},
+			Database: "cache-antivirus",
+			TTL:      24 * time.Hour,
+		},
+		Store: config.Store{
+			Store:    "nats-js-kv",
+			Nodes:    []string{"127.0.0.1:9233"},
+			Database: "antivirus",
+		},
+		Rescan: config.Rescan{
+			CheckInterval: time.Hour,
+			Throttle:      100 * time.Millisecond,
+			RecentFiles:   7 * 24 * time.Hour,
+		},
 		Workers:              10,
 		InfectedFileHandling: "delete",
+		RevaGateway:          shared.DefaultRevaConfig().Address,
 		Scanner: config.Scanner{
 			Type: "clamav",
 			ClamAV: config.ClamAV{
@@ -40,6 +61,12 @@ func DefaultConfig() *config.Config {
 				Service: "avscan",
 				Timeout: 5 * time.Minute,
 			},
+			YARA: config.YARA{
+				RulesDir: path.Join(defaults.BaseDataPath(), "antivirus", "yara"),
+			},
+			Blocklist: config.Blocklist{
+				File: path.Join(defaults.BaseDataPath(), "antivirus", "blocklist.txt"),
+			},
 		},
 	}
 }
@@ -53,6 +80,12 @@ func EnsureDefaults(cfg *config.Config) {
 	if cfg.Tracing == nil {
 		cfg.Tracing = &config.Tracing{}
 	}
+
+	if cfg.GRPCClientTLS == nil && cfg.Commons != nil {
+		cfg.GRPCClientTLS = structs.CopyOrZeroValue(cfg.Commons.GRPCClientTLS)
+	} else if cfg.GRPCClientTLS == nil {
+		cfg.GRPCClientTLS = &shared.GRPCClientTLS{}
+	}
 }
 
 // Sanitize sanitizes the configuration
@@ -0,0 +1,63 @@
+package event
+
+import (
+	"encoding/json"
+
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	types "github.com/cs3org/go-cs3apis/cs3/types/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/events"
+)
+
+// PPOutcomeQuarantine is the outcome of the antivirus step when the infected file was moved to the
+// quarantine space. To the postprocessing the upload is deleted.
+const PPOutcomeQuarantine events.PostprocessingOutcome = "quarantine"
+
+// FileQuarantined is emitted when an infected upload was moved to the quarantine space
+type FileQuarantined struct {
+	ItemID        string
+	UploadID      string
+	Filename      string
+	ResourceID    *provider.ResourceId // the resource the upload was targeted to
+	ExecutingUser *user.User
+	Virus         string
+	Scanner       string
+	Timestamp     *types.Timestamp
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (FileQuarantined) Unmarshal(v []byte) (interface{}, error) {
+	e := FileQuarantined{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
+
+// RescanRequested is emitted to start a rescan of all stored files
+type RescanRequested struct {
+	Timestamp *types.Timestamp
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (RescanRequested) Unmarshal(v []byte) (interface{}, error) {
+	e := RescanRequested{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
+
+// FileInfected is emitted when a rescan finds an infected file among the stored files
+type FileInfected struct {
+	ResourceID *provider.ResourceId
+	Path       string // the path of the file relative to its space root
+	Owner      *user.UserId
+	Virus      string
+	Scanner    string
+	Version    string // the signature version of the scanner
+	Timestamp  *types.Timestamp
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (FileInfected) Unmarshal(v []byte) (interface{}, error) {
+	e := FileInfected{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
@@ -0,0 +1,90 @@
+package rescan
+
+import (
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/config"
+)
+
+// Option defines a single option function.
+type Option func(o *Options)
+
+// Options defines the available options for the Rescanner.
+type Options struct {
+	Config          *config.Config
+	Logger          log.Logger
+	GatewaySelector pool.Selectable[gateway.GatewayAPIClient]
+	Scanner         Scanner
+	ScannerName     string
+	Store           kv.Store
+	MaxScanSize     uint64
+	Skip            func(*provider.ResourceId) bool
+}
+
+// newOptions initializes the available default options.
+func newOptions(opts ...Option) Options {
+	opt := Options{
+		Skip: func(*provider.ResourceId) bool { return false },
+	}
+
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	return opt
+}
+
+// Config provides a function to set the config option.
+func Config(val *config.Config) Option {
+	return func(o *Options) {
+		o.Config = val
+	}
+}
+
+// Logger provides a function to set the logger option.
+func Logger(val log.Logger) Option {
+	return func(o *Options) {
+		o.Logger = val
+	}
+}
+
+// GatewaySelector provides a function to set the gateway selector option.
+func GatewaySelector(val pool.Selectable[gateway.GatewayAPIClient]) Option {
+	return func(o *Options) {
+		o.GatewaySelector = val
+	}
+}
+
+// WithScanner provides a function to set the scanner option.
+// The name is reported in the events of infected files.
+func WithScanner(val Scanner, name string) Option {
+	return func(o *Options) {
+		o.Scanner = val
+		o.ScannerName = name
+	}
+}
+
+// Store provides a function to set the store option. The progress of the rescan is persisted in it.
+func Store(val kv.Store) Option {
+	return func(o *Options) {
+		o.Store = val
+	}
+}
+
+// MaxScanSize provides a function to set the maximum scan size option, larger files are skipped.
+func MaxScanSize(val uint64) Option {
+	return func(o *Options) {
+		o.MaxScanSize = val
+	}
+}
+
+// Skip provides a function to exclude spaces from the rescan.
+func Skip(val func(*provider.ResourceId) bool) Option {
+	return func(o *Options) {
+		o.Skip = val
+	}
+}
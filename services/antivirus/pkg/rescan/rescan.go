@@ -0,0 +1,559 @@
+package rescan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/rhttp"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/scanners"
+)
+
+const (
+	// StatusRunning is the status of a rescan in progress, interrupted rescans are resumed
+	StatusRunning = "running"
+	// StatusFinished is the status of a completed rescan
+	StatusFinished = "finished"
+
+	_stateKey = "rescan-state"
+
+	// _leaseTimeout is the time after which a rescan of another instance is considered dead and taken over
+	_leaseTimeout = 5 * time.Minute
+	// _heartbeat is the interval the progress is persisted in even if no files are scanned
+	_heartbeat = time.Minute
+	// _saveEvery is the number of scanned files after which the progress is persisted,
+	// at most this many files are scanned again when an interrupted rescan is resumed
+	_saveEvery = 50
+	// _tokenTTL is the time after which the service account token is renewed
+	_tokenTTL = 10 * time.Minute
+
+	_transferHeader = "X-Reva-Transfer"
+)
+
+// the rescan walks all spaces twice, first scanning the recently modified and shared files, then the others
+const (
+	passPriority = iota
+	passRemaining
+)
+
+// errLeaseLost is returned when another instance took over the rescan
+var errLeaseLost = errors.New("rescan was taken over by another instance")
+
+// Scanner scans the content of a file
+type Scanner interface {
+	Scan(in scanners.Input) (scanners.Result, error)
+}
+
+// Space is a space to be rescanned
+type Space struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// State is the persisted progress of a rescan
+type State struct {
+	ID       string    `json:"id"`
+	Status   string    `json:"status"`
+	Version  string    `json:"version"` // the signature version of the scanner the rescan is done with
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+
+	Instance string    `json:"instance"` // the instance running the rescan
+	Updated  time.Time `json:"updated"`
+
+	Spaces []Space `json:"spaces"`
+	Pass   int     `json:"pass"`
+	Space  int     `json:"space"`  // the index of the space currently scanned
+	Cursor string  `json:"cursor"` // the path of the last file visited in the current space
+
+	Scanned  int `json:"scanned"`
+	Infected int `json:"infected"`
+	Failed   int `json:"failed"`
+}
+
+// LoadState returns the state of the current or last rescan, it is nil if there was none
+func LoadState(s kv.Store) (*State, error) {
+	b, err := s.Get(_stateKey)
+	if err != nil || b == nil {
+		return nil, err
+	}
+
+	st := &State{}
+	if err := json.Unmarshal(b, st); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// Rescanner rescans the stored files whenever the signature version of the scanner changes or a rescan is requested
+type Rescanner struct {
+	opts     Options
+	client   *http.Client
+	instance string
+	running  atomic.Bool
+	trigger  chan struct{}
+
+	token     string
+	tokenTime time.Time
+	lastSave  time.Time
+	unsaved   int
+}
+
+// New returns a new Rescanner
+func New(opts ...Option) *Rescanner {
+	return &Rescanner{
+		opts:     newOptions(opts...),
+		client:   rhttp.GetHTTPClient(rhttp.Insecure(true)),
+		instance: uuid.New().String(),
+		trigger:  make(chan struct{}, 1),
+	}
+}
+
+// Trigger requests a rescan of all stored files. It is ignored if a rescan is already running.
+func (r *Rescanner) Trigger() {
+	if r.running.Load() {
+		r.opts.Logger.Info().Msg("rescan already running, ignoring request")
+		return
+	}
+
+	select {
+	case r.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Run resumes interrupted rescans and starts new ones until the context is done.
+// Infected files are reported via the publisher.
+func (r *Rescanner) Run(ctx context.Context, pub events.Publisher) error {
+	r.check(ctx, pub, false)
+
+	var tick <-chan time.Time
+	if interval := r.opts.Config.Rescan.CheckInterval; interval > 0 {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		tick = t.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-tick:
+			r.check(ctx, pub, false)
+		case <-r.trigger:
+			r.check(ctx, pub, true)
+		}
+
+		// requests received while rescanning are covered by the rescan
+		select {
+		case <-r.trigger:
+		default:
+		}
+	}
+}
+
+// check decides if a rescan needs to be started or resumed and runs it
+func (r *Rescanner) check(ctx context.Context, pub events.Publisher, force bool) {
+	logger := r.opts.Logger
+
+	st, err := LoadState(r.opts.Store)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not load rescan state")
+		return
+	}
+
+	version := r.version()
+	switch {
+	case st != nil && st.Status == StatusRunning:
+		if st.Instance != r.instance && time.Since(st.Updated) < _leaseTimeout {
+			logger.Debug().Str("id", st.ID).Msg("rescan is running on another instance")
+			return
+		}
+		if version != "" && st.Version != version {
+			logger.Info().Str("id", st.ID).Str("version", version).Msg("signature version changed during rescan, starting over")
+			st = r.newState(version)
+		}
+	case force:
+		st = r.newState(version)
+	case st == nil:
+		// the stored files have been scanned with the current signatures on upload
+		if err := r.save(&State{Status: StatusFinished, Version: version, Finished: time.Now()}); err != nil {
+			logger.Error().Err(err).Msg("could not save rescan state")
+		}
+		return
+	case version != "" && st.Version != version:
+		logger.Info().Str("version", version).Str("previous", st.Version).Msg("signature version changed")
+		st = r.newState(version)
+	default:
+		return
+	}
+
+	r.running.Store(true)
+	defer r.running.Store(false)
+
+	if err := r.run(ctx, st, pub); err != nil {
+		logger.Error().Err(err).Str("id", st.ID).Msg("rescan stopped")
+	}
+}
+
+func (r *Rescanner) newState(version string) *State {
+	return &State{
+		ID:      uuid.New().String(),
+		Status:  StatusRunning,
+		Version: version,
+		Started: time.Now(),
+	}
+}
+
+// version returns the signature version of the scanner, it is empty if the scanner does not report one
+func (r *Rescanner) version() string {
+	v, ok := r.opts.Scanner.(scanners.Versioned)
+	if !ok {
+		return ""
+	}
+
+	version, err := v.Version()
+	if err != nil {
+		r.opts.Logger.Error().Err(err).Msg("could not get the signature version of the scanner")
+		return ""
+	}
+	return version
+}
+
+// run runs the rescan from the given state
+func (r *Rescanner) run(ctx context.Context, st *State, pub events.Publisher) error {
+	logger := r.opts.Logger
+
+	if st.Spaces == nil {
+		spaces, err := r.listSpaces(ctx)
+		if err != nil {
+			return err
+		}
+		st.Spaces = spaces
+		logger.Info().Str("id", st.ID).Int("spaces", len(spaces)).Str("version", st.Version).Msg("starting rescan")
+	} else {
+		logger.Info().Str("id", st.ID).Int("pass", st.Pass).Int("space", st.Space).Str("cursor", st.Cursor).Msg("resuming rescan")
+	}
+
+	st.Instance = r.instance
+	if err := r.save(st); err != nil {
+		return err
+	}
+
+	for ; st.Pass <= passRemaining; st.Pass, st.Space = st.Pass+1, 0 {
+		for ; st.Space < len(st.Spaces); st.Space, st.Cursor = st.Space+1, "" {
+			if err := r.scanSpace(ctx, st, pub); err != nil {
+				if ctx.Err() != nil || errors.Is(err, errLeaseLost) {
+					st.Instance = ""
+					_ = r.save(st)
+					return err
+				}
+				logger.Error().Err(err).Str("space", st.Spaces[st.Space].ID).Msg("could not rescan space")
+			}
+		}
+	}
+
+	st.Status = StatusFinished
+	st.Finished = time.Now()
+	st.Instance = ""
+	logger.Info().Str("id", st.ID).Int("scanned", st.Scanned).Int("infected", st.Infected).Int("failed", st.Failed).Dur("duration", st.Finished.Sub(st.Started)).Msg("rescan finished")
+	return r.save(st)
+}
+
+// listSpaces returns the spaces to rescan, project spaces are shared and therefore scanned first
+func (r *Rescanner) listSpaces(ctx context.Context) ([]Space, error) {
+	gwc, ctx, err := r.serviceContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := gwc.ListStorageSpaces(ctx, &provider.ListStorageSpacesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if res.GetStatus().GetCode() != rpc.Code_CODE_OK {
+		return nil, fmt.Errorf("could not list spaces: %s", res.GetStatus().GetMessage())
+	}
+
+	spaces := make([]Space, 0, len(res.GetStorageSpaces()))
+	for _, s := range res.GetStorageSpaces() {
+		switch {
+		case s.GetSpaceType() != "personal" && s.GetSpaceType() != "project":
+			continue
+		case r.opts.Skip(s.GetRoot()):
+			continue
+		}
+		spaces = append(spaces, Space{ID: s.GetId().GetOpaqueId(), Type: s.GetSpaceType()})
+	}
+
+	sort.SliceStable(spaces, func(i, j int) bool {
+		return spaces[i].Type == "project" && spaces[j].Type != "project"
+	})
+	return spaces, nil
+}
+
+// scanSpace walks the current space of the state starting after its cursor
+func (r *Rescanner) scanSpace(ctx context.Context, st *State, pub events.Publisher) error {
+	space := st.Spaces[st.Space]
+	root, err := storagespace.ParseID(space.ID)
+	if err != nil {
+		return err
+	}
+	root.OpaqueId = root.GetSpaceId()
+
+	return r.walk(ctx, st, pub, &root, ".", st.Cursor, space.Type == "project")
+}
+
+// walk visits the files of the directory in the order of their names
+func (r *Rescanner) walk(ctx context.Context, st *State, pub events.Publisher, root *provider.ResourceId, dir, resume string, shared bool) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	gwc, sctx, err := r.serviceContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := gwc.ListContainer(sctx, &provider.ListContainerRequest{Ref: &provider.Reference{ResourceId: root, Path: utils.MakeRelativePath(dir)}})
+	if err != nil {
+		return err
+	}
+	switch res.GetStatus().GetCode() {
+	case rpc.Code_CODE_OK:
+	case rpc.Code_CODE_NOT_FOUND:
+		// deleted in the meantime
+		return nil
+	default:
+		return fmt.Errorf("could not list '%s': %s", dir, res.GetStatus().GetMessage())
+	}
+
+	infos := res.GetInfos()
+	sort.Slice(infos, func(i, j int) bool {
+		return path.Base(infos[i].GetPath()) < path.Base(infos[j].GetPath())
+	})
+
+	for _, info := range infos {
+		p := path.Join(dir, path.Base(info.GetPath()))
+		s := shared || utils.ReadPlainFromOpaque(info.GetOpaque(), "share-types") != ""
+
+		if info.GetType() == provider.ResourceType_RESOURCE_TYPE_CONTAINER {
+			if resume != "" && comparePaths(p, resume) < 0 && !strings.HasPrefix(resume, p+"/") {
+				continue
+			}
+			if err := r.walk(ctx, st, pub, root, p, resume, s); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if resume != "" && comparePaths(p, resume) <= 0 {
+			continue
+		}
+
+		st.Cursor = p
+		if r.priority(st, info, s) != (st.Pass == passPriority) {
+			if err := r.checkpoint(st); err != nil {
+				return err
+			}
+			continue
+		}
+
+		r.scanFile(ctx, st, pub, info, p)
+		r.unsaved++
+		if err := r.checkpoint(st); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(r.opts.Config.Rescan.Throttle):
+		}
+	}
+
+	return nil
+}
+
+// priority tells if the file is scanned in the first pass because it is shared or was modified recently.
+// It is evaluated relative to the start of the rescan to give the same answer in both passes.
+func (r *Rescanner) priority(st *State, info *provider.ResourceInfo, shared bool) bool {
+	if shared {
+		return true
+	}
+	recent := r.opts.Config.Rescan.RecentFiles
+	return recent > 0 && !utils.TSToTime(info.GetMtime()).Before(st.Started.Add(-recent))
+}
+
+// scanFile scans the file and reports it if it is infected, errors are counted and logged only
+func (r *Rescanner) scanFile(ctx context.Context, st *State, pub events.Publisher, info *provider.ResourceInfo, p string) {
+	logger := r.opts.Logger
+
+	if info.GetSize() == 0 || (r.opts.MaxScanSize > 0 && info.GetSize() > r.opts.MaxScanSize) {
+		logger.Debug().Str("path", p).Uint64("size", info.GetSize()).Msg("skipping file")
+		return
+	}
+
+	res, err := r.scan(ctx, info)
+	if err != nil {
+		st.Failed++
+		logger.Error().Err(err).Interface("resourceID", info.GetId()).Str("path", p).Msg("could not rescan file")
+		return
+	}
+	st.Scanned++
+
+	if !res.Infected {
+		return
+	}
+	st.Infected++
+
+	logger.Warn().Interface("resourceID", info.GetId()).Str("path", p).Str("virus", res.Description).Str("version", st.Version).Msg("rescan found infected file")
+	if err := events.Publish(ctx, pub, event.FileInfected{
+		ResourceID: info.GetId(),
+		Path:       p,
+		Owner:      info.GetOwner(),
+		Virus:      res.Description,
+		Scanner:    r.opts.ScannerName,
+		Version:    st.Version,
+		Timestamp:  utils.TSNow(),
+	}); err != nil {
+		logger.Error().Err(err).Interface("resourceID", info.GetId()).Msg("could not publish infected file event")
+	}
+}
+
+func (r *Rescanner) scan(ctx context.Context, info *provider.ResourceInfo) (scanners.Result, error) {
+	gwc, ctx, err := r.serviceContext(ctx)
+	if err != nil {
+		return scanners.Result{}, err
+	}
+
+	rc, err := r.download(ctx, gwc, &provider.Reference{ResourceId: info.GetId()})
+	if err != nil {
+		return scanners.Result{}, err
+	}
+	defer rc.Close()
+
+	return r.opts.Scanner.Scan(scanners.Input{Body: rc, Size: int64(info.GetSize()), Name: path.Base(info.GetPath())})
+}
+
+// checkpoint persists the state once enough files have been scanned or the heartbeat is due
+func (r *Rescanner) checkpoint(st *State) error {
+	if r.unsaved < _saveEvery && time.Since(r.lastSave) <= _heartbeat {
+		return nil
+	}
+	return r.save(st)
+}
+
+// save persists the state unless another instance holds the lease of a running rescan.
+// The lease is checked and taken in a single atomic update, so only one instance wins a take over.
+func (r *Rescanner) save(st *State) error {
+	st.Updated = time.Now()
+	r.lastSave = st.Updated
+	r.unsaved = 0
+
+	b, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.opts.Store.Update(_stateKey, func(value []byte) ([]byte, error) {
+		if value == nil {
+			return b, nil
+		}
+		cur := &State{}
+		if err := json.Unmarshal(value, cur); err == nil && cur.Status == StatusRunning && cur.Instance != "" && cur.Instance != r.instance && time.Since(cur.Updated) < _leaseTimeout {
+			return nil, errLeaseLost
+		}
+		return b, nil
+	})
+	return err
+}
+
+// serviceContext returns a gateway client and a context authenticated as the service account
+func (r *Rescanner) serviceContext(ctx context.Context) (gateway.GatewayAPIClient, context.Context, error) {
+	gwc, err := r.opts.GatewaySelector.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if r.token == "" || time.Since(r.tokenTime) > _tokenTTL {
+		sa := r.opts.Config.ServiceAccount
+		token, err := utils.GetServiceUserToken(ctx, gwc, sa.ServiceAccountID, sa.ServiceAccountSecret)
+		if err != nil {
+			return nil, nil, err
+		}
+		r.token, r.tokenTime = token, time.Now()
+	}
+
+	// the token is needed for the grpc calls as well as for the data transfers
+	ctx = metadata.AppendToOutgoingContext(ctx, revactx.TokenHeader, r.token)
+	return gwc, revactx.ContextSetToken(ctx, r.token), nil
+}
+
+func (r *Rescanner) download(ctx context.Context, gwc gateway.GatewayAPIClient, ref *provider.Reference) (io.ReadCloser, error) {
+	res, err := gwc.InitiateFileDownload(ctx, &provider.InitiateFileDownloadRequest{Ref: ref})
+	if err != nil {
+		return nil, err
+	}
+	if res.GetStatus().GetCode() != rpc.Code_CODE_OK {
+		return nil, fmt.Errorf("could not initiate download: %s", res.GetStatus().GetMessage())
+	}
+
+	var ep, token string
+	for _, p := range res.GetProtocols() {
+		if p.GetProtocol() == "spaces" {
+			ep, token = p.GetDownloadEndpoint(), p.GetToken()
+			break
+		}
+	}
+	if (ep == "" || token == "") && len(res.GetProtocols()) > 0 {
+		ep, token = res.GetProtocols()[0].GetDownloadEndpoint(), res.GetProtocols()[0].GetToken()
+	}
+
+	req, err := rhttp.NewRequest(ctx, http.MethodGet, ep, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(_transferHeader, token)
+
+	hres, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if hres.StatusCode != http.StatusOK {
+		hres.Body.Close()
+		return nil, fmt.Errorf("unexpected status code from download %v", hres.StatusCode)
+	}
+	return hres.Body, nil
+}
+
+// comparePaths compares two paths by their elements, which is the order they are visited in
+func comparePaths(a, b string) int {
+	ae, be := strings.Split(a, "/"), strings.Split(b, "/")
+	for i := 0; i < len(ae) && i < len(be); i++ {
+		if c := strings.Compare(ae[i], be[i]); c != 0 {
+			return c
+		}
+	}
+	return len(ae) - len(be)
+}
@@ -0,0 +1,284 @@
+package rescan
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	userpb "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	cs3mocks "github.com/cs3org/reva/v2/tests/cs3mocks/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go-micro.dev/v4/events"
+	"google.golang.org/grpc"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/config"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/scanners"
+)
+
+type file struct {
+	content string
+	mtime   time.Time
+	shared  bool
+}
+
+// tree maps the space id to the files of the space by their path
+type tree map[string]map[string]file
+
+type testScanner struct {
+	version string
+	scanned []string
+}
+
+func (s *testScanner) Scan(in scanners.Input) (scanners.Result, error) {
+	b, err := io.ReadAll(in.Body)
+	if err != nil {
+		return scanners.Result{}, err
+	}
+	s.scanned = append(s.scanned, in.Name)
+	if strings.Contains(string(b), "virus") {
+		return scanners.Result{Infected: true, Description: "Test-Virus"}, nil
+	}
+	return scanners.Result{}, nil
+}
+
+func (s *testScanner) Version() (string, error) {
+	return s.version, nil
+}
+
+type testPublisher struct {
+	events []interface{}
+}
+
+func (p *testPublisher) Publish(_ string, ev interface{}, _ ...events.PublishOption) error {
+	p.events = append(p.events, ev)
+	return nil
+}
+
+func newGateway(t *testing.T, files tree) pool.Selectable[gateway.GatewayAPIClient] {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		space, p, _ := strings.Cut(r.Header.Get("X-Reva-Transfer"), ":")
+		_, _ = io.WriteString(w, files[space][p].content)
+	}))
+	t.Cleanup(srv.Close)
+
+	gwc := &cs3mocks.GatewayAPIClient{}
+	ok := &rpc.Status{Code: rpc.Code_CODE_OK}
+	gwc.On("Authenticate", mock.Anything, mock.Anything).Return(&gateway.AuthenticateResponse{Status: ok, Token: "token"}, nil)
+	gwc.On("ListStorageSpaces", mock.Anything, mock.Anything).Return(&provider.ListStorageSpacesResponse{Status: ok, StorageSpaces: []*provider.StorageSpace{
+		{Id: &provider.StorageSpaceId{OpaqueId: "storage$personal!personal"}, Root: &provider.ResourceId{StorageId: "storage", SpaceId: "personal", OpaqueId: "personal"}, SpaceType: "personal"},
+		{Id: &provider.StorageSpaceId{OpaqueId: "storage$project!project"}, Root: &provider.ResourceId{StorageId: "storage", SpaceId: "project", OpaqueId: "project"}, SpaceType: "project"},
+		{Id: &provider.StorageSpaceId{OpaqueId: "storage$quarantine!quarantine"}, Root: &provider.ResourceId{StorageId: "storage", SpaceId: "quarantine", OpaqueId: "quarantine"}, SpaceType: "project"},
+		{Id: &provider.StorageSpaceId{OpaqueId: "storage$mountpoint!mountpoint"}, Root: &provider.ResourceId{StorageId: "storage", SpaceId: "mountpoint", OpaqueId: "mountpoint"}, SpaceType: "mountpoint"},
+	}}, nil)
+	gwc.On("ListContainer", mock.Anything, mock.Anything).Return(func(_ context.Context, req *provider.ListContainerRequest, _ ...grpc.CallOption) (*provider.ListContainerResponse, error) {
+		space := req.GetRef().GetResourceId().GetSpaceId()
+		dir := path.Clean(req.GetRef().GetPath())
+
+		seen := map[string]bool{}
+		var infos []*provider.ResourceInfo
+		for p, f := range files[space] {
+			if !strings.HasPrefix(p, dir+"/") && dir != "." {
+				continue
+			}
+			rel := strings.TrimPrefix(strings.TrimPrefix(p, dir), "/")
+			name, rest, isDir := strings.Cut(rel, "/")
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			info := &provider.ResourceInfo{
+				Id:    &provider.ResourceId{StorageId: "storage", SpaceId: space, OpaqueId: path.Join(dir, name)},
+				Path:  name,
+				Size:  uint64(len(f.content)),
+				Mtime: utils.TimeToTS(f.mtime),
+				Owner: &userpb.UserId{OpaqueId: "einstein"},
+				Type:  provider.ResourceType_RESOURCE_TYPE_FILE,
+			}
+			if isDir && rest != "" {
+				info.Type = provider.ResourceType_RESOURCE_TYPE_CONTAINER
+			} else if f.shared {
+				info.Opaque = utils.AppendPlainToOpaque(nil, "share-types", "0")
+			}
+			infos = append(infos, info)
+		}
+		return &provider.ListContainerResponse{Status: ok, Infos: infos}, nil
+	})
+	gwc.On("InitiateFileDownload", mock.Anything, mock.Anything).Return(func(_ context.Context, req *provider.InitiateFileDownloadRequest, _ ...grpc.CallOption) (*gateway.InitiateFileDownloadResponse, error) {
+		rid := req.GetRef().GetResourceId()
+		return &gateway.InitiateFileDownloadResponse{Status: ok, Protocols: []*gateway.FileDownloadProtocol{
+			{Protocol: "spaces", DownloadEndpoint: srv.URL, Token: rid.GetSpaceId() + ":" + rid.GetOpaqueId()},
+		}}, nil
+	})
+
+	return pool.GetSelector[gateway.GatewayAPIClient](
+		"GatewaySelector",
+		"com.owncloud.api.gateway."+uuid.New().String(), // the selectors are cached by their id
+		func(cc grpc.ClientConnInterface) gateway.GatewayAPIClient {
+			return gwc
+		},
+	)
+}
+
+func newRescanner(t *testing.T, files tree, s *testScanner, st kv.Store) *Rescanner {
+	cfg := &config.Config{
+		Rescan: config.Rescan{Enabled: true, RecentFiles: 24 * time.Hour},
+	}
+	return New(
+		Config(cfg),
+		Logger(log.NopLogger()),
+		GatewaySelector(newGateway(t, files)),
+		WithScanner(s, "test"),
+		Store(st),
+		Skip(func(rid *provider.ResourceId) bool { return rid.GetSpaceId() == "quarantine" }),
+	)
+}
+
+func TestRescan(t *testing.T) {
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	files := tree{
+		"personal": {
+			"a.txt":        {content: "clean", mtime: old},
+			"b/c.txt":      {content: "virus", mtime: old},
+			"b/recent.txt": {content: "clean", mtime: time.Now()},
+			"d.txt":        {content: "clean", mtime: old, shared: true},
+			"e.txt":        {content: "", mtime: old},
+		},
+		"project": {
+			"p.txt": {content: "clean", mtime: old},
+		},
+		"quarantine": {
+			"q.txt": {content: "virus", mtime: old},
+		},
+	}
+
+	st := kv.New(kv.Options{})
+	s := &testScanner{version: "1"}
+	pub := &testPublisher{}
+	r := newRescanner(t, files, s, st)
+
+	r.check(context.Background(), pub, false)
+	require.Empty(t, s.scanned, "the files have been scanned on upload with the current version")
+	state, err := LoadState(st)
+	require.NoError(t, err)
+	require.Equal(t, StatusFinished, state.Status)
+
+	r.check(context.Background(), pub, false)
+	require.Empty(t, s.scanned, "the version did not change")
+
+	s.version = "2"
+	r.check(context.Background(), pub, false)
+	require.Equal(t, []string{"p.txt", "recent.txt", "d.txt", "a.txt", "c.txt"}, s.scanned, "shared and recent files are scanned first")
+
+	require.Len(t, pub.events, 1)
+	ev := pub.events[0].(event.FileInfected)
+	require.Equal(t, "b/c.txt", ev.Path)
+	require.Equal(t, "Test-Virus", ev.Virus)
+	require.Equal(t, "2", ev.Version)
+	require.Equal(t, "einstein", ev.Owner.GetOpaqueId())
+
+	state, err = LoadState(st)
+	require.NoError(t, err)
+	require.Equal(t, StatusFinished, state.Status)
+	require.Equal(t, "2", state.Version)
+	require.Equal(t, 5, state.Scanned)
+	require.Equal(t, 1, state.Infected)
+
+	t.Run("forced", func(t *testing.T) {
+		s.scanned = nil
+		r.check(context.Background(), pub, true)
+		require.Len(t, s.scanned, 5)
+	})
+}
+
+func TestRescanResume(t *testing.T) {
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	files := tree{
+		"personal": {
+			"a.txt":   {content: "clean", mtime: old},
+			"b/c.txt": {content: "clean", mtime: old},
+			"b/d.txt": {content: "clean", mtime: old},
+			"e.txt":   {content: "clean", mtime: old},
+		},
+	}
+
+	st := kv.New(kv.Options{})
+	s := &testScanner{version: "1"}
+	r := newRescanner(t, files, s, st)
+
+	// an interrupted rescan of another instance
+	interrupted := &State{
+		ID:       "interrupted",
+		Status:   StatusRunning,
+		Version:  "1",
+		Started:  time.Now(),
+		Instance: "other",
+		Updated:  time.Now(),
+		Spaces:   []Space{{ID: "storage$personal!personal", Type: "personal"}},
+		Pass:     passRemaining,
+		Cursor:   "b/c.txt",
+		Scanned:  2,
+	}
+	write := func() {
+		b, err := json.Marshal(interrupted)
+		require.NoError(t, err)
+		_, err = st.Update(_stateKey, func([]byte) ([]byte, error) { return b, nil })
+		require.NoError(t, err)
+	}
+	write()
+
+	r.check(context.Background(), &testPublisher{}, false)
+	require.Empty(t, s.scanned, "the rescan is still owned by the other instance")
+
+	interrupted.Updated = time.Now().Add(-2 * _leaseTimeout)
+	write()
+
+	r.check(context.Background(), &testPublisher{}, false)
+	require.Equal(t, []string{"d.txt", "e.txt"}, s.scanned)
+
+	state, err := LoadState(st)
+	require.NoError(t, err)
+	require.Equal(t, "interrupted", state.ID)
+	require.Equal(t, StatusFinished, state.Status)
+	require.Equal(t, 4, state.Scanned)
+}
+
+func TestComparePaths(t *testing.T) {
+	require.Negative(t, comparePaths("a", "b"))
+	require.Negative(t, comparePaths("a/z", "b"))
+	require.Negative(t, comparePaths("a", "a/b"))
+	require.Positive(t, comparePaths("a b/c", "a/b"), "elements are compared, not strings")
+	require.Zero(t, comparePaths("a/b", "a/b"))
+	require.Positive(t, comparePaths("b", "a/z"))
+}
+
+func TestSaveRespectsLease(t *testing.T) {
+	st := kv.New(kv.Options{})
+	a := newRescanner(t, tree{}, &testScanner{version: "1"}, st)
+	b := newRescanner(t, tree{}, &testScanner{version: "1"}, st)
+
+	// both instances found an expired lease, the first one to save takes over
+	require.NoError(t, a.save(&State{ID: "a", Status: StatusRunning, Instance: a.instance}))
+	require.ErrorIs(t, b.save(&State{ID: "b", Status: StatusRunning, Instance: b.instance}), errLeaseLost)
+	require.ErrorIs(t, b.save(&State{ID: "b", Status: StatusFinished}), errLeaseLost)
+
+	state, err := LoadState(st)
+	require.NoError(t, err)
+	require.Equal(t, "a", state.ID)
+}
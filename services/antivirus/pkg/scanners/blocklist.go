@@ -0,0 +1,133 @@
+package scanners
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NewBlocklist returns a Scanner checking the SHA-256 and MD5 hashes of files against the given blocklist file.
+// The file is reloaded as soon as it changes.
+func NewBlocklist(file string) (*Blocklist, error) {
+	b := &Blocklist{file: file}
+	return b, b.reload()
+}
+
+// Blocklist is a Scanner based on a list of known file hashes
+type Blocklist struct {
+	file string
+
+	mu      sync.RWMutex
+	modTime time.Time
+	size    int64
+	hashes  map[string]string
+	version string
+}
+
+// Scan to fulfill Scanner interface
+func (s *Blocklist) Scan(in Input) (Result, error) {
+	if err := s.reload(); err != nil {
+		return Result{}, err
+	}
+
+	sha, md := sha256.New(), md5.New()
+	if _, err := io.Copy(io.MultiWriter(sha, md), in.Body); err != nil {
+		return Result{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := Result{ScanTime: time.Now()}
+	for _, c := range []struct {
+		alg string
+		sum []byte
+	}{{"sha256", sha.Sum(nil)}, {"md5", md.Sum(nil)}} {
+		h := hex.EncodeToString(c.sum)
+		if name, ok := s.hashes[h]; ok {
+			result.Infected = true
+			result.Description = name
+			if name == "" {
+				result.Description = fmt.Sprintf("blocklisted %s hash %s", c.alg, h)
+			}
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// reload reads the blocklist file if it changed since it was last read
+func (s *Blocklist) reload() error {
+	info, err := os.Stat(s.file)
+	if err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	unchanged := s.hashes != nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size
+	s.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.file)
+	if err != nil {
+		return err
+	}
+
+	hashes, err := parseBlocklist(data)
+	if err != nil {
+		return fmt.Errorf("%s:%w", s.file, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sum := sha256.Sum256(data)
+	s.hashes, s.modTime, s.size = hashes, info.ModTime(), info.Size()
+	s.version = "blocklist-" + hex.EncodeToString(sum[:])
+	return nil
+}
+
+// parseBlocklist parses lines of `<hash> [name]`, which is compatible to the output of sha256sum and md5sum.
+// Everything following a # is a comment.
+func parseBlocklist(data []byte) (map[string]string, error) {
+	hashes := make(map[string]string)
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		h := strings.ToLower(fields[0])
+		if _, err := hex.DecodeString(h); err != nil || (len(h) != 2*sha256.Size && len(h) != 2*md5.Size) {
+			return nil, fmt.Errorf("%d: invalid hash '%s'", n, fields[0])
+		}
+
+		hashes[h] = strings.Join(fields[1:], " ")
+	}
+
+	return hashes, sc.Err()
+}
+
+// Version to fulfill Versioned interface, it is derived from the content of the blocklist file
+func (s *Blocklist) Version() (string, error) {
+	if err := s.reload(); err != nil {
+		return "", err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.version, nil
+}
@@ -0,0 +1,53 @@
+package scanners_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/scanners"
+)
+
+const (
+	eicarSHA256 = "275a021bbfb6489e54d471899f7db9d1663fc695ec2fe2a2c4538aabf651fd0f"
+	eicarMD5    = "44d88612fea8a8f36de82e1278abb02f"
+)
+
+func TestBlocklist_Scan(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "blocklist.txt")
+	require.NoError(t, os.WriteFile(file, []byte("# known bad files\n"+eicarSHA256+"  Eicar-Test-Signature\n"), 0600))
+
+	s, err := scanners.NewBlocklist(file)
+	require.NoError(t, err)
+
+	res, err := s.Scan(scanners.Input{Body: strings.NewReader(eicar)})
+	require.NoError(t, err)
+	require.True(t, res.Infected)
+	require.Equal(t, "Eicar-Test-Signature", res.Description)
+
+	res, err = s.Scan(scanners.Input{Body: strings.NewReader("harmless")})
+	require.NoError(t, err)
+	require.False(t, res.Infected)
+
+	t.Run("reloads the file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(file, []byte(strings.ToUpper(eicarMD5)+"\n"), 0600))
+		require.NoError(t, os.Chtimes(file, time.Now(), time.Now().Add(time.Minute)))
+
+		res, err := s.Scan(scanners.Input{Body: strings.NewReader(eicar)})
+		require.NoError(t, err)
+		require.True(t, res.Infected)
+		require.Equal(t, "blocklisted md5 hash "+eicarMD5, res.Description)
+	})
+
+	t.Run("fails on invalid hashes", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(file, []byte("not-a-hash\n"), 0600))
+		require.NoError(t, os.Chtimes(file, time.Now(), time.Now().Add(2*time.Minute)))
+
+		_, err := s.Scan(scanners.Input{Body: strings.NewReader(eicar)})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,90 @@
+package scanners
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Chainable is the interface a scanner has to fulfill to be part of a Chain
+type Chainable interface {
+	Scan(in Input) (Result, error)
+}
+
+// NewChain returns a Scanner running the given scanners in order
+func NewChain(scanners ...Chainable) Chain {
+	return Chain{scanners: scanners}
+}
+
+// Chain is a Scanner merging the results of multiple scanners
+type Chain struct {
+	scanners []Chainable
+}
+
+// Scan to fulfill Scanner interface.
+// Unless it is seekable, the body is buffered to a temporary file so every scanner can read it from the start.
+// A file is infected if any of the scanners says so, the descriptions of all findings are joined.
+func (s Chain) Scan(in Input) (Result, error) {
+	f, ok := in.Body.(io.ReadSeeker)
+	if !ok {
+		tmp, err := os.CreateTemp("", "antivirus-chain-")
+		if err != nil {
+			return Result{}, err
+		}
+		defer func() {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}()
+
+		if _, err := io.Copy(tmp, in.Body); err != nil {
+			return Result{}, err
+		}
+		f = tmp
+	}
+
+	var (
+		result       Result
+		descriptions []string
+	)
+	for _, scanner := range s.scanners {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return result, err
+		}
+
+		res, err := scanner.Scan(Input{Body: f, Size: in.Size, Url: in.Url, Name: in.Name})
+		if err != nil {
+			return result, err
+		}
+
+		if res.ScanTime.After(result.ScanTime) {
+			result.ScanTime = res.ScanTime
+		}
+
+		if res.Infected {
+			result.Infected = true
+			descriptions = append(descriptions, res.Description)
+		}
+		result.Description = strings.Join(descriptions, ", ")
+	}
+
+	return result, nil
+}
+
+// Version to fulfill Versioned interface, it combines the versions of all scanners of the chain
+func (s Chain) Version() (string, error) {
+	versions := make([]string, 0, len(s.scanners))
+	for _, scanner := range s.scanners {
+		v, ok := scanner.(Versioned)
+		if !ok {
+			return "", fmt.Errorf("scanner %T does not provide a version", scanner)
+		}
+
+		version, err := v.Version()
+		if err != nil {
+			return "", err
+		}
+		versions = append(versions, version)
+	}
+	return strings.Join(versions, ";"), nil
+}
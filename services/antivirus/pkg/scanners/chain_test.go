@@ -0,0 +1,73 @@
+package scanners_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/scanners"
+)
+
+type scanFunc func(in scanners.Input) (scanners.Result, error)
+
+func (f scanFunc) Scan(in scanners.Input) (scanners.Result, error) { return f(in) }
+
+func finding(description string) scanFunc {
+	return func(in scanners.Input) (scanners.Result, error) {
+		b, err := io.ReadAll(in.Body)
+		if err != nil {
+			return scanners.Result{}, err
+		}
+		return scanners.Result{Infected: description != "" && string(b) == eicar, Description: description}, nil
+	}
+}
+
+func TestChain_Scan(t *testing.T) {
+	t.Run("every scanner reads the whole body", func(t *testing.T) {
+		res, err := scanners.NewChain(finding("first"), finding(""), finding("third")).Scan(scanners.Input{Body: strings.NewReader(eicar)})
+		require.NoError(t, err)
+		require.True(t, res.Infected)
+		require.Equal(t, "first, third", res.Description)
+	})
+
+	t.Run("clean", func(t *testing.T) {
+		res, err := scanners.NewChain(finding("first"), finding("second")).Scan(scanners.Input{Body: strings.NewReader("harmless")})
+		require.NoError(t, err)
+		require.False(t, res.Infected)
+		require.Empty(t, res.Description)
+	})
+
+	t.Run("errors abort the chain", func(t *testing.T) {
+		scanErr := errors.New("scanner unavailable")
+		_, err := scanners.NewChain(finding("first"), scanFunc(func(scanners.Input) (scanners.Result, error) {
+			return scanners.Result{}, scanErr
+		})).Scan(scanners.Input{Body: strings.NewReader(eicar)})
+		require.ErrorIs(t, err, scanErr)
+	})
+}
+
+func TestChain_Version(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "blocklist.txt")
+	require.NoError(t, os.WriteFile(file, []byte(eicarSHA256+"\n"), 0600))
+	blocklist, err := scanners.NewBlocklist(file)
+	require.NoError(t, err)
+	yara := newYARA(t, `rule A { condition: false }`)
+
+	v1, err := scanners.NewChain(blocklist, yara).Version()
+	require.NoError(t, err)
+	require.Contains(t, v1, "blocklist-")
+	require.Contains(t, v1, "yara-")
+
+	require.NoError(t, os.WriteFile(file, []byte(eicarMD5+"\n"), 0600))
+	v2, err := scanners.NewChain(blocklist, yara).Version()
+	require.NoError(t, err)
+	require.NotEqual(t, v1, v2)
+
+	_, err = scanners.NewChain(blocklist, finding("")).Version()
+	require.Error(t, err)
+}
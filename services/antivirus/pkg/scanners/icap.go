@@ -2,11 +2,14 @@ package scanners
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
 	"regexp"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/cs3org/reva/v2/pkg/mime"
@@ -36,13 +39,66 @@ func NewICAP(icapURL string, icapService string, timeout time.Duration) (ICAP, e
 		return ICAP{}, err
 	}
 
-	return ICAP{Client: &client, URL: endpoint.String()}, nil
+	return ICAP{Client: &client, URL: endpoint.String(), options: &icapOptions{}}, nil
 }
 
 // ICAP is responsible for scanning files using an ICAP server
 type ICAP struct {
 	Client Scanner
 	URL    string
+
+	// caches the OPTIONS response, it is requested for every scan if nil
+	options *icapOptions
+}
+
+// defaultOptionsTTL is used when the ICAP server does not send an Options-TTL header
+const defaultOptionsTTL = time.Minute
+
+// icapOptions is the cached OPTIONS response of the ICAP server
+type icapOptions struct {
+	mu           sync.Mutex
+	previewBytes int
+	tag          string
+	expires      time.Time
+}
+
+// getOptions returns the preview size and the ISTag of the ICAP service
+func (s ICAP) getOptions(ctx context.Context) (int, string, error) {
+	if s.options != nil {
+		s.options.mu.Lock()
+		defer s.options.mu.Unlock()
+		if time.Now().Before(s.options.expires) {
+			return s.options.previewBytes, s.options.tag, nil
+		}
+	}
+
+	req, err := ic.NewRequest(ctx, ic.MethodOPTIONS, s.URL, nil, nil)
+	if err != nil {
+		return 0, "", err
+	}
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	closeBody(res)
+
+	tag := res.Header.Get("ISTag")
+	if s.options != nil {
+		ttl := defaultOptionsTTL
+		if v, err := strconv.Atoi(res.Header.Get("Options-TTL")); err == nil && v >= 0 {
+			ttl = time.Duration(v) * time.Second
+		}
+		s.options.previewBytes, s.options.tag, s.options.expires = res.PreviewBytes, tag, time.Now().Add(ttl)
+	}
+	return res.PreviewBytes, tag, nil
+}
+
+// closeBody closes the body of the encapsulated http response
+func closeBody(res ic.Response) {
+	if res.ContentResponse != nil && res.ContentResponse.Body != nil {
+		_ = res.ContentResponse.Body.Close()
+	}
 }
 
 // Scan scans a file using the ICAP server
@@ -50,12 +106,7 @@ func (s ICAP) Scan(in Input) (Result, error) {
 	ctx := context.TODO()
 	result := Result{}
 
-	optReq, err := ic.NewRequest(ctx, ic.MethodOPTIONS, s.URL, nil, nil)
-	if err != nil {
-		return result, err
-	}
-
-	optRes, err := s.Client.Do(optReq)
+	previewBytes, _, err := s.getOptions(ctx)
 	if err != nil {
 		return result, err
 	}
@@ -75,8 +126,8 @@ func (s ICAP) Scan(in Input) (Result, error) {
 		return result, err
 	}
 
-	if optRes.PreviewBytes > 0 {
-		err = req.SetPreview(optRes.PreviewBytes)
+	if previewBytes > 0 {
+		err = req.SetPreview(previewBytes)
 		if err != nil {
 			return result, err
 		}
@@ -86,6 +137,7 @@ func (s ICAP) Scan(in Input) (Result, error) {
 	if err != nil {
 		return result, err
 	}
+	defer closeBody(res)
 	result.ScanTime = time.Now()
 
 	// TODO: make header configurable. See oc10 documentation: https://doc.owncloud.com/server/10.12/admin_manual/configuration/server/virus-scanner-support.html
@@ -110,3 +162,17 @@ func (s ICAP) Scan(in Input) (Result, error) {
 
 	return result, nil
 }
+
+// Version to fulfill Versioned interface, it returns the ISTag of the ICAP service
+// which changes when the service, e.g. its signatures, changes
+func (s ICAP) Version() (string, error) {
+	_, tag, err := s.getOptions(context.TODO())
+	if err != nil {
+		return "", err
+	}
+
+	if tag == "" {
+		return "", errors.New("no ISTag received from icap server")
+	}
+	return tag, nil
+}
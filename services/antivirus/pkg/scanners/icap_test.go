@@ -3,8 +3,11 @@ package scanners_test
 import (
 	"bytes"
 	"errors"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -184,3 +187,59 @@ func TestICAP_Scan(t *testing.T) {
 		})
 	})
 }
+
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestICAP_Version(t *testing.T) {
+	newScanner := func(t *testing.T) (scanners.ICAP, *mocks.Scanner) {
+		s, err := scanners.NewICAP("icap://localhost:1344", "avscan", time.Second)
+		assert.NoError(t, err)
+		client := mocks.NewScanner(t)
+		s.Client = client
+		return s, client
+	}
+
+	t.Run("it caches the OPTIONS response and closes its body", func(t *testing.T) {
+		s, client := newScanner(t)
+		body := &closeTracker{Reader: strings.NewReader("")}
+		client.EXPECT().Do(mock.Anything).Return(ic.Response{
+			Header:          http.Header{"Istag": []string{"tag-1"}},
+			ContentResponse: &http.Response{Body: body},
+		}, nil).Once()
+
+		for i := 0; i < 2; i++ {
+			v, err := s.Version()
+			assert.NoError(t, err)
+			assert.Equal(t, "tag-1", v)
+		}
+		assert.True(t, body.closed)
+	})
+
+	t.Run("it honors the Options-TTL header", func(t *testing.T) {
+		s, client := newScanner(t)
+		client.EXPECT().Do(mock.Anything).Return(ic.Response{
+			Header: http.Header{"Istag": []string{"tag-1"}, "Options-Ttl": []string{"0"}},
+		}, nil).Twice()
+
+		for i := 0; i < 2; i++ {
+			_, err := s.Version()
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("it fails without ISTag", func(t *testing.T) {
+		s, client := newScanner(t)
+		client.EXPECT().Do(mock.Anything).Return(ic.Response{}, nil).Once()
+
+		_, err := s.Version()
+		assert.Error(t, err)
+	})
+}
@@ -12,6 +12,12 @@ type Result struct {
 	Description string
 }
 
+// Versioned is implemented by scanners which can report the version of their signatures.
+// The version changes whenever the scanner might judge a file differently.
+type Versioned interface {
+	Version() (string, error)
+}
+
 // The Input is the common input to all scanners
 type Input struct {
 	Body io.Reader
@@ -0,0 +1,80 @@
+package scanners
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// _yaraDefaultMaxScanSize limits the bytes held in memory during a scan if no max scan size is configured
+const _yaraDefaultMaxScanSize = 64 << 20
+
+// NewYARA returns a Scanner matching files against the YARA rules (*.yar, *.yara) found in the given directory.
+// Only the first maxScanSize bytes of a file are scanned, 0 means _yaraDefaultMaxScanSize.
+func NewYARA(dir string, maxScanSize uint64) (*YARA, error) {
+	rs := &yaraRuleset{names: make(map[string]*yaraRule)}
+	h := sha256.New()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || (filepath.Ext(path) != ".yar" && filepath.Ext(path) != ".yara") {
+			return nil
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		_, _ = h.Write(src)
+		return rs.parse(path, src)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rs.rules) == 0 {
+		return nil, errors.New("no yara rules found in " + dir)
+	}
+
+	if maxScanSize == 0 {
+		maxScanSize = _yaraDefaultMaxScanSize
+	}
+
+	return &YARA{rules: rs, version: "yara-" + hex.EncodeToString(h.Sum(nil)), maxScanSize: maxScanSize}, nil
+}
+
+// YARA is a Scanner based on YARA rules
+type YARA struct {
+	rules       *yaraRuleset
+	version     string
+	maxScanSize uint64
+}
+
+// Scan to fulfill Scanner interface. The file is held in memory during the scan.
+func (s *YARA) Scan(in Input) (Result, error) {
+	data, err := io.ReadAll(io.LimitReader(in.Body, int64(s.maxScanSize)))
+	if err != nil {
+		return Result{}, err
+	}
+
+	matched := s.rules.match(data)
+	return Result{
+		Infected:    len(matched) > 0,
+		Description: strings.Join(matched, ", "),
+		ScanTime:    time.Now(),
+	}, nil
+}
+
+// Version to fulfill Versioned interface, it is derived from the content of the rules
+func (s *YARA) Version() (string, error) {
+	return s.version, nil
+}
@@ -0,0 +1,1105 @@
+package scanners
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// yaraRuleset is a set of compiled YARA rules.
+// Only a subset of the YARA language is supported: text, hex and regular expression strings,
+// boolean conditions, string counts, `filesize` and references to previously defined rules.
+// Everything else, e.g. modules (`import`), `include`, string offsets or `for` loops, is rejected when loading the rules.
+type yaraRuleset struct {
+	rules []*yaraRule
+	names map[string]*yaraRule
+}
+
+// yaraUnsupported are the keywords of the YARA language which are not part of the supported subset.
+// They are rejected when loading the rules, instead of being mistaken for rule references.
+var yaraUnsupported = map[string]bool{
+	"at": true, "in": true, "for": true, "of": true, "them": true, "entrypoint": true, "defined": true,
+	"contains": true, "icontains": true, "startswith": true, "istartswith": true, "endswith": true,
+	"iendswith": true, "iequals": true, "matches": true,
+	"int8": true, "int16": true, "int32": true, "int8be": true, "int16be": true, "int32be": true,
+	"uint8": true, "uint16": true, "uint32": true, "uint8be": true, "uint16be": true, "uint32be": true,
+}
+
+type yaraRule struct {
+	name    string
+	private bool
+	strings []*yaraString
+	cond    yaraExpr
+}
+
+type yaraString struct {
+	id string
+	m  yaraMatcher
+}
+
+// yaraScan holds the state of a single scan
+type yaraScan struct {
+	data   []byte
+	lower  []byte
+	counts map[*yaraString]int
+	rules  map[*yaraRule]bool
+}
+
+func newYaraScan(data []byte) *yaraScan {
+	return &yaraScan{
+		data:   data,
+		counts: make(map[*yaraString]int),
+		rules:  make(map[*yaraRule]bool),
+	}
+}
+
+func (s *yaraScan) lowered() []byte {
+	if s.lower == nil {
+		s.lower = asciiLower(s.data)
+	}
+	return s.lower
+}
+
+func (s *yaraScan) count(str *yaraString) int {
+	c, ok := s.counts[str]
+	if !ok {
+		c = str.m.count(s)
+		s.counts[str] = c
+	}
+	return c
+}
+
+func (s *yaraScan) matches(r *yaraRule) bool {
+	m, ok := s.rules[r]
+	if !ok {
+		m = r.cond.eval(s)
+		s.rules[r] = m
+	}
+	return m
+}
+
+// match returns the names of all public rules matching the data
+func (rs *yaraRuleset) match(data []byte) []string {
+	s := newYaraScan(data)
+
+	var matched []string
+	for _, r := range rs.rules {
+		if !r.private && s.matches(r) {
+			matched = append(matched, r.name)
+		}
+	}
+	return matched
+}
+
+// yaraMatcher counts the occurrences of a YARA string
+type yaraMatcher interface {
+	count(s *yaraScan) int
+}
+
+// literalMatcher matches text strings
+type literalMatcher struct {
+	patterns [][]byte
+	nocase   bool
+	fullword bool
+}
+
+func (m literalMatcher) count(s *yaraScan) int {
+	data := s.data
+	if m.nocase {
+		data = s.lowered()
+	}
+
+	c := 0
+	for _, p := range m.patterns {
+		for off := 0; off <= len(data)-len(p); {
+			i := bytes.Index(data[off:], p)
+			if i < 0 {
+				break
+			}
+			i += off
+			if !m.fullword || (!isWordByte(data, i-1) && !isWordByte(data, i+len(p))) {
+				c++
+			}
+			off = i + 1
+		}
+	}
+	return c
+}
+
+// asciiLower lowers ASCII letters only, other bytes are kept as they are
+func asciiLower(b []byte) []byte {
+	l := make([]byte, len(b))
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		l[i] = c
+	}
+	return l
+}
+
+func isWordByte(data []byte, i int) bool {
+	if i < 0 || i >= len(data) {
+		return false
+	}
+	b := data[i]
+	return b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+type hexTokenKind int
+
+const (
+	hexByte hexTokenKind = iota
+	hexJump
+	hexAlt
+)
+
+type hexToken struct {
+	kind hexTokenKind
+
+	value, mask byte // hexByte
+
+	min, max int // hexJump, max < 0 means unbounded
+
+	alts [][]hexToken // hexAlt
+}
+
+// hexMatcher matches hex strings
+type hexMatcher struct {
+	tokens []hexToken
+}
+
+func (m hexMatcher) count(s *yaraScan) int {
+	return len(hexPositions(s.data, m.tokens))
+}
+
+// hexPositions returns the ascending positions at which the tokens match. The string is split at its jumps
+// and the segments are resolved from the last to the first one, so every position is visited once per
+// segment instead of backtracking over all jump lengths.
+func hexPositions(data []byte, tokens []hexToken) []int {
+	var segments [][]hexToken
+	var jumps []hexToken
+	start := 0
+	for i, t := range tokens {
+		if t.kind == hexJump {
+			segments = append(segments, tokens[start:i])
+			jumps = append(jumps, t)
+			start = i + 1
+		}
+	}
+	segments = append(segments, tokens[start:])
+
+	// next holds the positions at which the segments following the current one match
+	var next []int
+	for k := len(segments) - 1; k >= 0; k-- {
+		var cur []int
+		segment := segments[k]
+		for pos := 0; pos < len(data); pos++ {
+			if len(segment) > 0 && segment[0].kind == hexByte && segment[0].mask == 0xff {
+				i := bytes.IndexByte(data[pos:], segment[0].value)
+				if i < 0 {
+					break
+				}
+				pos += i
+			}
+
+			matched := hexEnds(data, pos, segment, func(end int) bool {
+				if k == len(segments)-1 {
+					return true
+				}
+				lo, hi := end+jumps[k].min, len(data)
+				if jumps[k].max >= 0 && end+jumps[k].max < hi {
+					hi = end + jumps[k].max
+				}
+				i := sort.SearchInts(next, lo)
+				return i < len(next) && next[i] <= hi
+			})
+			if matched {
+				cur = append(cur, pos)
+			}
+		}
+		next = cur
+	}
+	return next
+}
+
+// hexEnds calls fn with the end position of every match of the jump free tokens at pos until fn returns true
+func hexEnds(data []byte, pos int, tokens []hexToken, fn func(end int) bool) bool {
+	for i, t := range tokens {
+		switch t.kind {
+		case hexByte:
+			if pos >= len(data) || data[pos]&t.mask != t.value {
+				return false
+			}
+			pos++
+		case hexAlt:
+			rest := tokens[i+1:]
+			for _, alt := range t.alts {
+				if hexEnds(data, pos, alt, func(end int) bool { return hexEnds(data, end, rest, fn) }) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return fn(pos)
+}
+
+// regexpMatcher matches regular expression strings.
+// Note that the expressions are evaluated by the go regexp package which works on UTF-8.
+type regexpMatcher struct {
+	re *regexp.Regexp
+}
+
+func (m regexpMatcher) count(s *yaraScan) int {
+	return len(m.re.FindAllIndex(s.data, -1))
+}
+
+// yaraExpr is a boolean expression of a rule condition
+type yaraExpr interface {
+	eval(s *yaraScan) bool
+}
+
+// yaraNum is a numeric expression of a rule condition
+type yaraNum interface {
+	value(s *yaraScan) int64
+}
+
+type boolExpr bool
+
+func (e boolExpr) eval(_ *yaraScan) bool { return bool(e) }
+
+type andExpr []yaraExpr
+
+func (e andExpr) eval(s *yaraScan) bool {
+	for _, x := range e {
+		if !x.eval(s) {
+			return false
+		}
+	}
+	return true
+}
+
+type orExpr []yaraExpr
+
+func (e orExpr) eval(s *yaraScan) bool {
+	for _, x := range e {
+		if x.eval(s) {
+			return true
+		}
+	}
+	return false
+}
+
+type notExpr struct{ x yaraExpr }
+
+func (e notExpr) eval(s *yaraScan) bool { return !e.x.eval(s) }
+
+type stringExpr struct{ str *yaraString }
+
+func (e stringExpr) eval(s *yaraScan) bool { return s.count(e.str) > 0 }
+
+type ruleExpr struct{ rule *yaraRule }
+
+func (e ruleExpr) eval(s *yaraScan) bool { return s.matches(e.rule) }
+
+// ofExpr implements `<quantifier> of <set>`, a quantifier < 0 means all
+type ofExpr struct {
+	quantifier int
+	set        []*yaraString
+}
+
+func (e ofExpr) eval(s *yaraScan) bool {
+	want := e.quantifier
+	if want < 0 {
+		want = len(e.set)
+	}
+
+	n := 0
+	for _, str := range e.set {
+		if s.count(str) > 0 {
+			n++
+		}
+	}
+
+	if want == 0 {
+		return n == 0 // none of
+	}
+	return n >= want
+}
+
+type compareExpr struct {
+	op   string
+	l, r yaraNum
+}
+
+func (e compareExpr) eval(s *yaraScan) bool {
+	l, r := e.l.value(s), e.r.value(s)
+	switch e.op {
+	case "==":
+		return l == r
+	case "!=":
+		return l != r
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	case ">=":
+		return l >= r
+	}
+	return false
+}
+
+type intNum int64
+
+func (n intNum) value(_ *yaraScan) int64 { return int64(n) }
+
+type countNum struct{ str *yaraString }
+
+func (n countNum) value(s *yaraScan) int64 { return int64(s.count(n.str)) }
+
+type filesizeNum struct{}
+
+func (filesizeNum) value(s *yaraScan) int64 { return int64(len(s.data)) }
+
+// parse parses the rules of the given source and adds them to the ruleset
+func (rs *yaraRuleset) parse(name string, src []byte) error {
+	p := &yaraParser{file: name, src: src, rs: rs}
+	return p.parse()
+}
+
+type yaraParser struct {
+	file string
+	src  []byte
+	pos  int
+	rs   *yaraRuleset
+	rule *yaraRule
+}
+
+func (p *yaraParser) errorf(format string, args ...interface{}) error {
+	line := bytes.Count(p.src[:p.pos], []byte("\n")) + 1
+	return fmt.Errorf("%s:%d: %s", p.file, line, fmt.Sprintf(format, args...))
+}
+
+// skip skips whitespace and comments
+func (p *yaraParser) skip() {
+	for p.pos < len(p.src) {
+		switch {
+		case p.src[p.pos] == ' ', p.src[p.pos] == '\t', p.src[p.pos] == '\n', p.src[p.pos] == '\r':
+			p.pos++
+		case bytes.HasPrefix(p.src[p.pos:], []byte("//")):
+			i := bytes.IndexByte(p.src[p.pos:], '\n')
+			if i < 0 {
+				p.pos = len(p.src)
+				return
+			}
+			p.pos += i
+		case bytes.HasPrefix(p.src[p.pos:], []byte("/*")):
+			i := bytes.Index(p.src[p.pos+2:], []byte("*/"))
+			if i < 0 {
+				p.pos = len(p.src)
+				return
+			}
+			p.pos += i + 4
+		default:
+			return
+		}
+	}
+}
+
+func (p *yaraParser) peek() byte {
+	p.skip()
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *yaraParser) expect(c byte) error {
+	if p.peek() != c {
+		return p.errorf("expected '%c'", c)
+	}
+	p.pos++
+	return nil
+}
+
+// word reads an identifier or keyword
+func (p *yaraParser) word() string {
+	p.skip()
+	start := p.pos
+	for p.pos < len(p.src) && isWordByte(p.src, p.pos) {
+		p.pos++
+	}
+	return string(p.src[start:p.pos])
+}
+
+// peekWord returns the next identifier or keyword without consuming it
+func (p *yaraParser) peekWord() string {
+	pos := p.pos
+	w := p.word()
+	p.pos = pos
+	return w
+}
+
+func (p *yaraParser) parse() error {
+	for p.peek() != 0 {
+		switch w := p.word(); w {
+		case "import", "include":
+			return p.errorf("'%s' is not supported", w)
+		case "private", "global", "rule":
+			if err := p.parseRule(w); err != nil {
+				return err
+			}
+		default:
+			return p.errorf("unexpected '%s'", w)
+		}
+	}
+	return nil
+}
+
+func (p *yaraParser) parseRule(w string) error {
+	r := &yaraRule{}
+	for ; w != "rule"; w = p.word() {
+		switch w {
+		case "private":
+			r.private = true
+		case "global":
+			return p.errorf("global rules are not supported")
+		default:
+			return p.errorf("expected 'rule'")
+		}
+	}
+
+	r.name = p.word()
+	if r.name == "" {
+		return p.errorf("missing rule name")
+	}
+	if _, ok := p.rs.names[r.name]; ok {
+		return p.errorf("duplicate rule '%s'", r.name)
+	}
+
+	if p.peek() == ':' {
+		p.pos++
+		for p.peek() != '{' && p.peek() != 0 {
+			if p.word() == "" {
+				return p.errorf("invalid tag")
+			}
+		}
+	}
+
+	if err := p.expect('{'); err != nil {
+		return err
+	}
+
+	p.rule = r
+	for {
+		section := p.word()
+		if err := p.expect(':'); err != nil {
+			return err
+		}
+
+		var err error
+		switch section {
+		case "meta":
+			err = p.parseMeta()
+		case "strings":
+			err = p.parseStrings()
+		case "condition":
+			r.cond, err = p.parseOr()
+			if w := p.peekWord(); err == nil && yaraUnsupported[w] {
+				err = p.errorf("'%s' is not supported", w)
+			}
+		default:
+			err = p.errorf("unexpected section '%s'", section)
+		}
+		if err != nil {
+			return err
+		}
+
+		if section == "condition" {
+			break
+		}
+	}
+
+	if err := p.expect('}'); err != nil {
+		return err
+	}
+
+	p.rs.rules = append(p.rs.rules, r)
+	p.rs.names[r.name] = r
+	return nil
+}
+
+func (p *yaraParser) parseMeta() error {
+	for {
+		if w := p.peekWord(); w == "strings" || w == "condition" || w == "" {
+			return nil
+		}
+
+		p.word()
+		if err := p.expect('='); err != nil {
+			return err
+		}
+
+		switch c := p.peek(); {
+		case c == '"':
+			if _, err := p.text(); err != nil {
+				return err
+			}
+		case c == '-' || (c >= '0' && c <= '9'):
+			p.pos++
+			p.word()
+		default:
+			if w := p.word(); w != "true" && w != "false" {
+				return p.errorf("invalid meta value")
+			}
+		}
+	}
+}
+
+func (p *yaraParser) parseStrings() error {
+	for p.peek() == '$' {
+		p.pos++
+		id := p.word()
+		if id == "" {
+			return p.errorf("anonymous strings are not supported")
+		}
+		for _, s := range p.rule.strings {
+			if s.id == id {
+				return p.errorf("duplicate string '$%s'", id)
+			}
+		}
+
+		if err := p.expect('='); err != nil {
+			return err
+		}
+
+		var (
+			m   yaraMatcher
+			err error
+		)
+		switch p.peek() {
+		case '"':
+			m, err = p.literal()
+		case '{':
+			m, err = p.hex()
+		case '/':
+			m, err = p.regex()
+		default:
+			err = p.errorf("invalid string '$%s'", id)
+		}
+		if err != nil {
+			return err
+		}
+
+		p.rule.strings = append(p.rule.strings, &yaraString{id: id, m: m})
+	}
+	return nil
+}
+
+// text reads a quoted text string
+func (p *yaraParser) text() ([]byte, error) {
+	if err := p.expect('"'); err != nil {
+		return nil, err
+	}
+
+	var b []byte
+	for {
+		if p.pos >= len(p.src) || p.src[p.pos] == '\n' {
+			return nil, p.errorf("unterminated string")
+		}
+
+		c := p.src[p.pos]
+		p.pos++
+		switch c {
+		case '"':
+			return b, nil
+		case '\\':
+			if p.pos >= len(p.src) {
+				return nil, p.errorf("unterminated string")
+			}
+			e := p.src[p.pos]
+			p.pos++
+			switch e {
+			case 'n':
+				b = append(b, '\n')
+			case 't':
+				b = append(b, '\t')
+			case 'r':
+				b = append(b, '\r')
+			case '"', '\\':
+				b = append(b, e)
+			case 'x':
+				if p.pos+2 > len(p.src) {
+					return nil, p.errorf("invalid escape sequence")
+				}
+				v, err := strconv.ParseUint(string(p.src[p.pos:p.pos+2]), 16, 8)
+				if err != nil {
+					return nil, p.errorf("invalid escape sequence")
+				}
+				b = append(b, byte(v))
+				p.pos += 2
+			default:
+				return nil, p.errorf("invalid escape sequence '\\%c'", e)
+			}
+		default:
+			b = append(b, c)
+		}
+	}
+}
+
+func (p *yaraParser) literal() (yaraMatcher, error) {
+	t, err := p.text()
+	if err != nil {
+		return nil, err
+	}
+	if len(t) == 0 {
+		return nil, p.errorf("empty string")
+	}
+
+	m := literalMatcher{}
+	var ascii, wide bool
+	for {
+		switch w := p.peekWord(); w {
+		case "nocase":
+			m.nocase = true
+		case "fullword":
+			m.fullword = true
+		case "ascii":
+			ascii = true
+		case "wide":
+			wide = true
+		case "private":
+		case "", "condition":
+			if m.nocase {
+				t = asciiLower(t)
+			}
+			if ascii || !wide {
+				m.patterns = append(m.patterns, t)
+			}
+			if wide {
+				w := make([]byte, 0, 2*len(t))
+				for _, c := range t {
+					w = append(w, c, 0)
+				}
+				m.patterns = append(m.patterns, w)
+			}
+			return m, nil
+		default:
+			return nil, p.errorf("string modifier '%s' is not supported", w)
+		}
+		p.word()
+	}
+}
+
+func (p *yaraParser) hex() (yaraMatcher, error) {
+	if err := p.expect('{'); err != nil {
+		return nil, err
+	}
+
+	tokens, err := p.hexTokens('}')
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 || tokens[0].kind == hexJump || tokens[len(tokens)-1].kind == hexJump {
+		return nil, p.errorf("hex strings must start and end with a byte")
+	}
+	return hexMatcher{tokens: tokens}, nil
+}
+
+// hexTokens reads hex string tokens until one of the given terminators, which is consumed
+func (p *yaraParser) hexTokens(terminators ...byte) ([]hexToken, error) {
+	var tokens []hexToken
+	for {
+		c := p.peek()
+		if bytes.IndexByte(terminators, c) >= 0 {
+			p.pos++
+			return tokens, nil
+		}
+
+		switch {
+		case c == 0:
+			return nil, p.errorf("unterminated hex string")
+		case c == '[':
+			p.pos++
+			t, err := p.hexJump()
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, t)
+		case c == '(':
+			p.pos++
+			t := hexToken{kind: hexAlt}
+			for {
+				alt, err := p.hexTokens('|', ')')
+				if err != nil {
+					return nil, err
+				}
+				for _, a := range alt {
+					if a.kind == hexJump {
+						return nil, p.errorf("jumps in alternatives are not supported")
+					}
+				}
+				t.alts = append(t.alts, alt)
+				if p.src[p.pos-1] == ')' {
+					break
+				}
+			}
+			tokens = append(tokens, t)
+		default:
+			if p.pos+2 > len(p.src) {
+				return nil, p.errorf("unterminated hex string")
+			}
+			t, err := hexNibbles(p.src[p.pos], p.src[p.pos+1])
+			if err != nil {
+				return nil, p.errorf("%s", err)
+			}
+			p.pos += 2
+			tokens = append(tokens, t)
+		}
+	}
+}
+
+func (p *yaraParser) hexJump() (hexToken, error) {
+	t := hexToken{kind: hexJump, max: -1}
+
+	bounds := p.src[p.pos:]
+	end := bytes.IndexByte(bounds, ']')
+	if end < 0 {
+		return t, p.errorf("unterminated jump")
+	}
+	bounds = bounds[:end]
+	p.pos += end + 1
+
+	min, max, isRange := strings.Cut(string(bounds), "-")
+	var err error
+	if min = strings.TrimSpace(min); min != "" {
+		if t.min, err = strconv.Atoi(min); err != nil {
+			return t, p.errorf("invalid jump")
+		}
+	}
+
+	switch max = strings.TrimSpace(max); {
+	case !isRange:
+		if min == "" {
+			return t, p.errorf("invalid jump")
+		}
+		t.max = t.min
+	case max != "":
+		if t.max, err = strconv.Atoi(max); err != nil || t.max < t.min {
+			return t, p.errorf("invalid jump")
+		}
+	}
+	return t, nil
+}
+
+func hexNibbles(hi, lo byte) (hexToken, error) {
+	t := hexToken{kind: hexByte}
+	for i, n := range []byte{hi, lo} {
+		shift := 4 * (1 - i)
+		if n == '?' {
+			continue
+		}
+		v, err := strconv.ParseUint(string(n), 16, 8)
+		if err != nil {
+			return t, fmt.Errorf("invalid hex byte '%c%c'", hi, lo)
+		}
+		t.value |= byte(v) << shift
+		t.mask |= 0xf << shift
+	}
+	return t, nil
+}
+
+func (p *yaraParser) regex() (yaraMatcher, error) {
+	p.pos++ // opening slash
+
+	var expr []byte
+	for {
+		if p.pos >= len(p.src) || p.src[p.pos] == '\n' {
+			return nil, p.errorf("unterminated regular expression")
+		}
+		c := p.src[p.pos]
+		p.pos++
+		if c == '/' {
+			break
+		}
+		if c == '\\' && p.pos < len(p.src) && p.src[p.pos] == '/' {
+			c = '/'
+			p.pos++
+		}
+		expr = append(expr, c)
+	}
+
+	var flags string
+	for p.pos < len(p.src) && (p.src[p.pos] == 'i' || p.src[p.pos] == 's') {
+		flags += string(p.src[p.pos])
+		p.pos++
+	}
+	if flags != "" {
+		expr = append([]byte("(?"+flags+")"), expr...)
+	}
+
+	for w := p.peekWord(); w != "" && w != "condition"; w = p.peekWord() {
+		if w != "ascii" && w != "private" {
+			return nil, p.errorf("string modifier '%s' is not supported", w)
+		}
+		p.word()
+	}
+
+	re, err := regexp.Compile(string(expr))
+	if err != nil {
+		return nil, p.errorf("invalid regular expression: %s", err)
+	}
+	return regexpMatcher{re: re}, nil
+}
+
+func (p *yaraParser) parseOr() (yaraExpr, error) {
+	x, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	or := orExpr{x}
+	for p.peekWord() == "or" {
+		p.word()
+		x, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, x)
+	}
+
+	if len(or) == 1 {
+		return x, nil
+	}
+	return or, nil
+}
+
+func (p *yaraParser) parseAnd() (yaraExpr, error) {
+	x, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	and := andExpr{x}
+	for p.peekWord() == "and" {
+		p.word()
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, x)
+	}
+
+	if len(and) == 1 {
+		return x, nil
+	}
+	return and, nil
+}
+
+func (p *yaraParser) parseNot() (yaraExpr, error) {
+	if p.peekWord() == "not" {
+		p.word()
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *yaraParser) parsePrimary() (yaraExpr, error) {
+	switch c := p.peek(); {
+	case c == '(':
+		p.pos++
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return x, p.expect(')')
+	case c == '$':
+		p.pos++
+		str, err := p.stringRef(p.word())
+		if err != nil {
+			return nil, err
+		}
+		if w := p.peekWord(); w == "at" || w == "in" {
+			return nil, p.errorf("'%s' is not supported", w)
+		}
+		return stringExpr{str}, nil
+	case c == '@' || c == '!':
+		return nil, p.errorf("string offsets and lengths are not supported")
+	case c == '#' || (c >= '0' && c <= '9'):
+		pos := p.pos
+		if c != '#' {
+			if n, err := p.integer(); err == nil && p.peekWord() == "of" {
+				p.word()
+				return p.parseOf(int(n))
+			}
+			p.pos = pos
+		}
+		return p.parseComparison()
+	}
+
+	switch w := p.peekWord(); w {
+	case "true", "false":
+		p.word()
+		return boolExpr(w == "true"), nil
+	case "any", "all", "none":
+		p.word()
+		if p.word() != "of" {
+			return nil, p.errorf("expected 'of'")
+		}
+		return p.parseOf(map[string]int{"any": 1, "all": -1, "none": 0}[w])
+	case "filesize":
+		return p.parseComparison()
+	case "":
+		return nil, p.errorf("invalid condition")
+	default:
+		if yaraUnsupported[w] {
+			return nil, p.errorf("'%s' is not supported", w)
+		}
+		p.word()
+		r, ok := p.rs.names[w]
+		if !ok {
+			return nil, p.errorf("undefined identifier '%s'", w)
+		}
+		return ruleExpr{r}, nil
+	}
+}
+
+func (p *yaraParser) parseOf(quantifier int) (yaraExpr, error) {
+	if p.peekWord() == "them" {
+		p.word()
+		if len(p.rule.strings) == 0 {
+			return nil, p.errorf("rule has no strings")
+		}
+		return ofExpr{quantifier: quantifier, set: p.rule.strings}, nil
+	}
+
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	e := ofExpr{quantifier: quantifier}
+	for {
+		if err := p.expect('$'); err != nil {
+			return nil, err
+		}
+		id := p.word()
+		if p.pos < len(p.src) && p.src[p.pos] == '*' {
+			p.pos++
+			n := len(e.set)
+			for _, s := range p.rule.strings {
+				if strings.HasPrefix(s.id, id) {
+					e.set = append(e.set, s)
+				}
+			}
+			if len(e.set) == n {
+				return nil, p.errorf("no strings match '$%s*'", id)
+			}
+		} else {
+			str, err := p.stringRef(id)
+			if err != nil {
+				return nil, err
+			}
+			e.set = append(e.set, str)
+		}
+
+		if p.peek() == ')' {
+			p.pos++
+			break
+		}
+		if err := p.expect(','); err != nil {
+			return nil, err
+		}
+	}
+
+	if quantifier > len(e.set) {
+		return nil, p.errorf("quantifier exceeds the number of strings")
+	}
+	return e, nil
+}
+
+func (p *yaraParser) parseComparison() (yaraExpr, error) {
+	l, err := p.parseNum()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skip()
+	var op string
+	for _, o := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if bytes.HasPrefix(p.src[p.pos:], []byte(o)) {
+			op = o
+			break
+		}
+	}
+	if op == "" {
+		return nil, p.errorf("expected comparison operator")
+	}
+	p.pos += len(op)
+
+	r, err := p.parseNum()
+	if err != nil {
+		return nil, err
+	}
+	return compareExpr{op: op, l: l, r: r}, nil
+}
+
+func (p *yaraParser) parseNum() (yaraNum, error) {
+	switch c := p.peek(); {
+	case c == '#':
+		p.pos++
+		str, err := p.stringRef(p.word())
+		if err != nil {
+			return nil, err
+		}
+		return countNum{str}, nil
+	case c >= '0' && c <= '9':
+		n, err := p.integer()
+		if err != nil {
+			return nil, err
+		}
+		return intNum(n), nil
+	}
+
+	if p.word() == "filesize" {
+		return filesizeNum{}, nil
+	}
+	return nil, p.errorf("expected number")
+}
+
+// integer reads a decimal or hexadecimal integer with an optional KB or MB suffix
+func (p *yaraParser) integer() (int64, error) {
+	w := p.word()
+
+	mul := int64(1)
+	switch {
+	case strings.HasSuffix(w, "KB"):
+		mul, w = 1024, strings.TrimSuffix(w, "KB")
+	case strings.HasSuffix(w, "MB"):
+		mul, w = 1024*1024, strings.TrimSuffix(w, "MB")
+	}
+
+	n, err := strconv.ParseInt(w, 0, 64)
+	if err != nil {
+		return 0, p.errorf("invalid number '%s'", w)
+	}
+	return n * mul, nil
+}
+
+func (p *yaraParser) stringRef(id string) (*yaraString, error) {
+	for _, s := range p.rule.strings {
+		if s.id == id {
+			return s, nil
+		}
+	}
+	return nil, p.errorf("undefined string '$%s'", id)
+}
@@ -0,0 +1,211 @@
+package scanners_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/scanners"
+)
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+func newYARA(t *testing.T, rules string) *scanners.YARA {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "test.yar"), []byte(rules), 0600))
+
+	s, err := scanners.NewYARA(dir, 0)
+	require.NoError(t, err)
+	return s
+}
+
+func TestYARA_Scan(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    string
+		content  string
+		infected bool
+	}{
+		{
+			name: "text string",
+			rules: `rule Eicar {
+				meta:
+					description = "eicar test file"
+					score = 100
+				strings:
+					$a = "EICAR-STANDARD-ANTIVIRUS-TEST-FILE"
+				condition:
+					$a
+			}`,
+			content:  eicar,
+			infected: true,
+		},
+		{
+			name:     "text string not found",
+			rules:    `rule Eicar { strings: $a = "EICAR-STANDARD" condition: $a }`,
+			content:  "harmless",
+			infected: false,
+		},
+		{
+			name:     "nocase",
+			rules:    `rule Eicar { strings: $a = "eicar-standard" nocase condition: $a }`,
+			content:  eicar,
+			infected: true,
+		},
+		{
+			name:     "wide",
+			rules:    `rule Wide { strings: $a = "evil" wide condition: $a }`,
+			content:  "e\x00v\x00i\x00l\x00",
+			infected: true,
+		},
+		{
+			name:     "fullword",
+			rules:    `rule Word { strings: $a = "evil" fullword condition: $a }`,
+			content:  "medieval",
+			infected: false,
+		},
+		{
+			name:     "hex string with wildcards, jumps and alternatives",
+			rules:    `rule Hex { strings: $a = { 4D 5A ?? 0? [2-4] ( 01 | 02 ) FF } condition: $a }`,
+			content:  "MZ\x99\x03\xaa\xbb\xcc\x02\xff",
+			infected: true,
+		},
+		{
+			name:     "hex string mismatch",
+			rules:    `rule Hex { strings: $a = { 4D 5A [2] ( 01 | 02 ) } condition: $a }`,
+			content:  "MZ\x99\x03\xaa\x02",
+			infected: false,
+		},
+		{
+			name:     "regular expression",
+			rules:    `rule Re { strings: $a = /eval\(base64_decode\(/i condition: $a }`,
+			content:  "<?php EVAL(base64_decode('...'));",
+			infected: true,
+		},
+		{
+			name: "of them",
+			rules: `rule Of {
+				strings:
+					$a1 = "one"
+					$a2 = "two"
+					$b = "three"
+				condition:
+					all of ($a*) and not $b
+			}`,
+			content:  "one two",
+			infected: true,
+		},
+		{
+			name: "count and filesize",
+			rules: `rule Count {
+				strings: $a = "x"
+				condition: #a >= 3 and filesize < 1KB
+			}`,
+			content:  "xxx",
+			infected: true,
+		},
+		{
+			name: "private rule reference",
+			rules: `private rule IsScript { strings: $a = "#!" condition: $a }
+			rule Dropper { strings: $b = "curl" condition: IsScript and $b }`,
+			content:  "#!/bin/sh\ncurl evil",
+			infected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := newYARA(t, tt.rules).Scan(scanners.Input{Body: strings.NewReader(tt.content)})
+			require.NoError(t, err)
+			require.Equal(t, tt.infected, res.Infected)
+		})
+	}
+}
+
+func TestYARA_Description(t *testing.T) {
+	s := newYARA(t, `
+		rule A { strings: $a = "evil" condition: $a }
+		rule B { condition: filesize > 0 }
+		private rule C { condition: true }
+	`)
+
+	res, err := s.Scan(scanners.Input{Body: strings.NewReader("evil")})
+	require.NoError(t, err)
+	require.True(t, res.Infected)
+	require.Equal(t, "A, B", res.Description)
+}
+
+func TestYARA_MaxScanSize(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "test.yar"), []byte(`rule A { strings: $a = "evil" condition: $a }`), 0600))
+
+	s, err := scanners.NewYARA(dir, 8)
+	require.NoError(t, err)
+
+	res, err := s.Scan(scanners.Input{Body: strings.NewReader("12345678evil")})
+	require.NoError(t, err)
+	require.False(t, res.Infected)
+
+	res, err = s.Scan(scanners.Input{Body: strings.NewReader("1234evil")})
+	require.NoError(t, err)
+	require.True(t, res.Infected)
+}
+
+func TestYARA_UnboundedJumps(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "test.yar"), []byte(`
+rule Count { strings: $a = { 01 [-] 02 [1-] 03 } condition: #a == 2 }
+rule Missing { strings: $a = { 00 [-] 00 [-] 00 [-] 00 [-] 01 } condition: $a }
+`), 0600))
+
+	s, err := scanners.NewYARA(dir, 0)
+	require.NoError(t, err)
+
+	res, err := s.Scan(scanners.Input{Body: strings.NewReader("\x01\x01\x02\x03\x02\xff\x03")})
+	require.NoError(t, err)
+	require.True(t, res.Infected)
+	require.Equal(t, "Count", res.Description)
+
+	// backtracking over the jumps would not finish
+	res, err = s.Scan(scanners.Input{Body: bytes.NewReader(make([]byte, 1<<16))})
+	require.NoError(t, err)
+	require.False(t, res.Infected)
+}
+
+func TestNewYARA_Errors(t *testing.T) {
+	tests := map[string]string{
+		"imports":           `import "pe" rule A { condition: true }`,
+		"undefined string":  `rule A { condition: $a }`,
+		"undefined rule":    `rule A { condition: B }`,
+		"duplicate rule":    `rule A { condition: true } rule A { condition: true }`,
+		"unsupported mod":   `rule A { strings: $a = "x" xor condition: $a }`,
+		"invalid hex":       `rule A { strings: $a = { 4G } condition: $a }`,
+		"leading jump":      `rule A { strings: $a = { [2] 4D } condition: $a }`,
+		"missing condition": `rule A { strings: $a = "x" }`,
+		"offset":            `rule A { strings: $a = "x" condition: $a at 0 }`,
+		"range":             `rule A { strings: $a = "x" condition: ($a in (0..10)) }`,
+		"match offset":      `rule A { strings: $a = "x" condition: @a[1] == 0 }`,
+		"integer function":  `rule A { condition: uint16(0) == 0x5A4D }`,
+		"for loop":          `rule A { strings: $a = "x" condition: for any of them : ($) }`,
+		"string operator":   `rule A { condition: true and filesize > 0 matches /x/ }`,
+	}
+
+	for name, rules := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "test.yara"), []byte(rules), 0600))
+
+			_, err := scanners.NewYARA(dir, 0)
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("no rules", func(t *testing.T) {
+		_, err := scanners.NewYARA(t.TempDir(), 0)
+		require.Error(t, err)
+	})
+}
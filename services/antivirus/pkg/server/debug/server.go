@@ -3,6 +3,7 @@ package debug
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/dutchcoders/go-clamd"
@@ -11,6 +12,7 @@ import (
 	"github.com/owncloud/ocis/v2/ocis-pkg/handlers"
 	"github.com/owncloud/ocis/v2/ocis-pkg/service/debug"
 	"github.com/owncloud/ocis/v2/ocis-pkg/version"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/config"
 )
 
 // Server initializes the debug service and server.
@@ -22,14 +24,19 @@ func Server(opts ...Option) (*http.Server, error) {
 		WithCheck("nats reachability", checks.NewNatsCheck(options.Config.Events.Endpoint)).
 		WithCheck("antivirus reachability", func(ctx context.Context) error {
 			cfg := options.Config
-			switch cfg.Scanner.Type {
-			default:
-				return errors.New("no antivirus configured")
-			case "clamav":
-				return clamd.NewClamd(cfg.Scanner.ClamAV.Socket).Ping()
-			case "icap":
-				return checks.NewTCPCheck(cfg.Scanner.ICAP.URL)(ctx)
+			if cfg.Scanner.Type != "chain" {
+				return scannerCheck(ctx, cfg, cfg.Scanner.Type)
 			}
+
+			if len(cfg.Scanner.Chain) == 0 {
+				return errors.New("no antivirus configured for the chain")
+			}
+			for _, t := range cfg.Scanner.Chain {
+				if err := scannerCheck(ctx, cfg, t); err != nil {
+					return fmt.Errorf("%s: %w", t, err)
+				}
+			}
+			return nil
 		})
 
 	return debug.NewService(
@@ -43,3 +50,18 @@ func Server(opts ...Option) (*http.Server, error) {
 		debug.Ready(handlers.NewCheckHandler(readyHandlerConfiguration)),
 	), nil
 }
+
+// scannerCheck checks if the antivirus scanner of the given type is reachable
+func scannerCheck(ctx context.Context, cfg *config.Config, t string) error {
+	switch t {
+	default:
+		return errors.New("no antivirus configured")
+	case "clamav":
+		return clamd.NewClamd(cfg.Scanner.ClamAV.Socket).Ping()
+	case "icap":
+		return checks.NewTCPCheck(cfg.Scanner.ICAP.URL)(ctx)
+	case "yara", "blocklist":
+		// the scanners are created on startup, the service would not run if they were not usable
+		return nil
+	}
+}
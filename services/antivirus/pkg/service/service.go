@@ -3,27 +3,45 @@ package service
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
 	"github.com/cs3org/reva/v2/pkg/bytesize"
 	ctxpkg "github.com/cs3org/reva/v2/pkg/ctx"
 	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/events/stream"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	"github.com/cs3org/reva/v2/pkg/rhttp"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"go-micro.dev/v4/store"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quarantine"
+	"github.com/owncloud/ocis/v2/ocis-pkg/registry"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/cache"
 	"github.com/owncloud/ocis/v2/services/antivirus/pkg/config"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/rescan"
 	"github.com/owncloud/ocis/v2/services/antivirus/pkg/scanners"
 )
 
+// PPOutcomeQuarantine moves infected files to the quarantine space.
+// It is handled by the antivirus service, to the postprocessing the file is deleted.
+const PPOutcomeQuarantine = event.PPOutcomeQuarantine
+
 var (
 	// ErrFatal is returned when a fatal error occurs, and we want to exit.
 	ErrFatal = errors.New("fatal error")
@@ -37,43 +55,131 @@ type Scanner interface {
 }
 
 // NewAntivirus returns a service implementation for Service.
-func NewAntivirus(c *config.Config, l log.Logger, tp trace.TracerProvider) (Antivirus, error) {
+// The cache store keeps the scan results, the rescan store the progress of rescans.
+func NewAntivirus(c *config.Config, l log.Logger, tp trace.TracerProvider, st store.Store, rst kv.Store) (Antivirus, error) {
+
+	var maxScanSize uint64
+	if c.MaxScanSize != "" {
+		b, err := bytesize.Parse(c.MaxScanSize)
+		if err != nil {
+			return Antivirus{}, err
+		}
+
+		maxScanSize = b.Bytes()
+	}
 
 	var scanner Scanner
 	var err error
 	switch c.Scanner.Type {
+	case "chain":
+		if len(c.Scanner.Chain) == 0 {
+			return Antivirus{}, errors.New("no av scanners configured for the chain")
+		}
+
+		chain := make([]scanners.Chainable, 0, len(c.Scanner.Chain))
+		for _, t := range c.Scanner.Chain {
+			s, err := newScanner(c, t, maxScanSize)
+			if err != nil {
+				return Antivirus{}, err
+			}
+			chain = append(chain, s)
+		}
+		scanner = scanners.NewChain(chain...)
 	default:
-		return Antivirus{}, fmt.Errorf("unknown av scanner: '%s'", c.Scanner.Type)
-	case "clamav":
-		scanner = scanners.NewClamAV(c.Scanner.ClamAV.Socket)
-	case "icap":
-		scanner, err = scanners.NewICAP(c.Scanner.ICAP.URL, c.Scanner.ICAP.Service, c.Scanner.ICAP.Timeout)
+		scanner, err = newScanner(c, c.Scanner.Type, maxScanSize)
 	}
 	if err != nil {
 		return Antivirus{}, err
 	}
 
-	av := Antivirus{c: c, l: l, tp: tp, s: scanner, client: rhttp.GetHTTPClient(rhttp.Insecure(true))}
+	av := Antivirus{c: c, l: l, tp: tp, s: scanner, m: maxScanSize, client: rhttp.GetHTTPClient(rhttp.Insecure(true))}
+
+	if c.Cache.Enabled {
+		if _, ok := scanner.(scanners.Versioned); !ok {
+			return av, fmt.Errorf("av scanner '%s' does not support caching", c.Scanner.Type)
+		}
+		av.cache = cache.New(st, c.Cache.TTL)
+	}
 
 	switch o := events.PostprocessingOutcome(c.InfectedFileHandling); o {
 	case events.PPOutcomeContinue, events.PPOutcomeAbort, events.PPOutcomeDelete:
 		av.o = o
+	case PPOutcomeQuarantine:
+		gatewaySelector, err := newGatewaySelector(c, tp)
+		if err != nil {
+			return av, err
+		}
+		av.q, err = quarantine.New(c.QuarantineSpaceID, gatewaySelector, c.ServiceAccount.ServiceAccountID, c.ServiceAccount.ServiceAccountSecret)
+		if err != nil {
+			return av, err
+		}
+		av.o = events.PPOutcomeDelete
 	default:
 		return av, fmt.Errorf("unknown infected file handling '%s'", o)
 	}
 
-	if c.MaxScanSize != "" {
-		b, err := bytesize.Parse(c.MaxScanSize)
+	if c.Rescan.Enabled {
+		gatewaySelector, err := newGatewaySelector(c, tp)
 		if err != nil {
 			return av, err
 		}
 
-		av.m = b.Bytes()
+		skip := func(*provider.ResourceId) bool { return false }
+		if av.q != nil {
+			skip = av.q.Contains
+		}
+
+		av.r = rescan.New(
+			rescan.Config(c),
+			rescan.Logger(l),
+			rescan.GatewaySelector(gatewaySelector),
+			rescan.WithScanner(scanner, av.scannerName()),
+			rescan.Store(rst),
+			rescan.MaxScanSize(av.m),
+			rescan.Skip(skip),
+		)
 	}
 
 	return av, nil
 }
 
+// newScanner returns the scanner of the given type
+func newScanner(c *config.Config, t string, maxScanSize uint64) (Scanner, error) {
+	switch t {
+	default:
+		return nil, fmt.Errorf("unknown av scanner: '%s'", t)
+	case "clamav":
+		return scanners.NewClamAV(c.Scanner.ClamAV.Socket), nil
+	case "icap":
+		return scanners.NewICAP(c.Scanner.ICAP.URL, c.Scanner.ICAP.Service, c.Scanner.ICAP.Timeout)
+	case "yara":
+		return scanners.NewYARA(c.Scanner.YARA.RulesDir, maxScanSize)
+	case "blocklist":
+		return scanners.NewBlocklist(c.Scanner.Blocklist.File)
+	}
+}
+
+// newGatewaySelector returns the selector for the gateway used to access the stored files
+func newGatewaySelector(c *config.Config, tp trace.TracerProvider) (pool.Selectable[gateway.GatewayAPIClient], error) {
+	tm, err := pool.StringToTLSMode(c.GRPCClientTLS.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	gatewaySelector, err := pool.GatewaySelector(
+		c.RevaGateway,
+		pool.WithTLSCACert(c.GRPCClientTLS.CACert),
+		pool.WithTLSMode(tm),
+		pool.WithRegistry(registry.GetRegistry()),
+		pool.WithTracerProvider(tp),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not get reva client selector: %s", err)
+	}
+
+	return gatewaySelector, nil
+}
+
 // Antivirus defines implements the business logic for Service.
 type Antivirus struct {
 	c  *config.Config
@@ -83,11 +189,15 @@ type Antivirus struct {
 	m  uint64
 	tp trace.TracerProvider
 
+	cache *cache.Cache
+	q     *quarantine.Quarantine
+	r     *rescan.Rescanner
+
 	client *http.Client
 }
 
-// Run runs the service
-func (av Antivirus) Run() error {
+// Run runs the service, the rescanner is stopped when the context is done
+func (av Antivirus) Run(ctx context.Context) error {
 	evtsCfg := av.c.Events
 
 	var rootCAPool *x509.CertPool
@@ -112,17 +222,28 @@ func (av Antivirus) Run() error {
 		return err
 	}
 
-	ch, err := events.Consume(natsStream, "antivirus", events.StartPostprocessingStep{})
+	ch, err := events.Consume(natsStream, "antivirus", events.StartPostprocessingStep{}, event.RescanRequested{})
 	if err != nil {
 		return err
 	}
 
+	if av.r != nil {
+		go func() {
+			_ = av.r.Run(ctx, natsStream)
+		}()
+	}
+
 	wg := sync.WaitGroup{}
 	for i := 0; i < av.c.Workers; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			for e := range ch {
+				if _, ok := e.Event.(event.RescanRequested); ok {
+					av.rescan()
+					continue
+				}
+
 				err := av.processEvent(e, natsStream)
 				if err != nil {
 					switch {
@@ -173,13 +294,37 @@ func (av Antivirus) processEvent(e events.Event, s events.Publisher) error {
 		return fmt.Errorf("%w: no actual virus scan performed", ErrEvent)
 	}
 
+	if av.q != nil && av.q.Contains(ev.ResourceID) {
+		// files in the quarantine space are infected by definition, scanning them would move them to the quarantine again
+		av.l.Debug().Str("uploadid", ev.UploadID).Str("filename", ev.Filename).Msg("Skipping virus scan for the quarantine space.")
+		if err := events.Publish(ctx, s, events.PostprocessingStepFinished{
+			FinishedStep:  events.PPStepAntivirus,
+			Outcome:       events.PPOutcomeContinue,
+			UploadID:      ev.UploadID,
+			ExecutingUser: ev.ExecutingUser,
+			Filename:      ev.Filename,
+			Result: events.VirusscanResult{
+				Description: "not scanned: quarantine space",
+				Scandate:    time.Now(),
+				ResourceID:  ev.ResourceID,
+			},
+		}); err != nil {
+			av.l.Fatal().Err(err).Str("uploadid", ev.UploadID).Interface("resourceID", ev.ResourceID).Msg("cannot publish events - exiting")
+			return fmt.Errorf("%w: %s", ErrFatal, err)
+		}
+		return nil
+	}
+
 	av.l.Debug().Str("uploadid", ev.UploadID).Str("filename", ev.Filename).Msg("Starting virus scan.")
 	var errmsg string
 	start := time.Now()
-	res, err := av.process(ev)
+	res, content, err := av.process(ev)
 	if err != nil {
 		errmsg = err.Error()
 	}
+	if content != nil {
+		defer content.Close()
+	}
 	duration := time.Since(start)
 
 	var outcome events.PostprocessingOutcome
@@ -195,6 +340,15 @@ func (av Antivirus) processEvent(e events.Event, s events.Publisher) error {
 		outcome = events.PPOutcomeAbort
 	}
 
+	if res.Infected && content != nil {
+		if err := av.quarantine(ctx, ev, res, content, s); err != nil {
+			av.l.Error().Err(err).Str("uploadid", ev.UploadID).Interface("resourceID", ev.ResourceID).Msg("cannot move file to the quarantine, keeping it in the uploads folder")
+			outcome = events.PPOutcomeAbort
+		} else {
+			outcome = PPOutcomeQuarantine
+		}
+	}
+
 	av.l.Info().Str("uploadid", ev.UploadID).Interface("resourceID", ev.ResourceID).Str("virus", res.Description).Str("outcome", string(outcome)).Str("filename", ev.Filename).Str("user", ev.ExecutingUser.GetId().GetOpaqueId()).Bool("infected", res.Infected).Dur("duration", duration).Msg("File scanned")
 	if err := events.Publish(ctx, s, events.PostprocessingStepFinished{
 		FinishedStep:  events.PPStepAntivirus,
@@ -216,37 +370,184 @@ func (av Antivirus) processEvent(e events.Event, s events.Publisher) error {
 	return nil
 }
 
-// process the scan
-func (av Antivirus) process(ev events.StartPostprocessingStep) (scanners.Result, error) {
+// process the scan. When the quarantine is enabled, the content of infected files is returned to move
+// them to the quarantine without downloading them again, the caller has to close it.
+func (av Antivirus) process(ev events.StartPostprocessingStep) (scanners.Result, io.ReadCloser, error) {
 	if ev.Filesize == 0 || (0 < av.m && av.m < ev.Filesize) {
 		av.l.Info().Str("uploadid", ev.UploadID).Uint64("limit", av.m).Uint64("filesize", ev.Filesize).Msg("Skipping file to be virus scanned because its file size is higher than the defined limit.")
 		return scanners.Result{
 			ScanTime: time.Now(),
-		}, nil
+		}, nil, nil
 	}
 
-	var err error
-	var rrc io.ReadCloser
+	rrc, err := av.download(ev)
+	if err != nil {
+		av.l.Error().Err(err).Str("uploadid", ev.UploadID).Msg("error downloading file")
+		return scanners.Result{}, nil, err
+	}
+	defer rrc.Close()
 
-	switch ev.UploadID {
-	default:
-		rrc, err = av.downloadViaToken(ev.URL)
-	case "":
-		rrc, err = av.downloadViaReva(ev.URL, ev.Token, ev.RevaToken)
+	if av.cache == nil && av.q == nil {
+		av.l.Debug().Str("uploadid", ev.UploadID).Msg("Downloaded file successfully, starting virusscan")
+
+		res, err := av.s.Scan(scanners.Input{Body: rrc, Size: int64(ev.Filesize), Url: ev.URL, Name: ev.Filename})
+		if err != nil {
+			av.l.Error().Err(err).Str("uploadid", ev.UploadID).Msg("error scanning file")
+		}
+		return res, nil, err
 	}
+
+	f, hash, err := av.spool(rrc)
 	if err != nil {
 		av.l.Error().Err(err).Str("uploadid", ev.UploadID).Msg("error downloading file")
+		return scanners.Result{}, nil, err
+	}
+
+	res, err := av.processSpooled(ev, f, hash)
+	if err != nil || !res.Infected || av.q == nil {
+		_ = f.Close()
+		return res, nil, err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		_ = f.Close()
+		return res, nil, err
+	}
+	return res, f, nil
+}
+
+// spooledFile is a temporary file which is removed when it is closed
+type spooledFile struct {
+	*os.File
+}
+
+// Close closes and removes the file
+func (f spooledFile) Close() error {
+	err := f.File.Close()
+	_ = os.Remove(f.Name())
+	return err
+}
+
+// spool writes the content to a temporary file. The content is hashed while writing it if the cache is enabled.
+func (av Antivirus) spool(body io.Reader) (spooledFile, string, error) {
+	tmp, err := os.CreateTemp("", "antivirus-")
+	if err != nil {
+		return spooledFile{}, "", err
+	}
+	f := spooledFile{tmp}
+
+	var w io.Writer = f
+	h := sha256.New()
+	if av.cache != nil {
+		w = io.MultiWriter(f, h)
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		_ = f.Close()
+		return spooledFile{}, "", err
+	}
+
+	var hash string
+	if av.cache != nil {
+		hash = hex.EncodeToString(h.Sum(nil))
+	}
+	return f, hash, nil
+}
+
+// processSpooled scans the spooled file. If the cache is enabled, it is only scanned
+// if there is no result for the hash and the current signature version in the cache.
+func (av Antivirus) processSpooled(ev events.StartPostprocessingStep, f spooledFile, hash string) (scanners.Result, error) {
+	var version string
+	if av.cache != nil {
+		var err error
+		version, err = av.s.(scanners.Versioned).Version()
+		if err != nil {
+			av.l.Error().Err(err).Str("uploadid", ev.UploadID).Msg("error getting the scanner signature version, skipping cache")
+		}
+	}
+
+	if version != "" {
+		res, ok, err := av.cache.Get(version, hash)
+		switch {
+		case err != nil:
+			av.l.Error().Err(err).Str("uploadid", ev.UploadID).Msg("error reading scan result from cache")
+		case ok:
+			av.l.Debug().Str("uploadid", ev.UploadID).Str("hash", hash).Msg("Found scan result in cache, skipping virusscan")
+			return res, nil
+		}
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
 		return scanners.Result{}, err
 	}
-	defer rrc.Close()
-	av.l.Debug().Str("uploadid", ev.UploadID).Msg("Downloaded file successfully, starting virusscan")
 
-	res, err := av.s.Scan(scanners.Input{Body: rrc, Size: int64(ev.Filesize), Url: ev.URL, Name: ev.Filename})
+	av.l.Debug().Str("uploadid", ev.UploadID).Msg("Downloaded file successfully, starting virusscan")
+	res, err := av.s.Scan(scanners.Input{Body: f, Size: int64(ev.Filesize), Url: ev.URL, Name: ev.Filename})
 	if err != nil {
 		av.l.Error().Err(err).Str("uploadid", ev.UploadID).Msg("error scanning file")
+		return res, err
+	}
+
+	if version != "" {
+		if err := av.cache.Set(version, hash, res); err != nil {
+			av.l.Error().Err(err).Str("uploadid", ev.UploadID).Msg("error writing scan result to cache")
+		}
+	}
+
+	return res, nil
+}
+
+// quarantine moves the infected file to the quarantine space
+func (av Antivirus) quarantine(ctx context.Context, ev events.StartPostprocessingStep, res scanners.Result, content io.Reader, s events.Publisher) error {
+	item := quarantine.Item{
+		ID:         ev.UploadID,
+		Filename:   ev.Filename,
+		Size:       ev.Filesize,
+		ResourceID: ev.ResourceID,
+		Uploader:   ev.ExecutingUser.GetId(),
+		Virus:      res.Description,
+		Scanner:    av.scannerName(),
+		ScanDate:   res.ScanTime,
+	}
+	if err := av.q.Add(ctx, item, content); err != nil {
+		return err
+	}
+
+	return events.Publish(ctx, s, event.FileQuarantined{
+		ItemID:        item.ID,
+		UploadID:      ev.UploadID,
+		Filename:      ev.Filename,
+		ResourceID:    ev.ResourceID,
+		ExecutingUser: ev.ExecutingUser,
+		Virus:         item.Virus,
+		Scanner:       item.Scanner,
+		Timestamp:     utils.TSNow(),
+	})
+}
+
+// rescan starts a rescan of the stored files
+func (av Antivirus) rescan() {
+	if av.r == nil {
+		av.l.Warn().Msg("Rescan requested but rescanning is not enabled, see ANTIVIRUS_RESCAN_ENABLED.")
+		return
+	}
+	av.l.Info().Msg("Rescan requested.")
+	av.r.Trigger()
+}
+
+// scannerName returns the name of the configured scanner
+func (av Antivirus) scannerName() string {
+	if av.c.Scanner.Type == "chain" {
+		return strings.Join(av.c.Scanner.Chain, ",")
 	}
+	return av.c.Scanner.Type
+}
 
-	return res, err
+// download downloads the file of the postprocessing step
+func (av Antivirus) download(ev events.StartPostprocessingStep) (io.ReadCloser, error) {
+	if ev.UploadID == "" {
+		return av.downloadViaReva(ev.URL, ev.Token, ev.RevaToken)
+	}
+	return av.downloadViaToken(ev.URL)
 }
 
 // download will download the file
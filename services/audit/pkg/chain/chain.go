@@ -0,0 +1,225 @@
+// Package chain implements a tamper-evident audit log. Every record carries the
+// hash of its predecessor and signed checkpoints are written periodically, so
+// edited, reordered or dropped entries can be detected by Verify.
+package chain
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// TypeEvent marks a record holding an audit event
+	TypeEvent = "event"
+	// TypeCheckpoint marks a signed checkpoint record
+	TypeCheckpoint = "checkpoint"
+)
+
+// Genesis is the predecessor hash of the very first record in a chain
+var Genesis = hex.EncodeToString(make([]byte, sha256.Size))
+
+// Record is a single line of a hash chained audit log
+type Record struct {
+	Seq       uint64          `json:"seq"`
+	Time      string          `json:"time"`
+	Type      string          `json:"type"`
+	Prev      string          `json:"prev"`
+	Hash      string          `json:"hash"`
+	Signature string          `json:"signature,omitempty"`
+	Event     json.RawMessage `json:"event,omitempty"`
+}
+
+// ComputeHash returns the hex encoded hash of the record. The hash covers all
+// fields except the hash itself and the signature.
+func (r Record) ComputeHash() (string, error) {
+	payload := new(bytes.Buffer)
+	if len(r.Event) > 0 {
+		if err := json.Compact(payload, r.Event); err != nil {
+			return "", err
+		}
+	}
+
+	h := sha256.New()
+	for _, f := range []string{strconv.FormatUint(r.Seq, 10), r.Time, r.Type, r.Prev} {
+		h.Write([]byte(f))
+		h.Write([]byte{'\n'})
+	}
+	h.Write(payload.Bytes())
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Writer appends chained records to an underlying writer
+type Writer struct {
+	mu sync.Mutex
+
+	out                io.Writer
+	key                ed25519.PrivateKey
+	checkpointInterval uint64
+	now                func() time.Time
+
+	seq             uint64
+	prev            string
+	sinceCheckpoint uint64
+}
+
+// Option configures a Writer
+type Option func(*Writer)
+
+// WithSigningKey sets the key used to sign checkpoints
+func WithSigningKey(key ed25519.PrivateKey) Option {
+	return func(w *Writer) {
+		w.key = key
+	}
+}
+
+// WithCheckpointInterval sets the number of events after which a checkpoint is written.
+// A value of 0 disables checkpoints.
+func WithCheckpointInterval(n uint64) Option {
+	return func(w *Writer) {
+		w.checkpointInterval = n
+	}
+}
+
+// WithState continues an existing chain after the record with the given sequence number and hash
+func WithState(seq uint64, hash string) Option {
+	return func(w *Writer) {
+		w.seq = seq
+		w.prev = hash
+	}
+}
+
+// WithClock overrides the clock used to timestamp records
+func WithClock(now func() time.Time) Option {
+	return func(w *Writer) {
+		w.now = now
+	}
+}
+
+// NewWriter returns a Writer appending to out
+func NewWriter(out io.Writer, opts ...Option) *Writer {
+	w := &Writer{
+		out:  out,
+		prev: Genesis,
+		now:  time.Now,
+	}
+	for _, o := range opts {
+		o(w)
+	}
+	return w
+}
+
+// Write appends an event to the chain. Payloads which are not valid json are stored as json string.
+// A checkpoint is appended after the event when the checkpoint interval is reached.
+func (w *Writer) Write(event []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	payload := event
+	if !json.Valid(event) {
+		var err error
+		if payload, err = json.Marshal(string(event)); err != nil {
+			return err
+		}
+	}
+
+	if err := w.append(Record{Type: TypeEvent, Event: payload}); err != nil {
+		return err
+	}
+	w.sinceCheckpoint++
+
+	if w.checkpointInterval > 0 && w.sinceCheckpoint >= w.checkpointInterval {
+		return w.checkpoint()
+	}
+	return nil
+}
+
+// Checkpoint appends a signed checkpoint to the chain
+func (w *Writer) Checkpoint() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.checkpoint()
+}
+
+// State returns the sequence number and hash of the last written record
+func (w *Writer) State() (uint64, string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.seq, w.prev
+}
+
+func (w *Writer) checkpoint() error {
+	if err := w.append(Record{Type: TypeCheckpoint}); err != nil {
+		return err
+	}
+	w.sinceCheckpoint = 0
+	return nil
+}
+
+func (w *Writer) append(r Record) error {
+	r.Seq = w.seq + 1
+	r.Time = w.now().UTC().Format(time.RFC3339Nano)
+	r.Prev = w.prev
+
+	hash, err := r.ComputeHash()
+	if err != nil {
+		return err
+	}
+	r.Hash = hash
+
+	if r.Type == TypeCheckpoint && w.key != nil {
+		sum, _ := hex.DecodeString(hash)
+		r.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(w.key, sum))
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err := w.out.Write(append(b, '\n')); err != nil {
+		return err
+	}
+
+	w.seq = r.Seq
+	w.prev = r.Hash
+	return nil
+}
+
+// LastRecord returns the last record found in r. It returns nil if r contains no records and an error
+// if r contains lines which are no chain records, e.g. audit events written before the chain was enabled.
+func LastRecord(r io.Reader) (*Record, error) {
+	var last *Record
+	err := scan(r, func(_ int, rec *Record) error {
+		last = rec
+		return nil
+	})
+	return last, err
+}
+
+func scan(r io.Reader, fn func(line int, rec *Record) error) error {
+	dec := json.NewDecoder(r)
+	for line := 1; ; line++ {
+		rec := &Record{}
+		err := dec.Decode(rec)
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return &VerificationError{Line: line, Reason: fmt.Sprintf("malformed record: %s", err)}
+		case rec.Seq == 0 || rec.Type == "" || rec.Hash == "":
+			return &VerificationError{Line: line, Reason: "not a hash chain record"}
+		}
+		if err := fn(line, rec); err != nil {
+			return err
+		}
+	}
+}
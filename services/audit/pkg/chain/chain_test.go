@@ -0,0 +1,188 @@
+package chain_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/owncloud/ocis/v2/services/audit/pkg/chain"
+	"github.com/stretchr/testify/require"
+)
+
+func writeChain(t *testing.T, key ed25519.PrivateKey, events ...string) *bytes.Buffer {
+	buf := new(bytes.Buffer)
+	w := chain.NewWriter(buf, chain.WithSigningKey(key), chain.WithCheckpointInterval(2))
+	for _, e := range events {
+		require.NoError(t, w.Write([]byte(e)))
+	}
+	return buf
+}
+
+func TestVerify(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	buf := writeChain(t, key, `{"Action":"file_create"}`, "minimal)\n   message", `{"Action":"file_delete"}`)
+
+	report, err := chain.Verify(bytes.NewReader(buf.Bytes()), chain.WithPublicKey(pub))
+	require.NoError(t, err)
+	require.Equal(t, 3, report.Events)
+	require.Equal(t, 1, report.Checkpoints)
+	require.Equal(t, 0, report.Unsigned)
+	require.Equal(t, uint64(4), report.LastSeq)
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		tamper func(lines []string) []string
+		line   int
+	}{
+		{
+			name: "modified event",
+			tamper: func(lines []string) []string {
+				lines[1] = strings.Replace(lines[1], "file_delete", "file_create", 1)
+				return lines
+			},
+			line: 2,
+		},
+		{
+			name: "dropped event",
+			tamper: func(lines []string) []string {
+				return append(lines[:1], lines[2:]...)
+			},
+			line: 2,
+		},
+		{
+			name: "reordered events",
+			tamper: func(lines []string) []string {
+				lines[3], lines[4] = lines[4], lines[3]
+				return lines
+			},
+			line: 4,
+		},
+		{
+			name: "resigned chain",
+			tamper: func(lines []string) []string {
+				forged := strings.Split(strings.TrimSpace(writeChain(t, otherKey, `{"Action":"a"}`, `{"Action":"b"}`).String()), "\n")
+				return append(forged, lines[3:]...)
+			},
+			line: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := writeChain(t, key, `{"Action":"file_create"}`, `{"Action":"file_delete"}`, `{"Action":"file_rename"}`, `{"Action":"file_copy"}`)
+			lines := tc.tamper(strings.Split(strings.TrimSpace(buf.String()), "\n"))
+
+			_, err := chain.Verify(strings.NewReader(strings.Join(lines, "\n")), chain.WithPublicKey(pub))
+			var verr *chain.VerificationError
+			require.ErrorAs(t, err, &verr)
+			require.Equal(t, tc.line, verr.Line)
+		})
+	}
+}
+
+func TestVerifyDetectsRemovedCheckpoints(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	// a chain rewritten without a signing key
+	unsigned := new(bytes.Buffer)
+	w := chain.NewWriter(unsigned, chain.WithCheckpointInterval(2))
+	for _, e := range []string{`{"Action":"a"}`, `{"Action":"b"}`} {
+		require.NoError(t, w.Write([]byte(e)))
+	}
+	_, err = chain.Verify(bytes.NewReader(unsigned.Bytes()), chain.WithPublicKey(pub))
+	var verr *chain.VerificationError
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, 3, verr.Line)
+
+	// a chain rewritten without checkpoints
+	rewritten := new(bytes.Buffer)
+	w = chain.NewWriter(rewritten)
+	for _, e := range []string{`{"Action":"a"}`, `{"Action":"b"}`, `{"Action":"c"}`} {
+		require.NoError(t, w.Write([]byte(e)))
+	}
+	_, err = chain.Verify(bytes.NewReader(rewritten.Bytes()), chain.WithPublicKey(pub), chain.WithCheckpointGap(2))
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, 3, verr.Line)
+
+	buf := writeChain(t, key, `{"Action":"a"}`, `{"Action":"b"}`, `{"Action":"c"}`)
+	_, err = chain.Verify(bytes.NewReader(buf.Bytes()), chain.WithPublicKey(pub), chain.WithCheckpointGap(2))
+	require.NoError(t, err)
+}
+
+func TestLastRecordRejectsPlainEvents(t *testing.T) {
+	_, err := chain.LastRecord(strings.NewReader(`{"Action":"file_create"}` + "\n"))
+	var verr *chain.VerificationError
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, 1, verr.Line)
+}
+
+func TestResume(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	buf := writeChain(t, key, `{"Action":"file_create"}`)
+	last, err := chain.LastRecord(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	w := chain.NewWriter(buf, chain.WithState(last.Seq, last.Hash))
+	require.NoError(t, w.Write([]byte(`{"Action":"file_delete"}`)))
+
+	report, err := chain.Verify(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, 2, report.Events)
+}
+
+func TestVerifyDetectsTruncatedHead(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	buf := writeChain(t, key, `{"Action":"a"}`, `{"Action":"b"}`, `{"Action":"c"}`)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+	_, err = chain.Verify(strings.NewReader(strings.Join(lines[1:], "\n")), chain.WithPublicKey(pub))
+	var verr *chain.VerificationError
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, 1, verr.Line)
+}
+
+func TestVerifyFromCheckpoint(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	buf := writeChain(t, key, `{"Action":"a"}`, `{"Action":"b"}`, `{"Action":"c"}`)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	checkpoint, err := chain.ParseCheckpoint([]byte(lines[2]))
+	require.NoError(t, err)
+
+	// a rotated file starting after the checkpoint
+	report, err := chain.Verify(strings.NewReader(lines[3]), chain.WithPublicKey(pub), chain.FromCheckpoint(checkpoint))
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Events)
+	require.Equal(t, 1, report.Tail)
+
+	// records up to the checkpoint are skipped
+	_, err = chain.Verify(strings.NewReader(buf.String()), chain.WithPublicKey(pub), chain.FromCheckpoint(checkpoint))
+	require.NoError(t, err)
+
+	_, err = chain.Verify(strings.NewReader(lines[3]), chain.FromCheckpoint(checkpoint))
+	require.Error(t, err)
+	_, err = chain.Verify(strings.NewReader(lines[3]), chain.WithPublicKey(otherPub), chain.FromCheckpoint(checkpoint))
+	require.Error(t, err)
+
+	_, err = chain.ParseCheckpoint([]byte(lines[0]))
+	require.Error(t, err)
+}
@@ -0,0 +1,197 @@
+package chain
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// VerificationError describes the first broken link found in a chain
+type VerificationError struct {
+	Line   int
+	Seq    uint64
+	Reason string
+}
+
+// Error implements the error interface
+func (e *VerificationError) Error() string {
+	if e.Seq == 0 {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Reason)
+	}
+	return fmt.Sprintf("line %d (seq %d): %s", e.Line, e.Seq, e.Reason)
+}
+
+// Report summarizes a successful verification
+type Report struct {
+	Events      int
+	Checkpoints int
+	// Unsigned counts checkpoints which were not checked because no key was given
+	Unsigned int
+	// Tail counts the events after the last checkpoint, removing them can't be detected
+	Tail      int
+	FirstSeq  uint64
+	LastSeq   uint64
+	LastHash  string
+	FirstPrev string
+}
+
+// VerifyOption configures Verify
+type VerifyOption func(*verifier)
+
+type verifier struct {
+	key      ed25519.PublicKey
+	interval uint64
+	seq      uint64
+	prev     string
+	anchor   *Record
+}
+
+// WithPublicKey enables signature verification of checkpoints
+func WithPublicKey(key ed25519.PublicKey) VerifyOption {
+	return func(v *verifier) {
+		v.key = key
+	}
+}
+
+// WithCheckpointGap fails the verification if more than n events follow each other without a checkpoint.
+// A value of 0 disables the check.
+func WithCheckpointGap(n uint64) VerifyOption {
+	return func(v *verifier) {
+		v.interval = n
+	}
+}
+
+// FromCheckpoint anchors the verification at the given signed checkpoint instead of the genesis
+// record, e.g. when verifying a log file which was rotated. The checkpoint needs to be verified
+// with WithPublicKey. Records up to the checkpoint are skipped, the record with the sequence
+// number of the checkpoint has to be the checkpoint if it is contained in the file.
+func FromCheckpoint(checkpoint Record) VerifyOption {
+	return func(v *verifier) {
+		v.anchor = &checkpoint
+	}
+}
+
+// ParseCheckpoint parses a checkpoint record as written to the log.
+func ParseCheckpoint(b []byte) (Record, error) {
+	var rec Record
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return rec, fmt.Errorf("malformed checkpoint: %w", err)
+	}
+	if rec.Type != TypeCheckpoint {
+		return rec, errors.New("the record is not a checkpoint")
+	}
+	return rec, nil
+}
+
+// verifyAnchor checks that the anchor is an unmodified checkpoint with a valid signature
+func (v *verifier) verifyAnchor() error {
+	if v.key == nil {
+		return errors.New("a public key is needed to verify the checkpoint")
+	}
+	hash, err := v.anchor.ComputeHash()
+	if err != nil || hash != v.anchor.Hash {
+		return errors.New("the checkpoint was modified")
+	}
+	if !v.validSignature(v.anchor) {
+		return errors.New("invalid checkpoint signature")
+	}
+	return nil
+}
+
+func (v *verifier) validSignature(rec *Record) bool {
+	sig, err := base64.StdEncoding.DecodeString(rec.Signature)
+	if err != nil {
+		return false
+	}
+	sum, _ := hex.DecodeString(rec.Hash)
+	return ed25519.Verify(v.key, sum, sig)
+}
+
+// Verify walks the chain in r and returns an error of type *VerificationError for the first broken
+// or missing link. The chain has to start at the genesis record unless FromCheckpoint is given, so
+// removed records at the head of the chain are detected as well.
+func Verify(r io.Reader, opts ...VerifyOption) (Report, error) {
+	v := &verifier{prev: Genesis}
+	for _, o := range opts {
+		o(v)
+	}
+	if v.anchor != nil {
+		if err := v.verifyAnchor(); err != nil {
+			return Report{}, err
+		}
+		v.seq = v.anchor.Seq
+		v.prev = v.anchor.Hash
+	}
+
+	rep := Report{}
+	var sinceCheckpoint uint64
+	first := true
+	err := scan(r, func(line int, rec *Record) error {
+		fail := func(format string, args ...interface{}) error {
+			return &VerificationError{Line: line, Seq: rec.Seq, Reason: fmt.Sprintf(format, args...)}
+		}
+
+		if v.anchor != nil && rec.Seq <= v.anchor.Seq {
+			if rec.Seq == v.anchor.Seq && rec.Hash != v.anchor.Hash {
+				return fail("the record does not match the checkpoint")
+			}
+			return nil
+		}
+
+		if first {
+			first = false
+			rep.FirstSeq = rec.Seq
+			rep.FirstPrev = rec.Prev
+		}
+
+		if rec.Seq != v.seq+1 {
+			return fail("expected sequence number %d, records are missing or reordered", v.seq+1)
+		}
+		if rec.Prev != v.prev {
+			return fail("previous hash mismatch, expected '%s' got '%s'", v.prev, rec.Prev)
+		}
+
+		hash, err := rec.ComputeHash()
+		if err != nil {
+			return fail("could not hash record: %s", err)
+		}
+		if hash != rec.Hash {
+			return fail("hash mismatch, the record was modified")
+		}
+
+		switch rec.Type {
+		case TypeEvent:
+			rep.Events++
+			sinceCheckpoint++
+			if v.interval > 0 && sinceCheckpoint > v.interval {
+				return fail("more than %d events without a checkpoint, checkpoints were removed", v.interval)
+			}
+		case TypeCheckpoint:
+			rep.Checkpoints++
+			sinceCheckpoint = 0
+			switch {
+			case v.key == nil:
+				rep.Unsigned++
+			case rec.Signature == "":
+				return fail("checkpoint is not signed")
+			case !v.validSignature(rec):
+				return fail("invalid checkpoint signature")
+			}
+		default:
+			return fail("unknown record type '%s'", rec.Type)
+		}
+
+		v.seq = rec.Seq
+		v.prev = rec.Hash
+		return nil
+	})
+
+	rep.Tail = int(sinceCheckpoint)
+	rep.LastSeq = v.seq
+	rep.LastHash = v.prev
+	return rep, err
+}
@@ -10,10 +10,14 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
+	ogrpc "github.com/owncloud/ocis/v2/ocis-pkg/service/grpc"
+	"github.com/owncloud/ocis/v2/ocis-pkg/tracing"
+	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
 	"github.com/owncloud/ocis/v2/services/audit/pkg/config"
 	"github.com/owncloud/ocis/v2/services/audit/pkg/config/parser"
 	"github.com/owncloud/ocis/v2/services/audit/pkg/logging"
 	"github.com/owncloud/ocis/v2/services/audit/pkg/server/debug"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/server/http"
 	svc "github.com/owncloud/ocis/v2/services/audit/pkg/service"
 	"github.com/owncloud/ocis/v2/services/audit/pkg/types"
 )
@@ -25,7 +29,10 @@ func Server(cfg *config.Config) *cli.Command {
 		Usage:    fmt.Sprintf("start the %s service without runtime (unsupervised mode)", cfg.Service.Name),
 		Category: "server",
 		Before: func(c *cli.Context) error {
-			return configlog.ReturnFatal(parser.ParseConfig(cfg))
+			if err := configlog.ReturnFatal(parser.ParseConfig(cfg)); err != nil {
+				return err
+			}
+			return configlog.ReturnFatal(parser.ValidateServer(cfg))
 		},
 		Action: func(c *cli.Context) error {
 			var (
@@ -45,6 +52,36 @@ func Server(cfg *config.Config) *cli.Command {
 				return err
 			}
 
+			traceProvider, err := tracing.GetServiceTraceProvider(cfg.Tracing, cfg.Service.Name)
+			if err != nil {
+				return err
+			}
+
+			grpcClient, err := ogrpc.NewClient(
+				append(ogrpc.GetClientOptions(cfg.GRPCClientTLS), ogrpc.WithTraceProvider(traceProvider))...,
+			)
+			if err != nil {
+				return err
+			}
+
+			{
+				server, err := http.Server(
+					http.Logger(logger),
+					http.Context(ctx),
+					http.Config(cfg),
+					http.RoleClient(settingssvc.NewRoleService("com.owncloud.api.settings", grpcClient)),
+					http.TracerProvider(traceProvider),
+				)
+				if err != nil {
+					logger.Info().Err(err).Str("server", "http").Msg("Failed to initialize server")
+					return err
+				}
+
+				gr.Add(server.Run, func(_ error) {
+					cancel()
+				})
+			}
+
 			gr.Add(func() error {
 				svc.AuditLoggerFromConfig(ctx, cfg.Auditlog, evts, logger)
 				return nil
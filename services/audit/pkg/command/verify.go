@@ -0,0 +1,119 @@
+package command
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/chain"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/config"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/config/parser"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/rotate"
+	"github.com/urfave/cli/v2"
+)
+
+// Verify is the entrypoint for the verify command
+func Verify(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:      "verify",
+		Usage:     "verify the integrity of a hash chained audit log file",
+		Category:  "maintenance",
+		ArgsUsage: "[file]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "key",
+				Usage: "path of the ed25519 key used to verify checkpoint signatures. Defaults to the configured signing key",
+			},
+			&cli.StringFlag{
+				Name:  "checkpoint",
+				Usage: "a signed checkpoint record, e.g. the last one of the previous file, to start the verification at. Required for files which don't start at the genesis record",
+			},
+			&cli.BoolFlag{
+				Name:  "export-public-key",
+				Usage: "print the public key of the signing key and exit",
+			},
+		},
+		Before: func(_ *cli.Context) error {
+			return configlog.ReturnError(parser.ParseConfig(cfg))
+		},
+		Action: func(c *cli.Context) error {
+			keyPath := c.String("key")
+			if keyPath == "" {
+				keyPath = cfg.Auditlog.HashChain.SigningKeyPath
+			}
+
+			if keyPath == "" && c.Bool("export-public-key") {
+				return errors.New("no signing key configured")
+			}
+
+			var key ed25519.PublicKey
+			var err error
+			if keyPath != "" {
+				key, err = chain.LoadPublicKey(keyPath)
+			}
+			switch {
+			case keyPath == "":
+				fmt.Fprintln(os.Stderr, "WARNING: no signing key configured, checkpoint signatures are not verified")
+			case err == nil:
+			case c.Bool("export-public-key"), c.IsSet("key"):
+				return err
+			default:
+				fmt.Fprintf(os.Stderr, "WARNING: could not load key '%s', checkpoint signatures are not verified: %s\n", keyPath, err)
+			}
+
+			if c.Bool("export-public-key") {
+				b, err := chain.EncodePublicKey(key)
+				if err != nil {
+					return err
+				}
+				fmt.Print(string(b))
+				return nil
+			}
+
+			path := c.Args().First()
+			if path == "" {
+				path = cfg.Auditlog.FilePath
+			}
+			if path == "" {
+				return errors.New("no audit log file given")
+			}
+
+			f, err := rotate.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			opts := []chain.VerifyOption{chain.WithCheckpointGap(cfg.Auditlog.HashChain.CheckpointInterval)}
+			if key != nil {
+				opts = append(opts, chain.WithPublicKey(key))
+			}
+			if c.IsSet("checkpoint") {
+				checkpoint, err := chain.ParseCheckpoint([]byte(c.String("checkpoint")))
+				if err != nil {
+					return err
+				}
+				opts = append(opts, chain.FromCheckpoint(checkpoint))
+			}
+
+			report, err := chain.Verify(f, opts...)
+			if err != nil {
+				return fmt.Errorf("verification of '%s' failed: %w", path, err)
+			}
+
+			fmt.Printf("'%s' is intact: %d events and %d checkpoints from seq %d to %d\n", path, report.Events, report.Checkpoints, report.FirstSeq, report.LastSeq)
+			if report.FirstSeq > 1 {
+				fmt.Printf("the file continues a chain at hash '%s'\n", report.FirstPrev)
+			}
+			if report.Unsigned > 0 {
+				fmt.Printf("WARNING: %d checkpoints were not signature checked\n", report.Unsigned)
+			}
+			if report.Tail > 0 {
+				fmt.Printf("WARNING: the last %d events are not covered by a checkpoint, removing them can't be detected\n", report.Tail)
+			}
+			return nil
+		},
+	}
+}
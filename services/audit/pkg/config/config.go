@@ -2,8 +2,10 @@ package config
 
 import (
 	"context"
+	"time"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
+	"github.com/owncloud/ocis/v2/ocis-pkg/tracing"
 )
 
 // Config combines all available configuration parts.
@@ -16,6 +18,10 @@ type Config struct {
 	Log     *Log     `yaml:"log"`
 	Debug   Debug    `yaml:"debug"`
 
+	HTTP          HTTP                  `yaml:"http"`
+	GRPCClientTLS *shared.GRPCClientTLS `yaml:"grpc_client_tls"`
+	TokenManager  *TokenManager         `yaml:"token_manager"`
+
 	Events   Events   `yaml:"events"`
 	Auditlog Auditlog `yaml:"auditlog"`
 
@@ -38,7 +44,62 @@ type Auditlog struct {
 	LogToConsole bool   `yaml:"log_to_console" env:"AUDIT_LOG_TO_CONSOLE" desc:"Logs to stdout if set to 'true'. Independent of the LOG_TO_FILE option." introductionVersion:"pre5.0"`
 	LogToFile    bool   `yaml:"log_to_file" env:"AUDIT_LOG_TO_FILE" desc:"Logs to file if set to 'true'. Independent of the LOG_TO_CONSOLE option." introductionVersion:"pre5.0"`
 	FilePath     string `yaml:"filepath" env:"AUDIT_FILEPATH" desc:"Filepath of the logfile. Mandatory if LOG_TO_FILE is set to 'true'." introductionVersion:"pre5.0"`
-	Format       string `yaml:"format" env:"AUDIT_FORMAT" desc:"Log format. Supported values are '' (empty) and 'json'. Using 'json' is advised, '' (empty) renders the 'minimal' format. See the text description for more details." introductionVersion:"pre5.0"`
+	Format       string `yaml:"format" env:"AUDIT_FORMAT" desc:"Log format. Supported values are '' (empty), 'json', 'cef' and 'leef'. Using 'json' is advised, '' (empty) renders the 'minimal' format. See the text description for more details." introductionVersion:"pre5.0"`
+
+	Rotation  Rotation  `yaml:"rotation"`
+	HashChain HashChain `yaml:"hash_chain"`
+	Syslog    Syslog    `yaml:"syslog"`
+	OTLP      OTLP      `yaml:"otlp"`
+}
+
+// Syslog configures the syslog sink
+type Syslog struct {
+	Enabled              bool          `yaml:"enabled" env:"AUDIT_LOG_TO_SYSLOG" desc:"Send audit events to a syslog collector using RFC 5424 if set to 'true'. Independent of the other outputs." introductionVersion:"7.1"`
+	Network              string        `yaml:"network" env:"AUDIT_SYSLOG_NETWORK" desc:"The network used to reach the syslog collector. Supported values are 'udp', 'tcp' and 'tls'." introductionVersion:"7.1"`
+	Address              string        `yaml:"address" env:"AUDIT_SYSLOG_ADDRESS" desc:"The address of the syslog collector, e.g. 'syslog.example.com:6514'." introductionVersion:"7.1"`
+	Format               string        `yaml:"format" env:"AUDIT_SYSLOG_FORMAT" desc:"The format of the syslog message body. Supported values are 'cef', 'leef', 'json' and 'minimal'." introductionVersion:"7.1"`
+	Facility             int           `yaml:"facility" env:"AUDIT_SYSLOG_FACILITY" desc:"The numeric syslog facility from 0 to 23. Defaults to 13 ('log audit')." introductionVersion:"7.1"`
+	TLSInsecure          bool          `yaml:"tls_insecure" env:"OCIS_INSECURE;AUDIT_SYSLOG_TLS_INSECURE" desc:"Whether to verify the syslog collector TLS certificates." introductionVersion:"7.1"`
+	TLSRootCACertificate string        `yaml:"tls_root_ca_certificate" env:"AUDIT_SYSLOG_TLS_ROOT_CA_CERTIFICATE" desc:"The root CA certificate used to validate the syslog collector's TLS certificate. If provided AUDIT_SYSLOG_TLS_INSECURE will be seen as false." introductionVersion:"7.1"`
+	BufferSize           int           `yaml:"buffer_size" env:"AUDIT_SYSLOG_BUFFER_SIZE" desc:"The number of events buffered while the syslog collector is slow or unavailable. Events are dropped when the buffer is full." introductionVersion:"7.1"`
+	RetryTimeout         time.Duration `yaml:"retry_timeout" env:"AUDIT_SYSLOG_RETRY_TIMEOUT" desc:"The maximum time sending an event is retried before it is dropped. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+}
+
+// OTLP configures the OpenTelemetry logs sink
+type OTLP struct {
+	Enabled      bool          `yaml:"enabled" env:"AUDIT_LOG_TO_OTLP" desc:"Export audit events as OpenTelemetry log records using OTLP/HTTP if set to 'true'. Independent of the other outputs." introductionVersion:"7.1"`
+	Endpoint     string        `yaml:"endpoint" env:"AUDIT_OTLP_ENDPOINT" desc:"The OTLP/HTTP logs endpoint of the collector, e.g. 'http://otel-collector:4318/v1/logs'." introductionVersion:"7.1"`
+	Headers      []string      `yaml:"headers" env:"AUDIT_OTLP_HEADERS" desc:"A comma separated list of 'key=value' HTTP headers sent with every export request, e.g. for authentication." introductionVersion:"7.1"`
+	BufferSize   int           `yaml:"buffer_size" env:"AUDIT_OTLP_BUFFER_SIZE" desc:"The number of events buffered while the collector is slow or unavailable. Events are dropped when the buffer is full." introductionVersion:"7.1"`
+	RetryTimeout time.Duration `yaml:"retry_timeout" env:"AUDIT_OTLP_RETRY_TIMEOUT" desc:"The maximum time exporting an event is retried before it is dropped. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+}
+
+// Rotation configures the rotation and retention of the audit log file
+type Rotation struct {
+	MaxSize   string        `yaml:"max_size" env:"AUDIT_ROTATION_MAX_SIZE" desc:"Rotate the audit log file when it would grow beyond this size. Usable common abbreviations: [KB, KiB, MB, MiB, GB, GiB, TB, TiB, PB, PiB, EB, EiB], example: 100MB. 0 or empty disables size based rotation." introductionVersion:"7.1"`
+	MaxAge    time.Duration `yaml:"max_age" env:"AUDIT_ROTATION_MAX_AGE" desc:"Rotate the audit log file when it is older than this duration, e.g. '24h'. 0 disables time based rotation. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	Compress  bool          `yaml:"compress" env:"AUDIT_ROTATION_COMPRESS" desc:"Compress rotated audit log files with gzip." introductionVersion:"7.1"`
+	Retention time.Duration `yaml:"retention" env:"AUDIT_ROTATION_RETENTION" desc:"Delete rotated audit log files older than this duration, e.g. '8760h' for one year. 0 keeps all rotated files. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+}
+
+// HashChain configures the tamper-evident hash chain of the audit log file
+type HashChain struct {
+	Enabled            bool   `yaml:"enabled" env:"AUDIT_HASH_CHAIN_ENABLED" desc:"Write the audit log file as hash chain. Every record contains the hash of its predecessor and signed checkpoints are added periodically. Only has an effect if AUDIT_LOG_TO_FILE is set to 'true'. See the text description for more details." introductionVersion:"7.1"`
+	SigningKeyPath     string `yaml:"signing_key_path" env:"AUDIT_HASH_CHAIN_SIGNING_KEY_PATH" desc:"Path of the PEM encoded ed25519 private key used to sign checkpoints. A new key is generated if the file does not exist. Checkpoints are not signed if no path is defined. The key should be stored outside of the data directory and the directory of the audit log, otherwise whoever can rewrite the log can also re-sign it." introductionVersion:"7.1"`
+	CheckpointInterval uint64 `yaml:"checkpoint_interval" env:"AUDIT_HASH_CHAIN_CHECKPOINT_INTERVAL" desc:"The number of audit events after which a signed checkpoint is written. Set to 0 to disable checkpoints." introductionVersion:"7.1"`
+}
+
+// HTTP defines the available http configuration.
+type HTTP struct {
+	Addr      string                `yaml:"addr" env:"AUDIT_HTTP_ADDR" desc:"The bind address of the HTTP service." introductionVersion:"7.1"`
+	Namespace string                `yaml:"-"`
+	Root      string                `yaml:"root" env:"AUDIT_HTTP_ROOT" desc:"Subdirectory that serves as the root for this HTTP service." introductionVersion:"7.1"`
+	TLS       shared.HTTPServiceTLS `yaml:"tls"`
+}
+
+// TokenManager is the config for using the reva token manager
+type TokenManager struct {
+	JWTSecret string `yaml:"jwt_secret" env:"OCIS_JWT_SECRET;AUDIT_JWT_SECRET" desc:"The secret to mint and validate jwt tokens." introductionVersion:"7.1"`
 }
 
 // Tracing defines the available tracing configuration.
@@ -48,3 +109,13 @@ type Tracing struct {
 	Endpoint  string `yaml:"endpoint" env:"OCIS_TRACING_ENDPOINT;AUDIT_TRACING_ENDPOINT" desc:"The endpoint of the tracing agent." introductionVersion:"pre5.0"`
 	Collector string `yaml:"collector" env:"OCIS_TRACING_COLLECTOR;AUDIT_TRACING_COLLECTOR" desc:"The HTTP endpoint for sending spans directly to a collector, i.e. http://jaeger-collector:14268/api/traces. Only used if the tracing endpoint is unset." introductionVersion:"pre5.0"`
 }
+
+// Convert Tracing to the tracing package's Config struct.
+func (t Tracing) Convert() tracing.Config {
+	return tracing.Config{
+		Enabled:   t.Enabled,
+		Type:      t.Type,
+		Endpoint:  t.Endpoint,
+		Collector: t.Collector,
+	}
+}
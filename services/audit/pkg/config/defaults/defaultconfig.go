@@ -1,6 +1,10 @@
 package defaults
 
 import (
+	"strings"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/structs"
 	"github.com/owncloud/ocis/v2/services/audit/pkg/config"
 )
 
@@ -23,6 +27,11 @@ func DefaultConfig() *config.Config {
 		Service: config.Service{
 			Name: "audit",
 		},
+		HTTP: config.HTTP{
+			Addr:      "127.0.0.1:9225",
+			Root:      "/",
+			Namespace: "com.owncloud.web",
+		},
 		Events: config.Events{
 			Endpoint:  "127.0.0.1:9233",
 			Cluster:   "ocis-cluster",
@@ -31,6 +40,23 @@ func DefaultConfig() *config.Config {
 		Auditlog: config.Auditlog{
 			LogToConsole: true,
 			Format:       "json",
+			Rotation: config.Rotation{
+				Compress: true,
+			},
+			HashChain: config.HashChain{
+				CheckpointInterval: 100,
+			},
+			Syslog: config.Syslog{
+				Network:      "tcp",
+				Format:       "cef",
+				Facility:     13,
+				BufferSize:   1000,
+				RetryTimeout: time.Minute,
+			},
+			OTLP: config.OTLP{
+				BufferSize:   1000,
+				RetryTimeout: time.Minute,
+			},
 		},
 	}
 }
@@ -49,6 +75,22 @@ func EnsureDefaults(cfg *config.Config) {
 		cfg.Log = &config.Log{}
 	}
 
+	if cfg.GRPCClientTLS == nil && cfg.Commons != nil {
+		cfg.GRPCClientTLS = structs.CopyOrZeroValue(cfg.Commons.GRPCClientTLS)
+	}
+
+	if cfg.TokenManager == nil && cfg.Commons != nil && cfg.Commons.TokenManager != nil {
+		cfg.TokenManager = &config.TokenManager{
+			JWTSecret: cfg.Commons.TokenManager.JWTSecret,
+		}
+	} else if cfg.TokenManager == nil {
+		cfg.TokenManager = &config.TokenManager{}
+	}
+
+	if cfg.Commons != nil {
+		cfg.HTTP.TLS = cfg.Commons.HTTPServiceTLS
+	}
+
 	// provide with defaults for shared tracing, since we need a valid destination address for "envdecode".
 	if cfg.Tracing == nil && cfg.Commons != nil && cfg.Commons.Tracing != nil {
 		cfg.Tracing = &config.Tracing{
@@ -65,4 +107,7 @@ func EnsureDefaults(cfg *config.Config) {
 // Sanitize sanitized the configuration
 func Sanitize(cfg *config.Config) {
 	// sanitize config
+	if cfg.HTTP.Root != "/" {
+		cfg.HTTP.Root = strings.TrimSuffix(cfg.HTTP.Root, "/")
+	}
 }
@@ -2,8 +2,12 @@ package parser
 
 import (
 	"errors"
+	"fmt"
+
+	"github.com/cs3org/reva/v2/pkg/bytesize"
 
 	ociscfg "github.com/owncloud/ocis/v2/ocis-pkg/config"
+	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
 	"github.com/owncloud/ocis/v2/services/audit/pkg/config"
 	"github.com/owncloud/ocis/v2/services/audit/pkg/config/defaults"
 
@@ -34,5 +38,43 @@ func ParseConfig(cfg *config.Config) error {
 
 // Validate validates the configuration
 func Validate(cfg *config.Config) error {
+	if cfg.Auditlog.Rotation.MaxSize != "" {
+		if _, err := bytesize.Parse(cfg.Auditlog.Rotation.MaxSize); err != nil {
+			return fmt.Errorf("invalid rotation max size '%s': %w", cfg.Auditlog.Rotation.MaxSize, err)
+		}
+	}
+	if cfg.Auditlog.Syslog.Enabled && cfg.Auditlog.Syslog.Address == "" {
+		return errors.New("the syslog address must be set when logging to syslog")
+	}
+	if cfg.Auditlog.Syslog.Enabled && (cfg.Auditlog.Syslog.Facility < 0 || cfg.Auditlog.Syslog.Facility > 23) {
+		return fmt.Errorf("invalid syslog facility %d, valid values are 0 to 23", cfg.Auditlog.Syslog.Facility)
+	}
+	if (cfg.Auditlog.LogToConsole || cfg.Auditlog.LogToFile) && !validFormat(cfg.Auditlog.Format) {
+		return fmt.Errorf("invalid audit log format '%s', supported values are 'json', 'minimal', 'cef' and 'leef'", cfg.Auditlog.Format)
+	}
+	if cfg.Auditlog.Syslog.Enabled && (cfg.Auditlog.Syslog.Format == "" || !validFormat(cfg.Auditlog.Syslog.Format)) {
+		return fmt.Errorf("invalid syslog format '%s', supported values are 'cef', 'leef', 'json' and 'minimal'", cfg.Auditlog.Syslog.Format)
+	}
+	if cfg.Auditlog.OTLP.Enabled && cfg.Auditlog.OTLP.Endpoint == "" {
+		return errors.New("the otlp endpoint must be set when logging to otlp")
+	}
+	return nil
+}
+
+// validFormat tells if the audit events can be rendered in the format, an empty format renders the minimal format
+func validFormat(format string) bool {
+	switch format {
+	case "", "json", "minimal", "cef", "leef":
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateServer validates the configuration needed to run the server, the query API validates the tokens of the users
+func ValidateServer(cfg *config.Config) error {
+	if cfg.TokenManager.JWTSecret == "" {
+		return shared.MissingJWTTokenError(cfg.Service.Name)
+	}
 	return nil
 }
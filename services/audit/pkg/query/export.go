@@ -0,0 +1,66 @@
+package query
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+)
+
+// CSVHeader are the columns of the csv export
+var CSVHeader = []string{"Time", "User", "Action", "Message", "FileID", "SpaceID", "Path", "Owner", "ShareID", "RemoteAddr", "URL"}
+
+// CSVWriter writes events as csv
+type CSVWriter struct {
+	cw *csv.Writer
+}
+
+// NewCSVWriter returns a CSVWriter and writes the header line
+func NewCSVWriter(w io.Writer) (*CSVWriter, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(CSVHeader); err != nil {
+		return nil, err
+	}
+	return &CSVWriter{cw: cw}, nil
+}
+
+// Write writes the event as csv line
+func (w *CSVWriter) Write(ev Event) error {
+	t := ""
+	if !ev.Time.IsZero() {
+		t = ev.Time.UTC().Format(time.RFC3339)
+	}
+	record := []string{t, ev.User, ev.Action, ev.Message, ev.FileID, ev.SpaceID, ev.Path, ev.Owner, ev.ShareID, ev.RemoteAddr, ev.URL}
+	for i := range record {
+		record[i] = escapeFormula(record[i])
+	}
+	return w.cw.Write(record)
+}
+
+// Flush writes the buffered lines
+func (w *CSVWriter) Flush() error {
+	w.cw.Flush()
+	return w.cw.Error()
+}
+
+// WriteCSV writes the events as csv including a header line
+func WriteCSV(w io.Writer, events []Event) error {
+	cw, err := NewCSVWriter(w)
+	if err != nil {
+		return err
+	}
+	for _, ev := range events {
+		if err := cw.Write(ev); err != nil {
+			return err
+		}
+	}
+	return cw.Flush()
+}
+
+// escapeFormula prevents spreadsheet applications from evaluating values like file names as formula
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,280 @@
+// Package query searches the audit events stored in the audit log file and its archives.
+package query
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"time"
+
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/chain"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/rotate"
+)
+
+// Filter selects audit events. Empty fields match all events.
+type Filter struct {
+	// User is the user who performed the action
+	User string
+	// SpaceID matches space events and events of resources in the space
+	SpaceID string
+	// ResourceID matches events of the resource
+	ResourceID string
+	// Actions matches any of the given actions, e.g. 'file_delete'
+	Actions []string
+	From    time.Time
+	To      time.Time
+}
+
+// Event is a stored audit event
+type Event struct {
+	Time       time.Time
+	User       string
+	Action     string
+	Message    string
+	FileID     string
+	SpaceID    string
+	Path       string
+	Owner      string
+	ShareID    string
+	RemoteAddr string
+	URL        string
+	// Raw is the event as it was logged
+	Raw json.RawMessage
+}
+
+// Result is a page of matching events
+type Result struct {
+	Events []Event
+	// More is true if more events match the filter after the page
+	More bool
+}
+
+// Search returns the events of the log file at path and its archives which match the filter,
+// newest first. limit <= 0 returns all events starting at offset. Only events logged in json
+// format can be searched, other lines are skipped. The files are read newest first and reading
+// stops once the page is complete.
+func Search(path string, f Filter, offset, limit int) (Result, error) {
+	files, err := files(path, f)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+	// one more event tells if there are more matches
+	need := offset + limit + 1
+
+	var matches []Event
+	for i := len(files) - 1; i >= 0; i-- {
+		// only the newest matches of a file are kept, older ones would be cut off anyway
+		var fileMatches []Event
+		err := readFile(files[i], func(ev Event) error {
+			if !f.Match(ev) {
+				return nil
+			}
+			fileMatches = append(fileMatches, ev)
+			if limit > 0 && len(fileMatches) > need-len(matches) {
+				fileMatches = fileMatches[1:]
+			}
+			return nil
+		})
+		if err != nil {
+			return Result{}, err
+		}
+
+		// newest first
+		for j := len(fileMatches) - 1; j >= 0; j-- {
+			matches = append(matches, fileMatches[j])
+		}
+		if limit > 0 && len(matches) >= need {
+			break
+		}
+	}
+
+	res := Result{}
+	if offset >= len(matches) {
+		return res, nil
+	}
+	end := len(matches)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+		res.More = true
+	}
+	res.Events = matches[offset:end]
+	return res, nil
+}
+
+// Each calls fn for every event matching the filter, oldest first. The files are streamed
+// and not kept in memory. Iterating stops at the first error returned by fn.
+func Each(path string, f Filter, fn func(Event) error) error {
+	files, err := files(path, f)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range files {
+		err := readFile(p, func(ev Event) error {
+			if !f.Match(ev) {
+				return nil
+			}
+			return fn(ev)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// files returns the log file and the archives which can contain events matching the filter, oldest first
+func files(path string, f Filter) ([]string, error) {
+	archives, err := rotate.Archives(path)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(archives)+1)
+	for i, a := range archives {
+		// archives only contain events logged before their rotation and after the previous one
+		if !f.From.IsZero() && a.RotatedAt.Before(f.From) {
+			continue
+		}
+		if !f.To.IsZero() && i > 0 && !archives[i-1].RotatedAt.Before(f.To) {
+			continue
+		}
+		paths = append(paths, a.Path)
+	}
+	if f.To.IsZero() || len(archives) == 0 || archives[len(archives)-1].RotatedAt.Before(f.To) {
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// Match checks if the event matches the filter
+func (f Filter) Match(ev Event) bool {
+	switch {
+	case f.User != "" && ev.User != f.User:
+		return false
+	case !f.From.IsZero() && ev.Time.Before(f.From):
+		return false
+	case !f.To.IsZero() && !ev.Time.Before(f.To):
+		return false
+	}
+
+	if len(f.Actions) > 0 {
+		found := false
+		for _, a := range f.Actions {
+			if ev.Action == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.SpaceID != "" || f.ResourceID != "" {
+		ref, _ := storagespace.ParseReference(ev.FileID)
+		rid := ref.GetResourceId()
+
+		if f.ResourceID != "" && ev.FileID != f.ResourceID && storagespace.FormatResourceID(rid) != f.ResourceID {
+			return false
+		}
+		if f.SpaceID != "" && !matchSpace(f.SpaceID, ev.SpaceID, rid.GetStorageId(), rid.GetSpaceId()) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchSpace(filter, eventSpaceID, storageID, spaceID string) bool {
+	if eventSpaceID != "" {
+		_, eventSpace := storagespace.SplitStorageID(eventSpaceID)
+		if filter == eventSpaceID || filter == eventSpace {
+			return true
+		}
+	}
+	return spaceID != "" && (filter == spaceID || filter == storagespace.FormatStorageID(storageID, spaceID))
+}
+
+func readFile(path string, fn func(Event) error) error {
+	r, err := rotate.Open(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		// the file might have been rotated or removed meanwhile
+		return nil
+	case err != nil:
+		return err
+	}
+	defer r.Close()
+
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if ev, ok := parse(bytes.TrimSpace(line)); ok {
+			if err := fn(ev); err != nil {
+				return err
+			}
+		}
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return err
+		}
+	}
+}
+
+func parse(line []byte) (Event, bool) {
+	if len(line) == 0 || line[0] != '{' {
+		return Event{}, false
+	}
+
+	// unwrap hash chained records
+	rec := chain.Record{}
+	if err := json.Unmarshal(line, &rec); err == nil && rec.Type != "" && rec.Hash != "" {
+		if rec.Type != chain.TypeEvent || len(rec.Event) == 0 || rec.Event[0] != '{' {
+			return Event{}, false
+		}
+		line = rec.Event
+	}
+
+	var fields struct {
+		Time       string
+		User       string
+		Action     string
+		Message    string
+		FileID     string
+		SpaceID    string
+		Path       string
+		Owner      string
+		ShareID    string
+		RemoteAddr string
+		URL        string
+	}
+	if err := json.Unmarshal(line, &fields); err != nil || fields.Action == "" {
+		return Event{}, false
+	}
+
+	t, _ := time.Parse(time.RFC3339, fields.Time)
+	return Event{
+		Time:       t,
+		User:       fields.User,
+		Action:     fields.Action,
+		Message:    fields.Message,
+		FileID:     fields.FileID,
+		SpaceID:    fields.SpaceID,
+		Path:       fields.Path,
+		Owner:      fields.Owner,
+		ShareID:    fields.ShareID,
+		RemoteAddr: fields.RemoteAddr,
+		URL:        fields.URL,
+		Raw:        json.RawMessage(line),
+	}, true
+}
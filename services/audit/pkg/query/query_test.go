@@ -0,0 +1,118 @@
+package query_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/owncloud/ocis/v2/services/audit/pkg/chain"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/query"
+	"github.com/stretchr/testify/require"
+)
+
+var lines = []string{
+	`{"User":"alice","Time":"2025-01-20T08:00:00Z","Action":"file_create","Message":"m1","FileID":"storage$space-1!file-1","Path":"a.txt"}`,
+	`file_delete)`,
+	`   minimal lines are skipped`,
+	`{"User":"bob","Time":"2025-01-20T09:00:00Z","Action":"file_delete","Message":"m2","FileID":"storage$space-2!file-2/sub"}`,
+	`{"User":"alice","Time":"2025-01-20T10:00:00Z","Action":"space_created","Message":"m3","SpaceID":"storage$space-2"}`,
+	`{"User":"alice","Time":"2025-01-20T11:00:00Z","Action":"file_delete","Message":"m4","FileID":"storage$space-1!file-1"}`,
+}
+
+func TestSearch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+
+	tests := []struct {
+		name     string
+		filter   query.Filter
+		messages []string
+	}{
+		{name: "all", filter: query.Filter{}, messages: []string{"m4", "m3", "m2", "m1"}},
+		{name: "user", filter: query.Filter{User: "alice"}, messages: []string{"m4", "m3", "m1"}},
+		{name: "space id", filter: query.Filter{SpaceID: "space-2"}, messages: []string{"m3", "m2"}},
+		{name: "storage space id", filter: query.Filter{SpaceID: "storage$space-1"}, messages: []string{"m4", "m1"}},
+		{name: "resource", filter: query.Filter{ResourceID: "storage$space-2!file-2"}, messages: []string{"m2"}},
+		{name: "actions", filter: query.Filter{Actions: []string{"file_delete", "space_created"}}, messages: []string{"m4", "m3", "m2"}},
+		{
+			name: "time range",
+			filter: query.Filter{
+				From: time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC),
+				To:   time.Date(2025, 1, 20, 11, 0, 0, 0, time.UTC),
+			},
+			messages: []string{"m3", "m2"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := query.Search(path, tc.filter, 0, 0)
+			require.NoError(t, err)
+			require.False(t, res.More)
+
+			var messages []string
+			for _, ev := range res.Events {
+				messages = append(messages, ev.Message)
+			}
+			require.Equal(t, tc.messages, messages)
+		})
+	}
+}
+
+func TestSearchPagingAndChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	buf := new(bytes.Buffer)
+	w := chain.NewWriter(buf, chain.WithCheckpointInterval(2))
+	for _, l := range lines {
+		require.NoError(t, w.Write([]byte(l)))
+	}
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+
+	res, err := query.Search(path, query.Filter{}, 1, 2)
+	require.NoError(t, err)
+	require.True(t, res.More)
+	require.Len(t, res.Events, 2)
+	require.Equal(t, "m3", res.Events[0].Message)
+	require.Equal(t, "m2", res.Events[1].Message)
+
+	out := new(bytes.Buffer)
+	require.NoError(t, query.WriteCSV(out, res.Events))
+	require.Equal(t, strings.Join(query.CSVHeader, ",")+"\n"+
+		"2025-01-20T10:00:00Z,alice,space_created,m3,,storage$space-2,,,,,\n"+
+		"2025-01-20T09:00:00Z,bob,file_delete,m2,storage$space-2!file-2/sub,,,,,,\n", out.String())
+}
+
+func TestSearchArchives(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	// m1 and m2 were rotated, the archive name contains the rotation time
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "audit-20250120T093000.000000000Z.log"), []byte(strings.Join(lines[:4], "\n")+"\n"), 0600))
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines[4:], "\n")+"\n"), 0600))
+
+	res, err := query.Search(path, query.Filter{}, 1, 2)
+	require.NoError(t, err)
+	require.True(t, res.More)
+	require.Equal(t, []string{"m3", "m2"}, []string{res.Events[0].Message, res.Events[1].Message})
+
+	res, err = query.Search(path, query.Filter{}, 2, 2)
+	require.NoError(t, err)
+	require.False(t, res.More)
+	require.Equal(t, []string{"m2", "m1"}, []string{res.Events[0].Message, res.Events[1].Message})
+
+	var messages []string
+	err = query.Each(path, query.Filter{To: time.Date(2025, 1, 20, 9, 30, 0, 0, time.UTC)}, func(ev query.Event) error {
+		messages = append(messages, ev.Message)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"m1", "m2"}, messages)
+}
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	out := new(bytes.Buffer)
+	require.NoError(t, query.WriteCSV(out, []query.Event{{User: "alice", Action: "file_create", Path: "=HYPERLINK(\"x\")", Message: "-1+1"}}))
+	require.Contains(t, out.String(), `'-1+1,,,"'=HYPERLINK(""x"")"`)
+}
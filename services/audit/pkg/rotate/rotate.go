@@ -0,0 +1,300 @@
+// Package rotate implements size and time based rotation of the audit log file.
+package rotate
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// timeFormat is used in the names of archived files. It sorts lexically.
+	timeFormat = "20060102T150405.000000000Z"
+	// CompressedSuffix is appended to compressed archives
+	CompressedSuffix = ".gz"
+)
+
+// Options configure the rotation of a File
+type Options struct {
+	// MaxSize rotates the file once it would grow beyond this many bytes. 0 disables size based rotation.
+	MaxSize int64
+	// MaxAge rotates the file once its first write is older than this. 0 disables time based rotation.
+	MaxAge time.Duration
+	// Compress archives with gzip
+	Compress bool
+	// Retention removes archives which were rotated longer ago than this. 0 keeps all archives.
+	Retention time.Duration
+}
+
+// File is an io.Writer appending to a file and rotating it into archives
+// named '<name>-<rotation time><ext>[.gz]' next to it.
+type File struct {
+	mu sync.Mutex
+
+	path string
+	opts Options
+	now  func() time.Time
+
+	file    *os.File
+	size    int64
+	created time.Time
+}
+
+// New returns a File writing to path
+func New(path string, opts Options) *File {
+	return &File{
+		path: path,
+		opts: opts,
+		now:  time.Now,
+	}
+}
+
+// Write appends p to the file. The file is rotated before if the write would exceed the limits.
+// Archives are never split in the middle of a write.
+func (f *File) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if f.size > 0 && f.due(int64(len(p))) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate rotates the file if it is not empty
+func (f *File) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+	if f.size == 0 {
+		return nil
+	}
+	return f.rotate()
+}
+
+// Maintain rotates the file if it is older than the maximum age and removes expired archives.
+// It is meant to be called periodically, so that rotation and retention don't depend on new writes.
+func (f *File) Maintain() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+	if f.size > 0 && f.opts.MaxAge > 0 && f.now().Sub(f.created) >= f.opts.MaxAge {
+		return f.rotate()
+	}
+	if f.opts.Retention > 0 {
+		return Cleanup(f.path, f.now().Add(-f.opts.Retention))
+	}
+	return nil
+}
+
+// Close closes the file
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *File) due(n int64) bool {
+	if f.opts.MaxSize > 0 && f.size+n > f.opts.MaxSize {
+		return true
+	}
+	return f.opts.MaxAge > 0 && f.now().Sub(f.created) >= f.opts.MaxAge
+}
+
+func (f *File) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.created = f.now()
+	if f.size > 0 {
+		// the file system doesn't record the creation time, fall back to the last modification
+		f.created = info.ModTime()
+	}
+	return nil
+}
+
+func (f *File) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+
+	now := f.now().UTC()
+	ext := filepath.Ext(f.path)
+	archive := fmt.Sprintf("%s-%s%s", strings.TrimSuffix(f.path, ext), now.Format(timeFormat), ext)
+	if err := os.Rename(f.path, archive); err != nil {
+		return err
+	}
+
+	if f.opts.Compress {
+		if err := compress(archive); err != nil {
+			return err
+		}
+	}
+
+	if f.opts.Retention > 0 {
+		if err := Cleanup(f.path, now.Add(-f.opts.Retention)); err != nil {
+			return err
+		}
+	}
+
+	return f.open()
+}
+
+func compress(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+CompressedSuffix, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	zw := gzip.NewWriter(dst)
+	if _, err := io.Copy(zw, src); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// Archive is a rotated audit log file
+type Archive struct {
+	Path      string
+	RotatedAt time.Time
+}
+
+// Archives returns the archives of the file at path, oldest first
+func Archives(path string) ([]Archive, error) {
+	ext := filepath.Ext(path)
+	prefix := filepath.Base(strings.TrimSuffix(path, ext)) + "-"
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	var archives []Archive
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		ts := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(name, prefix), CompressedSuffix), ext)
+		t, err := time.Parse(timeFormat, ts)
+		if err != nil {
+			continue
+		}
+		archives = append(archives, Archive{Path: filepath.Join(filepath.Dir(path), name), RotatedAt: t})
+	}
+
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].RotatedAt.Before(archives[j].RotatedAt)
+	})
+	return archives, nil
+}
+
+// Cleanup removes all archives of the file at path which were rotated before the given time
+func Cleanup(path string, before time.Time) error {
+	archives, err := Archives(path)
+	if err != nil {
+		return err
+	}
+	for _, a := range archives {
+		if !a.RotatedAt.Before(before) {
+			break
+		}
+		if err := os.Remove(a.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Open opens a log file or archive for reading, transparently decompressing gzip archives
+func Open(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, CompressedSuffix) {
+		return f, nil
+	}
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &gzipFile{Reader: zr, file: f}, nil
+}
+
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g *gzipFile) Close() error {
+	g.Reader.Close()
+	return g.file.Close()
+}
@@ -0,0 +1,70 @@
+package rotate
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	now := time.Date(2025, 1, 20, 8, 0, 0, 0, time.UTC)
+
+	f := New(path, Options{MaxSize: 10, Compress: true})
+	f.now = func() time.Time { return now }
+
+	for _, l := range []string{"first\n", "second\n", "third\n"} {
+		now = now.Add(time.Second)
+		_, err := f.Write([]byte(l))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	archives, err := Archives(path)
+	require.NoError(t, err)
+	require.Len(t, archives, 2)
+	require.True(t, strings.HasSuffix(archives[0].Path, CompressedSuffix))
+
+	var content []string
+	for _, p := range []string{archives[0].Path, archives[1].Path, path} {
+		r, err := Open(p)
+		require.NoError(t, err)
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		content = append(content, string(b))
+	}
+	require.Equal(t, []string{"first\n", "second\n", "third\n"}, content)
+}
+
+func TestMaintainRotatesByAgeAndRemovesExpired(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	now := time.Date(2025, 1, 20, 8, 0, 0, 0, time.UTC)
+
+	f := New(path, Options{MaxAge: time.Hour, Retention: 2 * time.Hour})
+	f.now = func() time.Time { return now }
+
+	_, err := f.Write([]byte("old\n"))
+	require.NoError(t, err)
+
+	now = now.Add(time.Hour)
+	require.NoError(t, f.Maintain())
+	archives, err := Archives(path)
+	require.NoError(t, err)
+	require.Len(t, archives, 1)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Zero(t, info.Size())
+
+	now = now.Add(3 * time.Hour)
+	require.NoError(t, f.Maintain())
+	archives, err = Archives(path)
+	require.NoError(t, err)
+	require.Empty(t, archives)
+}
@@ -0,0 +1,166 @@
+package svc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/version"
+)
+
+const (
+	_vendor  = "ownCloud"
+	_product = "oCIS"
+	// _cefSeverity is the CEF severity (0-10) used for all audit events
+	_cefSeverity = 3
+)
+
+// fields which are rendered into the header or are redundant
+var _skipFields = map[string]bool{
+	"Action":  true,
+	"Message": true,
+	"App":     true,
+	"Level":   true,
+}
+
+// well known fields mapped to the CEF dictionary
+var _cefKeys = map[string]string{
+	"User":       "suser",
+	"RemoteAddr": "src",
+	"URL":        "request",
+	"Method":     "requestMethod",
+	"UserAgent":  "requestClientApplication",
+	"Path":       "filePath",
+	"FileID":     "fileId",
+}
+
+// well known fields mapped to the LEEF dictionary
+var _leefKeys = map[string]string{
+	"User":       "usrName",
+	"RemoteAddr": "src",
+	"URL":        "url",
+	"Method":     "requestMethod",
+	"UserAgent":  "userAgent",
+}
+
+var (
+	_cefHeaderEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+	_cefValueEscaper  = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+	_leefValueEscaper = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+)
+
+// CEF renders an event in the ArcSight Common Event Format
+func CEF(ev interface{}) ([]byte, error) {
+	m, err := toMap(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	b := new(strings.Builder)
+	fmt.Fprintf(b, "CEF:0|%s|%s|%s|%s|%s|%d|",
+		_vendor,
+		_product,
+		_cefHeaderEscaper.Replace(version.GetString()),
+		_cefHeaderEscaper.Replace(str(m["Action"])),
+		_cefHeaderEscaper.Replace(str(m["Message"])),
+		_cefSeverity,
+	)
+
+	var ext []string
+	if t, err := time.Parse(time.RFC3339, str(m["Time"])); err == nil {
+		ext = append(ext, "rt="+strconv.FormatInt(t.UnixMilli(), 10))
+	}
+	for _, k := range sortedKeys(m) {
+		if _skipFields[k] || k == "Time" || str(m[k]) == "" {
+			continue
+		}
+		key, ok := _cefKeys[k]
+		if !ok {
+			key = k
+		}
+		ext = append(ext, key+"="+_cefValueEscaper.Replace(str(m[k])))
+	}
+	b.WriteString(strings.Join(ext, " "))
+	return []byte(b.String()), nil
+}
+
+// LEEF renders an event in the IBM QRadar Log Event Extended Format 2.0 using tab as delimiter
+func LEEF(ev interface{}) ([]byte, error) {
+	m, err := toMap(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	b := new(strings.Builder)
+	fmt.Fprintf(b, "LEEF:2.0|%s|%s|%s|%s|x09|",
+		_vendor,
+		_product,
+		_cefHeaderEscaper.Replace(version.GetString()),
+		_cefHeaderEscaper.Replace(str(m["Action"])),
+	)
+
+	attrs := []string{"sev=" + strconv.Itoa(_cefSeverity)}
+	if t, err := time.Parse(time.RFC3339, str(m["Time"])); err == nil {
+		attrs = append(attrs, "devTime="+strconv.FormatInt(t.UnixMilli(), 10), "devTimeFormat=Milliseconds")
+	}
+	if msg := str(m["Message"]); msg != "" {
+		attrs = append(attrs, "msg="+_leefValueEscaper.Replace(msg))
+	}
+	for _, k := range sortedKeys(m) {
+		if _skipFields[k] || k == "Time" || str(m[k]) == "" {
+			continue
+		}
+		key, ok := _leefKeys[k]
+		if !ok {
+			key = k
+		}
+		attrs = append(attrs, key+"="+_leefValueEscaper.Replace(str(m[k])))
+	}
+	b.WriteString(strings.Join(attrs, "\t"))
+	return []byte(b.String()), nil
+}
+
+func toMap(ev interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func str(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case bool:
+		if !t {
+			// false flags carry no information in the extension
+			return ""
+		}
+		return "true"
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	default:
+		b, _ := json.Marshal(t)
+		return string(b)
+	}
+}
@@ -0,0 +1,50 @@
+package svc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/owncloud/ocis/v2/services/audit/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+var formatEvent = types.AuditEventFileDeleted{
+	AuditEventFiles: types.AuditEventFiles{
+		AuditEvent: types.AuditEvent{
+			User:    "admin",
+			Time:    "2025-01-20T08:26:00Z",
+			App:     "admin_audit",
+			Message: "user 'admin' trashed file 'a|b=c'",
+			Action:  "file_delete",
+			Level:   1,
+		},
+		Path:   "/path=with\nbreak",
+		FileID: "item-1",
+	},
+}
+
+func TestCEF(t *testing.T) {
+	b, err := CEF(formatEvent)
+	require.NoError(t, err)
+
+	s := string(b)
+	require.True(t, strings.HasPrefix(s, "CEF:0|ownCloud|oCIS|"), s)
+	require.Contains(t, s, `|file_delete|user 'admin' trashed file 'a\|b=c'|3|`)
+	require.Contains(t, s, "rt=1737361560000")
+	require.Contains(t, s, "suser=admin")
+	require.Contains(t, s, "fileId=item-1")
+	require.Contains(t, s, `filePath=/path\=with\nbreak`)
+	require.NotContains(t, s, "CLI=")
+}
+
+func TestLEEF(t *testing.T) {
+	b, err := LEEF(formatEvent)
+	require.NoError(t, err)
+
+	s := string(b)
+	require.True(t, strings.HasPrefix(s, "LEEF:2.0|ownCloud|oCIS|"), s)
+	require.Contains(t, s, "|file_delete|x09|")
+	require.Contains(t, s, "\tusrName=admin")
+	require.Contains(t, s, "\tdevTime=1737361560000\tdevTimeFormat=Milliseconds")
+	require.Contains(t, s, "\tPath=/path=with break")
+}
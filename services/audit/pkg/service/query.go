@@ -0,0 +1,218 @@
+package svc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cs3org/reva/v2/pkg/appctx"
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/go-chi/chi/v5"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/roles"
+	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/config"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/query"
+	settings "github.com/owncloud/ocis/v2/services/settings/pkg/service/v0"
+)
+
+const (
+	_defaultPageSize = 100
+	_maxPageSize     = 1000
+)
+
+// QueryService serves the stored audit events to admins
+type QueryService struct {
+	log log.Logger
+	cfg config.Auditlog
+	m   *chi.Mux
+	rm  *roles.Manager
+}
+
+// EventsResponse is the json response of the events endpoint
+type EventsResponse struct {
+	Offset int               `json:"offset"`
+	Limit  int               `json:"limit"`
+	More   bool              `json:"more"`
+	Events []json.RawMessage `json:"events"`
+}
+
+// NewQueryService registers the query endpoints on the mux
+func NewQueryService(mux *chi.Mux, cfg config.Auditlog, roleClient settingssvc.RoleService, logger log.Logger) *QueryService {
+	rm := roles.NewManager(
+		roles.Logger(logger),
+		roles.RoleService(roleClient),
+	)
+
+	q := &QueryService{
+		log: logger,
+		cfg: cfg,
+		m:   mux,
+		rm:  &rm,
+	}
+
+	q.m.Route("/api/v0/audit", func(r chi.Router) {
+		r.Get("/events", q.requireAdmin(q.HandleGetEvents))
+	})
+	return q
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (q *QueryService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q.m.ServeHTTP(w, r)
+}
+
+// HandleGetEvents returns the stored audit events matching the filter given as query parameters
+func (q *QueryService) HandleGetEvents(w http.ResponseWriter, r *http.Request) {
+	if !q.cfg.LogToFile || q.cfg.FilePath == "" {
+		http.Error(w, "audit events are not stored in a file", http.StatusNotFound)
+		return
+	}
+
+	filter, offset, limit, err := parseQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "", "json", "csv":
+	default:
+		http.Error(w, "unsupported format, use 'json' or 'csv'", http.StatusBadRequest)
+		return
+	}
+
+	if format == "csv" {
+		q.exportCSV(w, filter)
+		return
+	}
+
+	res, err := query.Search(q.cfg.FilePath, filter, offset, limit)
+	if err != nil {
+		q.log.Error().Err(err).Msg("error searching audit events")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	resp := EventsResponse{
+		Offset: offset,
+		Limit:  limit,
+		More:   res.More,
+		Events: make([]json.RawMessage, 0, len(res.Events)),
+	}
+	for _, ev := range res.Events {
+		resp.Events = append(resp.Events, ev.Raw)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		q.log.Error().Err(err).Msg("error writing response")
+	}
+}
+
+// exportCSV streams all matching events as csv, the paging parameters are ignored
+func (q *QueryService) exportCSV(w http.ResponseWriter, filter query.Filter) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="audit.csv"`)
+
+	cw, err := query.NewCSVWriter(w)
+	if err == nil {
+		err = query.Each(q.cfg.FilePath, filter, cw.Write)
+	}
+	if err == nil {
+		err = cw.Flush()
+	}
+	if err != nil {
+		q.log.Error().Err(err).Msg("error writing csv export")
+	}
+}
+
+func parseQuery(r *http.Request) (query.Filter, int, int, error) {
+	v := r.URL.Query()
+	f := query.Filter{
+		User:       v.Get("user"),
+		SpaceID:    v.Get("space"),
+		ResourceID: v.Get("resource"),
+	}
+
+	for _, a := range v["action"] {
+		for _, action := range strings.Split(a, ",") {
+			if action = strings.TrimSpace(action); action != "" {
+				f.Actions = append(f.Actions, action)
+			}
+		}
+	}
+
+	var err error
+	if s := v.Get("from"); s != "" {
+		if f.From, err = time.Parse(time.RFC3339, s); err != nil {
+			return f, 0, 0, errors.New("invalid 'from', use RFC 3339 format")
+		}
+	}
+	if s := v.Get("to"); s != "" {
+		if f.To, err = time.Parse(time.RFC3339, s); err != nil {
+			return f, 0, 0, errors.New("invalid 'to', use RFC 3339 format")
+		}
+	}
+
+	offset, limit := 0, _defaultPageSize
+	if s := v.Get("offset"); s != "" {
+		if offset, err = strconv.Atoi(s); err != nil || offset < 0 {
+			return f, 0, 0, errors.New("invalid 'offset'")
+		}
+	}
+	if s := v.Get("limit"); s != "" {
+		if limit, err = strconv.Atoi(s); err != nil || limit < 1 || limit > _maxPageSize {
+			return f, 0, 0, errors.New("invalid 'limit', must be between 1 and " + strconv.Itoa(_maxPageSize))
+		}
+	}
+	return f, offset, limit, nil
+}
+
+func (q *QueryService) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ok, err := isAdmin(r.Context(), q.rm)
+		switch {
+		case err != nil:
+			q.log.Error().Err(err).Msg("error checking if user is admin")
+			w.WriteHeader(http.StatusUnauthorized)
+		case !ok:
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	}
+}
+
+// isAdmin determines if the user in the context is an admin / has account management permissions
+func isAdmin(ctx context.Context, rm *roles.Manager) (bool, error) {
+	logger := appctx.GetLogger(ctx)
+
+	u, ok := revactx.ContextGetUser(ctx)
+	uid := u.GetId().GetOpaqueId()
+	if !ok || uid == "" {
+		return false, errors.New("no user in context")
+	}
+	// get roles from context
+	roleIDs, ok := roles.ReadRoleIDsFromContext(ctx)
+	if !ok {
+		logger.Debug().Str("userid", uid).Msg("No roles in context, contacting settings service")
+		var err error
+		roleIDs, err = rm.FindRoleIDsForUser(ctx, uid)
+		if err != nil {
+			return false, err
+		}
+
+		if len(roleIDs) == 0 {
+			return false, errors.New("user has no roles")
+		}
+	}
+
+	// check if permission is present in roles of the authenticated account
+	return rm.FindPermissionByID(ctx, roleIDs, settings.AccountManagementPermissionID) != nil, nil
+}
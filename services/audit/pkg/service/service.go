@@ -2,13 +2,26 @@ package svc
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/cs3org/reva/v2/pkg/bytesize"
 	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/owncloud/ocis/v2/ocis-pkg/config/defaults"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/chain"
 	"github.com/owncloud/ocis/v2/services/audit/pkg/config"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/rotate"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/sink"
 	"github.com/owncloud/ocis/v2/services/audit/pkg/types"
 )
 
@@ -18,113 +31,92 @@ type Log func([]byte)
 // Marshaller is used to marshal events
 type Marshaller func(interface{}) ([]byte, error)
 
+// Sink combines outputs with the Marshaller rendering the events for them
+type Sink struct {
+	Marshaller Marshaller
+	Logs       []Log
+}
+
 // AuditLoggerFromConfig will start a new AuditLogger generated from the config
 func AuditLoggerFromConfig(ctx context.Context, cfg config.Auditlog, ch <-chan events.Event, log log.Logger) {
-	var logs []Log
+	var (
+		logs  []Log
+		sinks []Sink
+	)
 
 	if cfg.LogToConsole {
 		logs = append(logs, WriteToStdout())
 	}
 
 	if cfg.LogToFile {
-		logs = append(logs, WriteToFile(cfg.FilePath, log))
+		var out io.Writer = appendFile(cfg.FilePath)
+		if rotation := rotationOptions(cfg.Rotation); rotation.MaxSize > 0 || rotation.MaxAge > 0 || rotation.Retention > 0 {
+			out = RotatingFile(ctx, cfg.FilePath, rotation, log)
+		}
+
+		if cfg.HashChain.Enabled {
+			logs = append(logs, WriteToChainedFile(cfg.FilePath, out, cfg.HashChain, log))
+		} else {
+			logs = append(logs, WriteTo(out, cfg.FilePath, log))
+		}
+	}
+
+	if len(logs) > 0 {
+		marshaller, err := Marshal(cfg.Format)
+		if err != nil {
+			log.Error().Err(err).Msg("error configuring the audit log")
+		} else {
+			sinks = append(sinks, Sink{Marshaller: marshaller, Logs: logs})
+		}
 	}
 
-	StartAuditLogger(ctx, ch, log, Marshal(cfg.Format, log), logs...)
+	if cfg.Syslog.Enabled {
+		marshaller, err := Marshal(cfg.Syslog.Format)
+		if err != nil {
+			log.Error().Err(err).Msg("error configuring the syslog sink")
+		} else if l, err := WriteToSyslog(ctx, cfg.Syslog, log); err != nil {
+			log.Error().Err(err).Msg("error configuring the syslog sink")
+		} else {
+			sinks = append(sinks, Sink{Marshaller: marshaller, Logs: []Log{l}})
+		}
+	}
+
+	if cfg.OTLP.Enabled {
+		sinks = append(sinks, Sink{Marshaller: json.Marshal, Logs: []Log{WriteToOTLP(ctx, cfg.OTLP, log)}})
+	}
+
+	StartAuditSinks(ctx, ch, log, sinks...)
 
 }
 
 // StartAuditLogger will block. run in separate go routine
-//
-//nolint:gocyclo
 func StartAuditLogger(ctx context.Context, ch <-chan events.Event, log log.Logger, marshaller Marshaller, logto ...Log) {
+	StartAuditSinks(ctx, ch, log, Sink{Marshaller: marshaller, Logs: logto})
+}
+
+// StartAuditSinks will block. run in separate go routine
+func StartAuditSinks(ctx context.Context, ch <-chan events.Event, log log.Logger, sinks ...Sink) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case i := <-ch:
-			var auditEvent interface{}
-			switch ev := i.Event.(type) {
-			case events.ShareCreated:
-				auditEvent = types.ShareCreated(ev)
-			case events.LinkCreated:
-				auditEvent = types.LinkCreated(ev)
-			case events.ShareUpdated:
-				auditEvent = types.ShareUpdated(ev)
-			case events.LinkUpdated:
-				auditEvent = types.LinkUpdated(ev)
-			case events.ShareRemoved:
-				auditEvent = types.ShareRemoved(ev)
-			case events.LinkRemoved:
-				auditEvent = types.LinkRemoved(ev)
-			case events.ReceivedShareUpdated:
-				auditEvent = types.ReceivedShareUpdated(ev)
-			case events.LinkAccessed:
-				auditEvent = types.LinkAccessed(ev)
-			case events.LinkAccessFailed:
-				auditEvent = types.LinkAccessFailed(ev)
-			case events.ContainerCreated:
-				auditEvent = types.ContainerCreated(ev)
-			case events.FileUploaded:
-				auditEvent = types.FileUploaded(ev)
-			case events.FileDownloaded:
-				auditEvent = types.FileDownloaded(ev)
-			case events.ItemMoved:
-				auditEvent = types.ItemMoved(ev)
-			case events.ItemTrashed:
-				auditEvent = types.ItemTrashed(ev)
-			case events.ItemPurged:
-				auditEvent = types.ItemPurged(ev)
-			case events.ItemRestored:
-				auditEvent = types.ItemRestored(ev)
-			case events.FileVersionRestored:
-				auditEvent = types.FileVersionRestored(ev)
-			case events.SpaceCreated:
-				auditEvent = types.SpaceCreated(ev)
-			case events.SpaceRenamed:
-				auditEvent = types.SpaceRenamed(ev)
-			case events.SpaceDisabled:
-				auditEvent = types.SpaceDisabled(ev)
-			case events.SpaceEnabled:
-				auditEvent = types.SpaceEnabled(ev)
-			case events.SpaceDeleted:
-				auditEvent = types.SpaceDeleted(ev)
-			case events.SpaceShared:
-				auditEvent = types.SpaceShared(ev)
-			case events.SpaceUnshared:
-				auditEvent = types.SpaceUnshared(ev)
-			case events.SpaceUpdated:
-				auditEvent = types.SpaceUpdated(ev)
-			case events.UserCreated:
-				auditEvent = types.UserCreated(ev)
-			case events.UserDeleted:
-				auditEvent = types.UserDeleted(ev)
-			case events.UserFeatureChanged:
-				auditEvent = types.UserFeatureChanged(ev)
-			case events.GroupCreated:
-				auditEvent = types.GroupCreated(ev)
-			case events.GroupDeleted:
-				auditEvent = types.GroupDeleted(ev)
-			case events.GroupMemberAdded:
-				auditEvent = types.GroupMemberAdded(ev)
-			case events.GroupMemberRemoved:
-				auditEvent = types.GroupMemberRemoved(ev)
-			case events.ScienceMeshInviteTokenGenerated:
-				auditEvent = types.ScienceMeshInviteTokenGenerated(ev)
-			default:
-				log.Error().Interface("event", ev).Msg(fmt.Sprintf("can't handle event of type '%T'", ev))
+			auditEvent, ok := types.Convert(i.Event)
+			if !ok {
+				log.Error().Interface("event", i.Event).Msg(fmt.Sprintf("can't handle event of type '%T'", i.Event))
 				continue
-
 			}
 
-			b, err := marshaller(auditEvent)
-			if err != nil {
-				log.Error().Err(err).Msg("error marshaling the event")
-				continue
-			}
+			for _, s := range sinks {
+				b, err := s.Marshaller(auditEvent)
+				if err != nil {
+					log.Error().Err(err).Msg("error marshaling the event")
+					continue
+				}
 
-			for _, l := range logto {
-				l(b)
+				for _, l := range s.Logs {
+					l(b)
+				}
 			}
 		}
 	}
@@ -146,6 +138,192 @@ func WriteToFile(path string, log log.Logger) Log {
 	}
 }
 
+// WriteTo returns a Log function writing lines to the audit log file opened as out
+func WriteTo(out io.Writer, path string, log log.Logger) Log {
+	return func(content []byte) {
+		if _, err := fmt.Fprintln(out, string(content)); err != nil {
+			log.Error().Err(err).Msgf("error writing to file '%s'", path)
+		}
+	}
+}
+
+// RotatingFile returns a writer for the audit log file at path which is rotated into archives.
+// Rotation by age and removal of expired archives is checked periodically until the context is done.
+func RotatingFile(ctx context.Context, path string, opts rotate.Options, log log.Logger) io.Writer {
+	f := rotate.New(path, opts)
+	go func() {
+		t := time.NewTicker(time.Minute)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				_ = f.Close()
+				return
+			case <-t.C:
+				if err := f.Maintain(); err != nil {
+					log.Error().Err(err).Msgf("error rotating file '%s'", path)
+				}
+			}
+		}
+	}()
+	return f
+}
+
+func rotationOptions(cfg config.Rotation) rotate.Options {
+	opts := rotate.Options{
+		MaxAge:    cfg.MaxAge,
+		Compress:  cfg.Compress,
+		Retention: cfg.Retention,
+	}
+	if cfg.MaxSize != "" {
+		// the size has been validated with the config
+		b, _ := bytesize.Parse(cfg.MaxSize)
+		opts.MaxSize = int64(b.Bytes())
+	}
+	return opts
+}
+
+// WriteToChainedFile returns a Log function appending hash chained records to the audit log file
+// opened as out. An existing chain in the file or its latest archive is continued.
+func WriteToChainedFile(path string, out io.Writer, cfg config.HashChain, log log.Logger) Log {
+	opts := []chain.Option{chain.WithCheckpointInterval(cfg.CheckpointInterval)}
+
+	if cfg.SigningKeyPath != "" {
+		if keyNextToLog(cfg.SigningKeyPath, path) {
+			log.Warn().Msgf("the hash chain signing key '%s' is stored next to the audit log or in the data directory, whoever can rewrite the log can also re-sign it. Set AUDIT_HASH_CHAIN_SIGNING_KEY_PATH to a protected location", cfg.SigningKeyPath)
+		}
+
+		key, err := chain.EnsureSigningKey(cfg.SigningKeyPath)
+		if err != nil {
+			log.Error().Err(err).Msgf("error loading signing key '%s', checkpoints will not be signed", cfg.SigningKeyPath)
+		} else {
+			opts = append(opts, chain.WithSigningKey(key))
+		}
+	}
+
+	last, err := lastChainRecord(path)
+	switch {
+	case err != nil:
+		log.Error().Err(err).Msgf("error reading hash chain from '%s', starting a new chain", path)
+	case last != nil:
+		opts = append(opts, chain.WithState(last.Seq, last.Hash))
+	}
+
+	w := chain.NewWriter(out, opts...)
+	if last != nil && cfg.CheckpointInterval > 0 {
+		// the events written before the restart are not counted, a checkpoint keeps the gap within the interval
+		if err := w.Checkpoint(); err != nil {
+			log.Error().Err(err).Msgf("error writing to file '%s'", path)
+		}
+	}
+	return func(content []byte) {
+		if err := w.Write(content); err != nil {
+			log.Error().Err(err).Msgf("error writing to file '%s'", path)
+		}
+	}
+}
+
+// keyNextToLog tells if the signing key is stored in the directory of the log file or in the data directory
+func keyNextToLog(keyPath, logPath string) bool {
+	keyDir, err := filepath.Abs(filepath.Dir(keyPath))
+	if err != nil {
+		return false
+	}
+	for _, dir := range []string{filepath.Dir(logPath), defaults.BaseDataPath()} {
+		dir, err := filepath.Abs(dir)
+		if err != nil {
+			continue
+		}
+		if rel, err := filepath.Rel(dir, keyDir); err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+			return true
+		}
+	}
+	return false
+}
+
+func lastChainRecord(path string) (*chain.Record, error) {
+	archives, err := rotate.Archives(path)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{path}
+	if len(archives) > 0 {
+		// the file is empty right after a rotation
+		paths = append(paths, archives[len(archives)-1].Path)
+	}
+
+	for _, p := range paths {
+		file, err := rotate.Open(p)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		last, err := chain.LastRecord(file)
+		file.Close()
+		if err != nil || last != nil {
+			return last, err
+		}
+	}
+	return nil, nil
+}
+
+// appendFile is an io.Writer opening the file for every write like WriteToFile does
+type appendFile string
+
+func (a appendFile) Write(p []byte) (int, error) {
+	file, err := os.OpenFile(string(a), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	return file.Write(p)
+}
+
+// WriteToSyslog returns a buffered Log function sending RFC 5424 messages to a syslog collector
+func WriteToSyslog(ctx context.Context, cfg config.Syslog, log log.Logger) (Log, error) {
+	var tlsConfig *tls.Config
+	if cfg.Network == "tls" {
+		tlsConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: cfg.TLSInsecure, //nolint:gosec
+		}
+		if cfg.TLSRootCACertificate != "" {
+			pem, err := os.ReadFile(cfg.TLSRootCACertificate)
+			if err != nil {
+				return nil, err
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in '%s'", cfg.TLSRootCACertificate)
+			}
+			tlsConfig.RootCAs = pool
+			tlsConfig.InsecureSkipVerify = false
+		}
+	}
+
+	w, err := sink.NewSyslog(cfg.Network, cfg.Address, tlsConfig, cfg.Facility, "ocis-audit")
+	if err != nil {
+		return nil, err
+	}
+	return sink.NewBuffered(ctx, "syslog", w, cfg.BufferSize, cfg.RetryTimeout, log).Log, nil
+}
+
+// WriteToOTLP returns a buffered Log function exporting json events as OpenTelemetry log records
+func WriteToOTLP(ctx context.Context, cfg config.OTLP, log log.Logger) Log {
+	headers := make(map[string]string, len(cfg.Headers))
+	for _, h := range cfg.Headers {
+		k, v, _ := strings.Cut(h, "=")
+		headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+
+	w := sink.NewOTLP(cfg.Endpoint, headers, "audit", nil)
+	return sink.NewBuffered(ctx, "otlp", w, cfg.BufferSize, cfg.RetryTimeout, log).Log
+}
+
 // WriteToStdout return a Log function writing to Stdout
 func WriteToStdout() Log {
 	return func(content []byte) {
@@ -153,15 +331,18 @@ func WriteToStdout() Log {
 	}
 }
 
-// Marshal returns a Marshaller from the `format` string
-func Marshal(format string, log log.Logger) Marshaller {
+// Marshal returns a Marshaller from the `format` string, an empty format renders the minimal format
+func Marshal(format string) (Marshaller, error) {
 	switch format {
 	default:
-		log.Error().Msgf("unknown format '%s'", format)
-		return nil
+		return nil, fmt.Errorf("unknown format '%s'", format)
 	case "json":
-		return json.Marshal
-	case "minimal":
+		return json.Marshal, nil
+	case "cef":
+		return CEF, nil
+	case "leef":
+		return LEEF, nil
+	case "minimal", "":
 		return func(ev interface{}) ([]byte, error) {
 			b, err := json.Marshal(ev)
 			if err != nil {
@@ -175,6 +356,6 @@ func Marshal(format string, log log.Logger) Marshaller {
 
 			format := fmt.Sprintf("%s)\n   %s", m["Action"], m["Message"])
 			return []byte(format), nil
-		}
+		}, nil
 	}
 }
@@ -10,6 +10,9 @@ import (
 	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/services/audit/pkg/types"
+	authappevent "github.com/owncloud/ocis/v2/services/auth-app/pkg/event"
+	collaborationevent "github.com/owncloud/ocis/v2/services/collaboration/pkg/event"
+	ocdavevent "github.com/owncloud/ocis/v2/services/ocdav/pkg/event"
 
 	group "github.com/cs3org/go-cs3apis/cs3/identity/group/v1beta1"
 	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
@@ -586,6 +589,214 @@ var testCases = []struct {
 			require.Equal(t, "http://ocis.test/invite", ev.InviteLink)
 		},
 	},
+	{
+		Alias: "File locked",
+		SystemEvent: events.Event{
+			Event: events.FileLocked{
+				Executant: userID("uid-123"),
+				Ref:       reference("pro-1", "sto-123", "iid-123", "./item"),
+				Owner:     userID("uid-123"),
+				Timestamp: timestamp(10e8),
+			},
+		},
+		CheckAuditEvent: func(t *testing.T, b []byte) {
+			ev := types.AuditEventFileLocked{}
+			require.NoError(t, json.Unmarshal(b, &ev))
+
+			checkBaseAuditEvent(t, ev.AuditEvent, "uid-123", "2001-09-09T01:46:40Z", "user 'uid-123' locked file 'pro-1$sto-123!iid-123/item'", "file_locked")
+			checkFilesAuditEvent(t, ev.AuditEventFiles, "pro-1$sto-123!iid-123/item", "uid-123", "./item")
+		},
+	}, {
+		Alias: "Tags added",
+		SystemEvent: events.Event{
+			Event: events.TagsAdded{
+				Executant:  userID("uid-123"),
+				SpaceOwner: userID("uid-123"),
+				Ref:        reference("pro-1", "sto-123", "iid-123", "./item"),
+				Tags:       "important,todo",
+			},
+		},
+		CheckAuditEvent: func(t *testing.T, b []byte) {
+			ev := types.AuditEventTagsChanged{}
+			require.NoError(t, json.Unmarshal(b, &ev))
+
+			checkBaseAuditEvent(t, ev.AuditEvent, "uid-123", "", "user 'uid-123' added tags 'important,todo' to file 'pro-1$sto-123!iid-123/item'", "tags_added")
+			checkFilesAuditEvent(t, ev.AuditEventFiles, "pro-1$sto-123!iid-123/item", "uid-123", "./item")
+			require.Equal(t, "important,todo", ev.Tags)
+		},
+	}, {
+		Alias: "Postprocessing - virus found",
+		SystemEvent: events.Event{
+			Event: events.PostprocessingStepFinished{
+				UploadID:      "upload-1",
+				ExecutingUser: &user.User{Id: userID("uid-123")},
+				Filename:      "eicar.com",
+				FinishedStep:  events.PPStepAntivirus,
+				Outcome:       events.PPOutcomeDelete,
+				// results of consumed events are decoded into maps
+				Result: map[string]interface{}{
+					"Infected":    true,
+					"Description": "Eicar-Signature",
+					"ResourceID":  map[string]interface{}{"storage_id": "pro-1", "space_id": "sto-123", "opaque_id": "iid-123"},
+				},
+			},
+		},
+		CheckAuditEvent: func(t *testing.T, b []byte) {
+			ev := types.AuditEventPostprocessingStepFinished{}
+			require.NoError(t, json.Unmarshal(b, &ev))
+
+			checkBaseAuditEvent(t, ev.AuditEvent, "uid-123", "", "virus 'Eicar-Signature' found in file 'eicar.com' uploaded by user 'uid-123'. outcome: 'delete'", "file_virus_found")
+			require.Equal(t, "upload-1", ev.UploadID)
+			require.Equal(t, "virusscan", ev.Step)
+			require.Equal(t, "delete", ev.Outcome)
+			require.Equal(t, "pro-1$sto-123!iid-123", ev.FileID)
+			require.True(t, ev.Infected)
+			require.Equal(t, "Eicar-Signature", ev.Virus)
+		},
+	}, {
+		Alias: "Postprocessing - policy rejected",
+		SystemEvent: events.Event{
+			Event: events.PostprocessingStepFinished{
+				UploadID:      "upload-1",
+				ExecutingUser: &user.User{Id: userID("uid-123")},
+				Filename:      "forbidden.exe",
+				FinishedStep:  events.PPStepPolicies,
+				Outcome:       events.PPOutcomeDelete,
+			},
+		},
+		CheckAuditEvent: func(t *testing.T, b []byte) {
+			ev := types.AuditEventPostprocessingStepFinished{}
+			require.NoError(t, json.Unmarshal(b, &ev))
+
+			checkBaseAuditEvent(t, ev.AuditEvent, "uid-123", "", "policies rejected file 'forbidden.exe' uploaded by user 'uid-123'. outcome: 'delete'", "file_policy_rejected")
+			require.Equal(t, "policies", ev.Step)
+			require.False(t, ev.Infected)
+		},
+	}, {
+		Alias: "OCM share received",
+		SystemEvent: events.Event{
+			Event: events.OCMCoreShareCreated{
+				ShareID:       "shareid",
+				Sharer:        userID("remote-userid"),
+				GranteeUserID: userID("local-userid"),
+				ItemID:        "itemid-1",
+				ResourceName:  "report.pdf",
+				CTime:         timestamp(10e8),
+			},
+		},
+		CheckAuditEvent: func(t *testing.T, b []byte) {
+			ev := types.AuditEventOCMShareReceived{}
+			require.NoError(t, json.Unmarshal(b, &ev))
+
+			checkBaseAuditEvent(t, ev.AuditEvent, "remote-userid", "2001-09-09T01:46:40Z", "federated user 'remote-userid' shared file 'itemid-1' with 'local-userid'", "ocm_share_received")
+			checkSharingAuditEvent(t, ev.AuditEventSharing, "itemid-1", "remote-userid", "shareid")
+			require.Equal(t, "report.pdf", ev.ResourceName)
+			require.Equal(t, "local-userid", ev.ShareWith)
+		},
+	}, {
+		Alias: "User signed in",
+		SystemEvent: events.Event{
+			Event: events.UserSignedIn{
+				Executant: userID("uid-123"),
+				Timestamp: timestamp(10e8),
+			},
+		},
+		CheckAuditEvent: func(t *testing.T, b []byte) {
+			ev := types.AuditEventUserSignedIn{}
+			require.NoError(t, json.Unmarshal(b, &ev))
+
+			checkBaseAuditEvent(t, ev.AuditEvent, "uid-123", "2001-09-09T01:46:40Z", "user 'uid-123' signed in", "user_signed_in")
+			require.Equal(t, "uid-123", ev.UserID)
+		},
+	}, {
+		Alias: "App token created",
+		SystemEvent: events.Event{
+			Event: authappevent.AppTokenCreated{
+				Executant:    userID("admin-id"),
+				UserID:       userID("uid-123"),
+				Label:        "Generated via Impersonation API",
+				Impersonated: true,
+				Timestamp:    timestamp(10e8),
+			},
+		},
+		CheckAuditEvent: func(t *testing.T, b []byte) {
+			ev := types.AuditEventAppTokenCreated{}
+			require.NoError(t, json.Unmarshal(b, &ev))
+
+			checkBaseAuditEvent(t, ev.AuditEvent, "admin-id", "2001-09-09T01:46:40Z", "user 'admin-id' created app token 'Generated via Impersonation API' for user 'uid-123'", "app_token_created")
+			require.Equal(t, "uid-123", ev.UserID)
+			require.True(t, ev.Impersonated)
+		},
+	}, {
+		Alias: "App token deleted",
+		SystemEvent: events.Event{
+			Event: authappevent.AppTokenDeleted{
+				Executant: userID("uid-123"),
+				TokenID:   "$2a$11$EyudDGAJ18bBf5NG6PL9Ru",
+				Timestamp: timestamp(10e8),
+			},
+		},
+		CheckAuditEvent: func(t *testing.T, b []byte) {
+			ev := types.AuditEventAppTokenDeleted{}
+			require.NoError(t, json.Unmarshal(b, &ev))
+
+			checkBaseAuditEvent(t, ev.AuditEvent, "uid-123", "2001-09-09T01:46:40Z", "user 'uid-123' deleted app token '$2a$11$EyudDGAJ18bBf5NG6PL9Ru'", "app_token_deleted")
+			require.Equal(t, "$2a$11$EyudDGAJ18bBf5NG6PL9Ru", ev.TokenID)
+		},
+	}, {
+		Alias: "WOPI session started",
+		SystemEvent: events.Event{
+			Event: collaborationevent.WopiSessionStarted{
+				Executant:  userID("uid-123"),
+				ResourceID: resourceID("pro-1", "sto-123", "iid-123"),
+				Path:       "./doc.odt",
+				AppName:    "Collabora",
+				ViewMode:   "VIEW_MODE_READ_WRITE",
+			},
+		},
+		CheckAuditEvent: func(t *testing.T, b []byte) {
+			ev := types.AuditEventWopiSessionStarted{}
+			require.NoError(t, json.Unmarshal(b, &ev))
+
+			checkBaseAuditEvent(t, ev.AuditEvent, "uid-123", "", "user 'uid-123' opened file 'pro-1$sto-123!iid-123' in app 'Collabora' with view mode 'VIEW_MODE_READ_WRITE'", "wopi_session_started")
+			checkFilesAuditEvent(t, ev.AuditEventFiles, "pro-1$sto-123!iid-123", "", "./doc.odt")
+			require.Equal(t, "Collabora", ev.AppName)
+		},
+	}, {
+		Alias: "Favorite added",
+		SystemEvent: events.Event{
+			Event: ocdavevent.FavoriteAdded{
+				Executant:  userID("uid-123"),
+				ResourceID: resourceID("pro-1", "sto-123", "iid-123"),
+				Path:       "./doc.odt",
+				Timestamp:  timestamp(10e8),
+			},
+		},
+		CheckAuditEvent: func(t *testing.T, b []byte) {
+			ev := types.AuditEventFavoriteAdded{}
+			require.NoError(t, json.Unmarshal(b, &ev))
+
+			checkBaseAuditEvent(t, ev.AuditEvent, "uid-123", "2001-09-09T01:46:40Z", "user 'uid-123' marked file 'pro-1$sto-123!iid-123' as favorite", "favorite_added")
+			checkFilesAuditEvent(t, ev.AuditEventFiles, "pro-1$sto-123!iid-123", "", "./doc.odt")
+		},
+	}, {
+		Alias: "Favorite removed",
+		SystemEvent: events.Event{
+			Event: ocdavevent.FavoriteRemoved{
+				Executant:  userID("uid-123"),
+				ResourceID: resourceID("pro-1", "sto-123", "iid-123"),
+				Path:       "./doc.odt",
+				Timestamp:  timestamp(10e8),
+			},
+		},
+		CheckAuditEvent: func(t *testing.T, b []byte) {
+			ev := types.AuditEventFavoriteRemoved{}
+			require.NoError(t, json.Unmarshal(b, &ev))
+
+			checkBaseAuditEvent(t, ev.AuditEvent, "uid-123", "2001-09-09T01:46:40Z", "user 'uid-123' unmarked file 'pro-1$sto-123!iid-123' as favorite", "favorite_removed")
+			checkFilesAuditEvent(t, ev.AuditEventFiles, "pro-1$sto-123!iid-123", "", "./doc.odt")
+		},
+	},
 }
 
 func TestAuditLogging(t *testing.T) {
@@ -600,7 +811,9 @@ func TestAuditLogging(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.TODO())
 	defer cancel()
 
-	go StartAuditLogger(ctx, inch, l, Marshal("json", l), func(b []byte) {
+	marshaller, err := Marshal("json")
+	require.NoError(t, err)
+	go StartAuditLogger(ctx, inch, l, marshaller, func(b []byte) {
 		outch <- b
 	})
 
@@ -722,3 +935,14 @@ func permissions(permissions ...string) *provider.ResourcePermissions {
 
 	return perms
 }
+
+func TestMarshal_UnknownFormat(t *testing.T) {
+	_, err := Marshal("xml")
+	require.Error(t, err)
+
+	marshaller, err := Marshal("")
+	require.NoError(t, err)
+	b, err := marshaller(map[string]string{"Action": "file_create", "Message": "created"})
+	require.NoError(t, err)
+	require.Equal(t, "file_create)\n   created", string(b))
+}
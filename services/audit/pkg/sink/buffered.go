@@ -0,0 +1,74 @@
+// Package sink contains remote destinations for audit events.
+package sink
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+)
+
+// Writer delivers a single rendered audit event
+type Writer interface {
+	Write(ctx context.Context, content []byte) error
+}
+
+// Buffered decouples a Writer from the caller. Events are queued in a bounded buffer
+// and written by a separate go routine which retries failed writes with an exponential
+// backoff. Events are dropped if the buffer is full or the retries are exhausted, so a
+// slow or unavailable collector never blocks the audit logger.
+type Buffered struct {
+	name         string
+	w            Writer
+	queue        chan []byte
+	retryTimeout time.Duration
+	log          log.Logger
+}
+
+// NewBuffered returns a Buffered sink with a buffer of the given size. It starts processing
+// the buffer until the context is done.
+func NewBuffered(ctx context.Context, name string, w Writer, size int, retryTimeout time.Duration, logger log.Logger) *Buffered {
+	if size < 1 {
+		size = 1
+	}
+	b := &Buffered{
+		name:         name,
+		w:            w,
+		queue:        make(chan []byte, size),
+		retryTimeout: retryTimeout,
+		log:          logger,
+	}
+	go b.run(ctx)
+	return b
+}
+
+// Log queues the content. It never blocks.
+func (b *Buffered) Log(content []byte) {
+	select {
+	case b.queue <- content:
+	default:
+		b.log.Error().Str("sink", b.name).Msg("audit sink buffer is full, dropping event")
+	}
+}
+
+func (b *Buffered) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case content := <-b.queue:
+			bo := backoff.NewExponentialBackOff()
+			bo.MaxElapsedTime = b.retryTimeout
+
+			err := backoff.RetryNotify(func() error {
+				return b.w.Write(ctx, content)
+			}, backoff.WithContext(bo, ctx), func(err error, next time.Duration) {
+				b.log.Debug().Err(err).Str("sink", b.name).Dur("retry_in", next).Msg("error writing audit event, retrying")
+			})
+			if err != nil {
+				b.log.Error().Err(err).Str("sink", b.name).Msg("error writing audit event, dropping event")
+			}
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cenkalti/backoff"
+)
+
+// severityNumberInfo is the OpenTelemetry severity number of INFO records
+const severityNumberInfo = 9
+
+// OTLP exports audit events as log records to an OpenTelemetry collector using OTLP/HTTP
+// with json encoding. Every top level field of the event becomes a log attribute and the
+// event message becomes the body.
+type OTLP struct {
+	endpoint    string
+	headers     map[string]string
+	serviceName string
+	client      *http.Client
+	now         func() time.Time
+}
+
+// NewOTLP returns an OTLP writer posting to endpoint, e.g. 'http://collector:4318/v1/logs'
+func NewOTLP(endpoint string, headers map[string]string, serviceName string, client *http.Client) *OTLP {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OTLP{
+		endpoint:    endpoint,
+		headers:     headers,
+		serviceName: serviceName,
+		client:      client,
+		now:         time.Now,
+	}
+}
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         string         `json:"timeUnixNano"`
+	ObservedTimeUnixNano string         `json:"observedTimeUnixNano"`
+	SeverityNumber       int            `json:"severityNumber"`
+	SeverityText         string         `json:"severityText"`
+	Body                 otlpValue      `json:"body"`
+	Attributes           []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope struct {
+		Name string `json:"name"`
+	} `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpResourceLogs struct {
+	Resource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	} `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+// Write exports a json encoded audit event
+func (o *OTLP) Write(ctx context.Context, content []byte) error {
+	body, err := o.request(content)
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range o.headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return nil
+	case res.StatusCode == http.StatusTooManyRequests, res.StatusCode >= 500:
+		return fmt.Errorf("otlp collector returned '%s'", res.Status)
+	default:
+		return backoff.Permanent(fmt.Errorf("otlp collector rejected the event: '%s'", res.Status))
+	}
+}
+
+func (o *OTLP) request(content []byte) ([]byte, error) {
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, err
+	}
+
+	rec := otlpLogRecord{
+		SeverityNumber: severityNumberInfo,
+		SeverityText:   "INFO",
+	}
+
+	ts := o.now()
+	if t, ok := fields["Time"].(string); ok {
+		if parsed, err := time.Parse(time.RFC3339, t); err == nil {
+			ts = parsed
+		}
+	}
+	rec.TimeUnixNano = strconv.FormatInt(ts.UnixNano(), 10)
+	rec.ObservedTimeUnixNano = strconv.FormatInt(o.now().UnixNano(), 10)
+
+	msg, _ := fields["Message"].(string)
+	rec.Body = otlpValue{StringValue: &msg}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		rec.Attributes = append(rec.Attributes, otlpKeyValue{Key: "audit." + k, Value: toOTLPValue(fields[k])})
+	}
+
+	rl := otlpResourceLogs{}
+	name := o.serviceName
+	rl.Resource.Attributes = []otlpKeyValue{{Key: "service.name", Value: otlpValue{StringValue: &name}}}
+	sl := otlpScopeLogs{LogRecords: []otlpLogRecord{rec}}
+	sl.Scope.Name = "github.com/owncloud/ocis/v2/services/audit"
+	rl.ScopeLogs = []otlpScopeLogs{sl}
+
+	return json.Marshal(otlpRequest{ResourceLogs: []otlpResourceLogs{rl}})
+}
+
+func toOTLPValue(v interface{}) otlpValue {
+	switch t := v.(type) {
+	case string:
+		return otlpValue{StringValue: &t}
+	case bool:
+		return otlpValue{BoolValue: &t}
+	case float64:
+		if t == float64(int64(t)) {
+			s := strconv.FormatInt(int64(t), 10)
+			return otlpValue{IntValue: &s}
+		}
+		s := strconv.FormatFloat(t, 'f', -1, 64)
+		return otlpValue{StringValue: &s}
+	default:
+		b, _ := json.Marshal(t)
+		s := string(b)
+		return otlpValue{StringValue: &s}
+	}
+}
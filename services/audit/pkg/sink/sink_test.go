@@ -0,0 +1,98 @@
+package sink_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/sink"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyslogTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		l, err := r.ReadString(' ')
+		if err != nil {
+			return
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(l))
+		msg := make([]byte, n)
+		_, _ = io.ReadFull(r, msg)
+		received <- string(msg)
+	}()
+
+	s, err := sink.NewSyslog("tcp", ln.Addr().String(), nil, sink.FacilityLogAudit, "ocis-audit")
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, s.Write(context.Background(), []byte("CEF:0|ownCloud|oCIS|1.0|file_delete|msg|3|suser=admin")))
+
+	select {
+	case msg := <-received:
+		require.True(t, strings.HasPrefix(msg, "<109>1 "), msg)
+		require.Contains(t, msg, " ocis-audit ")
+		require.True(t, strings.HasSuffix(msg, " audit - CEF:0|ownCloud|oCIS|1.0|file_delete|msg|3|suser=admin"), msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no syslog message received")
+	}
+}
+
+func TestSyslogUnsupportedNetwork(t *testing.T) {
+	_, err := sink.NewSyslog("unix", "/tmp/syslog", nil, sink.FacilityLogAudit, "ocis-audit")
+	require.Error(t, err)
+}
+
+func TestBufferedOTLPRetries(t *testing.T) {
+	var calls atomic.Int32
+	received := make(chan map[string]interface{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		require.Equal(t, "secret", r.Header.Get("Authorization"))
+		body := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		received <- body
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := sink.NewOTLP(srv.URL, map[string]string{"Authorization": "secret"}, "audit", srv.Client())
+	b := sink.NewBuffered(ctx, "otlp", w, 10, 10*time.Second, log.NopLogger())
+	b.Log([]byte(`{"User":"admin","Action":"file_delete","Message":"user 'admin' trashed file 'item'","Time":"2025-01-20T08:26:00Z","CLI":false,"Level":1}`))
+
+	select {
+	case body := <-received:
+		rec := body["resourceLogs"].([]interface{})[0].(map[string]interface{})["scopeLogs"].([]interface{})[0].(map[string]interface{})["logRecords"].([]interface{})[0].(map[string]interface{})
+		require.Equal(t, "user 'admin' trashed file 'item'", rec["body"].(map[string]interface{})["stringValue"])
+		require.Equal(t, "1737361560000000000", rec["timeUnixNano"])
+		require.Contains(t, rec["attributes"], map[string]interface{}{"key": "audit.Action", "value": map[string]interface{}{"stringValue": "file_delete"}})
+	case <-time.After(10 * time.Second):
+		t.Fatal("event was not exported")
+	}
+	require.Equal(t, int32(2), calls.Load())
+}
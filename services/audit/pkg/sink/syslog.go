@@ -0,0 +1,123 @@
+package sink
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// Syslog severities and facilities used for audit events
+const (
+	SeverityNotice = 5
+	// FacilityLogAudit is the default facility for audit messages as defined in RFC 5424
+	FacilityLogAudit = 13
+)
+
+// Syslog writes RFC 5424 messages to a syslog collector. Messages are sent as single datagrams
+// via udp and with octet counting framing (RFC 6587, RFC 5425) via tcp and tls.
+type Syslog struct {
+	mu sync.Mutex
+
+	network   string
+	address   string
+	tlsConfig *tls.Config
+	facility  int
+	appName   string
+	hostname  string
+	timeout   time.Duration
+	now       func() time.Time
+
+	conn net.Conn
+}
+
+// NewSyslog returns a Syslog writer. Supported networks are 'udp', 'tcp' and 'tls'.
+// The connection is established with the first write.
+func NewSyslog(network, address string, tlsConfig *tls.Config, facility int, appName string) (*Syslog, error) {
+	switch network {
+	case "udp", "tcp", "tls":
+	default:
+		return nil, fmt.Errorf("unsupported syslog network '%s'", network)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	return &Syslog{
+		network:   network,
+		address:   address,
+		tlsConfig: tlsConfig,
+		facility:  facility,
+		appName:   appName,
+		hostname:  hostname,
+		timeout:   10 * time.Second,
+		now:       time.Now,
+	}, nil
+}
+
+// Write sends the content as a single syslog message. The connection is reset on errors
+// so the next write reconnects.
+func (s *Syslog) Write(ctx context.Context, content []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	msg := s.Format(content)
+	if s.network != "udp" {
+		msg = append([]byte(fmt.Sprintf("%d ", len(msg))), msg...)
+	}
+
+	_ = s.conn.SetWriteDeadline(s.now().Add(s.timeout))
+	if _, err := s.conn.Write(msg); err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+// Format renders the content as RFC 5424 message without framing
+func (s *Syslog) Format(content []byte) []byte {
+	// PRI VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+	header := fmt.Sprintf("<%d>1 %s %s %s %d audit - ",
+		s.facility*8+SeverityNotice,
+		s.now().UTC().Format(time.RFC3339Nano),
+		s.hostname,
+		s.appName,
+		os.Getpid(),
+	)
+	return append([]byte(header), content...)
+}
+
+// Close closes the connection to the collector
+func (s *Syslog) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func (s *Syslog) dial(ctx context.Context) (net.Conn, error) {
+	d := &net.Dialer{Timeout: s.timeout}
+	if s.network == "tls" {
+		td := &tls.Dialer{NetDialer: d, Config: s.tlsConfig}
+		return td.DialContext(ctx, "tcp", s.address)
+	}
+	return d.DialContext(ctx, s.network, s.address)
+}
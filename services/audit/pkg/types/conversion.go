@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -15,6 +16,10 @@ import (
 	types "github.com/cs3org/go-cs3apis/cs3/types/v1beta1"
 
 	sdk "github.com/cs3org/reva/v2/pkg/sdk/common"
+
+	authappevent "github.com/owncloud/ocis/v2/services/auth-app/pkg/event"
+	collaborationevent "github.com/owncloud/ocis/v2/services/collaboration/pkg/event"
+	ocdavevent "github.com/owncloud/ocis/v2/services/ocdav/pkg/event"
 )
 
 const _linktype = "link"
@@ -520,6 +525,307 @@ func ScienceMeshInviteTokenGenerated(ev events.ScienceMeshInviteTokenGenerated)
 	}
 }
 
+// ShareExpired converts a ShareExpired event to an AuditEventShareExpired
+func ShareExpired(ev events.ShareExpired) AuditEventShareExpired {
+	uid := ev.ShareOwner.GetOpaqueId()
+	sid := ev.ShareID.GetOpaqueId()
+	iid := ev.ItemID.GetOpaqueId()
+	with, typ := extractGrantee(ev.GranteeUserID, ev.GranteeGroupID)
+	base := BasicAuditEvent(uid, formatTime(utils.TimeToTS(ev.ExpiredAt)), MessageShareExpired(uid, iid, sid), ActionShareExpired)
+	sharing := SharingAuditEvent(sid, iid, uid, base)
+	sharing.Path = ev.Path
+	return AuditEventShareExpired{
+		AuditEventSharing: sharing,
+		ShareWith:         with,
+		ShareType:         typ,
+	}
+}
+
+// OCMCoreShareCreated converts an OCMCoreShareCreated event to an AuditEventOCMShareReceived
+func OCMCoreShareCreated(ev events.OCMCoreShareCreated) AuditEventOCMShareReceived {
+	uid := ev.Sharer.GetOpaqueId()
+	with := ev.GranteeUserID.GetOpaqueId()
+	base := BasicAuditEvent(uid, formatTime(ev.CTime), MessageOCMShareReceived(uid, ev.ItemID, with), ActionOCMShareReceived)
+	return AuditEventOCMShareReceived{
+		AuditEventSharing: SharingAuditEvent(ev.ShareID, ev.ItemID, uid, base),
+		ResourceName:      ev.ResourceName,
+		ShareWith:         with,
+		Permissions:       normalizeString(ev.Permissions.String()),
+	}
+}
+
+// FileTouched converts a FileTouched event to an AuditEventFileCreated
+func FileTouched(ev events.FileTouched) AuditEventFileCreated {
+	iid, path, uid := extractFileDetails(ev.Ref, ev.SpaceOwner)
+	base := BasicAuditEvent(uid, formatTime(ev.Timestamp), MessageFileCreated(ev.Executant.GetOpaqueId(), iid), ActionFileCreated)
+	return AuditEventFileCreated{
+		AuditEventFiles: FilesAuditEvent(base, iid, uid, path),
+	}
+}
+
+// FileLocked converts a FileLocked event to an AuditEventFileLocked
+func FileLocked(ev events.FileLocked) AuditEventFileLocked {
+	iid, path, uid := extractFileDetails(ev.Ref, ev.Owner)
+	base := BasicAuditEvent(uid, formatTime(ev.Timestamp), MessageFileLocked(ev.Executant.GetOpaqueId(), iid), ActionFileLocked)
+	return AuditEventFileLocked{
+		AuditEventFiles: FilesAuditEvent(base, iid, uid, path),
+	}
+}
+
+// FileUnlocked converts a FileUnlocked event to an AuditEventFileUnlocked
+func FileUnlocked(ev events.FileUnlocked) AuditEventFileUnlocked {
+	iid, path, uid := extractFileDetails(ev.Ref, ev.Owner)
+	base := BasicAuditEvent(uid, formatTime(ev.Timestamp), MessageFileUnlocked(ev.Executant.GetOpaqueId(), iid), ActionFileUnlocked)
+	return AuditEventFileUnlocked{
+		AuditEventFiles: FilesAuditEvent(base, iid, uid, path),
+	}
+}
+
+// TagsAdded converts a TagsAdded event to an AuditEventTagsChanged
+func TagsAdded(ev events.TagsAdded) AuditEventTagsChanged {
+	iid, path, uid := extractFileDetails(ev.Ref, ev.SpaceOwner)
+	base := BasicAuditEvent(uid, formatTime(ev.Timestamp), MessageTagsAdded(ev.Executant.GetOpaqueId(), iid, ev.Tags), ActionTagsAdded)
+	return AuditEventTagsChanged{
+		AuditEventFiles: FilesAuditEvent(base, iid, uid, path),
+		Tags:            ev.Tags,
+	}
+}
+
+// TagsRemoved converts a TagsRemoved event to an AuditEventTagsChanged
+func TagsRemoved(ev events.TagsRemoved) AuditEventTagsChanged {
+	iid, path, uid := extractFileDetails(ev.Ref, ev.SpaceOwner)
+	base := BasicAuditEvent(uid, formatTime(ev.Timestamp), MessageTagsRemoved(ev.Executant.GetOpaqueId(), iid, ev.Tags), ActionTagsRemoved)
+	return AuditEventTagsChanged{
+		AuditEventFiles: FilesAuditEvent(base, iid, uid, path),
+		Tags:            ev.Tags,
+	}
+}
+
+// PostprocessingStepFinished converts a PostprocessingStepFinished event to an AuditEventPostprocessingStepFinished.
+// Found viruses and files rejected by the policies service are logged with their own actions.
+func PostprocessingStepFinished(ev events.PostprocessingStepFinished) AuditEventPostprocessingStepFinished {
+	executant := ev.ExecutingUser.GetId().GetOpaqueId()
+	e := AuditEventPostprocessingStepFinished{
+		UploadID: ev.UploadID,
+		Filename: ev.Filename,
+		Step:     string(ev.FinishedStep),
+		Outcome:  string(ev.Outcome),
+	}
+	if ev.Error != nil {
+		e.Error = ev.Error.Error()
+	}
+
+	msg := MessagePostprocessingStepFinished(ev.UploadID, ev.Filename, e.Step, e.Outcome)
+	action := ActionPostprocessingStepFinished
+	switch ev.FinishedStep {
+	case events.PPStepAntivirus:
+		res, ok := virusscanResult(ev.Result)
+		if !ok {
+			break
+		}
+		e.Infected = res.Infected
+		e.Virus = res.Description
+		if res.ResourceID != nil {
+			e.FileID = storagespace.FormatResourceID(res.ResourceID)
+		}
+		if res.ErrorMsg != "" {
+			e.Error = res.ErrorMsg
+		}
+		if res.Infected {
+			msg = MessageFileVirusFound(executant, ev.Filename, res.Description, e.Outcome)
+			action = ActionFileVirusFound
+		}
+	case events.PPStepPolicies:
+		if ev.Outcome == events.PPOutcomeDelete || ev.Outcome == events.PPOutcomeAbort {
+			msg = MessageFilePolicyRejected(executant, ev.Filename, e.Outcome)
+			action = ActionFilePolicyRejected
+		}
+	}
+
+	e.AuditEvent = BasicAuditEvent(executant, formatTime(ev.Timestamp), msg, action)
+	return e
+}
+
+// PostprocessingFinished converts a PostprocessingFinished event to an AuditEventPostprocessingFinished
+func PostprocessingFinished(ev events.PostprocessingFinished) AuditEventPostprocessingFinished {
+	msg := MessagePostprocessingFinished(ev.UploadID, ev.Filename, string(ev.Outcome))
+	base := BasicAuditEvent(ev.ExecutingUser.GetId().GetOpaqueId(), formatTime(ev.Timestamp), msg, ActionPostprocessingFinished)
+	return AuditEventPostprocessingFinished{
+		AuditEvent: base,
+		UploadID:   ev.UploadID,
+		Filename:   ev.Filename,
+		Outcome:    string(ev.Outcome),
+	}
+}
+
+// SpaceShareUpdated converts a SpaceShareUpdated event to an AuditEventSpaceShareUpdated
+func SpaceShareUpdated(ev events.SpaceShareUpdated) AuditEventSpaceShareUpdated {
+	ssu := AuditEventSpaceShareUpdated{}
+
+	sid := ev.ID.GetOpaqueId()
+	grantee := "N/A"
+	if ev.GranteeUserID != nil {
+		ssu.GranteeUserID = ev.GranteeUserID.OpaqueId
+		grantee = "user:" + ev.GranteeUserID.OpaqueId
+	} else if ev.GranteeGroupID != nil {
+		ssu.GranteeGroupID = ev.GranteeGroupID.OpaqueId
+		grantee = "group:" + ev.GranteeGroupID.OpaqueId
+	}
+	base := BasicAuditEvent("", formatTime(utils.TimeToTS(ev.Timestamp)), MessageSpaceShareUpdated(ev.Executant.GetOpaqueId(), sid, grantee), ActionSpaceShareUpdated)
+	ssu.AuditEventSpaces = SpacesAuditEvent(base, sid)
+
+	return ssu
+}
+
+// SpaceMembershipExpired converts a SpaceMembershipExpired event to an AuditEventSpaceMembershipExpired
+func SpaceMembershipExpired(ev events.SpaceMembershipExpired) AuditEventSpaceMembershipExpired {
+	sme := AuditEventSpaceMembershipExpired{
+		Name:  ev.SpaceName,
+		Owner: ev.SpaceOwner.GetOpaqueId(),
+	}
+
+	sid := ev.SpaceID.GetOpaqueId()
+	grantee := "N/A"
+	if ev.GranteeUserID != nil {
+		sme.GranteeUserID = ev.GranteeUserID.OpaqueId
+		grantee = "user:" + ev.GranteeUserID.OpaqueId
+	} else if ev.GranteeGroupID != nil {
+		sme.GranteeGroupID = ev.GranteeGroupID.OpaqueId
+		grantee = "group:" + ev.GranteeGroupID.OpaqueId
+	}
+	base := BasicAuditEvent("", formatTime(utils.TimeToTS(ev.ExpiredAt)), MessageSpaceMembershipExpired(sid, ev.SpaceName, grantee), ActionSpaceMembershipExpired)
+	sme.AuditEventSpaces = SpacesAuditEvent(base, sid)
+
+	return sme
+}
+
+// UserSignedIn converts a UserSignedIn event to an AuditEventUserSignedIn
+func UserSignedIn(ev events.UserSignedIn) AuditEventUserSignedIn {
+	uid := ev.Executant.GetOpaqueId()
+	base := BasicAuditEvent(uid, formatTime(ev.Timestamp), MessageUserSignedIn(uid), ActionUserSignedIn)
+	return AuditEventUserSignedIn{
+		AuditEvent: base,
+		UserID:     uid,
+	}
+}
+
+// BackchannelLogout converts a BackchannelLogout event to an AuditEventUserLoggedOut
+func BackchannelLogout(ev events.BackchannelLogout) AuditEventUserLoggedOut {
+	uid := ev.Executant.GetOpaqueId()
+	base := BasicAuditEvent(uid, formatTime(ev.Timestamp), MessageUserLoggedOut(uid, ev.SessionId), ActionUserLoggedOut)
+	return AuditEventUserLoggedOut{
+		AuditEvent: base,
+		UserID:     uid,
+		SessionID:  ev.SessionId,
+	}
+}
+
+// PersonalDataExtracted converts a PersonalDataExtracted event to an AuditEventPersonalDataExtracted
+func PersonalDataExtracted(ev events.PersonalDataExtracted) AuditEventPersonalDataExtracted {
+	uid := ev.Executant.GetOpaqueId()
+	base := BasicAuditEvent(uid, formatTime(ev.Timestamp), MessagePersonalDataExtracted(uid, ev.ErrorMsg), ActionPersonalDataExtracted)
+	return AuditEventPersonalDataExtracted{
+		AuditEvent: base,
+		UserID:     uid,
+		Error:      ev.ErrorMsg,
+	}
+}
+
+// GroupFeatureChanged converts a GroupFeatureChanged event to an AuditEventGroupFeatureChanged
+func GroupFeatureChanged(ev events.GroupFeatureChanged) AuditEventGroupFeatureChanged {
+	msg := MessageGroupFeatureChanged(ev.Executant.GetOpaqueId(), ev.GroupID, ev.Features)
+	base := BasicAuditEvent("", formatTime(ev.Timestamp), msg, ActionGroupFeatureChanged)
+	return AuditEventGroupFeatureChanged{
+		AuditEvent: base,
+		GroupID:    ev.GroupID,
+		Features:   ev.Features,
+	}
+}
+
+// AppTokenCreated converts an AppTokenCreated event to an AuditEventAppTokenCreated
+func AppTokenCreated(ev authappevent.AppTokenCreated) AuditEventAppTokenCreated {
+	executant, uid := ev.Executant.GetOpaqueId(), ev.UserID.GetOpaqueId()
+	base := BasicAuditEvent(executant, formatTime(ev.Timestamp), MessageAppTokenCreated(executant, uid, ev.Label), ActionAppTokenCreated)
+	return AuditEventAppTokenCreated{
+		AuditEvent:   base,
+		UserID:       uid,
+		Label:        ev.Label,
+		Expiration:   formatTime(utils.TimeToTS(ev.Expiration)),
+		Impersonated: ev.Impersonated,
+	}
+}
+
+// AppTokenDeleted converts an AppTokenDeleted event to an AuditEventAppTokenDeleted
+func AppTokenDeleted(ev authappevent.AppTokenDeleted) AuditEventAppTokenDeleted {
+	executant := ev.Executant.GetOpaqueId()
+	base := BasicAuditEvent(executant, formatTime(ev.Timestamp), MessageAppTokenDeleted(executant, ev.TokenID), ActionAppTokenDeleted)
+	return AuditEventAppTokenDeleted{
+		AuditEvent: base,
+		TokenID:    ev.TokenID,
+	}
+}
+
+// WopiSessionStarted converts a WopiSessionStarted event to an AuditEventWopiSessionStarted
+func WopiSessionStarted(ev collaborationevent.WopiSessionStarted) AuditEventWopiSessionStarted {
+	executant := ev.Executant.GetOpaqueId()
+	iid := ""
+	if ev.ResourceID != nil {
+		iid = storagespace.FormatResourceID(ev.ResourceID)
+	}
+	base := BasicAuditEvent(executant, formatTime(ev.Timestamp), MessageWopiSessionStarted(executant, iid, ev.AppName, ev.ViewMode), ActionWopiSessionStarted)
+	return AuditEventWopiSessionStarted{
+		AuditEventFiles: FilesAuditEvent(base, iid, "", ev.Path),
+		AppName:         ev.AppName,
+		ViewMode:        ev.ViewMode,
+	}
+}
+
+// FavoriteAdded converts a FavoriteAdded event to an AuditEventFavoriteAdded
+func FavoriteAdded(ev ocdavevent.FavoriteAdded) AuditEventFavoriteAdded {
+	executant := ev.Executant.GetOpaqueId()
+	iid := ""
+	if ev.ResourceID != nil {
+		iid = storagespace.FormatResourceID(ev.ResourceID)
+	}
+	base := BasicAuditEvent(executant, formatTime(ev.Timestamp), MessageFavoriteAdded(executant, iid), ActionFavoriteAdded)
+	return AuditEventFavoriteAdded{
+		AuditEventFiles: FilesAuditEvent(base, iid, "", ev.Path),
+	}
+}
+
+// FavoriteRemoved converts a FavoriteRemoved event to an AuditEventFavoriteRemoved
+func FavoriteRemoved(ev ocdavevent.FavoriteRemoved) AuditEventFavoriteRemoved {
+	executant := ev.Executant.GetOpaqueId()
+	iid := ""
+	if ev.ResourceID != nil {
+		iid = storagespace.FormatResourceID(ev.ResourceID)
+	}
+	base := BasicAuditEvent(executant, formatTime(ev.Timestamp), MessageFavoriteRemoved(executant, iid), ActionFavoriteRemoved)
+	return AuditEventFavoriteRemoved{
+		AuditEventFiles: FilesAuditEvent(base, iid, "", ev.Path),
+	}
+}
+
+// virusscanResult extracts the result of a virus scan. Results of consumed events are decoded json objects.
+func virusscanResult(r interface{}) (events.VirusscanResult, bool) {
+	switch res := r.(type) {
+	case events.VirusscanResult:
+		return res, true
+	case nil:
+		return events.VirusscanResult{}, false
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		return events.VirusscanResult{}, false
+	}
+	var res events.VirusscanResult
+	if err := json.Unmarshal(b, &res); err != nil {
+		return events.VirusscanResult{}, false
+	}
+	return res, true
+}
+
 func extractGrantee(uid *user.UserId, gid *group.GroupId) (string, string) {
 	switch {
 	case uid != nil && uid.OpaqueId != "":
@@ -2,6 +2,10 @@ package types
 
 import (
 	"github.com/cs3org/reva/v2/pkg/events"
+
+	authappevent "github.com/owncloud/ocis/v2/services/auth-app/pkg/event"
+	collaborationevent "github.com/owncloud/ocis/v2/services/collaboration/pkg/event"
+	ocdavevent "github.com/owncloud/ocis/v2/services/ocdav/pkg/event"
 )
 
 // RegisteredEvents returns the events the service is registered for
@@ -16,14 +20,23 @@ func RegisteredEvents() []events.Unmarshaller {
 		events.ReceivedShareUpdated{},
 		events.LinkAccessed{},
 		events.LinkAccessFailed{},
+		events.ShareExpired{},
+		events.OCMCoreShareCreated{},
 		events.ContainerCreated{},
 		events.FileUploaded{},
 		events.FileDownloaded{},
+		events.FileTouched{},
+		events.FileLocked{},
+		events.FileUnlocked{},
 		events.ItemTrashed{},
 		events.ItemMoved{},
 		events.ItemPurged{},
 		events.ItemRestored{},
 		events.FileVersionRestored{},
+		events.TagsAdded{},
+		events.TagsRemoved{},
+		events.PostprocessingStepFinished{},
+		events.PostprocessingFinished{},
 		events.SpaceCreated{},
 		events.SpaceRenamed{},
 		events.SpaceEnabled{},
@@ -32,14 +45,141 @@ func RegisteredEvents() []events.Unmarshaller {
 		events.SpaceShared{},
 		events.SpaceUnshared{},
 		events.SpaceUpdated{},
+		events.SpaceShareUpdated{},
+		events.SpaceMembershipExpired{},
 		events.UserCreated{},
 		events.UserDeleted{},
 		events.UserFeatureChanged{},
+		events.UserSignedIn{},
+		events.BackchannelLogout{},
+		events.PersonalDataExtracted{},
 		events.GroupCreated{},
 		events.GroupDeleted{},
+		events.GroupFeatureChanged{},
 		events.GroupMemberAdded{},
 		events.GroupMemberRemoved{},
-		events.BackchannelLogout{},
 		events.ScienceMeshInviteTokenGenerated{},
+		authappevent.AppTokenCreated{},
+		authappevent.AppTokenDeleted{},
+		collaborationevent.WopiSessionStarted{},
+		ocdavevent.FavoriteAdded{},
+		ocdavevent.FavoriteRemoved{},
+	}
+}
+
+// Convert converts a consumed event to the audit event to log.
+// It returns false if there is no audit mapping for the type of the event.
+//
+//nolint:gocyclo
+func Convert(ev interface{}) (interface{}, bool) {
+	switch ev := ev.(type) {
+	case events.ShareCreated:
+		return ShareCreated(ev), true
+	case events.LinkCreated:
+		return LinkCreated(ev), true
+	case events.ShareUpdated:
+		return ShareUpdated(ev), true
+	case events.LinkUpdated:
+		return LinkUpdated(ev), true
+	case events.ShareRemoved:
+		return ShareRemoved(ev), true
+	case events.LinkRemoved:
+		return LinkRemoved(ev), true
+	case events.ReceivedShareUpdated:
+		return ReceivedShareUpdated(ev), true
+	case events.LinkAccessed:
+		return LinkAccessed(ev), true
+	case events.LinkAccessFailed:
+		return LinkAccessFailed(ev), true
+	case events.ShareExpired:
+		return ShareExpired(ev), true
+	case events.OCMCoreShareCreated:
+		return OCMCoreShareCreated(ev), true
+	case events.ContainerCreated:
+		return ContainerCreated(ev), true
+	case events.FileUploaded:
+		return FileUploaded(ev), true
+	case events.FileDownloaded:
+		return FileDownloaded(ev), true
+	case events.FileTouched:
+		return FileTouched(ev), true
+	case events.FileLocked:
+		return FileLocked(ev), true
+	case events.FileUnlocked:
+		return FileUnlocked(ev), true
+	case events.ItemMoved:
+		return ItemMoved(ev), true
+	case events.ItemTrashed:
+		return ItemTrashed(ev), true
+	case events.ItemPurged:
+		return ItemPurged(ev), true
+	case events.ItemRestored:
+		return ItemRestored(ev), true
+	case events.FileVersionRestored:
+		return FileVersionRestored(ev), true
+	case events.TagsAdded:
+		return TagsAdded(ev), true
+	case events.TagsRemoved:
+		return TagsRemoved(ev), true
+	case events.PostprocessingStepFinished:
+		return PostprocessingStepFinished(ev), true
+	case events.PostprocessingFinished:
+		return PostprocessingFinished(ev), true
+	case events.SpaceCreated:
+		return SpaceCreated(ev), true
+	case events.SpaceRenamed:
+		return SpaceRenamed(ev), true
+	case events.SpaceDisabled:
+		return SpaceDisabled(ev), true
+	case events.SpaceEnabled:
+		return SpaceEnabled(ev), true
+	case events.SpaceDeleted:
+		return SpaceDeleted(ev), true
+	case events.SpaceShared:
+		return SpaceShared(ev), true
+	case events.SpaceUnshared:
+		return SpaceUnshared(ev), true
+	case events.SpaceUpdated:
+		return SpaceUpdated(ev), true
+	case events.SpaceShareUpdated:
+		return SpaceShareUpdated(ev), true
+	case events.SpaceMembershipExpired:
+		return SpaceMembershipExpired(ev), true
+	case events.UserCreated:
+		return UserCreated(ev), true
+	case events.UserDeleted:
+		return UserDeleted(ev), true
+	case events.UserFeatureChanged:
+		return UserFeatureChanged(ev), true
+	case events.UserSignedIn:
+		return UserSignedIn(ev), true
+	case events.BackchannelLogout:
+		return BackchannelLogout(ev), true
+	case events.PersonalDataExtracted:
+		return PersonalDataExtracted(ev), true
+	case events.GroupCreated:
+		return GroupCreated(ev), true
+	case events.GroupDeleted:
+		return GroupDeleted(ev), true
+	case events.GroupFeatureChanged:
+		return GroupFeatureChanged(ev), true
+	case events.GroupMemberAdded:
+		return GroupMemberAdded(ev), true
+	case events.GroupMemberRemoved:
+		return GroupMemberRemoved(ev), true
+	case events.ScienceMeshInviteTokenGenerated:
+		return ScienceMeshInviteTokenGenerated(ev), true
+	case authappevent.AppTokenCreated:
+		return AppTokenCreated(ev), true
+	case authappevent.AppTokenDeleted:
+		return AppTokenDeleted(ev), true
+	case collaborationevent.WopiSessionStarted:
+		return WopiSessionStarted(ev), true
+	case ocdavevent.FavoriteAdded:
+		return FavoriteAdded(ev), true
+	case ocdavevent.FavoriteRemoved:
+		return FavoriteRemoved(ev), true
+	default:
+		return nil, false
 	}
 }
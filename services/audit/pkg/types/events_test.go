@@ -0,0 +1,52 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegisteredEventsAreConverted fails when the service consumes an event type without an audit mapping
+func TestRegisteredEventsAreConverted(t *testing.T) {
+	for _, u := range RegisteredEvents() {
+		ev := reflect.New(reflect.TypeOf(u)).Elem()
+		fill(ev, 3)
+
+		t.Run(ev.Type().String(), func(t *testing.T) {
+			var (
+				auditEvent interface{}
+				ok         bool
+			)
+			require.NotPanics(t, func() {
+				auditEvent, ok = Convert(ev.Interface())
+			})
+			require.True(t, ok, "no audit mapping for '%s'", ev.Type())
+			require.NotNil(t, auditEvent)
+		})
+	}
+}
+
+// fill allocates the nil pointers of the exported fields of v up to the given depth,
+// like events carrying all their references would have them.
+func fill(v reflect.Value, depth int) {
+	if depth == 0 {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() && v.CanSet() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		if !v.IsNil() {
+			fill(v.Elem(), depth-1)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				fill(v.Field(i), depth)
+			}
+		}
+	}
+}
@@ -20,6 +20,8 @@ const (
 	ActionShareAccepted           = "share_accepted"
 	ActionShareDeclined           = "share_declined"
 	ActionLinkAccessed            = "public_link_accessed"
+	ActionShareExpired            = "share_expired"
+	ActionOCMShareReceived        = "ocm_share_received"
 
 	// Files
 	ActionContainerCreated    = "container_create"
@@ -30,6 +32,16 @@ const (
 	ActionFilePurged          = "file_trash_delete"
 	ActionFileRestored        = "file_trash_restore"
 	ActionFileVersionRestored = "file_version_restore"
+	ActionFileLocked          = "file_locked"
+	ActionFileUnlocked        = "file_unlocked"
+	ActionTagsAdded           = "tags_added"
+	ActionTagsRemoved         = "tags_removed"
+
+	// Postprocessing
+	ActionPostprocessingStepFinished = "postprocessing_step_finished"
+	ActionPostprocessingFinished     = "postprocessing_finished"
+	ActionFileVirusFound             = "file_virus_found"
+	ActionFilePolicyRejected         = "file_policy_rejected"
 
 	// Spaces
 	ActionSpaceCreated  = "space_created"
@@ -41,16 +53,35 @@ const (
 	ActionSpaceUnshared = "space_unshared"
 	ActionSpaceUpdated  = "space_updated"
 
+	ActionSpaceShareUpdated      = "space_share_updated"
+	ActionSpaceMembershipExpired = "space_membership_expired"
+
 	// Users
 	ActionUserCreated        = "user_created"
 	ActionUserDeleted        = "user_deleted"
 	ActionUserFeatureChanged = "user_feature_changed"
 
+	ActionUserSignedIn          = "user_signed_in"
+	ActionUserLoggedOut         = "user_logged_out"
+	ActionPersonalDataExtracted = "personal_data_extracted"
+
+	// App tokens
+	ActionAppTokenCreated = "app_token_created"
+	ActionAppTokenDeleted = "app_token_deleted"
+
+	// WOPI
+	ActionWopiSessionStarted = "wopi_session_started"
+
+	// Favorites
+	ActionFavoriteAdded   = "favorite_added"
+	ActionFavoriteRemoved = "favorite_removed"
+
 	// Groups
-	ActionGroupCreated       = "group_created"
-	ActionGroupDeleted       = "group_deleted"
-	ActionGroupMemberAdded   = "group_member_added"
-	ActionGroupMemberRemoved = "group_member_removed"
+	ActionGroupCreated        = "group_created"
+	ActionGroupDeleted        = "group_deleted"
+	ActionGroupMemberAdded    = "group_member_added"
+	ActionGroupMemberRemoved  = "group_member_removed"
+	ActionGroupFeatureChanged = "group_feature_changed"
 
 	// ScienceMesh
 	ActionScienceMeshInviteTokenGenerated = "science_mesh_invite_token_generated"
@@ -242,3 +273,120 @@ func MessageGroupMemberRemoved(executant, userID, groupID string) string {
 func MessageScienceMeshInviteTokenGenerated(user, token string) string {
 	return fmt.Sprintf("user '%s' generated a ScienceMesh invite with token '%s'", user, token)
 }
+
+// MessageShareExpired returns the human-readable string that describes the action
+func MessageShareExpired(owner, item, shareID string) string {
+	return fmt.Sprintf("share '%s' of user '%s' to file '%s' expired", shareID, owner, item)
+}
+
+// MessageOCMShareReceived returns the human-readable string that describes the action
+func MessageOCMShareReceived(sharer, item, grantee string) string {
+	return fmt.Sprintf("federated user '%s' shared file '%s' with '%s'", sharer, item, grantee)
+}
+
+// MessageFileLocked returns the human-readable string that describes the action
+func MessageFileLocked(executant, item string) string {
+	return fmt.Sprintf("user '%s' locked file '%s'", executant, item)
+}
+
+// MessageFileUnlocked returns the human-readable string that describes the action
+func MessageFileUnlocked(executant, item string) string {
+	return fmt.Sprintf("user '%s' unlocked file '%s'", executant, item)
+}
+
+// MessageTagsAdded returns the human-readable string that describes the action
+func MessageTagsAdded(executant, item, tags string) string {
+	return fmt.Sprintf("user '%s' added tags '%s' to file '%s'", executant, tags, item)
+}
+
+// MessageTagsRemoved returns the human-readable string that describes the action
+func MessageTagsRemoved(executant, item, tags string) string {
+	return fmt.Sprintf("user '%s' removed tags '%s' from file '%s'", executant, tags, item)
+}
+
+// MessagePostprocessingStepFinished returns the human-readable string that describes the action
+func MessagePostprocessingStepFinished(uploadID, filename, step, outcome string) string {
+	return fmt.Sprintf("postprocessing step '%s' of upload '%s' (file '%s') finished with outcome '%s'", step, uploadID, filename, outcome)
+}
+
+// MessageFileVirusFound returns the human-readable string that describes the action
+func MessageFileVirusFound(executant, filename, virus, outcome string) string {
+	return fmt.Sprintf("virus '%s' found in file '%s' uploaded by user '%s'. outcome: '%s'", virus, filename, executant, outcome)
+}
+
+// MessageFilePolicyRejected returns the human-readable string that describes the action
+func MessageFilePolicyRejected(executant, filename, outcome string) string {
+	return fmt.Sprintf("policies rejected file '%s' uploaded by user '%s'. outcome: '%s'", filename, executant, outcome)
+}
+
+// MessagePostprocessingFinished returns the human-readable string that describes the action
+func MessagePostprocessingFinished(uploadID, filename, outcome string) string {
+	return fmt.Sprintf("postprocessing of upload '%s' (file '%s') finished with outcome '%s'", uploadID, filename, outcome)
+}
+
+// MessageSpaceShareUpdated returns the human-readable string that describes the action
+func MessageSpaceShareUpdated(executant, spaceID, grantee string) string {
+	storagID, spaceID := storagespace.SplitStorageID(spaceID)
+	return fmt.Sprintf("user '%s' updated the share of space '%s' with '%s' (storage: '%s')", executant, spaceID, grantee, storagID)
+}
+
+// MessageSpaceMembershipExpired returns the human-readable string that describes the action
+func MessageSpaceMembershipExpired(spaceID, name, grantee string) string {
+	storagID, spaceID := storagespace.SplitStorageID(spaceID)
+	return fmt.Sprintf("membership of '%s' in space '%s' with name '%s' expired (storage: '%s')", grantee, spaceID, name, storagID)
+}
+
+// MessageGroupFeatureChanged returns the human-readable string that describes the action
+func MessageGroupFeatureChanged(executant, groupID string, features []events.GroupFeature) string {
+	var sb strings.Builder
+	for _, f := range features {
+		sb.WriteString(" ")
+		sb.WriteString(f.Name)
+		sb.WriteRune('=')
+		sb.WriteString(f.Value)
+	}
+	return fmt.Sprintf("user '%s' changed group '%s' features:%s", executant, groupID, sb.String())
+}
+
+// MessageUserSignedIn returns the human-readable string that describes the action
+func MessageUserSignedIn(userID string) string {
+	return fmt.Sprintf("user '%s' signed in", userID)
+}
+
+// MessageUserLoggedOut returns the human-readable string that describes the action
+func MessageUserLoggedOut(userID, sessionID string) string {
+	return fmt.Sprintf("user '%s' was logged out of session '%s' by the identity provider", userID, sessionID)
+}
+
+// MessagePersonalDataExtracted returns the human-readable string that describes the action
+func MessagePersonalDataExtracted(userID, errMsg string) string {
+	if errMsg != "" {
+		return fmt.Sprintf("extracting the personal data of user '%s' failed: %s", userID, errMsg)
+	}
+	return fmt.Sprintf("personal data of user '%s' was extracted", userID)
+}
+
+// MessageAppTokenCreated returns the human-readable string that describes the action
+func MessageAppTokenCreated(executant, userID, label string) string {
+	return fmt.Sprintf("user '%s' created app token '%s' for user '%s'", executant, label, userID)
+}
+
+// MessageAppTokenDeleted returns the human-readable string that describes the action
+func MessageAppTokenDeleted(executant, tokenID string) string {
+	return fmt.Sprintf("user '%s' deleted app token '%s'", executant, tokenID)
+}
+
+// MessageFavoriteAdded returns the human-readable string that describes the action
+func MessageFavoriteAdded(executant, item string) string {
+	return fmt.Sprintf("user '%s' marked file '%s' as favorite", executant, item)
+}
+
+// MessageFavoriteRemoved returns the human-readable string that describes the action
+func MessageFavoriteRemoved(executant, item string) string {
+	return fmt.Sprintf("user '%s' unmarked file '%s' as favorite", executant, item)
+}
+
+// MessageWopiSessionStarted returns the human-readable string that describes the action
+func MessageWopiSessionStarted(executant, item, app, viewMode string) string {
+	return fmt.Sprintf("user '%s' opened file '%s' in app '%s' with view mode '%s'", executant, item, app, viewMode)
+}
@@ -284,3 +284,136 @@ type AuditEventScienceMeshInviteTokenGenerated struct {
 	Expiration    uint64
 	InviteLink    string
 }
+
+// AuditEventShareExpired is the event logged when a share expired
+type AuditEventShareExpired struct {
+	AuditEventSharing
+	ShareType string // group or user
+	ShareWith string // The UID or GID of the share recipient.
+}
+
+// AuditEventOCMShareReceived is the event logged when a federated share is received
+type AuditEventOCMShareReceived struct {
+	AuditEventSharing
+	ResourceName string // The name of the shared item on the remote instance.
+	ShareWith    string // The UID of the local share recipient.
+	Permissions  string // The permissions string eg: "READ"
+}
+
+// AuditEventFileLocked is the event logged when a file is locked
+type AuditEventFileLocked struct {
+	AuditEventFiles
+}
+
+// AuditEventFileUnlocked is the event logged when a file is unlocked
+type AuditEventFileUnlocked struct {
+	AuditEventFiles
+}
+
+// AuditEventTagsChanged is the event logged when tags are added to or removed from a file
+type AuditEventTagsChanged struct {
+	AuditEventFiles
+
+	Tags string // comma separated list of the added or removed tags
+}
+
+// AuditEventPostprocessingStepFinished is the event logged when a postprocessing step finished
+type AuditEventPostprocessingStepFinished struct {
+	AuditEvent
+
+	UploadID string
+	Filename string
+	Step     string // the name of the step, eg: "virusscan" or "policies"
+	Outcome  string // the outcome of the step, eg: "continue" or "delete"
+	FileID   string // only available for virus scans
+	Infected bool   // only available for virus scans
+	Virus    string // the description of the found virus
+	Error    string // the error message of the step if any
+}
+
+// AuditEventPostprocessingFinished is the event logged when the postprocessing of an upload finished
+type AuditEventPostprocessingFinished struct {
+	AuditEvent
+
+	UploadID string
+	Filename string
+	Outcome  string
+}
+
+// AuditEventSpaceShareUpdated is the event logged when a space share is updated
+type AuditEventSpaceShareUpdated struct {
+	AuditEventSpaces
+
+	GranteeUserID  string
+	GranteeGroupID string
+}
+
+// AuditEventSpaceMembershipExpired is the event logged when a space membership expired
+type AuditEventSpaceMembershipExpired struct {
+	AuditEventSpaces
+
+	Name           string
+	Owner          string
+	GranteeUserID  string
+	GranteeGroupID string
+}
+
+// AuditEventGroupFeatureChanged is the event logged when a group feature is changed
+type AuditEventGroupFeatureChanged struct {
+	AuditEvent
+	GroupID  string
+	Features []events.GroupFeature
+}
+
+// AuditEventUserSignedIn is the event logged when a user signs in
+type AuditEventUserSignedIn struct {
+	AuditEvent
+	UserID string
+}
+
+// AuditEventUserLoggedOut is the event logged when the identity provider logged out a user session
+type AuditEventUserLoggedOut struct {
+	AuditEvent
+	UserID    string
+	SessionID string
+}
+
+// AuditEventPersonalDataExtracted is the event logged when the personal data of a user is extracted
+type AuditEventPersonalDataExtracted struct {
+	AuditEvent
+	UserID string
+	Error  string
+}
+
+// AuditEventAppTokenCreated is the event logged when an app token is created
+type AuditEventAppTokenCreated struct {
+	AuditEvent
+	UserID       string
+	Label        string
+	Expiration   string
+	Impersonated bool
+}
+
+// AuditEventAppTokenDeleted is the event logged when an app token is deleted
+type AuditEventAppTokenDeleted struct {
+	AuditEvent
+	TokenID string
+}
+
+// AuditEventFavoriteAdded is the event logged when a file is marked as favorite
+type AuditEventFavoriteAdded struct {
+	AuditEventFiles
+}
+
+// AuditEventFavoriteRemoved is the event logged when a file is unmarked as favorite
+type AuditEventFavoriteRemoved struct {
+	AuditEventFiles
+}
+
+// AuditEventWopiSessionStarted is the event logged when a file is opened in a WOPI app
+type AuditEventWopiSessionStarted struct {
+	AuditEventFiles
+
+	AppName  string
+	ViewMode string
+}
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"fmt"
+	"os"
 
 	authpb "github.com/cs3org/go-cs3apis/cs3/auth/provider/v1beta1"
 	"github.com/cs3org/reva/v2/pkg/auth/scope"
@@ -15,12 +16,16 @@ import (
 	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
 	typesv1beta1 "github.com/cs3org/go-cs3apis/cs3/types/v1beta1"
 	ctxpkg "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/events/stream"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	"github.com/cs3org/reva/v2/pkg/utils"
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
 	"github.com/owncloud/ocis/v2/ocis-pkg/registry"
 	"github.com/owncloud/ocis/v2/ocis-pkg/tracing"
 	"github.com/owncloud/ocis/v2/services/auth-app/pkg/config"
 	"github.com/owncloud/ocis/v2/services/auth-app/pkg/config/parser"
+	"github.com/owncloud/ocis/v2/services/auth-app/pkg/event"
 	"github.com/urfave/cli/v2"
 	"google.golang.org/grpc/metadata"
 )
@@ -102,17 +107,34 @@ func Create(cfg *config.Config) *cli.Command {
 				return err
 			}
 
+			label := "Generated via CLI"
+			expiration := time.Now().Add(expiry)
 			appPassword, err := next.GenerateAppPassword(granteeCtx, &applicationsv1beta1.GenerateAppPasswordRequest{
 				TokenScope: scopes,
-				Label:      "Generated via CLI",
+				Label:      label,
 				Expiration: &typesv1beta1.Timestamp{
-					Seconds: uint64(time.Now().Add(expiry).Unix()),
+					Seconds: uint64(expiration.Unix()),
 				},
 			})
 			if err != nil {
 				return err
 			}
 
+			if cfg.Events.Endpoint != "" {
+				s, err := stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig(cfg.Events))
+				if err == nil {
+					err = events.Publish(ctx, s, event.AppTokenCreated{
+						UserID:     authRes.GetUser().GetId(),
+						Label:      label,
+						Expiration: expiration,
+						Timestamp:  utils.TSNow(),
+					})
+				}
+				if err != nil {
+					fmt.Fprintln(os.Stderr, "WARNING: could not publish the app token creation event:", err)
+				}
+			}
+
 			fmt.Printf("App token created for %s", authRes.GetUser().GetUsername())
 			fmt.Println()
 			fmt.Printf(" token: %s", appPassword.GetAppPassword().GetPassword())
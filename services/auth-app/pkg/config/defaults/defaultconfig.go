@@ -45,6 +45,11 @@ func DefaultConfig() *config.Config {
 			Name: "auth-app",
 		},
 		Reva: shared.DefaultRevaConfig(),
+		Events: config.Events{
+			Endpoint:  "127.0.0.1:9233",
+			Cluster:   "ocis-cluster",
+			EnableTLS: false,
+		},
 	}
 }
 
@@ -0,0 +1,40 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	types "github.com/cs3org/go-cs3apis/cs3/types/v1beta1"
+)
+
+// AppTokenCreated is emitted when an app token was created
+type AppTokenCreated struct {
+	Executant    *user.UserId
+	UserID       *user.UserId // the user the token was created for, differs from the executant when impersonating
+	Label        string
+	Expiration   time.Time
+	Impersonated bool
+	Timestamp    *types.Timestamp
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (AppTokenCreated) Unmarshal(v []byte) (interface{}, error) {
+	e := AppTokenCreated{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
+
+// AppTokenDeleted is emitted when an app token was deleted
+type AppTokenDeleted struct {
+	Executant *user.UserId
+	TokenID   string // the hashed token as returned when listing the tokens, never the token itself
+	Timestamp *types.Timestamp
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (AppTokenDeleted) Unmarshal(v []byte) (interface{}, error) {
+	e := AppTokenDeleted{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
@@ -5,6 +5,8 @@ import (
 
 	stdhttp "net/http"
 
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/events/stream"
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/owncloud/ocis/v2/ocis-pkg/account"
@@ -43,6 +45,17 @@ func Server(opts ...Option) (http.Service, error) {
 		return http.Service{}, fmt.Errorf("could not initialize http service: %w", err)
 	}
 
+	var eventsStream events.Stream
+	if options.Config.Events.Endpoint != "" {
+		eventsStream, err = stream.NatsFromConfig(options.Config.Service.Name, false, stream.NatsConfig(options.Config.Events))
+		if err != nil {
+			options.Logger.Error().
+				Err(err).
+				Msg("Error initializing events publisher")
+			return http.Service{}, fmt.Errorf("could not initialize events publisher: %w", err)
+		}
+	}
+
 	middlewares := []func(stdhttp.Handler) stdhttp.Handler{
 		chimiddleware.RequestID,
 		middleware.Version(
@@ -84,6 +97,7 @@ func Server(opts ...Option) (http.Service, error) {
 		svc.GatewaySelector(options.GatewaySelector),
 		svc.RoleClient(options.RoleClient),
 		svc.TraceProvider(options.TracerProvider),
+		svc.EventsPublisher(eventsStream),
 	)
 	if err != nil {
 		return http.Service{}, err
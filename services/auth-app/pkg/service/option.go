@@ -4,6 +4,7 @@ import (
 	"context"
 
 	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	"github.com/go-chi/chi/v5"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
@@ -24,6 +25,7 @@ type Options struct {
 	Mux             *chi.Mux
 	TracerProvider  trace.TracerProvider
 	RoleClient      settingssvc.RoleService
+	EventsPublisher events.Publisher
 }
 
 // Logger provides a function to set the logger option.
@@ -74,3 +76,10 @@ func RoleClient(rs settingssvc.RoleService) Option {
 		o.RoleClient = rs
 	}
 }
+
+// EventsPublisher provides a function to set the EventsPublisher option
+func EventsPublisher(val events.Publisher) Option {
+	return func(o *Options) {
+		o.EventsPublisher = val
+	}
+}
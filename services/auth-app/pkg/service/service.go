@@ -2,10 +2,13 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	applications "github.com/cs3org/go-cs3apis/cs3/auth/applications/v1beta1"
@@ -16,12 +19,14 @@ import (
 	"github.com/cs3org/reva/v2/pkg/appctx"
 	"github.com/cs3org/reva/v2/pkg/auth/scope"
 	ctxpkg "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	"github.com/cs3org/reva/v2/pkg/utils"
 	"github.com/go-chi/chi/v5"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/ocis-pkg/roles"
 	"github.com/owncloud/ocis/v2/services/auth-app/pkg/config"
+	"github.com/owncloud/ocis/v2/services/auth-app/pkg/event"
 	settings "github.com/owncloud/ocis/v2/services/settings/pkg/service/v0"
 	"google.golang.org/grpc/metadata"
 )
@@ -43,6 +48,7 @@ type AuthAppService struct {
 	gws pool.Selectable[gateway.GatewayAPIClient]
 	m   *chi.Mux
 	r   *roles.Manager
+	evs events.Publisher
 }
 
 // NewAuthAppService initializes a new AuthAppService.
@@ -63,6 +69,7 @@ func NewAuthAppService(opts ...Option) (*AuthAppService, error) {
 		gws: o.GatewaySelector,
 		m:   o.Mux,
 		r:   &r,
+		evs: o.EventsPublisher,
 	}
 
 	a.m.Route("/auth-app/tokens", func(r chi.Router) {
@@ -100,6 +107,8 @@ func (a *AuthAppService) HandleCreate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	label := "Generated via API"
+	executant := ctxpkg.ContextMustGetUser(ctx).GetId()
+	impersonated := false
 
 	// Impersonated request
 	userID, userName := q.Get("userID"), q.Get("userName")
@@ -132,6 +141,7 @@ func (a *AuthAppService) HandleCreate(w http.ResponseWriter, r *http.Request) {
 		}
 
 		label = "Generated via Impersonation API"
+		impersonated = true
 	}
 
 	scopes, err := scope.AddOwnerScope(map[string]*authpb.Scope{})
@@ -141,10 +151,11 @@ func (a *AuthAppService) HandleCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	expiration := time.Now().Add(expiry)
 	res, err := gwc.GenerateAppPassword(ctx, &applications.GenerateAppPasswordRequest{
 		TokenScope: scopes,
 		Label:      label,
-		Expiration: utils.TimeToTS(time.Now().Add(expiry)),
+		Expiration: utils.TimeToTS(expiration),
 	})
 	if err != nil {
 		sublog.Error().Err(err).Msg("error generating app password")
@@ -157,6 +168,15 @@ func (a *AuthAppService) HandleCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	a.publishEvent(event.AppTokenCreated{
+		Executant:    executant,
+		UserID:       ctxpkg.ContextMustGetUser(ctx).GetId(),
+		Label:        label,
+		Expiration:   expiration,
+		Impersonated: impersonated,
+		Timestamp:    utils.TSNow(),
+	})
+
 	b, err := json.Marshal(convert(res.GetAppPassword()))
 	if err != nil {
 		sublog.Error().Err(err).Msg("error marshaling app password")
@@ -247,9 +267,35 @@ func (a *AuthAppService) HandleDelete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	a.publishEvent(event.AppTokenDeleted{
+		Executant: ctxpkg.ContextMustGetUser(ctx).GetId(),
+		TokenID:   tokenID(pw),
+		Timestamp: utils.TSNow(),
+	})
+
 	w.WriteHeader(http.StatusOK)
 }
 
+// tokenID returns an identifier of the token which can be logged. Listed tokens are already hashed,
+// tokens in clear text are replaced by their sha256 sum.
+func tokenID(token string) string {
+	if strings.HasPrefix(token, "$2") {
+		return token
+	}
+	sum := sha256.Sum256([]byte(token))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// publishEvent publishes the event if an events publisher is configured
+func (a *AuthAppService) publishEvent(ev interface{}) {
+	if a.evs == nil {
+		return
+	}
+	if err := events.Publish(context.Background(), a.evs, ev); err != nil {
+		a.log.Error().Err(err).Interface("event", ev).Msg("could not publish event")
+	}
+}
+
 func (a *AuthAppService) authenticateUser(userID, userName string, gwc gateway.GatewayAPIClient) (context.Context, error) {
 	ctx := context.Background()
 	authRes, err := gwc.Authenticate(ctx, &gateway.AuthenticateRequest{
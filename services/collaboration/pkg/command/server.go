@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/events/stream"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	"github.com/cs3org/reva/v2/pkg/store"
 	"github.com/oklog/run"
@@ -81,6 +83,15 @@ func Server(cfg *config.Config) *cli.Command {
 				store.Authentication(cfg.Store.AuthUsername, cfg.Store.AuthPassword),
 			)
 
+			var publisher events.Publisher
+			if cfg.Events.Endpoint != "" {
+				publisher, err = stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig(cfg.Events))
+				if err != nil {
+					logger.Error().Err(err).Msg("Error initializing events publisher")
+					return err
+				}
+			}
+
 			// start GRPC server
 			grpcServer, teardown, err := grpc.Server(
 				grpc.AppURLs(appUrls),
@@ -88,6 +99,7 @@ func Server(cfg *config.Config) *cli.Command {
 				grpc.Logger(logger),
 				grpc.TraceProvider(traceProvider),
 				grpc.Store(st),
+				grpc.Publisher(publisher),
 			)
 			defer teardown()
 			if err != nil {
@@ -40,6 +40,11 @@ func DefaultConfig() *config.Config {
 			Table:    "",
 			TTL:      30 * time.Minute,
 		},
+		Events: config.Events{
+			Endpoint:  "127.0.0.1:9233",
+			Cluster:   "ocis-cluster",
+			EnableTLS: false,
+		},
 		GRPC: config.GRPC{
 			Addr:      "127.0.0.1:9301",
 			Protocol:  "tcp",
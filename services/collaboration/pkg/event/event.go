@@ -0,0 +1,26 @@
+package event
+
+import (
+	"encoding/json"
+
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	types "github.com/cs3org/go-cs3apis/cs3/types/v1beta1"
+)
+
+// WopiSessionStarted is emitted when a user opens a file in a WOPI app
+type WopiSessionStarted struct {
+	Executant  *user.UserId
+	ResourceID *provider.ResourceId
+	Path       string
+	AppName    string
+	ViewMode   string
+	Timestamp  *types.Timestamp
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (WopiSessionStarted) Unmarshal(v []byte) (interface{}, error) {
+	e := WopiSessionStarted{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
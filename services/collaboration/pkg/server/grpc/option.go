@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 
+	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/config"
 	microstore "go-micro.dev/v4/store"
@@ -21,6 +22,7 @@ type Options struct {
 	Config        *config.Config
 	TraceProvider trace.TracerProvider
 	Store         microstore.Store
+	Publisher     events.Publisher
 }
 
 // newOptions initializes the available default options.
@@ -82,3 +84,10 @@ func Store(val microstore.Store) Option {
 		o.Store = val
 	}
 }
+
+// Publisher provides a function to set the Publisher option.
+func Publisher(val events.Publisher) Option {
+	return func(o *Options) {
+		o.Publisher = val
+	}
+}
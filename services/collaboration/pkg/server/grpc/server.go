@@ -28,6 +28,7 @@ func Server(opts ...Option) (*grpc.Server, func(), error) {
 		svc.Logger(options.Logger),
 		svc.AppURLs(options.AppURLs),
 		svc.Store(options.Store),
+		svc.Publisher(options.Publisher),
 	)
 	if err != nil {
 		options.Logger.Error().
@@ -2,6 +2,7 @@ package service
 
 import (
 	gatewayv1beta1 "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	microstore "go-micro.dev/v4/store"
 
@@ -19,6 +20,7 @@ type Options struct {
 	AppURLs         map[string]map[string]string
 	GatewaySelector pool.Selectable[gatewayv1beta1.GatewayAPIClient]
 	Store           microstore.Store
+	Publisher       events.Publisher
 }
 
 // newOptions initializes the available default options.
@@ -66,3 +68,11 @@ func Store(val microstore.Store) Option {
 		o.Store = val
 	}
 }
+
+// Publisher provides a function to set the events publisher. Events are only
+// published when a publisher is set.
+func Publisher(val events.Publisher) Option {
+	return func(o *Options) {
+		o.Publisher = val
+	}
+}
@@ -13,6 +13,7 @@ import (
 	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
 	rpcv1beta1 "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
 	providerv1beta1 "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	"github.com/cs3org/reva/v2/pkg/storagespace"
 	"github.com/cs3org/reva/v2/pkg/utils"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/config"
+	"github.com/owncloud/ocis/v2/services/collaboration/pkg/event"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/helpers"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/middleware"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/wopisrc"
@@ -49,6 +51,7 @@ func NewHandler(opts ...Option) (*Service, func(), error) {
 		config:          options.Config,
 		gatewaySelector: gatewaySelector,
 		store:           options.Store,
+		publisher:       options.Publisher,
 	}, teardown, nil
 }
 
@@ -60,6 +63,7 @@ type Service struct {
 	config          *config.Config
 	gatewaySelector pool.Selectable[gatewayv1beta1.GatewayAPIClient]
 	store           microstore.Store
+	publisher       events.Publisher
 }
 
 // OpenInApp will implement the OpenInApp interface of the app provider
@@ -155,6 +159,20 @@ func (s *Service) OpenInApp(
 		}, err
 	}
 
+	if s.publisher != nil {
+		ev := event.WopiSessionStarted{
+			Executant:  user.GetId(),
+			ResourceID: req.GetResourceInfo().GetId(),
+			Path:       req.GetResourceInfo().GetPath(),
+			AppName:    s.config.App.Name,
+			ViewMode:   req.GetViewMode().String(),
+			Timestamp:  utils.TSNow(),
+		}
+		if err := events.Publish(ctx, s.publisher, ev); err != nil {
+			logger.Error().Err(err).Msg("OpenInApp: error publishing the wopi session event")
+		}
+	}
+
 	logger.Debug().Msg("OpenInApp: success")
 
 	return &appproviderv1beta1.OpenInAppResponse{
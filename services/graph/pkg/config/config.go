@@ -32,6 +32,7 @@ type Config struct {
 	Events            Events       `yaml:"events"`
 	UnifiedRoles      UnifiedRoles `yaml:"unified_roles"`
 	MaxConcurrency    int          `yaml:"max_concurrency" env:"OCIS_MAX_CONCURRENCY;GRAPH_MAX_CONCURRENCY" desc:"The maximum number of concurrent requests the service will handle." introductionVersion:"7.0.0"`
+	QuarantineSpaceID string       `yaml:"quarantine_space_id" env:"OCIS_QUARANTINE_SPACE_ID;GRAPH_QUARANTINE_SPACE_ID" desc:"The ID of the space the antivirus service moves infected files to. Only needs to be set if a space other than the one created automatically by the antivirus service is used. See the antivirus service documentation for more details." introductionVersion:"7.1"`
 
 	Keycloak       Keycloak       `yaml:"keycloak"`
 	ServiceAccount ServiceAccount `yaml:"service_account"`
@@ -0,0 +1,106 @@
+package svc
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/quarantine"
+	"github.com/owncloud/ocis/v2/services/graph/pkg/errorcode"
+)
+
+// ListQuarantineItems lists the files moved to the quarantine by the antivirus service
+func (g Graph) ListQuarantineItems(w http.ResponseWriter, r *http.Request) {
+	logger := g.logger.SubloggerWithRequestID(r.Context())
+
+	items, err := g.quarantine.List(r.Context())
+	if err != nil {
+		logger.Error().Err(err).Msg("could not list quarantine items")
+		errorcode.GeneralException.Render(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	values := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		values = append(values, item)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, &ListResponse{Value: values})
+}
+
+// GetQuarantineItem returns a single quarantined file
+func (g Graph) GetQuarantineItem(w http.ResponseWriter, r *http.Request) {
+	itemID, ok := quarantineItemID(w, r)
+	if !ok {
+		return
+	}
+
+	item, err := g.quarantine.Get(r.Context(), itemID)
+	if err != nil {
+		g.renderQuarantineError(w, r, itemID, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, item)
+}
+
+// ReleaseQuarantineItem uploads a quarantined file to its original location again.
+// The upload is scanned like any other, files still infected end up in the quarantine again.
+func (g Graph) ReleaseQuarantineItem(w http.ResponseWriter, r *http.Request) {
+	itemID, ok := quarantineItemID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := g.quarantine.Release(r.Context(), itemID); err != nil {
+		g.renderQuarantineError(w, r, itemID, err)
+		return
+	}
+
+	render.Status(r, http.StatusNoContent)
+	render.NoContent(w, r)
+}
+
+// PurgeQuarantineItem irrevocably deletes a quarantined file
+func (g Graph) PurgeQuarantineItem(w http.ResponseWriter, r *http.Request) {
+	itemID, ok := quarantineItemID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := g.quarantine.Purge(r.Context(), itemID); err != nil {
+		g.renderQuarantineError(w, r, itemID, err)
+		return
+	}
+
+	render.Status(r, http.StatusNoContent)
+	render.NoContent(w, r)
+}
+
+// quarantineItemID returns the item id of the request, ids containing path elements are rejected
+func quarantineItemID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	itemID := chi.URLParam(r, "itemID")
+	if !quarantine.ValidID(itemID) {
+		errorcode.InvalidRequest.Render(w, r, http.StatusBadRequest, "invalid quarantine item id")
+		return "", false
+	}
+	return itemID, true
+}
+
+func (g Graph) renderQuarantineError(w http.ResponseWriter, r *http.Request, itemID string, err error) {
+	switch {
+	case errors.Is(err, quarantine.ErrNotFound):
+		errorcode.ItemNotFound.Render(w, r, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, quarantine.ErrInvalidID):
+		errorcode.InvalidRequest.Render(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	logger := g.logger.SubloggerWithRequestID(r.Context())
+	logger.Error().Err(err).Str("itemID", itemID).Msg("quarantine operation failed")
+	errorcode.GeneralException.Render(w, r, http.StatusInternalServerError, err.Error())
+}
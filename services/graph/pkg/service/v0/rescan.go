@@ -0,0 +1,30 @@
+package svc
+
+import (
+	"net/http"
+
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"github.com/go-chi/render"
+
+	avevent "github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
+	"github.com/owncloud/ocis/v2/services/graph/pkg/errorcode"
+)
+
+// RequestRescan asks the antivirus service to rescan all stored files
+func (g Graph) RequestRescan(w http.ResponseWriter, r *http.Request) {
+	logger := g.logger.SubloggerWithRequestID(r.Context())
+	if g.eventsPublisher == nil {
+		errorcode.ServiceNotAvailable.Render(w, r, http.StatusServiceUnavailable, "events are not available")
+		return
+	}
+
+	if err := events.Publish(r.Context(), g.eventsPublisher, avevent.RescanRequested{Timestamp: utils.TSNow()}); err != nil {
+		logger.Error().Err(err).Msg("could not request rescan")
+		errorcode.GeneralException.Render(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.NoContent(w, r)
+}
@@ -24,6 +24,7 @@ import (
 
 	ocisldap "github.com/owncloud/ocis/v2/ocis-pkg/ldap"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quarantine"
 	"github.com/owncloud/ocis/v2/ocis-pkg/registry"
 	"github.com/owncloud/ocis/v2/ocis-pkg/roles"
 	"github.com/owncloud/ocis/v2/ocis-pkg/service/grpc"
@@ -119,6 +120,12 @@ type Service interface { //nolint:interfacebloat
 	GetTags(w http.ResponseWriter, r *http.Request)
 	AssignTags(w http.ResponseWriter, r *http.Request)
 	UnassignTags(w http.ResponseWriter, r *http.Request)
+
+	ListQuarantineItems(w http.ResponseWriter, r *http.Request)
+	GetQuarantineItem(w http.ResponseWriter, r *http.Request)
+	ReleaseQuarantineItem(w http.ResponseWriter, r *http.Request)
+	PurgeQuarantineItem(w http.ResponseWriter, r *http.Request)
+	RequestRescan(w http.ResponseWriter, r *http.Request)
 }
 
 // NewService returns a service implementation for Service.
@@ -165,6 +172,12 @@ func NewService(opts ...Option) (Graph, error) { //nolint:maintidx
 		return svc, err
 	}
 
+	q, err := quarantine.New(options.Config.QuarantineSpaceID, options.GatewaySelector, options.Config.ServiceAccount.ServiceAccountID, options.Config.ServiceAccount.ServiceAccountSecret)
+	if err != nil {
+		return svc, fmt.Errorf("could not initialize quarantine: %w", err)
+	}
+	svc.quarantine = q
+
 	if options.PermissionService == nil {
 		grpcClient, err := grpc.NewClient(append(grpc.GetClientOptions(options.Config.GRPCClientTLS), grpc.WithTraceProvider(options.TraceProvider))...)
 		if err != nil {
@@ -271,6 +284,15 @@ func NewService(opts ...Option) (Graph, error) { //nolint:maintidx
 				r.Get("/", svc.GetRoleDefinitions)
 				r.Get("/{roleID}", svc.GetRoleDefinition)
 			})
+			r.With(requireAdmin).Post("/antivirus/rescan", svc.RequestRescan)
+			r.With(requireAdmin).Route("/quarantine", func(r chi.Router) {
+				r.Get("/", svc.ListQuarantineItems)
+				r.Route("/{itemID}", func(r chi.Router) {
+					r.Get("/", svc.GetQuarantineItem)
+					r.Delete("/", svc.PurgeQuarantineItem)
+					r.Post("/release", svc.ReleaseQuarantineItem)
+				})
+			})
 		})
 		r.Route("/v1.0", func(r chi.Router) {
 			r.Route("/extensions/org.libregraph", func(r chi.Router) {
@@ -0,0 +1,254 @@
+package channels
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/notifications/pkg/config"
+)
+
+const (
+	// ChatMatrix is the chat channel sending messages to Matrix rooms.
+	ChatMatrix = "matrix"
+	// ChatTeams is the chat channel sending messages to Microsoft Teams incoming webhooks.
+	ChatTeams = "teams"
+	// ChatSlack is the chat channel sending messages to Slack incoming webhooks.
+	ChatSlack = "slack"
+	// ChatWebhook is the chat channel sending messages to the webhook of the organization.
+	ChatWebhook = "webhook"
+
+	// HeaderWebhookSignature is the header containing the signature of a webhook request.
+	HeaderWebhookSignature = "X-Ocis-Signature"
+	// HeaderWebhookTimestamp is the header containing the unix timestamp the signature was created at.
+	HeaderWebhookTimestamp = "X-Ocis-Timestamp"
+)
+
+// NewChatChannels instantiates the configured chat channels by their setting value.
+func NewChatChannels(cfg config.Config, logger log.Logger) map[string]Channel {
+	chats := make(map[string]Channel)
+	n := cfg.Notifications
+	if n.Webhook.URL != "" {
+		chats[ChatWebhook] = NewWebhookChannel(n.Webhook, logger)
+	}
+	if n.Matrix.Homeserver != "" {
+		chats[ChatMatrix] = NewMatrixChannel(n.Matrix, n.Webhook, logger)
+	}
+	if n.ChatWebhooks.Enabled {
+		chats[ChatSlack] = NewIncomingWebhookChannel(ChatSlack, n.ChatWebhooks.SlackAllowedHosts, n.Webhook, logger)
+		chats[ChatTeams] = NewIncomingWebhookChannel(ChatTeams, n.ChatWebhooks.TeamsAllowedHosts, n.Webhook, logger)
+	}
+	return chats
+}
+
+// NewWebhookChannel instantiates a new webhook communication channel.
+func NewWebhookChannel(cfg config.Webhook, logger log.Logger) Channel {
+	return Webhook{
+		conf:   cfg,
+		client: newHTTPClient(cfg.Timeout),
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Webhook is the communication channel posting the messages as signed JSON to the webhook of the organization.
+// The recipients are the ids of the users.
+type Webhook struct {
+	conf   config.Webhook
+	client *http.Client
+	logger log.Logger
+	now    func() time.Time
+}
+
+// webhookPayload is the JSON document posted to the webhook
+type webhookPayload struct {
+	Sender     string   `json:"sender,omitempty"`
+	Recipients []string `json:"recipients"`
+	Subject    string   `json:"subject"`
+	Text       string   `json:"text"`
+	HTML       string   `json:"html,omitempty"`
+}
+
+// SendMessage posts the message to the webhook.
+func (w Webhook) SendMessage(ctx context.Context, message *Message) error {
+	body, err := json.Marshal(webhookPayload{
+		Sender:     message.Sender,
+		Recipients: message.Recipient,
+		Subject:    message.Subject,
+		Text:       message.TextBody,
+		HTML:       message.HTMLBody,
+	})
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(w.now().Unix(), 10)
+	header := http.Header{}
+	header.Set(HeaderWebhookTimestamp, timestamp)
+	header.Set(HeaderWebhookSignature, "sha256="+Sign(w.conf.Secret, timestamp, body))
+	return post(ctx, w.client, http.MethodPost, w.conf.URL, body, header, w.conf.MaxRetries)
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the timestamp and the body of a webhook request. The
+// timestamp is part of the signature to allow receivers to reject replayed requests.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewMatrixChannel instantiates a new Matrix communication channel. The retries and timeouts
+// are the ones of the webhook.
+func NewMatrixChannel(cfg config.Matrix, retry config.Webhook, logger log.Logger) Channel {
+	return Matrix{
+		conf:       cfg,
+		maxRetries: retry.MaxRetries,
+		client:     newHTTPClient(retry.Timeout),
+		logger:     logger,
+	}
+}
+
+// Matrix is the communication channel sending the messages to Matrix rooms. The recipients are the room ids,
+// they are provided by the users and have to be one of the allowed rooms.
+type Matrix struct {
+	conf       config.Matrix
+	maxRetries int
+	client     *http.Client
+	logger     log.Logger
+}
+
+// SendMessage sends the message to all rooms.
+func (m Matrix) SendMessage(ctx context.Context, message *Message) error {
+	body, err := json.Marshal(map[string]string{
+		"msgtype": "m.text",
+		"body":    chatText(message),
+	})
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+m.conf.AccessToken)
+
+	var errs error
+	for _, room := range message.Recipient {
+		if !m.allowed(room) {
+			m.logger.Warn().Str("room", room).Msg("the matrix room is not allowed")
+			errs = errors.New("matrix room not allowed")
+			continue
+		}
+		// the transaction id stays the same for the retries, the homeserver ignores duplicates
+		endpoint, err := url.JoinPath(m.conf.Homeserver, "_matrix/client/v3/rooms", url.PathEscape(room), "send/m.room.message", uuid.NewString())
+		if err != nil {
+			errs = errors.Wrap(err, "invalid matrix room")
+			continue
+		}
+		if err := post(ctx, m.client, http.MethodPut, endpoint, body, header, m.maxRetries); err != nil {
+			m.logger.Error().Err(err).Str("room", room).Msg("could not send the matrix message")
+			errs = err
+		}
+	}
+	return errs
+}
+
+// allowed checks that the room is one of the allowed rooms or belongs to one of the allowed servers
+func (m Matrix) allowed(room string) bool {
+	for _, allowed := range m.conf.AllowedRooms {
+		if room == allowed || (strings.HasPrefix(allowed, ":") && strings.HasSuffix(strings.ToLower(room), strings.ToLower(allowed))) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewIncomingWebhookChannel instantiates a new communication channel for the incoming webhooks of
+// Microsoft Teams or Slack. The retries and timeouts are the ones of the webhook.
+func NewIncomingWebhookChannel(kind string, allowedHosts []string, retry config.Webhook, logger log.Logger) Channel {
+	return IncomingWebhook{
+		kind:         kind,
+		allowedHosts: allowedHosts,
+		maxRetries:   retry.MaxRetries,
+		client:       newHTTPClient(retry.Timeout),
+		logger:       logger,
+	}
+}
+
+// IncomingWebhook is the communication channel posting the messages to the incoming webhooks of
+// Microsoft Teams or Slack. The recipients are the webhook urls, they are provided by the users and
+// have to point to one of the allowed hosts.
+type IncomingWebhook struct {
+	kind         string
+	allowedHosts []string
+	maxRetries   int
+	client       *http.Client
+	logger       log.Logger
+}
+
+// SendMessage posts the message to all webhooks.
+func (i IncomingWebhook) SendMessage(ctx context.Context, message *Message) error {
+	var payload interface{}
+	switch i.kind {
+	case ChatTeams:
+		payload = map[string]string{
+			"@type":    "MessageCard",
+			"@context": "https://schema.org/extensions",
+			"summary":  message.Subject,
+			"title":    message.Subject,
+			"text":     message.TextBody,
+		}
+	default:
+		payload = map[string]string{"text": chatText(message)}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, target := range message.Recipient {
+		if !i.allowed(target) {
+			i.logger.Warn().Str("channel", i.kind).Msg("the webhook url is not allowed")
+			errs = errors.New("webhook url not allowed")
+			continue
+		}
+		if err := post(ctx, i.client, http.MethodPost, target, body, http.Header{}, i.maxRetries); err != nil {
+			i.logger.Error().Err(err).Str("channel", i.kind).Msg("could not send the webhook message")
+			errs = err
+		}
+	}
+	return errs
+}
+
+// allowed checks that the url uses https on the default port and points to one of the allowed hosts
+func (i IncomingWebhook) allowed(target string) bool {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme != "https" || u.User != nil || (u.Port() != "" && u.Port() != "443") {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range i.allowedHosts {
+		allowed = strings.ToLower(allowed)
+		if host == strings.TrimPrefix(allowed, ".") || (strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// chatText returns the plain text of the message including the subject
+func chatText(message *Message) string {
+	return message.Subject + "\n\n" + message.TextBody
+}
@@ -0,0 +1,213 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/notifications/pkg/config"
+)
+
+func TestWebhook_SendMessage(t *testing.T) {
+	backoff := retryBackoff
+	t.Cleanup(func() { retryBackoff = backoff })
+	retryBackoff = time.Millisecond
+
+	var (
+		attempts int32
+		received webhookPayload
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		timestamp := r.Header.Get(HeaderWebhookTimestamp)
+		if r.Header.Get(HeaderWebhookSignature) != "sha256="+Sign("secret", timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.Unmarshal(body, &received)
+	}))
+	defer srv.Close()
+
+	w := NewWebhookChannel(config.Webhook{URL: srv.URL, Secret: "secret", MaxRetries: 2, Timeout: time.Second}, log.NopLogger())
+	err := w.SendMessage(context.Background(), &Message{Recipient: []string{"einstein"}, Subject: "subject", TextBody: "text"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+	if received.Subject != "subject" || received.Text != "text" || len(received.Recipients) != 1 || received.Recipients[0] != "einstein" {
+		t.Errorf("unexpected payload %+v", received)
+	}
+}
+
+func TestWebhook_SendMessageNoRetry(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	w := NewWebhookChannel(config.Webhook{URL: srv.URL, MaxRetries: 3, Timeout: time.Second}, log.NopLogger())
+	if err := w.SendMessage(context.Background(), &Message{}); err == nil {
+		t.Error("expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("client errors must not be retried, got %d attempts", attempts)
+	}
+}
+
+func TestMatrix_SendMessage(t *testing.T) {
+	var (
+		path, auth string
+		received   map[string]string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		path, auth = r.URL.EscapedPath(), r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer srv.Close()
+
+	m := NewMatrixChannel(config.Matrix{Homeserver: srv.URL, AccessToken: "token", AllowedRooms: []string{":example.com"}}, config.Webhook{Timeout: time.Second}, log.NopLogger())
+	err := m.SendMessage(context.Background(), &Message{Recipient: []string{"!room:example.com"}, Subject: "subject", TextBody: "text"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(path, "/_matrix/client/v3/rooms/%21room:example.com/send/m.room.message/") {
+		t.Errorf("unexpected path %s", path)
+	}
+	if auth != "Bearer token" {
+		t.Errorf("unexpected authorization %s", auth)
+	}
+	if received["msgtype"] != "m.text" || received["body"] != "subject\n\ntext" {
+		t.Errorf("unexpected message %v", received)
+	}
+}
+
+func TestMatrix_SendMessageToForbiddenRoom(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer srv.Close()
+
+	m := NewMatrixChannel(config.Matrix{Homeserver: srv.URL, AccessToken: "token", AllowedRooms: []string{"!board:example.com"}}, config.Webhook{Timeout: time.Second}, log.NopLogger())
+	if err := m.SendMessage(context.Background(), &Message{Recipient: []string{"!other:example.com"}}); err == nil {
+		t.Error("expected an error")
+	}
+	if requests != 0 {
+		t.Errorf("no message must be sent to a forbidden room, got %d requests", requests)
+	}
+}
+
+func TestMatrix_allowed(t *testing.T) {
+	m := Matrix{conf: config.Matrix{AllowedRooms: []string{"!board:example.com", ":example.org"}}}
+
+	tests := []struct {
+		room string
+		want bool
+	}{
+		{room: "!board:example.com", want: true},
+		{room: "!any:example.org", want: true},
+		{room: "!any:EXAMPLE.org", want: true},
+		{room: "!BOARD:example.com", want: false},
+		{room: "!other:example.com", want: false},
+		{room: "!any:evilexample.org", want: false},
+		{room: "!any:example.org.evil.com", want: false},
+		{room: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.room, func(t *testing.T) {
+			if got := m.allowed(tt.room); got != tt.want {
+				t.Errorf("allowed(%s) = %v, want %v", tt.room, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncomingWebhook_allowed(t *testing.T) {
+	i := IncomingWebhook{allowedHosts: []string{"hooks.slack.com", ".webhook.office.com"}}
+
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{url: "https://hooks.slack.com/services/T0/B0/X", want: true},
+		{url: "https://HOOKS.slack.com/services/T0/B0/X", want: true},
+		{url: "https://contoso.webhook.office.com/webhookb2/x", want: true},
+		{url: "https://webhook.office.com/webhookb2/x", want: true},
+		{url: "http://hooks.slack.com/services/T0/B0/X", want: false},
+		{url: "https://hooks.slack.com:8443/services", want: false},
+		{url: "https://user@hooks.slack.com/services", want: false},
+		{url: "https://evilhooks.slack.com/services", want: false},
+		{url: "https://evilwebhook.office.com/webhookb2/x", want: false},
+		{url: "https://hooks.slack.com.example.com/services", want: false},
+		{url: "https://127.0.0.1/services", want: false},
+		{url: "not a url", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := i.allowed(tt.url); got != tt.want {
+				t.Errorf("allowed(%s) = %v, want %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+// rewriteTransport sends all requests to the test server
+type rewriteTransport struct {
+	target string
+	next   http.RoundTripper
+}
+
+func (t rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	u, _ := url.Parse(t.target)
+	r.URL.Host = u.Host
+	return t.next.RoundTrip(r)
+}
+
+func TestIncomingWebhook_SendMessage(t *testing.T) {
+	var received []map[string]string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var m map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&m)
+		received = append(received, m)
+	}))
+	defer srv.Close()
+
+	client := srv.Client()
+	client.Transport = rewriteTransport{target: srv.URL, next: client.Transport}
+	message := &Message{
+		Recipient: []string{"https://hooks.example.com/hook", "https://127.0.0.1/hook"},
+		Subject:   "subject",
+		TextBody:  "text",
+	}
+
+	slack := IncomingWebhook{kind: ChatSlack, allowedHosts: []string{"hooks.example.com"}, client: client, logger: log.NopLogger()}
+	if err := slack.SendMessage(context.Background(), message); err == nil {
+		t.Error("expected the second url to be rejected")
+	}
+	if len(received) != 1 || received[0]["text"] != "subject\n\ntext" {
+		t.Fatalf("unexpected messages %v", received)
+	}
+
+	teams := IncomingWebhook{kind: ChatTeams, allowedHosts: []string{"hooks.example.com"}, client: client, logger: log.NopLogger()}
+	_ = teams.SendMessage(context.Background(), message)
+	if len(received) != 2 || received[1]["@type"] != "MessageCard" || received[1]["title"] != "subject" || received[1]["text"] != "text" {
+		t.Errorf("unexpected messages %v", received)
+	}
+}
@@ -0,0 +1,69 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// retryBackoff is the delay before the first retry of a failed request, it doubles with every retry.
+var retryBackoff = 500 * time.Millisecond
+
+// post sends the body to the url and retries the request with an exponential backoff
+// on network errors, server errors and when being rate limited.
+func post(ctx context.Context, client *http.Client, method, url string, body []byte, header http.Header, maxRetries int) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		retry, err = send(ctx, client, method, url, body, header)
+		if err == nil || !retry || attempt >= maxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(retryBackoff << attempt):
+		}
+	}
+}
+
+// send sends a single request, it returns true if the request should be retried
+func send(ctx context.Context, client *http.Client, method, url string, body []byte, header http.Header) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header = header.Clone()
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return false, nil
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	default:
+		return false, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+}
+
+// newHTTPClient returns a client which doesn't follow redirects, the targets of the channels
+// are checked before sending and must not be changed by the servers.
+func newHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
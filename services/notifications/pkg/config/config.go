@@ -38,6 +38,31 @@ type Notifications struct {
 	DefaultLanguage   string                `yaml:"default_language" env:"OCIS_DEFAULT_LANGUAGE" desc:"The default language used by services and the WebUI. If not defined, English will be used as default. See the documentation for more details." introductionVersion:"5.0"`
 	RevaGateway       string                `yaml:"reva_gateway" env:"OCIS_REVA_GATEWAY" desc:"CS3 gateway used to look up user metadata" introductionVersion:"pre5.0"`
 	GRPCClientTLS     *shared.GRPCClientTLS `yaml:"grpc_client_tls"`
+	Webhook           Webhook               `yaml:"webhook"`
+	Matrix            Matrix                `yaml:"matrix"`
+	ChatWebhooks      ChatWebhooks          `yaml:"chat_webhooks"`
+}
+
+// Webhook combines the configuration options of the organization webhook.
+type Webhook struct {
+	URL        string        `yaml:"url" env:"NOTIFICATIONS_WEBHOOK_URL" desc:"The URL notifications are posted to as JSON when users chose the organization webhook as their chat channel. The channel is disabled when empty." introductionVersion:"7.1"`
+	Secret     string        `yaml:"secret" env:"NOTIFICATIONS_WEBHOOK_SECRET" desc:"The secret used to sign the webhook requests with HMAC-SHA256. The signature is sent in the 'X-Ocis-Signature' header." introductionVersion:"7.1"`
+	MaxRetries int           `yaml:"max_retries" env:"NOTIFICATIONS_WEBHOOK_MAX_RETRIES" desc:"The number of times a failed webhook request is retried with an exponential backoff." introductionVersion:"7.1"`
+	Timeout    time.Duration `yaml:"timeout" env:"NOTIFICATIONS_WEBHOOK_TIMEOUT" desc:"The timeout of a single webhook request. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+}
+
+// Matrix combines the configuration options of the Matrix channel.
+type Matrix struct {
+	Homeserver   string   `yaml:"homeserver" env:"NOTIFICATIONS_MATRIX_HOMESERVER" desc:"The URL of the Matrix homeserver used to send notifications to the Matrix rooms of the users. The channel is disabled when empty." introductionVersion:"7.1"`
+	AccessToken  string   `yaml:"access_token" env:"NOTIFICATIONS_MATRIX_ACCESS_TOKEN" desc:"The access token of the Matrix account sending the notifications. The account needs to be a member of the rooms of the users." introductionVersion:"7.1"`
+	AllowedRooms []string `yaml:"allowed_rooms" env:"NOTIFICATIONS_MATRIX_ALLOWED_ROOMS" desc:"The Matrix room IDs the users are allowed to receive notifications in. Entries starting with a colon like ':example.com' allow all rooms of that Matrix server. Required when the Matrix channel is enabled. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+}
+
+// ChatWebhooks combines the configuration options of the incoming webhooks of Microsoft Teams and Slack.
+type ChatWebhooks struct {
+	Enabled           bool     `yaml:"enabled" env:"NOTIFICATIONS_CHAT_WEBHOOKS_ENABLED" desc:"Allow users to receive notifications via the incoming webhooks of Microsoft Teams and Slack." introductionVersion:"7.1"`
+	SlackAllowedHosts []string `yaml:"slack_allowed_hosts" env:"NOTIFICATIONS_CHAT_WEBHOOKS_SLACK_ALLOWED_HOSTS" desc:"The hosts the Slack webhook URLs of the users are allowed to point to. Entries starting with a dot also allow all subdomains. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	TeamsAllowedHosts []string `yaml:"teams_allowed_hosts" env:"NOTIFICATIONS_CHAT_WEBHOOKS_TEAMS_ALLOWED_HOSTS" desc:"The hosts the Microsoft Teams webhook URLs of the users are allowed to point to. Entries starting with a dot also allow all subdomains. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
 }
 
 // SMTP combines the smtp configuration options.
@@ -40,6 +40,14 @@ func DefaultConfig() *config.Config {
 				EnableTLS: false,
 			},
 			RevaGateway: shared.DefaultRevaConfig().Address,
+			Webhook: config.Webhook{
+				MaxRetries: 3,
+				Timeout:    10 * time.Second,
+			},
+			ChatWebhooks: config.ChatWebhooks{
+				SlackAllowedHosts: []string{"hooks.slack.com"},
+				TeamsAllowedHosts: []string{".webhook.office.com"},
+			},
 		},
 		Store: config.Store{
 			Store:    "nats-js-kv",
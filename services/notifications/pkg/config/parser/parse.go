@@ -67,6 +67,10 @@ func Validate(cfg *config.Config) error {
 		}
 	}
 
+	if cfg.Notifications.Matrix.Homeserver != "" && len(cfg.Notifications.Matrix.AllowedRooms) == 0 {
+		return fmt.Errorf("the Matrix channel of service %s requires the allowed rooms to be set, see NOTIFICATIONS_MATRIX_ALLOWED_ROOMS", cfg.Service.Name)
+	}
+
 	if cfg.ServiceAccount.ServiceAccountID == "" {
 		return shared.MissingServiceAccountID(cfg.Service.Name)
 	}
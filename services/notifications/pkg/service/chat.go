@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	group "github.com/cs3org/go-cs3apis/cs3/identity/group/v1beta1"
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	"github.com/owncloud/ocis/v2/ocis-pkg/l10n"
+	"github.com/owncloud/ocis/v2/ocis-pkg/middleware"
+	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	"github.com/owncloud/ocis/v2/services/notifications/pkg/channels"
+	"github.com/owncloud/ocis/v2/services/notifications/pkg/email"
+	"github.com/owncloud/ocis/v2/services/settings/pkg/store/defaults"
+	micrometadata "go-micro.dev/v4/metadata"
+)
+
+// sendChat sends the notification to the grantees who enabled the chat option of the event and chose one
+// of the configured chat channels. Chat messages are always sent instantly, the email sending interval
+// doesn't apply to them. The event handlers defer it, so slow chat services don't delay the emails.
+func (s eventsNotifier) sendChat(ctx context.Context, executant, u *user.UserId, g *group.GroupId, settingID string,
+	template email.MessageTemplate, granteeFieldName string, fields map[string]string, sender string) {
+	if len(s.chatChannels) == 0 {
+		return
+	}
+
+	granteeList, err := s.getGranteeList(ctx, executant, u, g, false)
+	if err != nil {
+		s.logger.Error().Err(err).Str("event", "sendChat").Msg("Could not get grantee list")
+		return
+	}
+
+	for _, usr := range granteeList {
+		userID := usr.GetId().GetOpaqueId()
+		logger := s.logger.With().Str("event", "sendChat").Str("userId", userID).Logger()
+
+		if enabled, err := getSetting(ctx, s.valueService, userID, settingID, _optionChat); err != nil || !enabled {
+			continue
+		}
+		chat, err := getStringSetting(ctx, s.valueService, userID, defaults.SettingUUIDProfileChatChannel)
+		if err != nil {
+			logger.Error().Err(err).Msg("cannot get user chat channel")
+			continue
+		}
+		channel, ok := s.chatChannels[chat]
+		if !ok {
+			continue
+		}
+
+		recipient := userID
+		if chat != channels.ChatWebhook {
+			recipient, err = getStringSetting(ctx, s.valueService, userID, defaults.SettingUUIDProfileChatAddress)
+			if err != nil || strings.TrimSpace(recipient) == "" {
+				logger.Debug().Err(err).Str("channel", chat).Msg("user has no chat address, skipped")
+				continue
+			}
+		}
+
+		locale := l10n.MustGetUserLocale(ctx, userID, "", s.valueService)
+		fields[granteeFieldName] = usr.GetDisplayName()
+		message, err := email.RenderEmailTemplate(template, locale, s.defaultLanguage, s.emailTemplatePath, s.translationPath, fields)
+		if err != nil {
+			logger.Error().Err(err).Msg("could not render the chat message")
+			continue
+		}
+		message.Sender = sender
+		message.Recipient = []string{strings.TrimSpace(recipient)}
+		message.AttachInline = nil
+
+		if err := channel.SendMessage(ctx, message); err != nil {
+			logger.Error().Err(err).Str("channel", chat).Msg("failed to send a chat message")
+		}
+	}
+}
+
+// getStringSetting returns the string value of a user setting
+func getStringSetting(ctx context.Context, vc settingssvc.ValueService, userId string, settingId string) (string, error) {
+	resp, err := vc.GetValueByUniqueIdentifiers(
+		micrometadata.Set(ctx, middleware.AccountID, userId),
+		&settingssvc.GetValueByUniqueIdentifiersRequest{
+			AccountUuid: userId,
+			SettingId:   settingId,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	return resp.GetValue().GetValue().GetStringValue(), nil
+}
@@ -25,7 +25,7 @@ func (nf notificationFilter) execute(ctx context.Context, users []*user.User, se
 
 	for _, u := range users {
 		userId := u.GetId().GetOpaqueId()
-		enabled, err := getSetting(ctx, nf.valueClient, userId, settingId)
+		enabled, err := getSetting(ctx, nf.valueClient, userId, settingId, _optionMail)
 		if err != nil {
 			nf.log.Error().Err(err).Str("userId", userId).Str("settingId", settingId).Msg("cannot get user event setting")
 			filteredUsers = append(filteredUsers, u)
@@ -39,7 +39,13 @@ func (nf notificationFilter) execute(ctx context.Context, users []*user.User, se
 	return filteredUsers
 }
 
-func getSetting(ctx context.Context, vc settingssvc.ValueService, userId string, settingId string) (bool, error) {
+const (
+	_optionMail = "mail"
+	_optionChat = "chat"
+)
+
+// getSetting returns the value of the option of an event setting
+func getSetting(ctx context.Context, vc settingssvc.ValueService, userId string, settingId string, optionKey string) (bool, error) {
 	resp, err := vc.GetValueByUniqueIdentifiers(
 		micrometadata.Set(ctx, middleware.AccountID, userId),
 		&settingssvc.GetValueByUniqueIdentifiersRequest{
@@ -54,7 +60,7 @@ func getSetting(ctx context.Context, vc settingssvc.ValueService, userId string,
 
 	val := resp.GetValue().GetValue().GetCollectionValue().GetValues()
 	for _, option := range val {
-		if option.GetKey() == "mail" {
+		if option.GetKey() == optionKey {
 			return option.GetBoolValue(), nil
 		}
 	}
@@ -49,6 +49,7 @@ type Service interface {
 func NewEventsNotifier(
 	events <-chan events.Event,
 	channel channels.Channel,
+	chatChannels map[string]channels.Channel,
 	logger log.Logger,
 	gatewaySelector pool.Selectable[gateway.GatewayAPIClient],
 	valueService settingssvc.ValueService,
@@ -60,6 +61,7 @@ func NewEventsNotifier(
 	return eventsNotifier{
 		logger:               logger,
 		channel:              channel,
+		chatChannels:         chatChannels,
 		events:               events,
 		signals:              make(chan os.Signal, 1),
 		gatewaySelector:      gatewaySelector,
@@ -81,6 +83,7 @@ func NewEventsNotifier(
 type eventsNotifier struct {
 	logger               log.Logger
 	channel              channels.Channel
+	chatChannels         map[string]channels.Channel
 	events               <-chan events.Event
 	signals              chan os.Signal
 	gatewaySelector      pool.Selectable[gateway.GatewayAPIClient]
@@ -160,7 +163,7 @@ func (s eventsNotifier) send(ctx context.Context, emails []*channels.Message) {
 }
 
 func (s eventsNotifier) ensureGranteeList(ctx context.Context, executant, u *user.UserId, g *group.GroupId) []*user.User {
-	granteeList, err := s.getGranteeList(ctx, executant, u, g)
+	granteeList, err := s.getGranteeList(ctx, executant, u, g, true)
 	if err != nil {
 		s.logger.Error().Err(err).Str("event", "ensureGranteeList").Msg("Could not get grantee list")
 		return nil
@@ -170,7 +173,9 @@ func (s eventsNotifier) ensureGranteeList(ctx context.Context, executant, u *use
 	return granteeList
 }
 
-func (s eventsNotifier) getGranteeList(ctx context.Context, executant, u *user.UserId, g *group.GroupId) ([]*user.User, error) {
+// getGranteeList returns the grantees who didn't disable the notifications, users without an email
+// address are skipped if requireMail is set
+func (s eventsNotifier) getGranteeList(ctx context.Context, executant, u *user.UserId, g *group.GroupId, requireMail bool) ([]*user.User, error) {
 	switch {
 	case u != nil:
 		if s.disableEmails(ctx, u) {
@@ -180,7 +185,7 @@ func (s eventsNotifier) getGranteeList(ctx context.Context, executant, u *user.U
 		if err != nil {
 			return nil, err
 		}
-		if strings.TrimSpace(usr.GetMail()) == "" {
+		if requireMail && strings.TrimSpace(usr.GetMail()) == "" {
 			s.logger.Debug().Str("event", "getGranteeList").Msgf("User %s has no email, skipped", usr.GetUsername())
 			return nil, nil
 		}
@@ -213,7 +218,7 @@ func (s eventsNotifier) getGranteeList(ctx context.Context, executant, u *user.U
 			if err != nil {
 				return nil, err
 			}
-			if strings.TrimSpace(usr.GetMail()) == "" {
+			if requireMail && strings.TrimSpace(usr.GetMail()) == "" {
 				s.logger.Debug().Str("event", "getGranteeList").Msgf("User %s has no email, skipped", usr.GetUsername())
 				continue
 			}
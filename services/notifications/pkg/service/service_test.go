@@ -22,6 +22,7 @@ import (
 	"github.com/owncloud/ocis/v2/services/graph/pkg/config/defaults"
 	"github.com/owncloud/ocis/v2/services/notifications/pkg/channels"
 	"github.com/owncloud/ocis/v2/services/notifications/pkg/service"
+	settingsdefaults "github.com/owncloud/ocis/v2/services/settings/pkg/store/defaults"
 	"github.com/stretchr/testify/mock"
 	"go-micro.dev/v4/client"
 	"google.golang.org/grpc"
@@ -94,7 +95,7 @@ var _ = Describe("Notifications", func() {
 			cfg := defaults.FullDefaultConfig()
 			cfg.GRPCClientTLS = &shared.GRPCClientTLS{}
 			ch := make(chan events.Event)
-			evts := service.NewEventsNotifier(ch, tc, log.NewLogger(), gatewaySelector, vs, "",
+			evts := service.NewEventsNotifier(ch, tc, nil, log.NewLogger(), gatewaySelector, vs, "",
 				"", "", "", "", "", "",
 				store.Create(), nil, nil)
 			go evts.Run()
@@ -309,7 +310,7 @@ var _ = Describe("Notifications X-Site Scripting", func() {
 			cfg := defaults.FullDefaultConfig()
 			cfg.GRPCClientTLS = &shared.GRPCClientTLS{}
 			ch := make(chan events.Event)
-			evts := service.NewEventsNotifier(ch, tc, log.NewLogger(), gatewaySelector, vs, "",
+			evts := service.NewEventsNotifier(ch, tc, nil, log.NewLogger(), gatewaySelector, vs, "",
 				"", "", "", "", "", "",
 				store.Create(), nil, nil)
 			go evts.Run()
@@ -460,6 +461,148 @@ https://owncloud.com
 	)
 })
 
+var _ = Describe("Chat notifications", func() {
+	var (
+		gatewayClient   *cs3mocks.GatewayAPIClient
+		gatewaySelector pool.Selectable[gateway.GatewayAPIClient]
+		vs              *settingssvc.MockValueService
+		sharer          = &user.User{
+			Id:          &user.UserId{OpaqueId: "sharer"},
+			Mail:        "sharer@owncloud.com",
+			DisplayName: "Dr. S. Harer",
+		}
+		sharee = &user.User{
+			Id:          &user.UserId{OpaqueId: "sharee"},
+			DisplayName: "Eric Expireling",
+		}
+	)
+
+	BeforeEach(func() {
+		pool.RemoveSelector("GatewaySelector" + "com.owncloud.api.gateway")
+		gatewayClient = &cs3mocks.GatewayAPIClient{}
+		gatewaySelector = pool.GetSelector[gateway.GatewayAPIClient](
+			"GatewaySelector",
+			"com.owncloud.api.gateway",
+			func(cc grpc.ClientConnInterface) gateway.GatewayAPIClient {
+				return gatewayClient
+			},
+		)
+
+		gatewayClient.On("GetUser", mock.Anything, mock.Anything).Return(&user.GetUserResponse{Status: &rpc.Status{Code: rpc.Code_CODE_OK}, User: sharer}, nil).Once()
+		gatewayClient.On("GetUser", mock.Anything, mock.Anything).Return(&user.GetUserResponse{Status: &rpc.Status{Code: rpc.Code_CODE_OK}, User: sharee}, nil)
+		gatewayClient.On("Authenticate", mock.Anything, mock.Anything).Return(&gateway.AuthenticateResponse{Status: &rpc.Status{Code: rpc.Code_CODE_OK}, User: sharer}, nil)
+		gatewayClient.On("Stat", mock.Anything, mock.Anything).Return(&provider.StatResponse{Status: &rpc.Status{Code: rpc.Code_CODE_OK}, Info: &provider.ResourceInfo{Name: "secrets of the board"}}, nil)
+		vs = &settingssvc.MockValueService{}
+		vs.GetValueByUniqueIdentifiersFunc = func(ctx context.Context, req *settingssvc.GetValueByUniqueIdentifiersRequest, opts ...client.CallOption) (*settingssvc.GetValueResponse, error) {
+			value := &settingsmsg.Value{}
+			switch req.GetSettingId() {
+			case settingsdefaults.SettingUUIDProfileChatChannel:
+				value.Value = &settingsmsg.Value_StringValue{StringValue: channels.ChatMatrix}
+			case settingsdefaults.SettingUUIDProfileChatAddress:
+				value.Value = &settingsmsg.Value_StringValue{StringValue: "!room:example.com"}
+			default:
+				value.Value = &settingsmsg.Value_CollectionValue{
+					CollectionValue: &settingsmsg.CollectionValue{
+						Values: []*settingsmsg.CollectionOption{
+							{Key: "mail", Option: &settingsmsg.CollectionOption_BoolValue{BoolValue: true}},
+							{Key: "chat", Option: &settingsmsg.CollectionOption_BoolValue{BoolValue: true}},
+						},
+					},
+				}
+			}
+			return &settingssvc.GetValueResponse{Value: &settingsmsg.ValueWithIdentifier{Value: value}}, nil
+		}
+	})
+
+	It("sends the notification to the chosen chat channel of users without an email address", func() {
+		chat := testChannel{
+			expectedReceipients: []string{"!room:example.com"},
+			expectedSubject:     "Dr. S. Harer shared 'secrets of the board' with you",
+			expectedTextBody: `Hello Eric Expireling
+
+Dr. S. Harer has shared "secrets of the board" with you.
+
+Click here to view it: files/shares/with-me
+
+
+---
+ownCloud - Store. Share. Work.
+https://owncloud.com
+`,
+			expectedSender: sharer.GetDisplayName(),
+			done:           make(chan struct{}),
+		}
+		// the grantee has no email address, no email must be sent
+		mail := testChannel{done: make(chan struct{})}
+
+		ch := make(chan events.Event)
+		evts := service.NewEventsNotifier(ch, mail, map[string]channels.Channel{channels.ChatMatrix: chat}, log.NewLogger(), gatewaySelector, vs, "",
+			"", "", "", "", "", "",
+			store.Create(), nil, nil)
+		go evts.Run()
+
+		ch <- events.Event{
+			Event: events.ShareCreated{
+				Sharer:        sharer.GetId(),
+				GranteeUserID: sharee.GetId(),
+				CTime:         utils.TimeToTS(time.Date(2023, 4, 17, 16, 42, 0, 0, time.UTC)),
+				ItemID:        &provider.ResourceId{StorageId: "storageid", SpaceId: "spaceid", OpaqueId: "itemid"},
+			},
+		}
+		select {
+		case <-chat.done:
+			// finished
+		case <-mail.done:
+			Fail("unexpected email")
+		case <-time.Tick(3 * time.Second):
+			Fail("timeout waiting for notification")
+		}
+	})
+
+	It("sends the emails before the chat messages", func() {
+		sharee := &user.User{
+			Id:          sharee.GetId(),
+			Mail:        "eric@owncloud.com",
+			DisplayName: sharee.GetDisplayName(),
+		}
+		gatewayClient.ExpectedCalls = nil
+		gatewayClient.On("GetUser", mock.Anything, mock.Anything).Return(&user.GetUserResponse{Status: &rpc.Status{Code: rpc.Code_CODE_OK}, User: sharer}, nil).Once()
+		gatewayClient.On("GetUser", mock.Anything, mock.Anything).Return(&user.GetUserResponse{Status: &rpc.Status{Code: rpc.Code_CODE_OK}, User: sharee}, nil)
+		gatewayClient.On("Authenticate", mock.Anything, mock.Anything).Return(&gateway.AuthenticateResponse{Status: &rpc.Status{Code: rpc.Code_CODE_OK}, User: sharer}, nil)
+		gatewayClient.On("Stat", mock.Anything, mock.Anything).Return(&provider.StatResponse{Status: &rpc.Status{Code: rpc.Code_CODE_OK}, Info: &provider.ResourceInfo{Name: "secrets of the board"}}, nil)
+
+		sent := make(chan string, 2)
+		ch := make(chan events.Event)
+		evts := service.NewEventsNotifier(ch, recordingChannel{name: "mail", sent: sent},
+			map[string]channels.Channel{channels.ChatMatrix: recordingChannel{name: "chat", sent: sent}}, log.NewLogger(), gatewaySelector, vs, "",
+			"", "", "", "", "", "",
+			store.Create(), nil, nil)
+		go evts.Run()
+
+		ch <- events.Event{
+			Event: events.ShareCreated{
+				Sharer:        sharer.GetId(),
+				GranteeUserID: sharee.GetId(),
+				CTime:         utils.TimeToTS(time.Date(2023, 4, 17, 16, 42, 0, 0, time.UTC)),
+				ItemID:        &provider.ResourceId{StorageId: "storageid", SpaceId: "spaceid", OpaqueId: "itemid"},
+			},
+		}
+		Eventually(sent, 3*time.Second).Should(Receive(Equal("mail")))
+		Eventually(sent, 3*time.Second).Should(Receive(Equal("chat")))
+	})
+})
+
+// recordingChannel records the name of the channel when a message is sent
+type recordingChannel struct {
+	name string
+	sent chan<- string
+}
+
+func (rc recordingChannel) SendMessage(_ context.Context, _ *channels.Message) error {
+	rc.sent <- rc.name
+	return nil
+}
+
 // NOTE: This is explictitly not testing the message itself. Should we?
 type testChannel struct {
 	expectedReceipients []string
@@ -23,6 +23,15 @@ func (s eventsNotifier) handleShareCreated(e events.ShareCreated, eventId string
 		return
 	}
 
+	sharerDisplayName := owner.GetDisplayName()
+	fields := map[string]string{
+		"ShareSharer": sharerDisplayName,
+		"ShareFolder": shareFolder,
+		"ShareLink":   shareLink,
+	}
+	defer s.sendChat(ctx, owner.GetId(), e.GranteeUserID, e.GranteeGroupID, defaults.SettingUUIDProfileEventShareCreated,
+		email.ShareCreated, "ShareGrantee", fields, sharerDisplayName)
+
 	granteeList := s.ensureGranteeList(ctx, owner.GetId(), e.GranteeUserID, e.GranteeGroupID)
 	filteredGrantees := s.filter.execute(ctx, granteeList, defaults.SettingUUIDProfileEventShareCreated)
 
@@ -33,14 +42,7 @@ func (s eventsNotifier) handleShareCreated(e events.ShareCreated, eventId string
 		return
 	}
 
-	sharerDisplayName := owner.GetDisplayName()
-	emails, err := s.render(ctx, email.ShareCreated,
-		"ShareGrantee",
-		map[string]string{
-			"ShareSharer": sharerDisplayName,
-			"ShareFolder": shareFolder,
-			"ShareLink":   shareLink,
-		}, recipientsInstant, sharerDisplayName)
+	emails, err := s.render(ctx, email.ShareCreated, "ShareGrantee", fields, recipientsInstant, sharerDisplayName)
 	if err != nil {
 		logger.Error().Err(err).Msg("could not get render the email")
 		return
@@ -113,6 +115,13 @@ func (s eventsNotifier) handleShareExpired(e events.ShareExpired, eventId string
 		return
 	}
 
+	fields := map[string]string{
+		"ShareFolder": shareFolder,
+		"ExpiredAt":   e.ExpiredAt.Format("2006-01-02 15:04:05"),
+	}
+	defer s.sendChat(ctx, owner.GetId(), e.GranteeUserID, e.GranteeGroupID, defaults.SettingUUIDProfileEventShareExpired,
+		email.ShareExpired, "ShareGrantee", fields, owner.GetDisplayName())
+
 	granteeList := s.ensureGranteeList(ctx, owner.GetId(), e.GranteeUserID, e.GranteeGroupID)
 	filteredGrantees := s.filter.execute(ctx, granteeList, defaults.SettingUUIDProfileEventShareExpired)
 
@@ -123,12 +132,7 @@ func (s eventsNotifier) handleShareExpired(e events.ShareExpired, eventId string
 		return
 	}
 
-	emails, err := s.render(ctx, email.ShareExpired,
-		"ShareGrantee",
-		map[string]string{
-			"ShareFolder": shareFolder,
-			"ExpiredAt":   e.ExpiredAt.Format("2006-01-02 15:04:05"),
-		}, recipientsInstant, owner.GetDisplayName())
+	emails, err := s.render(ctx, email.ShareExpired, "ShareGrantee", fields, recipientsInstant, owner.GetDisplayName())
 	if err != nil {
 		logger.Error().Err(err).Msg("could not get render the email")
 		return
@@ -22,6 +22,15 @@ func (s eventsNotifier) handleSpaceShared(e events.SpaceShared, eventId string)
 		return
 	}
 
+	sharerDisplayName := executant.GetDisplayName()
+	fields := map[string]string{
+		"SpaceSharer": sharerDisplayName,
+		"SpaceName":   spaceName,
+		"ShareLink":   shareLink,
+	}
+	defer s.sendChat(ctx, executant.GetId(), e.GranteeUserID, e.GranteeGroupID, defaults.SettingUUIDProfileEventSpaceShared,
+		email.SharedSpace, "SpaceGrantee", fields, sharerDisplayName)
+
 	granteeList := s.ensureGranteeList(ctx, executant.GetId(), e.GranteeUserID, e.GranteeGroupID)
 	filteredGrantees := s.filter.execute(ctx, granteeList, defaults.SettingUUIDProfileEventSpaceShared)
 
@@ -32,14 +41,7 @@ func (s eventsNotifier) handleSpaceShared(e events.SpaceShared, eventId string)
 		return
 	}
 
-	sharerDisplayName := executant.GetDisplayName()
-	emails, err := s.render(ctx, email.SharedSpace,
-		"SpaceGrantee",
-		map[string]string{
-			"SpaceSharer": sharerDisplayName,
-			"SpaceName":   spaceName,
-			"ShareLink":   shareLink,
-		}, recipientsInstant, sharerDisplayName)
+	emails, err := s.render(ctx, email.SharedSpace, "SpaceGrantee", fields, recipientsInstant, sharerDisplayName)
 	if err != nil {
 		logger.Error().Err(err).Msg("could not get render the email")
 		return
@@ -107,6 +109,15 @@ func (s eventsNotifier) handleSpaceUnshared(e events.SpaceUnshared, eventId stri
 		return
 	}
 
+	sharerDisplayName := executant.GetDisplayName()
+	fields := map[string]string{
+		"SpaceSharer": sharerDisplayName,
+		"SpaceName":   spaceName,
+		"ShareLink":   shareLink,
+	}
+	defer s.sendChat(ctx, executant.GetId(), e.GranteeUserID, e.GranteeGroupID, defaults.SettingUUIDProfileEventSpaceUnshared,
+		email.UnsharedSpace, "SpaceGrantee", fields, sharerDisplayName)
+
 	granteeList := s.ensureGranteeList(ctx, executant.GetId(), e.GranteeUserID, e.GranteeGroupID)
 	filteredGrantees := s.filter.execute(ctx, granteeList, defaults.SettingUUIDProfileEventSpaceUnshared)
 
@@ -117,14 +128,7 @@ func (s eventsNotifier) handleSpaceUnshared(e events.SpaceUnshared, eventId stri
 		return
 	}
 
-	sharerDisplayName := executant.GetDisplayName()
-	emails, err := s.render(ctx, email.UnsharedSpace,
-		"SpaceGrantee",
-		map[string]string{
-			"SpaceSharer": sharerDisplayName,
-			"SpaceName":   spaceName,
-			"ShareLink":   shareLink,
-		}, recipientsInstant, sharerDisplayName)
+	emails, err := s.render(ctx, email.UnsharedSpace, "SpaceGrantee", fields, recipientsInstant, sharerDisplayName)
 	if err != nil {
 		logger.Error().Err(err).Msg("Could not get render the email")
 		return
@@ -206,6 +210,13 @@ func (s eventsNotifier) handleSpaceMembershipExpired(e events.SpaceMembershipExp
 		return
 	}
 
+	fields := map[string]string{
+		"SpaceName": e.SpaceName,
+		"ExpiredAt": e.ExpiredAt.Format("2006-01-02 15:04:05"),
+	}
+	defer s.sendChat(ctx, owner.GetId(), e.GranteeUserID, e.GranteeGroupID, defaults.SettingUUIDProfileEventSpaceMembershipExpired,
+		email.MembershipExpired, "SpaceGrantee", fields, owner.GetDisplayName())
+
 	granteeList := s.ensureGranteeList(ctx, owner.GetId(), e.GranteeUserID, e.GranteeGroupID)
 	if granteeList == nil {
 		return
@@ -219,12 +230,7 @@ func (s eventsNotifier) handleSpaceMembershipExpired(e events.SpaceMembershipExp
 		return
 	}
 
-	emails, err := s.render(ctx, email.MembershipExpired,
-		"SpaceGrantee",
-		map[string]string{
-			"SpaceName": e.SpaceName,
-			"ExpiredAt": e.ExpiredAt.Format("2006-01-02 15:04:05"),
-		}, recipientsInstant, owner.GetDisplayName())
+	emails, err := s.render(ctx, email.MembershipExpired, "SpaceGrantee", fields, recipientsInstant, owner.GetDisplayName())
 	if err != nil {
 		logger.Error().Err(err).Msg("could not get render the email")
 		return
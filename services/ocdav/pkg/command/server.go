@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/events/stream"
 	"github.com/cs3org/reva/v2/pkg/micro/ocdav"
 	"github.com/cs3org/reva/v2/pkg/sharedconf"
+	"github.com/cs3org/reva/v2/pkg/storage/favorite/memory"
 	"github.com/oklog/run"
 	"github.com/owncloud/ocis/v2/ocis-pkg/broker"
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
@@ -14,6 +17,7 @@ import (
 	"github.com/owncloud/ocis/v2/ocis-pkg/version"
 	"github.com/owncloud/ocis/v2/services/ocdav/pkg/config"
 	"github.com/owncloud/ocis/v2/services/ocdav/pkg/config/parser"
+	"github.com/owncloud/ocis/v2/services/ocdav/pkg/favorite"
 	"github.com/owncloud/ocis/v2/services/ocdav/pkg/logging"
 	"github.com/owncloud/ocis/v2/services/ocdav/pkg/server/debug"
 	"github.com/urfave/cli/v2"
@@ -51,6 +55,19 @@ func Server(cfg *config.Config) *cli.Command {
 				if err := sharedconf.Decode(sc); err != nil {
 					logger.Error().Err(err).Msg("error decoding shared config for ocdav")
 				}
+
+				var publisher events.Publisher
+				if cfg.Events.Endpoint != "" {
+					publisher, err = stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig(cfg.Events))
+					if err != nil {
+						logger.Error().Err(err).Msg("cannot connect to nats")
+						return err
+					}
+				}
+				favorites, err := memory.New(map[string]interface{}{})
+				if err != nil {
+					return err
+				}
 				opts := []ocdav.Option{
 					ocdav.Name(cfg.HTTP.Namespace + "." + cfg.Service.Name),
 					ocdav.Version(version.GetString()),
@@ -80,7 +97,8 @@ func Server(cfg *config.Config) *cli.Command {
 					ocdav.Edition(cfg.Status.Edition),
 					ocdav.MachineAuthAPIKey(cfg.MachineAuthAPIKey),
 					ocdav.Broker(broker.NoOp{}),
-					// ocdav.FavoriteManager() // FIXME needs a proper persistence implementation https://github.com/owncloud/ocis/issues/1228
+					// FIXME the favorites need a proper persistence implementation https://github.com/owncloud/ocis/issues/1228
+					ocdav.FavoriteManager(favorite.NewManager(favorites, publisher, logger)),
 					// ocdav.LockSystem(), // will default to the CS3 lock system
 					// ocdav.TLSConfig() // tls config for the http server
 					ocdav.MetricsEnabled(true),
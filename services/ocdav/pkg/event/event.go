@@ -0,0 +1,39 @@
+package event
+
+import (
+	"encoding/json"
+
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	types "github.com/cs3org/go-cs3apis/cs3/types/v1beta1"
+)
+
+// FavoriteAdded is emitted when a user marked a resource as favorite
+type FavoriteAdded struct {
+	Executant  *user.UserId
+	ResourceID *provider.ResourceId
+	Path       string
+	Timestamp  *types.Timestamp
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (FavoriteAdded) Unmarshal(v []byte) (interface{}, error) {
+	e := FavoriteAdded{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
+
+// FavoriteRemoved is emitted when a user unmarked a resource as favorite
+type FavoriteRemoved struct {
+	Executant  *user.UserId
+	ResourceID *provider.ResourceId
+	Path       string
+	Timestamp  *types.Timestamp
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (FavoriteRemoved) Unmarshal(v []byte) (interface{}, error) {
+	e := FavoriteRemoved{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
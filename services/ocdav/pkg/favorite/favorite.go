@@ -0,0 +1,68 @@
+package favorite
+
+import (
+	"context"
+
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/storage/favorite"
+	"github.com/cs3org/reva/v2/pkg/utils"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/ocdav/pkg/event"
+)
+
+// Manager emits an event whenever a favorite of the wrapped manager is set or unset
+type Manager struct {
+	favorite.Manager
+
+	publisher events.Publisher
+	logger    log.Logger
+}
+
+// NewManager returns a favorite manager emitting events, the publisher may be nil
+func NewManager(m favorite.Manager, publisher events.Publisher, logger log.Logger) *Manager {
+	return &Manager{
+		Manager:   m,
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+// SetFavorite marks a resource as favorited by a user
+func (m *Manager) SetFavorite(ctx context.Context, userID *user.UserId, resourceInfo *provider.ResourceInfo) error {
+	if err := m.Manager.SetFavorite(ctx, userID, resourceInfo); err != nil {
+		return err
+	}
+	m.publish(ctx, event.FavoriteAdded{
+		Executant:  userID,
+		ResourceID: resourceInfo.GetId(),
+		Path:       resourceInfo.GetPath(),
+		Timestamp:  utils.TSNow(),
+	})
+	return nil
+}
+
+// UnsetFavorite unmarks a resource as favorited by a user
+func (m *Manager) UnsetFavorite(ctx context.Context, userID *user.UserId, resourceInfo *provider.ResourceInfo) error {
+	if err := m.Manager.UnsetFavorite(ctx, userID, resourceInfo); err != nil {
+		return err
+	}
+	m.publish(ctx, event.FavoriteRemoved{
+		Executant:  userID,
+		ResourceID: resourceInfo.GetId(),
+		Path:       resourceInfo.GetPath(),
+		Timestamp:  utils.TSNow(),
+	})
+	return nil
+}
+
+func (m *Manager) publish(ctx context.Context, ev interface{}) {
+	if m.publisher == nil {
+		return
+	}
+	if err := events.Publish(ctx, m.publisher, ev); err != nil {
+		m.logger.Error().Err(err).Interface("event", ev).Msg("could not publish event")
+	}
+}
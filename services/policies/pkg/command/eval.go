@@ -0,0 +1,225 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	tw "github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli/v2"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/policies/pkg/config"
+	"github.com/owncloud/ocis/v2/services/policies/pkg/config/parser"
+	"github.com/owncloud/ocis/v2/services/policies/pkg/engine"
+	"github.com/owncloud/ocis/v2/services/policies/pkg/engine/opa"
+)
+
+// evaluation is the result of a single evaluation of the eval command
+type evaluation struct {
+	Source   string `json:"source"`
+	Query    string `json:"query"`
+	Stage    string `json:"stage"`
+	Result   bool   `json:"result"`
+	Error    string `json:"error,omitempty"`
+	Expected *bool  `json:"expected,omitempty"`
+}
+
+func (e evaluation) failed() bool {
+	return e.Error != "" || (e.Expected != nil && *e.Expected != e.Result)
+}
+
+// Eval is the entrypoint for the eval command.
+func Eval(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "eval",
+		Usage: "evaluate policies against environment fixtures or recorded audit denials",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "query",
+				Aliases: []string{"q"},
+				Usage:   "the query to evaluate, e.g. 'data.proxy.granted'. Required for input files, overrides the recorded query on replay.",
+			},
+			&cli.StringSliceFlag{
+				Name:    "input",
+				Aliases: []string{"i"},
+				Usage:   "a json file containing an environment or a list of environments",
+			},
+			&cli.StringSliceFlag{
+				Name:  "replay",
+				Usage: "a file with denials recorded in audit mode, see POLICIES_AUDIT_RECORD_FILE",
+			},
+			&cli.StringSliceFlag{
+				Name:    "policy",
+				Aliases: []string{"p"},
+				Usage:   "the policy files to use instead of the configured ones",
+			},
+			&cli.StringFlag{
+				Name:  "expect",
+				Usage: "fail if a result differs from the expectation, 'granted' or 'denied'",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "output as json",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			return configlog.ReturnFatal(parser.ParseConfig(cfg))
+		},
+		Action: func(c *cli.Context) error {
+			if len(c.StringSlice("input")) == 0 && len(c.StringSlice("replay")) == 0 {
+				return errors.New("either input or replay files are required")
+			}
+			if len(c.StringSlice("input")) > 0 && c.String("query") == "" {
+				return errors.New("a query is required to evaluate input files")
+			}
+
+			var expected *bool
+			switch granted, denied := true, false; c.String("expect") {
+			case "":
+			case "granted":
+				expected = &granted
+			case "denied":
+				expected = &denied
+			default:
+				return fmt.Errorf("invalid expectation '%s', must be 'granted' or 'denied'", c.String("expect"))
+			}
+
+			conf := cfg.Engine
+			if len(c.StringSlice("policy")) > 0 {
+				conf.Policies = c.StringSlice("policy")
+			}
+			// evaluate what would be enforced, without side effects
+			conf.Audit = config.Audit{}
+			conf.DecisionLog = config.DecisionLog{}
+
+			logger := log.NewLogger(
+				log.Name(cfg.Service.Name),
+				log.Level(cfg.Log.Level),
+				log.Pretty(cfg.Log.Pretty),
+				log.Color(cfg.Log.Color),
+				log.File(cfg.Log.File),
+			)
+
+			e, err := opa.NewOPA(conf.Timeout, logger, conf)
+			if err != nil {
+				return err
+			}
+			if e.Revision() == "" {
+				// the policies failed to load, get the error
+				if err := e.Reload(c.Context); err != nil {
+					return err
+				}
+			}
+
+			var evaluations []evaluation
+			evaluate := func(source, query string, raw json.RawMessage) error {
+				var env engine.Environment
+				if err := json.Unmarshal(raw, &env); err != nil {
+					return fmt.Errorf("invalid environment in '%s': %w", source, err)
+				}
+
+				result, err := e.Evaluate(c.Context, query, &env)
+				ev := evaluation{
+					Source:   source,
+					Query:    query,
+					Stage:    string(env.Stage),
+					Result:   result,
+					Expected: expected,
+				}
+				if err != nil {
+					ev.Error = err.Error()
+				}
+				evaluations = append(evaluations, ev)
+				return nil
+			}
+
+			for _, file := range c.StringSlice("input") {
+				envs, err := readEnvironments(file)
+				if err != nil {
+					return fmt.Errorf("could not read input file '%s': %w", file, err)
+				}
+				for i, env := range envs {
+					if err := evaluate(fmt.Sprintf("%s#%d", file, i), c.String("query"), env); err != nil {
+						return err
+					}
+				}
+			}
+
+			for _, file := range c.StringSlice("replay") {
+				f, err := os.Open(file)
+				if err != nil {
+					return err
+				}
+				records, err := opa.ReadRecords(f)
+				f.Close()
+				if err != nil {
+					return fmt.Errorf("could not read replay file '%s': %w", file, err)
+				}
+				for i, rec := range records {
+					query := rec.Query
+					if c.String("query") != "" {
+						query = c.String("query")
+					}
+					if err := evaluate(fmt.Sprintf("%s#%d", file, i), query, rec.Environment); err != nil {
+						return err
+					}
+				}
+			}
+
+			if c.Bool("json") {
+				b, err := json.MarshalIndent(evaluations, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(b))
+			} else {
+				table := tw.NewWriter(os.Stdout)
+				table.SetHeader([]string{"Source", "Query", "Stage", "Result", "Error"})
+				table.SetAutoFormatHeaders(false)
+				for _, ev := range evaluations {
+					table.Append([]string{ev.Source, ev.Query, ev.Stage, result(ev.Result), ev.Error})
+				}
+				table.Render()
+			}
+
+			failed := 0
+			for _, ev := range evaluations {
+				if ev.failed() {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d evaluations failed or did not match the expectation", failed, len(evaluations))
+			}
+			return nil
+		},
+	}
+}
+
+// readEnvironments reads a single environment or a list of environments from a json file
+func readEnvironments(file string) ([]json.RawMessage, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var envs []json.RawMessage
+	if err := json.Unmarshal(b, &envs); err == nil {
+		return envs, nil
+	}
+
+	if !json.Valid(b) {
+		return nil, errors.New("invalid json")
+	}
+	return []json.RawMessage{b}, nil
+}
+
+func result(granted bool) string {
+	if granted {
+		return "granted"
+	}
+	return "denied"
+}
@@ -5,17 +5,22 @@ import (
 	"fmt"
 
 	"github.com/cs3org/reva/v2/pkg/events/stream"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	"github.com/oklog/run"
 	"github.com/urfave/cli/v2"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/registry"
+	"github.com/owncloud/ocis/v2/ocis-pkg/roles"
 	"github.com/owncloud/ocis/v2/ocis-pkg/service/grpc"
 	"github.com/owncloud/ocis/v2/ocis-pkg/tracing"
 	"github.com/owncloud/ocis/v2/ocis-pkg/version"
 	svcProtogen "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/policies/v0"
+	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
 	"github.com/owncloud/ocis/v2/services/policies/pkg/config"
 	"github.com/owncloud/ocis/v2/services/policies/pkg/config/parser"
+	"github.com/owncloud/ocis/v2/services/policies/pkg/engine"
 	"github.com/owncloud/ocis/v2/services/policies/pkg/engine/opa"
 	"github.com/owncloud/ocis/v2/services/policies/pkg/server/debug"
 	svcEvent "github.com/owncloud/ocis/v2/services/policies/pkg/service/event"
@@ -50,22 +55,60 @@ func Server(cfg *config.Config) *cli.Command {
 				return err
 			}
 
-			e, err := opa.NewOPA(cfg.Engine.Timeout, logger, cfg.Engine)
+			o, err := opa.NewOPA(cfg.Engine.Timeout, logger, cfg.Engine)
 			if err != nil {
 				return err
 			}
 
-			{
-				grpcClient, err := grpc.NewClient(
+			gr.Add(func() error {
+				return o.Watch(ctx)
+			}, func(_ error) {
+				cancel()
+			})
+
+			grpcClient, err := grpc.NewClient(
+				append(
+					grpc.GetClientOptions(cfg.GRPCClientTLS),
+					grpc.WithTraceProvider(traceProvider),
+				)...,
+			)
+			if err != nil {
+				return err
+			}
+
+			var e engine.Engine = o
+			if cfg.ServiceAccount.ServiceAccountID != "" && cfg.ServiceAccount.ServiceAccountSecret != "" {
+				tm, err := pool.StringToTLSMode(cfg.GRPCClientTLS.Mode)
+				if err != nil {
+					return err
+				}
+				gatewaySelector, err := pool.GatewaySelector(
+					cfg.Reva.Address,
 					append(
-						grpc.GetClientOptions(cfg.GRPCClientTLS),
-						grpc.WithTraceProvider(traceProvider),
+						cfg.Reva.GetRevaOptions(),
+						pool.WithTLSCACert(cfg.GRPCClientTLS.CACert),
+						pool.WithTLSMode(tm),
+						pool.WithRegistry(registry.GetRegistry()),
+						pool.WithTracerProvider(traceProvider),
 					)...,
 				)
 				if err != nil {
 					return err
 				}
 
+				rm := roles.NewManager(
+					roles.Logger(logger),
+					roles.RoleService(settingssvc.NewRoleService("com.owncloud.api.settings", grpcClient)),
+				)
+
+				enricher := engine.NewEnricher(o, gatewaySelector, &rm, cfg.ServiceAccount.ServiceAccountID, cfg.ServiceAccount.ServiceAccountSecret, cfg.Engine.EnvironmentCacheTTL, logger)
+				defer enricher.Stop()
+				e = enricher
+			} else {
+				logger.Info().Msg("no service account configured, the policy environment is not extended")
+			}
+
+			{
 				svc, err := grpc.NewServiceWithClient(
 					grpcClient,
 					grpc.Logger(logger),
@@ -20,6 +20,8 @@ type Config struct {
 	Engine         Engine                `yaml:"engine"`
 	Postprocessing Postprocessing        `yaml:"postprocessing"`
 	Tracing        *Tracing              `yaml:"tracing"`
+	Reva           *shared.Reva          `yaml:"reva"`
+	ServiceAccount ServiceAccount        `yaml:"service_account"`
 }
 
 // Service defines the available service configuration.
@@ -27,6 +29,12 @@ type Service struct {
 	Name string `yaml:"-"`
 }
 
+// ServiceAccount is the configuration for the used service account
+type ServiceAccount struct {
+	ServiceAccountID     string `yaml:"service_account_id" env:"OCIS_SERVICE_ACCOUNT_ID;POLICIES_SERVICE_ACCOUNT_ID" desc:"The ID of the service account the service should use. See the 'auth-service' service description for more details. The policy environment is only extended by the space and the roles and groups of the user if a service account is configured." introductionVersion:"7.1"`
+	ServiceAccountSecret string `yaml:"service_account_secret" env:"OCIS_SERVICE_ACCOUNT_SECRET;POLICIES_SERVICE_ACCOUNT_SECRET" desc:"The service account secret." introductionVersion:"7.1"`
+}
+
 // GRPC defines the available grpc configuration.
 type GRPC struct {
 	Addr      string                 `ocisConfig:"addr" env:"POLICIES_GRPC_ADDR" desc:"The bind address of the GRPC service." introductionVersion:"pre5.0"`
@@ -40,6 +48,31 @@ type Engine struct {
 	Policies []string      `yaml:"policies"`
 	// Mimes file path, RFC 4288
 	Mimes string `yaml:"mimes" env:"POLICIES_ENGINE_MIMES" desc:"Sets the mimes file path which maps mimetypes to associated file extensions. See the text description for details." introductionVersion:"pre5.0"`
+
+	EnvironmentCacheTTL time.Duration `yaml:"environment_cache_ttl" env:"POLICIES_ENGINE_ENVIRONMENT_CACHE_TTL" desc:"The time the spaces and the roles and groups of users are cached when extending the policy environment. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+
+	Watch       bool        `yaml:"watch" env:"POLICIES_ENGINE_WATCH" desc:"Recompile the policies when the policy files change. Policies are also recompiled when the service receives a SIGHUP signal. See the text description for details." introductionVersion:"7.1"`
+	Bundle      Bundle      `yaml:"bundle"`
+	DecisionLog DecisionLog `yaml:"decision_log"`
+	Audit       Audit       `yaml:"audit"`
+}
+
+// Audit configures queries which are evaluated but not enforced.
+type Audit struct {
+	Queries    []string `yaml:"queries" env:"POLICIES_AUDIT_QUERIES" desc:"A list of queries which are evaluated in audit mode. Denials of these queries are logged and recorded but not enforced. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	RecordFile string   `yaml:"record_file" env:"POLICIES_AUDIT_RECORD_FILE" desc:"Record the environments of denials in audit mode as json lines to this file. The file can be replayed with the 'ocis policies eval' command." introductionVersion:"7.1"`
+}
+
+// Bundle configures an OPA bundle the policies are loaded from in addition to the policy files.
+type Bundle struct {
+	URL          string        `yaml:"url" env:"POLICIES_ENGINE_BUNDLE_URL" desc:"The URL of an OPA bundle tarball. The bundle is loaded in addition to the configured policy files. See the text description for details." introductionVersion:"7.1"`
+	PollInterval time.Duration `yaml:"poll_interval" env:"POLICIES_ENGINE_BUNDLE_POLL_INTERVAL" desc:"The interval in which the bundle URL is checked for a new revision of the bundle. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+}
+
+// DecisionLog configures the logging of policy decisions.
+type DecisionLog struct {
+	Enabled bool   `yaml:"enabled" env:"POLICIES_DECISION_LOG_ENABLED" desc:"Log every policy decision with the query, a hash of the input, the result, the latency and the revision of the policies." introductionVersion:"7.1"`
+	File    string `yaml:"file" env:"POLICIES_DECISION_LOG_FILE" desc:"Write the decision log as json lines to this file instead of the service log." introductionVersion:"7.1"`
 }
 
 // Postprocessing defines the config options for the postprocessing policy handling.
@@ -3,6 +3,7 @@ package defaults
 import (
 	"time"
 
+	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
 	"github.com/owncloud/ocis/v2/ocis-pkg/structs"
 	"github.com/owncloud/ocis/v2/services/policies/pkg/config"
 )
@@ -21,6 +22,7 @@ func DefaultConfig() *config.Config {
 		Service: config.Service{
 			Name: "policies",
 		},
+		Reva: shared.DefaultRevaConfig(),
 		Debug: config.Debug{
 			Addr:   "127.0.0.1:9129",
 			Token:  "",
@@ -37,7 +39,12 @@ func DefaultConfig() *config.Config {
 			EnableTLS: false,
 		},
 		Engine: config.Engine{
-			Timeout: 10 * time.Second,
+			Timeout:             10 * time.Second,
+			EnvironmentCacheTTL: time.Minute,
+			Watch:               true,
+			Bundle: config.Bundle{
+				PollInterval: time.Minute,
+			},
 		},
 	}
 }
@@ -54,6 +61,10 @@ func EnsureDefaults(cfg *config.Config) {
 		cfg.Log = &config.Log{}
 	}
 
+	if cfg.Reva == nil && cfg.Commons != nil {
+		cfg.Reva = structs.CopyOrZeroValue(cfg.Commons.Reva)
+	}
+
 	if cfg.GRPCClientTLS == nil && cfg.Commons != nil {
 		cfg.GRPCClientTLS = structs.CopyOrZeroValue(cfg.Commons.GRPCClientTLS)
 	}
@@ -8,11 +8,12 @@ import (
 	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
 	"github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/policies/v0"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 // Engine defines the granted handlers.
 type Engine interface {
-	Evaluate(ctx context.Context, query string, env Environment) (bool, error)
+	Evaluate(ctx context.Context, query string, env *Environment) (bool, error)
 }
 
 type (
@@ -42,25 +43,72 @@ type Request struct {
 	Path   string `json:"path"`
 }
 
+// Space contains information about the space of the resource and is used as part of the evaluated environment.
+type Space struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Owner string `json:"owner"`
+}
+
+type (
+	// ShareType defines how a resource is shared with the user
+	ShareType string
+)
+
+var (
+	// ShareTypePublic defines access via a public link
+	ShareTypePublic ShareType = "public"
+
+	// ShareTypeInternal defines access via a share with a user or group
+	ShareTypeInternal ShareType = "internal"
+)
+
+// Share contains information about the share the resource is accessed through and is used as part of the evaluated environment.
+// The type is empty if the resource is not accessed through a share.
+type Share struct {
+	Type ShareType `json:"type"`
+}
+
 // Environment contains every data that is needed to decide if the request should pass or not
 type Environment struct {
 	Stage    Stage     `json:"stage"`
 	User     user.User `json:"user"`
+	Roles    []string  `json:"roles"`
 	Request  Request   `json:"request"`
 	Resource Resource  `json:"resource"`
+	Space    Space     `json:"space"`
+	Share    Share     `json:"share"`
 }
 
 // NewEnvironmentFromPB converts a PBEnvironment to Environment.
-func NewEnvironmentFromPB(pEnv *v0.Environment) (Environment, error) {
-	env := Environment{}
+func NewEnvironmentFromPB(pEnv *v0.Environment) (*Environment, error) {
+	env := &Environment{}
 
-	rData, err := protojson.Marshal(pEnv)
+	// protojson encodes 64-bit integers as strings, the size is copied below
+	cEnv := proto.Clone(pEnv).(*v0.Environment)
+	if cEnv.GetResource() != nil {
+		cEnv.Resource.Size = 0
+	}
+
+	rData, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(cEnv)
 	if err != nil {
-		return env, err
+		return nil, err
+	}
+
+	if err := json.Unmarshal(rData, env); err != nil {
+		return nil, err
 	}
 
-	if err := json.Unmarshal(rData, &env); err != nil {
-		return env, err
+	// the fields which differ between the messages and the environment
+	env.Roles = pEnv.GetUser().GetRoles()
+	env.Resource.Size = pEnv.GetResource().GetSize()
+	if id := pEnv.GetResource().GetId(); id != nil {
+		env.Resource.ID = provider.ResourceId{
+			StorageId: id.GetStorageId(),
+			SpaceId:   id.GetSpaceId(),
+			OpaqueId:  id.GetOpaqueId(),
+		}
 	}
 
 	switch pEnv.Stage {
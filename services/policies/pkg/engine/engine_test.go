@@ -22,4 +22,36 @@ var _ = Describe("Engine", func() {
 		Entry("http stage", pMessage.Stage_STAGE_HTTP, engine.StageHTTP),
 		Entry("pp stage", pMessage.Stage_STAGE_PP, engine.StagePP),
 	)
+
+	It("converts the environment", func() {
+		pEnv := &pMessage.Environment{
+			Stage: pMessage.Stage_STAGE_HTTP,
+			User: &pMessage.User{
+				Id:          &pMessage.User_ID{OpaqueId: "einstein"},
+				DisplayName: "Albert Einstein",
+				Groups:      []string{"physics-lovers"},
+				Roles:       []string{"user"},
+			},
+			Resource: &pMessage.Resource{
+				Id:   &pMessage.Resource_ID{StorageId: "storage", SpaceId: "space", OpaqueId: "file"},
+				Name: "relativity.pdf",
+				Size: 1905,
+			},
+			Space: &pMessage.Space{Id: "storage$space", Type: "project", Name: "Physics", Owner: "einstein"},
+			Share: &pMessage.Share{Type: "public"},
+		}
+
+		env, err := engine.NewEnvironmentFromPB(pEnv)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(env.User.GetId().GetOpaqueId()).To(Equal("einstein"))
+		Expect(env.User.GetDisplayName()).To(Equal("Albert Einstein"))
+		Expect(env.User.GetGroups()).To(Equal([]string{"physics-lovers"}))
+		Expect(env.Roles).To(Equal([]string{"user"}))
+		Expect(env.Resource.ID.GetSpaceId()).To(Equal("space"))
+		Expect(env.Resource.Name).To(Equal("relativity.pdf"))
+		Expect(env.Resource.Size).To(Equal(uint64(1905)))
+		Expect(env.Space).To(Equal(engine.Space{ID: "storage$space", Type: "project", Name: "Physics", Owner: "einstein"}))
+		Expect(env.Share.Type).To(Equal(engine.ShareTypePublic))
+	})
 })
@@ -0,0 +1,192 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"github.com/jellydator/ttlcache/v3"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/roles"
+)
+
+// Enricher is an Engine which adds the space of the resource, the share type and the roles and groups of the user
+// to the environment before it is evaluated by the next engine.
+// Lookups which fail are logged, the affected parts of the environment stay empty.
+type Enricher struct {
+	next            Engine
+	gatewaySelector pool.Selectable[gateway.GatewayAPIClient]
+	roles           *roles.Manager
+	serviceUserID   string
+	serviceSecret   string
+	logger          log.Logger
+
+	spaces    *ttlcache.Cache[string, Space]
+	roleNames *ttlcache.Cache[string, []string]
+	groups    *ttlcache.Cache[string, []string]
+}
+
+// NewEnricher returns an Enricher, lookups are cached for the given ttl.
+func NewEnricher(next Engine, gatewaySelector pool.Selectable[gateway.GatewayAPIClient], rm *roles.Manager, serviceUserID, serviceSecret string, ttl time.Duration, logger log.Logger) *Enricher {
+	e := &Enricher{
+		next:            next,
+		gatewaySelector: gatewaySelector,
+		roles:           rm,
+		serviceUserID:   serviceUserID,
+		serviceSecret:   serviceSecret,
+		logger:          logger,
+		spaces:          ttlcache.New(ttlcache.WithTTL[string, Space](ttl), ttlcache.WithDisableTouchOnHit[string, Space]()),
+		roleNames:       ttlcache.New(ttlcache.WithTTL[string, []string](ttl), ttlcache.WithDisableTouchOnHit[string, []string]()),
+		groups:          ttlcache.New(ttlcache.WithTTL[string, []string](ttl), ttlcache.WithDisableTouchOnHit[string, []string]()),
+	}
+
+	go e.spaces.Start()
+	go e.roleNames.Start()
+	go e.groups.Start()
+
+	return e
+}
+
+// Evaluate enriches the environment in place and evaluates it with the next engine.
+func (e *Enricher) Evaluate(ctx context.Context, query string, env *Environment) (bool, error) {
+	e.enrich(ctx, env)
+	return e.next.Evaluate(ctx, query, env)
+}
+
+// Stop stops the cache cleanups.
+func (e *Enricher) Stop() {
+	e.spaces.Stop()
+	e.roleNames.Stop()
+	e.groups.Stop()
+}
+
+func (e *Enricher) enrich(ctx context.Context, env *Environment) {
+	if env.Share.Type == "" && env.Resource.ID.GetSpaceId() == utils.ShareStorageSpaceID {
+		env.Share.Type = ShareTypeInternal
+	}
+
+	if env.Space.ID == "" && env.Resource.ID.GetSpaceId() != "" && env.Resource.ID.GetSpaceId() != utils.ShareStorageSpaceID {
+		space, err := e.space(ctx, storagespace.FormatStorageID(env.Resource.ID.GetStorageId(), env.Resource.ID.GetSpaceId()))
+		if err != nil {
+			e.logger.Error().Err(err).Str("space", env.Resource.ID.GetSpaceId()).Msg("could not add the space to the policy environment")
+		}
+		env.Space = space
+	}
+
+	uid := env.User.GetId().GetOpaqueId()
+	if uid == "" {
+		return
+	}
+
+	if len(env.Roles) == 0 {
+		names, err := e.roleNamesForUser(ctx, uid)
+		if err != nil {
+			e.logger.Error().Err(err).Str("userid", uid).Msg("could not add the roles to the policy environment")
+		}
+		env.Roles = names
+	}
+
+	if len(env.User.GetGroups()) == 0 {
+		groups, err := e.groupsForUser(ctx, env.User.GetId())
+		if err != nil {
+			e.logger.Error().Err(err).Str("userid", uid).Msg("could not add the groups to the policy environment")
+		}
+		env.User.Groups = groups
+	}
+}
+
+func (e *Enricher) space(ctx context.Context, id string) (Space, error) {
+	if item := e.spaces.Get(id); item != nil {
+		return item.Value(), nil
+	}
+
+	gwc, err := e.gatewaySelector.Next()
+	if err != nil {
+		return Space{}, err
+	}
+
+	ctx, err = utils.GetServiceUserContextWithContext(ctx, gwc, e.serviceUserID, e.serviceSecret)
+	if err != nil {
+		return Space{}, err
+	}
+
+	res, err := gwc.ListStorageSpaces(ctx, &provider.ListStorageSpacesRequest{
+		Filters: []*provider.ListStorageSpacesRequest_Filter{{
+			Type: provider.ListStorageSpacesRequest_Filter_TYPE_ID,
+			Term: &provider.ListStorageSpacesRequest_Filter_Id{
+				Id: &provider.StorageSpaceId{OpaqueId: id},
+			},
+		}},
+	})
+	switch {
+	case err != nil:
+		return Space{}, err
+	case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return Space{}, errors.New(res.GetStatus().GetMessage())
+	case len(res.GetStorageSpaces()) == 0:
+		return Space{}, errors.New("space not found")
+	}
+
+	s := res.GetStorageSpaces()[0]
+	space := Space{
+		ID:    s.GetId().GetOpaqueId(),
+		Type:  s.GetSpaceType(),
+		Name:  s.GetName(),
+		Owner: s.GetOwner().GetId().GetOpaqueId(),
+	}
+	e.spaces.Set(id, space, ttlcache.DefaultTTL)
+	return space, nil
+}
+
+func (e *Enricher) roleNamesForUser(ctx context.Context, uid string) ([]string, error) {
+	if item := e.roleNames.Get(uid); item != nil {
+		return item.Value(), nil
+	}
+
+	ids, err := e.roles.FindRoleIDsForUser(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(ids))
+	for _, role := range e.roles.List(ctx, ids) {
+		names = append(names, role.GetName())
+	}
+	e.roleNames.Set(uid, names, ttlcache.DefaultTTL)
+	return names, nil
+}
+
+func (e *Enricher) groupsForUser(ctx context.Context, uid *user.UserId) ([]string, error) {
+	if item := e.groups.Get(uid.GetOpaqueId()); item != nil {
+		return item.Value(), nil
+	}
+
+	gwc, err := e.gatewaySelector.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = utils.GetServiceUserContextWithContext(ctx, gwc, e.serviceUserID, e.serviceSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := gwc.GetUserGroups(ctx, &user.GetUserGroupsRequest{UserId: uid})
+	switch {
+	case err != nil:
+		return nil, err
+	case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return nil, errors.New(res.GetStatus().GetMessage())
+	}
+
+	e.groups.Set(uid.GetOpaqueId(), res.GetGroups(), ttlcache.DefaultTTL)
+	return res.GetGroups(), nil
+}
@@ -0,0 +1,128 @@
+package engine_test
+
+import (
+	"context"
+	"time"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	cs3mocks "github.com/cs3org/reva/v2/tests/cs3mocks/mocks"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"go-micro.dev/v4/client"
+	"google.golang.org/grpc"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/roles"
+	settingsmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/settings/v0"
+	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	"github.com/owncloud/ocis/v2/services/policies/pkg/engine"
+)
+
+// capturingEngine records the evaluated environments
+type capturingEngine struct {
+	envs []*engine.Environment
+}
+
+func (c *capturingEngine) Evaluate(_ context.Context, _ string, env *engine.Environment) (bool, error) {
+	c.envs = append(c.envs, env)
+	return true, nil
+}
+
+// roleService returns the user role for every user
+type roleService struct {
+	settingssvc.RoleService
+}
+
+func (roleService) ListRoleAssignments(_ context.Context, _ *settingssvc.ListRoleAssignmentsRequest, _ ...client.CallOption) (*settingssvc.ListRoleAssignmentsResponse, error) {
+	return &settingssvc.ListRoleAssignmentsResponse{Assignments: []*settingsmsg.UserRoleAssignment{{RoleId: "user-role-id"}}}, nil
+}
+
+func (roleService) ListRoles(_ context.Context, _ *settingssvc.ListBundlesRequest, _ ...client.CallOption) (*settingssvc.ListBundlesResponse, error) {
+	return &settingssvc.ListBundlesResponse{Bundles: []*settingsmsg.Bundle{{Id: "user-role-id", Name: "user"}}}, nil
+}
+
+var _ = Describe("Enricher", func() {
+	var (
+		gatewayClient *cs3mocks.GatewayAPIClient
+		next          *capturingEngine
+		enricher      *engine.Enricher
+	)
+
+	BeforeEach(func() {
+		gatewayClient = &cs3mocks.GatewayAPIClient{}
+		gatewayClient.On("Authenticate", mock.Anything, mock.Anything).Return(&gateway.AuthenticateResponse{
+			Status: &rpc.Status{Code: rpc.Code_CODE_OK},
+			Token:  "service-token",
+		}, nil)
+
+		gatewaySelector := pool.GetSelector[gateway.GatewayAPIClient](
+			"GatewaySelector",
+			"com.owncloud.api.gateway."+uuid.New().String(),
+			func(cc grpc.ClientConnInterface) gateway.GatewayAPIClient {
+				return gatewayClient
+			},
+		)
+
+		rm := roles.NewManager(roles.RoleService(roleService{}))
+		next = &capturingEngine{}
+		enricher = engine.NewEnricher(next, gatewaySelector, &rm, "service-user", "secret", time.Minute, log.NopLogger())
+	})
+
+	AfterEach(func() {
+		enricher.Stop()
+	})
+
+	It("adds the space, the roles and the groups", func() {
+		gatewayClient.On("ListStorageSpaces", mock.Anything, mock.Anything).Return(&provider.ListStorageSpacesResponse{
+			Status: &rpc.Status{Code: rpc.Code_CODE_OK},
+			StorageSpaces: []*provider.StorageSpace{{
+				Id:        &provider.StorageSpaceId{OpaqueId: "storage$space"},
+				SpaceType: "project",
+				Name:      "Physics",
+				Owner:     &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "einstein"}},
+			}},
+		}, nil).Once()
+		gatewayClient.On("GetUserGroups", mock.Anything, mock.Anything).Return(&userv1beta1.GetUserGroupsResponse{
+			Status: &rpc.Status{Code: rpc.Code_CODE_OK},
+			Groups: []string{"physics-lovers"},
+		}, nil).Once()
+
+		for i := 0; i < 2; i++ {
+			env := &engine.Environment{
+				User:     userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "marie"}},
+				Resource: engine.Resource{ID: provider.ResourceId{StorageId: "storage", SpaceId: "space", OpaqueId: "file"}},
+			}
+			_, err := enricher.Evaluate(context.Background(), "data.test.granted", env)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		Expect(next.envs).To(HaveLen(2))
+		for _, got := range next.envs {
+			Expect(got.Space).To(Equal(engine.Space{ID: "storage$space", Type: "project", Name: "Physics", Owner: "einstein"}))
+			Expect(got.Roles).To(Equal([]string{"user"}))
+			Expect(got.User.GetGroups()).To(Equal([]string{"physics-lovers"}))
+			Expect(got.Share.Type).To(BeEmpty())
+		}
+		gatewayClient.AssertNumberOfCalls(GinkgoT(), "ListStorageSpaces", 1)
+		gatewayClient.AssertNumberOfCalls(GinkgoT(), "GetUserGroups", 1)
+	})
+
+	It("detects received shares", func() {
+		env := &engine.Environment{
+			Roles:    []string{"user"},
+			Resource: engine.Resource{ID: provider.ResourceId{StorageId: utils.ShareStorageProviderID, SpaceId: utils.ShareStorageSpaceID}},
+		}
+		_, err := enricher.Evaluate(context.Background(), "data.test.granted", env)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(next.envs[0].Share.Type).To(Equal(engine.ShareTypeInternal))
+		gatewayClient.AssertNotCalled(GinkgoT(), "ListStorageSpaces", mock.Anything, mock.Anything)
+	})
+})
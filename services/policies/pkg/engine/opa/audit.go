@@ -0,0 +1,125 @@
+package opa
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// _redacted replaces the credentials in recorded environments
+const _redacted = "REDACTED"
+
+// _jwtSegment matches path segments holding a token like the ones of the data gateway
+var _jwtSegment = regexp.MustCompile(`^eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+$`)
+
+// Record is a recorded evaluation of a query in audit mode which would have been denied.
+type Record struct {
+	Timestamp   time.Time       `json:"timestamp"`
+	Query       string          `json:"query"`
+	Revision    string          `json:"revision"`
+	Error       string          `json:"error,omitempty"`
+	Environment json.RawMessage `json:"environment"`
+}
+
+// recorder writes the records as json lines to a file
+type recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func newRecorder(file string) (*recorder, error) {
+	if file == "" {
+		return nil, nil
+	}
+
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &recorder{enc: json.NewEncoder(f)}, nil
+}
+
+func (r *recorder) Record(rec *Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enc.Encode(rec)
+}
+
+// redactEnvironment removes the credentials from the urls of the marshalled environment before it is recorded.
+// Upload and download urls carry tokens, which grant access to the file, in the path or the query.
+func redactEnvironment(raw []byte) ([]byte, error) {
+	var env map[string]interface{}
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return nil, err
+	}
+
+	for _, field := range [][2]string{{"resource", "url"}, {"request", "path"}} {
+		obj, ok := env[field[0]].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v, ok := obj[field[1]].(string); ok && v != "" {
+			obj[field[1]] = redactURL(v)
+		}
+	}
+	return json.Marshal(env)
+}
+
+// redactURL replaces user info, query values, fragments and token path segments of the url
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return _redacted
+	}
+
+	if u.User != nil {
+		u.User = url.User(_redacted)
+	}
+	if u.RawQuery != "" {
+		q := u.Query()
+		for k := range q {
+			q[k] = []string{_redacted}
+		}
+		u.RawQuery = q.Encode()
+	}
+	if u.Fragment != "" {
+		u.Fragment = _redacted
+	}
+
+	segments := strings.Split(u.Path, "/")
+	for i, s := range segments {
+		if _jwtSegment.MatchString(s) {
+			segments[i] = _redacted
+		}
+	}
+	u.Path, u.RawPath = strings.Join(segments, "/"), ""
+	return u.String()
+}
+
+// ReadRecords reads the records written in audit mode.
+func ReadRecords(r io.Reader) ([]Record, error) {
+	var records []Record
+
+	scanner := bufio.NewScanner(r)
+	// environments can get big, e.g. users with many groups
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+
+	return records, scanner.Err()
+}
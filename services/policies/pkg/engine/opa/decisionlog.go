@@ -0,0 +1,76 @@
+package opa
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/policies/pkg/config"
+)
+
+// Decision is the log entry of a single policy evaluation.
+type Decision struct {
+	ID        string    `json:"decision_id"`
+	Timestamp time.Time `json:"timestamp"`
+	Query     string    `json:"query"`
+	InputHash string    `json:"input_hash"`
+	Result    bool      `json:"result"`
+	Audit     bool      `json:"audit,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	LatencyMS float64   `json:"latency_ms"`
+	Revision  string    `json:"revision"`
+}
+
+// DecisionLogger exports policy decisions.
+type DecisionLogger interface {
+	Log(d Decision)
+}
+
+// NewDecisionLogger returns the decision logger for the given configuration.
+// It returns nil if decision logging is disabled.
+func NewDecisionLogger(conf config.DecisionLog, logger log.Logger) (DecisionLogger, error) {
+	switch {
+	case !conf.Enabled:
+		return nil, nil
+	case conf.File != "":
+		f, err := os.OpenFile(conf.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		return &fileDecisionLogger{enc: json.NewEncoder(f)}, nil
+	default:
+		return logDecisionLogger{logger: logger}, nil
+	}
+}
+
+// logDecisionLogger writes the decisions to the service log
+type logDecisionLogger struct {
+	logger log.Logger
+}
+
+func (l logDecisionLogger) Log(d Decision) {
+	l.logger.Info().
+		Str("decision_id", d.ID).
+		Str("query", d.Query).
+		Str("input_hash", d.InputHash).
+		Bool("result", d.Result).
+		Bool("audit", d.Audit).
+		Str("error", d.Error).
+		Float64("latency_ms", d.LatencyMS).
+		Str("revision", d.Revision).
+		Msg("policy decision")
+}
+
+// fileDecisionLogger writes the decisions as json lines to a file
+type fileDecisionLogger struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (l *fileDecisionLogger) Log(d Decision) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_ = l.enc.Encode(d)
+}
@@ -2,10 +2,19 @@ package opa
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"io"
+	"net/http"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/open-policy-agent/opa/bundle"
 	"github.com/open-policy-agent/opa/rego"
 	"github.com/open-policy-agent/opa/topdown/print"
 
@@ -14,23 +23,40 @@ import (
 	"github.com/owncloud/ocis/v2/services/policies/pkg/engine"
 )
 
+// ErrNoPolicies is returned when no policies could be loaded, evaluations are denied.
+var ErrNoPolicies = errors.New("no policies loaded")
+
 // OPA wraps open policy agent makes it possible to ask if an action is granted.
+// The policies are compiled once and replaced atomically on Reload.
 type OPA struct {
+	logger    log.Logger
 	printHook print.Hook
 	policies  []string
 	timeout   time.Duration
 	options   []func(r *rego.Rego)
+	conf      config.Engine
+	decisions DecisionLogger
+	recorder  *recorder
+	audit     map[string]bool
+	client    *http.Client
+
+	current atomic.Pointer[policySet]
+
+	// reload serializes reloads, the bundle state is only accessed while holding it
+	reload     sync.Mutex
+	bundle     *bundle.Bundle
+	bundleETag string
 }
 
 // NewOPA returns a ready to use opa engine.
-func NewOPA(timeout time.Duration, logger log.Logger, conf config.Engine) (OPA, error) {
+func NewOPA(timeout time.Duration, logger log.Logger, conf config.Engine) (*OPA, error) {
 	var mtReader io.ReadCloser
 
 	if conf.Mimes != "" {
 		var err error
 		mtReader, err = os.Open(conf.Mimes)
 		if err != nil {
-			return OPA{}, err
+			return nil, err
 		}
 
 		defer mtReader.Close()
@@ -38,34 +64,137 @@ func NewOPA(timeout time.Duration, logger log.Logger, conf config.Engine) (OPA,
 
 	rfMimetypeExtensions, err := RFMimetypeExtensions(mtReader)
 	if err != nil {
-		return OPA{}, err
+		return nil, err
+	}
+
+	decisions, err := NewDecisionLogger(conf.DecisionLog, logger)
+	if err != nil {
+		return nil, err
 	}
 
-	return OPA{
+	recorder, err := newRecorder(conf.Audit.RecordFile)
+	if err != nil {
+		return nil, err
+	}
+
+	audit := make(map[string]bool, len(conf.Audit.Queries))
+	for _, q := range conf.Audit.Queries {
+		audit[q] = true
+	}
+
+	o := &OPA{
+		logger:    logger,
 		policies:  conf.Policies,
 		timeout:   timeout,
 		printHook: logPrinter{logger: logger},
-		options: []func(r *rego.Rego){
-			RFMimetypeDetect,
-			RFResourceDownload,
-			rfMimetypeExtensions,
-		},
-	}, nil
+		conf:      conf,
+		decisions: decisions,
+		recorder:  recorder,
+		audit:     audit,
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+	o.options = []func(r *rego.Rego){
+		rego.EnablePrintStatements(true),
+		rego.PrintHook(o.printHook),
+		RFMimetypeDetect,
+		RFResourceDownload,
+		RFResourceSHA256,
+		RFUserInGroup,
+		RFArchiveListEntries,
+		rfMimetypeExtensions,
+	}
+
+	// a broken policy must not prevent the service from starting, evaluations are denied until it is fixed
+	if err := o.Reload(context.Background()); err != nil {
+		logger.Error().Err(err).Msg("could not load policies")
+	}
+
+	return o, nil
 }
 
 // Evaluate evaluates the opa policies and returns the result.
-func (o OPA) Evaluate(ctx context.Context, qs string, env engine.Environment) (bool, error) {
+// Queries in audit mode are always granted, would-be denials are logged and recorded instead.
+func (o *OPA) Evaluate(ctx context.Context, qs string, env *engine.Environment) (bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, o.timeout)
 	defer cancel()
 
-	q, err := rego.New(
-		append([]func(r *rego.Rego){
-			rego.Query(qs),
-			rego.Load(o.policies, nil),
-			rego.EnablePrintStatements(true),
-			rego.PrintHook(o.printHook),
-		}, o.options...)...,
-	).PrepareForEval(ctx)
+	start := time.Now()
+	ps := o.current.Load()
+
+	allowed, err := o.evaluate(ctx, ps, qs, env)
+
+	revision := ""
+	if ps != nil {
+		revision = ps.revision
+	}
+
+	if o.decisions != nil {
+		d := Decision{
+			ID:        uuid.New().String(),
+			Timestamp: start,
+			Query:     qs,
+			InputHash: inputHash(env),
+			Result:    allowed,
+			Audit:     o.audit[qs],
+			LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+			Revision:  revision,
+		}
+		if err != nil {
+			d.Error = err.Error()
+		}
+		o.decisions.Log(d)
+	}
+
+	if o.audit[qs] && !allowed {
+		o.auditDenial(start, qs, revision, err, env)
+		return true, nil
+	}
+
+	return allowed, err
+}
+
+// auditDenial logs and records a denial which is not enforced
+func (o *OPA) auditDenial(t time.Time, qs, revision string, err error, env *engine.Environment) {
+	o.logger.Warn().Err(err).
+		Str("query", qs).
+		Str("revision", revision).
+		Str("stage", string(env.Stage)).
+		Str("input_hash", inputHash(env)).
+		Msg("policy would deny, not enforced in audit mode")
+
+	if o.recorder == nil {
+		return
+	}
+
+	raw, merr := json.Marshal(env)
+	if merr == nil {
+		raw, merr = redactEnvironment(raw)
+	}
+	if merr != nil {
+		o.logger.Error().Err(merr).Str("query", qs).Msg("could not record audit denial")
+		return
+	}
+
+	rec := &Record{
+		Timestamp:   t,
+		Query:       qs,
+		Revision:    revision,
+		Environment: raw,
+	}
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	if err := o.recorder.Record(rec); err != nil {
+		o.logger.Error().Err(err).Str("query", qs).Msg("could not record audit denial")
+	}
+}
+
+func (o *OPA) evaluate(ctx context.Context, ps *policySet, qs string, env *engine.Environment) (bool, error) {
+	if ps == nil {
+		return false, ErrNoPolicies
+	}
+
+	q, err := ps.prepare(ctx, qs)
 	if err != nil {
 		return false, err
 	}
@@ -77,3 +206,79 @@ func (o OPA) Evaluate(ctx context.Context, qs string, env engine.Environment) (b
 
 	return result.Allowed(), nil
 }
+
+// Revision returns the revision of the policies in use, it changes whenever the policies change.
+func (o *OPA) Revision() string {
+	if ps := o.current.Load(); ps != nil {
+		return ps.revision
+	}
+	return ""
+}
+
+// Reload loads and compiles the policies and, if configured, fetches the bundle.
+// The policies in use are only replaced if everything compiles.
+func (o *OPA) Reload(ctx context.Context) error {
+	o.reload.Lock()
+	defer o.reload.Unlock()
+
+	if o.conf.Bundle.URL != "" {
+		if err := o.fetchBundle(ctx); err != nil {
+			// keep using the last known bundle
+			o.logger.Error().Err(err).Str("url", o.conf.Bundle.URL).Msg("could not fetch policy bundle")
+		}
+	}
+
+	ps, err := loadPolicySet(ctx, o.policies, o.bundle, o.options)
+	if err != nil {
+		return err
+	}
+
+	if old := o.current.Swap(ps); old == nil || old.revision != ps.revision {
+		o.logger.Info().Str("revision", ps.revision).Msg("policies loaded")
+	}
+	return nil
+}
+
+// fetchBundle downloads the bundle if it changed since the last download
+func (o *OPA) fetchBundle(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.conf.Bundle.URL, nil)
+	if err != nil {
+		return err
+	}
+	if o.bundleETag != "" {
+		req.Header.Set("If-None-Match", o.bundleETag)
+	}
+
+	res, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusNotModified:
+		return nil
+	case http.StatusOK:
+	default:
+		return errors.New("unexpected status code " + res.Status)
+	}
+
+	b, err := bundle.NewReader(res.Body).Read()
+	if err != nil {
+		return err
+	}
+
+	o.bundle = &b
+	o.bundleETag = res.Header.Get("ETag")
+	return nil
+}
+
+// inputHash identifies the input of a decision without logging it
+func inputHash(env *engine.Environment) string {
+	b, err := json.Marshal(env)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
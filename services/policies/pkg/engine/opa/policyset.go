@@ -0,0 +1,119 @@
+package opa
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/bundle"
+	"github.com/open-policy-agent/opa/loader"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/storage/inmem"
+)
+
+// policySet is an immutable set of loaded policies. The queries are compiled once on first use.
+type policySet struct {
+	revision  string
+	modules   map[string]*ast.Module
+	documents map[string]interface{}
+	options   []func(r *rego.Rego)
+
+	mu       sync.Mutex
+	prepared map[string]rego.PreparedEvalQuery
+}
+
+// loadPolicySet loads the policy and data files and merges them with the bundle if given.
+// All modules are compiled to detect errors before the set is used.
+func loadPolicySet(ctx context.Context, paths []string, b *bundle.Bundle, options []func(r *rego.Rego)) (*policySet, error) {
+	ps := &policySet{
+		modules:   map[string]*ast.Module{},
+		documents: map[string]interface{}{},
+		options:   options,
+		prepared:  map[string]rego.PreparedEvalQuery{},
+	}
+
+	h := sha256.New()
+	if len(paths) > 0 {
+		res, err := loader.NewFileLoader().WithProcessAnnotation(true).All(paths)
+		if err != nil {
+			return nil, err
+		}
+
+		names := make([]string, 0, len(res.Modules))
+		for name := range res.Modules {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			ps.modules[name] = res.Modules[name].Parsed
+			h.Write([]byte(name))
+			h.Write(res.Modules[name].Raw)
+		}
+
+		mergeDocuments(ps.documents, res.Documents)
+		docs, err := json.Marshal(res.Documents)
+		if err != nil {
+			return nil, err
+		}
+		h.Write(docs)
+	}
+	if b != nil {
+		h.Write([]byte(b.Manifest.Revision))
+		for _, m := range b.Modules {
+			ps.modules["bundle:"+m.Path] = m.Parsed
+			h.Write([]byte(m.Path))
+			h.Write(m.Raw)
+		}
+		mergeDocuments(ps.documents, b.Data)
+	}
+	ps.revision = hex.EncodeToString(h.Sum(nil))[:16]
+
+	// compile everything once, a broken policy must not replace a working one
+	if _, err := ps.prepare(ctx, "data"); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+// prepare returns the compiled query
+func (ps *policySet) prepare(ctx context.Context, query string) (rego.PreparedEvalQuery, error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if q, ok := ps.prepared[query]; ok {
+		return q, nil
+	}
+
+	options := append([]func(r *rego.Rego){
+		rego.Query(query),
+		// every query gets its own store, the store is bound to the prepared query
+		rego.Store(inmem.NewFromObject(ps.documents)),
+	}, ps.options...)
+	for _, m := range ps.modules {
+		options = append(options, rego.ParsedModule(m))
+	}
+
+	q, err := rego.New(options...).PrepareForEval(ctx)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, err
+	}
+	ps.prepared[query] = q
+	return q, nil
+}
+
+// mergeDocuments merges src into dst, the values of src win on conflicts
+func mergeDocuments(dst, src map[string]interface{}) {
+	for k, v := range src {
+		d, dok := dst[k].(map[string]interface{})
+		s, sok := v.(map[string]interface{})
+		if dok && sok {
+			mergeDocuments(d, s)
+			continue
+		}
+		dst[k] = v
+	}
+}
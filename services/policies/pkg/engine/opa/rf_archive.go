@@ -0,0 +1,56 @@
+package opa
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/types"
+)
+
+// _maxArchiveEntries limits the entries of an archive, archives with more entries can not be inspected
+const _maxArchiveEntries = 10000
+
+// RFArchiveListEntries extends the rego dictionary with the possibility to list the entries of zip archives.
+// Only the directory of the archive is read, the entries are not extracted.
+//
+// Rego: `ocis.archive.list_entries(ocis.resource.download(input.resource.url))`
+// Result: `[{"name": "setup.exe", "size": 1024, "compressed_size": 512, "dir": false}]`
+var RFArchiveListEntries = rego.Function1(
+	&rego.Function{
+		Name:             "ocis.archive.list_entries",
+		Decl:             types.NewFunction(types.Args(types.A), types.A),
+		Memoize:          true,
+		Nondeterministic: true,
+	},
+	func(_ rego.BuiltinContext, a *ast.Term) (*ast.Term, error) {
+		var body []byte
+
+		if err := ast.As(a.Value, &body); err != nil {
+			return nil, err
+		}
+
+		r, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(r.File) > _maxArchiveEntries {
+			return nil, fmt.Errorf("archive has more than %d entries", _maxArchiveEntries)
+		}
+
+		entries := make([]*ast.Term, 0, len(r.File))
+		for _, f := range r.File {
+			entries = append(entries, ast.ObjectTerm(
+				ast.Item(ast.StringTerm("name"), ast.StringTerm(f.Name)),
+				ast.Item(ast.StringTerm("size"), ast.UIntNumberTerm(f.UncompressedSize64)),
+				ast.Item(ast.StringTerm("compressed_size"), ast.UIntNumberTerm(f.CompressedSize64)),
+				ast.Item(ast.StringTerm("dir"), ast.BooleanTerm(f.FileInfo().IsDir())),
+			))
+		}
+
+		return ast.ArrayTerm(entries...), nil
+	},
+)
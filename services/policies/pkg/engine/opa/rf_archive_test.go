@@ -0,0 +1,69 @@
+package opa_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-policy-agent/opa/rego"
+
+	"github.com/owncloud/ocis/v2/services/policies/pkg/engine/opa"
+)
+
+var _ = Describe("opa ocis archive functions", func() {
+	Describe("ocis.archive.list_entries", func() {
+		It("lists the entries of zip archives", func() {
+			buf := &bytes.Buffer{}
+			w := zip.NewWriter(buf)
+			_, err := w.Create("docs/")
+			Expect(err).ToNot(HaveOccurred())
+			f, err := w.Create("docs/setup.exe")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = f.Write([]byte("MZ"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(w.Close()).To(Succeed())
+
+			r := rego.New(
+				rego.Query(`[e.name | e := ocis.archive.list_entries(input.body)[_]; not e.dir; endswith(e.name, ".exe")]`),
+				rego.Input(map[string]interface{}{"body": buf.Bytes()}),
+				opa.RFArchiveListEntries,
+			)
+			rs, err := r.Eval(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rs[0].Expressions[0].Value).To(Equal([]interface{}{"docs/setup.exe"}))
+		})
+
+		It("fails for other files", func() {
+			r := rego.New(
+				rego.Query(`ocis.archive.list_entries(input.body)`),
+				rego.Input(map[string]interface{}{"body": []byte("no zip")}),
+				rego.StrictBuiltinErrors(true),
+				opa.RFArchiveListEntries,
+			)
+			_, err := r.Eval(context.Background())
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("reports the sizes", func() {
+			buf := &bytes.Buffer{}
+			w := zip.NewWriter(buf)
+			f, err := w.Create("a.txt")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = f.Write([]byte("abc"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(w.Close()).To(Succeed())
+
+			r := rego.New(
+				rego.Query(`ocis.archive.list_entries(input.body)[0].size`),
+				rego.Input(map[string]interface{}{"body": buf.Bytes()}),
+				opa.RFArchiveListEntries,
+			)
+			rs, err := r.Eval(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rs[0].Expressions[0].Value).To(Equal(json.Number("3")))
+		})
+	})
+})
@@ -2,7 +2,10 @@ package opa
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/cs3org/reva/v2/pkg/rhttp"
@@ -29,32 +32,77 @@ var RFResourceDownload = rego.Function1(
 			return nil, err
 		}
 
-		req, err := http.NewRequest(http.MethodGet, url, nil)
+		body, err := download(url)
 		if err != nil {
 			return nil, err
 		}
+		defer body.Close()
 
-		client := rhttp.GetHTTPClient(rhttp.Insecure(true))
-		res, err := client.Do(req)
-		if err != nil {
+		buf := new(bytes.Buffer)
+		if _, err := buf.ReadFrom(body); err != nil {
 			return nil, err
 		}
-		defer res.Body.Close()
 
-		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("unexpected status code from Download %v", res.StatusCode)
+		v, err := ast.InterfaceToValue(buf.Bytes())
+		if err != nil {
+			return nil, err
 		}
 
-		buf := new(bytes.Buffer)
-		if _, err := buf.ReadFrom(res.Body); err != nil {
+		return ast.NewTerm(v), nil
+	},
+)
+
+// RFResourceSHA256 extends the rego dictionary with the possibility to get the SHA-256 checksum of oCis resources.
+// The resource is streamed, it is not kept in memory.
+//
+// Rego: `ocis.resource.sha256("ocis/path/0034892347349827")`
+// Result: `"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+var RFResourceSHA256 = rego.Function1(
+	&rego.Function{
+		Name:             "ocis.resource.sha256",
+		Decl:             types.NewFunction(types.Args(types.S), types.S),
+		Memoize:          true,
+		Nondeterministic: true,
+	},
+	func(_ rego.BuiltinContext, a *ast.Term) (*ast.Term, error) {
+		var url string
+
+		if err := ast.As(a.Value, &url); err != nil {
 			return nil, err
 		}
 
-		v, err := ast.InterfaceToValue(buf.Bytes())
+		body, err := download(url)
 		if err != nil {
 			return nil, err
 		}
+		defer body.Close()
 
-		return ast.NewTerm(v), nil
+		h := sha256.New()
+		if _, err := io.Copy(h, body); err != nil {
+			return nil, err
+		}
+
+		return ast.StringTerm(hex.EncodeToString(h.Sum(nil))), nil
 	},
 )
+
+// download returns the body of the given resource url
+func download(url string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := rhttp.GetHTTPClient(rhttp.Insecure(true))
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("unexpected status code from Download %v", res.StatusCode)
+	}
+
+	return res.Body, nil
+}
@@ -33,4 +33,18 @@ var _ = Describe("opa ocis resource functions", func() {
 
 		})
 	})
+	Describe("ocis.resource.sha256", func() {
+		It("hashes reva resources", func() {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("test"))
+			}))
+			defer srv.Close()
+
+			r := rego.New(rego.Query(`ocis.resource.sha256("`+srv.URL+`")`), opa.RFResourceSHA256)
+			rs, err := r.Eval(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(rs[0].Expressions[0].Value).To(Equal("9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"))
+		})
+	})
 })
@@ -0,0 +1,41 @@
+package opa
+
+import (
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/types"
+)
+
+// RFUserInGroup extends the rego dictionary with the possibility to check the group membership of users.
+// The groups are taken from the groups of the given user.
+//
+// Rego: `ocis.user.in_group(input.user, "physics-lovers")`
+// Result: `true`
+var RFUserInGroup = rego.Function2(
+	&rego.Function{
+		Name:    "ocis.user.in_group",
+		Decl:    types.NewFunction(types.Args(types.A, types.S), types.B),
+		Memoize: true,
+	},
+	func(_ rego.BuiltinContext, a, b *ast.Term) (*ast.Term, error) {
+		var user struct {
+			Groups []string `json:"groups"`
+		}
+		var group string
+
+		if err := ast.As(a.Value, &user); err != nil {
+			return nil, err
+		}
+		if err := ast.As(b.Value, &group); err != nil {
+			return nil, err
+		}
+
+		for _, g := range user.Groups {
+			if g == group {
+				return ast.BooleanTerm(true), nil
+			}
+		}
+
+		return ast.BooleanTerm(false), nil
+	},
+)
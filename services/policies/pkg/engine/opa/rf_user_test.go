@@ -0,0 +1,30 @@
+package opa_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-policy-agent/opa/rego"
+
+	"github.com/owncloud/ocis/v2/services/policies/pkg/engine/opa"
+)
+
+var _ = Describe("opa ocis user functions", func() {
+	DescribeTable("ocis.user.in_group",
+		func(group string, expected bool) {
+			r := rego.New(
+				rego.Query(`ocis.user.in_group(input.user, "`+group+`")`),
+				rego.Input(map[string]interface{}{
+					"user": map[string]interface{}{"groups": []string{"physics-lovers", "sailing-lovers"}},
+				}),
+				opa.RFUserInGroup,
+			)
+			rs, err := r.Eval(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rs[0].Expressions[0].Value).To(Equal(expected))
+		},
+		Entry("member", "sailing-lovers", true),
+		Entry("no member", "violin-haters", false),
+	)
+})
@@ -0,0 +1,87 @@
+package opa
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// _debounce collects the file events of a single change, editors and config maps touch several files at once
+const _debounce = 500 * time.Millisecond
+
+// Watch reloads the policies when the policy files change, the bundle is updated or a SIGHUP is received.
+// It blocks until the context is done.
+func (o *OPA) Watch(ctx context.Context) error {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var fileEvents <-chan fsnotify.Event
+	if o.conf.Watch && len(o.policies) > 0 {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return err
+		}
+		defer watcher.Close()
+
+		for _, dir := range watchedDirs(o.policies) {
+			if err := watcher.Add(dir); err != nil {
+				o.logger.Error().Err(err).Str("dir", dir).Msg("could not watch policy directory")
+			}
+		}
+		fileEvents = watcher.Events
+	}
+
+	var poll <-chan time.Time
+	if o.conf.Bundle.URL != "" && o.conf.Bundle.PollInterval > 0 {
+		ticker := time.NewTicker(o.conf.Bundle.PollInterval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
+	debounce := time.NewTimer(_debounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-fileEvents:
+			debounce.Reset(_debounce)
+			continue
+		case <-hup:
+			o.logger.Info().Msg("received SIGHUP, reloading policies")
+		case <-debounce.C:
+			o.logger.Debug().Msg("policy files changed, reloading policies")
+		case <-poll:
+		}
+
+		if err := o.Reload(ctx); err != nil {
+			o.logger.Error().Err(err).Str("revision", o.Revision()).Msg("could not reload policies, keeping the current policies")
+		}
+	}
+}
+
+// watchedDirs returns the directories to watch for the given policy paths.
+// Files are watched via their directory to notice them being replaced.
+func watchedDirs(paths []string) []string {
+	seen := map[string]bool{}
+	var dirs []string
+	for _, p := range paths {
+		dir := p
+		if info, err := os.Stat(p); err != nil || !info.IsDir() {
+			dir = filepath.Dir(p)
+		}
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
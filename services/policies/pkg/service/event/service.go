@@ -81,7 +81,7 @@ func (s Service) processEvent(e events.Event) error {
 				env.Resource.ID = *ev.ResourceID
 			}
 
-			result, err := s.engine.Evaluate(context.TODO(), s.query, env)
+			result, err := s.engine.Evaluate(context.TODO(), s.query, &env)
 			if err != nil {
 				s.log.Error().Err(err).Msg("unable evaluate policy")
 			}
@@ -2,13 +2,23 @@ package command
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/events/stream"
 	"github.com/cs3org/reva/v2/pkg/utils"
+	tw "github.com/olekukonko/tablewriter"
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config/parser"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/event"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/service"
 	"github.com/urfave/cli/v2"
 )
 
@@ -69,3 +79,179 @@ func RestartPostprocessing(cfg *config.Config) *cli.Command {
 		},
 	}
 }
+
+// ListUploads cli command to list the uploads in postprocessing
+func ListUploads(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "list",
+		Usage: "list the uploads in postprocessing",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "step",
+				Aliases: []string{"s"},
+				Usage:   "only list uploads in the given postprocessing step",
+			},
+			&cli.StringFlag{
+				Name:  "user",
+				Usage: "only list uploads of the given user id or username",
+			},
+			&cli.DurationFlag{
+				Name:  "older-than",
+				Usage: "only list uploads whose postprocessing started before the given duration, e.g. '1h'",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "output as json",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			return configlog.ReturnFatal(parser.ParseConfig(cfg))
+		},
+		Action: func(c *cli.Context) error {
+			pps, err := service.ListUploads(postprocessingStore(cfg), cfg.Postprocessing, service.UploadFilter{
+				Step:      events.Postprocessingstep(c.String("step")),
+				User:      c.String("user"),
+				OlderThan: c.Duration("older-than"),
+			})
+			if err != nil {
+				return err
+			}
+
+			uploads := make([]service.Upload, 0, len(pps))
+			for _, pp := range pps {
+				uploads = append(uploads, service.NewUpload(pp))
+			}
+
+			if c.Bool("json") {
+				return printJSON(uploads)
+			}
+
+			table := tw.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Upload Id", "Name", "Size", "User", "Step", "Outcome", "Failures", "Started", "Age"})
+			table.SetAutoFormatHeaders(false)
+			for _, u := range uploads {
+				started, age := "", ""
+				if u.Started != nil {
+					started = u.Started.Format(time.RFC3339)
+					age = time.Since(*u.Started).Round(time.Second).String()
+				}
+				table.Append([]string{
+					u.ID,
+					u.Filename,
+					strconv.FormatUint(u.Filesize, 10),
+					u.Username,
+					steps(u),
+					string(u.Outcome),
+					strconv.Itoa(u.Failures),
+					started,
+					age,
+				})
+			}
+			table.Render()
+			return nil
+		},
+	}
+}
+
+// ShowUpload cli command to show the details of an upload in postprocessing
+func ShowUpload(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "show",
+		Usage: "show the postprocessing details of an upload",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "upload-id",
+				Aliases:  []string{"u"},
+				Usage:    "the uploadid to show",
+				Required: true,
+			},
+		},
+		Before: func(c *cli.Context) error {
+			return configlog.ReturnFatal(parser.ParseConfig(cfg))
+		},
+		Action: func(c *cli.Context) error {
+			pp, err := service.GetUpload(postprocessingStore(cfg), cfg.Postprocessing, c.String("upload-id"))
+			if err != nil {
+				if errors.Is(err, service.ErrNotFound) {
+					return fmt.Errorf("upload '%s' is not in postprocessing", c.String("upload-id"))
+				}
+				return err
+			}
+			return printJSON(service.NewUpload(pp))
+		},
+	}
+}
+
+// CancelPostprocessing cli command to cancel the postprocessing of uploads
+func CancelPostprocessing(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "cancel",
+		Usage: "cancel postprocessing for an uploadID or all uploads in a step",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "upload-id",
+				Aliases: []string{"u"},
+				Usage:   "the uploadid to cancel.",
+			},
+			&cli.StringFlag{
+				Name:    "step",
+				Aliases: []string{"s"},
+				Usage:   "cancel all uploads in the given postprocessing step. Ignored if upload-id is set.",
+			},
+			&cli.BoolFlag{
+				Name:  "delete",
+				Usage: "delete the uploaded bytes instead of keeping them",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			return configlog.ReturnFatal(parser.ParseConfig(cfg))
+		},
+		Action: func(c *cli.Context) error {
+			uid, step := c.String("upload-id"), ""
+			if uid == "" {
+				step = c.String("step")
+			}
+			if uid == "" && step == "" {
+				return errors.New("either an upload-id or a step is required")
+			}
+
+			outcome := events.PPOutcomeAbort
+			if c.Bool("delete") {
+				outcome = events.PPOutcomeDelete
+			}
+
+			stream, err := stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig(cfg.Postprocessing.Events))
+			if err != nil {
+				return err
+			}
+
+			return events.Publish(context.Background(), stream, event.CancelPostprocessing{
+				UploadID:  uid,
+				Step:      events.Postprocessingstep(step),
+				Outcome:   outcome,
+				Timestamp: utils.TSNow(),
+			})
+		},
+	}
+}
+
+// steps renders the running steps of an upload
+func steps(u service.Upload) string {
+	if len(u.Running) == 0 {
+		return string(u.Step)
+	}
+	s := make([]string, 0, len(u.Running))
+	for _, r := range u.Running {
+		s = append(s, string(r))
+	}
+	return strings.Join(s, ",")
+}
+
+func printJSON(v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
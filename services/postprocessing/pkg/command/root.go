@@ -16,6 +16,9 @@ func GetCommands(cfg *config.Config) cli.Commands {
 
 		// interaction with this service
 		RestartPostprocessing(cfg),
+		ListUploads(cfg),
+		ShowUpload(cfg),
+		CancelPostprocessing(cfg),
 
 		// infos about this service
 		Health(cfg),
@@ -11,11 +11,15 @@ import (
 	"github.com/urfave/cli/v2"
 	microstore "go-micro.dev/v4/store"
 
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
+	ogrpc "github.com/owncloud/ocis/v2/ocis-pkg/service/grpc"
 	"github.com/owncloud/ocis/v2/ocis-pkg/tracing"
+	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config/parser"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/logging"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/server/debug"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/server/http"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/service"
 )
 
@@ -46,22 +50,22 @@ func Server(cfg *config.Config) *cli.Command {
 				return err
 			}
 
-			{
-				bus, err := stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig(cfg.Postprocessing.Events))
-				if err != nil {
-					return err
-				}
+			grpcClient, err := ogrpc.NewClient(
+				append(ogrpc.GetClientOptions(cfg.GRPCClientTLS), ogrpc.WithTraceProvider(traceProvider))...,
+			)
+			if err != nil {
+				return err
+			}
 
-				st := store.Create(
-					store.Store(cfg.Store.Store),
-					store.TTL(cfg.Store.TTL),
-					microstore.Nodes(cfg.Store.Nodes...),
-					microstore.Database(cfg.Store.Database),
-					microstore.Table(cfg.Store.Table),
-					store.Authentication(cfg.Store.AuthUsername, cfg.Store.AuthPassword),
-				)
+			bus, err := stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig(cfg.Postprocessing.Events))
+			if err != nil {
+				return err
+			}
+
+			st := postprocessingStore(cfg)
 
-				svc, err := service.NewPostprocessingService(ctx, bus, logger, st, traceProvider, cfg.Postprocessing)
+			{
+				svc, err := service.NewPostprocessingService(ctx, bus, logger, st, postprocessingLocks(cfg), traceProvider, cfg.Postprocessing)
 				if err != nil {
 					return err
 				}
@@ -91,6 +95,26 @@ func Server(cfg *config.Config) *cli.Command {
 				})
 			}
 
+			{
+				server, err := http.Server(
+					http.Logger(logger),
+					http.Context(ctx),
+					http.Config(cfg),
+					http.Store(st),
+					http.Publisher(bus),
+					http.RoleClient(settingssvc.NewRoleService("com.owncloud.api.settings", grpcClient)),
+					http.TracerProvider(traceProvider),
+				)
+				if err != nil {
+					logger.Info().Err(err).Str("server", "http").Msg("Failed to initialize server")
+					return err
+				}
+
+				gr.Add(server.Run, func(_ error) {
+					cancel()
+				})
+			}
+
 			{
 				debugServer, err := debug.Server(
 					debug.Logger(logger),
@@ -111,3 +135,30 @@ func Server(cfg *config.Config) *cli.Command {
 		},
 	}
 }
+
+// postprocessingStore returns the store holding the uploads in postprocessing
+func postprocessingStore(cfg *config.Config) microstore.Store {
+	return store.Create(
+		store.Store(cfg.Store.Store),
+		store.TTL(cfg.Store.TTL),
+		microstore.Nodes(cfg.Store.Nodes...),
+		microstore.Database(cfg.Store.Database),
+		microstore.Table(cfg.Store.Table),
+		store.Authentication(cfg.Store.AuthUsername, cfg.Store.AuthPassword),
+	)
+}
+
+// postprocessingLocks returns the store holding the locks of the uploads, which are shared by all instances
+func postprocessingLocks(cfg *config.Config) kv.Store {
+	return kv.New(kv.Options{
+		Type:     cfg.Store.Store,
+		Nodes:    cfg.Store.Nodes,
+		Bucket:   cfg.Store.Database + "-locks",
+		Username: cfg.Store.AuthUsername,
+		Password: cfg.Store.AuthPassword,
+		// the store is served by the same nats servers as the events
+		EnableTLS:            cfg.Postprocessing.Events.EnableTLS,
+		TLSInsecure:          cfg.Postprocessing.Events.TLSInsecure,
+		TLSRootCACertificate: cfg.Postprocessing.Events.TLSRootCACertificate,
+	})
+}
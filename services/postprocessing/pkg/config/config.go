@@ -17,6 +17,10 @@ type Config struct {
 	Log     *Log     `yaml:"log"`
 	Debug   Debug    `yaml:"debug"`
 
+	HTTP          HTTP                  `yaml:"http"`
+	GRPCClientTLS *shared.GRPCClientTLS `yaml:"grpc_client_tls"`
+	TokenManager  *TokenManager         `yaml:"token_manager"`
+
 	Store          Store          `yaml:"store"`
 	Postprocessing Postprocessing `yaml:"postprocessing"`
 
@@ -33,6 +37,28 @@ type Postprocessing struct {
 
 	RetryBackoffDuration time.Duration `yaml:"retry_backoff_duration" env:"POSTPROCESSING_RETRY_BACKOFF_DURATION" desc:"The base for the exponential backoff duration before retrying a failed postprocessing step. See the Environment Variable Types description for more details." introductionVersion:"5.0"`
 	MaxRetries           int           `yaml:"max_retries" env:"POSTPROCESSING_MAX_RETRIES" desc:"The maximum number of retries for a failed postprocessing step." introductionVersion:"5.0"`
+
+	Pipeline []Stage `yaml:"pipeline"`
+}
+
+// Stage is a set of postprocessing steps running in parallel. The stages of a pipeline are processed in order.
+type Stage struct {
+	Steps []Step `yaml:"steps"`
+}
+
+// Step is a postprocessing step that only runs for uploads matching its condition.
+type Step struct {
+	Name string    `yaml:"name"`
+	If   Condition `yaml:"if"`
+}
+
+// Condition restricts a postprocessing step to certain uploads. Empty fields match all uploads.
+type Condition struct {
+	MimeTypes  []string `yaml:"mime_types"`
+	MinSize    uint64   `yaml:"min_size"`
+	MaxSize    uint64   `yaml:"max_size"`
+	SpaceTypes []string `yaml:"space_types"`
+	Groups     []string `yaml:"groups"`
 }
 
 // Events combines the configuration options for the event bus.
@@ -47,6 +73,19 @@ type Events struct {
 	AuthPassword         string `yaml:"password" env:"OCIS_EVENTS_AUTH_PASSWORD;POSTPROCESSING_EVENTS_AUTH_PASSWORD" desc:"The password to authenticate with the events broker. The events broker is the ocis service which receives and delivers events between the services." introductionVersion:"5.0"`
 }
 
+// HTTP defines the available http configuration.
+type HTTP struct {
+	Addr      string                `yaml:"addr" env:"POSTPROCESSING_HTTP_ADDR" desc:"The bind address of the HTTP service." introductionVersion:"7.1"`
+	Namespace string                `yaml:"-"`
+	Root      string                `yaml:"root" env:"POSTPROCESSING_HTTP_ROOT" desc:"Subdirectory that serves as the root for this HTTP service." introductionVersion:"7.1"`
+	TLS       shared.HTTPServiceTLS `yaml:"tls"`
+}
+
+// TokenManager is the config for using the reva token manager
+type TokenManager struct {
+	JWTSecret string `yaml:"jwt_secret" env:"OCIS_JWT_SECRET;POSTPROCESSING_JWT_SECRET" desc:"The secret to mint and validate jwt tokens." introductionVersion:"7.1"`
+}
+
 // Debug defines the available debug configuration.
 type Debug struct {
 	Addr   string `yaml:"addr" env:"POSTPROCESSING_DEBUG_ADDR" desc:"Bind address of the debug server, where metrics, health, config and debug endpoints will be exposed." introductionVersion:"pre5.0"`
@@ -1,8 +1,11 @@
 package defaults
 
 import (
+	"strings"
 	"time"
 
+	"github.com/owncloud/ocis/v2/ocis-pkg/structs"
+
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
 )
 
@@ -26,6 +29,11 @@ func DefaultConfig() *config.Config {
 		Service: config.Service{
 			Name: "postprocessing",
 		},
+		HTTP: config.HTTP{
+			Addr:      "127.0.0.1:9256",
+			Root:      "/",
+			Namespace: "com.owncloud.web",
+		},
 		Postprocessing: config.Postprocessing{
 			Events: config.Events{
 				Endpoint: "127.0.0.1:9233",
@@ -58,6 +66,22 @@ func EnsureDefaults(cfg *config.Config) {
 		cfg.Log = &config.Log{}
 	}
 
+	if cfg.GRPCClientTLS == nil && cfg.Commons != nil {
+		cfg.GRPCClientTLS = structs.CopyOrZeroValue(cfg.Commons.GRPCClientTLS)
+	}
+
+	if cfg.TokenManager == nil && cfg.Commons != nil && cfg.Commons.TokenManager != nil {
+		cfg.TokenManager = &config.TokenManager{
+			JWTSecret: cfg.Commons.TokenManager.JWTSecret,
+		}
+	} else if cfg.TokenManager == nil {
+		cfg.TokenManager = &config.TokenManager{}
+	}
+
+	if cfg.Commons != nil {
+		cfg.HTTP.TLS = cfg.Commons.HTTPServiceTLS
+	}
+
 	// provide with defaults for shared tracing, since we need a valid destination address for "envdecode".
 	if cfg.Tracing == nil && cfg.Commons != nil && cfg.Commons.Tracing != nil {
 		cfg.Tracing = &config.Tracing{
@@ -71,6 +95,9 @@ func EnsureDefaults(cfg *config.Config) {
 	}
 }
 
-// Sanitize does nothing atm
+// Sanitize sanitizes the configuration
 func Sanitize(cfg *config.Config) {
+	if cfg.HTTP.Root != "/" {
+		cfg.HTTP.Root = strings.TrimSuffix(cfg.HTTP.Root, "/")
+	}
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/cs3org/reva/v2/pkg/events"
 	ociscfg "github.com/owncloud/ocis/v2/ocis-pkg/config"
+	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config/defaults"
 
@@ -37,6 +38,32 @@ func ParseConfig(cfg *config.Config) error {
 
 // Validate validates the config
 func Validate(cfg *config.Config) error {
+	if cfg.TokenManager.JWTSecret == "" {
+		return shared.MissingJWTTokenError(cfg.Service.Name)
+	}
+
+	for i, stage := range cfg.Postprocessing.Pipeline {
+		if len(stage.Steps) == 0 {
+			return fmt.Errorf("postprocessing pipeline stage %d has no steps", i+1)
+		}
+		for _, step := range stage.Steps {
+			if step.Name == "" {
+				return fmt.Errorf("postprocessing pipeline stage %d contains a step without a name", i+1)
+			}
+			if step.If.MaxSize > 0 && step.If.MinSize > step.If.MaxSize {
+				return fmt.Errorf("postprocessing step '%s' can never run: min_size is larger than max_size", step.Name)
+			}
+		}
+	}
+
+	if len(cfg.Postprocessing.Pipeline) > 0 {
+		if cfg.Postprocessing.Delayprocessing != 0 && !pipelineContains(cfg.Postprocessing.Pipeline, events.PPStepDelay) {
+			fmt.Println("Added delay step as last stage of the postprocessing pipeline.")
+			cfg.Postprocessing.Pipeline = append(cfg.Postprocessing.Pipeline, config.Stage{Steps: []config.Step{{Name: string(events.PPStepDelay)}}})
+		}
+		return nil
+	}
+
 	if cfg.Postprocessing.Delayprocessing != 0 {
 		if !contains(cfg.Postprocessing.Steps, events.PPStepDelay) {
 			if len(cfg.Postprocessing.Steps) > 0 {
@@ -58,3 +85,14 @@ func contains(all []string, candidate events.Postprocessingstep) bool {
 	}
 	return false
 }
+
+func pipelineContains(pipeline []config.Stage, candidate events.Postprocessingstep) bool {
+	for _, stage := range pipeline {
+		for _, s := range stage.Steps {
+			if s.Name == string(candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package event
+
+import (
+	"encoding/json"
+
+	types "github.com/cs3org/go-cs3apis/cs3/types/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/events"
+)
+
+// CancelPostprocessing is emitted to stop the postprocessing of uploads. Either a specific
+// upload or all uploads currently in the given step are cancelled.
+type CancelPostprocessing struct {
+	UploadID  string
+	Step      events.Postprocessingstep
+	Outcome   events.PostprocessingOutcome // abort or delete
+	Timestamp *types.Timestamp
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (CancelPostprocessing) Unmarshal(v []byte) (interface{}, error) {
+	e := CancelPostprocessing{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
@@ -0,0 +1,101 @@
+package postprocessing
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/mime"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
+)
+
+// Space types known to the pipeline conditions
+const (
+	SpaceTypePersonal = "personal"
+	SpaceTypeProject  = "project"
+)
+
+// Pipeline defines the postprocessing of uploads. The stages are processed in order,
+// the steps of a stage run in parallel.
+type Pipeline []config.Stage
+
+// NewPipeline returns the configured pipeline. Without a pipeline definition
+// every configured step runs for every upload, one after another.
+func NewPipeline(c config.Postprocessing) Pipeline {
+	if len(c.Pipeline) > 0 {
+		return Pipeline(c.Pipeline)
+	}
+
+	p := make(Pipeline, 0, len(c.Steps))
+	for _, s := range c.Steps {
+		p = append(p, config.Stage{Steps: []config.Step{{Name: s}}})
+	}
+	return p
+}
+
+// Plan returns the stages of steps to run for the given upload. Steps not matching
+// their conditions are left out, stages without any step are skipped.
+func (p Pipeline) Plan(ev events.BytesReceived) [][]events.Postprocessingstep {
+	var stages [][]events.Postprocessingstep
+	for _, stage := range p {
+		var steps []events.Postprocessingstep
+		for _, s := range stage.Steps {
+			if Matches(s.If, ev) {
+				steps = append(steps, events.Postprocessingstep(s.Name))
+			}
+		}
+		if len(steps) > 0 {
+			stages = append(stages, steps)
+		}
+	}
+	return stages
+}
+
+// Matches checks if the upload fulfills the condition
+func Matches(c config.Condition, ev events.BytesReceived) bool {
+	if c.MinSize > 0 && ev.Filesize < c.MinSize {
+		return false
+	}
+	if c.MaxSize > 0 && ev.Filesize > c.MaxSize {
+		return false
+	}
+	if len(c.MimeTypes) > 0 && !matchesMimeType(c.MimeTypes, mime.Detect(false, ev.Filename)) {
+		return false
+	}
+	if len(c.SpaceTypes) > 0 && !slices.Contains(c.SpaceTypes, spaceType(ev)) {
+		return false
+	}
+	if len(c.Groups) > 0 && !containsAny(c.Groups, ev.ExecutingUser.GetGroups()) {
+		return false
+	}
+	return true
+}
+
+// spaceType derives the type of the space from the upload. The id of a personal space
+// is the id of its owner, so there is no need to ask the storage for it.
+func spaceType(ev events.BytesReceived) string {
+	if ev.SpaceOwner.GetOpaqueId() != "" && ev.ResourceID.GetSpaceId() == ev.SpaceOwner.GetOpaqueId() {
+		return SpaceTypePersonal
+	}
+	return SpaceTypeProject
+}
+
+// matchesMimeType supports wildcard patterns like 'image/*'
+func matchesMimeType(patterns []string, mimeType string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(strings.ToLower(p), strings.ToLower(mimeType)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(all []string, candidates []string) bool {
+	for _, c := range candidates {
+		if slices.Contains(all, c) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,11 +2,13 @@ package postprocessing
 
 import (
 	"math"
+	"slices"
 	"time"
 
 	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
 	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
 	"github.com/cs3org/reva/v2/pkg/events"
+	avevent "github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
 )
 
@@ -20,10 +22,14 @@ type Postprocessing struct {
 	Filesize          uint64
 	ResourceID        *provider.ResourceId
 	Steps             []events.Postprocessingstep
+	Stages            [][]events.Postprocessingstep `json:",omitempty"`
 	Status            Status
 	Failures          int
 	InitiatorID       string
 	Finished          bool
+	Started           time.Time // when the postprocessing started
+	Updated           time.Time // when the status last changed
+	DelayUntil        time.Time `json:",omitempty"` // when the running delay step finishes
 
 	config config.Postprocessing
 }
@@ -32,6 +38,9 @@ type Postprocessing struct {
 type Status struct {
 	CurrentStep events.Postprocessingstep
 	Outcome     events.PostprocessingOutcome
+	Stage       int                                                        `json:",omitempty"`
+	Running     []events.Postprocessingstep                                `json:",omitempty"`
+	Results     map[events.Postprocessingstep]events.PostprocessingOutcome `json:",omitempty"`
 }
 
 // New returns a new postprocessing instance
@@ -41,46 +50,60 @@ func New(config config.Postprocessing) *Postprocessing {
 	}
 }
 
-// Init is the first step of the postprocessing
-func (pp *Postprocessing) Init(_ events.BytesReceived) interface{} {
-	if len(pp.Steps) == 0 {
-		return pp.finished(events.PPOutcomeContinue)
-	}
-
-	return pp.step(pp.Steps[0])
+// Init is the first step of the postprocessing. It starts all steps of the first stage.
+func (pp *Postprocessing) Init(_ events.BytesReceived) []interface{} {
+	return pp.start(0)
 }
 
-// NextStep returns the next postprocessing step
-func (pp *Postprocessing) NextStep(ev events.PostprocessingStepFinished) interface{} {
+// NextStep handles a finished step. Once all steps of the current stage are finished
+// their outcomes are joined and either the next stage is started or the postprocessing is finished.
+func (pp *Postprocessing) NextStep(ev events.PostprocessingStepFinished) []interface{} {
+	pp.upgrade()
+	if !slices.Contains(pp.Status.Running, ev.FinishedStep) {
+		// the step is not part of the current stage or has been reported already
+		return nil
+	}
+
 	switch ev.Outcome {
-	case events.PPOutcomeContinue:
-		return pp.next(ev.FinishedStep)
 	case events.PPOutcomeRetry:
 		pp.Failures++
 		if pp.Failures > pp.config.MaxRetries {
-			return pp.finished(events.PPOutcomeAbort)
+			return pp.done(ev.FinishedStep, events.PPOutcomeAbort)
 		}
-		return pp.retry()
+		return []interface{}{pp.retry()}
+	case avevent.PPOutcomeQuarantine:
+		// the antivirus keeps a copy of the file in the quarantine space, the upload itself is deleted
+		return pp.done(ev.FinishedStep, events.PPOutcomeDelete)
 	default:
-		return pp.finished(ev.Outcome)
+		return pp.done(ev.FinishedStep, ev.Outcome)
 	}
 }
 
-// CurrentStep returns the current postprocessing step
-func (pp *Postprocessing) CurrentStep() interface{} {
+// CurrentSteps returns the events to continue the postprocessing with: the steps of
+// the current stage which are not finished yet or the final event.
+func (pp *Postprocessing) CurrentSteps() []interface{} {
+	pp.upgrade()
 	if pp.Status.CurrentStep == events.PPStepFinished {
-		return pp.finished(pp.Status.Outcome)
+		return []interface{}{pp.finished(pp.Status.Outcome)}
 	}
-	return pp.step(pp.Status.CurrentStep)
+
+	next := make([]interface{}, 0, len(pp.Status.Running))
+	for _, s := range pp.Status.Running {
+		next = append(next, pp.step(s))
+	}
+	return next
+}
+
+// InStep reports whether the given step is currently running or,
+// when asked for the finished step, if the postprocessing is finished.
+func (pp *Postprocessing) InStep(step events.Postprocessingstep) bool {
+	pp.upgrade()
+	return pp.Status.CurrentStep == step || slices.Contains(pp.Status.Running, step)
 }
 
-// Delay will sleep the configured time then continue
-func (pp *Postprocessing) Delay(f func(next interface{})) {
-	next := pp.next(events.PPStepDelay)
-	go func() {
-		time.Sleep(pp.config.Delayprocessing)
-		f(next)
-	}()
+// Cancel stops the postprocessing with the given outcome, running steps are not waited for
+func (pp *Postprocessing) Cancel(outcome events.PostprocessingOutcome) interface{} {
+	return pp.finished(outcome)
 }
 
 // BackoffDuration calculates the duration for exponential backoff based on the number of failures.
@@ -88,18 +111,96 @@ func (pp *Postprocessing) BackoffDuration() time.Duration {
 	return pp.config.RetryBackoffDuration * time.Duration(math.Pow(2, float64(pp.Failures-1)))
 }
 
-func (pp *Postprocessing) next(current events.Postprocessingstep) interface{} {
-	l := len(pp.Steps)
-	for i, s := range pp.Steps {
-		if s == current && i+1 < l {
-			return pp.step(pp.Steps[i+1])
+// stages returns the planned stages. Uploads stored by former versions only know a list of steps.
+func (pp *Postprocessing) stages() [][]events.Postprocessingstep {
+	if pp.Stages != nil {
+		return pp.Stages
+	}
+
+	stages := make([][]events.Postprocessingstep, 0, len(pp.Steps))
+	for _, s := range pp.Steps {
+		stages = append(stages, []events.Postprocessingstep{s})
+	}
+	return stages
+}
+
+// upgrade reconstructs the running steps of uploads stored by former versions
+func (pp *Postprocessing) upgrade() {
+	if pp.Status.Running != nil || pp.Status.CurrentStep == "" || pp.Status.CurrentStep == events.PPStepFinished {
+		return
+	}
+
+	for i, stage := range pp.stages() {
+		if slices.Contains(stage, pp.Status.CurrentStep) {
+			pp.Status.Stage = i
+			pp.Status.Running = []events.Postprocessingstep{pp.Status.CurrentStep}
+			return
+		}
+	}
+}
+
+// start starts all steps of the given stage
+func (pp *Postprocessing) start(stage int) []interface{} {
+	stages := pp.stages()
+	if stage >= len(stages) {
+		return []interface{}{pp.finished(events.PPOutcomeContinue)}
+	}
+
+	pp.Status.Stage = stage
+	pp.Status.Running = append([]events.Postprocessingstep{}, stages[stage]...)
+	pp.Status.CurrentStep = pp.Status.Running[0]
+
+	next := make([]interface{}, 0, len(pp.Status.Running))
+	for _, s := range pp.Status.Running {
+		next = append(next, pp.step(s))
+	}
+	return next
+}
+
+// done records the outcome of a step and joins the outcomes once the stage is complete
+func (pp *Postprocessing) done(step events.Postprocessingstep, outcome events.PostprocessingOutcome) []interface{} {
+	if pp.Status.Results == nil {
+		pp.Status.Results = make(map[events.Postprocessingstep]events.PostprocessingOutcome)
+	}
+	pp.Status.Results[step] = outcome
+	pp.Status.Outcome = outcome
+
+	running := make([]events.Postprocessingstep, 0, len(pp.Status.Running))
+	for _, s := range pp.Status.Running {
+		if s != step {
+			running = append(running, s)
 		}
 	}
-	return pp.finished(events.PPOutcomeContinue)
+	pp.Status.Running = running
+	if len(running) > 0 {
+		pp.Status.CurrentStep = running[0]
+		return nil
+	}
+
+	joined := events.PPOutcomeContinue
+	for _, s := range pp.stages()[pp.Status.Stage] {
+		joined = join(joined, pp.Status.Results[s])
+	}
+	if joined != events.PPOutcomeContinue {
+		return []interface{}{pp.finished(joined)}
+	}
+	return pp.start(pp.Status.Stage + 1)
+}
+
+// join returns the more severe outcome. Deleting the file outweighs aborting,
+// aborting outweighs continuing.
+func join(a, b events.PostprocessingOutcome) events.PostprocessingOutcome {
+	switch {
+	case a == events.PPOutcomeDelete || b == events.PPOutcomeDelete:
+		return events.PPOutcomeDelete
+	case a == events.PPOutcomeContinue:
+		return b
+	default:
+		return a
+	}
 }
 
 func (pp *Postprocessing) step(next events.Postprocessingstep) events.StartPostprocessingStep {
-	pp.Status.CurrentStep = next
 	return events.StartPostprocessingStep{
 		UploadID:          pp.ID,
 		URL:               pp.URL,
@@ -115,6 +216,7 @@ func (pp *Postprocessing) step(next events.Postprocessingstep) events.StartPostp
 func (pp *Postprocessing) finished(outcome events.PostprocessingOutcome) events.PostprocessingFinished {
 	pp.Status.CurrentStep = events.PPStepFinished
 	pp.Status.Outcome = outcome
+	pp.Status.Running = nil
 	return events.PostprocessingFinished{
 		UploadID:          pp.ID,
 		ExecutingUser:     pp.User,
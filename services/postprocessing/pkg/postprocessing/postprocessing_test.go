@@ -0,0 +1,162 @@
+package postprocessing_test
+
+import (
+	"testing"
+
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/stretchr/testify/require"
+
+	avevent "github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/postprocessing"
+)
+
+func steps(next []interface{}) []events.Postprocessingstep {
+	var s []events.Postprocessingstep
+	for _, n := range next {
+		if ev, ok := n.(events.StartPostprocessingStep); ok {
+			s = append(s, ev.StepToStart)
+		}
+	}
+	return s
+}
+
+func finished(step events.Postprocessingstep, outcome events.PostprocessingOutcome) events.PostprocessingStepFinished {
+	return events.PostprocessingStepFinished{UploadID: "upload", FinishedStep: step, Outcome: outcome}
+}
+
+func TestPlan(t *testing.T) {
+	pipeline := postprocessing.NewPipeline(config.Postprocessing{Pipeline: []config.Stage{
+		{Steps: []config.Step{
+			{Name: "virusscan", If: config.Condition{MinSize: 1024}},
+			{Name: "ocr", If: config.Condition{MimeTypes: []string{"application/pdf"}}},
+			{Name: "thumbs", If: config.Condition{MimeTypes: []string{"image/*"}, MaxSize: 4096}},
+		}},
+		{Steps: []config.Step{
+			{Name: "policies", If: config.Condition{SpaceTypes: []string{"project"}}},
+			{Name: "legal", If: config.Condition{Groups: []string{"lawyers"}}},
+		}},
+	}})
+
+	upload := func(name string, size uint64, spaceID string, groups ...string) events.BytesReceived {
+		return events.BytesReceived{
+			Filename:      name,
+			Filesize:      size,
+			SpaceOwner:    &user.UserId{OpaqueId: "einstein"},
+			ResourceID:    &provider.ResourceId{SpaceId: spaceID},
+			ExecutingUser: &user.User{Groups: groups},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		upload   events.BytesReceived
+		expected [][]events.Postprocessingstep
+	}{
+		{
+			name:     "small text file in the personal space",
+			upload:   upload("notes.txt", 100, "einstein"),
+			expected: nil,
+		},
+		{
+			name:     "large pdf in a project space",
+			upload:   upload("paper.pdf", 2048, "project"),
+			expected: [][]events.Postprocessingstep{{"virusscan", "ocr"}, {"policies"}},
+		},
+		{
+			name:     "small image uploaded by a lawyer",
+			upload:   upload("photo.PNG", 2000, "einstein", "physicists", "lawyers"),
+			expected: [][]events.Postprocessingstep{{"virusscan", "thumbs"}, {"legal"}},
+		},
+		{
+			name:     "large image",
+			upload:   upload("photo.jpg", 8192, "einstein"),
+			expected: [][]events.Postprocessingstep{{"virusscan"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, pipeline.Plan(tt.upload))
+		})
+	}
+
+	t.Run("the steps run one after another without a pipeline", func(t *testing.T) {
+		p := postprocessing.NewPipeline(config.Postprocessing{Steps: []string{"virusscan", "delay"}})
+		require.Equal(t, [][]events.Postprocessingstep{{"virusscan"}, {"delay"}}, p.Plan(upload("notes.txt", 100, "einstein")))
+	})
+}
+
+func TestParallelSteps(t *testing.T) {
+	newPP := func() *postprocessing.Postprocessing {
+		pp := postprocessing.New(config.Postprocessing{MaxRetries: 1})
+		pp.ID = "upload"
+		pp.Stages = [][]events.Postprocessingstep{{"virusscan", "ocr"}, {"policies"}}
+		return pp
+	}
+
+	t.Run("the next stage starts when all steps continue", func(t *testing.T) {
+		pp := newPP()
+		require.Equal(t, []events.Postprocessingstep{"virusscan", "ocr"}, steps(pp.Init(events.BytesReceived{})))
+
+		require.Empty(t, pp.NextStep(finished("ocr", events.PPOutcomeContinue)))
+		require.True(t, pp.InStep("virusscan"))
+		require.Empty(t, pp.NextStep(finished("ocr", events.PPOutcomeContinue)), "duplicate events are ignored")
+
+		require.Equal(t, []events.Postprocessingstep{"policies"}, steps(pp.NextStep(finished("virusscan", events.PPOutcomeContinue))))
+
+		next := pp.NextStep(finished("policies", events.PPOutcomeContinue))
+		require.Len(t, next, 1)
+		require.Equal(t, events.PPOutcomeContinue, next[0].(events.PostprocessingFinished).Outcome)
+		require.True(t, pp.InStep(events.PPStepFinished))
+	})
+
+	t.Run("the outcomes of a stage are joined", func(t *testing.T) {
+		pp := newPP()
+		pp.Init(events.BytesReceived{})
+
+		require.Empty(t, pp.NextStep(finished("virusscan", events.PPOutcomeDelete)), "waits for the other steps")
+		next := pp.NextStep(finished("ocr", events.PPOutcomeAbort))
+		require.Len(t, next, 1)
+		require.Equal(t, events.PPOutcomeDelete, next[0].(events.PostprocessingFinished).Outcome)
+	})
+
+	t.Run("quarantined uploads are deleted", func(t *testing.T) {
+		pp := newPP()
+		pp.Init(events.BytesReceived{})
+
+		require.Empty(t, pp.NextStep(finished("ocr", events.PPOutcomeContinue)))
+		next := pp.NextStep(finished("virusscan", avevent.PPOutcomeQuarantine))
+		require.Len(t, next, 1)
+		require.Equal(t, events.PPOutcomeDelete, next[0].(events.PostprocessingFinished).Outcome)
+	})
+
+	t.Run("only the failed step is retried", func(t *testing.T) {
+		pp := newPP()
+		pp.Init(events.BytesReceived{})
+
+		next := pp.NextStep(finished("ocr", events.PPOutcomeRetry))
+		require.IsType(t, events.PostprocessingRetry{}, next[0])
+		require.Empty(t, pp.NextStep(finished("virusscan", events.PPOutcomeContinue)))
+		require.Equal(t, []events.Postprocessingstep{"ocr"}, steps(pp.CurrentSteps()))
+
+		next = pp.NextStep(finished("ocr", events.PPOutcomeRetry))
+		require.Equal(t, events.PPOutcomeAbort, next[0].(events.PostprocessingFinished).Outcome, "too many retries")
+	})
+
+	t.Run("uploads without steps finish immediately", func(t *testing.T) {
+		pp := postprocessing.New(config.Postprocessing{})
+		next := pp.Init(events.BytesReceived{})
+		require.Equal(t, events.PPOutcomeContinue, next[0].(events.PostprocessingFinished).Outcome)
+	})
+
+	t.Run("uploads stored by former versions are continued", func(t *testing.T) {
+		pp := postprocessing.New(config.Postprocessing{})
+		pp.Steps = []events.Postprocessingstep{"virusscan", "policies"}
+		pp.Status.CurrentStep = "virusscan"
+
+		require.Equal(t, []events.Postprocessingstep{"virusscan"}, steps(pp.CurrentSteps()))
+		require.Equal(t, []events.Postprocessingstep{"policies"}, steps(pp.NextStep(finished("virusscan", events.PPOutcomeContinue))))
+	})
+}
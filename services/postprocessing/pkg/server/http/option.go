@@ -0,0 +1,87 @@
+package http
+
+import (
+	"context"
+
+	"github.com/cs3org/reva/v2/pkg/events"
+	"go-micro.dev/v4/store"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option defines a single option function.
+type Option func(o *Options)
+
+// Options defines the available options for this package.
+type Options struct {
+	Logger         log.Logger
+	Context        context.Context
+	Config         *config.Config
+	Store          store.Store
+	Publisher      events.Publisher
+	RoleClient     settingssvc.RoleService
+	TracerProvider trace.TracerProvider
+}
+
+// newOptions initializes the available default options.
+func newOptions(opts ...Option) Options {
+	opt := Options{}
+
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	return opt
+}
+
+// Logger provides a function to set the logger option.
+func Logger(val log.Logger) Option {
+	return func(o *Options) {
+		o.Logger = val
+	}
+}
+
+// Context provides a function to set the context option.
+func Context(val context.Context) Option {
+	return func(o *Options) {
+		o.Context = val
+	}
+}
+
+// Config provides a function to set the config option.
+func Config(val *config.Config) Option {
+	return func(o *Options) {
+		o.Config = val
+	}
+}
+
+// Store provides a function to set the store option.
+func Store(val store.Store) Option {
+	return func(o *Options) {
+		o.Store = val
+	}
+}
+
+// Publisher provides a function to set the publisher option.
+func Publisher(val events.Publisher) Option {
+	return func(o *Options) {
+		o.Publisher = val
+	}
+}
+
+// RoleClient adds a grpc client for the role service
+func RoleClient(rs settingssvc.RoleService) Option {
+	return func(o *Options) {
+		o.RoleClient = rs
+	}
+}
+
+// TracerProvider provides a function to set the TracerProvider option
+func TracerProvider(val trace.TracerProvider) Option {
+	return func(o *Options) {
+		o.TracerProvider = val
+	}
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/cs3org/reva/v2/pkg/appctx"
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"github.com/go-chi/chi/v5"
+	"go-micro.dev/v4/store"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/roles"
+	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/event"
+	settings "github.com/owncloud/ocis/v2/services/settings/pkg/service/v0"
+)
+
+// AdminService allows admins to inspect and control the uploads in postprocessing
+type AdminService struct {
+	log   log.Logger
+	cfg   config.Postprocessing
+	store store.Store
+	pub   events.Publisher
+	m     *chi.Mux
+	rm    *roles.Manager
+}
+
+// UploadsResponse is the json response of the uploads endpoint
+type UploadsResponse struct {
+	Total   int      `json:"total"`
+	Uploads []Upload `json:"uploads"`
+}
+
+// NewAdminService registers the admin endpoints on the mux
+func NewAdminService(mux *chi.Mux, cfg config.Postprocessing, sto store.Store, pub events.Publisher, roleClient settingssvc.RoleService, logger log.Logger) *AdminService {
+	rm := roles.NewManager(
+		roles.Logger(logger),
+		roles.RoleService(roleClient),
+	)
+
+	a := &AdminService{
+		log:   logger,
+		cfg:   cfg,
+		store: sto,
+		pub:   pub,
+		m:     mux,
+		rm:    &rm,
+	}
+
+	a.m.Route("/api/v0/postprocessing", func(r chi.Router) {
+		r.Get("/uploads", a.requireAdmin(a.HandleListUploads))
+		r.Post("/uploads/resume", a.requireAdmin(a.HandleResume))
+		r.Post("/uploads/cancel", a.requireAdmin(a.HandleCancel))
+		r.Get("/uploads/{uploadID}", a.requireAdmin(a.HandleGetUpload))
+		r.Post("/uploads/{uploadID}/resume", a.requireAdmin(a.HandleResume))
+		r.Post("/uploads/{uploadID}/cancel", a.requireAdmin(a.HandleCancel))
+	})
+	return a
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (a *AdminService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.m.ServeHTTP(w, r)
+}
+
+// HandleListUploads returns the uploads in postprocessing matching the filter given as query parameters
+func (a *AdminService) HandleListUploads(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pps, err := ListUploads(a.store, a.cfg, filter)
+	if err != nil {
+		a.log.Error().Err(err).Msg("error listing uploads")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	resp := UploadsResponse{
+		Total:   len(pps),
+		Uploads: make([]Upload, 0, len(pps)),
+	}
+	for _, pp := range pps {
+		resp.Uploads = append(resp.Uploads, NewUpload(pp))
+	}
+	a.writeJSON(w, resp)
+}
+
+// HandleGetUpload returns a single upload in postprocessing
+func (a *AdminService) HandleGetUpload(w http.ResponseWriter, r *http.Request) {
+	pp, err := GetUpload(a.store, a.cfg, chi.URLParam(r, "uploadID"))
+	switch {
+	case errors.Is(err, ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		return
+	case err != nil:
+		a.log.Error().Err(err).Msg("error reading upload")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	a.writeJSON(w, NewUpload(pp))
+}
+
+// HandleResume resumes the postprocessing of an upload or, if no upload is given, of all uploads in the given step
+func (a *AdminService) HandleResume(w http.ResponseWriter, r *http.Request) {
+	uploadID, step, ok := a.target(w, r)
+	if !ok {
+		return
+	}
+
+	a.publish(w, r, events.ResumePostprocessing{
+		UploadID:  uploadID,
+		Step:      step,
+		Timestamp: utils.TSNow(),
+	})
+}
+
+// HandleCancel cancels the postprocessing of an upload or, if no upload is given, of all uploads in the given step.
+// The bytes of the uploads are kept unless the 'outcome' query parameter is set to 'delete'.
+func (a *AdminService) HandleCancel(w http.ResponseWriter, r *http.Request) {
+	uploadID, step, ok := a.target(w, r)
+	if !ok {
+		return
+	}
+
+	outcome := events.PostprocessingOutcome(r.URL.Query().Get("outcome"))
+	switch outcome {
+	case "":
+		outcome = events.PPOutcomeAbort
+	case events.PPOutcomeAbort, events.PPOutcomeDelete:
+	default:
+		http.Error(w, "invalid 'outcome', use 'abort' or 'delete'", http.StatusBadRequest)
+		return
+	}
+
+	a.publish(w, r, event.CancelPostprocessing{
+		UploadID:  uploadID,
+		Step:      step,
+		Outcome:   outcome,
+		Timestamp: utils.TSNow(),
+	})
+}
+
+// target returns the upload or the step a bulk action applies to
+func (a *AdminService) target(w http.ResponseWriter, r *http.Request) (string, events.Postprocessingstep, bool) {
+	uploadID := chi.URLParam(r, "uploadID")
+	if uploadID != "" {
+		if _, err := GetUpload(a.store, a.cfg, uploadID); err != nil {
+			if errors.Is(err, ErrNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				return "", "", false
+			}
+			a.log.Error().Err(err).Msg("error reading upload")
+			w.WriteHeader(http.StatusInternalServerError)
+			return "", "", false
+		}
+		return uploadID, "", true
+	}
+
+	step := events.Postprocessingstep(r.URL.Query().Get("step"))
+	if step == "" {
+		http.Error(w, "missing 'step'", http.StatusBadRequest)
+		return "", "", false
+	}
+	return "", step, true
+}
+
+func (a *AdminService) publish(w http.ResponseWriter, r *http.Request, ev interface{}) {
+	if err := events.Publish(r.Context(), a.pub, ev); err != nil {
+		a.log.Error().Err(err).Msg("error publishing event")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *AdminService) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		a.log.Error().Err(err).Msg("error writing response")
+	}
+}
+
+func parseFilter(r *http.Request) (UploadFilter, error) {
+	v := r.URL.Query()
+	f := UploadFilter{
+		Step: events.Postprocessingstep(v.Get("step")),
+		User: v.Get("user"),
+	}
+
+	if s := v.Get("olderThan"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil || d < 0 {
+			return f, errors.New("invalid 'olderThan', use a duration like '1h'")
+		}
+		f.OlderThan = d
+	}
+	return f, nil
+}
+
+func (a *AdminService) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ok, err := isAdmin(r.Context(), a.rm)
+		switch {
+		case err != nil:
+			a.log.Error().Err(err).Msg("error checking if user is admin")
+			w.WriteHeader(http.StatusUnauthorized)
+		case !ok:
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	}
+}
+
+// isAdmin determines if the user in the context is an admin / has account management permissions
+func isAdmin(ctx context.Context, rm *roles.Manager) (bool, error) {
+	logger := appctx.GetLogger(ctx)
+
+	u, ok := revactx.ContextGetUser(ctx)
+	uid := u.GetId().GetOpaqueId()
+	if !ok || uid == "" {
+		return false, errors.New("no user in context")
+	}
+	// get roles from context
+	roleIDs, ok := roles.ReadRoleIDsFromContext(ctx)
+	if !ok {
+		logger.Debug().Str("userid", uid).Msg("No roles in context, contacting settings service")
+		var err error
+		roleIDs, err = rm.FindRoleIDsForUser(ctx, uid)
+		if err != nil {
+			return false, err
+		}
+
+		if len(roleIDs) == 0 {
+			return false, errors.New("user has no roles")
+		}
+	}
+
+	// check if permission is present in roles of the authenticated account
+	return rm.FindPermissionByID(ctx, roleIDs, settings.AccountManagementPermissionID) != nil, nil
+}
@@ -11,8 +11,10 @@ import (
 	ctxpkg "github.com/cs3org/reva/v2/pkg/ctx"
 	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/utils"
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/event"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/postprocessing"
 	"go-micro.dev/v4/store"
 	"go.opentelemetry.io/otel/trace"
@@ -20,16 +22,25 @@ import (
 
 // PostprocessingService is an instance of the service handling postprocessing of files
 type PostprocessingService struct {
-	ctx    context.Context
-	log    log.Logger
-	events <-chan events.Event
-	pub    events.Publisher
-	steps  []events.Postprocessingstep
-	store  store.Store
-	c      config.Postprocessing
-	tp     trace.TracerProvider
+	ctx      context.Context
+	log      log.Logger
+	events   <-chan events.Event
+	pub      events.Publisher
+	pipeline postprocessing.Pipeline
+	store    store.Store
+	locks    kv.Store
+	c        config.Postprocessing
+	tp       trace.TracerProvider
 }
 
+const (
+	// _lockTTL is the time after which the lock of an upload is released if the instance holding it died
+	_lockTTL = 30 * time.Second
+	// _delayCheckInterval is the interval the delay steps of all instances are checked in. A delay step
+	// finishes on time unless the instance which started it stopped in the meantime.
+	_delayCheckInterval = time.Minute
+)
+
 var (
 	// ErrFatal is returned when a fatal error occurs and we want to exit.
 	ErrFatal = errors.New("fatal error")
@@ -40,32 +51,36 @@ var (
 )
 
 // NewPostprocessingService returns a new instance of a postprocessing service
-func NewPostprocessingService(ctx context.Context, stream events.Stream, logger log.Logger, sto store.Store, tp trace.TracerProvider, c config.Postprocessing) (*PostprocessingService, error) {
+func NewPostprocessingService(ctx context.Context, stream events.Stream, logger log.Logger, sto store.Store, locks kv.Store, tp trace.TracerProvider, c config.Postprocessing) (*PostprocessingService, error) {
 	evs, err := events.Consume(stream, "postprocessing",
 		events.BytesReceived{},
 		events.StartPostprocessingStep{},
 		events.UploadReady{},
 		events.PostprocessingStepFinished{},
 		events.ResumePostprocessing{},
+		event.CancelPostprocessing{},
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	return &PostprocessingService{
-		ctx:    ctx,
-		log:    logger,
-		events: evs,
-		pub:    stream,
-		steps:  getSteps(c),
-		store:  sto,
-		c:      c,
-		tp:     tp,
+		ctx:      ctx,
+		log:      logger,
+		events:   evs,
+		pub:      stream,
+		pipeline: postprocessing.NewPipeline(c),
+		store:    sto,
+		locks:    locks,
+		c:        c,
+		tp:       tp,
 	}, nil
 }
 
 // Run to fulfil Runner interface
 func (pps *PostprocessingService) Run() error {
+	go pps.checkDelays()
+
 	wg := sync.WaitGroup{}
 
 	for i := 0; i < pps.c.Workers; i++ {
@@ -93,9 +108,8 @@ func (pps *PostprocessingService) Run() error {
 
 func (pps *PostprocessingService) processEvent(e events.Event) error {
 	var (
-		next interface{}
+		next []interface{}
 		pp   *postprocessing.Postprocessing
-		err  error
 	)
 
 	ctx := e.GetTraceContext(pps.ctx)
@@ -104,6 +118,7 @@ func (pps *PostprocessingService) processEvent(e events.Event) error {
 
 	switch ev := e.Event.(type) {
 	case events.BytesReceived:
+		stages := pps.pipeline.Plan(ev)
 		pp = &postprocessing.Postprocessing{
 			ID:                ev.UploadID,
 			URL:               ev.URL,
@@ -111,9 +126,11 @@ func (pps *PostprocessingService) processEvent(e events.Event) error {
 			Filename:          ev.Filename,
 			Filesize:          ev.Filesize,
 			ResourceID:        ev.ResourceID,
-			Steps:             pps.steps,
+			Steps:             flatten(stages),
+			Stages:            stages,
 			InitiatorID:       e.InitiatorID,
 			ImpersonatingUser: ev.ImpersonatingUser,
+			Started:           time.Now(),
 		}
 		next = pp.Init(ev)
 	case events.PostprocessingStepFinished:
@@ -121,7 +138,15 @@ func (pps *PostprocessingService) processEvent(e events.Event) error {
 			// no current upload - this was an on demand scan
 			return nil
 		}
-		pp, err = pps.getPP(pps.store, ev.UploadID)
+		// parallel steps of an upload may finish at the same time on different instances
+		unlock, err := pps.lock(ev.UploadID)
+		if err != nil {
+			pps.log.Error().Str("uploadID", ev.UploadID).Err(err).Msg("cannot lock upload")
+			return fmt.Errorf("%w: cannot lock upload", ErrEvent)
+		}
+		defer unlock()
+
+		pp, err = GetUpload(pps.store, pps.c, ev.UploadID)
 		if err != nil {
 			pps.log.Error().Str("uploadID", ev.UploadID).Err(err).Msg("cannot get upload")
 			return fmt.Errorf("%w: cannot get upload", ErrEvent)
@@ -141,7 +166,7 @@ func (pps *PostprocessingService) processEvent(e events.Event) error {
 					Filename:          pp.Filename,
 					Filesize:          pp.Filesize,
 					ResourceID:        pp.ResourceID,
-					StepToStart:       pp.Status.CurrentStep,
+					StepToStart:       ev.FinishedStep,
 					ImpersonatingUser: pp.ImpersonatingUser,
 				}
 				err := events.Publish(ctx, pps.pub, retryEvent)
@@ -154,20 +179,22 @@ func (pps *PostprocessingService) processEvent(e events.Event) error {
 		if ev.StepToStart != events.PPStepDelay {
 			return nil
 		}
-		pp, err = pps.getPP(pps.store, ev.UploadID)
-		if err != nil {
-			pps.log.Error().Str("uploadID", ev.UploadID).Err(err).Msg("cannot get upload")
-			return fmt.Errorf("%w: cannot get upload", ErrEvent)
+		// the delay step finishes like any other step, so that it can run in parallel to others
+		if err := pps.startDelay(ev.UploadID); err != nil {
+			pps.log.Error().Str("uploadID", ev.UploadID).Err(err).Msg("cannot start delay")
+			return fmt.Errorf("%w: cannot start delay", ErrEvent)
 		}
-		pp.Delay(func(next interface{}) {
-			if err := events.Publish(ctx, pps.pub, next); err != nil {
-				pps.log.Error().Err(err).Msg("cannot publish event")
-			}
-		})
 	case events.UploadReady:
 		if ev.Failed {
 			// the upload failed - let's keep it around for a while - but mark it as finished
-			pp, err = pps.getPP(pps.store, ev.UploadID)
+			unlock, err := pps.lock(ev.UploadID)
+			if err != nil {
+				pps.log.Error().Str("uploadID", ev.UploadID).Err(err).Msg("cannot lock upload")
+				return fmt.Errorf("%w: cannot lock upload", ErrEvent)
+			}
+			defer unlock()
+
+			pp, err = GetUpload(pps.store, pps.c, ev.UploadID)
 			if err != nil {
 				pps.log.Error().Str("uploadID", ev.UploadID).Err(err).Msg("cannot get upload")
 				return fmt.Errorf("%w: cannot get upload", ErrEvent)
@@ -183,6 +210,8 @@ func (pps *PostprocessingService) processEvent(e events.Event) error {
 		}
 	case events.ResumePostprocessing:
 		return pps.handleResumePPEvent(ctx, ev)
+	case event.CancelPostprocessing:
+		return pps.handleCancelPPEvent(ctx, ev)
 	}
 
 	if pp != nil {
@@ -194,8 +223,8 @@ func (pps *PostprocessingService) processEvent(e events.Event) error {
 		}
 	}
 
-	if next != nil {
-		if err := events.Publish(ctx, pps.pub, next); err != nil {
+	for _, n := range next {
+		if err := events.Publish(ctx, pps.pub, n); err != nil {
 			pps.log.Error().Err(err).Msg("unable to publish event")
 			return fmt.Errorf("%w: unable to publish event", ErrFatal) // we can't publish -> we are screwed
 		}
@@ -203,45 +232,115 @@ func (pps *PostprocessingService) processEvent(e events.Event) error {
 	return nil
 }
 
-func (pps *PostprocessingService) getPP(sto store.Store, uploadID string) (*postprocessing.Postprocessing, error) {
-	recs, err := sto.Read(uploadID)
+// lock serializes the handling of events of the same upload across all instances.
+// The lock is kept until it is released, _lockTTL only applies if the instance died.
+func (pps *PostprocessingService) lock(uploadID string) (func(), error) {
+	return kv.KeepLock(pps.ctx, pps.locks, uploadID, _lockTTL)
+}
+
+// startDelay persists the deadline of the delay step, so that it is finished even if the instance stops
+func (pps *PostprocessingService) startDelay(uploadID string) error {
+	unlock, err := pps.lock(uploadID)
 	if err != nil {
-		if err == store.ErrNotFound {
-			return nil, ErrNotFound
-		}
-		return nil, err
+		return err
 	}
+	defer unlock()
 
-	if len(recs) == 0 {
-		return nil, ErrNotFound
+	pp, err := GetUpload(pps.store, pps.c, uploadID)
+	if err != nil {
+		return err
 	}
+	if pp.DelayUntil.IsZero() {
+		pp.DelayUntil = time.Now().Add(pps.c.Delayprocessing)
+		if err := storePP(pps.store, pp); err != nil {
+			return err
+		}
+	}
+
+	time.AfterFunc(time.Until(pp.DelayUntil), func() { pps.finishDelay(uploadID) })
+	return nil
+}
+
+// checkDelays finishes the delay steps whose deadline passed until the service stops
+func (pps *PostprocessingService) checkDelays() {
+	t := time.NewTicker(_delayCheckInterval)
+	defer t.Stop()
+
+	for {
+		pps.finishDelays()
 
-	if len(recs) > 1 {
-		return nil, fmt.Errorf("expected only one result for '%s', got %d", uploadID, len(recs))
+		select {
+		case <-pps.ctx.Done():
+			return
+		case <-t.C:
+		}
 	}
+}
 
-	pp := postprocessing.New(pps.c)
-	err = json.Unmarshal(recs[0].Value, pp)
+// finishDelays finishes the delay steps whose deadline passed, e.g. because the instance which started them stopped
+func (pps *PostprocessingService) finishDelays() {
+	pp, err := ListUploads(pps.store, pps.c, UploadFilter{Step: events.PPStepDelay})
 	if err != nil {
-		return nil, err
+		pps.log.Error().Err(err).Msg("cannot list uploads")
+		return
 	}
 
-	return pp, nil
+	for _, p := range pp {
+		if !p.DelayUntil.IsZero() && !time.Now().Before(p.DelayUntil) {
+			pps.finishDelay(p.ID)
+		}
+	}
 }
 
-func getSteps(c config.Postprocessing) []events.Postprocessingstep {
-	// NOTE: improved version only allows configuring order of postprocessing steps
-	// But we aim for a system where postprocessing steps can be configured per space, ideally by the spaceadmin itself
-	// We need to iterate over configuring PP service when we see fit
-	steps := make([]events.Postprocessingstep, 0, len(c.Steps))
-	for _, s := range c.Steps {
-		steps = append(steps, events.Postprocessingstep(s))
+// finishDelay publishes the end of the delay step of the upload once the deadline passed.
+// The deadline is reset under the lock of the upload, so the step is finished only once.
+func (pps *PostprocessingService) finishDelay(uploadID string) {
+	unlock, err := pps.lock(uploadID)
+	if err != nil {
+		pps.log.Error().Str("uploadID", uploadID).Err(err).Msg("cannot lock upload")
+		return
+	}
+	defer unlock()
+
+	pp, err := GetUpload(pps.store, pps.c, uploadID)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return
+	case err != nil:
+		pps.log.Error().Str("uploadID", uploadID).Err(err).Msg("cannot get upload")
+		return
+	case pp.DelayUntil.IsZero() || time.Now().Before(pp.DelayUntil):
+		return
+	}
+
+	if err := events.Publish(ctxpkg.ContextSetInitiator(pps.ctx, pp.InitiatorID), pps.pub, events.PostprocessingStepFinished{
+		UploadID:      pp.ID,
+		ExecutingUser: pp.User,
+		Filename:      pp.Filename,
+		FinishedStep:  events.PPStepDelay,
+		Outcome:       events.PPOutcomeContinue,
+		Timestamp:     utils.TSNow(),
+	}); err != nil {
+		pps.log.Error().Str("uploadID", uploadID).Err(err).Msg("cannot publish event")
+		return
 	}
 
+	pp.DelayUntil = time.Time{}
+	if err := storePP(pps.store, pp); err != nil {
+		pps.log.Error().Str("uploadID", uploadID).Err(err).Msg("cannot store upload")
+	}
+}
+
+func flatten(stages [][]events.Postprocessingstep) []events.Postprocessingstep {
+	var steps []events.Postprocessingstep
+	for _, stage := range stages {
+		steps = append(steps, stage...)
+	}
 	return steps
 }
 
 func storePP(sto store.Store, pp *postprocessing.Postprocessing) error {
+	pp.Updated = time.Now()
 	b, err := json.Marshal(pp)
 	if err != nil {
 		return err
@@ -268,7 +367,7 @@ func (pps *PostprocessingService) handleResumePPEvent(ctx context.Context, ev ev
 }
 
 func (pps *PostprocessingService) resumePP(ctx context.Context, uploadID string) error {
-	pp, err := pps.getPP(pps.store, uploadID)
+	pp, err := GetUpload(pps.store, pps.c, uploadID)
 	if err != nil {
 		if err == ErrNotFound {
 			if err := events.Publish(ctx, pps.pub, events.RestartPostprocessing{
@@ -287,39 +386,67 @@ func (pps *PostprocessingService) resumePP(ctx context.Context, uploadID string)
 		return nil
 	}
 
-	return events.Publish(ctx, pps.pub, pp.CurrentStep())
+	for _, next := range pp.CurrentSteps() {
+		if err := events.Publish(ctx, pps.pub, next); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (pps *PostprocessingService) findUploadsByStep(step events.Postprocessingstep) []string {
-	var ids []string
+func (pps *PostprocessingService) handleCancelPPEvent(ctx context.Context, ev event.CancelPostprocessing) error {
+	ids := []string{ev.UploadID}
+	if ev.UploadID == "" {
+		ids = pps.findUploadsByStep(ev.Step)
+	}
+
+	for _, id := range ids {
+		if err := pps.cancelPP(ctx, id, ev.Outcome); err != nil {
+			pps.log.Error().Str("uploadID", id).Err(err).Msg("cannot cancel upload")
+		}
+	}
+	return nil
+}
 
-	keys, err := pps.store.List()
+func (pps *PostprocessingService) cancelPP(ctx context.Context, uploadID string, outcome events.PostprocessingOutcome) error {
+	unlock, err := pps.lock(uploadID)
 	if err != nil {
-		pps.log.Error().Err(err).Msg("cannot list uploads")
+		return fmt.Errorf("cannot lock upload: %w", err)
 	}
+	defer unlock()
 
-	for _, k := range keys {
-		rec, err := pps.store.Read(k)
-		if err != nil {
-			pps.log.Error().Err(err).Msg("cannot read upload")
-			continue
-		}
+	pp, err := GetUpload(pps.store, pps.c, uploadID)
+	if err != nil {
+		return fmt.Errorf("cannot get upload: %w", err)
+	}
 
-		if len(rec) != 1 {
-			pps.log.Error().Err(err).Msg("expected only one result")
-			continue
-		}
+	if pp.Status.CurrentStep == events.PPStepFinished {
+		// the storage decides about the upload already
+		return nil
+	}
 
-		pp := &postprocessing.Postprocessing{}
-		err = json.Unmarshal(rec[0].Value, pp)
-		if err != nil {
-			pps.log.Error().Err(err).Msg("cannot unmarshal upload")
-			continue
-		}
+	if outcome != events.PPOutcomeDelete {
+		outcome = events.PPOutcomeAbort
+	}
+	next := pp.Cancel(outcome)
+	if err := storePP(pps.store, pp); err != nil {
+		return fmt.Errorf("cannot store upload: %w", err)
+	}
 
-		if pp.Status.CurrentStep == step {
-			ids = append(ids, pp.ID)
-		}
+	pps.log.Info().Str("uploadID", uploadID).Str("outcome", string(outcome)).Msg("postprocessing cancelled")
+	return events.Publish(ctxpkg.ContextSetInitiator(ctx, pp.InitiatorID), pps.pub, next)
+}
+
+func (pps *PostprocessingService) findUploadsByStep(step events.Postprocessingstep) []string {
+	var ids []string
+
+	pp, err := ListUploads(pps.store, pps.c, UploadFilter{Step: step})
+	if err != nil {
+		pps.log.Error().Err(err).Msg("cannot list uploads")
+	}
+
+	for _, p := range pp {
+		ids = append(ids, p.ID)
 	}
 
 	return ids
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"go-micro.dev/v4/store"
+
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/postprocessing"
+)
+
+// Upload is the administrative view on an upload in postprocessing
+type Upload struct {
+	ID         string                        `json:"id"`
+	Filename   string                        `json:"filename"`
+	Filesize   uint64                        `json:"filesize"`
+	ResourceID string                        `json:"resourceId,omitempty"`
+	User       string                        `json:"user,omitempty"`
+	Username   string                        `json:"username,omitempty"`
+	Step       events.Postprocessingstep     `json:"step"`
+	Running    []events.Postprocessingstep   `json:"running,omitempty"`
+	Stages     [][]events.Postprocessingstep `json:"stages"`
+	Outcome    events.PostprocessingOutcome  `json:"outcome,omitempty"`
+	Failures   int                           `json:"failures"`
+	Finished   bool                          `json:"finished"`
+	Started    *time.Time                    `json:"started,omitempty"`
+	Updated    *time.Time                    `json:"updated,omitempty"`
+}
+
+// UploadFilter restricts the uploads returned by ListUploads. Empty fields match all uploads.
+type UploadFilter struct {
+	Step      events.Postprocessingstep
+	User      string
+	OlderThan time.Duration
+}
+
+// ListUploads returns the uploads known to the postprocessing, the oldest first
+func ListUploads(sto store.Store, c config.Postprocessing, filter UploadFilter) ([]*postprocessing.Postprocessing, error) {
+	keys, err := sto.List()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list uploads: %w", err)
+	}
+
+	var pps []*postprocessing.Postprocessing
+	for _, k := range keys {
+		pp, err := GetUpload(sto, c, k)
+		if err != nil {
+			// the upload might have been finished in the meantime
+			continue
+		}
+
+		switch {
+		case filter.Step != "" && !pp.InStep(filter.Step):
+			continue
+		case filter.User != "" && pp.User.GetId().GetOpaqueId() != filter.User && pp.User.GetUsername() != filter.User:
+			continue
+		case filter.OlderThan > 0 && (pp.Started.IsZero() || time.Since(pp.Started) < filter.OlderThan):
+			continue
+		}
+		pps = append(pps, pp)
+	}
+
+	sort.SliceStable(pps, func(i, j int) bool {
+		return pps[i].Started.Before(pps[j].Started)
+	})
+	return pps, nil
+}
+
+// GetUpload returns a single upload known to the postprocessing
+func GetUpload(sto store.Store, c config.Postprocessing, uploadID string) (*postprocessing.Postprocessing, error) {
+	recs, err := sto.Read(uploadID)
+	if err != nil {
+		if err == store.ErrNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	if len(recs) == 0 {
+		return nil, ErrNotFound
+	}
+
+	if len(recs) > 1 {
+		return nil, fmt.Errorf("expected only one result for '%s', got %d", uploadID, len(recs))
+	}
+
+	pp := postprocessing.New(c)
+	err = json.Unmarshal(recs[0].Value, pp)
+	if err != nil {
+		return nil, err
+	}
+
+	return pp, nil
+}
+
+// NewUpload returns the administrative view on the upload. It leaves out the upload url which grants access to the file.
+func NewUpload(pp *postprocessing.Postprocessing) Upload {
+	u := Upload{
+		ID:       pp.ID,
+		Filename: pp.Filename,
+		Filesize: pp.Filesize,
+		User:     pp.User.GetId().GetOpaqueId(),
+		Username: pp.User.GetUsername(),
+		Step:     pp.Status.CurrentStep,
+		Running:  pp.Status.Running,
+		Stages:   pp.Stages,
+		Outcome:  pp.Status.Outcome,
+		Failures: pp.Failures,
+		Finished: pp.Finished,
+	}
+	if u.Stages == nil {
+		for _, s := range pp.Steps {
+			u.Stages = append(u.Stages, []events.Postprocessingstep{s})
+		}
+	}
+	if pp.ResourceID != nil {
+		u.ResourceID = storagespace.FormatResourceID(pp.ResourceID)
+	}
+	if !pp.Started.IsZero() {
+		u.Started = &pp.Started
+	}
+	if !pp.Updated.IsZero() {
+		u.Updated = &pp.Updated
+	}
+	return u
+}
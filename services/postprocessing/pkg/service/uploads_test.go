@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/stretchr/testify/require"
+	microevents "go-micro.dev/v4/events"
+	"go-micro.dev/v4/store"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/event"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/postprocessing"
+)
+
+type testPublisher struct {
+	events []interface{}
+}
+
+func (p *testPublisher) Publish(_ string, ev interface{}, _ ...microevents.PublishOption) error {
+	p.events = append(p.events, ev)
+	return nil
+}
+
+func addUpload(t *testing.T, sto store.Store, id, username string, started time.Time, step events.Postprocessingstep) {
+	pp := &postprocessing.Postprocessing{
+		ID:      id,
+		User:    &user.User{Id: &user.UserId{OpaqueId: username + "-id"}, Username: username},
+		Steps:   []events.Postprocessingstep{"virusscan", "policies"},
+		Started: started,
+		Status:  postprocessing.Status{CurrentStep: step},
+	}
+	require.NoError(t, storePP(sto, pp))
+}
+
+func TestListUploads(t *testing.T) {
+	sto := store.NewMemoryStore()
+	addUpload(t, sto, "upload-1", "einstein", time.Now().Add(-2*time.Hour), "virusscan")
+	addUpload(t, sto, "upload-2", "marie", time.Now().Add(-3*time.Hour), "policies")
+	addUpload(t, sto, "upload-3", "einstein", time.Now(), "virusscan")
+
+	ids := func(filter UploadFilter) []string {
+		pps, err := ListUploads(sto, config.Postprocessing{}, filter)
+		require.NoError(t, err)
+		var ids []string
+		for _, pp := range pps {
+			ids = append(ids, pp.ID)
+		}
+		return ids
+	}
+
+	require.Equal(t, []string{"upload-2", "upload-1", "upload-3"}, ids(UploadFilter{}), "the oldest uploads come first")
+	require.Equal(t, []string{"upload-1", "upload-3"}, ids(UploadFilter{Step: "virusscan"}))
+	require.Equal(t, []string{"upload-1", "upload-3"}, ids(UploadFilter{User: "einstein"}))
+	require.Equal(t, []string{"upload-2"}, ids(UploadFilter{User: "marie-id"}))
+	require.Equal(t, []string{"upload-2", "upload-1"}, ids(UploadFilter{OlderThan: time.Hour}))
+
+	pp, err := GetUpload(sto, config.Postprocessing{}, "upload-1")
+	require.NoError(t, err)
+	u := NewUpload(pp)
+	require.Equal(t, [][]events.Postprocessingstep{{"virusscan"}, {"policies"}}, u.Stages)
+	require.Equal(t, "einstein", u.Username)
+
+	_, err = GetUpload(sto, config.Postprocessing{}, "unknown")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestCancelPostprocessing(t *testing.T) {
+	sto := store.NewMemoryStore()
+	addUpload(t, sto, "upload-1", "einstein", time.Now(), "virusscan")
+	addUpload(t, sto, "upload-2", "einstein", time.Now(), "virusscan")
+	addUpload(t, sto, "upload-3", "einstein", time.Now(), "policies")
+
+	pub := &testPublisher{}
+	pps := &PostprocessingService{
+		ctx:   context.Background(),
+		log:   log.NopLogger(),
+		pub:   pub,
+		store: sto,
+		locks: kv.New(kv.Options{}),
+		tp:    noop.NewTracerProvider(),
+	}
+
+	require.NoError(t, pps.processEvent(events.Event{Event: event.CancelPostprocessing{
+		Step:    "virusscan",
+		Outcome: events.PPOutcomeDelete,
+	}}))
+
+	require.Len(t, pub.events, 2)
+	for _, ev := range pub.events {
+		require.Equal(t, events.PPOutcomeDelete, ev.(events.PostprocessingFinished).Outcome)
+	}
+
+	pp, err := GetUpload(sto, config.Postprocessing{}, "upload-1")
+	require.NoError(t, err)
+	require.Equal(t, events.PPStepFinished, pp.Status.CurrentStep)
+
+	pp, err = GetUpload(sto, config.Postprocessing{}, "upload-3")
+	require.NoError(t, err)
+	require.Equal(t, events.Postprocessingstep("policies"), pp.Status.CurrentStep, "uploads in other steps are not affected")
+
+	t.Run("finished uploads are left alone", func(t *testing.T) {
+		pub.events = nil
+		require.NoError(t, pps.processEvent(events.Event{Event: event.CancelPostprocessing{UploadID: "upload-1"}}))
+		require.Empty(t, pub.events)
+	})
+}
+
+func TestFinishDelays(t *testing.T) {
+	sto := store.NewMemoryStore()
+	addUpload(t, sto, "upload-1", "einstein", time.Now(), events.PPStepDelay)
+	addUpload(t, sto, "upload-2", "einstein", time.Now(), events.PPStepDelay)
+
+	pub := &testPublisher{}
+	pps := &PostprocessingService{
+		ctx:   context.Background(),
+		log:   log.NopLogger(),
+		pub:   pub,
+		store: sto,
+		locks: kv.New(kv.Options{}),
+		c:     config.Postprocessing{Delayprocessing: time.Hour},
+		tp:    noop.NewTracerProvider(),
+	}
+	require.NoError(t, pps.startDelay("upload-1"))
+	require.NoError(t, pps.startDelay("upload-2"))
+
+	// the instance which started the delay of upload-1 stopped after the deadline passed
+	pp, err := GetUpload(sto, config.Postprocessing{}, "upload-1")
+	require.NoError(t, err)
+	pp.DelayUntil = time.Now().Add(-time.Minute)
+	require.NoError(t, storePP(sto, pp))
+
+	pps.finishDelays()
+	pps.finishDelays()
+	require.Len(t, pub.events, 1, "the delay step finishes only once")
+	ev := pub.events[0].(events.PostprocessingStepFinished)
+	require.Equal(t, "upload-1", ev.UploadID)
+	require.Equal(t, events.PPStepDelay, ev.FinishedStep)
+
+	pp, err = GetUpload(sto, config.Postprocessing{}, "upload-1")
+	require.NoError(t, err)
+	require.True(t, pp.DelayUntil.IsZero())
+}
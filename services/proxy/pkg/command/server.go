@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/justinas/alice"
 	"github.com/oklog/run"
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	pkgmiddleware "github.com/owncloud/ocis/v2/ocis-pkg/middleware"
 	"github.com/owncloud/ocis/v2/ocis-pkg/oidc"
@@ -324,6 +326,55 @@ func loadMiddlewares(logger log.Logger, cfg *config.Config,
 		Now:                time.Now,
 	})
 
+	// rules counting by user are enforced after the authentication, all others before it
+	rateLimit, userRateLimit := passThrough, passThrough
+	if cfg.RateLimit.Enabled {
+		var rules, userRules []config.RateLimitRule
+		var ttl time.Duration
+		for _, rule := range cfg.RateLimit.Rules {
+			if rule.Key == config.RateLimitKeyUser {
+				userRules = append(userRules, rule)
+			} else {
+				rules = append(rules, rule)
+			}
+			ttl = max(ttl, rule.Interval)
+		}
+
+		rateLimitStore := kv.New(kv.Options{
+			Type:               cfg.RateLimit.Store.Store,
+			Nodes:              cfg.RateLimit.Store.Nodes,
+			Bucket:             "proxy-rate-limits",
+			TTL:                ttl,
+			DisablePersistence: cfg.RateLimit.Store.DisablePersistence,
+			Username:           cfg.RateLimit.Store.AuthUsername,
+			Password:           cfg.RateLimit.Store.AuthPassword,
+			// the store is served by the same nats servers as the events
+			EnableTLS:            cfg.Events.EnableTLS,
+			TLSInsecure:          cfg.Events.TLSInsecure,
+			TLSRootCACertificate: cfg.Events.TLSRootCACertificate,
+		})
+		trustedProxies := make([]*net.IPNet, 0, len(cfg.RateLimit.TrustedProxies))
+		for _, cidr := range cfg.RateLimit.TrustedProxies {
+			// the networks are validated by the config parser
+			if _, n, err := net.ParseCIDR(cidr); err == nil {
+				trustedProxies = append(trustedProxies, n)
+			}
+		}
+		rateLimit = middleware.RateLimit(
+			middleware.Logger(logger),
+			middleware.RateLimitRules(rules),
+			middleware.RateLimitStore(rateLimitStore),
+			middleware.TrustedProxies(trustedProxies),
+			middleware.Metrics(&metrics),
+		)
+		userRateLimit = middleware.RateLimit(
+			middleware.Logger(logger),
+			middleware.RateLimitRules(userRules),
+			middleware.RateLimitStore(rateLimitStore),
+			middleware.Metrics(&metrics),
+		)
+	}
+
 	cspConfig, err := middleware.LoadCSPConfig(cfg)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to load CSP configuration.")
@@ -340,12 +391,14 @@ func loadMiddlewares(logger log.Logger, cfg *config.Config,
 		middleware.Tracer(traceProvider),
 		pkgmiddleware.TraceContext,
 		middleware.Instrumenter(metrics),
+		middleware.PeerAddr,
 		chimiddleware.RealIP,
 		chimiddleware.RequestID,
 		middleware.AccessLog(logger),
 		middleware.ContextLogger(logger),
 		middleware.HTTPSRedirect,
 		middleware.Security(cspConfig),
+		rateLimit,
 		router.Middleware(serviceSelector, cfg.PolicySelector, cfg.Policies, logger),
 		middleware.Authentication(
 			authenticators,
@@ -365,6 +418,7 @@ func loadMiddlewares(logger log.Logger, cfg *config.Config,
 			middleware.AutoprovisionAccounts(cfg.AutoprovisionAccounts),
 			middleware.EventsPublisher(publisher),
 		),
+		userRateLimit,
 		middleware.SelectorCookie(
 			middleware.Logger(logger),
 			middleware.PolicySelectorConfig(*cfg.PolicySelector),
@@ -383,3 +437,7 @@ func loadMiddlewares(logger log.Logger, cfg *config.Config,
 		),
 	)
 }
+
+func passThrough(next http.Handler) http.Handler {
+	return next
+}
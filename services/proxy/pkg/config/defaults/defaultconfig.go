@@ -82,6 +82,15 @@ func DefaultConfig() *config.Config {
 				DisablePersistence: true,
 			},
 		},
+		RateLimit: config.RateLimit{
+			Enabled: false,
+			Rules:   DefaultRateLimitRules(),
+			Store: &config.RateLimitStore{
+				Store:              "nats-js-kv", // the rate limits need to be shared by all proxy instances
+				Nodes:              []string{"127.0.0.1:9233"},
+				DisablePersistence: true,
+			},
+		},
 		AccountBackend:        "cs3",
 		UserOIDCClaim:         "preferred_username",
 		UserCS3Claim:          "username",
@@ -103,6 +112,30 @@ func DefaultConfig() *config.Config {
 	}
 }
 
+// DefaultRateLimitRules returns the default rate limit rules.
+func DefaultRateLimitRules() []config.RateLimitRule {
+	// rules with the key ip are not enabled by default, all clients share the address of a reverse proxy
+	// unless it is configured as trusted proxy
+	publicLinkPaths := []string{"/dav/public-files/", "/remote.php/dav/public-files/", "/archiver"}
+	return []config.RateLimitRule{
+		{
+			Name:     "public-links",
+			Key:      config.RateLimitKeyPublicLink,
+			Paths:    publicLinkPaths,
+			Requests: 600,
+			Interval: time.Minute,
+		},
+		{
+			Name:       "public-link-passwords",
+			Key:        config.RateLimitKeyPublicLink,
+			Paths:      publicLinkPaths,
+			Requests:   10,
+			Interval:   time.Minute,
+			FailedOnly: true,
+		},
+	}
+}
+
 // DefaultPolicies returns the default proxy policies.
 func DefaultPolicies() []config.Policy {
 	return []config.Policy{
@@ -152,6 +185,11 @@ func DefaultPolicies() []config.Policy {
 					Endpoint: "/ocs/v2.php/apps/notifications/api/v1/notifications/sse",
 					Service:  "com.owncloud.sse.sse",
 				},
+				{
+					// web push subscriptions are managed by the sse service
+					Endpoint: "/ocs/v2.php/apps/notifications/api/v1/notifications/push",
+					Service:  "com.owncloud.sse.sse",
+				},
 				{
 					// reroute oc10 notifications endpoint to userlog service
 					Endpoint: "/ocs/v2.php/apps/notifications/api/v1/notifications",
@@ -269,6 +307,18 @@ func DefaultPolicies() []config.Policy {
 					Endpoint: "/auth-app/tokens",
 					Service:  "com.owncloud.web.auth-app",
 				},
+				{
+					Endpoint: "/api/v0/audit",
+					Service:  "com.owncloud.web.audit",
+				},
+				{
+					Endpoint: "/api/v0/postprocessing",
+					Service:  "com.owncloud.web.postprocessing",
+				},
+				{
+					Endpoint: "/api/v0/search",
+					Service:  "com.owncloud.web.search",
+				},
 			},
 		},
 	}
@@ -3,6 +3,7 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"net"
 
 	ociscfg "github.com/owncloud/ocis/v2/ocis-pkg/config"
 	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
@@ -53,6 +54,28 @@ func Validate(cfg *config.Config) error {
 		)
 	}
 
+	if cfg.RateLimit.Enabled {
+		for _, rule := range cfg.RateLimit.Rules {
+			switch rule.Key {
+			case config.RateLimitKeyIP, config.RateLimitKeyUser, config.RateLimitKeyPublicLink, config.RateLimitKeyRoute:
+			default:
+				return fmt.Errorf(
+					"Invalid value '%s' for the key of the rate limit rule '%s' in service %s. Possible values are: '%s', '%s', '%s' or '%s'.",
+					rule.Key, rule.Name, cfg.Service.Name,
+					config.RateLimitKeyIP, config.RateLimitKeyUser, config.RateLimitKeyPublicLink, config.RateLimitKeyRoute,
+				)
+			}
+			if rule.Requests <= 0 || rule.Interval <= 0 {
+				return fmt.Errorf("The rate limit rule '%s' in service %s needs a positive number of requests and interval.", rule.Name, cfg.Service.Name)
+			}
+		}
+		for _, cidr := range cfg.RateLimit.TrustedProxies {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("Invalid trusted proxy network '%s' of the rate limit in service %s: %w", cidr, cfg.Service.Name, err)
+			}
+		}
+	}
+
 	if cfg.ServiceAccount.ServiceAccountID == "" {
 		return shared.MissingServiceAccountID(cfg.Service.Name)
 	}
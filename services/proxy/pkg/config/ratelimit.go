@@ -0,0 +1,40 @@
+package config
+
+import "time"
+
+// Keys the rate limit rules can count the requests by
+const (
+	RateLimitKeyIP         = "ip"
+	RateLimitKeyUser       = "user"
+	RateLimitKeyPublicLink = "public_link"
+	RateLimitKeyRoute      = "route"
+)
+
+// RateLimit is the config for the rate limit middleware
+type RateLimit struct {
+	Enabled        bool            `yaml:"enabled" env:"PROXY_RATE_LIMIT_ENABLED" desc:"Enable the rate limiting of requests. Requests exceeding the limits of the rate limit rules are rejected with the status code '429 Too Many Requests'. See the text description for details." introductionVersion:"7.1"`
+	Rules          []RateLimitRule `yaml:"rules" desc:"The rate limit rules. This setting can only be configured in the configuration file and not via environment variables."`
+	TrustedProxies []string        `yaml:"trusted_proxies" env:"PROXY_RATE_LIMIT_TRUSTED_PROXIES" desc:"A list of networks in CIDR notation, like '10.0.0.0/8', of the reverse proxies in front of the proxy. The client addresses in the X-Forwarded-For and X-Real-IP headers are only used for the rate limit rules with the key 'ip' if the request was received from one of these networks. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	Store          *RateLimitStore `yaml:"store"`
+}
+
+// RateLimitRule limits the requests matching the paths and methods. The requests are counted by the key,
+// every value of the key has a token bucket of the size of requests which is refilled in the interval.
+type RateLimitRule struct {
+	Name       string        `yaml:"name" desc:"The name of the rule, it is used in the logs and metrics."`
+	Key        string        `yaml:"key" desc:"The key to count the requests by. Supported values are 'ip', 'user', 'public_link' and 'route'."`
+	Paths      []string      `yaml:"paths" desc:"The path prefixes of the requests the rule applies to. The rule applies to all paths when empty."`
+	Methods    []string      `yaml:"methods" desc:"The HTTP methods of the requests the rule applies to. The rule applies to all methods when empty."`
+	Requests   int           `yaml:"requests" desc:"The number of requests allowed in the interval. Bursts up to this number are allowed."`
+	Interval   time.Duration `yaml:"interval" desc:"The interval in which the number of requests is allowed."`
+	FailedOnly bool          `yaml:"failed_only" desc:"Only count requests which were rejected with '401 Unauthorized' to slow down password guessing."`
+}
+
+// RateLimitStore is the store configuration for the state of the rate limits.
+type RateLimitStore struct {
+	Store              string   `yaml:"store" env:"OCIS_CACHE_STORE;PROXY_RATE_LIMIT_STORE" desc:"The type of the rate limit store. Supported values are: 'memory' and 'nats-js-kv'. Only 'nats-js-kv' is shared when running multiple proxy instances. See the text description for details." introductionVersion:"7.1"`
+	Nodes              []string `yaml:"addresses" env:"OCIS_CACHE_STORE_NODES;PROXY_RATE_LIMIT_STORE_NODES" desc:"A list of nodes to access the configured store. This has no effect when 'memory' store is configured. Note that the behaviour how nodes are used is dependent on the library of the configured store. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	DisablePersistence bool     `yaml:"disable_persistence" env:"OCIS_CACHE_DISABLE_PERSISTENCE;PROXY_RATE_LIMIT_STORE_DISABLE_PERSISTENCE" desc:"Disables persistence of the store. Only applies when store type 'nats-js-kv' is configured. Defaults to true." introductionVersion:"7.1"`
+	AuthUsername       string   `yaml:"username" env:"OCIS_CACHE_AUTH_USERNAME;PROXY_RATE_LIMIT_STORE_AUTH_USERNAME" desc:"The username to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"7.1"`
+	AuthPassword       string   `yaml:"password" env:"OCIS_CACHE_AUTH_PASSWORD;PROXY_RATE_LIMIT_STORE_AUTH_PASSWORD" desc:"The password to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"7.1"`
+}
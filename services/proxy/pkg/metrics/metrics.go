@@ -14,10 +14,11 @@ var (
 
 // Metrics defines the available metrics of this service.
 type Metrics struct {
-	Requests  *prometheus.CounterVec
-	Errors    *prometheus.CounterVec
-	Duration  *prometheus.HistogramVec
-	BuildInfo *prometheus.GaugeVec
+	Requests    *prometheus.CounterVec
+	Errors      *prometheus.CounterVec
+	RateLimited *prometheus.CounterVec
+	Duration    *prometheus.HistogramVec
+	BuildInfo   *prometheus.GaugeVec
 }
 
 // New initializes the available metrics.
@@ -35,6 +36,12 @@ func New() *Metrics {
 			Name:      "errors_total",
 			Help:      "How many requests run into errors",
 		}, []string{"method"}),
+		RateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "rate_limited_requests_total",
+			Help:      "How many requests were rejected by the rate limit rules",
+		}, []string{"rule"}),
 		Duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: Namespace,
 			Subsystem: Subsystem,
@@ -55,6 +62,7 @@ func New() *Metrics {
 
 	_ = prometheus.Register(m.Requests)
 	_ = prometheus.Register(m.Errors)
+	_ = prometheus.Register(m.RateLimited)
 	_ = prometheus.Register(m.Duration)
 	_ = prometheus.Register(m.BuildInfo)
 	return m
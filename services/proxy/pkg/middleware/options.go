@@ -1,17 +1,20 @@
 package middleware
 
 import (
+	"net"
 	"net/http"
 	"time"
 
 	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
 	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/ocis-pkg/oidc"
 	policiessvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/policies/v0"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/config"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/metrics"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/user/backend"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/userroles"
 	"go-micro.dev/v4/store"
@@ -72,6 +75,14 @@ type Options struct {
 	// SkipUserInfo prevents the oidc middleware from querying the userinfo endpoint and read any claims directly from the access token instead
 	SkipUserInfo    bool
 	EventsPublisher events.Publisher
+	// RateLimitRules are the rules enforced by the rate limit middleware
+	RateLimitRules []config.RateLimitRule
+	// RateLimitStore keeps the state of the rate limits
+	RateLimitStore kv.Store
+	// TrustedProxies are the networks of the proxies whose forwarded client addresses are trusted
+	TrustedProxies []*net.IPNet
+	// Metrics to count the rejected requests
+	Metrics *metrics.Metrics
 }
 
 // newOptions initializes the available default options.
@@ -245,3 +256,31 @@ func EventsPublisher(ep events.Publisher) Option {
 		o.EventsPublisher = ep
 	}
 }
+
+// RateLimitRules sets the rate limit rules.
+func RateLimitRules(rules []config.RateLimitRule) Option {
+	return func(o *Options) {
+		o.RateLimitRules = rules
+	}
+}
+
+// RateLimitStore sets the store for the rate limits.
+func RateLimitStore(val kv.Store) Option {
+	return func(o *Options) {
+		o.RateLimitStore = val
+	}
+}
+
+// TrustedProxies sets the networks of the trusted proxies.
+func TrustedProxies(val []*net.IPNet) Option {
+	return func(o *Options) {
+		o.TrustedProxies = val
+	}
+}
+
+// Metrics sets the metrics.
+func Metrics(m *metrics.Metrics) Option {
+	return func(o *Options) {
+		o.Metrics = m
+	}
+}
@@ -109,6 +109,21 @@ func Policies(qs string, opts ...Option) func(next http.Handler) http.Handler {
 				resource.Name = sRes.GetInfo().GetName()
 			}
 
+			if id, ok := spacesResourceID(r.URL.Path); ok {
+				resource.Id = &pMessage.Resource_ID{
+					StorageId: id.GetStorageId(),
+					SpaceId:   id.GetSpaceId(),
+					OpaqueId:  id.GetOpaqueId(),
+				}
+				if id.GetSpaceId() == utils.ShareStorageSpaceID {
+					req.Environment.Share = &pMessage.Share{Type: "internal"}
+				}
+			}
+
+			if strings.HasPrefix(r.URL.Path, "/dav/public-files") || strings.HasPrefix(r.URL.Path, "/remote.php/dav/public-files") {
+				req.Environment.Share = &pMessage.Share{Type: "public"}
+			}
+
 			req.Environment.Resource = resource
 
 			if user, ok := revactx.ContextGetUser(r.Context()); ok {
@@ -140,6 +155,21 @@ func Policies(qs string, opts ...Option) func(next http.Handler) http.Handler {
 	}
 }
 
+// spacesResourceID returns the resource id of spaces webdav requests
+func spacesResourceID(p string) (*provider.ResourceId, bool) {
+	p = strings.TrimPrefix(p, "/remote.php")
+	if !strings.HasPrefix(p, "/dav/spaces/") {
+		return nil, false
+	}
+
+	ref, _, _ := strings.Cut(strings.TrimPrefix(p, "/dav/spaces/"), "/")
+	id, err := storagespace.ParseID(ref)
+	if err != nil || id.GetSpaceId() == "" {
+		return nil, false
+	}
+	return &id, true
+}
+
 // RenderError writes a Policies ErrorObject to the response writer
 func RenderError(w http.ResponseWriter, r *http.Request, evaluateReq *pService.EvaluateRequest, status int, msg string) {
 	filename := evaluateReq.Environment.GetResource().GetName()
@@ -140,6 +140,50 @@ func TestPolicies_EvaluationEnvironment_Resource(t *testing.T) {
 	}
 }
 
+func TestPolicies_EvaluationEnvironment_SpaceAndShare(t *testing.T) {
+	var g = NewWithT(t)
+
+	policiesMiddleware, policiesProviderService, _ := prepare("any")
+
+	// space
+	{
+		policiesProviderService.On("Evaluate", mock.Anything, mock.Anything, mock.Anything).Return(
+			func(_ context.Context, in *policiesPG.EvaluateRequest, _ ...client.CallOption) (*policiesPG.EvaluateResponse, error) {
+				g.Expect(in.Environment.Resource.Id.StorageId).To(Equal("storage"))
+				g.Expect(in.Environment.Resource.Id.SpaceId).To(Equal("space"))
+				g.Expect(in.Environment.Share).To(BeNil())
+
+				return &policiesPG.EvaluateResponse{Result: false}, nil
+			},
+		).Once()
+		policiesMiddleware.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/remote.php/dav/spaces/storage$space/file.png", nil))
+	}
+
+	// received share
+	{
+		policiesProviderService.On("Evaluate", mock.Anything, mock.Anything, mock.Anything).Return(
+			func(_ context.Context, in *policiesPG.EvaluateRequest, _ ...client.CallOption) (*policiesPG.EvaluateResponse, error) {
+				g.Expect(in.Environment.Share.Type).To(Equal("internal"))
+
+				return &policiesPG.EvaluateResponse{Result: false}, nil
+			},
+		).Once()
+		policiesMiddleware.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/dav/spaces/a0ca6a90-a365-4782-871e-d44447bbc668$a0ca6a90-a365-4782-871e-d44447bbc668!share/file.png", nil))
+	}
+
+	// public link
+	{
+		policiesProviderService.On("Evaluate", mock.Anything, mock.Anything, mock.Anything).Return(
+			func(_ context.Context, in *policiesPG.EvaluateRequest, _ ...client.CallOption) (*policiesPG.EvaluateResponse, error) {
+				g.Expect(in.Environment.Share.Type).To(Equal("public"))
+
+				return &policiesPG.EvaluateResponse{Result: false}, nil
+			},
+		).Once()
+		policiesMiddleware.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/remote.php/dav/public-files/token/file.png", nil))
+	}
+}
+
 func prepare(q string) (http.Handler, *mocks.PoliciesProviderService, *cs3mocks.GatewayAPIClient) {
 
 	// mocked gatewaySelector
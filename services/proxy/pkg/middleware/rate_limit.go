@@ -0,0 +1,281 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/config"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/metrics"
+)
+
+// errRateLimited aborts the update of an empty bucket
+var errRateLimited = errors.New("rate limited")
+
+// peerAddrKey is the context key of the address of the peer the request was received from
+type peerAddrKey struct{}
+
+// PeerAddr provides a middleware which keeps the address of the peer the request was received from,
+// it has to be added before middlewares like RealIP which replace the remote address of the request.
+func PeerAddr(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), peerAddrKey{}, req.RemoteAddr)))
+	})
+}
+
+// RateLimit provides a middleware which rejects requests exceeding the configured rate limit rules
+// with "429 Too Many Requests". Every rule has a token bucket per value of its key, the buckets are
+// kept in the store so that they are shared by all proxy instances. The buckets are updated atomically.
+//
+// Rules with the key "user" need the authenticated user, the middleware has to be added after the
+// authentication to enforce them. All other rules are enforced before the authentication.
+func RateLimit(optionSetters ...Option) func(next http.Handler) http.Handler {
+	options := newOptions(optionSetters...)
+
+	return func(next http.Handler) http.Handler {
+		return &rateLimit{
+			next:           next,
+			logger:         options.Logger,
+			rules:          options.RateLimitRules,
+			store:          options.RateLimitStore,
+			trustedProxies: options.TrustedProxies,
+			metrics:        options.Metrics,
+			now:            time.Now,
+		}
+	}
+}
+
+type rateLimit struct {
+	next   http.Handler
+	logger log.Logger
+	rules  []config.RateLimitRule
+	store  kv.Store
+	// trustedProxies are the networks of the proxies whose forwarded client addresses are used
+	trustedProxies []*net.IPNet
+	metrics        *metrics.Metrics
+	now            func() time.Time
+}
+
+// bucket is the state of a token bucket in the store
+type bucket struct {
+	Tokens  float64   `json:"tokens"`
+	Updated time.Time `json:"updated"`
+}
+
+func (m *rateLimit) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	type match struct {
+		rule config.RateLimitRule
+		key  string
+	}
+
+	var failedOnly []match
+	for _, rule := range m.rules {
+		key, ok := m.key(rule, req)
+		if !ok {
+			continue
+		}
+
+		allowed, retryAfter := m.take(rule, key, !rule.FailedOnly)
+		if !allowed {
+			m.reject(w, req, rule, retryAfter)
+			return
+		}
+		if rule.FailedOnly {
+			failedOnly = append(failedOnly, match{rule: rule, key: key})
+		}
+	}
+
+	if len(failedOnly) == 0 {
+		m.next.ServeHTTP(w, req)
+		return
+	}
+
+	ww := middleware.NewWrapResponseWriter(w, req.ProtoMajor)
+	m.next.ServeHTTP(ww, req)
+	if ww.Status() == http.StatusUnauthorized {
+		for _, f := range failedOnly {
+			m.take(f.rule, f.key, true)
+		}
+	}
+}
+
+func (m *rateLimit) reject(w http.ResponseWriter, req *http.Request, rule config.RateLimitRule, retryAfter time.Duration) {
+	m.logger.Debug().Str("rule", rule.Name).Str("path", req.URL.Path).Msg("rate limit exceeded")
+	if m.metrics != nil {
+		m.metrics.RateLimited.WithLabelValues(rule.Name).Inc()
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	w.WriteHeader(http.StatusTooManyRequests)
+}
+
+// key returns the key the requests are counted by for the rule or false if the rule doesn't apply to the request
+func (m *rateLimit) key(rule config.RateLimitRule, req *http.Request) (string, bool) {
+	if len(rule.Methods) > 0 && !slices.Contains(rule.Methods, req.Method) {
+		return "", false
+	}
+
+	route := ""
+	if len(rule.Paths) > 0 {
+		i := slices.IndexFunc(rule.Paths, func(p string) bool {
+			return strings.HasPrefix(req.URL.Path, p)
+		})
+		if i < 0 {
+			return "", false
+		}
+		route = rule.Paths[i]
+	}
+
+	switch rule.Key {
+	case config.RateLimitKeyIP:
+		ip := m.clientIP(req)
+		return ip, ip != ""
+	case config.RateLimitKeyUser:
+		u, ok := revactx.ContextGetUser(req.Context())
+		if !ok || u.GetId().GetOpaqueId() == "" {
+			return "", false
+		}
+		return u.GetId().GetOpaqueId(), true
+	case config.RateLimitKeyPublicLink:
+		token := publicLinkToken(req)
+		return token, token != ""
+	case config.RateLimitKeyRoute:
+		return route, true
+	default:
+		return "", false
+	}
+}
+
+// publicLinkToken returns the public link token of the request from the header, the query or the path
+func publicLinkToken(req *http.Request) string {
+	if token := req.Header.Get(headerShareToken); token != "" {
+		return token
+	}
+	if token := req.URL.Query().Get(headerShareToken); token != "" {
+		return token
+	}
+	for _, prefix := range []string{"/dav/public-files/", "/remote.php/dav/public-files/"} {
+		if rest, ok := strings.CutPrefix(req.URL.Path, prefix); ok {
+			token, _, _ := strings.Cut(rest, "/")
+			return token
+		}
+	}
+	return ""
+}
+
+// clientIP returns the address of the client. The addresses in the X-Forwarded-For and X-Real-IP headers
+// are only used if the request was received from a trusted proxy, the forwarded addresses are followed
+// from the right to the first address which is not a trusted proxy.
+func (m *rateLimit) clientIP(req *http.Request) string {
+	addr, ok := req.Context().Value(peerAddrKey{}).(string)
+	if !ok {
+		addr = req.RemoteAddr
+	}
+	ip, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		ip = addr
+	}
+	if !m.trusted(ip) {
+		return ip
+	}
+
+	forwarded := strings.Split(req.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		fip := strings.TrimSpace(forwarded[i])
+		if net.ParseIP(fip) == nil {
+			break
+		}
+		ip = fip
+		if !m.trusted(ip) {
+			return ip
+		}
+	}
+	if realIP := strings.TrimSpace(req.Header.Get("X-Real-IP")); net.ParseIP(realIP) != nil {
+		return realIP
+	}
+	return ip
+}
+
+func (m *rateLimit) trusted(ip string) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && slices.ContainsFunc(m.trustedProxies, func(n *net.IPNet) bool {
+		return n.Contains(parsed)
+	})
+}
+
+// take refills the bucket of the key and takes a token from it if consume is set. It returns false and the
+// time until the next token is available if the bucket is empty.
+func (m *rateLimit) take(rule config.RateLimitRule, key string, consume bool) (bool, time.Duration) {
+	now := m.now()
+	capacity := float64(rule.Requests)
+	rate := capacity / rule.Interval.Seconds()
+	recordKey := bucketKey(rule, key)
+
+	var retryAfter time.Duration
+	refill := func(value []byte) (bucket, error) {
+		b := bucket{Tokens: capacity, Updated: now}
+		if value != nil {
+			if err := json.Unmarshal(value, &b); err != nil {
+				m.logger.Error().Err(err).Str("rule", rule.Name).Msg("could not decode the rate limit bucket")
+				b = bucket{Tokens: capacity, Updated: now}
+			}
+		}
+
+		if elapsed := now.Sub(b.Updated).Seconds(); elapsed > 0 {
+			b.Tokens = math.Min(capacity, b.Tokens+elapsed*rate)
+		}
+		b.Updated = now
+
+		if b.Tokens < 1 {
+			retryAfter = time.Duration((1 - b.Tokens) / rate * float64(time.Second))
+			return b, errRateLimited
+		}
+		return b, nil
+	}
+
+	var err error
+	if consume {
+		_, err = m.store.Update(recordKey, func(value []byte) ([]byte, error) {
+			b, err := refill(value)
+			if err != nil {
+				return nil, err
+			}
+			b.Tokens--
+			return json.Marshal(b)
+		})
+	} else {
+		var value []byte
+		if value, err = m.store.Get(recordKey); err == nil {
+			_, err = refill(value)
+		}
+	}
+
+	switch {
+	case errors.Is(err, errRateLimited):
+		return false, retryAfter
+	case err != nil:
+		// don't block the requests when the store is not available
+		m.logger.Error().Err(err).Str("rule", rule.Name).Msg("could not update the rate limit bucket")
+	}
+	return true, 0
+}
+
+// bucketKey hashes the key, the keys contain secrets like public link tokens and characters which
+// are not supported by all stores
+func bucketKey(rule config.RateLimitRule, key string) string {
+	sum := sha256.Sum256([]byte(rule.Name + "\x00" + key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/config"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/config/defaults"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/metrics"
+)
+
+var _ = Describe("Rate limiting requests", Label("RateLimit"), func() {
+	var (
+		now     time.Time
+		status  int
+		m       *metrics.Metrics
+		trusted []*net.IPNet
+		limiter func(rules ...config.RateLimitRule) http.Handler
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		status = http.StatusOK
+		m = &metrics.Metrics{
+			RateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "rate_limited"}, []string{"rule"}),
+		}
+		trusted = nil
+		s := kv.New(kv.Options{Type: "memory"})
+		limiter = func(rules ...config.RateLimitRule) http.Handler {
+			return &rateLimit{
+				next: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(status)
+				}),
+				logger:         log.NopLogger(),
+				rules:          rules,
+				store:          s,
+				trustedProxies: trusted,
+				metrics:        m,
+				now:            func() time.Time { return now },
+			}
+		}
+	})
+
+	serve := func(h http.Handler, req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	request := func(remoteAddr, path string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com"+path, http.NoBody)
+		req.RemoteAddr = remoteAddr
+		return req
+	}
+
+	rejected := func(rule string) float64 {
+		var metric dto.Metric
+		Expect(m.RateLimited.WithLabelValues(rule).Write(&metric)).To(Succeed())
+		return metric.GetCounter().GetValue()
+	}
+
+	It("rejects requests exceeding the limit until the bucket is refilled", func() {
+		h := limiter(config.RateLimitRule{Name: "ip", Key: config.RateLimitKeyIP, Requests: 2, Interval: time.Minute})
+
+		Expect(serve(h, request("10.0.0.1:1234", "/")).Code).To(Equal(http.StatusOK))
+		Expect(serve(h, request("10.0.0.1:1235", "/")).Code).To(Equal(http.StatusOK))
+
+		rec := serve(h, request("10.0.0.1:1236", "/"))
+		Expect(rec.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(rec.Header().Get("Retry-After")).To(Equal("30"))
+		Expect(rejected("ip")).To(Equal(1.0))
+
+		// other clients have their own bucket
+		Expect(serve(h, request("10.0.0.2:1234", "/")).Code).To(Equal(http.StatusOK))
+
+		now = now.Add(30 * time.Second)
+		Expect(serve(h, request("10.0.0.1:1234", "/")).Code).To(Equal(http.StatusOK))
+		Expect(serve(h, request("10.0.0.1:1234", "/")).Code).To(Equal(http.StatusTooManyRequests))
+	})
+
+	It("only applies rules to the matching paths and methods", func() {
+		h := limiter(config.RateLimitRule{Name: "route", Key: config.RateLimitKeyRoute, Paths: []string{"/api/"}, Methods: []string{http.MethodGet}, Requests: 1, Interval: time.Minute})
+
+		Expect(serve(h, request("10.0.0.1:1234", "/api/a")).Code).To(Equal(http.StatusOK))
+		Expect(serve(h, request("10.0.0.2:1234", "/api/b")).Code).To(Equal(http.StatusTooManyRequests))
+		Expect(serve(h, request("10.0.0.1:1234", "/other")).Code).To(Equal(http.StatusOK))
+
+		req := request("10.0.0.1:1234", "/api/a")
+		req.Method = http.MethodPost
+		Expect(serve(h, req).Code).To(Equal(http.StatusOK))
+	})
+
+	It("counts public link requests by token", func() {
+		h := limiter(config.RateLimitRule{Name: "public-links", Key: config.RateLimitKeyPublicLink, Requests: 1, Interval: time.Minute})
+
+		Expect(serve(h, request("10.0.0.1:1234", "/dav/public-files/token1/file.txt")).Code).To(Equal(http.StatusOK))
+		Expect(serve(h, request("10.0.0.2:1234", "/remote.php/dav/public-files/token1/")).Code).To(Equal(http.StatusTooManyRequests))
+		Expect(serve(h, request("10.0.0.1:1234", "/archiver?public-token=token2")).Code).To(Equal(http.StatusOK))
+
+		// requests without a token are not limited
+		Expect(serve(h, request("10.0.0.1:1234", "/")).Code).To(Equal(http.StatusOK))
+		Expect(serve(h, request("10.0.0.1:1234", "/")).Code).To(Equal(http.StatusOK))
+	})
+
+	It("slows down guessing public link passwords with the default rules", func() {
+		h := limiter(defaults.DefaultRateLimitRules()...)
+		link := "/remote.php/dav/public-files/token1/"
+
+		status = http.StatusUnauthorized
+		for i := 0; i < 10; i++ {
+			Expect(serve(h, request("10.0.0.1:1234", link)).Code).To(Equal(http.StatusUnauthorized))
+		}
+		Expect(serve(h, request("10.0.0.2:1234", link)).Code).To(Equal(http.StatusTooManyRequests))
+		Expect(rejected("public-link-passwords")).To(Equal(1.0))
+
+		// other links are not affected
+		status = http.StatusOK
+		Expect(serve(h, request("10.0.0.1:1234", "/dav/public-files/token2/")).Code).To(Equal(http.StatusOK))
+	})
+
+	It("counts requests by user", func() {
+		h := limiter(config.RateLimitRule{Name: "user", Key: config.RateLimitKeyUser, Requests: 1, Interval: time.Minute})
+		withUser := func(id string) *http.Request {
+			req := request("10.0.0.1:1234", "/")
+			ctx := revactx.ContextSetUser(context.Background(), &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: id}})
+			return req.WithContext(ctx)
+		}
+
+		Expect(serve(h, withUser("einstein")).Code).To(Equal(http.StatusOK))
+		Expect(serve(h, withUser("einstein")).Code).To(Equal(http.StatusTooManyRequests))
+		Expect(serve(h, withUser("marie")).Code).To(Equal(http.StatusOK))
+	})
+
+	It("only counts failed requests for failed only rules", func() {
+		h := limiter(config.RateLimitRule{Name: "failed", Key: config.RateLimitKeyIP, Requests: 2, Interval: time.Minute, FailedOnly: true})
+
+		Expect(serve(h, request("10.0.0.1:1234", "/")).Code).To(Equal(http.StatusOK))
+		Expect(serve(h, request("10.0.0.1:1234", "/")).Code).To(Equal(http.StatusOK))
+		Expect(serve(h, request("10.0.0.1:1234", "/")).Code).To(Equal(http.StatusOK))
+
+		status = http.StatusUnauthorized
+		Expect(serve(h, request("10.0.0.1:1234", "/")).Code).To(Equal(http.StatusUnauthorized))
+		Expect(serve(h, request("10.0.0.1:1234", "/")).Code).To(Equal(http.StatusUnauthorized))
+		Expect(serve(h, request("10.0.0.1:1234", "/")).Code).To(Equal(http.StatusTooManyRequests))
+
+		// correct credentials are rejected as well until the bucket is refilled
+		status = http.StatusOK
+		Expect(serve(h, request("10.0.0.1:1234", "/")).Code).To(Equal(http.StatusTooManyRequests))
+		Expect(rejected("failed")).To(Equal(2.0))
+	})
+
+	Describe("forwarded client addresses", func() {
+		var h http.Handler
+
+		forwarded := func(peer, forwardedFor string) *http.Request {
+			req := request(peer, "/")
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+			return req
+		}
+
+		BeforeEach(func() {
+			_, n, err := net.ParseCIDR("192.168.0.0/16")
+			Expect(err).ToNot(HaveOccurred())
+			trusted = []*net.IPNet{n}
+			h = limiter(config.RateLimitRule{Name: "ip", Key: config.RateLimitKeyIP, Requests: 1, Interval: time.Minute})
+		})
+
+		It("ignores the headers of untrusted peers", func() {
+			Expect(serve(h, forwarded("10.0.0.1:1234", "10.1.0.1")).Code).To(Equal(http.StatusOK))
+			Expect(serve(h, forwarded("10.0.0.1:1234", "10.1.0.2")).Code).To(Equal(http.StatusTooManyRequests))
+		})
+
+		It("uses the first untrusted address forwarded by trusted proxies", func() {
+			Expect(serve(h, forwarded("192.168.0.1:1234", "10.1.0.1, 192.168.0.2")).Code).To(Equal(http.StatusOK))
+			Expect(serve(h, forwarded("192.168.0.1:1234", "10.1.0.2")).Code).To(Equal(http.StatusOK))
+			// a spoofed address in front of the client address is ignored
+			Expect(serve(h, forwarded("192.168.0.1:1234", "10.9.9.9, 10.1.0.1")).Code).To(Equal(http.StatusTooManyRequests))
+
+			req := request("192.168.0.1:1234", "/")
+			req.Header.Set("X-Real-IP", "10.1.0.3")
+			Expect(serve(h, req).Code).To(Equal(http.StatusOK))
+		})
+
+		It("uses the peer address kept before it was replaced", func() {
+			realIP := func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					req.RemoteAddr = req.Header.Get("X-Forwarded-For")
+					next.ServeHTTP(w, req)
+				})
+			}
+			h = PeerAddr(realIP(h))
+
+			Expect(serve(h, forwarded("10.0.0.1:1234", "10.1.0.1")).Code).To(Equal(http.StatusOK))
+			Expect(serve(h, forwarded("10.0.0.1:1234", "10.1.0.2")).Code).To(Equal(http.StatusTooManyRequests))
+		})
+	})
+})
@@ -165,3 +165,23 @@ func TestRouter(t *testing.T) {
 		}
 	}
 }
+
+func TestDefaultPoliciesRouteTheSearchAPI(t *testing.T) {
+	cfg := defaults.DefaultConfig()
+	cfg.Policies = defaults.DefaultPolicies()
+	reg := registry.GetRegistry()
+	sel := selector.NewSelector(selector.Registry(reg))
+	rt := New(sel, cfg.PolicySelector, cfg.Policies, log.NewLogger())
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v0/search/saved-search-run", nil)
+	routingInfo, ok := rt.Route(r)
+	if !ok {
+		t.Fatal("the search api is not routed")
+	}
+	if routingInfo.endpoint != "/api/v0/search" {
+		t.Errorf("the search api is routed to %s", routingInfo.endpoint)
+	}
+	if routingInfo.IsRouteUnprotected() {
+		t.Error("the search api must be protected")
+	}
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/events/stream"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"github.com/urfave/cli/v2"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+	"github.com/owncloud/ocis/v2/services/search/pkg/config/parser"
+	"github.com/owncloud/ocis/v2/services/search/pkg/event"
+)
+
+// Reconcile is the entrypoint for the reconcile command.
+func Reconcile(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:     "reconcile",
+		Usage:    "reconcile the index with the storage, only resources which drifted are reindexed. The command only triggers the reconciliation in the running search service, which logs the report.",
+		Category: "index management",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "space",
+				Aliases: []string{"s"},
+				Usage:   "the id of the space to reconcile. This or --all-spaces is required.",
+			},
+			&cli.BoolFlag{
+				Name:  "all-spaces",
+				Usage: "reconcile all spaces instead. This or --space is required.",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "only report the drift without changing the index. The report is logged by the search service, not printed by this command.",
+			},
+		},
+		Before: func(_ *cli.Context) error {
+			return configlog.ReturnFatal(parser.ParseConfig(cfg))
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.String("space") == "" && !ctx.Bool("all-spaces") {
+				return errors.New("either --space or --all-spaces is required")
+			}
+
+			bus, err := stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig{
+				Endpoint:             cfg.Events.Endpoint,
+				Cluster:              cfg.Events.Cluster,
+				EnableTLS:            cfg.Events.EnableTLS,
+				TLSInsecure:          cfg.Events.TLSInsecure,
+				TLSRootCACertificate: cfg.Events.TLSRootCACertificate,
+				AuthUsername:         cfg.Events.AuthUsername,
+				AuthPassword:         cfg.Events.AuthPassword,
+			})
+			if err != nil {
+				return err
+			}
+
+			return events.Publish(context.Background(), bus, event.ReconcileIndex{
+				SpaceID:   ctx.String("space"),
+				DryRun:    ctx.Bool("dry-run"),
+				Timestamp: utils.TSNow(),
+			})
+		},
+	}
+}
@@ -15,6 +15,8 @@ import (
 	"github.com/owncloud/ocis/v2/services/search/pkg/metrics"
 	"github.com/owncloud/ocis/v2/services/search/pkg/server/debug"
 	"github.com/owncloud/ocis/v2/services/search/pkg/server/grpc"
+	"github.com/owncloud/ocis/v2/services/search/pkg/server/http"
+	svc "github.com/owncloud/ocis/v2/services/search/pkg/service/grpc/v0"
 	"github.com/urfave/cli/v2"
 )
 
@@ -47,16 +49,28 @@ func Server(cfg *config.Config) *cli.Command {
 			mtrcs := metrics.New()
 			mtrcs.BuildInfo.WithLabelValues(version.GetString()).Set(1)
 
-			grpcServer, teardown, err := grpc.Server(
+			// the grpc and the http server share the handler
+			handle, teardown, err := svc.NewHandler(
+				svc.Config(cfg),
+				svc.Logger(logger),
+				svc.JWTSecret(cfg.TokenManager.JWTSecret),
+				svc.TracerProvider(traceProvider),
+			)
+			defer teardown()
+			if err != nil {
+				logger.Error().Err(err).Msg("Error initializing search service")
+				return err
+			}
+
+			grpcServer, err := grpc.Server(
 				grpc.Config(cfg),
 				grpc.Logger(logger),
 				grpc.Name(cfg.Service.Name),
 				grpc.Context(ctx),
 				grpc.Metrics(mtrcs),
-				grpc.JWTSecret(cfg.TokenManager.JWTSecret),
+				grpc.Handler(handle),
 				grpc.TraceProvider(traceProvider),
 			)
-			defer teardown()
 			if err != nil {
 				logger.Info().Err(err).Str("transport", "grpc").Msg("Failed to initialize server")
 				return err
@@ -78,6 +92,22 @@ func Server(cfg *config.Config) *cli.Command {
 				cancel()
 			})
 
+			httpServer, err := http.Server(
+				http.Logger(logger),
+				http.Context(ctx),
+				http.Config(cfg),
+				http.Handler(handle),
+				http.TraceProvider(traceProvider),
+			)
+			if err != nil {
+				logger.Info().Err(err).Str("transport", "http").Msg("Failed to initialize server")
+				return err
+			}
+
+			gr.Add(httpServer.Run, func(_ error) {
+				cancel()
+			})
+
 			debugServer, err := debug.Server(
 				debug.Logger(logger),
 				debug.Context(ctx),
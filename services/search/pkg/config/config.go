@@ -19,6 +19,7 @@ type Config struct {
 
 	GRPC       GRPCConfig    `yaml:"grpc"`
 	GrpcClient client.Client `yaml:"-"`
+	HTTP       HTTP          `yaml:"http"`
 
 	TokenManager *TokenManager `yaml:"token_manager"`
 
@@ -27,9 +28,14 @@ type Config struct {
 	Events                     Events                `yaml:"events"`
 	Engine                     Engine                `yaml:"engine"`
 	Extractor                  Extractor             `yaml:"extractor"`
+	Embedding                  Embedding             `yaml:"embedding"`
+	Reconcile                  Reconcile             `yaml:"reconcile"`
+	SavedSearches              SavedSearches         `yaml:"saved_searches"`
+	Store                      Store                 `yaml:"store"`
 	ContentExtractionSizeLimit uint64                `yaml:"content_extraction_size_limit" env:"SEARCH_CONTENT_EXTRACTION_SIZE_LIMIT" desc:"Maximum file size in bytes that is allowed for content extraction." introductionVersion:"pre5.0"`
 
-	ServiceAccount ServiceAccount `yaml:"service_account"`
+	ServiceAccount    ServiceAccount `yaml:"service_account"`
+	MachineAuthAPIKey string         `yaml:"machine_auth_api_key" env:"OCIS_MACHINE_AUTH_API_KEY;SEARCH_MACHINE_AUTH_API_KEY" desc:"The machine auth API key used to run the saved searches with enabled notifications as their owner. The notifications are disabled if no key is set." introductionVersion:"7.1" mask:"password"`
 
 	Context context.Context `yaml:"-"`
 }
@@ -1,10 +1,13 @@
 package config
 
+import "time"
+
 // Extractor defines which extractor to use
 type Extractor struct {
-	Type             string        `yaml:"type" env:"SEARCH_EXTRACTOR_TYPE" desc:"Defines the content extraction engine. Defaults to 'basic'. Supported values are: 'basic' and 'tika'." introductionVersion:"pre5.0"`
-	CS3AllowInsecure bool          `yaml:"cs3_allow_insecure" env:"OCIS_INSECURE;SEARCH_EXTRACTOR_CS3SOURCE_INSECURE" desc:"Ignore untrusted SSL certificates when connecting to the CS3 source." introductionVersion:"pre5.0"`
-	Tika             ExtractorTika `yaml:"tika"`
+	Type             string          `yaml:"type" env:"SEARCH_EXTRACTOR_TYPE" desc:"Defines the content extraction engine. Defaults to 'basic'. Supported values are: 'basic', 'tika' and 'native'. See the documentation for more details." introductionVersion:"pre5.0"`
+	CS3AllowInsecure bool            `yaml:"cs3_allow_insecure" env:"OCIS_INSECURE;SEARCH_EXTRACTOR_CS3SOURCE_INSECURE" desc:"Ignore untrusted SSL certificates when connecting to the CS3 source." introductionVersion:"pre5.0"`
+	Tika             ExtractorTika   `yaml:"tika"`
+	Native           ExtractorNative `yaml:"native"`
 }
 
 // ExtractorTika configures the Tika extractor
@@ -12,3 +15,9 @@ type ExtractorTika struct {
 	TikaURL        string `yaml:"tika_url" env:"SEARCH_EXTRACTOR_TIKA_TIKA_URL" desc:"URL of the tika server." introductionVersion:"pre5.0"`
 	CleanStopWords bool   `yaml:"clean_stop_words" env:"SEARCH_EXTRACTOR_TIKA_CLEAN_STOP_WORDS" desc:"Defines if stop words should be cleaned or not. See the documentation for more details." introductionVersion:"5.0"`
 }
+
+// ExtractorNative configures the native extractor
+type ExtractorNative struct {
+	Timeout          time.Duration `yaml:"timeout" env:"SEARCH_EXTRACTOR_NATIVE_TIMEOUT" desc:"The maximum time to extract the content of a single resource. Resources which take longer are indexed without their content. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	ContentSizeLimit uint64        `yaml:"content_size_limit" env:"SEARCH_EXTRACTOR_NATIVE_CONTENT_SIZE_LIMIT" desc:"The maximum number of bytes of text which are extracted from a single resource. Longer content is truncated." introductionVersion:"7.1"`
+}
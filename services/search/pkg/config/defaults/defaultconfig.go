@@ -2,6 +2,8 @@ package defaults
 
 import (
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/defaults"
 	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
@@ -29,6 +31,11 @@ func DefaultConfig() *config.Config {
 			Addr:      "127.0.0.1:9220",
 			Namespace: "com.owncloud.api",
 		},
+		HTTP: config.HTTP{
+			Addr:      "127.0.0.1:9221",
+			Root:      "/",
+			Namespace: "com.owncloud.web",
+		},
 		Service: config.Service{
 			Name: "search",
 		},
@@ -38,6 +45,20 @@ func DefaultConfig() *config.Config {
 			Bleve: config.EngineBleve{
 				Datapath: filepath.Join(defaults.BaseDataPath(), "search"),
 			},
+			OpenSearch: config.EngineOpenSearch{
+				Address: "http://127.0.0.1:9200",
+				Index:   "ocis-resources",
+				Timeout: 30 * time.Second,
+			},
+		},
+		Embedding: config.Embedding{
+			Type:           "none",
+			Dimension:      384,
+			MaxInputLength: 8000,
+			MaxCandidates:  10000,
+			HTTP: config.EmbeddingHTTP{
+				Timeout: 30 * time.Second,
+			},
 		},
 		Extractor: config.Extractor{
 			Type:             "basic",
@@ -46,6 +67,10 @@ func DefaultConfig() *config.Config {
 				TikaURL:        "http://127.0.0.1:9998",
 				CleanStopWords: true,
 			},
+			Native: config.ExtractorNative{
+				Timeout:          30 * time.Second,
+				ContentSizeLimit: 1024 * 1024, // Limit the extracted text to 1MB by default
+			},
 		},
 		Events: config.Events{
 			Endpoint:         "127.0.0.1:9233",
@@ -54,6 +79,15 @@ func DefaultConfig() *config.Config {
 			AsyncUploads:     true,
 			EnableTLS:        false,
 		},
+		SavedSearches: config.SavedSearches{
+			NotifyInterval: time.Hour,
+			MaxPerUser:     50,
+		},
+		Store: config.Store{
+			Store:    "nats-js-kv",
+			Nodes:    []string{"127.0.0.1:9233"},
+			Database: "search",
+		},
 		ContentExtractionSizeLimit: 20 * 1024 * 1024, // Limit content extraction to <20MB files by default
 	}
 }
@@ -91,6 +125,10 @@ func EnsureDefaults(cfg *config.Config) {
 		cfg.TokenManager = &config.TokenManager{}
 	}
 
+	if cfg.MachineAuthAPIKey == "" && cfg.Commons != nil && cfg.Commons.MachineAuthAPIKey != "" {
+		cfg.MachineAuthAPIKey = cfg.Commons.MachineAuthAPIKey
+	}
+
 	if cfg.Reva == nil && cfg.Commons != nil {
 		cfg.Reva = structs.CopyOrZeroValue(cfg.Commons.Reva)
 	}
@@ -100,9 +138,14 @@ func EnsureDefaults(cfg *config.Config) {
 	if cfg.GRPC.TLS == nil && cfg.Commons != nil {
 		cfg.GRPC.TLS = structs.CopyOrZeroValue(cfg.Commons.GRPCServiceTLS)
 	}
+	if cfg.Commons != nil {
+		cfg.HTTP.TLS = cfg.Commons.HTTPServiceTLS
+	}
 }
 
 // Sanitize sanitizes the configuration
 func Sanitize(cfg *config.Config) {
-	// no http endpoint to be sanitized
+	if cfg.HTTP.Root != "/" {
+		cfg.HTTP.Root = strings.TrimSuffix(cfg.HTTP.Root, "/")
+	}
 }
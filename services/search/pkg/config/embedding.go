@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+// Embedding defines how the embeddings for the similarity search are computed
+type Embedding struct {
+	Type           string        `yaml:"type" env:"SEARCH_EMBEDDING_TYPE" desc:"Defines how the embeddings of the resources are computed for the similarity search. Defaults to 'none' which disables the similarity search. Supported values are: 'none', 'http' and 'hashing'. See the documentation for more details." introductionVersion:"7.1"`
+	Dimension      int           `yaml:"dimension" env:"SEARCH_EMBEDDING_DIMENSION" desc:"The number of dimensions of the embeddings. It must match the model used by the embedding endpoint." introductionVersion:"7.1"`
+	MaxInputLength int           `yaml:"max_input_length" env:"SEARCH_EMBEDDING_MAX_INPUT_LENGTH" desc:"The maximum number of characters of the name, title and content of a resource which are used to compute its embedding. Longer content is truncated." introductionVersion:"7.1"`
+	MinScore       float64       `yaml:"min_score" env:"SEARCH_EMBEDDING_MIN_SCORE" desc:"The minimum score between 0 and 1 a resource needs to be returned by a similarity search. Defaults to 0 which returns all resources ordered by their similarity." introductionVersion:"7.1"`
+	MaxCandidates  int           `yaml:"max_candidates" env:"SEARCH_EMBEDDING_MAX_CANDIDATES" desc:"The maximum number of resources whose embeddings are compared with the search text by the 'bleve' engine. The most recently modified resources matching the other terms of the query are compared, older ones are not found. Has no effect on the 'opensearch' engine." introductionVersion:"7.1"`
+	HTTP           EmbeddingHTTP `yaml:"http"`
+}
+
+// EmbeddingHTTP configures the http embedding endpoint
+type EmbeddingHTTP struct {
+	URL     string        `yaml:"url" env:"SEARCH_EMBEDDING_HTTP_URL" desc:"The URL of an OpenAI compatible embeddings endpoint, e.g. 'http://localhost:11434/v1/embeddings'." introductionVersion:"7.1"`
+	Model   string        `yaml:"model" env:"SEARCH_EMBEDDING_HTTP_MODEL" desc:"The name of the model which computes the embeddings." introductionVersion:"7.1"`
+	APIKey  string        `yaml:"api_key" env:"SEARCH_EMBEDDING_HTTP_API_KEY" desc:"The API key which is sent as bearer token to the embeddings endpoint. Leave empty if no authentication is required." introductionVersion:"7.1"`
+	Timeout time.Duration `yaml:"timeout" env:"SEARCH_EMBEDDING_HTTP_TIMEOUT" desc:"The timeout for requests to the embeddings endpoint. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+}
@@ -1,12 +1,25 @@
 package config
 
+import "time"
+
 // Engine defines which search engine to use
 type Engine struct {
-	Type  string      `yaml:"type" env:"SEARCH_ENGINE_TYPE" desc:"Defines which search engine to use. Defaults to 'bleve'. Supported values are: 'bleve'." introductionVersion:"pre5.0"`
-	Bleve EngineBleve `yaml:"bleve"`
+	Type       string           `yaml:"type" env:"SEARCH_ENGINE_TYPE" desc:"Defines which search engine to use. Defaults to 'bleve'. Supported values are: 'bleve' and 'opensearch'." introductionVersion:"pre5.0"`
+	Bleve      EngineBleve      `yaml:"bleve"`
+	OpenSearch EngineOpenSearch `yaml:"opensearch"`
 }
 
 // EngineBleve configures the bleve engine
 type EngineBleve struct {
 	Datapath string `yaml:"data_path" env:"SEARCH_ENGINE_BLEVE_DATA_PATH" desc:"The directory where the filesystem will store search data. If not defined, the root directory derives from $OCIS_BASE_DATA_PATH/search." introductionVersion:"pre5.0"`
 }
+
+// EngineOpenSearch configures the opensearch engine
+type EngineOpenSearch struct {
+	Address  string        `yaml:"address" env:"SEARCH_ENGINE_OPENSEARCH_ADDRESS" desc:"The URL of the OpenSearch or Elasticsearch cluster, e.g. 'https://opensearch:9200'." introductionVersion:"7.1"`
+	Index    string        `yaml:"index" env:"SEARCH_ENGINE_OPENSEARCH_INDEX" desc:"The name of the index which stores the resources. It is created with the required mapping if it does not exist." introductionVersion:"7.1"`
+	Username string        `yaml:"username" env:"SEARCH_ENGINE_OPENSEARCH_USERNAME" desc:"The username for basic authentication against the cluster. Leave empty to disable authentication." introductionVersion:"7.1"`
+	Password string        `yaml:"password" env:"SEARCH_ENGINE_OPENSEARCH_PASSWORD" desc:"The password for basic authentication against the cluster." introductionVersion:"7.1"`
+	Insecure bool          `yaml:"insecure" env:"OCIS_INSECURE;SEARCH_ENGINE_OPENSEARCH_INSECURE" desc:"Ignore untrusted SSL certificates when connecting to the cluster." introductionVersion:"7.1"`
+	Timeout  time.Duration `yaml:"timeout" env:"SEARCH_ENGINE_OPENSEARCH_TIMEOUT" desc:"The timeout for requests to the cluster. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+}
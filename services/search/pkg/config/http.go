@@ -0,0 +1,11 @@
+package config
+
+import "github.com/owncloud/ocis/v2/ocis-pkg/shared"
+
+// HTTP defines the available http configuration.
+type HTTP struct {
+	Addr      string                `yaml:"addr" env:"SEARCH_HTTP_ADDR" desc:"The bind address of the HTTP service." introductionVersion:"7.1"`
+	Namespace string                `yaml:"-"`
+	Root      string                `yaml:"root" env:"SEARCH_HTTP_ROOT" desc:"Subdirectory that serves as the root for this HTTP service." introductionVersion:"7.1"`
+	TLS       shared.HTTPServiceTLS `yaml:"tls"`
+}
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"errors"
+	"fmt"
 
 	ociscfg "github.com/owncloud/ocis/v2/ocis-pkg/config"
 	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
@@ -45,5 +46,15 @@ func Validate(cfg *config.Config) error {
 		return shared.MissingServiceAccountSecret(cfg.Service.Name)
 	}
 
+	if cfg.Embedding.Type != "none" && cfg.Engine.Type == "bleve" && cfg.Embedding.MaxCandidates <= 0 {
+		return fmt.Errorf("the maximum number of embedding candidates must be greater than 0 for the bleve search engine")
+	}
+	if cfg.Embedding.Type != "none" && cfg.Embedding.Dimension <= 0 {
+		return fmt.Errorf("the embedding dimension must be greater than 0 for the search embedding type '%s'", cfg.Embedding.Type)
+	}
+	if cfg.Embedding.Type == "http" && cfg.Embedding.HTTP.URL == "" {
+		return errors.New("the search embedding type 'http' requires SEARCH_EMBEDDING_HTTP_URL to be set")
+	}
+
 	return nil
 }
@@ -0,0 +1,8 @@
+package config
+
+import "time"
+
+// Reconcile defines the schedule of the reconciliation of the index with the storage.
+type Reconcile struct {
+	Interval time.Duration `yaml:"interval" env:"SEARCH_RECONCILE_INTERVAL" desc:"The interval in which all spaces are reconciled with the index. Resources which changed without being reindexed, for example because events were lost, are upserted, moved or purged. Set to 0 to disable the scheduled reconciliation. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+}
@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+// SavedSearches configures the saved searches of the users.
+type SavedSearches struct {
+	NotifyInterval time.Duration `yaml:"notify_interval" env:"SEARCH_SAVED_SEARCHES_NOTIFY_INTERVAL" desc:"The interval in which the saved searches with enabled notifications are run again. The owners are notified when new resources match. Set to 0 to disable the notifications. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	MaxPerUser     int           `yaml:"max_per_user" env:"SEARCH_SAVED_SEARCHES_MAX_PER_USER" desc:"The maximum number of saved searches a user can create." introductionVersion:"7.1"`
+}
+
+// Store configures the store of the saved searches.
+type Store struct {
+	Store        string        `yaml:"store" env:"OCIS_PERSISTENT_STORE;SEARCH_STORE" desc:"The type of the store. Supported values are: 'memory', 'nats-js-kv', 'redis-sentinel', 'noop'. See the text description for details." introductionVersion:"7.1"`
+	Nodes        []string      `yaml:"nodes" env:"OCIS_PERSISTENT_STORE_NODES;SEARCH_STORE_NODES" desc:"A list of nodes to access the configured store. This has no effect when 'memory' store is configured. Note that the behaviour how nodes are used is dependent on the library of the configured store. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	Database     string        `yaml:"database" env:"SEARCH_STORE_DATABASE" desc:"The database name the configured store should use." introductionVersion:"7.1"`
+	Table        string        `yaml:"table" env:"SEARCH_STORE_TABLE" desc:"The database table the store should use." introductionVersion:"7.1"`
+	TTL          time.Duration `yaml:"ttl" env:"OCIS_PERSISTENT_STORE_TTL;SEARCH_STORE_TTL" desc:"Time to live for saved searches in the store. Defaults to '0' which means they don't expire. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	AuthUsername string        `yaml:"username" env:"OCIS_PERSISTENT_STORE_AUTH_USERNAME;SEARCH_STORE_AUTH_USERNAME" desc:"The username to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"7.1"`
+	AuthPassword string        `yaml:"password" env:"OCIS_PERSISTENT_STORE_AUTH_PASSWORD;SEARCH_STORE_AUTH_PASSWORD" desc:"The password to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"7.1"`
+}
@@ -66,7 +66,7 @@ func (s cs3) Retrieve(ctx context.Context, rID *provider.ResourceId) (io.ReadClo
 		ep, tt = res.Protocols[0].DownloadEndpoint, res.Protocols[0].Token
 	}
 
-	req, err := http.NewRequest(http.MethodGet, ep, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,190 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+)
+
+// _maxInflatedSize limits the size of compressed parts of a document, like the entries of an office document
+// or the streams of a pdf, after decompressing them. It protects against decompression bombs.
+const _maxInflatedSize = 100 * 1024 * 1024
+
+// errContentLimit is returned by the parsers to stop parsing once the content size limit is reached.
+var errContentLimit = errors.New("content size limit reached")
+
+// nativeParser extracts the content and meta information of a document into doc,
+// the text of the document is written to text.
+type nativeParser func(ctx context.Context, data []byte, doc *Document, text *textBuilder) error
+
+// Native is used to extract content from a resource without any external service,
+// it supports the most common text, office, pdf, image and audio formats.
+type Native struct {
+	*Basic
+	Retriever
+	ContentExtractionSizeLimit uint64
+	ContentSizeLimit           uint64
+	Timeout                    time.Duration
+}
+
+// NewNativeExtractor creates a new Native instance.
+func NewNativeExtractor(gatewaySelector pool.Selectable[gateway.GatewayAPIClient], logger log.Logger, cfg *config.Config) (*Native, error) {
+	basic, err := NewBasicExtractor(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Native{
+		Basic:                      basic,
+		Retriever:                  newCS3Retriever(gatewaySelector, logger, cfg.Extractor.CS3AllowInsecure),
+		ContentExtractionSizeLimit: cfg.ContentExtractionSizeLimit,
+		ContentSizeLimit:           cfg.Extractor.Native.ContentSizeLimit,
+		Timeout:                    cfg.Extractor.Native.Timeout,
+	}, nil
+}
+
+// Extract loads a resource from its underlying storage, parses it depending on its mime type and processes the result into a Document.
+// Documents which can not be parsed are indexed without their content.
+func (n Native) Extract(ctx context.Context, ri *provider.ResourceInfo) (Document, error) {
+	doc, err := n.Basic.Extract(ctx, ri)
+	if err != nil {
+		return doc, err
+	}
+
+	if ri.Size == 0 || ri.Type != provider.ResourceType_RESOURCE_TYPE_FILE {
+		return doc, nil
+	}
+
+	parse := parserFor(ri.MimeType)
+	if parse == nil {
+		return doc, nil
+	}
+
+	if ri.Size > n.ContentExtractionSizeLimit {
+		n.logger.Info().Interface("ResourceID", ri.Id).Str("Name", ri.Name).Msg("file exceeds content extraction size limit. skipping.")
+		return doc, nil
+	}
+
+	if n.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.Timeout)
+		defer cancel()
+	}
+
+	b, err := n.retrieve(ctx, ri.Id)
+	switch {
+	case err != nil && ctx.Err() != nil:
+		n.logger.Warn().Err(err).Interface("ResourceID", ri.Id).Str("Name", ri.Name).Msg("file content extraction timed out. skipping.")
+		return doc, nil
+	case err != nil:
+		return doc, err
+	}
+
+	text := &textBuilder{limit: n.ContentSizeLimit}
+	parsed := doc
+	switch err := safeParse(ctx, parse, b, &parsed, text); {
+	case err == nil, errors.Is(err, errContentLimit):
+		doc = parsed
+	default:
+		n.logger.Warn().Err(err).Interface("ResourceID", ri.Id).Str("Name", ri.Name).Str("MimeType", ri.MimeType).Msg("failed to parse the file content. skipping.")
+		return doc, nil
+	}
+
+	doc.Title = strings.TrimSpace(doc.Title)
+	doc.Content = strings.Join(strings.Fields(text.String()), " ")
+
+	return doc, nil
+}
+
+// safeParse turns the panics of a parser caused by malformed documents into errors.
+func safeParse(ctx context.Context, parse nativeParser, data []byte, doc *Document, text *textBuilder) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed document: %v", r)
+		}
+	}()
+
+	return parse(ctx, data, doc, text)
+}
+
+func (n Native) retrieve(ctx context.Context, rID *provider.ResourceId) ([]byte, error) {
+	data, err := n.Retrieve(ctx, rID)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Close()
+
+	return io.ReadAll(io.LimitReader(data, int64(n.ContentExtractionSizeLimit)))
+}
+
+// parserFor returns the parser for the given mime type or nil if the mime type is not supported.
+func parserFor(mimeType string) nativeParser {
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+
+	switch mimeType = strings.TrimSpace(strings.ToLower(mimeType)); mimeType {
+	case "text/markdown", "text/x-markdown":
+		return parseMarkdown
+	case "text/html", "application/xhtml+xml":
+		return parseHTML
+	case "application/vnd.oasis.opendocument.text",
+		"application/vnd.oasis.opendocument.spreadsheet",
+		"application/vnd.oasis.opendocument.presentation":
+		return parseODF
+	case "application/vnd.openxmlformats-officedocument.wordprocessingml.document":
+		return parseDOCX
+	case "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
+		return parseXLSX
+	case "application/vnd.openxmlformats-officedocument.presentationml.presentation":
+		return parsePPTX
+	case "application/pdf":
+		return parsePDF
+	case "image/jpeg", "image/tiff":
+		return parsePhoto
+	case "image/png", "image/gif":
+		return parseImage
+	case "audio/mpeg", "audio/mp4", "audio/x-m4a", "audio/flac", "audio/x-flac", "audio/ogg":
+		return parseAudio
+	}
+
+	if strings.HasPrefix(mimeType, "text/") {
+		return parseText
+	}
+
+	return nil
+}
+
+// textBuilder collects the text of a document up to a size limit.
+type textBuilder struct {
+	strings.Builder
+	limit uint64
+}
+
+// WriteString appends s to the text, it returns errContentLimit if the text has been truncated to the size limit.
+func (t *textBuilder) WriteString(s string) (int, error) {
+	if t.limit == 0 || uint64(t.Len()+len(s)) <= t.limit {
+		return t.Builder.WriteString(s)
+	}
+
+	// cut the text at the last complete character which fits
+	s = s[:t.limit-uint64(t.Len())]
+	for len(s) > 0 {
+		if r, size := utf8.DecodeLastRuneInString(s); r != utf8.RuneError || size > 1 {
+			break
+		}
+		s = s[:len(s)-1]
+	}
+	_, _ = t.Builder.WriteString(s)
+
+	return len(s), errContentLimit
+}
@@ -0,0 +1,399 @@
+package content
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"image"
+	_ "image/gif"  // register the gif decoder
+	_ "image/jpeg" // register the jpeg decoder
+	_ "image/png"  // register the png decoder
+	"strings"
+	"time"
+
+	"github.com/dhowden/tag"
+	_ "golang.org/x/image/tiff" // register the tiff decoder
+
+	libregraph "github.com/owncloud/libre-graph-api-go"
+)
+
+const _feetPerMetre = 3.28084
+
+// exif tags which are used for the photo and location metadata
+const (
+	_exifMake             = 0x010F
+	_exifModel            = 0x0110
+	_exifOrientation      = 0x0112
+	_exifIFD              = 0x8769
+	_exifGPSIFD           = 0x8825
+	_exifExposureTime     = 0x829A
+	_exifFNumber          = 0x829D
+	_exifISO              = 0x8827
+	_exifDateTimeOriginal = 0x9003
+	_exifFocalLength      = 0x920A
+	_exifGPSLatitudeRef   = 0x0001
+	_exifGPSLatitude      = 0x0002
+	_exifGPSLongitudeRef  = 0x0003
+	_exifGPSLongitude     = 0x0004
+	_exifGPSAltitudeRef   = 0x0005
+	_exifGPSAltitude      = 0x0006
+)
+
+var errInvalidExif = errors.New("invalid exif data")
+
+// parseImage adds the dimensions of images.
+func parseImage(_ context.Context, data []byte, doc *Document, _ *textBuilder) error {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	doc.Image = libregraph.NewImage()
+	doc.Image.SetWidth(int32(cfg.Width))
+	doc.Image.SetHeight(int32(cfg.Height))
+
+	return nil
+}
+
+// parsePhoto adds the dimensions of images and the photo and location metadata of their exif data.
+func parsePhoto(ctx context.Context, data []byte, doc *Document, text *textBuilder) error {
+	if err := parseImage(ctx, data, doc, text); err != nil {
+		return err
+	}
+
+	tiff := data
+	if bytes.HasPrefix(data, []byte{0xFF, 0xD8}) {
+		if tiff = jpegExif(data); tiff == nil {
+			return nil
+		}
+	}
+
+	x, err := newExifReader(tiff)
+	if err != nil {
+		return nil
+	}
+
+	ifd0 := x.ifd(x.order.Uint32(tiff[4:]))
+	exif := x.ifd(ifd0[_exifIFD].uint(x.order))
+	gps := x.ifd(ifd0[_exifGPSIFD].uint(x.order))
+
+	doc.Photo = x.photo(ifd0, exif)
+	doc.Location = x.location(gps)
+
+	return nil
+}
+
+// parseAudio adds the metadata of the ID3, MP4, FLAC and OGG tags of audio files, lyrics are added to the content.
+func parseAudio(_ context.Context, data []byte, doc *Document, text *textBuilder) error {
+	m, err := tag.ReadFrom(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	var audio *libregraph.Audio
+	initAudio := func() {
+		if audio == nil {
+			audio = libregraph.NewAudio()
+		}
+	}
+
+	if v := m.Album(); v != "" {
+		initAudio()
+		audio.SetAlbum(v)
+	}
+
+	if v := m.AlbumArtist(); v != "" {
+		initAudio()
+		audio.SetAlbumArtist(v)
+	}
+
+	if v := m.Artist(); v != "" {
+		initAudio()
+		audio.SetArtist(v)
+	}
+
+	if v := m.Composer(); v != "" {
+		initAudio()
+		audio.SetComposers(v)
+	}
+
+	if disc, discCount := m.Disc(); disc > 0 {
+		initAudio()
+		audio.SetDisc(int32(disc))
+		if discCount > 0 {
+			audio.SetDiscCount(int32(discCount))
+		}
+	}
+
+	if v := m.Genre(); v != "" {
+		initAudio()
+		audio.SetGenre(v)
+	}
+
+	if v := m.Title(); v != "" {
+		initAudio()
+		audio.SetTitle(v)
+		doc.Title = v
+	}
+
+	if track, trackCount := m.Track(); track > 0 {
+		initAudio()
+		audio.SetTrack(int32(track))
+		if trackCount > 0 {
+			audio.SetTrackCount(int32(trackCount))
+		}
+	}
+
+	if v := m.Year(); v > 0 {
+		initAudio()
+		audio.SetYear(int32(v))
+	}
+
+	doc.Audio = audio
+
+	if lyrics := m.Lyrics(); lyrics != "" {
+		_, err = text.WriteString(lyrics)
+	}
+	return err
+}
+
+// jpegExif returns the exif data of the APP1 segment of a jpeg image.
+func jpegExif(data []byte) []byte {
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return nil
+		}
+
+		switch marker := data[i+1]; {
+		case marker == 0xFF:
+			// fill byte
+			i++
+			continue
+		case marker == 0xDA, marker == 0xD9:
+			// the metadata segments precede the image data
+			return nil
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return nil
+		}
+
+		segment := data[i+4 : i+2+length]
+		if data[i+1] == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+		i += 2 + length
+	}
+
+	return nil
+}
+
+// exifReader reads the image file directories of exif data, which have the tiff format.
+type exifReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+type exifEntry struct {
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+func newExifReader(data []byte) (exifReader, error) {
+	if len(data) < 8 {
+		return exifReader{}, errInvalidExif
+	}
+
+	switch string(data[:2]) {
+	case "II":
+		return exifReader{data: data, order: binary.LittleEndian}, nil
+	case "MM":
+		return exifReader{data: data, order: binary.BigEndian}, nil
+	}
+	return exifReader{}, errInvalidExif
+}
+
+// ifd returns the entries of the image file directory at the given offset.
+func (x exifReader) ifd(offset uint32) map[uint16]exifEntry {
+	entries := map[uint16]exifEntry{}
+	if offset == 0 || int(offset)+2 > len(x.data) {
+		return entries
+	}
+
+	n := int(x.order.Uint16(x.data[offset:]))
+	for i := 0; i < n; i++ {
+		pos := int(offset) + 2 + i*12
+		if pos+12 > len(x.data) {
+			break
+		}
+
+		e := exifEntry{
+			typ:   x.order.Uint16(x.data[pos+2:]),
+			count: x.order.Uint32(x.data[pos+4:]),
+		}
+
+		var size int
+		switch e.typ {
+		case 1, 2, 6, 7:
+			size = 1
+		case 3, 8:
+			size = 2
+		case 4, 9:
+			size = 4
+		case 5, 10:
+			size = 8
+		default:
+			continue
+		}
+		length := size * int(e.count)
+		if length < 0 || length > len(x.data) {
+			continue
+		}
+
+		if length <= 4 {
+			e.value = x.data[pos+8 : pos+8+length]
+		} else {
+			valueOffset := int(x.order.Uint32(x.data[pos+8:]))
+			if valueOffset+length > len(x.data) {
+				continue
+			}
+			e.value = x.data[valueOffset : valueOffset+length]
+		}
+
+		entries[x.order.Uint16(x.data[pos:])] = e
+	}
+
+	return entries
+}
+
+func (x exifReader) photo(ifd0, exif map[uint16]exifEntry) *libregraph.Photo {
+	var photo *libregraph.Photo
+	initPhoto := func() {
+		if photo == nil {
+			photo = libregraph.NewPhoto()
+		}
+	}
+
+	if v := ifd0[_exifMake].string(); v != "" {
+		initPhoto()
+		photo.SetCameraMake(v)
+	}
+
+	if v := ifd0[_exifModel].string(); v != "" {
+		initPhoto()
+		photo.SetCameraModel(v)
+	}
+
+	if v := exif[_exifFNumber].rational(x.order, 0); v > 0 {
+		initPhoto()
+		photo.SetFNumber(v)
+	}
+
+	if v := exif[_exifFocalLength].rational(x.order, 0); v > 0 {
+		initPhoto()
+		photo.SetFocalLength(v)
+	}
+
+	if v := exif[_exifISO].uint(x.order); v > 0 {
+		initPhoto()
+		photo.SetIso(int32(v))
+	}
+
+	if v := ifd0[_exifOrientation].uint(x.order); v > 0 {
+		initPhoto()
+		photo.SetOrientation(int32(v))
+	}
+
+	if v := exif[_exifDateTimeOriginal].string(); v != "" {
+		if t, err := time.Parse("2006:01:02 15:04:05", v); err == nil {
+			initPhoto()
+			photo.SetTakenDateTime(t)
+		}
+	}
+
+	if e := exif[_exifExposureTime]; e.typ == 5 && len(e.value) == 8 {
+		if numerator, denominator := x.order.Uint32(e.value), x.order.Uint32(e.value[4:]); numerator > 0 && denominator > 0 {
+			initPhoto()
+			photo.SetExposureNumerator(float64(numerator))
+			photo.SetExposureDenominator(float64(denominator))
+		}
+	}
+
+	return photo
+}
+
+func (x exifReader) location(gps map[uint16]exifEntry) *libregraph.GeoCoordinates {
+	lat, lon := gps[_exifGPSLatitude], gps[_exifGPSLongitude]
+	if len(lat.value) != 24 || len(lon.value) != 24 {
+		return nil
+	}
+
+	location := libregraph.NewGeoCoordinates()
+
+	latitude := lat.rational(x.order, 0) + lat.rational(x.order, 1)/60 + lat.rational(x.order, 2)/3600
+	if strings.EqualFold(gps[_exifGPSLatitudeRef].string(), "S") {
+		latitude = -latitude
+	}
+	location.SetLatitude(latitude)
+
+	longitude := lon.rational(x.order, 0) + lon.rational(x.order, 1)/60 + lon.rational(x.order, 2)/3600
+	if strings.EqualFold(gps[_exifGPSLongitudeRef].string(), "W") {
+		longitude = -longitude
+	}
+	location.SetLongitude(longitude)
+
+	if alt := gps[_exifGPSAltitude]; len(alt.value) == 8 {
+		altitude := alt.rational(x.order, 0) * _feetPerMetre
+		if ref := gps[_exifGPSAltitudeRef]; len(ref.value) == 1 && ref.value[0] == 1 {
+			altitude = -altitude
+		}
+		location.SetAltitude(altitude)
+	}
+
+	return location
+}
+
+// string returns the value of ASCII entries.
+func (e exifEntry) string() string {
+	if e.typ != 2 {
+		return ""
+	}
+	s, _, _ := strings.Cut(string(e.value), "\x00")
+	return strings.TrimSpace(s)
+}
+
+// uint returns the value of SHORT and LONG entries.
+func (e exifEntry) uint(order binary.ByteOrder) uint32 {
+	switch {
+	case e.typ == 3 && len(e.value) >= 2:
+		return uint32(order.Uint16(e.value))
+	case e.typ == 4 && len(e.value) >= 4:
+		return order.Uint32(e.value)
+	}
+	return 0
+}
+
+// rational returns the i-th value of RATIONAL and SRATIONAL entries.
+func (e exifEntry) rational(order binary.ByteOrder, i int) float64 {
+	if (e.typ != 5 && e.typ != 10) || len(e.value) < (i+1)*8 {
+		return 0
+	}
+
+	v := e.value[i*8:]
+	if e.typ == 10 {
+		numerator, denominator := int32(order.Uint32(v)), int32(order.Uint32(v[4:]))
+		if denominator == 0 {
+			return 0
+		}
+		return float64(numerator) / float64(denominator)
+	}
+
+	numerator, denominator := order.Uint32(v), order.Uint32(v[4:])
+	if denominator == 0 {
+		return 0
+	}
+	return float64(numerator) / float64(denominator)
+}
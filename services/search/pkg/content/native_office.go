@@ -0,0 +1,224 @@
+package content
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+const _dublinCoreNamespace = "http://purl.org/dc/elements/1.1/"
+
+var errZipFileNotFound = errors.New("file not found in archive")
+
+var pptxSlideRegex = regexp.MustCompile(`^ppt/slides/slide(\d+)\.xml$`)
+
+// parseODF adds the text of OpenDocument text documents, spreadsheets and presentations to the content.
+func parseODF(ctx context.Context, data []byte, doc *Document, text *textBuilder) error {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	if title, err := zipXMLTitle(zr, "meta.xml"); err == nil {
+		doc.Title = title
+	}
+
+	return zipXMLText(ctx, zr, "content.xml", text, map[string]string{
+		"h":          "\n",
+		"p":          "\n",
+		"list-item":  "\n",
+		"table-row":  "\n",
+		"table-cell": " ",
+		"s":          " ",
+		"tab":        " ",
+		"line-break": "\n",
+	}, nil)
+}
+
+// parseDOCX adds the text of Office Open XML text documents to the content.
+func parseDOCX(ctx context.Context, data []byte, doc *Document, text *textBuilder) error {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	if title, err := zipXMLTitle(zr, "docProps/core.xml"); err == nil {
+		doc.Title = title
+	}
+
+	return zipXMLText(ctx, zr, "word/document.xml", text, map[string]string{
+		"p":   "\n",
+		"tab": " ",
+		"br":  "\n",
+		"cr":  "\n",
+		"tc":  " ",
+	}, map[string]bool{
+		// field codes and deleted text are not visible
+		"instrText": true,
+		"delText":   true,
+	})
+}
+
+// parseXLSX adds the strings of Office Open XML spreadsheets to the content.
+func parseXLSX(ctx context.Context, data []byte, doc *Document, text *textBuilder) error {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	if title, err := zipXMLTitle(zr, "docProps/core.xml"); err == nil {
+		doc.Title = title
+	}
+
+	err = zipXMLText(ctx, zr, "xl/sharedStrings.xml", text, map[string]string{
+		"si": "\n",
+	}, map[string]bool{
+		// phonetic hints of east asian languages
+		"rPh": true,
+	})
+	if errors.Is(err, errZipFileNotFound) {
+		// spreadsheets without strings have no shared strings
+		return nil
+	}
+	return err
+}
+
+// parsePPTX adds the text of the slides of Office Open XML presentations to the content.
+func parsePPTX(ctx context.Context, data []byte, doc *Document, text *textBuilder) error {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	if title, err := zipXMLTitle(zr, "docProps/core.xml"); err == nil {
+		doc.Title = title
+	}
+
+	type slide struct {
+		name   string
+		number int
+	}
+	var slides []slide
+	for _, f := range zr.File {
+		if m := pptxSlideRegex.FindStringSubmatch(f.Name); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			slides = append(slides, slide{name: f.Name, number: n})
+		}
+	}
+	sort.Slice(slides, func(i, j int) bool {
+		return slides[i].number < slides[j].number
+	})
+
+	for _, s := range slides {
+		if err := zipXMLText(ctx, zr, s.name, text, map[string]string{
+			"p":  "\n",
+			"br": "\n",
+		}, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// openZipFile opens the file with the given name of the archive,
+// the content is limited to protect against decompression bombs.
+func openZipFile(zr *zip.Reader, name string) (io.ReadCloser, error) {
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(rc, _maxInflatedSize), rc}, nil
+	}
+
+	return nil, errZipFileNotFound
+}
+
+// zipXMLTitle returns the dublin core title of the xml file with the given name of the archive.
+func zipXMLTitle(zr *zip.Reader, name string) (string, error) {
+	rc, err := openZipFile(zr, name)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	d := xml.NewDecoder(rc)
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return "", err
+		}
+
+		if se, ok := tok.(xml.StartElement); ok && se.Name.Space == _dublinCoreNamespace && se.Name.Local == "title" {
+			var title string
+			err := d.DecodeElement(&title, &se)
+			return title, err
+		}
+	}
+}
+
+// zipXMLText writes the character data of the xml file with the given name of the archive to the text.
+// The separators are written after the elements with the given local names, elements which should be skipped
+// are not considered.
+func zipXMLText(ctx context.Context, zr *zip.Reader, name string, text *textBuilder, separators map[string]string, skip map[string]bool) error {
+	rc, err := openZipFile(zr, name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	var (
+		d        = xml.NewDecoder(rc)
+		skipping int
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		tok, err := d.Token()
+		switch {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if skip[t.Name.Local] {
+				skipping++
+			}
+		case xml.EndElement:
+			if skip[t.Name.Local] {
+				skipping--
+				continue
+			}
+			if sep, ok := separators[t.Name.Local]; ok && skipping == 0 {
+				if _, err := text.WriteString(sep); err != nil {
+					return err
+				}
+			}
+		case xml.CharData:
+			if skipping == 0 {
+				if _, err := text.WriteString(string(t)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,705 @@
+package content
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	// _maxPDFNesting limits the depth of nested arrays in content streams
+	_maxPDFNesting = 32
+	// _maxPDFCMapCodes limits the number of character codes of a font's unicode mapping
+	_maxPDFCMapCodes = 1 << 16
+)
+
+var (
+	pdfObjectRegex     = regexp.MustCompile(`(\d+)\s+\d+\s+obj\b`)
+	pdfSkipStreamRegex = regexp.MustCompile(`/(?:Subtype|Type)\s*/(?:Image|XML|XRef|Metadata|EmbeddedFile|Type1C|CIDFontType0C|OpenType)\b|/Length[123]\b`)
+	pdfFilterRegex     = regexp.MustCompile(`/Filter\s*(?:\[\s*)?/(\w+)`)
+	pdfObjStmRegex     = regexp.MustCompile(`/Type\s*/ObjStm\b`)
+	pdfIntRegex        = regexp.MustCompile(`/(N|First)\s+(\d+)`)
+	pdfInfoRegex       = regexp.MustCompile(`/Info\s+(\d+)\s+\d+\s+R`)
+	pdfToUnicodeRegex  = regexp.MustCompile(`/ToUnicode\s+(\d+)\s+\d+\s+R`)
+	pdfFontsRegex      = regexp.MustCompile(`(?s)/Font\s*(?:<<(.*?)>>|(\d+)\s+\d+\s+R)`)
+	pdfFontRefRegex    = regexp.MustCompile(`/([^\s/<>\[\]()]+)\s*(\d+)\s+\d+\s+R`)
+	pdfBfcharRegex     = regexp.MustCompile(`(?s)beginbfchar(.*?)endbfchar`)
+	pdfBfrangeRegex    = regexp.MustCompile(`(?s)beginbfrange(.*?)endbfrange`)
+	pdfHexRegex        = regexp.MustCompile(`<([0-9A-Fa-f\s]*)>`)
+	pdfRangeRegex      = regexp.MustCompile(`(?s)<([0-9A-Fa-f\s]*)>\s*<([0-9A-Fa-f\s]*)>\s*(<[0-9A-Fa-f\s]*>|\[.*?\])`)
+
+	errPDFEncrypted         = errors.New("encrypted pdf documents are not supported")
+	errPDFUnsupportedFilter = errors.New("unsupported pdf stream filter")
+	errPDFNesting           = errors.New("pdf arrays are nested too deeply")
+)
+
+// parsePDF adds the text layer of pdf documents to the content, the title of the document information is used as title.
+// Only the most common encodings are supported, scanned documents without a text layer have no content.
+func parsePDF(ctx context.Context, data []byte, doc *Document, text *textBuilder) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("%PDF-")) {
+		return errors.New("not a pdf document")
+	}
+	if bytes.Contains(data, []byte("/Encrypt")) {
+		return errPDFEncrypted
+	}
+
+	f, err := readPDF(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	doc.Title = f.title()
+
+	fonts, err := f.fonts(ctx)
+	if err != nil {
+		return err
+	}
+	for _, id := range f.streamOrder {
+		s := f.streams[id]
+		if bytes.Contains(s, []byte("begincmap")) {
+			continue
+		}
+
+		if err := writePDFText(ctx, s, fonts, text); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pdfFile holds the objects and the decoded streams of a pdf document.
+type pdfFile struct {
+	data        []byte
+	objects     map[int][]byte
+	streams     map[int][]byte
+	streamOrder []int
+}
+
+// readPDF scans the document for its objects, the cross-reference table is not needed for this.
+// The decompressed streams of the document must not exceed _maxInflatedSize in total.
+func readPDF(ctx context.Context, data []byte) (*pdfFile, error) {
+	f := &pdfFile{
+		data:    data,
+		objects: map[int][]byte{},
+		streams: map[int][]byte{},
+	}
+	inflated := 0
+
+	for pos := 0; pos < len(data) && inflated < _maxInflatedSize; {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		loc := pdfObjectRegex.FindSubmatchIndex(data[pos:])
+		if loc == nil {
+			break
+		}
+		id, _ := strconv.Atoi(string(data[pos+loc[2] : pos+loc[3]]))
+		start := pos + loc[1]
+
+		end := bytes.Index(data[start:], []byte("endobj"))
+		if end < 0 {
+			end = len(data) - start
+		}
+		body := data[start : start+end]
+		pos = start + end
+
+		si := bytes.Index(body, []byte("stream"))
+		if si < 0 {
+			f.objects[id] = body
+			continue
+		}
+
+		// binary stream data might contain 'endobj', the stream ends with 'endstream'
+		raw := data[start+si+len("stream"):]
+		ei := bytes.Index(raw, []byte("endstream"))
+		if ei < 0 {
+			break
+		}
+		pos = start + si + len("stream") + ei + len("endstream")
+
+		dict := body[:si]
+		f.objects[id] = dict
+		raw = bytes.TrimPrefix(bytes.TrimPrefix(raw[:ei], []byte("\r")), []byte("\n"))
+
+		if pdfSkipStreamRegex.Match(dict) {
+			continue
+		}
+		decoded, err := decodePDFStream(dict, raw, _maxInflatedSize-inflated)
+		if err != nil {
+			continue
+		}
+		inflated += len(decoded)
+
+		if pdfObjStmRegex.Match(dict) {
+			f.addObjectStream(dict, decoded)
+			continue
+		}
+
+		f.streams[id] = decoded
+		f.streamOrder = append(f.streamOrder, id)
+	}
+
+	return f, nil
+}
+
+// addObjectStream adds the objects which are compressed in an object stream.
+func (f *pdfFile) addObjectStream(dict, decoded []byte) {
+	var n, first int
+	for _, m := range pdfIntRegex.FindAllSubmatch(dict, -1) {
+		v, _ := strconv.Atoi(string(m[2]))
+		switch string(m[1]) {
+		case "N":
+			n = v
+		case "First":
+			first = v
+		}
+	}
+	if first <= 0 || first > len(decoded) {
+		return
+	}
+
+	header := strings.Fields(string(decoded[:first]))
+	type entry struct{ id, offset int }
+	entries := make([]entry, 0, n)
+	for i := 0; i+1 < len(header) && len(entries) < n; i += 2 {
+		id, err1 := strconv.Atoi(header[i])
+		offset, err2 := strconv.Atoi(header[i+1])
+		if err1 != nil || err2 != nil || first+offset > len(decoded) {
+			return
+		}
+		entries = append(entries, entry{id: id, offset: first + offset})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].offset < entries[j].offset })
+
+	for i, e := range entries {
+		end := len(decoded)
+		if i+1 < len(entries) {
+			end = entries[i+1].offset
+		}
+		f.objects[e.id] = decoded[e.offset:end]
+	}
+}
+
+// title returns the title of the document information.
+func (f *pdfFile) title() string {
+	matches := pdfInfoRegex.FindAllSubmatch(f.data, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	// incremental updates append a new trailer, the last one is the current
+	id, _ := strconv.Atoi(string(matches[len(matches)-1][1]))
+
+	info := f.objects[id]
+	i := bytes.Index(info, []byte("/Title"))
+	if i < 0 {
+		return ""
+	}
+
+	l := &pdfLexer{data: info, pos: i + len("/Title")}
+	if s, ok := l.next().(pdfString); ok {
+		return pdfCMap{}.decode(s)
+	}
+	return ""
+}
+
+// fonts returns the unicode mappings of the fonts by their resource names.
+// The resource names are merged for all pages, which is correct for the most documents.
+func (f *pdfFile) fonts(ctx context.Context) (map[string]pdfCMap, error) {
+	cmaps := map[int]pdfCMap{}
+	fonts := map[string]pdfCMap{}
+
+	for _, obj := range f.objects {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for _, m := range pdfFontsRegex.FindAllSubmatch(obj, -1) {
+			resources := m[1]
+			if len(m[2]) > 0 {
+				id, _ := strconv.Atoi(string(m[2]))
+				resources = f.objects[id]
+			}
+
+			for _, ref := range pdfFontRefRegex.FindAllSubmatch(resources, -1) {
+				id, _ := strconv.Atoi(string(ref[2]))
+				tu := pdfToUnicodeRegex.FindSubmatch(f.objects[id])
+				if tu == nil {
+					continue
+				}
+
+				cmapID, _ := strconv.Atoi(string(tu[1]))
+				cmap, ok := cmaps[cmapID]
+				if !ok {
+					cmap = parsePDFCMap(f.streams[cmapID])
+					cmaps[cmapID] = cmap
+				}
+				fonts[string(ref[1])] = cmap
+			}
+		}
+	}
+
+	return fonts, nil
+}
+
+// decodePDFStream decodes the raw data of a stream, compressed data is inflated up to limit bytes.
+func decodePDFStream(dict, raw []byte, limit int) ([]byte, error) {
+	m := pdfFilterRegex.FindSubmatch(dict)
+	if m == nil {
+		return raw, nil
+	}
+	if string(m[1]) != "FlateDecode" {
+		return nil, errPDFUnsupportedFilter
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	b, err := io.ReadAll(io.LimitReader(zr, int64(limit)))
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	return b, nil
+}
+
+// writePDFText writes the text shown by the text operators of a content stream.
+func writePDFText(ctx context.Context, content []byte, fonts map[string]pdfCMap, text *textBuilder) error {
+	var (
+		l        = &pdfLexer{data: content}
+		operands []interface{}
+		arrays   []int
+		font     pdfCMap
+	)
+
+	write := func(s string) error {
+		_, err := text.WriteString(s)
+		return err
+	}
+
+	for n := 0; ; n++ {
+		if n%1000 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		tok := l.next()
+		if tok == nil {
+			return nil
+		}
+
+		op, ok := tok.(pdfOperator)
+		if !ok {
+			operands = append(operands, tok)
+			continue
+		}
+
+		switch op {
+		case "[":
+			if len(arrays) == _maxPDFNesting {
+				return errPDFNesting
+			}
+			arrays = append(arrays, len(operands))
+			continue
+		case "]":
+			if len(arrays) == 0 {
+				continue
+			}
+			start := arrays[len(arrays)-1]
+			arrays = arrays[:len(arrays)-1]
+			array := append([]interface{}{}, operands[start:]...)
+			operands = append(operands[:start], array)
+			continue
+		case "<<", ">>", "{", "}":
+			continue
+		}
+
+		var err error
+		switch op {
+		case "Tf":
+			if len(operands) >= 2 {
+				if name, ok := operands[len(operands)-2].(pdfName); ok {
+					font = fonts[string(name)]
+				}
+			}
+		case "Tj":
+			err = writePDFStrings(operands, font, write)
+		case "'", "\"":
+			if err = write("\n"); err == nil {
+				err = writePDFStrings(operands, font, write)
+			}
+		case "TJ":
+			if len(operands) > 0 {
+				if array, ok := operands[len(operands)-1].([]interface{}); ok {
+					err = writePDFStrings(array, font, write)
+				}
+			}
+		case "Td", "TD":
+			sep := " "
+			if len(operands) >= 2 {
+				if ty, ok := operands[len(operands)-1].(float64); ok && ty != 0 {
+					sep = "\n"
+				}
+			}
+			err = write(sep)
+		case "T*", "ET":
+			err = write("\n")
+		case "ID":
+			l.skipInlineImage()
+		}
+		if err != nil {
+			return err
+		}
+
+		operands = operands[:0]
+		arrays = arrays[:0]
+	}
+}
+
+// writePDFStrings writes the strings of the operands, large negative adjustments of the glyph positions are written as spaces.
+func writePDFStrings(operands []interface{}, font pdfCMap, write func(string) error) error {
+	for _, o := range operands {
+		var s string
+		switch v := o.(type) {
+		case pdfString:
+			s = font.decode(v)
+		case float64:
+			if v < -250 {
+				s = " "
+			}
+		}
+
+		if s == "" {
+			continue
+		}
+		if err := write(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pdfCMap maps the character codes of a font to unicode.
+type pdfCMap struct {
+	codes  map[string]string
+	widths []int
+}
+
+// decode returns the unicode text of the string, strings without mapping are decoded as utf-16 with byte order mark or latin-1.
+func (c pdfCMap) decode(s pdfString) string {
+	var sb strings.Builder
+
+	if len(c.codes) == 0 {
+		if bytes.HasPrefix(s, []byte{0xFE, 0xFF}) {
+			return decodeUTF16BE(s[2:])
+		}
+		for _, b := range s {
+			sb.WriteRune(printable(rune(b)))
+		}
+		return sb.String()
+	}
+
+	for i := 0; i < len(s); {
+		matched := false
+		for _, w := range c.widths {
+			if i+w > len(s) {
+				continue
+			}
+			if u, ok := c.codes[string(s[i:i+w])]; ok {
+				sb.WriteString(u)
+				i += w
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			sb.WriteRune(printable(rune(s[i])))
+			i++
+		}
+	}
+	return sb.String()
+}
+
+// parsePDFCMap parses the character mappings of a ToUnicode cmap.
+func parsePDFCMap(data []byte) pdfCMap {
+	c := pdfCMap{codes: map[string]string{}}
+	widths := map[int]bool{}
+	add := func(code []byte, u string) bool {
+		if len(c.codes) == _maxPDFCMapCodes {
+			return false
+		}
+		c.codes[string(code)] = u
+		widths[len(code)] = true
+		return true
+	}
+
+	for _, block := range pdfBfcharRegex.FindAllSubmatch(data, -1) {
+		hexes := pdfHexRegex.FindAllSubmatch(block[1], -1)
+		for i := 0; i+1 < len(hexes); i += 2 {
+			if !add(decodePDFHex(hexes[i][1]), decodeUTF16BE(decodePDFHex(hexes[i+1][1]))) {
+				break
+			}
+		}
+	}
+
+	for _, block := range pdfBfrangeRegex.FindAllSubmatch(data, -1) {
+		for _, r := range pdfRangeRegex.FindAllSubmatch(block[1], -1) {
+			lo, hi := decodePDFHex(r[1]), decodePDFHex(r[2])
+			if len(lo) == 0 || len(lo) != len(hi) || len(lo) > 4 {
+				continue
+			}
+			from, to := bytesToUint(lo), bytesToUint(hi)
+			if to < from || to-from > 0xFFFF {
+				continue
+			}
+
+			var dst []byte
+			dsts := [][]byte{}
+			if r[3][0] == '[' {
+				for _, h := range pdfHexRegex.FindAllSubmatch(r[3], -1) {
+					dsts = append(dsts, decodePDFHex(h[1]))
+				}
+			} else {
+				dst, dsts = decodePDFHex(r[3][1:len(r[3])-1]), nil
+			}
+
+			for code := from; code <= to; code++ {
+				offset := int(code - from)
+				var u []byte
+				switch {
+				case dsts != nil && offset < len(dsts):
+					u = dsts[offset]
+				case dsts != nil:
+					continue
+				default:
+					// the last byte of the destination is incremented for each code
+					u = append([]byte{}, dst...)
+					if len(u) > 0 {
+						u[len(u)-1] += byte(offset)
+					}
+				}
+				if !add(uintToBytes(code, len(lo)), decodeUTF16BE(u)) {
+					break
+				}
+			}
+		}
+	}
+
+	for w := range widths {
+		c.widths = append(c.widths, w)
+	}
+	// prefer the longest codes
+	sort.Sort(sort.Reverse(sort.IntSlice(c.widths)))
+
+	return c
+}
+
+func decodePDFHex(b []byte) []byte {
+	h := strings.Join(strings.Fields(string(b)), "")
+	if len(h)%2 == 1 {
+		h += "0"
+	}
+	d, _ := hex.DecodeString(h)
+	return d
+}
+
+func decodeUTF16BE(b []byte) string {
+	u := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		u = append(u, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return string(utf16.Decode(u))
+}
+
+func bytesToUint(b []byte) uint32 {
+	var v uint32
+	for _, c := range b {
+		v = v<<8 | uint32(c)
+	}
+	return v
+}
+
+func uintToBytes(v uint32, n int) []byte {
+	b := make([]byte, n)
+	for i := n - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+	return b
+}
+
+// printable replaces control characters, which are the result of unknown encodings, with a space.
+func printable(r rune) rune {
+	if r < 0x20 || (r >= 0x7F && r < 0xA0) {
+		return ' '
+	}
+	return r
+}
+
+type (
+	pdfString   []byte
+	pdfName     string
+	pdfOperator string
+)
+
+// pdfLexer splits pdf content streams and objects into tokens.
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+// next returns the next token, which is either a pdfString, pdfName, pdfOperator or float64, or nil at the end of the data.
+func (l *pdfLexer) next() interface{} {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case isPDFWhitespace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		case c == '(':
+			return l.literal()
+		case c == '<':
+			if l.pos+1 < len(l.data) && l.data[l.pos+1] == '<' {
+				l.pos += 2
+				return pdfOperator("<<")
+			}
+			return l.hex()
+		case c == '>':
+			l.pos++
+			if l.pos < len(l.data) && l.data[l.pos] == '>' {
+				l.pos++
+			}
+			return pdfOperator(">>")
+		case c == '[', c == ']', c == '{', c == '}', c == ')':
+			l.pos++
+			return pdfOperator([]byte{c})
+		case c == '/':
+			l.pos++
+			return pdfName(l.regular())
+		default:
+			tok := l.regular()
+			if tok == "" {
+				l.pos++
+				continue
+			}
+			if strings.ContainsAny(tok[:1], "+-.0123456789") {
+				if f, err := strconv.ParseFloat(tok, 64); err == nil {
+					return f
+				}
+			}
+			return pdfOperator(tok)
+		}
+	}
+	return nil
+}
+
+func (l *pdfLexer) regular() string {
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFWhitespace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	return string(l.data[start:l.pos])
+}
+
+func (l *pdfLexer) literal() pdfString {
+	l.pos++
+	var (
+		s     pdfString
+		depth = 1
+	)
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return s
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return s
+			}
+			c = l.data[l.pos]
+			l.pos++
+
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				// line continuation
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			case '\n':
+				continue
+			case '0', '1', '2', '3', '4', '5', '6', '7':
+				v := int(c - '0')
+				for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+					v = v*8 + int(l.data[l.pos]-'0')
+					l.pos++
+				}
+				c = byte(v)
+			}
+		}
+		s = append(s, c)
+	}
+	return s
+}
+
+func (l *pdfLexer) hex() pdfString {
+	l.pos++
+	end := bytes.IndexByte(l.data[l.pos:], '>')
+	if end < 0 {
+		end = len(l.data) - l.pos
+	}
+	s := decodePDFHex(l.data[l.pos : l.pos+end])
+	l.pos += end + 1
+	return s
+}
+
+// skipInlineImage skips the binary data of an inline image up to the 'EI' operator.
+func (l *pdfLexer) skipInlineImage() {
+	for i := l.pos + 1; i+1 < len(l.data); i++ {
+		if l.data[i] == 'E' && l.data[i+1] == 'I' && isPDFWhitespace(l.data[i-1]) &&
+			(i+2 == len(l.data) || isPDFWhitespace(l.data[i+2])) {
+			l.pos = i + 2
+			return
+		}
+	}
+	l.pos = len(l.data)
+}
+
+func isPDFWhitespace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
@@ -0,0 +1,444 @@
+package content_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+	"time"
+
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	libregraph "github.com/owncloud/libre-graph-api-go"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	conf "github.com/owncloud/ocis/v2/services/search/pkg/config/defaults"
+	"github.com/owncloud/ocis/v2/services/search/pkg/content"
+	contentMocks "github.com/owncloud/ocis/v2/services/search/pkg/content/mocks"
+)
+
+var _ = Describe("Native", func() {
+	var (
+		body      []byte
+		retriever *contentMocks.Retriever
+		native    *content.Native
+
+		extract = func(mimeType string) content.Document {
+			doc, err := native.Extract(context.TODO(), &provider.ResourceInfo{
+				Type:     provider.ResourceType_RESOURCE_TYPE_FILE,
+				MimeType: mimeType,
+				Size:     uint64(len(body)),
+			})
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			return doc
+		}
+	)
+
+	BeforeEach(func() {
+		body = nil
+
+		var err error
+		native, err = content.NewNativeExtractor(nil, log.NewLogger(), conf.DefaultConfig())
+		Expect(err).ToNot(HaveOccurred())
+
+		retriever = &contentMocks.Retriever{}
+		retriever.On("Retrieve", mock.Anything, mock.Anything).Return(func(context.Context, *provider.ResourceId) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		})
+		native.Retriever = retriever
+	})
+
+	Describe("limits", func() {
+		It("skips non file resources", func() {
+			doc, err := native.Extract(context.TODO(), &provider.ResourceInfo{MimeType: "text/plain", Size: 1})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(doc.Content).To(Equal(""))
+			retriever.AssertNotCalled(GinkgoT(), "Retrieve", mock.Anything, mock.Anything)
+		})
+
+		It("skips unsupported mime types", func() {
+			body = []byte("any body")
+			Expect(extract("application/octet-stream").Content).To(Equal(""))
+			retriever.AssertNotCalled(GinkgoT(), "Retrieve", mock.Anything, mock.Anything)
+		})
+
+		It("skips files which exceed the content extraction size limit", func() {
+			body = []byte("any body")
+			native.ContentExtractionSizeLimit = 3
+			Expect(extract("text/plain").Content).To(Equal(""))
+			retriever.AssertNotCalled(GinkgoT(), "Retrieve", mock.Anything, mock.Anything)
+		})
+
+		It("truncates the content to the content size limit", func() {
+			body = []byte("größer als das limit")
+			native.ContentSizeLimit = 4
+			Expect(extract("text/plain").Content).To(Equal("grö"))
+		})
+
+		It("skips the content of files which can not be parsed", func() {
+			body = []byte("no zip archive")
+			doc := extract("application/vnd.openxmlformats-officedocument.wordprocessingml.document")
+			Expect(doc.Content).To(Equal(""))
+			Expect(doc.Size).To(Equal(uint64(len(body))))
+		})
+
+		It("skips the content of files which take too long", func() {
+			body = []byte("slow")
+			native.Timeout = time.Nanosecond
+			retriever.ExpectedCalls = nil
+			retriever.On("Retrieve", mock.Anything, mock.Anything).Return(func(ctx context.Context, _ *provider.ResourceId) (io.ReadCloser, error) {
+				<-ctx.Done()
+				return io.NopCloser(bytes.NewReader(body)), nil
+			})
+
+			Expect(extract("text/html").Content).To(Equal(""))
+		})
+
+		It("skips the content of files which take too long to download", func() {
+			body = []byte("slow")
+			native.Timeout = time.Nanosecond
+			retriever.ExpectedCalls = nil
+			retriever.On("Retrieve", mock.Anything, mock.Anything).Return(func(ctx context.Context, _ *provider.ResourceId) (io.ReadCloser, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+
+			Expect(extract("text/plain").Content).To(Equal(""))
+		})
+	})
+
+	Describe("text", func() {
+		It("adds plain text", func() {
+			body = []byte("any  body\nwith lines")
+			Expect(extract("text/plain").Content).To(Equal("any body with lines"))
+		})
+
+		It("decodes legacy encodings", func() {
+			body = []byte("caf\xe9")
+			Expect(extract("text/csv").Content).To(Equal("café"))
+		})
+
+		It("uses the first heading of markdown as title", func() {
+			body = []byte("some intro\n# The Title\n\n## Section\ntext")
+			doc := extract("text/markdown")
+			Expect(doc.Title).To(Equal("The Title"))
+			Expect(doc.Content).To(Equal("some intro # The Title ## Section text"))
+		})
+
+		It("adds the visible text of html", func() {
+			body = []byte(`<html><head><title>The Title</title><style>p { color: red; }</style></head>
+				<body><script>var hidden = 1;</script><p>Hello <b>W</b>orld</p><div>Next</div></body></html>`)
+			doc := extract("text/html; charset=utf-8")
+			Expect(doc.Title).To(Equal("The Title"))
+			Expect(doc.Content).To(Equal("Hello World Next"))
+		})
+	})
+
+	Describe("office documents", func() {
+		It("adds the text of odf documents", func() {
+			body = zipFiles(map[string]string{
+				"meta.xml": `<office:document-meta xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+					<office:meta><dc:title>The Title</dc:title></office:meta></office:document-meta>`,
+				"content.xml": `<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+					<office:body><office:text><text:h>Heading</text:h><text:p>first<text:s/>paragraph</text:p><text:p>second</text:p></office:text></office:body></office:document-content>`,
+			})
+			doc := extract("application/vnd.oasis.opendocument.text")
+			Expect(doc.Title).To(Equal("The Title"))
+			Expect(doc.Content).To(Equal("Heading first paragraph second"))
+		})
+
+		It("adds the text of docx documents", func() {
+			body = zipFiles(map[string]string{
+				"docProps/core.xml": `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>The Title</dc:title></cp:coreProperties>`,
+				"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+					<w:p><w:r><w:t>Hel</w:t></w:r><w:r><w:t>lo</w:t></w:r></w:p>
+					<w:p><w:r><w:instrText>PAGE</w:instrText></w:r><w:r><w:t>World</w:t></w:r></w:p>
+					</w:body></w:document>`,
+			})
+			doc := extract("application/vnd.openxmlformats-officedocument.wordprocessingml.document")
+			Expect(doc.Title).To(Equal("The Title"))
+			Expect(doc.Content).To(Equal("Hello World"))
+		})
+
+		It("adds the strings of xlsx documents", func() {
+			body = zipFiles(map[string]string{
+				"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><si><t>Revenue</t></si><si><r><t>Q1</t></r><r><t> 2024</t></r></si></sst>`,
+			})
+			Expect(extract("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet").Content).To(Equal("Revenue Q1 2024"))
+		})
+
+		It("adds the text of pptx slides in their order", func() {
+			slide := `<p:sld xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"><p:cSld><p:spTree><p:sp><p:txBody><a:p><a:r><a:t>%s</a:t></a:r></a:p></p:txBody></p:sp></p:spTree></p:cSld></p:sld>`
+			body = zipFiles(map[string]string{
+				"ppt/slides/slide10.xml": fmt.Sprintf(slide, "last"),
+				"ppt/slides/slide2.xml":  fmt.Sprintf(slide, "second"),
+				"ppt/slides/slide1.xml":  fmt.Sprintf(slide, "first"),
+			})
+			Expect(extract("application/vnd.openxmlformats-officedocument.presentationml.presentation").Content).To(Equal("first second last"))
+		})
+	})
+
+	Describe("pdf", func() {
+		It("adds the text layer and the title", func() {
+			body = pdfFile([]string{
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+				"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 5 0 R >> >> /Contents 4 0 R >>",
+				pdfStream("BT /F1 12 Tf 72 712 Td (Hello \\(PDF\\)) Tj 0 -14 Td [(W) 120 (orld) -300 (again)] TJ ET", false),
+				"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+				"<< /Title (The Title) >>",
+			}, 6)
+			doc := extract("application/pdf")
+			Expect(doc.Title).To(Equal("The Title"))
+			Expect(doc.Content).To(Equal("Hello (PDF) World again"))
+		})
+
+		It("decodes compressed streams and fonts with unicode mappings", func() {
+			cmap := "/CIDInit /ProcSet findresource begin begincmap\n" +
+				"2 beginbfchar\n<0001> <0048>\n<0002> <00E9>\nendbfchar\n" +
+				"1 beginbfrange\n<0003> <0005> <006C>\nendbfrange\nendcmap"
+			body = pdfFile([]string{
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+				"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 5 0 R >> >> /Contents 4 0 R >>",
+				pdfStream("BT /F1 12 Tf <0001000200030003> Tj ET", true),
+				"<< /Type /Font /Subtype /Type0 /BaseFont /Custom /Encoding /Identity-H /ToUnicode 6 0 R >>",
+				pdfStream(cmap, true),
+				"<< /Title <FEFF00540069007400E9> >>",
+			}, 7)
+			doc := extract("application/pdf")
+			Expect(doc.Title).To(Equal("Tit\u00e9"))
+			Expect(doc.Content).To(Equal("H\u00e9ll"))
+		})
+
+		It("ignores malformed unicode mappings", func() {
+			cmap := "begincmap\n1 beginbfrange\n<0001> <FFFF> [ ]\nendbfrange\nendcmap"
+			body = pdfFile([]string{
+				"<< /Type /Page /Resources << /Font << /F1 3 0 R >> >> /Contents 2 0 R >>",
+				pdfStream("BT /F1 12 Tf (Hello) Tj ET", false),
+				"<< /Type /Font /ToUnicode 4 0 R >>",
+				pdfStream(cmap, false),
+			}, 0)
+			Expect(extract("application/pdf").Content).To(Equal("Hello"))
+		})
+
+		It("stops at deeply nested arrays", func() {
+			body = pdfFile([]string{
+				pdfStream("BT (Hello) Tj "+strings.Repeat("[", 100)+" ET", false),
+			}, 0)
+			Expect(extract("application/pdf").Content).To(Equal(""))
+		})
+
+		It("skips encrypted documents", func() {
+			body = []byte("%PDF-1.4\ntrailer << /Encrypt 5 0 R >>")
+			Expect(extract("application/pdf").Content).To(Equal(""))
+		})
+	})
+
+	Describe("images", func() {
+		It("adds the dimensions", func() {
+			buf := &bytes.Buffer{}
+			Expect(png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 3, 2)))).To(Succeed())
+			body = buf.Bytes()
+
+			doc := extract("image/png")
+			Expect(doc.Image).ToNot(BeNil())
+			Expect(doc.Image.Width).To(Equal(libregraph.PtrInt32(3)))
+			Expect(doc.Image.Height).To(Equal(libregraph.PtrInt32(2)))
+		})
+
+		It("adds the photo and location metadata of the exif data", func() {
+			buf := &bytes.Buffer{}
+			Expect(jpeg.Encode(buf, image.NewRGBA(image.Rect(0, 0, 4, 5)), nil)).To(Succeed())
+			jpg := buf.Bytes()
+			app1 := append([]byte("Exif\x00\x00"), exifData()...)
+			segment := append([]byte{0xFF, 0xE1, byte((len(app1) + 2) >> 8), byte(len(app1) + 2)}, app1...)
+			body = append(append(append([]byte{}, jpg[:2]...), segment...), jpg[2:]...)
+
+			doc := extract("image/jpeg")
+			Expect(doc.Image.Width).To(Equal(libregraph.PtrInt32(4)))
+			Expect(doc.Image.Height).To(Equal(libregraph.PtrInt32(5)))
+
+			Expect(doc.Photo).ToNot(BeNil())
+			Expect(doc.Photo.CameraMake).To(Equal(libregraph.PtrString("Canon")))
+			Expect(doc.Photo.Orientation).To(Equal(libregraph.PtrInt32(6)))
+			Expect(doc.Photo.FNumber).To(Equal(libregraph.PtrFloat64(2.8)))
+			Expect(doc.Photo.Iso).To(Equal(libregraph.PtrInt32(400)))
+			Expect(doc.Photo.ExposureNumerator).To(Equal(libregraph.PtrFloat64(1)))
+			Expect(doc.Photo.ExposureDenominator).To(Equal(libregraph.PtrFloat64(250)))
+			Expect(doc.Photo.TakenDateTime).To(Equal(libregraph.PtrTime(time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC))))
+
+			Expect(doc.Location).ToNot(BeNil())
+			Expect(*doc.Location.Latitude).To(BeNumerically("~", 52.5, 0.0001))
+			Expect(*doc.Location.Longitude).To(BeNumerically("~", -13.25, 0.0001))
+			Expect(*doc.Location.Altitude).To(BeNumerically("~", 100*3.28084, 0.0001))
+		})
+	})
+
+	Describe("audio", func() {
+		It("adds the metadata of id3 tags", func() {
+			body = id3Tag(map[string]string{
+				"TIT2": "Some Title",
+				"TPE1": "Some Artist",
+				"TPE2": "Some AlbumArtist",
+				"TALB": "Some Album",
+				"TCON": "Some Genre",
+				"TRCK": "7/9",
+				"TPOS": "4/5",
+				"TYER": "2004",
+				"TCOM": "Some Composers",
+			})
+
+			doc := extract("audio/mpeg")
+			Expect(doc.Title).To(Equal("Some Title"))
+
+			audio := doc.Audio
+			Expect(audio).ToNot(BeNil())
+			Expect(audio.Title).To(Equal(libregraph.PtrString("Some Title")))
+			Expect(audio.Artist).To(Equal(libregraph.PtrString("Some Artist")))
+			Expect(audio.AlbumArtist).To(Equal(libregraph.PtrString("Some AlbumArtist")))
+			Expect(audio.Album).To(Equal(libregraph.PtrString("Some Album")))
+			Expect(audio.Genre).To(Equal(libregraph.PtrString("Some Genre")))
+			Expect(audio.Composers).To(Equal(libregraph.PtrString("Some Composers")))
+			Expect(audio.Track).To(Equal(libregraph.PtrInt32(7)))
+			Expect(audio.TrackCount).To(Equal(libregraph.PtrInt32(9)))
+			Expect(audio.Disc).To(Equal(libregraph.PtrInt32(4)))
+			Expect(audio.DiscCount).To(Equal(libregraph.PtrInt32(5)))
+			Expect(audio.Year).To(Equal(libregraph.PtrInt32(2004)))
+		})
+	})
+})
+
+func zipFiles(files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for name, data := range files {
+		w, err := zw.Create(name)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = w.Write([]byte(data))
+		Expect(err).ToNot(HaveOccurred())
+	}
+	Expect(zw.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+// pdfFile builds a pdf document of the objects, they are numbered starting with 1.
+func pdfFile(objects []string, info int) []byte {
+	sb := &strings.Builder{}
+	sb.WriteString("%PDF-1.7\n")
+	for i, o := range objects {
+		fmt.Fprintf(sb, "%d 0 obj\n%s\nendobj\n", i+1, o)
+	}
+	fmt.Fprintf(sb, "trailer\n<< /Root 1 0 R /Info %d 0 R >>\n%%%%EOF\n", info)
+	return []byte(sb.String())
+}
+
+func pdfStream(data string, compress bool) string {
+	if !compress {
+		return fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(data), data)
+	}
+
+	buf := &bytes.Buffer{}
+	zw := zlib.NewWriter(buf)
+	_, err := zw.Write([]byte(data))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(zw.Close()).To(Succeed())
+	return fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", buf.Len(), buf.String())
+}
+
+type exifTag struct {
+	tag, typ uint16
+	count    uint32
+	value    []byte
+}
+
+// exifData builds little endian exif data with an exif and a gps directory.
+func exifData() []byte {
+	le := binary.LittleEndian
+	rational := func(v ...uint32) []byte {
+		b := make([]byte, 0, len(v)*4)
+		for _, x := range v {
+			b = le.AppendUint32(b, x)
+		}
+		return b
+	}
+	short := func(v uint16) []byte { return le.AppendUint16(nil, v) }
+	long := func(v uint32) []byte { return le.AppendUint32(nil, v) }
+
+	// ifd writes the directory at the offset, values which don't fit into an entry follow the directory
+	ifd := func(offset int, tags []exifTag) []byte {
+		dataOffset := offset + 2 + len(tags)*12 + 4
+		var entries, data []byte
+		entries = le.AppendUint16(entries, uint16(len(tags)))
+		for _, t := range tags {
+			entries = le.AppendUint16(entries, t.tag)
+			entries = le.AppendUint16(entries, t.typ)
+			entries = le.AppendUint32(entries, t.count)
+			if len(t.value) <= 4 {
+				entries = append(entries, append(t.value, make([]byte, 4-len(t.value))...)...)
+				continue
+			}
+			entries = le.AppendUint32(entries, uint32(dataOffset+len(data)))
+			data = append(data, t.value...)
+		}
+		entries = le.AppendUint32(entries, 0)
+		return append(entries, data...)
+	}
+
+	ifd0Tags := func(exifOffset, gpsOffset int) []exifTag {
+		return []exifTag{
+			{0x010F, 2, 6, []byte("Canon\x00")},
+			{0x0112, 3, 1, short(6)},
+			{0x8769, 4, 1, long(uint32(exifOffset))},
+			{0x8825, 4, 1, long(uint32(gpsOffset))},
+		}
+	}
+	exifTags := []exifTag{
+		{0x829A, 5, 1, rational(1, 250)},
+		{0x829D, 5, 1, rational(28, 10)},
+		{0x8827, 3, 1, short(400)},
+		{0x9003, 2, 20, []byte("2024:05:06 07:08:09\x00")},
+	}
+	gpsTags := []exifTag{
+		{0x0001, 2, 2, []byte("N\x00")},
+		{0x0002, 5, 3, rational(52, 1, 30, 1, 0, 1)},
+		{0x0003, 2, 2, []byte("W\x00")},
+		{0x0004, 5, 3, rational(13, 1, 15, 1, 0, 1)},
+		{0x0005, 1, 1, []byte{0}},
+		{0x0006, 5, 1, rational(100, 1)},
+	}
+
+	ifd0Size := len(ifd(8, ifd0Tags(0, 0)))
+	exifOffset := 8 + ifd0Size
+	gpsOffset := exifOffset + len(ifd(exifOffset, exifTags))
+
+	header := append([]byte("II"), le.AppendUint16(nil, 42)...)
+	header = le.AppendUint32(header, 8)
+	data := append(header, ifd(8, ifd0Tags(exifOffset, gpsOffset))...)
+	data = append(data, ifd(exifOffset, exifTags)...)
+	return append(data, ifd(gpsOffset, gpsTags)...)
+}
+
+// id3Tag builds an id3v2.3 tag with latin-1 encoded text frames followed by some audio data.
+func id3Tag(frames map[string]string) []byte {
+	var data []byte
+	for id, v := range frames {
+		data = append(data, id...)
+		data = binary.BigEndian.AppendUint32(data, uint32(len(v)+1))
+		data = append(data, 0, 0, 0)
+		data = append(data, v...)
+	}
+
+	size := len(data)
+	header := []byte{'I', 'D', '3', 3, 0, 0, byte(size >> 21 & 0x7F), byte(size >> 14 & 0x7F), byte(size >> 7 & 0x7F), byte(size & 0x7F)}
+	return append(append(header, data...), 0xFF, 0xFB, 0x90, 0x00)
+}
@@ -0,0 +1,126 @@
+package content
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// parseText adds plain text files to the content.
+func parseText(_ context.Context, data []byte, _ *Document, text *textBuilder) error {
+	_, err := text.WriteString(decodeText(data))
+	return err
+}
+
+// parseMarkdown adds markdown files to the content, the first heading is used as title.
+func parseMarkdown(_ context.Context, data []byte, doc *Document, text *textBuilder) error {
+	s := decodeText(data)
+	for _, line := range strings.Split(s, "\n") {
+		if title, ok := strings.CutPrefix(strings.TrimSpace(line), "# "); ok {
+			doc.Title = title
+			break
+		}
+	}
+
+	_, err := text.WriteString(s)
+	return err
+}
+
+// _htmlBlockElements are the elements which separate their text from the surrounding text.
+var _htmlBlockElements = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true, atom.Br: true,
+	atom.Dd: true, atom.Div: true, atom.Dt: true, atom.Figcaption: true, atom.Footer: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Header: true, atom.Hr: true, atom.Li: true, atom.Main: true, atom.Nav: true, atom.P: true,
+	atom.Pre: true, atom.Section: true, atom.Td: true, atom.Th: true, atom.Tr: true,
+}
+
+// parseHTML adds the visible text of html files to the content, the title element is used as title.
+func parseHTML(ctx context.Context, data []byte, doc *Document, text *textBuilder) error {
+	r, err := charset.NewReader(bytes.NewReader(data), "text/html")
+	if err != nil {
+		return err
+	}
+
+	var (
+		z       = html.NewTokenizer(r)
+		skip    int
+		inTitle bool
+	)
+	for {
+		switch tt := z.Next(); tt {
+		case html.ErrorToken:
+			if err := z.Err(); err != io.EOF {
+				return err
+			}
+			return nil
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			a := atom.Lookup(name)
+			if _htmlBlockElements[a] {
+				if _, err := text.WriteString("\n"); err != nil {
+					return err
+				}
+			}
+			switch {
+			case tt == html.SelfClosingTagToken:
+			case a == atom.Script, a == atom.Style, a == atom.Noscript, a == atom.Template:
+				skip++
+			case a == atom.Title:
+				inTitle = true
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			a := atom.Lookup(name)
+			switch a {
+			case atom.Script, atom.Style, atom.Noscript, atom.Template:
+				skip = max(skip-1, 0)
+			case atom.Title:
+				inTitle = false
+			}
+			if _htmlBlockElements[a] {
+				if _, err := text.WriteString("\n"); err != nil {
+					return err
+				}
+			}
+		case html.TextToken:
+			switch {
+			case inTitle:
+				doc.Title += string(z.Text())
+			case skip == 0:
+				if _, err := text.WriteString(string(z.Text())); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// decodeText returns the text of data, which is either utf-8, utf-16 with byte order mark or windows-1252 encoded.
+func decodeText(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return string(data[3:])
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}), bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		if s, err := unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM).NewDecoder().Bytes(data); err == nil {
+			return string(s)
+		}
+	case utf8.Valid(data):
+		return string(data)
+	}
+
+	s, _ := charmap.Windows1252.NewDecoder().Bytes(data)
+	return string(s)
+}
@@ -0,0 +1,70 @@
+// Package embedding provides the ability to compute embeddings of texts, which are used for the similarity search.
+package embedding
+
+import (
+	"context"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/owncloud/ocis/v2/services/search/pkg/content"
+)
+
+// Embedder is the interface to compute the embeddings of texts.
+type Embedder interface {
+	// Embed returns one embedding for each of the texts.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// Text returns the text of the document which is embedded,
+// it is truncated to the given number of characters if it is longer.
+func Text(doc content.Document, maxLength int) string {
+	parts := make([]string, 0, 3)
+	for _, p := range []string{doc.Name, doc.Title, doc.Content} {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	text := strings.Join(parts, "\n")
+
+	if maxLength > 0 && utf8.RuneCountInString(text) > maxLength {
+		text = string([]rune(text)[:maxLength])
+	}
+	return text
+}
+
+// Similarity returns the cosine similarity of both vectors scaled to the range 0 to 1,
+// vectors of different length or without magnitude have a similarity of 0.
+func Similarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+
+	return (1 + dot/(math.Sqrt(na)*math.Sqrt(nb))) / 2
+}
+
+func normalize(v []float32) []float32 {
+	var n float64
+	for _, x := range v {
+		n += float64(x) * float64(x)
+	}
+	if n == 0 {
+		return v
+	}
+
+	n = math.Sqrt(n)
+	for i := range v {
+		v[i] = float32(float64(v[i]) / n)
+	}
+	return v
+}
@@ -0,0 +1,36 @@
+package embedding_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/owncloud/ocis/v2/services/search/pkg/content"
+	"github.com/owncloud/ocis/v2/services/search/pkg/embedding"
+)
+
+var _ = Describe("Embedding", func() {
+	Describe("Text", func() {
+		It("joins the name, title and content", func() {
+			text := embedding.Text(content.Document{Name: "lease.pdf", Title: " ", Content: "rental agreement"}, 0)
+			Expect(text).To(Equal("lease.pdf\nrental agreement"))
+		})
+
+		It("truncates the text by characters", func() {
+			text := embedding.Text(content.Document{Name: "übersicht.pdf"}, 3)
+			Expect(text).To(Equal("übe"))
+		})
+	})
+
+	Describe("Similarity", func() {
+		It("scales the cosine similarity", func() {
+			Expect(embedding.Similarity([]float32{1, 0}, []float32{2, 0})).To(BeNumerically("~", 1, 0.0001))
+			Expect(embedding.Similarity([]float32{1, 0}, []float32{0, 1})).To(BeNumerically("~", 0.5, 0.0001))
+			Expect(embedding.Similarity([]float32{1, 0}, []float32{-1, 0})).To(BeNumerically("~", 0, 0.0001))
+		})
+
+		It("returns 0 for incomparable vectors", func() {
+			Expect(embedding.Similarity([]float32{1, 0}, []float32{1, 0, 0})).To(Equal(0.0))
+			Expect(embedding.Similarity([]float32{0, 0}, []float32{1, 0})).To(Equal(0.0))
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package embedding
+
+import (
+	"context"
+	"hash/fnv"
+	"strings"
+	"unicode"
+
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+)
+
+// Hashing computes embeddings locally by hashing the words and character trigrams of a text.
+// It needs no model and works offline, but it only captures the shared vocabulary of texts,
+// not their meaning. Use it for testing or if no embedding model is available.
+type Hashing struct {
+	dimension int
+}
+
+// NewHashingEmbedder creates a new Hashing instance.
+func NewHashingEmbedder(cfg *config.Config) *Hashing {
+	return &Hashing{
+		dimension: cfg.Embedding.Dimension,
+	}
+}
+
+// Embed returns the normalized feature hashes of the texts.
+func (h *Hashing) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = h.embed(text)
+	}
+	return vectors, nil
+}
+
+func (h *Hashing) embed(text string) []float32 {
+	v := make([]float32, h.dimension)
+	if h.dimension == 0 {
+		return v
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, word := range words {
+		h.add(v, word, 1)
+
+		// trigrams match inflected forms like 'lease' and 'leased'
+		runes := []rune("_" + word + "_")
+		for i := 0; i+3 <= len(runes); i++ {
+			h.add(v, string(runes[i:i+3]), 0.5)
+		}
+	}
+
+	return normalize(v)
+}
+
+func (h *Hashing) add(v []float32, feature string, weight float32) {
+	f := fnv.New64a()
+	_, _ = f.Write([]byte(feature))
+	sum := f.Sum64()
+
+	// the sign bit reduces the bias of hash collisions
+	if sum&(1<<63) != 0 {
+		weight = -weight
+	}
+	v[sum%uint64(h.dimension)] += weight
+}
@@ -0,0 +1,38 @@
+package embedding_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+	"github.com/owncloud/ocis/v2/services/search/pkg/embedding"
+)
+
+var _ = Describe("Hashing", func() {
+	var h *embedding.Hashing
+
+	BeforeEach(func() {
+		h = embedding.NewHashingEmbedder(&config.Config{Embedding: config.Embedding{Dimension: 256}})
+	})
+
+	It("returns one normalized embedding per text", func() {
+		vectors, err := h.Embed(context.Background(), []string{"the lease agreement", ""})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vectors).To(HaveLen(2))
+		Expect(vectors[0]).To(HaveLen(256))
+		Expect(embedding.Similarity(vectors[0], vectors[0])).To(BeNumerically("~", 1, 0.0001))
+		Expect(vectors[1]).To(HaveLen(256))
+	})
+
+	It("ranks texts with a shared vocabulary higher", func() {
+		vectors, err := h.Embed(context.Background(), []string{
+			"Lease agreement for the apartment",
+			"The apartment was leased, see the agreement",
+			"Quarterly revenue report",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(embedding.Similarity(vectors[0], vectors[1])).To(BeNumerically(">", embedding.Similarity(vectors[0], vectors[2])))
+	})
+})
@@ -0,0 +1,88 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+)
+
+// HTTP computes embeddings with an OpenAI compatible embeddings endpoint,
+// which is offered by most model servers like ollama, LocalAI or vLLM.
+type HTTP struct {
+	client    *http.Client
+	url       string
+	model     string
+	apiKey    string
+	dimension int
+}
+
+// NewHTTPEmbedder creates a new HTTP instance.
+func NewHTTPEmbedder(cfg *config.Config) *HTTP {
+	return &HTTP{
+		client:    &http.Client{Timeout: cfg.Embedding.HTTP.Timeout},
+		url:       cfg.Embedding.HTTP.URL,
+		model:     cfg.Embedding.HTTP.Model,
+		apiKey:    cfg.Embedding.HTTP.APIKey,
+		dimension: cfg.Embedding.Dimension,
+	}
+}
+
+// Embed sends the texts to the endpoint and returns the embeddings in the same order.
+func (h *HTTP) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"model": h.model,
+		"input": texts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	}
+
+	res, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status from the embeddings endpoint: %s", res.Status)
+	}
+
+	var embeddings struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&embeddings); err != nil {
+		return nil, err
+	}
+	if len(embeddings.Data) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(embeddings.Data))
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range embeddings.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("invalid embedding index %d", d.Index)
+		}
+		if len(d.Embedding) != h.dimension {
+			return nil, fmt.Errorf("expected embeddings with %d dimensions, got %d, check SEARCH_EMBEDDING_DIMENSION", h.dimension, len(d.Embedding))
+		}
+		vectors[d.Index] = d.Embedding
+	}
+
+	return vectors, nil
+}
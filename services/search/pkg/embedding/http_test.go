@@ -0,0 +1,73 @@
+package embedding_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+	"github.com/owncloud/ocis/v2/services/search/pkg/embedding"
+)
+
+var _ = Describe("HTTP", func() {
+	var (
+		srv      *httptest.Server
+		request  map[string]interface{}
+		auth     string
+		status   int
+		response string
+		h        *embedding.HTTP
+	)
+
+	BeforeEach(func() {
+		status = http.StatusOK
+		response = `{"data": [{"index": 1, "embedding": [0, 1]}, {"index": 0, "embedding": [1, 0]}]}`
+		srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			auth = req.Header.Get("Authorization")
+			Expect(json.NewDecoder(req.Body).Decode(&request)).To(Succeed())
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(response))
+		}))
+
+		cfg := &config.Config{Embedding: config.Embedding{
+			Dimension: 2,
+			HTTP: config.EmbeddingHTTP{
+				URL:     srv.URL,
+				Model:   "nomic-embed-text",
+				APIKey:  "secret",
+				Timeout: time.Second,
+			},
+		}}
+		h = embedding.NewHTTPEmbedder(cfg)
+	})
+
+	AfterEach(func() {
+		srv.Close()
+	})
+
+	It("returns the embeddings in the order of the texts", func() {
+		vectors, err := h.Embed(context.Background(), []string{"foo", "bar"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vectors).To(Equal([][]float32{{1, 0}, {0, 1}}))
+		Expect(request["model"]).To(Equal("nomic-embed-text"))
+		Expect(request["input"]).To(Equal([]interface{}{"foo", "bar"}))
+		Expect(auth).To(Equal("Bearer secret"))
+	})
+
+	It("fails on unexpected status codes", func() {
+		status = http.StatusInternalServerError
+		_, err := h.Embed(context.Background(), []string{"foo", "bar"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails if the dimension doesn't match", func() {
+		response = `{"data": [{"index": 0, "embedding": [1, 0, 0]}, {"index": 1, "embedding": [0, 1, 0]}]}`
+		_, err := h.Embed(context.Background(), []string{"foo", "bar"})
+		Expect(err).To(MatchError(ContainSubstring("SEARCH_EMBEDDING_DIMENSION")))
+	})
+})
@@ -2,11 +2,14 @@ package engine
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/binary"
 	"errors"
 	"math"
 	"path"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,6 +21,7 @@ import (
 	"github.com/blevesearch/bleve/v2/analysis/tokenizer/single"
 	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
 	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search"
 	"github.com/blevesearch/bleve/v2/search/query"
 	storageProvider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -28,16 +32,31 @@ import (
 
 	libregraph "github.com/owncloud/libre-graph-api-go"
 
+	"github.com/owncloud/ocis/v2/ocis-pkg/kql"
 	searchMessage "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
 	searchService "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
 	"github.com/owncloud/ocis/v2/services/search/pkg/content"
+	"github.com/owncloud/ocis/v2/services/search/pkg/embedding"
 	searchQuery "github.com/owncloud/ocis/v2/services/search/pkg/query"
 )
 
 // Bleve represents a search engine which utilizes bleve to search and store resources.
 type Bleve struct {
-	index        bleve.Index
-	queryCreator searchQuery.Creator[query.Query]
+	index                bleve.Index
+	queryCreator         searchQuery.Creator[query.Query]
+	similarityCandidates int
+}
+
+// BleveOption configures the Bleve engine
+type BleveOption func(*Bleve)
+
+// BleveSimilarityCandidates sets the maximum number of resources whose embeddings are compared by a similarity search
+func BleveSimilarityCandidates(n int) BleveOption {
+	return func(b *Bleve) {
+		if n > 0 {
+			b.similarityCandidates = n
+		}
+	}
 }
 
 // NewBleveIndex returns a new bleve index
@@ -62,11 +81,16 @@ func NewBleveIndex(root string) (bleve.Index, error) {
 }
 
 // NewBleveEngine creates a new Bleve instance
-func NewBleveEngine(index bleve.Index, queryCreator searchQuery.Creator[query.Query]) *Bleve {
-	return &Bleve{
-		index:        index,
-		queryCreator: queryCreator,
+func NewBleveEngine(index bleve.Index, queryCreator searchQuery.Creator[query.Query], opts ...BleveOption) *Bleve {
+	b := &Bleve{
+		index:                index,
+		queryCreator:         queryCreator,
+		similarityCandidates: _bleveSimilarityCandidates,
+	}
+	for _, o := range opts {
+		o(b)
 	}
+	return b
 }
 
 // BuildBleveMapping builds a bleve index mapping which can be used for indexing
@@ -87,6 +111,15 @@ func BuildBleveMapping() (mapping.IndexMapping, error) {
 	docMapping.AddFieldMappingsAt("Tags", lowercaseMapping)
 	docMapping.AddFieldMappingsAt("Content", fulltextFieldMapping)
 
+	// the embedding is stored encoded, bleve can only index vectors when it is built with faiss
+	embeddingMapping := bleve.NewTextFieldMapping()
+	embeddingMapping.Index = false
+	embeddingMapping.Store = true
+	embeddingMapping.IncludeInAll = false
+	embeddingMapping.IncludeTermVectors = false
+	docMapping.AddFieldMappingsAt("EmbeddingData", embeddingMapping)
+	docMapping.AddSubDocumentMapping("Embedding", bleve.NewDocumentDisabledMapping())
+
 	indexMapping := bleve.NewIndexMapping()
 	indexMapping.DefaultAnalyzer = keyword.Name
 	indexMapping.DefaultMapping = docMapping
@@ -123,41 +156,19 @@ func BuildBleveMapping() (mapping.IndexMapping, error) {
 // Search executes a search request operation within the index.
 // Returns a SearchIndexResponse object or an error.
 func (b *Bleve) Search(ctx context.Context, sir *searchService.SearchIndexRequest) (*searchService.SearchIndexResponse, error) {
-	createdQuery, err := b.queryCreator.Create(sir.Query)
-	if err != nil {
-		if searchQuery.IsValidationError(err) {
-			return nil, errtypes.BadRequest(err.Error())
-		}
+	if err := validateFacets(sir.Facets); err != nil {
 		return nil, err
 	}
 
-	q := bleve.NewConjunctionQuery(
-		// Skip documents that have been marked as deleted
-		&query.BoolFieldQuery{
-			Bool:     false,
-			FieldVal: "Deleted",
-		},
-		createdQuery,
-	)
-
-	if sir.Ref != nil {
-		q.Conjuncts = append(
-			q.Conjuncts,
-			&query.TermQuery{
-				FieldVal: "RootID",
-				Term: storagespace.FormatResourceID(
-					&storageProvider.ResourceId{
-						StorageId: sir.Ref.GetResourceId().GetStorageId(),
-						SpaceId:   sir.Ref.GetResourceId().GetSpaceId(),
-						OpaqueId:  sir.Ref.GetResourceId().GetOpaqueId(),
-					},
-				),
-			},
-		)
+	createdQuery, err := b.createQuery(sir.Query)
+	if err != nil {
+		return nil, err
 	}
+	q := scopedQuery(sir, createdQuery)
 
 	bleveReq := bleve.NewSearchRequest(q)
 	bleveReq.Highlight = bleve.NewHighlight()
+	addBleveFacets(bleveReq, sir.Facets)
 
 	switch {
 	case sir.PageSize == -1:
@@ -177,53 +188,134 @@ func (b *Bleve) Search(ctx context.Context, sir *searchService.SearchIndexReques
 	matches := make([]*searchMessage.Match, 0, len(res.Hits))
 	totalMatches := res.Total
 	for _, hit := range res.Hits {
-		if sir.Ref != nil {
-			hitPath := strings.TrimSuffix(getFieldValue[string](hit.Fields, "Path"), "/")
-			requestedPath := utils.MakeRelativePath(sir.Ref.Path)
-			isRoot := hitPath == requestedPath
-
-			if !isRoot && requestedPath != "." && !strings.HasPrefix(hitPath, requestedPath+"/") {
-				totalMatches--
-				continue
-			}
+		if !inRequestedPath(sir, hit.Fields) {
+			totalMatches--
+			continue
 		}
 
-		rootID, err := storagespace.ParseID(getFieldValue[string](hit.Fields, "RootID"))
+		match, err := matchFromFields(hit.Fields, hit.Score, getFragmentValue(hit.Fragments, "Content", 0))
 		if err != nil {
 			return nil, err
 		}
 
-		rID, err := storagespace.ParseID(getFieldValue[string](hit.Fields, "ID"))
+		matches = append(matches, match)
+	}
+
+	return &searchService.SearchIndexResponse{
+		Matches:      matches,
+		TotalMatches: int32(totalMatches),
+		Facets:       bleveFacets(res.Facets, sir.Facets),
+	}, nil
+}
+
+// _bleveSimilarityCandidates is the default maximum number of resources whose embeddings are compared by a similarity search.
+// Bleve has no vector index, the most recently modified resources matching the request are compared.
+const _bleveSimilarityCandidates = 10000
+
+// SearchSimilar executes a search request operation within the index and orders the results by the similarity
+// of their embedding to the given vector. The similarity is computed for the most recently modified resources
+// matching the request, at most the configured number of similarity candidates.
+func (b *Bleve) SearchSimilar(_ context.Context, sir *searchService.SearchIndexRequest, vector []float32, minScore float64) (*searchService.SearchIndexResponse, error) {
+	if err := validateFacets(sir.Facets); err != nil {
+		return nil, err
+	}
+
+	var createdQuery query.Query
+	if sir.Query != "" {
+		var err error
+		if createdQuery, err = b.createQuery(sir.Query); err != nil {
+			return nil, err
+		}
+	}
+
+	// only the fields needed to rank the candidates are loaded
+	bleveReq := bleve.NewSearchRequest(scopedQuery(sir, createdQuery))
+	bleveReq.Size = b.similarityCandidates
+	bleveReq.Fields = []string{"Path", "EmbeddingData"}
+	bleveReq.SortBy([]string{"-Mtime"})
+	res, err := b.index.Search(bleveReq)
+	if err != nil {
+		return nil, err
+	}
+
+	type candidate struct {
+		id    string
+		score float64
+	}
+	candidates := make([]candidate, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		if !inRequestedPath(sir, hit.Fields) {
+			continue
+		}
+
+		e, err := decodeEmbedding(getFieldValue[string](hit.Fields, "EmbeddingData"))
+		if err != nil || len(e) == 0 {
+			continue
+		}
+		if score := embedding.Similarity(vector, e); score >= minScore {
+			candidates = append(candidates, candidate{id: hit.ID, score: score})
+		}
+	}
+
+	// the facets are counted for all similar resources, not only for the page
+	var facets []*searchMessage.Facet
+	if len(sir.Facets) > 0 && len(candidates) > 0 {
+		ids := make([]string, 0, len(candidates))
+		for _, c := range candidates {
+			ids = append(ids, c.id)
+		}
+		facetReq := bleve.NewSearchRequest(bleve.NewDocIDQuery(ids))
+		facetReq.Size = 0
+		addBleveFacets(facetReq, sir.Facets)
+		facetRes, err := b.index.Search(facetReq)
 		if err != nil {
 			return nil, err
 		}
+		facets = bleveFacets(facetRes.Facets, sir.Facets)
+	}
 
-		pID, _ := storagespace.ParseID(getFieldValue[string](hit.Fields, "ParentID"))
-		match := &searchMessage.Match{
-			Score: float32(hit.Score),
-			Entity: &searchMessage.Entity{
-				Ref: &searchMessage.Reference{
-					ResourceId: resourceIDtoSearchID(rootID),
-					Path:       getFieldValue[string](hit.Fields, "Path"),
-				},
-				Id:         resourceIDtoSearchID(rID),
-				Name:       getFieldValue[string](hit.Fields, "Name"),
-				ParentId:   resourceIDtoSearchID(pID),
-				Size:       uint64(getFieldValue[float64](hit.Fields, "Size")),
-				Type:       uint64(getFieldValue[float64](hit.Fields, "Type")),
-				MimeType:   getFieldValue[string](hit.Fields, "MimeType"),
-				Deleted:    getFieldValue[bool](hit.Fields, "Deleted"),
-				Tags:       getFieldSliceValue[string](hit.Fields, "Tags"),
-				Highlights: getFragmentValue(hit.Fragments, "Content", 0),
-				Audio:      getAudioValue[searchMessage.Audio](hit.Fields),
-				Image:      getImageValue[searchMessage.Image](hit.Fields),
-				Location:   getLocationValue[searchMessage.GeoCoordinates](hit.Fields),
-				Photo:      getPhotoValue[searchMessage.Photo](hit.Fields),
-			},
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+	totalMatches := len(candidates)
+	switch {
+	case sir.PageSize == -1:
+	case sir.PageSize == 0 && len(candidates) > 200:
+		candidates = candidates[:200]
+	case sir.PageSize > 0 && len(candidates) > int(sir.PageSize):
+		candidates = candidates[:sir.PageSize]
+	}
+	if len(candidates) == 0 {
+		return &searchService.SearchIndexResponse{TotalMatches: int32(totalMatches), Facets: facets}, nil
+	}
+
+	// load the resources of the page
+	ids := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		ids = append(ids, c.id)
+	}
+	pageReq := bleve.NewSearchRequest(bleve.NewDocIDQuery(ids))
+	pageReq.Size = len(ids)
+	pageReq.Fields = []string{"*"}
+	pageRes, err := b.index.Search(pageReq)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]map[string]interface{}, len(pageRes.Hits))
+	for _, hit := range pageRes.Hits {
+		fields[hit.ID] = hit.Fields
+	}
+
+	matches := make([]*searchMessage.Match, 0, len(candidates))
+	for _, c := range candidates {
+		f, ok := fields[c.id]
+		if !ok {
+			continue
 		}
 
-		if mtime, err := time.Parse(time.RFC3339, getFieldValue[string](hit.Fields, "Mtime")); err == nil {
-			match.Entity.LastModifiedTime = &timestamppb.Timestamp{Seconds: mtime.Unix(), Nanos: int32(mtime.Nanosecond())}
+		match, err := matchFromFields(f, c.score, "")
+		if err != nil {
+			return nil, err
 		}
 
 		matches = append(matches, match)
@@ -232,12 +324,13 @@ func (b *Bleve) Search(ctx context.Context, sir *searchService.SearchIndexReques
 	return &searchService.SearchIndexResponse{
 		Matches:      matches,
 		TotalMatches: int32(totalMatches),
+		Facets:       facets,
 	}, nil
 }
 
 // Upsert indexes or stores Resource data fields.
 func (b *Bleve) Upsert(id string, r Resource) error {
-	return b.index.Index(id, r)
+	return b.index.Index(id, newBleveResource(r))
 }
 
 // Move updates the resource location and all of its necessary fields.
@@ -308,6 +401,37 @@ func (b *Bleve) DocCount() (uint64, error) {
 	return b.index.DocCount()
 }
 
+// Resources returns all resources of the space with the given root id, including the deleted ones.
+// Only the fields which are needed to compare the resources with the storage are loaded.
+func (b *Bleve) Resources(rootID string) ([]Resource, error) {
+	q := bleve.NewTermQuery(rootID)
+	q.SetField("RootID")
+	bleveReq := bleve.NewSearchRequest(q)
+	bleveReq.Size = math.MaxInt
+	bleveReq.Fields = []string{"ID", "ParentID", "Path", "Type", "Mtime", "Size", "Deleted"}
+	res, err := b.index.Search(bleveReq)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(res.Hits))
+	for _, h := range res.Hits {
+		r := Resource{
+			ID:       h.ID,
+			RootID:   rootID,
+			ParentID: getFieldValue[string](h.Fields, "ParentID"),
+			Path:     getFieldValue[string](h.Fields, "Path"),
+			Type:     uint64(getFieldValue[float64](h.Fields, "Type")),
+			Deleted:  getFieldValue[bool](h.Fields, "Deleted"),
+		}
+		r.Mtime = getFieldValue[string](h.Fields, "Mtime")
+		r.Size = uint64(getFieldValue[float64](h.Fields, "Size"))
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
 func (b *Bleve) getResource(id string) (*Resource, error) {
 	req := bleve.NewSearchRequest(bleve.NewDocIDQuery([]string{id}))
 	req.Fields = []string{"*"}
@@ -321,13 +445,19 @@ func (b *Bleve) getResource(id string) (*Resource, error) {
 
 	fields := res.Hits[0].Fields
 
+	e, err := decodeEmbedding(getFieldValue[string](fields, "EmbeddingData"))
+	if err != nil {
+		return nil, err
+	}
+
 	return &Resource{
-		ID:       getFieldValue[string](fields, "ID"),
-		RootID:   getFieldValue[string](fields, "RootID"),
-		Path:     getFieldValue[string](fields, "Path"),
-		ParentID: getFieldValue[string](fields, "ParentID"),
-		Type:     uint64(getFieldValue[float64](fields, "Type")),
-		Deleted:  getFieldValue[bool](fields, "Deleted"),
+		ID:        getFieldValue[string](fields, "ID"),
+		RootID:    getFieldValue[string](fields, "RootID"),
+		Path:      getFieldValue[string](fields, "Path"),
+		ParentID:  getFieldValue[string](fields, "ParentID"),
+		Type:      uint64(getFieldValue[float64](fields, "Type")),
+		Deleted:   getFieldValue[bool](fields, "Deleted"),
+		Embedding: e,
 		Document: content.Document{
 			Name:     getFieldValue[string](fields, "Name"),
 			Title:    getFieldValue[string](fields, "Title"),
@@ -454,7 +584,7 @@ func (b *Bleve) updateEntity(id string, mutateFunc func(r *Resource)) (*Resource
 
 	mutateFunc(it)
 
-	return it, b.index.Index(it.ID, it)
+	return it, b.index.Index(it.ID, newBleveResource(*it))
 }
 
 func (b *Bleve) setDeleted(id string, deleted bool) error {
@@ -490,3 +620,181 @@ func (b *Bleve) setDeleted(id string, deleted bool) error {
 
 	return nil
 }
+
+func (b *Bleve) createQuery(qs string) (query.Query, error) {
+	createdQuery, err := b.queryCreator.Create(qs)
+	if err != nil {
+		if searchQuery.IsValidationError(err) {
+			return nil, errtypes.BadRequest(err.Error())
+		}
+		return nil, err
+	}
+	return createdQuery, nil
+}
+
+// scopedQuery restricts the created query to the resources which are not deleted and belong to the requested space,
+// the created query is optional.
+func scopedQuery(sir *searchService.SearchIndexRequest, createdQuery query.Query) *query.ConjunctionQuery {
+	q := bleve.NewConjunctionQuery(
+		// Skip documents that have been marked as deleted
+		&query.BoolFieldQuery{
+			Bool:     false,
+			FieldVal: "Deleted",
+		},
+	)
+
+	if createdQuery != nil {
+		q.Conjuncts = append(q.Conjuncts, createdQuery)
+	}
+
+	if sir.Ref != nil {
+		q.Conjuncts = append(
+			q.Conjuncts,
+			&query.TermQuery{
+				FieldVal: "RootID",
+				Term: storagespace.FormatResourceID(
+					&storageProvider.ResourceId{
+						StorageId: sir.Ref.GetResourceId().GetStorageId(),
+						SpaceId:   sir.Ref.GetResourceId().GetSpaceId(),
+						OpaqueId:  sir.Ref.GetResourceId().GetOpaqueId(),
+					},
+				),
+			},
+		)
+
+		// the facets are counted by the index, resources outside the requested path must not be counted
+		if requestedPath := utils.MakeRelativePath(sir.Ref.Path); requestedPath != "." {
+			q.Conjuncts = append(q.Conjuncts, bleve.NewDisjunctionQuery(
+				&query.TermQuery{FieldVal: "Path", Term: requestedPath},
+				&query.PrefixQuery{FieldVal: "Path", Prefix: requestedPath + "/"},
+			))
+		}
+	}
+
+	return q
+}
+
+// addBleveFacets adds the requests of the facets which are counted by the index
+func addBleveFacets(req *bleve.SearchRequest, facets []string) {
+	for _, f := range facets {
+		switch f {
+		case FacetMediaType:
+			req.AddFacet(f, bleve.NewFacetRequest("MimeType", _facetTermsSize))
+		case FacetTag:
+			req.AddFacet(f, bleve.NewFacetRequest("Tags", _facetTermsSize))
+		case FacetMtime:
+			fr := bleve.NewFacetRequest("Mtime", len(kql.TimeRanges))
+			for _, r := range timeRanges() {
+				fr.AddDateTimeRange(r.name, r.from, r.to)
+			}
+			req.AddFacet(f, fr)
+		case FacetSize:
+			fr := bleve.NewFacetRequest("Size", len(_sizeRanges))
+			for _, r := range _sizeRanges {
+				var from, to *float64
+				if r.min > 0 {
+					from = &r.min
+				}
+				if r.max > 0 {
+					to = &r.max
+				}
+				fr.AddNumericRange(r.name, from, to)
+			}
+			req.AddFacet(f, fr)
+		}
+	}
+}
+
+// bleveFacets converts the facet results of the index in the order of the requested facets
+func bleveFacets(results search.FacetResults, facets []string) []*searchMessage.Facet {
+	var converted []*searchMessage.Facet
+	for _, f := range facets {
+		result, ok := results[f]
+		if !ok {
+			continue
+		}
+
+		counts := make(map[string]int64)
+		for _, t := range result.Terms.Terms() {
+			counts[t.Term] = int64(t.Count)
+		}
+		for _, r := range result.NumericRanges {
+			counts[r.Name] = int64(r.Count)
+		}
+		for _, r := range result.DateRanges {
+			counts[r.Name] = int64(r.Count)
+		}
+
+		switch f {
+		case FacetMediaType:
+			converted = append(converted, mediaTypeFacet(counts))
+		case FacetTag:
+			converted = append(converted, termsFacet(f, counts))
+		case FacetMtime:
+			converted = append(converted, rangesFacet(f, kql.TimeRanges, counts))
+		case FacetSize:
+			converted = append(converted, rangesFacet(f, sizeRangeNames(), counts))
+		}
+	}
+	return converted
+}
+
+// inRequestedPath checks if the resource is located below the path of the request
+func inRequestedPath(sir *searchService.SearchIndexRequest, fields map[string]interface{}) bool {
+	if sir.Ref == nil {
+		return true
+	}
+
+	hitPath := strings.TrimSuffix(getFieldValue[string](fields, "Path"), "/")
+	requestedPath := utils.MakeRelativePath(sir.Ref.Path)
+	isRoot := hitPath == requestedPath
+
+	return isRoot || requestedPath == "." || strings.HasPrefix(hitPath, requestedPath+"/")
+}
+
+// bleveResource is the document which is stored in the bleve index
+type bleveResource struct {
+	Resource
+
+	EmbeddingData string `json:"EmbeddingData,omitempty"`
+}
+
+func newBleveResource(r Resource) bleveResource {
+	return bleveResource{
+		Resource:      r,
+		EmbeddingData: encodeEmbedding(r.Embedding),
+	}
+}
+
+// encodeEmbedding encodes the vector as base64 of the little endian float32 values
+func encodeEmbedding(v []float32) string {
+	if len(v) == 0 {
+		return ""
+	}
+
+	b := make([]byte, 4*len(v))
+	for i, f := range v {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(f))
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func decodeEmbedding(s string) ([]float32, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b)%4 != 0 {
+		return nil, errors.New("invalid embedding")
+	}
+
+	v := make([]float32, len(b)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return v, nil
+}
@@ -3,6 +3,7 @@ package engine_test
 import (
 	"context"
 	"fmt"
+	"time"
 
 	bleveSearch "github.com/blevesearch/bleve/v2"
 	sprovider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
@@ -356,6 +357,174 @@ var _ = Describe("Bleve", func() {
 
 	})
 
+	Describe("Facets", func() {
+		BeforeEach(func() {
+			parentResource.MimeType = "httpd/unix-directory"
+			parentResource.Mtime = time.Now().UTC().Format(time.RFC3339Nano)
+			childResource.MimeType = "application/pdf"
+			childResource.Size = 2 << 20
+			childResource.Tags = []string{"Foo", "bar"}
+			childResource.Mtime = "2020-01-01T00:00:00Z"
+			Expect(eng.Upsert(parentResource.ID, parentResource)).To(Succeed())
+			Expect(eng.Upsert(childResource.ID, childResource)).To(Succeed())
+		})
+
+		search := func(path string, facets ...string) []*searchmsg.Facet {
+			rID, err := storagespace.ParseID(rootResource.ID)
+			Expect(err).ToNot(HaveOccurred())
+
+			res, err := eng.Search(context.Background(), &searchsvc.SearchIndexRequest{
+				Query: "name:*",
+				Ref: &searchmsg.Reference{
+					ResourceId: &searchmsg.ResourceID{StorageId: rID.StorageId, SpaceId: rID.SpaceId, OpaqueId: rID.OpaqueId},
+					Path:       path,
+				},
+				Facets: facets,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			return res.Facets
+		}
+
+		It("counts the matches by mediatype", func() {
+			facets := search("", engine.FacetMediaType)
+			Expect(facets).To(HaveLen(1))
+			Expect(facets[0].Name).To(Equal(engine.FacetMediaType))
+			Expect(facets[0].Values).To(ConsistOf(
+				HaveField("Value", "file"), HaveField("Value", "folder"), HaveField("Value", "pdf"),
+			))
+			for _, v := range facets[0].Values {
+				Expect(v.Count).To(Equal(int64(1)))
+			}
+		})
+
+		It("counts the matches by tag, mtime and size", func() {
+			facets := search("", engine.FacetTag, engine.FacetMtime, engine.FacetSize)
+			Expect(facets).To(HaveLen(3))
+
+			Expect(facets[0].Values).To(HaveLen(2))
+			Expect(facets[0].Values[0].Value).To(Equal("bar"))
+			Expect(facets[0].Values[1].Value).To(Equal("foo"))
+
+			Expect(facets[1].Name).To(Equal(engine.FacetMtime))
+			Expect(facets[1].Values[0].Value).To(Equal("today"))
+			Expect(facets[1].Values[0].Count).To(Equal(int64(1)))
+
+			Expect(facets[2].Values).To(HaveLen(2))
+			Expect(facets[2].Values[0].Value).To(Equal("small"))
+			Expect(facets[2].Values[1].Value).To(Equal("medium"))
+		})
+
+		It("only counts the matches in the requested path", func() {
+			facets := search("./parent d!r", engine.FacetMediaType)
+			Expect(facets[0].Values).To(ConsistOf(
+				HaveField("Value", "file"), HaveField("Value", "folder"), HaveField("Value", "pdf"),
+			))
+
+			Expect(eng.Upsert("1$2!5", engine.Resource{
+				ID:       "1$2!5",
+				ParentID: rootResource.ID,
+				RootID:   rootResource.ID,
+				Path:     "./other.png",
+				Document: content.Document{Name: "other.png", MimeType: "image/png"},
+			})).To(Succeed())
+			facets = search("./parent d!r", engine.FacetMediaType)
+			Expect(facets[0].Values).ToNot(ContainElement(HaveField("Value", "image")))
+			facets = search("", engine.FacetMediaType)
+			Expect(facets[0].Values).To(ContainElement(HaveField("Value", "image")))
+		})
+
+		It("rejects unknown facets", func() {
+			_, err := eng.Search(context.Background(), &searchsvc.SearchIndexRequest{Query: "name:*", Facets: []string{"color"}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("SearchSimilar", func() {
+		var searchSimilar = func(query string, vector []float32) *searchsvc.SearchIndexResponse {
+			res, err := eng.SearchSimilar(context.Background(), &searchsvc.SearchIndexRequest{
+				Query: query,
+				Ref: &searchmsg.Reference{
+					ResourceId: &searchmsg.ResourceID{StorageId: "1", SpaceId: "2", OpaqueId: "2"},
+				},
+			}, vector, 0)
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			return res
+		}
+
+		BeforeEach(func() {
+			parentResource.Embedding = []float32{0, 1, 0}
+			childResource.Embedding = []float32{1, 0, 0}
+			Expect(eng.Upsert(parentResource.ID, parentResource)).To(Succeed())
+			Expect(eng.Upsert(childResource.ID, childResource)).To(Succeed())
+			// resources without an embedding are never similar
+			Expect(eng.Upsert(rootResource.ID, rootResource)).To(Succeed())
+		})
+
+		It("sorts the resources by their similarity", func() {
+			res := searchSimilar("", []float32{0.8, 0.2, 0})
+			Expect(res.TotalMatches).To(Equal(int32(2)))
+			Expect(res.Matches).To(HaveLen(2))
+			Expect(res.Matches[0].Entity.Name).To(Equal("child.pdf"))
+			Expect(res.Matches[1].Entity.Name).To(Equal("parent d!r"))
+			Expect(res.Matches[0].Score).To(BeNumerically(">", res.Matches[1].Score))
+		})
+
+		It("applies the query", func() {
+			res := searchSimilar("name:parent*", []float32{1, 0, 0})
+			Expect(res.Matches).To(HaveLen(1))
+			Expect(res.Matches[0].Entity.Name).To(Equal("parent d!r"))
+		})
+
+		It("skips deleted resources", func() {
+			Expect(eng.Delete(childResource.ID)).To(Succeed())
+
+			res := searchSimilar("", []float32{1, 0, 0})
+			Expect(res.Matches).To(HaveLen(1))
+			Expect(res.Matches[0].Entity.Name).To(Equal("parent d!r"))
+		})
+
+		It("skips and doesn't count the resources below the minimum score", func() {
+			parentResource.MimeType = "httpd/unix-directory"
+			childResource.MimeType = "application/pdf"
+			Expect(eng.Upsert(parentResource.ID, parentResource)).To(Succeed())
+			Expect(eng.Upsert(childResource.ID, childResource)).To(Succeed())
+
+			res, err := eng.SearchSimilar(context.Background(), &searchsvc.SearchIndexRequest{
+				Ref: &searchmsg.Reference{
+					ResourceId: &searchmsg.ResourceID{StorageId: "1", SpaceId: "2", OpaqueId: "2"},
+				},
+				Facets: []string{engine.FacetMediaType},
+			}, []float32{1, 0, 0}, 0.75)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.TotalMatches).To(Equal(int32(1)))
+			Expect(res.Matches).To(HaveLen(1))
+			Expect(res.Matches[0].Entity.Name).To(Equal("child.pdf"))
+			Expect(res.Facets).To(HaveLen(1))
+			Expect(res.Facets[0].Values).To(ConsistOf(HaveField("Value", "file"), HaveField("Value", "pdf")))
+			Expect(res.Facets[0].Values).To(HaveEach(HaveField("Count", int64(1))))
+		})
+
+		It("compares only the most recently modified candidates", func() {
+			parentResource.Mtime = time.Now().UTC().Format(time.RFC3339Nano)
+			childResource.Mtime = "2020-01-01T00:00:00Z"
+			Expect(eng.Upsert(parentResource.ID, parentResource)).To(Succeed())
+			Expect(eng.Upsert(childResource.ID, childResource)).To(Succeed())
+
+			eng = engine.NewBleveEngine(idx, bleve.DefaultCreator, engine.BleveSimilarityCandidates(1))
+			res := searchSimilar("", []float32{1, 0, 0})
+			Expect(res.Matches).To(HaveLen(1))
+			Expect(res.Matches[0].Entity.Name).To(Equal("parent d!r"))
+		})
+
+		It("keeps the embedding when a resource is moved", func() {
+			Expect(eng.Move(childResource.ID, parentResource.ID, "./parent d!r/moved.pdf")).To(Succeed())
+
+			res := searchSimilar("", []float32{1, 0, 0})
+			Expect(res.Matches[0].Entity.Name).To(Equal("moved.pdf"))
+			Expect(res.Matches[0].Score).To(BeNumerically("~", 1, 0.0001))
+		})
+	})
+
 	Describe("Upsert", func() {
 		It("adds a resourceInfo to the index", func() {
 			err := eng.Upsert(childResource.ID, childResource)
@@ -486,6 +655,33 @@ var _ = Describe("Bleve", func() {
 		})
 	})
 
+	Describe("Resources", func() {
+		It("returns the resources of the space including the deleted ones", func() {
+			childResource.Mtime = "2024-01-01T00:00:00Z"
+			childResource.Size = 12
+			childResource.Deleted = true
+			Expect(eng.Upsert(parentResource.ID, parentResource)).To(Succeed())
+			Expect(eng.Upsert(childResource.ID, childResource)).To(Succeed())
+			Expect(eng.Upsert("1$5!5", engine.Resource{ID: "1$5!5", RootID: "1$5!5", Path: "."})).To(Succeed())
+
+			resources, err := eng.Resources(rootResource.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resources).To(HaveLen(2))
+
+			child := resources[0]
+			if child.ID != childResource.ID {
+				child = resources[1]
+			}
+			Expect(child.ID).To(Equal(childResource.ID))
+			Expect(child.ParentID).To(Equal(parentResource.ID))
+			Expect(child.Path).To(Equal(childResource.Path))
+			Expect(child.Type).To(Equal(childResource.Type))
+			Expect(child.Mtime).To(Equal(childResource.Mtime))
+			Expect(child.Size).To(Equal(childResource.Size))
+			Expect(child.Deleted).To(BeTrue())
+		})
+	})
+
 	Describe("File type specific metadata", func() {
 
 		Context("with audio metadata", func() {
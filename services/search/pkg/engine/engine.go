@@ -3,9 +3,12 @@ package engine
 import (
 	"context"
 	"regexp"
+	"time"
 
 	"github.com/blevesearch/bleve/v2/search"
 	storageProvider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	searchMessage "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
 	searchService "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
@@ -23,6 +26,27 @@ type Engine interface {
 	Restore(id string) error
 	Purge(id string) error
 	DocCount() (uint64, error)
+	Resources(rootID string) ([]Resource, error)
+}
+
+// SimilaritySearcher is implemented by engines which can rank the resources by the similarity of their embeddings.
+type SimilaritySearcher interface {
+	// SearchSimilar returns the resources matching the request ordered by the similarity of their embedding to the given vector.
+	// The query of the request is optional, resources without an embedding or with a score below minScore are skipped.
+	// The total number of matches and the facets only count the resources which are returned.
+	SearchSimilar(ctx context.Context, req *searchService.SearchIndexRequest, vector []float32, minScore float64) (*searchService.SearchIndexResponse, error)
+}
+
+// Batcher is implemented by engines which can index many resources with few requests.
+type Batcher interface {
+	// NewBatch returns a batch collecting upserts. They are sent once the batch is full or flushed.
+	NewBatch() Batch
+}
+
+// Batch collects the upserts of resources
+type Batch interface {
+	Upsert(id string, r Resource) error
+	Flush() error
 }
 
 // Resource is the entity that is stored in the index.
@@ -36,6 +60,9 @@ type Resource struct {
 	Type     uint64
 	Deleted  bool
 	Hidden   bool
+
+	// Embedding is the vector used by the similarity search, it is empty if the similarity search is disabled.
+	Embedding []float32 `json:"Embedding,omitempty"`
 }
 
 func resourceIDtoSearchID(id storageProvider.ResourceId) *searchMessage.ResourceID {
@@ -45,6 +72,49 @@ func resourceIDtoSearchID(id storageProvider.ResourceId) *searchMessage.Resource
 		OpaqueId:  id.GetOpaqueId()}
 }
 
+// matchFromFields converts the stored fields of a resource to a search match.
+func matchFromFields(fields map[string]interface{}, score float64, highlights string) (*searchMessage.Match, error) {
+	rootID, err := storagespace.ParseID(getFieldValue[string](fields, "RootID"))
+	if err != nil {
+		return nil, err
+	}
+
+	rID, err := storagespace.ParseID(getFieldValue[string](fields, "ID"))
+	if err != nil {
+		return nil, err
+	}
+
+	pID, _ := storagespace.ParseID(getFieldValue[string](fields, "ParentID"))
+	match := &searchMessage.Match{
+		Score: float32(score),
+		Entity: &searchMessage.Entity{
+			Ref: &searchMessage.Reference{
+				ResourceId: resourceIDtoSearchID(rootID),
+				Path:       getFieldValue[string](fields, "Path"),
+			},
+			Id:         resourceIDtoSearchID(rID),
+			Name:       getFieldValue[string](fields, "Name"),
+			ParentId:   resourceIDtoSearchID(pID),
+			Size:       uint64(getFieldValue[float64](fields, "Size")),
+			Type:       uint64(getFieldValue[float64](fields, "Type")),
+			MimeType:   getFieldValue[string](fields, "MimeType"),
+			Deleted:    getFieldValue[bool](fields, "Deleted"),
+			Tags:       getFieldSliceValue[string](fields, "Tags"),
+			Highlights: highlights,
+			Audio:      getAudioValue[searchMessage.Audio](fields),
+			Image:      getImageValue[searchMessage.Image](fields),
+			Location:   getLocationValue[searchMessage.GeoCoordinates](fields),
+			Photo:      getPhotoValue[searchMessage.Photo](fields),
+		},
+	}
+
+	if mtime, err := time.Parse(time.RFC3339, getFieldValue[string](fields, "Mtime")); err == nil {
+		match.Entity.LastModifiedTime = &timestamppb.Timestamp{Seconds: mtime.Unix(), Nanos: int32(mtime.Nanosecond())}
+	}
+
+	return match, nil
+}
+
 func escapeQuery(s string) string {
 	return queryEscape.ReplaceAllString(s, "\\$1")
 }
@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cs3org/reva/v2/pkg/errtypes"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/kql"
+	searchMessage "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
+	searchQuery "github.com/owncloud/ocis/v2/services/search/pkg/query"
+)
+
+// The facets the matches can be counted by, the space facet is counted by the search service.
+const (
+	FacetMediaType = "mediatype"
+	FacetTag       = "tag"
+	FacetMtime     = "mtime"
+	FacetSize      = "size"
+	FacetSpace     = "space"
+)
+
+// _facetTermsSize is the maximum number of distinct mime types and tags which are counted
+const _facetTermsSize = 1000
+
+type sizeRange struct {
+	name string
+	// min is inclusive and max is exclusive, a zero max is unbounded
+	min, max float64
+}
+
+// _sizeRanges are the buckets of the size facet
+var _sizeRanges = []sizeRange{
+	{name: "small", max: 1 << 20},
+	{name: "medium", min: 1 << 20, max: 100 << 20},
+	{name: "large", min: 100 << 20, max: 1 << 30},
+	{name: "huge", min: 1 << 30},
+}
+
+type timeRange struct {
+	name     string
+	from, to time.Time
+}
+
+// timeRanges returns the buckets of the mtime facet, they are named after the relative time ranges of the KQL
+func timeRanges() []timeRange {
+	ranges := make([]timeRange, 0, len(kql.TimeRanges))
+	for _, keyword := range kql.TimeRanges {
+		from, to, err := kql.TimeRange(keyword)
+		if err != nil {
+			continue
+		}
+		ranges = append(ranges, timeRange{name: keyword, from: from, to: to})
+	}
+	return ranges
+}
+
+// validateFacets checks that the requested facets are known
+func validateFacets(facets []string) error {
+	for _, f := range facets {
+		switch f {
+		case FacetMediaType, FacetTag, FacetMtime, FacetSize, FacetSpace:
+		default:
+			return errtypes.BadRequest("unknown facet: " + f)
+		}
+	}
+	return nil
+}
+
+// termsFacet returns the facet of the given counts ordered by the count
+func termsFacet(name string, counts map[string]int64) *searchMessage.Facet {
+	facet := &searchMessage.Facet{Name: name}
+	for value, count := range counts {
+		if count > 0 {
+			facet.Values = append(facet.Values, &searchMessage.FacetValue{Value: value, Count: count})
+		}
+	}
+
+	sort.Slice(facet.Values, func(i, j int) bool {
+		if facet.Values[i].Count != facet.Values[j].Count {
+			return facet.Values[i].Count > facet.Values[j].Count
+		}
+		return facet.Values[i].Value < facet.Values[j].Value
+	})
+
+	return facet
+}
+
+// mediaTypeFacet groups the counts of the mime types by the groups of the mediatype filter,
+// resources can belong to several groups, e.g. file and image.
+func mediaTypeFacet(mimeTypeCounts map[string]int64) *searchMessage.Facet {
+	counts := make(map[string]int64)
+	for mimeType, count := range mimeTypeCounts {
+		for _, group := range searchQuery.MediaTypeGroups(mimeType) {
+			counts[group] += count
+		}
+	}
+
+	return termsFacet(FacetMediaType, counts)
+}
+
+// rangesFacet returns the facet of the given counts in the order of the given bucket names
+func rangesFacet(name string, names []string, counts map[string]int64) *searchMessage.Facet {
+	facet := &searchMessage.Facet{Name: name}
+	for _, n := range names {
+		if count := counts[n]; count > 0 {
+			facet.Values = append(facet.Values, &searchMessage.FacetValue{Value: n, Count: count})
+		}
+	}
+
+	return facet
+}
+
+func sizeRangeNames() []string {
+	names := make([]string, len(_sizeRanges))
+	for i, r := range _sizeRanges {
+		names[i] = r.name
+	}
+	return names
+}
+
+// MergeFacets sums the counts of the facets of several responses, e.g. the responses of several spaces.
+func MergeFacets(names []string, facets ...[]*searchMessage.Facet) []*searchMessage.Facet {
+	counts := make(map[string]map[string]int64, len(names))
+	for _, fs := range facets {
+		for _, f := range fs {
+			if counts[f.GetName()] == nil {
+				counts[f.GetName()] = make(map[string]int64)
+			}
+			for _, v := range f.GetValues() {
+				counts[f.GetName()][v.GetValue()] += v.GetCount()
+			}
+		}
+	}
+
+	var merged []*searchMessage.Facet
+	for _, name := range names {
+		c, ok := counts[name]
+		if !ok {
+			continue
+		}
+
+		switch name {
+		case FacetMtime:
+			merged = append(merged, rangesFacet(name, kql.TimeRanges, c))
+		case FacetSize:
+			merged = append(merged, rangesFacet(name, sizeRangeNames(), c))
+		default:
+			merged = append(merged, termsFacet(name, c))
+		}
+	}
+	return merged
+}
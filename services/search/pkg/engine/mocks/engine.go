@@ -219,6 +219,64 @@ func (_c *Engine_Purge_Call) RunAndReturn(run func(string) error) *Engine_Purge_
 	return _c
 }
 
+// Resources provides a mock function with given fields: rootID
+func (_m *Engine) Resources(rootID string) ([]engine.Resource, error) {
+	ret := _m.Called(rootID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resources")
+	}
+
+	var r0 []engine.Resource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]engine.Resource, error)); ok {
+		return rf(rootID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []engine.Resource); ok {
+		r0 = rf(rootID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]engine.Resource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(rootID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Engine_Resources_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resources'
+type Engine_Resources_Call struct {
+	*mock.Call
+}
+
+// Resources is a helper method to define mock.On call
+//   - rootID string
+func (_e *Engine_Expecter) Resources(rootID interface{}) *Engine_Resources_Call {
+	return &Engine_Resources_Call{Call: _e.mock.On("Resources", rootID)}
+}
+
+func (_c *Engine_Resources_Call) Run(run func(rootID string)) *Engine_Resources_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Engine_Resources_Call) Return(_a0 []engine.Resource, _a1 error) *Engine_Resources_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Engine_Resources_Call) RunAndReturn(run func(string) ([]engine.Resource, error)) *Engine_Resources_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Restore provides a mock function with given fields: id
 func (_m *Engine) Restore(id string) error {
 	ret := _m.Called(id)
@@ -0,0 +1,769 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	storageProvider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/errtypes"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"github.com/cs3org/reva/v2/pkg/utils"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/kql"
+	searchMessage "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
+	searchService "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
+	searchQuery "github.com/owncloud/ocis/v2/services/search/pkg/query"
+	osQuery "github.com/owncloud/ocis/v2/services/search/pkg/query/opensearch"
+)
+
+const (
+	// _openSearchMaxResultWindow is the default maximum of hits opensearch returns for a single request
+	_openSearchMaxResultWindow = 10000
+	// _openSearchBatchSize is the number of resources which are updated with a single bulk request
+	_openSearchBatchSize = 1000
+	// _openSearchBatchBytes is the size of the bulk request after which a batch is sent regardless of the number of resources
+	_openSearchBatchBytes = 8 << 20
+)
+
+// OpenSearch represents a search engine which stores and searches resources in an OpenSearch or Elasticsearch index.
+// Several search services can share the same index.
+type OpenSearch struct {
+	client       *http.Client
+	cfg          OpenSearchConfig
+	queryCreator searchQuery.Creator[osQuery.Query]
+}
+
+// OpenSearchConfig configures the connection to the cluster and the index.
+type OpenSearchConfig struct {
+	Address string
+	Index   string
+	// Username and Password are used for basic authentication if set
+	Username string
+	Password string
+	// EmbeddingDimension enables the similarity search if it is greater than 0,
+	// it requires an OpenSearch cluster with the k-NN plugin.
+	EmbeddingDimension int
+}
+
+// NewOpenSearchEngine creates a new OpenSearch instance.
+func NewOpenSearchEngine(client *http.Client, cfg OpenSearchConfig, queryCreator searchQuery.Creator[osQuery.Query]) *OpenSearch {
+	cfg.Address = strings.TrimSuffix(cfg.Address, "/")
+	return &OpenSearch{
+		client:       client,
+		cfg:          cfg,
+		queryCreator: queryCreator,
+	}
+}
+
+// BuildOpenSearchMapping builds the index settings and mappings which are used for indexing,
+// they match the bleve mapping, see BuildBleveMapping.
+// The embeddings are mapped as k-NN vectors if the dimension is greater than 0.
+func BuildOpenSearchMapping(embeddingDimension int) map[string]interface{} {
+	keyword := map[string]interface{}{"type": "keyword"}
+	lowercaseKeyword := map[string]interface{}{"type": "keyword", "normalizer": "lowercase"}
+	fulltext := map[string]interface{}{"type": "text", "analyzer": "fulltext"}
+
+	m := map[string]interface{}{
+		"settings": map[string]interface{}{
+			"analysis": map[string]interface{}{
+				"normalizer": map[string]interface{}{
+					"lowercase": map[string]interface{}{
+						"type":   "custom",
+						"filter": []string{"lowercase"},
+					},
+				},
+				"analyzer": map[string]interface{}{
+					"fulltext": map[string]interface{}{
+						"type":      "custom",
+						"tokenizer": "standard",
+						"filter":    []string{"lowercase", "porter_stem"},
+					},
+				},
+			},
+		},
+		"mappings": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"ID":       keyword,
+				"RootID":   keyword,
+				"ParentID": keyword,
+				"Path":     keyword,
+				"Name":     lowercaseKeyword,
+				"Tags":     lowercaseKeyword,
+				"MimeType": lowercaseKeyword,
+				"Title":    fulltext,
+				"Content":  fulltext,
+				"Size":     map[string]interface{}{"type": "long"},
+				"Type":     map[string]interface{}{"type": "long"},
+				"Mtime":    map[string]interface{}{"type": "date", "ignore_malformed": true},
+				"Deleted":  map[string]interface{}{"type": "boolean"},
+				"Hidden":   map[string]interface{}{"type": "boolean"},
+			},
+		},
+	}
+
+	if embeddingDimension > 0 {
+		m["settings"].(map[string]interface{})["index"] = map[string]interface{}{"knn": true}
+		m["mappings"].(map[string]interface{})["properties"].(map[string]interface{})["Embedding"] = map[string]interface{}{
+			"type":      "knn_vector",
+			"dimension": embeddingDimension,
+			"method": map[string]interface{}{
+				"name":       "hnsw",
+				"space_type": "cosinesimil",
+				"engine":     "lucene",
+			},
+		}
+	}
+
+	return m
+}
+
+// EnsureIndex creates the index if it does not exist yet,
+// the mappings of an existing index are updated.
+func (o *OpenSearch) EnsureIndex(ctx context.Context) error {
+	m := BuildOpenSearchMapping(o.cfg.EmbeddingDimension)
+
+	err := o.do(ctx, http.MethodHead, o.cfg.Index, nil, nil)
+	switch {
+	case isOpenSearchNotFound(err):
+		return o.do(ctx, http.MethodPut, o.cfg.Index, m, nil)
+	case err != nil:
+		return err
+	}
+
+	return o.do(ctx, http.MethodPut, o.cfg.Index+"/_mapping", m["mappings"], nil)
+}
+
+// Search executes a search request operation within the index.
+// Returns a SearchIndexResponse object or an error.
+func (o *OpenSearch) Search(ctx context.Context, sir *searchService.SearchIndexRequest) (*searchService.SearchIndexResponse, error) {
+	if err := validateFacets(sir.Facets); err != nil {
+		return nil, err
+	}
+
+	createdQuery, err := o.createQuery(sir.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &osQuery.BooleanQuery{
+		Must:   []osQuery.Query{createdQuery},
+		Filter: scopeFilters(sir),
+	}
+
+	body := map[string]interface{}{
+		"query":            q,
+		"size":             pageSize(sir),
+		"track_total_hits": true,
+		"_source":          map[string]interface{}{"excludes": []string{"Embedding"}},
+		"highlight": map[string]interface{}{
+			"pre_tags":  []string{"<mark>"},
+			"post_tags": []string{"</mark>"},
+			"fields":    map[string]interface{}{"Content": map[string]interface{}{}},
+		},
+	}
+	if aggs := openSearchAggregations(sir.Facets); len(aggs) > 0 {
+		body["aggs"] = aggs
+	}
+
+	var res openSearchSearchResponse
+	err = o.do(ctx, http.MethodPost, o.cfg.Index+"/_search", body, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*searchMessage.Match, 0, len(res.Hits.Hits))
+	for _, hit := range res.Hits.Hits {
+		var highlights string
+		if fragments := hit.Highlight["Content"]; len(fragments) > 0 {
+			highlights = fragments[0]
+		}
+
+		match, err := matchFromFields(flattenFields(hit.Source, ""), hit.Score, highlights)
+		if err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, match)
+	}
+
+	return &searchService.SearchIndexResponse{
+		Matches:      matches,
+		TotalMatches: int32(res.Hits.Total.Value),
+		Facets:       openSearchFacets(res.Aggregations, sir.Facets),
+	}, nil
+}
+
+// SearchSimilar executes a k-NN search for the given vector within the index.
+// The search is restricted to the resources of the space which are not deleted and match the query,
+// the filter is applied while searching the nearest neighbors. Neighbors below the minimum score are neither
+// returned nor counted by the total and the aggregations of the facets.
+func (o *OpenSearch) SearchSimilar(ctx context.Context, sir *searchService.SearchIndexRequest, vector []float32, minScore float64) (*searchService.SearchIndexResponse, error) {
+	if o.cfg.EmbeddingDimension <= 0 {
+		return nil, errors.New("the similarity search is not enabled for the index")
+	}
+	if err := validateFacets(sir.Facets); err != nil {
+		return nil, err
+	}
+
+	filter := &osQuery.BooleanQuery{Filter: scopeFilters(sir)}
+	if sir.Query != "" {
+		createdQuery, err := o.createQuery(sir.Query)
+		if err != nil {
+			return nil, err
+		}
+		filter.Filter = append(filter.Filter, createdQuery)
+	}
+
+	size := pageSize(sir)
+	body := map[string]interface{}{
+		"query": map[string]interface{}{
+			"knn": map[string]interface{}{
+				"Embedding": map[string]interface{}{
+					"vector": vector,
+					"k":      min(size, _openSearchMaxResultWindow),
+					"filter": filter,
+				},
+			},
+		},
+		"min_score":        minScore,
+		"size":             size,
+		"track_total_hits": true,
+		"_source":          map[string]interface{}{"excludes": []string{"Embedding"}},
+	}
+	if aggs := openSearchAggregations(sir.Facets); len(aggs) > 0 {
+		body["aggs"] = aggs
+	}
+
+	var res openSearchSearchResponse
+	err := o.do(ctx, http.MethodPost, o.cfg.Index+"/_search", body, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*searchMessage.Match, 0, len(res.Hits.Hits))
+	for _, hit := range res.Hits.Hits {
+		match, err := matchFromFields(flattenFields(hit.Source, ""), hit.Score, "")
+		if err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, match)
+	}
+
+	return &searchService.SearchIndexResponse{
+		Matches:      matches,
+		TotalMatches: int32(res.Hits.Total.Value),
+		Facets:       openSearchFacets(res.Aggregations, sir.Facets),
+	}, nil
+}
+
+// Upsert indexes or stores Resource data fields.
+func (o *OpenSearch) Upsert(id string, r Resource) error {
+	return o.do(context.Background(), http.MethodPut, o.cfg.Index+"/_doc/"+url.PathEscape(id), r, nil)
+}
+
+// NewBatch returns a batch which indexes the resources with bulk requests.
+func (o *OpenSearch) NewBatch() Batch {
+	return &openSearchBatch{o: o}
+}
+
+// openSearchBatch collects upserts in the body of a bulk request
+type openSearchBatch struct {
+	o     *OpenSearch
+	body  bytes.Buffer
+	count int
+}
+
+// Upsert adds the resource to the batch and sends the batch if it is full.
+func (b *openSearchBatch) Upsert(id string, r Resource) error {
+	enc := json.NewEncoder(&b.body)
+	if err := enc.Encode(map[string]interface{}{"index": map[string]interface{}{"_id": id}}); err != nil {
+		return err
+	}
+	if err := enc.Encode(r); err != nil {
+		return err
+	}
+
+	b.count++
+	if b.count < _openSearchBatchSize && b.body.Len() < _openSearchBatchBytes {
+		return nil
+	}
+	return b.Flush()
+}
+
+// Flush sends the collected upserts.
+func (b *openSearchBatch) Flush() error {
+	if b.count == 0 {
+		return nil
+	}
+	defer func() {
+		b.body.Reset()
+		b.count = 0
+	}()
+	return b.o.bulk(context.Background(), b.body.Bytes())
+}
+
+// Move updates the resource location and all of its necessary fields.
+func (o *OpenSearch) Move(id string, parentid string, target string) error {
+	r, err := o.getResource(id)
+	if err != nil {
+		return err
+	}
+	currentPath := r.Path
+	nextPath := utils.MakeRelativePath(target)
+
+	r.Path = nextPath
+	r.Name = path.Base(nextPath)
+	r.ParentID = parentid
+	if err := o.Upsert(id, *r); err != nil {
+		return err
+	}
+
+	if r.Type == uint64(storageProvider.ResourceType_RESOURCE_TYPE_CONTAINER) {
+		return o.updateDescendants(r.RootID, currentPath, func(d Resource) map[string]interface{} {
+			return map[string]interface{}{"Path": strings.Replace(d.Path, currentPath, nextPath, 1)}
+		})
+	}
+
+	return nil
+}
+
+// Delete marks the resource as deleted.
+// The resource object will stay in the index,
+// instead of removing the resource it just marks it as deleted!
+// can be undone
+func (o *OpenSearch) Delete(id string) error {
+	return o.setDeleted(id, true)
+}
+
+// Restore is the counterpart to Delete.
+// It restores the resource which makes it available again.
+func (o *OpenSearch) Restore(id string) error {
+	return o.setDeleted(id, false)
+}
+
+// Purge removes a resource from the index, irreversible operation.
+func (o *OpenSearch) Purge(id string) error {
+	err := o.do(context.Background(), http.MethodDelete, o.cfg.Index+"/_doc/"+url.PathEscape(id), nil, nil)
+	if isOpenSearchNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// DocCount returns the number of resources in the index.
+func (o *OpenSearch) DocCount() (uint64, error) {
+	var res struct {
+		Count uint64 `json:"count"`
+	}
+	if err := o.do(context.Background(), http.MethodGet, o.cfg.Index+"/_count", nil, &res); err != nil {
+		return 0, err
+	}
+	return res.Count, nil
+}
+
+// Resources returns all resources of the space with the given root id, including the deleted ones.
+// Only the fields which are needed to compare the resources with the storage are loaded.
+func (o *OpenSearch) Resources(rootID string) ([]Resource, error) {
+	var (
+		resources []Resource
+		after     []interface{}
+	)
+	for {
+		req := map[string]interface{}{
+			"query":   &osQuery.TermQuery{Field: "RootID", Value: rootID},
+			"size":    _openSearchBatchSize,
+			"sort":    []interface{}{map[string]interface{}{"ID": "asc"}},
+			"_source": []string{"ID", "ParentID", "Path", "Type", "Mtime", "Size", "Deleted"},
+		}
+		if after != nil {
+			req["search_after"] = after
+		}
+
+		var res openSearchSearchResponse
+		if err := o.do(context.Background(), http.MethodPost, o.cfg.Index+"/_search", req, &res); err != nil {
+			return nil, err
+		}
+		if len(res.Hits.Hits) == 0 {
+			return resources, nil
+		}
+
+		for _, hit := range res.Hits.Hits {
+			r := Resource{
+				ID:       getFieldValue[string](hit.Source, "ID"),
+				RootID:   rootID,
+				ParentID: getFieldValue[string](hit.Source, "ParentID"),
+				Path:     getFieldValue[string](hit.Source, "Path"),
+				Type:     uint64(getFieldValue[float64](hit.Source, "Type")),
+				Deleted:  getFieldValue[bool](hit.Source, "Deleted"),
+			}
+			r.Mtime = getFieldValue[string](hit.Source, "Mtime")
+			r.Size = uint64(getFieldValue[float64](hit.Source, "Size"))
+			resources = append(resources, r)
+		}
+
+		after = res.Hits.Hits[len(res.Hits.Hits)-1].Sort
+	}
+}
+
+func (o *OpenSearch) getResource(id string) (*Resource, error) {
+	var res struct {
+		Source Resource `json:"_source"`
+	}
+	err := o.do(context.Background(), http.MethodGet, o.cfg.Index+"/_doc/"+url.PathEscape(id), nil, &res)
+	switch {
+	case isOpenSearchNotFound(err):
+		return nil, errors.New("entity not found")
+	case err != nil:
+		return nil, err
+	}
+
+	return &res.Source, nil
+}
+
+func (o *OpenSearch) setDeleted(id string, deleted bool) error {
+	r, err := o.getResource(id)
+	if err != nil {
+		return err
+	}
+
+	r.Deleted = deleted
+	if err := o.Upsert(id, *r); err != nil {
+		return err
+	}
+
+	if r.Type == uint64(storageProvider.ResourceType_RESOURCE_TYPE_CONTAINER) {
+		return o.updateDescendants(r.RootID, r.Path, func(Resource) map[string]interface{} {
+			return map[string]interface{}{"Deleted": deleted}
+		})
+	}
+
+	return nil
+}
+
+// updateDescendants applies the partial update returned by mutate to all resources below the given path.
+// The resources are paged by ID and updated with bulk requests.
+func (o *OpenSearch) updateDescendants(rootID, p string, mutate func(Resource) map[string]interface{}) error {
+	ctx := context.Background()
+	q := osQuery.NewConjunctionQuery(
+		&osQuery.TermQuery{Field: "RootID", Value: rootID},
+		&osQuery.PrefixQuery{Field: "Path", Value: p + "/"},
+	)
+
+	var after []interface{}
+	for {
+		req := map[string]interface{}{
+			"query":   q,
+			"size":    _openSearchBatchSize,
+			"sort":    []interface{}{map[string]interface{}{"ID": "asc"}},
+			"_source": []string{"ID", "Path", "Deleted"},
+		}
+		if after != nil {
+			req["search_after"] = after
+		}
+
+		var res openSearchSearchResponse
+		if err := o.do(ctx, http.MethodPost, o.cfg.Index+"/_search", req, &res); err != nil {
+			return err
+		}
+		if len(res.Hits.Hits) == 0 {
+			return nil
+		}
+
+		bulk := &bytes.Buffer{}
+		enc := json.NewEncoder(bulk)
+		for _, hit := range res.Hits.Hits {
+			d := Resource{
+				ID:      getFieldValue[string](hit.Source, "ID"),
+				Path:    getFieldValue[string](hit.Source, "Path"),
+				Deleted: getFieldValue[bool](hit.Source, "Deleted"),
+			}
+			if err := enc.Encode(map[string]interface{}{"update": map[string]interface{}{"_id": hit.ID}}); err != nil {
+				return err
+			}
+			if err := enc.Encode(map[string]interface{}{"doc": mutate(d)}); err != nil {
+				return err
+			}
+		}
+
+		if err := o.bulk(ctx, bulk.Bytes()); err != nil {
+			return err
+		}
+
+		after = res.Hits.Hits[len(res.Hits.Hits)-1].Sort
+	}
+}
+
+// bulk sends the ndjson body to the bulk api and returns the error of the first failed item
+func (o *OpenSearch) bulk(ctx context.Context, body []byte) error {
+	var res openSearchBulkResponse
+	if err := o.do(ctx, http.MethodPost, o.cfg.Index+"/_bulk", body, &res); err != nil {
+		return err
+	}
+	return res.err()
+}
+
+func (o *OpenSearch) createQuery(qs string) (osQuery.Query, error) {
+	createdQuery, err := o.queryCreator.Create(qs)
+	if err != nil {
+		if searchQuery.IsValidationError(err) {
+			return nil, errtypes.BadRequest(err.Error())
+		}
+		return nil, err
+	}
+	return createdQuery, nil
+}
+
+// scopeFilters returns the filters which skip deleted resources and resources outside of the requested space and path
+func scopeFilters(sir *searchService.SearchIndexRequest) []osQuery.Query {
+	filters := []osQuery.Query{
+		// Skip documents that have been marked as deleted
+		&osQuery.TermQuery{Field: "Deleted", Value: false},
+	}
+
+	if sir.Ref != nil {
+		filters = append(filters, &osQuery.TermQuery{
+			Field: "RootID",
+			Value: storagespace.FormatResourceID(
+				&storageProvider.ResourceId{
+					StorageId: sir.Ref.GetResourceId().GetStorageId(),
+					SpaceId:   sir.Ref.GetResourceId().GetSpaceId(),
+					OpaqueId:  sir.Ref.GetResourceId().GetOpaqueId(),
+				},
+			),
+		})
+
+		if requestedPath := utils.MakeRelativePath(sir.Ref.Path); requestedPath != "." {
+			filters = append(filters, osQuery.NewDisjunctionQuery(
+				&osQuery.TermQuery{Field: "Path", Value: requestedPath},
+				&osQuery.PrefixQuery{Field: "Path", Value: requestedPath + "/"},
+			))
+		}
+	}
+
+	return filters
+}
+
+// openSearchAggregations returns the aggregations of the facets which are counted by the index
+func openSearchAggregations(facets []string) map[string]interface{} {
+	aggs := map[string]interface{}{}
+	for _, f := range facets {
+		switch f {
+		case FacetMediaType:
+			aggs[f] = map[string]interface{}{"terms": map[string]interface{}{"field": "MimeType", "size": _facetTermsSize}}
+		case FacetTag:
+			aggs[f] = map[string]interface{}{"terms": map[string]interface{}{"field": "Tags", "size": _facetTermsSize}}
+		case FacetMtime:
+			var ranges []map[string]interface{}
+			for _, r := range timeRanges() {
+				ranges = append(ranges, map[string]interface{}{
+					"key":  r.name,
+					"from": r.from.Format(time.RFC3339Nano),
+					"to":   r.to.Format(time.RFC3339Nano),
+				})
+			}
+			aggs[f] = map[string]interface{}{"date_range": map[string]interface{}{"field": "Mtime", "ranges": ranges}}
+		case FacetSize:
+			var ranges []map[string]interface{}
+			for _, r := range _sizeRanges {
+				bucket := map[string]interface{}{"key": r.name}
+				if r.min > 0 {
+					bucket["from"] = r.min
+				}
+				if r.max > 0 {
+					bucket["to"] = r.max
+				}
+				ranges = append(ranges, bucket)
+			}
+			aggs[f] = map[string]interface{}{"range": map[string]interface{}{"field": "Size", "ranges": ranges}}
+		}
+	}
+	return aggs
+}
+
+// openSearchFacets converts the aggregations of the response in the order of the requested facets
+func openSearchFacets(aggregations map[string]openSearchAggregation, facets []string) []*searchMessage.Facet {
+	var converted []*searchMessage.Facet
+	for _, f := range facets {
+		agg, ok := aggregations[f]
+		if !ok {
+			continue
+		}
+
+		counts := make(map[string]int64, len(agg.Buckets))
+		for _, b := range agg.Buckets {
+			counts[b.Key] = b.DocCount
+		}
+
+		switch f {
+		case FacetMediaType:
+			converted = append(converted, mediaTypeFacet(counts))
+		case FacetTag:
+			converted = append(converted, termsFacet(f, counts))
+		case FacetMtime:
+			converted = append(converted, rangesFacet(f, kql.TimeRanges, counts))
+		case FacetSize:
+			converted = append(converted, rangesFacet(f, sizeRangeNames(), counts))
+		}
+	}
+	return converted
+}
+
+func pageSize(sir *searchService.SearchIndexRequest) int {
+	switch {
+	case sir.PageSize == -1:
+		return _openSearchMaxResultWindow
+	case sir.PageSize == 0:
+		return 200
+	default:
+		return int(sir.PageSize)
+	}
+}
+
+// do sends a request to the opensearch api, body is sent as ndjson if it is a byte slice and as json otherwise.
+// The response is decoded into out if it is not nil.
+func (o *OpenSearch) do(ctx context.Context, method, p string, body interface{}, out interface{}) error {
+	var (
+		r           io.Reader
+		contentType = "application/json"
+	)
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		r = bytes.NewReader(b)
+		contentType = "application/x-ndjson"
+	default:
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, o.cfg.Address+"/"+p, r)
+	if err != nil {
+		return err
+	}
+	if r != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if o.cfg.Username != "" {
+		req.SetBasicAuth(o.cfg.Username, o.cfg.Password)
+	}
+
+	res, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		e := &openSearchError{Status: res.StatusCode}
+		var errRes struct {
+			Error json.RawMessage `json:"error"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&errRes); err == nil && len(errRes.Error) > 0 {
+			// the error is either an object or a plain string
+			if json.Unmarshal(errRes.Error, e) != nil {
+				_ = json.Unmarshal(errRes.Error, &e.Reason)
+			}
+		}
+		return e
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// openSearchError is an error returned by the opensearch api
+type openSearchError struct {
+	Status int    `json:"status"`
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+func (e *openSearchError) Error() string {
+	if e.Type == "" && e.Reason == "" {
+		return fmt.Sprintf("opensearch: unexpected status %d", e.Status)
+	}
+	return fmt.Sprintf("opensearch: %s: %s", e.Type, e.Reason)
+}
+
+func isOpenSearchNotFound(err error) bool {
+	var e *openSearchError
+	return errors.As(err, &e) && e.Status == http.StatusNotFound
+}
+
+type openSearchSearchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			ID        string                 `json:"_id"`
+			Score     float64                `json:"_score"`
+			Source    map[string]interface{} `json:"_source"`
+			Highlight map[string][]string    `json:"highlight"`
+			Sort      []interface{}          `json:"sort"`
+		} `json:"hits"`
+	} `json:"hits"`
+	Aggregations map[string]openSearchAggregation `json:"aggregations"`
+}
+
+type openSearchAggregation struct {
+	Buckets []struct {
+		Key      string `json:"key"`
+		DocCount int64  `json:"doc_count"`
+	} `json:"buckets"`
+}
+
+type openSearchBulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID     string           `json:"_id"`
+		Status int              `json:"status"`
+		Error  *openSearchError `json:"error"`
+	} `json:"items"`
+}
+
+// err returns the error of the first failed item
+func (r openSearchBulkResponse) err() error {
+	if !r.Errors {
+		return nil
+	}
+	for _, item := range r.Items {
+		for _, result := range item {
+			if result.Error != nil {
+				result.Error.Status = result.Status
+				return fmt.Errorf("could not update '%s': %w", result.ID, result.Error)
+			}
+		}
+	}
+	return errors.New("opensearch: bulk request failed")
+}
+
+// flattenFields flattens nested objects of the source to dotted keys like bleve stores them, e.g. 'audio.artist'
+func flattenFields(source map[string]interface{}, prefix string) map[string]interface{} {
+	fields := make(map[string]interface{}, len(source))
+	for k, v := range source {
+		if nested, ok := v.(map[string]interface{}); ok {
+			for nk, nv := range flattenFields(nested, prefix+k+".") {
+				fields[nk] = nv
+			}
+			continue
+		}
+		fields[prefix+k] = v
+	}
+	return fields
+}
@@ -0,0 +1,618 @@
+package engine_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+
+	sprovider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	searchmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
+	searchsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
+	"github.com/owncloud/ocis/v2/services/search/pkg/content"
+	"github.com/owncloud/ocis/v2/services/search/pkg/engine"
+	"github.com/owncloud/ocis/v2/services/search/pkg/query/opensearch"
+)
+
+// exchange is a recorded request to the opensearch api and its response
+type exchange struct {
+	method   string
+	path     string
+	body     string
+	status   int
+	response string
+}
+
+// standIn replays the responses of the exchanges in order and records the requests
+type standIn struct {
+	mu        sync.Mutex
+	exchanges []exchange
+	requests  []exchange
+}
+
+func (s *standIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	p := r.URL.Path
+	if r.URL.RawQuery != "" {
+		p += "?" + r.URL.RawQuery
+	}
+	s.requests = append(s.requests, exchange{method: r.Method, path: p, body: string(body)})
+
+	i := len(s.requests) - 1
+	if i >= len(s.exchanges) {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(s.exchanges[i].status)
+	_, _ = w.Write([]byte(s.exchanges[i].response))
+}
+
+// assert checks that the recorded requests match the exchanges
+func (s *standIn) assert() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ExpectWithOffset(1, s.requests).To(HaveLen(len(s.exchanges)))
+	for i, e := range s.exchanges {
+		ExpectWithOffset(1, s.requests[i].method+" "+s.requests[i].path).To(Equal(e.method + " " + e.path))
+		switch {
+		case e.body == "":
+		case strings.HasSuffix(e.path, "/_bulk"):
+			ExpectWithOffset(1, ndjsonLines(s.requests[i].body)).To(Equal(ndjsonLines(e.body)))
+		default:
+			ExpectWithOffset(1, s.requests[i].body).To(MatchJSON(e.body))
+		}
+	}
+}
+
+func ndjsonLines(s string) []interface{} {
+	var lines []interface{}
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var v interface{}
+		Expect(json.Unmarshal(scanner.Bytes(), &v)).To(Succeed())
+		lines = append(lines, v)
+	}
+	return lines
+}
+
+var _ = Describe("OpenSearch", func() {
+	var (
+		srv      *httptest.Server
+		recorder *standIn
+		eng      *engine.OpenSearch
+
+		parentResource engine.Resource
+	)
+
+	BeforeEach(func() {
+		recorder = &standIn{}
+		srv = httptest.NewServer(recorder)
+		eng = engine.NewOpenSearchEngine(srv.Client(), engine.OpenSearchConfig{
+			Address:            srv.URL,
+			Index:              "ocis-resources",
+			EmbeddingDimension: 3,
+		}, opensearch.DefaultCreator)
+
+		parentResource = engine.Resource{
+			ID:       "1$2!3",
+			ParentID: "1$2!2",
+			RootID:   "1$2!2",
+			Path:     "./parent d!r",
+			Type:     uint64(sprovider.ResourceType_RESOURCE_TYPE_CONTAINER),
+			Document: content.Document{Name: "parent d!r"},
+		}
+	})
+
+	AfterEach(func() {
+		srv.Close()
+	})
+
+	Describe("EnsureIndex", func() {
+		It("creates the index with the mapping", func() {
+			mapping, err := json.Marshal(engine.BuildOpenSearchMapping(3))
+			Expect(err).ToNot(HaveOccurred())
+			recorder.exchanges = []exchange{
+				{method: http.MethodHead, path: "/ocis-resources", status: http.StatusNotFound},
+				{method: http.MethodPut, path: "/ocis-resources", body: string(mapping), status: http.StatusOK, response: `{"acknowledged":true}`},
+			}
+
+			Expect(eng.EnsureIndex(context.Background())).To(Succeed())
+			recorder.assert()
+		})
+
+		It("updates the mapping of an existing index", func() {
+			recorder.exchanges = []exchange{
+				{method: http.MethodHead, path: "/ocis-resources", status: http.StatusOK},
+				{method: http.MethodPut, path: "/ocis-resources/_mapping", status: http.StatusOK, response: `{"acknowledged":true}`},
+			}
+
+			Expect(eng.EnsureIndex(context.Background())).To(Succeed())
+			recorder.assert()
+		})
+	})
+
+	Describe("Search", func() {
+		It("filters deleted resources and other spaces and converts the hits", func() {
+			recorder.exchanges = []exchange{{
+				method: http.MethodPost,
+				path:   "/ocis-resources/_search",
+				body: `{
+					"query": {"bool": {
+						"must": [{"bool": {"must": [{"term": {"Name": {"value": "child.mp3"}}}]}}],
+						"filter": [
+							{"term": {"Deleted": {"value": false}}},
+							{"term": {"RootID": {"value": "1$2!2"}}},
+							{"bool": {"minimum_should_match": 1, "should": [
+								{"term": {"Path": {"value": "./parent d!r"}}},
+								{"prefix": {"Path": {"value": "./parent d!r/"}}}
+							]}}
+						]
+					}},
+					"size": 200,
+					"track_total_hits": true,
+					"highlight": {"pre_tags": ["<mark>"], "post_tags": ["</mark>"], "fields": {"Content": {}}},
+					"_source": {"excludes": ["Embedding"]}
+				}`,
+				status: http.StatusOK,
+				response: `{"hits": {"total": {"value": 1}, "hits": [{
+					"_id": "1$2!4",
+					"_score": 1.5,
+					"_source": {
+						"ID": "1$2!4", "RootID": "1$2!2", "ParentID": "1$2!3", "Path": "./parent d!r/child.mp3",
+						"Name": "child.mp3", "Size": 42, "Type": 1, "MimeType": "audio/mpeg", "Tags": ["foo"],
+						"Mtime": "2024-01-02T03:04:05Z", "Deleted": false,
+						"audio": {"artist": "Some Artist", "track": 3}
+					},
+					"highlight": {"Content": ["a <mark>child</mark>"]}
+				}]}}`,
+			}}
+
+			res, err := eng.Search(context.Background(), &searchsvc.SearchIndexRequest{
+				Query: "Name:child.mp3",
+				Ref: &searchmsg.Reference{
+					ResourceId: &searchmsg.ResourceID{StorageId: "1", SpaceId: "2", OpaqueId: "2"},
+					Path:       "./parent d!r",
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			recorder.assert()
+
+			Expect(res.TotalMatches).To(Equal(int32(1)))
+			Expect(res.Matches).To(HaveLen(1))
+			match := res.Matches[0]
+			Expect(match.Score).To(Equal(float32(1.5)))
+			Expect(match.Entity.Id.OpaqueId).To(Equal("4"))
+			Expect(match.Entity.ParentId.OpaqueId).To(Equal("3"))
+			Expect(match.Entity.Ref.Path).To(Equal("./parent d!r/child.mp3"))
+			Expect(match.Entity.Size).To(Equal(uint64(42)))
+			Expect(match.Entity.Tags).To(Equal([]string{"foo"}))
+			Expect(match.Entity.Highlights).To(Equal("a <mark>child</mark>"))
+			Expect(match.Entity.LastModifiedTime.AsTime().Unix()).To(Equal(int64(1704164645)))
+			Expect(match.Entity.Audio.GetArtist()).To(Equal("Some Artist"))
+			Expect(match.Entity.Audio.GetTrack()).To(Equal(int32(3)))
+		})
+
+		It("counts the facets with aggregations", func() {
+			recorder.exchanges = []exchange{{
+				method: http.MethodPost,
+				path:   "/ocis-resources/_search",
+				status: http.StatusOK,
+				response: `{"hits": {"total": {"value": 3}, "hits": []}, "aggregations": {
+					"mediatype": {"buckets": [{"key": "image/png", "doc_count": 2}, {"key": "image/jpeg", "doc_count": 1}, {"key": "httpd/unix-directory", "doc_count": 1}]},
+					"size": {"buckets": [{"key": "small", "doc_count": 3}, {"key": "medium", "doc_count": 0}, {"key": "large", "doc_count": 0}, {"key": "huge", "doc_count": 1}]}
+				}}`,
+			}}
+
+			res, err := eng.Search(context.Background(), &searchsvc.SearchIndexRequest{
+				Query:  "Name:*",
+				Facets: []string{engine.FacetSize, engine.FacetMediaType},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			recorder.assert()
+
+			var body struct {
+				Aggs map[string]map[string]interface{} `json:"aggs"`
+			}
+			Expect(json.Unmarshal([]byte(recorder.requests[0].body), &body)).To(Succeed())
+			Expect(body.Aggs).To(HaveLen(2))
+			Expect(body.Aggs["mediatype"]).To(HaveKeyWithValue("terms", HaveKeyWithValue("field", "MimeType")))
+			Expect(body.Aggs["size"]).To(HaveKeyWithValue("range", HaveKeyWithValue("field", "Size")))
+
+			Expect(res.Facets).To(HaveLen(2))
+			Expect(res.Facets[0].Name).To(Equal(engine.FacetSize))
+			Expect(res.Facets[0].Values).To(HaveLen(2))
+			Expect(res.Facets[0].Values[0].Value).To(Equal("small"))
+			Expect(res.Facets[0].Values[1].Value).To(Equal("huge"))
+			Expect(res.Facets[1].Name).To(Equal(engine.FacetMediaType))
+			Expect(res.Facets[1].Values).To(HaveLen(3))
+			Expect(res.Facets[1].Values[0].Value).To(Equal("file"))
+			Expect(res.Facets[1].Values[0].Count).To(Equal(int64(3)))
+			Expect(res.Facets[1].Values[1].Value).To(Equal("image"))
+			Expect(res.Facets[1].Values[2].Value).To(Equal("folder"))
+		})
+
+		It("returns the opensearch error", func() {
+			recorder.exchanges = []exchange{{
+				method:   http.MethodPost,
+				path:     "/ocis-resources/_search",
+				status:   http.StatusBadRequest,
+				response: `{"error": {"type": "search_phase_execution_exception", "reason": "all shards failed"}, "status": 400}`,
+			}}
+
+			_, err := eng.Search(context.Background(), &searchsvc.SearchIndexRequest{Query: "Name:foo"})
+			Expect(err).To(MatchError("opensearch: search_phase_execution_exception: all shards failed"))
+		})
+	})
+
+	Describe("SearchSimilar", func() {
+		It("searches the nearest neighbors and filters them", func() {
+			recorder.exchanges = []exchange{{
+				method: http.MethodPost,
+				path:   "/ocis-resources/_search",
+				body: `{
+					"query": {"knn": {"Embedding": {
+						"vector": [1, 0, 0],
+						"k": 10,
+						"filter": {"bool": {"filter": [
+							{"term": {"Deleted": {"value": false}}},
+							{"term": {"RootID": {"value": "1$2!2"}}},
+							{"bool": {"must": [{"term": {"MimeType": {"value": "application/pdf"}}}]}}
+						]}}
+					}}},
+					"min_score": 0.5,
+					"size": 10,
+					"track_total_hits": true,
+					"_source": {"excludes": ["Embedding"]},
+					"aggs": {"mediatype": {"terms": {"field": "MimeType", "size": 1000}}}
+				}`,
+				status: http.StatusOK,
+				response: `{"hits": {"total": {"value": 1}, "hits": [{
+					"_id": "1$2!4",
+					"_score": 0.9,
+					"_source": {"ID": "1$2!4", "RootID": "1$2!2", "ParentID": "1$2!3", "Path": "./lease.pdf", "Name": "lease.pdf", "MimeType": "application/pdf"}
+				}]}, "aggregations": {"mediatype": {"buckets": [{"key": "application/pdf", "doc_count": 1}]}}}`,
+			}}
+
+			res, err := eng.SearchSimilar(context.Background(), &searchsvc.SearchIndexRequest{
+				Query: "MimeType:application/pdf",
+				Ref: &searchmsg.Reference{
+					ResourceId: &searchmsg.ResourceID{StorageId: "1", SpaceId: "2", OpaqueId: "2"},
+				},
+				PageSize: 10,
+				Facets:   []string{engine.FacetMediaType},
+			}, []float32{1, 0, 0}, 0.5)
+			Expect(err).ToNot(HaveOccurred())
+			recorder.assert()
+
+			Expect(res.TotalMatches).To(Equal(int32(1)))
+			Expect(res.Facets).To(HaveLen(1))
+			Expect(res.Facets[0].Values).To(ConsistOf(HaveField("Value", "file"), HaveField("Value", "pdf")))
+			Expect(res.Matches).To(HaveLen(1))
+			Expect(res.Matches[0].Score).To(Equal(float32(0.9)))
+			Expect(res.Matches[0].Entity.Name).To(Equal("lease.pdf"))
+		})
+
+		It("fails if the index has no embeddings", func() {
+			eng = engine.NewOpenSearchEngine(srv.Client(), engine.OpenSearchConfig{
+				Address: srv.URL,
+				Index:   "ocis-resources",
+			}, opensearch.DefaultCreator)
+
+			_, err := eng.SearchSimilar(context.Background(), &searchsvc.SearchIndexRequest{}, []float32{1, 0, 0}, 0)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Upsert", func() {
+		It("indexes the resource", func() {
+			body, err := json.Marshal(parentResource)
+			Expect(err).ToNot(HaveOccurred())
+			recorder.exchanges = []exchange{
+				{method: http.MethodPut, path: "/ocis-resources/_doc/1$2!3", body: string(body), status: http.StatusCreated, response: `{"result":"created"}`},
+			}
+
+			Expect(eng.Upsert(parentResource.ID, parentResource)).To(Succeed())
+			recorder.assert()
+		})
+	})
+
+	Describe("Batch", func() {
+		It("indexes the resources with a bulk request once flushed", func() {
+			child := parentResource
+			child.ID = "1$2!4"
+			child.ParentID = parentResource.ID
+			child.Path = "./parent d!r/child"
+
+			parentBody, err := json.Marshal(parentResource)
+			Expect(err).ToNot(HaveOccurred())
+			childBody, err := json.Marshal(child)
+			Expect(err).ToNot(HaveOccurred())
+			recorder.exchanges = []exchange{
+				{
+					method:   http.MethodPost,
+					path:     "/ocis-resources/_bulk",
+					body:     `{"index":{"_id":"1$2!3"}}` + "\n" + string(parentBody) + "\n" + `{"index":{"_id":"1$2!4"}}` + "\n" + string(childBody) + "\n",
+					status:   http.StatusOK,
+					response: `{"errors":false,"items":[{"index":{"_id":"1$2!3","status":201}},{"index":{"_id":"1$2!4","status":201}}]}`,
+				},
+			}
+
+			batch := eng.NewBatch()
+			Expect(batch.Upsert(parentResource.ID, parentResource)).To(Succeed())
+			Expect(batch.Upsert(child.ID, child)).To(Succeed())
+			Expect(recorder.requests).To(BeEmpty())
+
+			Expect(batch.Flush()).To(Succeed())
+			Expect(batch.Flush()).To(Succeed())
+			recorder.assert()
+		})
+	})
+
+	Describe("Move", func() {
+		It("moves the resource and updates the paths of the children in bulk", func() {
+			source, err := json.Marshal(parentResource)
+			Expect(err).ToNot(HaveOccurred())
+			moved := parentResource
+			moved.Path = "./new"
+			moved.Name = "new"
+			moved.ParentID = "1$2!2"
+			body, err := json.Marshal(moved)
+			Expect(err).ToNot(HaveOccurred())
+
+			recorder.exchanges = []exchange{
+				{method: http.MethodGet, path: "/ocis-resources/_doc/1$2!3", status: http.StatusOK, response: `{"found": true, "_source": ` + string(source) + `}`},
+				{method: http.MethodPut, path: "/ocis-resources/_doc/1$2!3", body: string(body), status: http.StatusOK, response: `{"result":"updated"}`},
+				{
+					method: http.MethodPost,
+					path:   "/ocis-resources/_search",
+					body: `{
+						"query": {"bool": {"must": [
+							{"term": {"RootID": {"value": "1$2!2"}}},
+							{"prefix": {"Path": {"value": "./parent d!r/"}}}
+						]}},
+						"size": 1000,
+						"sort": [{"ID": "asc"}],
+						"_source": ["ID", "Path", "Deleted"]
+					}`,
+					status:   http.StatusOK,
+					response: `{"hits": {"total": {"value": 1}, "hits": [{"_id": "1$2!4", "_source": {"ID": "1$2!4", "Path": "./parent d!r/child.pdf"}, "sort": ["1$2!4"]}]}}`,
+				},
+				{
+					method: http.MethodPost,
+					path:   "/ocis-resources/_bulk",
+					body: `{"update": {"_id": "1$2!4"}}
+						{"doc": {"Path": "./new/child.pdf"}}`,
+					status:   http.StatusOK,
+					response: `{"errors": false, "items": [{"update": {"_id": "1$2!4", "status": 200}}]}`,
+				},
+				{method: http.MethodPost, path: "/ocis-resources/_search", status: http.StatusOK, response: `{"hits": {"total": {"value": 0}, "hits": []}}`},
+			}
+
+			Expect(eng.Move(parentResource.ID, "1$2!2", "/new")).To(Succeed())
+			recorder.assert()
+			Expect(recorder.requests[4].body).To(ContainSubstring(`"search_after":["1$2!4"]`))
+		})
+
+		It("fails if the resource does not exist", func() {
+			recorder.exchanges = []exchange{
+				{method: http.MethodGet, path: "/ocis-resources/_doc/1$2!3", status: http.StatusNotFound, response: `{"found": false}`},
+			}
+
+			Expect(eng.Move(parentResource.ID, "1$2!2", "/new")).To(MatchError("entity not found"))
+		})
+	})
+
+	Describe("Restore", func() {
+		It("returns the errors of the bulk update", func() {
+			parentResource.Deleted = true
+			source, err := json.Marshal(parentResource)
+			Expect(err).ToNot(HaveOccurred())
+
+			recorder.exchanges = []exchange{
+				{method: http.MethodGet, path: "/ocis-resources/_doc/1$2!3", status: http.StatusOK, response: `{"found": true, "_source": ` + string(source) + `}`},
+				{method: http.MethodPut, path: "/ocis-resources/_doc/1$2!3", status: http.StatusOK, response: `{"result":"updated"}`},
+				{method: http.MethodPost, path: "/ocis-resources/_search", status: http.StatusOK, response: `{"hits": {"hits": [{"_id": "1$2!4", "_source": {"ID": "1$2!4"}, "sort": ["1$2!4"]}]}}`},
+				{
+					method: http.MethodPost,
+					path:   "/ocis-resources/_bulk",
+					body: `{"update": {"_id": "1$2!4"}}
+						{"doc": {"Deleted": false}}`,
+					status:   http.StatusOK,
+					response: `{"errors": true, "items": [{"update": {"_id": "1$2!4", "status": 429, "error": {"type": "es_rejected_execution_exception", "reason": "rejected"}}}]}`,
+				},
+			}
+
+			Expect(eng.Restore(parentResource.ID)).To(MatchError("could not update '1$2!4': opensearch: es_rejected_execution_exception: rejected"))
+			recorder.assert()
+		})
+	})
+
+	Describe("Purge", func() {
+		It("ignores missing resources", func() {
+			recorder.exchanges = []exchange{
+				{method: http.MethodDelete, path: "/ocis-resources/_doc/1$2!3", status: http.StatusNotFound, response: `{"result":"not_found"}`},
+			}
+
+			Expect(eng.Purge(parentResource.ID)).To(Succeed())
+			recorder.assert()
+		})
+	})
+
+	Describe("Resources", func() {
+		It("pages the resources of the space", func() {
+			recorder.exchanges = []exchange{
+				{
+					method: http.MethodPost,
+					path:   "/ocis-resources/_search",
+					body: `{
+						"query": {"term": {"RootID": {"value": "1$2!2"}}},
+						"size": 1000,
+						"sort": [{"ID": "asc"}],
+						"_source": ["ID", "ParentID", "Path", "Type", "Mtime", "Size", "Deleted"]
+					}`,
+					status:   http.StatusOK,
+					response: `{"hits": {"hits": [{"_id": "1$2!3", "_source": {"ID": "1$2!3", "ParentID": "1$2!2", "Path": "./parent d!r", "Type": 2, "Mtime": "2024-01-01T00:00:00Z", "Size": 12, "Deleted": true}, "sort": ["1$2!3"]}]}}`,
+				},
+				{method: http.MethodPost, path: "/ocis-resources/_search", status: http.StatusOK, response: `{"hits": {"hits": []}}`},
+			}
+
+			resources, err := eng.Resources("1$2!2")
+			Expect(err).ToNot(HaveOccurred())
+			recorder.assert()
+			Expect(recorder.requests[1].body).To(ContainSubstring(`"search_after":["1$2!3"]`))
+			Expect(resources).To(HaveLen(1))
+			Expect(resources[0].ID).To(Equal("1$2!3"))
+			Expect(resources[0].RootID).To(Equal("1$2!2"))
+			Expect(resources[0].ParentID).To(Equal("1$2!2"))
+			Expect(resources[0].Path).To(Equal("./parent d!r"))
+			Expect(resources[0].Type).To(Equal(uint64(sprovider.ResourceType_RESOURCE_TYPE_CONTAINER)))
+			Expect(resources[0].Mtime).To(Equal("2024-01-01T00:00:00Z"))
+			Expect(resources[0].Size).To(Equal(uint64(12)))
+			Expect(resources[0].Deleted).To(BeTrue())
+		})
+	})
+
+	Describe("DocCount", func() {
+		It("counts the resources", func() {
+			recorder.exchanges = []exchange{
+				{method: http.MethodGet, path: "/ocis-resources/_count", status: http.StatusOK, response: `{"count": 7}`},
+			}
+
+			count, err := eng.DocCount()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(uint64(7)))
+		})
+	})
+})
+
+// the following tests run against a real instance, e.g.
+// docker run -p 9200:9200 -e discovery.type=single-node -e DISABLE_SECURITY_PLUGIN=true opensearchproject/opensearch
+var _ = Describe("OpenSearch instance", func() {
+	var (
+		address string
+		index   string
+		eng     *engine.OpenSearch
+
+		rootResource   engine.Resource
+		parentResource engine.Resource
+		childResource  engine.Resource
+
+		// refresh makes the changes visible to searches, opensearch does it once per second
+		refresh = func() {
+			req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(address, "/")+"/"+index+"/_refresh", nil)
+			ExpectWithOffset(2, err).ToNot(HaveOccurred())
+			res, err := http.DefaultClient.Do(req)
+			ExpectWithOffset(2, err).ToNot(HaveOccurred())
+			res.Body.Close()
+		}
+
+		assertDocCount = func(query string, expectedCount int) {
+			refresh()
+			res, err := eng.Search(context.Background(), &searchsvc.SearchIndexRequest{
+				Query: query,
+				Ref: &searchmsg.Reference{
+					ResourceId: &searchmsg.ResourceID{StorageId: "1", SpaceId: "2", OpaqueId: "2"},
+				},
+			})
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			ExpectWithOffset(1, res.Matches).To(HaveLen(expectedCount), "query returned unexpected number of results: "+query)
+		}
+	)
+
+	BeforeEach(func() {
+		address = os.Getenv("SEARCH_TEST_OPENSEARCH_ADDRESS")
+		if address == "" {
+			Skip("SEARCH_TEST_OPENSEARCH_ADDRESS is not set")
+		}
+
+		index = "ocis-test-" + uuid.New().String()
+		eng = engine.NewOpenSearchEngine(http.DefaultClient, engine.OpenSearchConfig{
+			Address: address,
+			Index:   index,
+		}, opensearch.DefaultCreator)
+		Expect(eng.EnsureIndex(context.Background())).To(Succeed())
+
+		rootResource = engine.Resource{ID: "1$2!2", RootID: "1$2!2", Path: "."}
+		parentResource = engine.Resource{
+			ID:       "1$2!3",
+			ParentID: rootResource.ID,
+			RootID:   rootResource.ID,
+			Path:     "./parent d!r",
+			Type:     uint64(sprovider.ResourceType_RESOURCE_TYPE_CONTAINER),
+			Document: content.Document{Name: "parent d!r", MimeType: "httpd/unix-directory"},
+		}
+		childResource = engine.Resource{
+			ID:       "1$2!4",
+			ParentID: parentResource.ID,
+			RootID:   rootResource.ID,
+			Path:     "./parent d!r/child.pdf",
+			Type:     uint64(sprovider.ResourceType_RESOURCE_TYPE_FILE),
+			Document: content.Document{Name: "Child.pdf", Size: 12345, MimeType: "application/pdf", Tags: []string{"Foo"}, Content: "the running fox"},
+		}
+
+		for _, r := range []engine.Resource{rootResource, parentResource, childResource} {
+			Expect(eng.Upsert(r.ID, r)).To(Succeed())
+		}
+		refresh()
+	})
+
+	AfterEach(func() {
+		if address == "" {
+			return
+		}
+		req, err := http.NewRequest(http.MethodDelete, strings.TrimSuffix(address, "/")+"/"+index, nil)
+		Expect(err).ToNot(HaveOccurred())
+		res, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		res.Body.Close()
+	})
+
+	It("searches like bleve", func() {
+		assertDocCount("Name:child.pdf", 1)
+		assertDocCount("Name:chi*", 1)
+		assertDocCount("Tags:foo", 1)
+		assertDocCount("Size:>1000", 1)
+		assertDocCount("Size:<1000", 0)
+		assertDocCount("mediatype:folder", 1)
+		assertDocCount("mediatype:pdf", 1)
+		assertDocCount("content:run", 1)
+
+		count, err := eng.DocCount()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(uint64(3)))
+	})
+
+	It("moves, deletes, restores and purges", func() {
+		Expect(eng.Move(parentResource.ID, rootResource.ID, "/renamed")).To(Succeed())
+		assertDocCount(`Name:"parent d!r"`, 0)
+		assertDocCount("Name:renamed", 1)
+		assertDocCount("Path:./renamed/child.pdf", 1)
+
+		Expect(eng.Delete(parentResource.ID)).To(Succeed())
+		assertDocCount("Name:renamed", 0)
+		assertDocCount("Name:child.pdf", 0)
+
+		Expect(eng.Restore(parentResource.ID)).To(Succeed())
+		assertDocCount("Name:renamed", 1)
+		assertDocCount("Name:child.pdf", 1)
+
+		Expect(eng.Purge(childResource.ID)).To(Succeed())
+		assertDocCount("Name:child.pdf", 0)
+	})
+})
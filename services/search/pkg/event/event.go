@@ -0,0 +1,41 @@
+package event
+
+import (
+	"encoding/json"
+
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	types "github.com/cs3org/go-cs3apis/cs3/types/v1beta1"
+)
+
+// ReconcileIndex is emitted to reconcile the index with the storage. Either a specific
+// space or all spaces are reconciled.
+type ReconcileIndex struct {
+	SpaceID   string
+	DryRun    bool
+	Timestamp *types.Timestamp
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (ReconcileIndex) Unmarshal(v []byte) (interface{}, error) {
+	e := ReconcileIndex{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
+
+// SavedSearchMatched is emitted when new resources match a saved search with enabled notifications
+type SavedSearchMatched struct {
+	SavedSearchID string
+	Name          string
+	Query         string
+	Owner         *user.UserId
+	// NewMatches is the number of resources which didn't match the last time the search was run
+	NewMatches int
+	Timestamp  *types.Timestamp
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (SavedSearchMatched) Unmarshal(v []byte) (interface{}, error) {
+	e := SavedSearchMatched{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
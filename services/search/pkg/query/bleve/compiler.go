@@ -8,6 +8,7 @@ import (
 	bleveQuery "github.com/blevesearch/bleve/v2/search/query"
 	"github.com/owncloud/ocis/v2/ocis-pkg/ast"
 	"github.com/owncloud/ocis/v2/ocis-pkg/kql"
+	"github.com/owncloud/ocis/v2/services/search/pkg/query"
 )
 
 var _fields = map[string]string{
@@ -66,7 +67,7 @@ func (c Compiler) Compile(givenAst *ast.Ast) (bleveQuery.Query, error) {
 }
 
 func compile(a *ast.Ast) (bleveQuery.Query, error) {
-	q, _, err := walk(0, a.Nodes)
+	q, err := kql.Walk[bleveQuery.Query](emitter{}, a.Nodes)
 	if err != nil {
 		return nil, err
 	}
@@ -77,146 +78,70 @@ func compile(a *ast.Ast) (bleveQuery.Query, error) {
 	return bleve.NewConjunctionQuery(q), nil
 }
 
-func walk(offset int, nodes []ast.Node) (bleveQuery.Query, int, error) {
-	var prev, next bleveQuery.Query
-	var operator *ast.OperatorNode
-	var isGroup bool
-	for i := offset; i < len(nodes); i++ {
-		switch n := nodes[i].(type) {
-		case *ast.StringNode:
-			k := getField(n.Key)
-			v := n.Value
-			if k != "ID" && k != "Size" {
-				v = bleveEscaper.Replace(n.Value)
-			}
-
-			if k != "Hidden" {
-				v = strings.ToLower(v)
-			}
+// emitter emits the bleve queries of the KQL nodes
+type emitter struct{}
 
-			var q bleveQuery.Query
-			var group bool
-			switch k {
-			case "MimeType":
-				q, group = mimeType(k, v)
-				if prev == nil {
-					isGroup = group
-				}
-			default:
-				q = bleveQuery.NewQueryStringQuery(k + ":" + v)
-			}
+func (emitter) String(n *ast.StringNode) (bleveQuery.Query, bool) {
+	k := getField(n.Key)
+	v := n.Value
+	if k != "ID" && k != "Size" {
+		v = bleveEscaper.Replace(n.Value)
+	}
 
-			if prev == nil {
-				prev = q
-			} else {
-				next = q
-			}
-		case *ast.DateTimeNode:
-			q := &bleveQuery.DateRangeQuery{
-				Start:          bleveQuery.BleveQueryTime{},
-				End:            bleveQuery.BleveQueryTime{},
-				InclusiveStart: nil,
-				InclusiveEnd:   nil,
-				FieldVal:       getField(n.Key),
-			}
+	if k != "Hidden" {
+		v = strings.ToLower(v)
+	}
 
-			if n.Operator == nil {
-				continue
-			}
+	if k == "MimeType" {
+		return mimeType(k, v)
+	}
+	return bleveQuery.NewQueryStringQuery(k + ":" + v), false
+}
 
-			switch n.Operator.Value {
-			case ">":
-				q.Start.Time = n.Value
-				q.InclusiveStart = &[]bool{false}[0]
-			case ">=":
-				q.Start.Time = n.Value
-				q.InclusiveStart = &[]bool{true}[0]
-			case "<":
-				q.End.Time = n.Value
-				q.InclusiveEnd = &[]bool{false}[0]
-			case "<=":
-				q.End.Time = n.Value
-				q.InclusiveEnd = &[]bool{true}[0]
-			default:
-				continue
-			}
+func (emitter) DateTime(n *ast.DateTimeNode) (bleveQuery.Query, bool) {
+	q := &bleveQuery.DateRangeQuery{
+		Start:          bleveQuery.BleveQueryTime{},
+		End:            bleveQuery.BleveQueryTime{},
+		InclusiveStart: nil,
+		InclusiveEnd:   nil,
+		FieldVal:       getField(n.Key),
+	}
 
-			if prev == nil {
-				prev = q
-			} else {
-				next = q
-			}
-		case *ast.BooleanNode:
-			q := bleveQuery.NewQueryStringQuery(getField(n.Key) + fmt.Sprintf(":%v", n.Value))
-			if prev == nil {
-				prev = q
-			} else {
-				next = q
-			}
-		case *ast.GroupNode:
-			if n.Key != "" {
-				n = normalizeGroupingProperty(n)
-			}
-			q, _, err := walk(0, n.Nodes)
-			if err != nil {
-				return nil, 0, err
-			}
-			if prev == nil {
-				prev = q
-				isGroup = true
-			} else {
-				next = q
-			}
-		case *ast.OperatorNode:
-			if n.Value == kql.BoolAND || n.Value == kql.BoolOR {
-				operator = n
-			} else if n.Value == kql.BoolNOT {
-				var err error
-				next, offset, err = nextNode(i+1, nodes)
-				if err != nil {
-					return nil, 0, err
-				}
-				q := bleve.NewBooleanQuery()
-				q.AddMustNot(next)
-				if prev == nil {
-					// unary in the beginning
-					prev = q
-				} else {
-					next = q
-				}
-			}
-		}
-		if prev != nil && next != nil && operator != nil {
-			prev = mapBinary(operator, prev, next, isGroup)
-			isGroup = false
-			operator = nil
-			next = nil
-		}
-		if i < offset {
-			i = offset
-		}
+	if n.Operator == nil {
+		return nil, false
 	}
-	if prev == nil {
-		return nil, 0, fmt.Errorf("can not compile the query")
+
+	switch n.Operator.Value {
+	case ">":
+		q.Start.Time = n.Value
+		q.InclusiveStart = &[]bool{false}[0]
+	case ">=":
+		q.Start.Time = n.Value
+		q.InclusiveStart = &[]bool{true}[0]
+	case "<":
+		q.End.Time = n.Value
+		q.InclusiveEnd = &[]bool{false}[0]
+	case "<=":
+		q.End.Time = n.Value
+		q.InclusiveEnd = &[]bool{true}[0]
+	default:
+		return nil, false
 	}
-	return prev, offset, nil
+	return q, true
 }
 
-func nextNode(offset int, nodes []ast.Node) (bleveQuery.Query, int, error) {
-	if n, ok := nodes[offset].(*ast.GroupNode); ok {
-		gq, _, err := walk(0, n.Nodes)
-		if err != nil {
-			return nil, 0, err
-		}
-		return gq, offset + 1, nil
-	}
-	if n, ok := nodes[offset].(*ast.OperatorNode); ok {
-		if n.Value == kql.BoolNOT {
-			return walk(offset, nodes)
-		}
-	}
-	one := nodes[:offset+1]
-	return walk(offset, one)
+func (emitter) Boolean(n *ast.BooleanNode) bleveQuery.Query {
+	return bleveQuery.NewQueryStringQuery(getField(n.Key) + fmt.Sprintf(":%v", n.Value))
+}
+
+func (emitter) Not(q bleveQuery.Query) bleveQuery.Query {
+	bq := bleve.NewBooleanQuery()
+	bq.AddMustNot(q)
+	return bq
+}
+
+func (emitter) Binary(operator *ast.OperatorNode, ln, rn bleveQuery.Query, leftIsGroup bool) bleveQuery.Query {
+	return mapBinary(operator, ln, rn, leftIsGroup)
 }
 
 func mapBinary(operator *ast.OperatorNode, ln, rn bleveQuery.Query, leftIsGroup bool) bleveQuery.Query {
@@ -275,15 +200,6 @@ func getField(name string) string {
 	return name
 }
 
-func normalizeGroupingProperty(group *ast.GroupNode) *ast.GroupNode {
-	for _, n := range group.Nodes {
-		if onode, ok := n.(*ast.StringNode); ok {
-			onode.Key = group.Key
-		}
-	}
-	return group
-}
-
 func mimeType(k, v string) (bleveQuery.Query, bool) {
 	switch v {
 	case "file":
@@ -293,32 +209,11 @@ func mimeType(k, v string) (bleveQuery.Query, bool) {
 	case "folder":
 		return bleveQuery.NewQueryStringQuery(k + ":httpd/unix-directory"), false
 	case "document":
-		return bleveQuery.NewDisjunctionQuery(newQueryStringQueryList(k,
-			"application/msword",
-			"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
-			"application/vnd.openxmlformats-officedocument.wordprocessingml.form",
-			"application/vnd.oasis.opendocument.text",
-			"text/plain",
-			"text/markdown",
-			"application/rtf",
-			"application/vnd.apple.pages",
-		)), true
+		return bleveQuery.NewDisjunctionQuery(newQueryStringQueryList(k, query.MediaTypes["document"]...)), true
 	case "spreadsheet":
-		return bleveQuery.NewDisjunctionQuery(newQueryStringQueryList(k,
-			"application/vnd.ms-excel",
-			"application/vnd.oasis.opendocument.spreadsheet",
-			"text/csv",
-			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
-			"application/vnd.oasis.opendocument.spreadsheet",
-			"application/vnd.apple.numbers",
-		)), true
+		return bleveQuery.NewDisjunctionQuery(newQueryStringQueryList(k, query.MediaTypes["spreadsheet"]...)), true
 	case "presentation":
-		return bleveQuery.NewDisjunctionQuery(newQueryStringQueryList(k,
-			"application/vnd.openxmlformats-officedocument.presentationml.presentation",
-			"application/vnd.oasis.opendocument.presentation",
-			"application/vnd.ms-powerpoint",
-			"application/vnd.apple.keynote",
-		)), true
+		return bleveQuery.NewDisjunctionQuery(newQueryStringQueryList(k, query.MediaTypes["presentation"]...)), true
 	case "pdf":
 		return bleveQuery.NewQueryStringQuery(k + ":application/pdf"), false
 	case "image":
@@ -328,17 +223,7 @@ func mimeType(k, v string) (bleveQuery.Query, bool) {
 	case "audio":
 		return bleveQuery.NewQueryStringQuery(k + ":audio/*"), false
 	case "archive":
-		return bleveQuery.NewDisjunctionQuery(newQueryStringQueryList(k,
-			"application/zip",
-			"application/gzip",
-			"application/x-gzip",
-			"application/x-7z-compressed",
-			"application/x-rar-compressed",
-			"application/x-tar",
-			"application/x-bzip2",
-			"application/x-bzip",
-			"application/x-tgz",
-		)), true
+		return bleveQuery.NewDisjunctionQuery(newQueryStringQueryList(k, query.MediaTypes["archive"]...)), true
 	default:
 		return bleveQuery.NewQueryStringQuery(k + ":" + v), false
 	}
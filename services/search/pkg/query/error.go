@@ -38,9 +38,18 @@ func (e UnsupportedTimeRangeError) Error() string {
 	return fmt.Sprintf("unable to convert '%v' to a time range", e.Value)
 }
 
+// InvalidSimilarNodeError records an error and the similarity search that caused it.
+type InvalidSimilarNodeError struct {
+	Node *ast.SimilarNode
+}
+
+func (e InvalidSimilarNodeError) Error() string {
+	return "the similarity search '" + e.Node.Value + "' can only be used once and neither negated nor within a group"
+}
+
 func IsValidationError(err error) bool {
 	switch err.(type) {
-	case *StartsWithBinaryOperatorError, *NamedGroupInvalidNodesError, *UnsupportedTimeRangeError:
+	case *StartsWithBinaryOperatorError, *NamedGroupInvalidNodesError, *UnsupportedTimeRangeError, *InvalidSimilarNodeError:
 		return true
 	}
 	return false
@@ -0,0 +1,73 @@
+package query
+
+import (
+	"sort"
+	"strings"
+)
+
+// FolderMimeType is the mime type of folders
+const FolderMimeType = "httpd/unix-directory"
+
+// MediaTypes are the groups of the mediatype filter and their mime types,
+// a mime type ending with a slash matches all subtypes. All resources which aren't folders belong to the group file.
+var MediaTypes = map[string][]string{
+	"folder": {FolderMimeType},
+	"document": {
+		"application/msword",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.form",
+		"application/vnd.oasis.opendocument.text",
+		"text/plain",
+		"text/markdown",
+		"application/rtf",
+		"application/vnd.apple.pages",
+	},
+	"spreadsheet": {
+		"application/vnd.ms-excel",
+		"application/vnd.oasis.opendocument.spreadsheet",
+		"text/csv",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		"application/vnd.apple.numbers",
+	},
+	"presentation": {
+		"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+		"application/vnd.oasis.opendocument.presentation",
+		"application/vnd.ms-powerpoint",
+		"application/vnd.apple.keynote",
+	},
+	"pdf":   {"application/pdf"},
+	"image": {"image/"},
+	"video": {"video/"},
+	"audio": {"audio/"},
+	"archive": {
+		"application/zip",
+		"application/gzip",
+		"application/x-gzip",
+		"application/x-7z-compressed",
+		"application/x-rar-compressed",
+		"application/x-tar",
+		"application/x-bzip2",
+		"application/x-bzip",
+		"application/x-tgz",
+	},
+}
+
+// MediaTypeGroups returns the groups of the mediatype filter which contain the given mime type.
+func MediaTypeGroups(mimeType string) []string {
+	var groups []string
+	if mimeType != FolderMimeType {
+		groups = append(groups, "file")
+	}
+
+	for group, mimeTypes := range MediaTypes {
+		for _, mt := range mimeTypes {
+			if mt == mimeType || (strings.HasSuffix(mt, "/") && strings.HasPrefix(mimeType, mt)) {
+				groups = append(groups, group)
+				break
+			}
+		}
+	}
+	sort.Strings(groups)
+
+	return groups
+}
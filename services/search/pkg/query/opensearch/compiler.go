@@ -0,0 +1,249 @@
+package opensearch
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/ast"
+	"github.com/owncloud/ocis/v2/ocis-pkg/kql"
+	"github.com/owncloud/ocis/v2/services/search/pkg/query"
+)
+
+var _fields = map[string]string{
+	"rootid":    "RootID",
+	"path":      "Path",
+	"id":        "ID",
+	"name":      "Name",
+	"size":      "Size",
+	"mtime":     "Mtime",
+	"mediatype": "MimeType",
+	"type":      "Type",
+	"tag":       "Tags",
+	"tags":      "Tags",
+	"content":   "Content",
+	"hidden":    "Hidden",
+}
+
+// _fulltextFields are analyzed, all other string fields are matched exactly
+var _fulltextFields = map[string]bool{
+	"Content": true,
+	"Title":   true,
+}
+
+// _lowercaseFields are normalized to lowercase on indexing
+var _lowercaseFields = map[string]bool{
+	"Name":     true,
+	"Tags":     true,
+	"MimeType": true,
+	"Content":  true,
+	"Title":    true,
+}
+
+// _numericFields support range values like '>1000'
+var _numericFields = map[string]bool{
+	"Size": true,
+	"Type": true,
+}
+
+// Compiler represents a KQL query search string to the opensearch query formatter.
+type Compiler struct{}
+
+// Compile implements the query formatter which converts the KQL query search string to the opensearch query.
+func (c Compiler) Compile(givenAst *ast.Ast) (Query, error) {
+	q, err := compile(givenAst)
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+func compile(a *ast.Ast) (Query, error) {
+	q, err := kql.Walk[Query](emitter{}, a.Nodes)
+	if err != nil {
+		return nil, err
+	}
+	switch q.(type) {
+	case *ConjunctionQuery, *DisjunctionQuery:
+		return q, nil
+	}
+	return NewConjunctionQuery(q), nil
+}
+
+// emitter emits the opensearch queries of the KQL nodes
+type emitter struct{}
+
+func (emitter) String(n *ast.StringNode) (Query, bool) {
+	k := getField(n.Key)
+	v := n.Value
+	if _lowercaseFields[k] {
+		v = strings.ToLower(v)
+	}
+
+	if k == "MimeType" {
+		return mimeType(k, v)
+	}
+	return stringQuery(k, v), false
+}
+
+func (emitter) DateTime(n *ast.DateTimeNode) (Query, bool) {
+	if n.Operator == nil {
+		return nil, false
+	}
+
+	q := &RangeQuery{Field: getField(n.Key)}
+	v := n.Value.Format(time.RFC3339Nano)
+	switch n.Operator.Value {
+	case ">":
+		q.GT = v
+	case ">=":
+		q.GTE = v
+	case "<":
+		q.LT = v
+	case "<=":
+		q.LTE = v
+	default:
+		return nil, false
+	}
+	return q, true
+}
+
+func (emitter) Boolean(n *ast.BooleanNode) Query {
+	return &TermQuery{Field: getField(n.Key), Value: n.Value}
+}
+
+func (emitter) Not(q Query) Query {
+	return &BooleanQuery{MustNot: []Query{q}}
+}
+
+func (emitter) Binary(operator *ast.OperatorNode, ln, rn Query, leftIsGroup bool) Query {
+	return mapBinary(operator, ln, rn, leftIsGroup)
+}
+
+func mapBinary(operator *ast.OperatorNode, ln, rn Query, leftIsGroup bool) Query {
+	if operator.Value == kql.BoolOR {
+		right, ok := rn.(*DisjunctionQuery)
+		switch left := ln.(type) {
+		case *DisjunctionQuery:
+			if ok {
+				left.AddQuery(right.Disjuncts...)
+			} else {
+				left.AddQuery(rn)
+			}
+			return left
+		case *ConjunctionQuery:
+			return NewDisjunctionQuery(ln, rn)
+		default:
+			if ok {
+				left := NewDisjunctionQuery(ln)
+				left.AddQuery(right.Disjuncts...)
+				return left
+			}
+			return NewDisjunctionQuery(ln, rn)
+		}
+	}
+	if operator.Value == kql.BoolAND {
+		switch left := ln.(type) {
+		case *ConjunctionQuery:
+			left.AddQuery(rn)
+			return left
+		case *DisjunctionQuery:
+			if !leftIsGroup {
+				last := left.Disjuncts[len(left.Disjuncts)-1]
+				rn = NewConjunctionQuery(last, rn)
+				dj := NewDisjunctionQuery(left.Disjuncts[:len(left.Disjuncts)-1]...)
+				dj.AddQuery(rn)
+				return dj
+			}
+		}
+	}
+	return NewConjunctionQuery(ln, rn)
+}
+
+func getField(name string) string {
+	if name == "" {
+		return "Name"
+	}
+	if _, ok := _fields[strings.ToLower(name)]; ok {
+		return _fields[strings.ToLower(name)]
+	}
+	return name
+}
+
+// stringQuery returns the query matching the string value depending on the type of the field
+func stringQuery(k, v string) Query {
+	switch {
+	case _numericFields[k]:
+		return numericQuery(k, v)
+	case k == "Hidden":
+		if b, err := strconv.ParseBool(v); err == nil {
+			return &TermQuery{Field: k, Value: b}
+		}
+		return &TermQuery{Field: k, Value: v}
+	case strings.ContainsAny(v, "*?"):
+		return &WildcardQuery{Field: k, Value: v}
+	case _fulltextFields[k]:
+		return &MatchQuery{Field: k, Value: v}
+	default:
+		return &TermQuery{Field: k, Value: v}
+	}
+}
+
+func numericQuery(k, v string) Query {
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if !strings.HasPrefix(v, op) {
+			continue
+		}
+
+		bound := numericValue(strings.TrimPrefix(v, op))
+		switch op {
+		case ">=":
+			return &RangeQuery{Field: k, GTE: bound}
+		case "<=":
+			return &RangeQuery{Field: k, LTE: bound}
+		case ">":
+			return &RangeQuery{Field: k, GT: bound}
+		default:
+			return &RangeQuery{Field: k, LT: bound}
+		}
+	}
+	return &TermQuery{Field: k, Value: numericValue(v)}
+}
+
+func numericValue(v string) interface{} {
+	if n, err := strconv.ParseUint(v, 10, 64); err == nil {
+		return n
+	}
+	return v
+}
+
+func mimeType(k, v string) (Query, bool) {
+	switch v {
+	case "file":
+		return &BooleanQuery{MustNot: []Query{&TermQuery{Field: k, Value: "httpd/unix-directory"}}}, false
+	case "folder":
+		return &TermQuery{Field: k, Value: "httpd/unix-directory"}, false
+	case "document":
+		return NewDisjunctionQuery(newTermQueryList(k, query.MediaTypes["document"]...)...), true
+	case "spreadsheet":
+		return NewDisjunctionQuery(newTermQueryList(k, query.MediaTypes["spreadsheet"]...)...), true
+	case "presentation":
+		return NewDisjunctionQuery(newTermQueryList(k, query.MediaTypes["presentation"]...)...), true
+	case "pdf":
+		return &TermQuery{Field: k, Value: "application/pdf"}, false
+	case "image", "video", "audio":
+		return &PrefixQuery{Field: k, Value: v + "/"}, false
+	case "archive":
+		return NewDisjunctionQuery(newTermQueryList(k, query.MediaTypes["archive"]...)...), true
+	default:
+		return stringQuery(k, v), false
+	}
+}
+
+func newTermQueryList(k string, v ...string) []Query {
+	list := make([]Query, len(v))
+	for i := 0; i < len(v); i++ {
+		list[i] = &TermQuery{Field: k, Value: v[i]}
+	}
+	return list
+}
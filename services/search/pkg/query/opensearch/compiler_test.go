@@ -0,0 +1,175 @@
+package opensearch
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/ast"
+	tAssert "github.com/stretchr/testify/assert"
+)
+
+func Test_compile(t *testing.T) {
+	mtime := time.Date(2023, 9, 5, 8, 42, 11, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		args    *ast.Ast
+		want    Query
+		wantErr bool
+	}{
+		{
+			name: `federated`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.StringNode{Value: "federated"},
+				},
+			},
+			want: NewConjunctionQuery(
+				&TermQuery{Field: "Name", Value: "federated"},
+			),
+		},
+		{
+			name: `"John Smith" Jane`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.StringNode{Key: "name", Value: "John Smith"},
+					&ast.OperatorNode{Value: "AND"},
+					&ast.StringNode{Key: "name", Value: "Jane"},
+				},
+			},
+			want: NewConjunctionQuery(
+				&TermQuery{Field: "Name", Value: "john smith"},
+				&TermQuery{Field: "Name", Value: "jane"},
+			),
+		},
+		{
+			name: `name:"foo o*" OR tag:book`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.StringNode{Key: "name", Value: "foo o*"},
+					&ast.OperatorNode{Value: "OR"},
+					&ast.StringNode{Key: "tag", Value: "Book"},
+				},
+			},
+			want: NewDisjunctionQuery(
+				&WildcardQuery{Field: "Name", Value: "foo o*"},
+				&TermQuery{Field: "Tags", Value: "book"},
+			),
+		},
+		{
+			name: `a OR b AND c`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.StringNode{Value: "a"},
+					&ast.OperatorNode{Value: "OR"},
+					&ast.StringNode{Value: "b"},
+					&ast.OperatorNode{Value: "AND"},
+					&ast.StringNode{Value: "c"},
+				},
+			},
+			want: NewDisjunctionQuery(
+				&TermQuery{Field: "Name", Value: "a"},
+				NewConjunctionQuery(
+					&TermQuery{Field: "Name", Value: "b"},
+					&TermQuery{Field: "Name", Value: "c"},
+				),
+			),
+		},
+		{
+			name: `NOT a AND content:"some text"`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.OperatorNode{Value: "NOT"},
+					&ast.StringNode{Value: "a"},
+					&ast.OperatorNode{Value: "AND"},
+					&ast.StringNode{Key: "content", Value: "Some Text"},
+				},
+			},
+			want: NewConjunctionQuery(
+				&BooleanQuery{MustNot: []Query{&TermQuery{Field: "Name", Value: "a"}}},
+				&MatchQuery{Field: "Content", Value: "some text"},
+			),
+		},
+		{
+			name: `size:>1000 AND hidden:T`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.StringNode{Key: "size", Value: ">1000"},
+					&ast.OperatorNode{Value: "AND"},
+					&ast.StringNode{Key: "hidden", Value: "T"},
+				},
+			},
+			want: NewConjunctionQuery(
+				&RangeQuery{Field: "Size", GT: uint64(1000)},
+				&TermQuery{Field: "Hidden", Value: true},
+			),
+		},
+		{
+			name: `mtime>=2023-09-05T08:42:11Z`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.DateTimeNode{Key: "mtime", Operator: &ast.OperatorNode{Value: ">="}, Value: mtime},
+				},
+			},
+			want: NewConjunctionQuery(
+				&RangeQuery{Field: "Mtime", GTE: "2023-09-05T08:42:11Z"},
+			),
+		},
+		{
+			name: `mediatype:(image OR folder)`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.GroupNode{Key: "mediatype", Nodes: []ast.Node{
+						&ast.StringNode{Value: "image"},
+						&ast.OperatorNode{Value: "OR"},
+						&ast.StringNode{Value: "folder"},
+					}},
+				},
+			},
+			want: NewDisjunctionQuery(
+				&PrefixQuery{Field: "MimeType", Value: "image/"},
+				&TermQuery{Field: "MimeType", Value: "httpd/unix-directory"},
+			),
+		},
+		{
+			name:    `empty`,
+			args:    &ast.Ast{},
+			wantErr: true,
+		},
+	}
+
+	assert := tAssert.New(t)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compiler{}.Compile(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Compile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func Test_marshal(t *testing.T) {
+	q := NewDisjunctionQuery(
+		&BooleanQuery{MustNot: []Query{&TermQuery{Field: "MimeType", Value: "httpd/unix-directory"}}},
+		NewConjunctionQuery(
+			&WildcardQuery{Field: "Name", Value: "foo*"},
+			&RangeQuery{Field: "Size", GTE: uint64(10), LT: uint64(20)},
+		),
+	)
+
+	b, err := json.Marshal(q)
+	tAssert.NoError(t, err)
+	tAssert.JSONEq(t, `{"bool": {"minimum_should_match": 1, "should": [
+		{"bool": {"must_not": [{"term": {"MimeType": {"value": "httpd/unix-directory"}}}]}},
+		{"bool": {"must": [
+			{"wildcard": {"Name": {"value": "foo*", "case_insensitive": true}}},
+			{"range": {"Size": {"gte": 10, "lt": 20}}}
+		]}}
+	]}}`, string(b))
+}
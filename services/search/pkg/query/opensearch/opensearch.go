@@ -0,0 +1,160 @@
+// Package opensearch provides the ability to work with opensearch queries.
+package opensearch
+
+import (
+	"encoding/json"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/kql"
+	"github.com/owncloud/ocis/v2/services/search/pkg/query"
+)
+
+// Query is a query of the opensearch query DSL.
+type Query interface {
+	json.Marshaler
+}
+
+// TermQuery matches documents which contain the exact value in the field.
+type TermQuery struct {
+	Field string
+	Value interface{}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (q *TermQuery) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"term": map[string]interface{}{q.Field: map[string]interface{}{"value": q.Value}},
+	})
+}
+
+// WildcardQuery matches documents which contain a value in the field matching the pattern, '*' and '?' are supported.
+type WildcardQuery struct {
+	Field string
+	Value string
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (q *WildcardQuery) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"wildcard": map[string]interface{}{q.Field: map[string]interface{}{"value": q.Value, "case_insensitive": true}},
+	})
+}
+
+// PrefixQuery matches documents which contain a value in the field starting with the prefix.
+type PrefixQuery struct {
+	Field string
+	Value string
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (q *PrefixQuery) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"prefix": map[string]interface{}{q.Field: map[string]interface{}{"value": q.Value}},
+	})
+}
+
+// MatchQuery matches documents which contain all terms of the analyzed text in the field.
+type MatchQuery struct {
+	Field string
+	Value string
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (q *MatchQuery) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"match": map[string]interface{}{q.Field: map[string]interface{}{"query": q.Value, "operator": "and"}},
+	})
+}
+
+// RangeQuery matches documents which contain a value in the field within the range, unset bounds are ignored.
+type RangeQuery struct {
+	Field string
+	GT    interface{}
+	GTE   interface{}
+	LT    interface{}
+	LTE   interface{}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (q *RangeQuery) MarshalJSON() ([]byte, error) {
+	bounds := map[string]interface{}{}
+	for k, v := range map[string]interface{}{"gt": q.GT, "gte": q.GTE, "lt": q.LT, "lte": q.LTE} {
+		if v != nil {
+			bounds[k] = v
+		}
+	}
+	return json.Marshal(map[string]interface{}{
+		"range": map[string]interface{}{q.Field: bounds},
+	})
+}
+
+// ConjunctionQuery matches documents which match all of the queries.
+type ConjunctionQuery struct {
+	Conjuncts []Query
+}
+
+// NewConjunctionQuery returns a new ConjunctionQuery.
+func NewConjunctionQuery(conjuncts ...Query) *ConjunctionQuery {
+	return &ConjunctionQuery{Conjuncts: conjuncts}
+}
+
+// AddQuery adds queries to the conjunction.
+func (q *ConjunctionQuery) AddQuery(aq ...Query) {
+	q.Conjuncts = append(q.Conjuncts, aq...)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (q *ConjunctionQuery) MarshalJSON() ([]byte, error) {
+	return (&BooleanQuery{Must: q.Conjuncts}).MarshalJSON()
+}
+
+// DisjunctionQuery matches documents which match at least one of the queries.
+type DisjunctionQuery struct {
+	Disjuncts []Query
+}
+
+// NewDisjunctionQuery returns a new DisjunctionQuery.
+func NewDisjunctionQuery(disjuncts ...Query) *DisjunctionQuery {
+	return &DisjunctionQuery{Disjuncts: disjuncts}
+}
+
+// AddQuery adds queries to the disjunction.
+func (q *DisjunctionQuery) AddQuery(aq ...Query) {
+	q.Disjuncts = append(q.Disjuncts, aq...)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (q *DisjunctionQuery) MarshalJSON() ([]byte, error) {
+	return (&BooleanQuery{Should: q.Disjuncts}).MarshalJSON()
+}
+
+// BooleanQuery combines queries, documents must match all Must and Filter queries,
+// none of the MustNot queries and at least one of the Should queries if any are given.
+// Filter queries do not contribute to the score.
+type BooleanQuery struct {
+	Must    []Query
+	Filter  []Query
+	MustNot []Query
+	Should  []Query
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (q *BooleanQuery) MarshalJSON() ([]byte, error) {
+	b := map[string]interface{}{}
+	if len(q.Must) > 0 {
+		b["must"] = q.Must
+	}
+	if len(q.Filter) > 0 {
+		b["filter"] = q.Filter
+	}
+	if len(q.MustNot) > 0 {
+		b["must_not"] = q.MustNot
+	}
+	if len(q.Should) > 0 {
+		b["should"] = q.Should
+		b["minimum_should_match"] = 1
+	}
+	return json.Marshal(map[string]interface{}{"bool": b})
+}
+
+// DefaultCreator exposes a kql to opensearch query creator.
+var DefaultCreator = query.NewCreator[Query](kql.Builder{}, Compiler{})
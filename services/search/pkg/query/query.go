@@ -17,3 +17,23 @@ type Compiler[T any] interface {
 type Creator[T any] interface {
 	Create(qs string) (T, error)
 }
+
+// NewCreator returns a Creator which builds the ast with the given Builder and compiles it with the given Compiler.
+func NewCreator[T any](builder Builder, compiler Compiler[T]) Creator[T] {
+	return creator[T]{builder: builder, compiler: compiler}
+}
+
+type creator[T any] struct {
+	builder  Builder
+	compiler Compiler[T]
+}
+
+func (c creator[T]) Create(qs string) (T, error) {
+	var t T
+	a, err := c.builder.Build(qs)
+	if err != nil {
+		return t, err
+	}
+
+	return c.compiler.Compile(a)
+}
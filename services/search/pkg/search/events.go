@@ -8,6 +8,7 @@ import (
 	"github.com/cs3org/reva/v2/pkg/storagespace"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+	searchevent "github.com/owncloud/ocis/v2/services/search/pkg/event"
 )
 
 // HandleEvents listens to the needed events,
@@ -23,6 +24,7 @@ func HandleEvents(s Searcher, bus events.Consumer, logger log.Logger, cfg *confi
 		events.TagsAdded{},
 		events.TagsRemoved{},
 		events.SpaceRenamed{},
+		searchevent.ReconcileIndex{},
 	}
 
 	if cfg.Events.AsyncUploads {
@@ -90,6 +92,8 @@ func HandleEvents(s Searcher, bus events.Consumer, logger log.Logger, cfg *confi
 						indexSpaceDebouncer.Debounce(getSpaceID(ev.FileRef))
 					case events.SpaceRenamed:
 						indexSpaceDebouncer.Debounce(ev.ID)
+					case searchevent.ReconcileIndex:
+						reconcile(s, ev, logger)
 					}
 				}()
 			}
@@ -101,3 +105,16 @@ func HandleEvents(s Searcher, bus events.Consumer, logger log.Logger, cfg *confi
 
 	return nil
 }
+
+func reconcile(s Searcher, ev searchevent.ReconcileIndex, logger log.Logger) {
+	if ev.SpaceID == "" {
+		if err := s.ReconcileSpaces(ev.DryRun); err != nil {
+			logger.Error().Err(err).Msg("failed to reconcile the spaces")
+		}
+		return
+	}
+
+	if _, err := s.ReconcileSpace(&provider.StorageSpaceId{OpaqueId: ev.SpaceID}, ev.DryRun); err != nil {
+		logger.Error().Err(err).Str("spaceID", ev.SpaceID).Msg("failed to reconcile the space")
+	}
+}
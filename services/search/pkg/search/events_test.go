@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+	"github.com/owncloud/ocis/v2/services/search/pkg/event"
 	"github.com/owncloud/ocis/v2/services/search/pkg/search"
 	searchMocks "github.com/owncloud/ocis/v2/services/search/pkg/search/mocks"
 	"github.com/stretchr/testify/mock"
@@ -54,4 +55,5 @@ var _ = DescribeTable("events",
 	Entry("TagsRemoved", []string{"UpsertItem"}, events.TagsRemoved{}, false),
 	Entry("FileUploaded", []string{"IndexSpace"}, events.FileUploaded{}, false),
 	Entry("UploadReady", []string{"IndexSpace"}, events.UploadReady{ExecutingUser: &userv1beta1.User{}}, true),
+	Entry("ReconcileIndex", []string{"ReconcileSpaces"}, event.ReconcileIndex{DryRun: true}, false),
 )
@@ -8,6 +8,8 @@ import (
 	providerv1beta1 "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
 	mock "github.com/stretchr/testify/mock"
 
+	search "github.com/owncloud/ocis/v2/services/search/pkg/search"
+
 	v0 "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
 )
 
@@ -103,6 +105,109 @@ func (_c *Searcher_MoveItem_Call) RunAndReturn(run func(*providerv1beta1.Referen
 	return _c
 }
 
+// ReconcileSpace provides a mock function with given fields: spaceID, dryRun
+func (_m *Searcher) ReconcileSpace(spaceID *providerv1beta1.StorageSpaceId, dryRun bool) (search.ReconcileReport, error) {
+	ret := _m.Called(spaceID, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileSpace")
+	}
+
+	var r0 search.ReconcileReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*providerv1beta1.StorageSpaceId, bool) (search.ReconcileReport, error)); ok {
+		return rf(spaceID, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(*providerv1beta1.StorageSpaceId, bool) search.ReconcileReport); ok {
+		r0 = rf(spaceID, dryRun)
+	} else {
+		r0 = ret.Get(0).(search.ReconcileReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(*providerv1beta1.StorageSpaceId, bool) error); ok {
+		r1 = rf(spaceID, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Searcher_ReconcileSpace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReconcileSpace'
+type Searcher_ReconcileSpace_Call struct {
+	*mock.Call
+}
+
+// ReconcileSpace is a helper method to define mock.On call
+//   - spaceID *providerv1beta1.StorageSpaceId
+//   - dryRun bool
+func (_e *Searcher_Expecter) ReconcileSpace(spaceID interface{}, dryRun interface{}) *Searcher_ReconcileSpace_Call {
+	return &Searcher_ReconcileSpace_Call{Call: _e.mock.On("ReconcileSpace", spaceID, dryRun)}
+}
+
+func (_c *Searcher_ReconcileSpace_Call) Run(run func(spaceID *providerv1beta1.StorageSpaceId, dryRun bool)) *Searcher_ReconcileSpace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*providerv1beta1.StorageSpaceId), args[1].(bool))
+	})
+	return _c
+}
+
+func (_c *Searcher_ReconcileSpace_Call) Return(_a0 search.ReconcileReport, _a1 error) *Searcher_ReconcileSpace_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Searcher_ReconcileSpace_Call) RunAndReturn(run func(*providerv1beta1.StorageSpaceId, bool) (search.ReconcileReport, error)) *Searcher_ReconcileSpace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReconcileSpaces provides a mock function with given fields: dryRun
+func (_m *Searcher) ReconcileSpaces(dryRun bool) error {
+	ret := _m.Called(dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileSpaces")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bool) error); ok {
+		r0 = rf(dryRun)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Searcher_ReconcileSpaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReconcileSpaces'
+type Searcher_ReconcileSpaces_Call struct {
+	*mock.Call
+}
+
+// ReconcileSpaces is a helper method to define mock.On call
+//   - dryRun bool
+func (_e *Searcher_Expecter) ReconcileSpaces(dryRun interface{}) *Searcher_ReconcileSpaces_Call {
+	return &Searcher_ReconcileSpaces_Call{Call: _e.mock.On("ReconcileSpaces", dryRun)}
+}
+
+func (_c *Searcher_ReconcileSpaces_Call) Run(run func(dryRun bool)) *Searcher_ReconcileSpaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(bool))
+	})
+	return _c
+}
+
+func (_c *Searcher_ReconcileSpaces_Call) Return(_a0 error) *Searcher_ReconcileSpaces_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Searcher_ReconcileSpaces_Call) RunAndReturn(run func(bool) error) *Searcher_ReconcileSpaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RestoreItem provides a mock function with given fields: ref
 func (_m *Searcher) RestoreItem(ref *providerv1beta1.Reference) {
 	_m.Called(ref)
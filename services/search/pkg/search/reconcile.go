@@ -0,0 +1,239 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"time"
+
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/storage/utils/walker"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"github.com/cs3org/reva/v2/pkg/utils"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/search/pkg/engine"
+)
+
+const (
+	// _reconcileLock is the name of the lock electing the instance which runs the scheduled reconciliation
+	_reconcileLock = "reconcile"
+	// _scheduleLockTTL is the time after which another instance takes over a schedule if its runner died
+	_scheduleLockTTL = 30 * time.Second
+)
+
+// ReconcileReport summarizes the differences between a space and the index.
+type ReconcileReport struct {
+	SpaceID string
+	DryRun  bool
+	// Checked is the number of resources in the space
+	Checked int
+	// Added is the number of resources which were missing in the index
+	Added int
+	// Updated is the number of resources which changed since they were indexed
+	Updated int
+	// Moved is the number of resources which were indexed with an outdated path
+	Moved int
+	// Purged is the number of indexed resources which don't exist in the space anymore
+	Purged   int
+	Duration time.Duration
+}
+
+// ReconcileSpace compares all resources of the space with the index and upserts, moves or purges only the
+// resources which drifted. Nothing is changed in dry run mode, the report contains the changes which would be made.
+func (s *Service) ReconcileSpace(spaceID *provider.StorageSpaceId, dryRun bool) (ReconcileReport, error) {
+	start := time.Now()
+	report := ReconcileReport{SpaceID: spaceID.GetOpaqueId(), DryRun: dryRun}
+
+	ownerCtx, err := getAuthContext(s.serviceAccountID, s.gatewaySelector, s.serviceAccountSecret, s.logger)
+	if err != nil {
+		return report, err
+	}
+
+	rootID, err := spaceRootID(spaceID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("invalid space id")
+		return report, err
+	}
+
+	resources, err := s.engine.Resources(storagespace.FormatResourceID(rootID))
+	if err != nil {
+		return report, err
+	}
+	indexed := make(map[string]engine.Resource, len(resources))
+	for _, r := range resources {
+		indexed[r.ID] = r
+	}
+
+	// moves of containers also move the indexed descendants, their old paths are rewritten to not move them again
+	type move struct{ from, to string }
+	var moves []move
+
+	w := walker.NewWalker(s.gatewaySelector)
+	err = w.Walk(ownerCtx, rootID, func(wd string, info *provider.ResourceInfo, err error) error {
+		if err != nil {
+			s.logger.Error().Err(err).Msg("error walking the tree")
+			return err
+		}
+
+		if info == nil {
+			return nil
+		}
+
+		report.Checked++
+		id := storagespace.FormatResourceID(info.Id)
+		ref := &provider.Reference{
+			Path:       utils.MakeRelativePath(filepath.Join(wd, info.Path)),
+			ResourceId: rootID,
+		}
+
+		r, ok := indexed[id]
+		delete(indexed, id)
+		if !ok {
+			report.Added++
+			if !dryRun {
+				s.UpsertItem(ref)
+			}
+			return nil
+		}
+
+		for _, m := range moves {
+			if strings.HasPrefix(r.Path, m.from+"/") {
+				r.Path = m.to + strings.TrimPrefix(r.Path, m.from)
+			}
+		}
+
+		// the path of the space root is resolved differently by the walker
+		if info.Id.OpaqueId != rootID.OpaqueId && r.Path != ref.Path {
+			report.Moved++
+			if info.Type == provider.ResourceType_RESOURCE_TYPE_CONTAINER {
+				moves = append(moves, move{from: r.Path, to: ref.Path})
+			}
+			if !dryRun {
+				if err := s.engine.Move(id, storagespace.FormatResourceID(info.ParentId), ref.Path); err != nil {
+					s.logger.Error().Err(err).Str("id", id).Msg("failed to move the drifted resource in the index")
+				}
+			}
+		}
+
+		if r.Deleted || r.Mtime != utils.TSToTime(info.Mtime).UTC().Format(time.RFC3339Nano) || r.Size != info.Size {
+			report.Updated++
+			if !dryRun {
+				s.UpsertItem(ref)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	// resources which are still marked as deleted are kept, they can be restored from the trash-bin
+	for id, r := range indexed {
+		if r.Deleted {
+			continue
+		}
+
+		report.Purged++
+		if !dryRun {
+			if err := s.engine.Purge(id); err != nil {
+				s.logger.Error().Err(err).Str("id", id).Msg("failed to purge the stale resource from the index")
+			}
+		}
+	}
+
+	report.Duration = time.Since(start)
+	s.logger.Info().
+		Str("spaceID", report.SpaceID).
+		Bool("dryRun", report.DryRun).
+		Int("checked", report.Checked).
+		Int("added", report.Added).
+		Int("updated", report.Updated).
+		Int("moved", report.Moved).
+		Int("purged", report.Purged).
+		Dur("duration", report.Duration).
+		Msg("reconciled the space with the index")
+
+	return report, nil
+}
+
+// ReconcileSpaces reconciles all spaces with the index, spaces which fail are skipped.
+func (s *Service) ReconcileSpaces(dryRun bool) error {
+	ownerCtx, err := getAuthContext(s.serviceAccountID, s.gatewaySelector, s.serviceAccountSecret, s.logger)
+	if err != nil {
+		return err
+	}
+
+	gatewayClient, err := s.gatewaySelector.Next()
+	if err != nil {
+		return err
+	}
+
+	res, err := gatewayClient.ListStorageSpaces(ownerCtx, &provider.ListStorageSpacesRequest{})
+	if err != nil {
+		return err
+	}
+	if res.GetStatus().GetCode() != rpc.Code_CODE_OK {
+		return errors.New(res.GetStatus().GetMessage())
+	}
+
+	for _, space := range res.GetStorageSpaces() {
+		if space.GetSpaceType() == _spaceTypeMountpoint {
+			continue
+		}
+
+		if _, err := s.ReconcileSpace(space.GetId(), dryRun); err != nil {
+			s.logger.Error().Err(err).Str("spaceID", space.GetId().GetOpaqueId()).Msg("failed to reconcile the space")
+		}
+	}
+
+	return nil
+}
+
+// ScheduleReconcile reconciles all spaces with the index in the given interval until the returned function is called.
+// Only the instance holding the reconcile lock in the given store runs the schedule.
+func ScheduleReconcile(s Searcher, locks kv.Store, interval time.Duration, logger log.Logger) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		kv.Lead(ctx, locks, _reconcileLock, _scheduleLockTTL, func(ctx context.Context) {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := s.ReconcileSpaces(false); err != nil {
+						logger.Error().Err(err).Msg("failed to reconcile the spaces")
+					}
+				}
+			}
+		})
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// spaceRootID returns the id of the root of the given space.
+func spaceRootID(spaceID *provider.StorageSpaceId) (*provider.ResourceId, error) {
+	rootID, err := storagespace.ParseID(spaceID.GetOpaqueId())
+	if err != nil {
+		return nil, err
+	}
+	if rootID.StorageId == "" || rootID.SpaceId == "" {
+		return nil, errors.New("invalid space id")
+	}
+	rootID.OpaqueId = rootID.SpaceId
+
+	return &rootID, nil
+}
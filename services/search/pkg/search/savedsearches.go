@@ -0,0 +1,378 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/errtypes"
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"github.com/google/uuid"
+	microstore "go-micro.dev/v4/store"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/kql"
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	searchmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
+	searchsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+	searchevent "github.com/owncloud/ocis/v2/services/search/pkg/event"
+)
+
+const (
+	// _savedSearchPageSize is the number of new matches the watermark of a saved search is advanced by per run
+	_savedSearchPageSize = 1000
+	// _savedSearchesLock is the name of the lock electing the instance which runs the saved searches
+	_savedSearchesLock = "saved-searches"
+)
+
+// SavedSearches manages the saved searches of the users. Saved searches with enabled notifications are
+// run again as their owner, the owner is notified when new resources match.
+type SavedSearches struct {
+	store             microstore.Store
+	searcher          Searcher
+	publisher         events.Publisher
+	gatewaySelector   pool.Selectable[gateway.GatewayAPIClient]
+	logger            log.Logger
+	maxPerUser        int
+	machineAuthAPIKey string
+}
+
+// savedSearch is the record of a saved search in the store
+type savedSearch struct {
+	ID      string
+	Owner   *user.UserId
+	Name    string
+	Query   string
+	Ref     *searchmsg.Reference
+	Notify  bool
+	Created time.Time
+	// Watermark is the latest modification time of the matches which were already notified,
+	// only resources modified later are new matches
+	Watermark time.Time
+}
+
+// NewSavedSearches creates a new SavedSearches instance.
+func NewSavedSearches(store microstore.Store, searcher Searcher, publisher events.Publisher, gatewaySelector pool.Selectable[gateway.GatewayAPIClient], logger log.Logger, cfg *config.Config) *SavedSearches {
+	return &SavedSearches{
+		store:             store,
+		searcher:          searcher,
+		publisher:         publisher,
+		gatewaySelector:   gatewaySelector,
+		logger:            logger,
+		maxPerUser:        cfg.SavedSearches.MaxPerUser,
+		machineAuthAPIKey: cfg.MachineAuthAPIKey,
+	}
+}
+
+// Create saves the search for the user of the context. The search is run once to validate the query,
+// only resources modified after the creation trigger a notification.
+func (ss *SavedSearches) Create(ctx context.Context, s *searchmsg.SavedSearch) (*searchmsg.SavedSearch, error) {
+	u := revactx.ContextMustGetUser(ctx)
+	if strings.TrimSpace(s.GetQuery()) == "" {
+		return nil, errtypes.BadRequest("empty query provided")
+	}
+
+	existing, err := ss.list(u.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if ss.maxPerUser > 0 && len(existing) >= ss.maxPerUser {
+		return nil, errtypes.BadRequest("the maximum number of saved searches is reached")
+	}
+
+	now := time.Now()
+	record := savedSearch{
+		ID:        uuid.New().String(),
+		Owner:     u.GetId(),
+		Name:      s.GetName(),
+		Query:     s.GetQuery(),
+		Ref:       s.GetRef(),
+		Notify:    s.GetNotify(),
+		Created:   now,
+		Watermark: now,
+	}
+	if record.Name == "" {
+		record.Name = record.Query
+	}
+
+	if _, err := ss.searcher.Search(ctx, record.request(1, nil)); err != nil {
+		return nil, err
+	}
+
+	if err := ss.write(record); err != nil {
+		return nil, err
+	}
+
+	return record.toProto(), nil
+}
+
+// List returns the saved searches of the user of the context ordered by their creation.
+func (ss *SavedSearches) List(ctx context.Context) ([]*searchmsg.SavedSearch, error) {
+	records, err := ss.list(revactx.ContextMustGetUser(ctx).GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	saved := make([]*searchmsg.SavedSearch, 0, len(records))
+	for _, r := range records {
+		saved = append(saved, r.toProto())
+	}
+	return saved, nil
+}
+
+// Delete deletes the saved search of the user of the context.
+func (ss *SavedSearches) Delete(ctx context.Context, id string) error {
+	r, err := ss.read(revactx.ContextMustGetUser(ctx).GetId(), id)
+	if err != nil {
+		return err
+	}
+
+	return ss.store.Delete(r.key())
+}
+
+// Run runs the saved search of the user of the context.
+func (ss *SavedSearches) Run(ctx context.Context, id string, pageSize int32, facets []string) (*searchsvc.SearchResponse, error) {
+	r, err := ss.read(revactx.ContextMustGetUser(ctx).GetId(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ss.searcher.Search(ctx, r.request(pageSize, facets))
+}
+
+// Notify runs the saved searches with enabled notifications as their owner and notifies the owner
+// about new matches.
+func (ss *SavedSearches) Notify() error {
+	keys, err := ss.store.List()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		recs, err := ss.store.Read(key)
+		if err != nil || len(recs) == 0 {
+			ss.logger.Error().Err(err).Str("key", key).Msg("failed to read the saved search")
+			continue
+		}
+
+		var r savedSearch
+		if err := json.Unmarshal(recs[0].Value, &r); err != nil {
+			ss.logger.Error().Err(err).Str("key", key).Msg("failed to decode the saved search")
+			continue
+		}
+		if !r.Notify {
+			continue
+		}
+
+		if err := ss.notify(r); err != nil {
+			ss.logger.Error().Err(err).Str("id", r.ID).Str("owner", r.Owner.GetOpaqueId()).Msg("failed to run the saved search")
+		}
+	}
+
+	return nil
+}
+
+func (ss *SavedSearches) notify(r savedSearch) error {
+	ctx, err := ss.ownerContext(r.Owner)
+	if err != nil {
+		return err
+	}
+
+	req, err := r.watermarkRequest()
+	if err != nil {
+		return err
+	}
+	res, err := ss.searcher.Search(ctx, req)
+	if err != nil {
+		return err
+	}
+	if res.GetTotalMatches() == 0 {
+		return nil
+	}
+
+	// all matches are reported, the watermark only advances to the latest match of the page.
+	// Matches beyond the page which were modified later are reported again by the next run.
+	for _, m := range res.GetMatches() {
+		if mtime := m.GetEntity().GetLastModifiedTime().AsTime(); mtime.After(r.Watermark) {
+			r.Watermark = mtime
+		}
+	}
+	if err := ss.write(r); err != nil {
+		return err
+	}
+
+	return events.Publish(context.Background(), ss.publisher, searchevent.SavedSearchMatched{
+		SavedSearchID: r.ID,
+		Name:          r.Name,
+		Query:         r.Query,
+		Owner:         r.Owner,
+		NewMatches:    int(res.GetTotalMatches()),
+		Timestamp:     utils.TSNow(),
+	})
+}
+
+// ownerContext returns a context authenticated as the given user, the saved searches see what their owner sees
+func (ss *SavedSearches) ownerContext(owner *user.UserId) (context.Context, error) {
+	gatewayClient, err := ss.gatewaySelector.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	authRes, err := gatewayClient.Authenticate(context.Background(), &gateway.AuthenticateRequest{
+		Type:         "machine",
+		ClientId:     "userid:" + owner.GetOpaqueId(),
+		ClientSecret: ss.machineAuthAPIKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if authRes.GetStatus().GetCode() != rpc.Code_CODE_OK {
+		return nil, errors.New("could not authenticate the owner of the saved search: " + authRes.GetStatus().GetMessage())
+	}
+
+	ctx := revactx.ContextSetUser(context.Background(), authRes.GetUser())
+	return metadata.AppendToOutgoingContext(ctx, revactx.TokenHeader, authRes.GetToken()), nil
+}
+
+// ScheduleSavedSearchNotifications runs the saved searches with enabled notifications in the given interval
+// until the returned function is called. Only the instance holding the lock in the given store runs the schedule.
+func ScheduleSavedSearchNotifications(ss *SavedSearches, locks kv.Store, interval time.Duration, logger log.Logger) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		kv.Lead(ctx, locks, _savedSearchesLock, _scheduleLockTTL, func(ctx context.Context) {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := ss.Notify(); err != nil {
+						logger.Error().Err(err).Msg("failed to run the saved searches")
+					}
+				}
+			}
+		})
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func (ss *SavedSearches) list(owner *user.UserId) ([]savedSearch, error) {
+	keys, err := ss.store.List(microstore.ListPrefix(owner.GetOpaqueId() + "_"))
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]savedSearch, 0, len(keys))
+	for _, key := range keys {
+		_, id, _ := strings.Cut(key, "_")
+		r, err := ss.read(owner, id)
+		if err != nil {
+			ss.logger.Error().Err(err).Str("key", key).Msg("failed to read the saved search")
+			continue
+		}
+		records = append(records, r)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Created.Before(records[j].Created)
+	})
+	return records, nil
+}
+
+func (ss *SavedSearches) read(owner *user.UserId, id string) (savedSearch, error) {
+	var r savedSearch
+	recs, err := ss.store.Read(owner.GetOpaqueId() + "_" + id)
+	switch {
+	case errors.Is(err, microstore.ErrNotFound), err == nil && len(recs) == 0:
+		return r, errtypes.NotFound("saved search " + id)
+	case err != nil:
+		return r, err
+	}
+
+	err = json.Unmarshal(recs[0].Value, &r)
+	return r, err
+}
+
+func (ss *SavedSearches) write(r savedSearch) error {
+	v, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	return ss.store.Write(&microstore.Record{Key: r.key(), Value: v})
+}
+
+func (r savedSearch) key() string {
+	return r.Owner.GetOpaqueId() + "_" + r.ID
+}
+
+func (r savedSearch) request(pageSize int32, facets []string) *searchsvc.SearchRequest {
+	return &searchsvc.SearchRequest{
+		Query:    r.Query,
+		Ref:      r.Ref,
+		PageSize: pageSize,
+		Facets:   facets,
+	}
+}
+
+// watermarkRequest returns the request finding the matches modified after the watermark. The scope and the
+// similarity search are kept outside of the grouped query, they are only recognized on the top level.
+func (r savedSearch) watermarkRequest() (*searchsvc.SearchRequest, error) {
+	q, scope := ParseScope(r.Query)
+	q, similar, err := kql.Similar(q)
+	if err != nil {
+		return nil, err
+	}
+
+	watermark := r.Watermark
+	if watermark.IsZero() {
+		watermark = r.Created
+	}
+
+	parts := make([]string, 0, 3)
+	if q != "" {
+		parts = append(parts, "("+q+")")
+	}
+	parts = append(parts, `mtime>"`+watermark.UTC().Format(time.RFC3339Nano)+`"`)
+	if similar != "" {
+		parts = append(parts, `similar:"`+similar+`"`)
+	}
+
+	req := r.request(_savedSearchPageSize, nil)
+	req.Query = strings.Join(parts, " AND ")
+	if scope != "" {
+		req.Query += " scope:" + scope
+	}
+	return req, nil
+}
+
+func (r savedSearch) toProto() *searchmsg.SavedSearch {
+	return &searchmsg.SavedSearch{
+		Id:      r.ID,
+		Name:    r.Name,
+		Query:   r.Query,
+		Ref:     r.Ref,
+		Notify:  r.Notify,
+		Created: timestamppb.New(r.Created),
+	}
+}
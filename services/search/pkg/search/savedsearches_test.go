@@ -0,0 +1,231 @@
+package search_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/errtypes"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/status"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	cs3mocks "github.com/cs3org/reva/v2/tests/cs3mocks/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	mEvents "go-micro.dev/v4/events"
+	microstore "go-micro.dev/v4/store"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	searchmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
+	searchsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+	"github.com/owncloud/ocis/v2/services/search/pkg/event"
+	"github.com/owncloud/ocis/v2/services/search/pkg/search"
+	searchMocks "github.com/owncloud/ocis/v2/services/search/pkg/search/mocks"
+)
+
+type recordingPublisher struct {
+	events []interface{}
+}
+
+func (p *recordingPublisher) Publish(_ string, msg interface{}, _ ...mEvents.PublishOption) error {
+	p.events = append(p.events, msg)
+	return nil
+}
+
+func searchResponse(ids ...string) *searchsvc.SearchResponse {
+	return searchResponseAt(time.Now(), ids...)
+}
+
+func searchResponseAt(mtime time.Time, ids ...string) *searchsvc.SearchResponse {
+	res := &searchsvc.SearchResponse{TotalMatches: int32(len(ids))}
+	for _, id := range ids {
+		res.Matches = append(res.Matches, &searchmsg.Match{
+			Entity: &searchmsg.Entity{
+				Id:               &searchmsg.ResourceID{StorageId: "storage", SpaceId: "space", OpaqueId: id},
+				LastModifiedTime: timestamppb.New(mtime),
+			},
+		})
+	}
+	return res
+}
+
+var _ = Describe("SavedSearches", func() {
+	var (
+		ss        *search.SavedSearches
+		searcher  *searchMocks.Searcher
+		publisher *recordingPublisher
+		cfg       *config.Config
+		owner     = &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "owner"}}
+		other     = &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "other"}}
+		ctx       context.Context
+	)
+
+	BeforeEach(func() {
+		pool.RemoveSelector("GatewaySelector" + "com.owncloud.api.gateway")
+		gatewayClient := &cs3mocks.GatewayAPIClient{}
+		gatewaySelector := pool.GetSelector[gateway.GatewayAPIClient](
+			"GatewaySelector",
+			"com.owncloud.api.gateway",
+			func(cc grpc.ClientConnInterface) gateway.GatewayAPIClient {
+				return gatewayClient
+			},
+		)
+		gatewayClient.On("Authenticate", mock.Anything, &gateway.AuthenticateRequest{
+			Type:         "machine",
+			ClientId:     "userid:owner",
+			ClientSecret: "machine-auth-api-key",
+		}).Return(&gateway.AuthenticateResponse{
+			Status: status.NewOK(context.Background()),
+			User:   owner,
+			Token:  "authtoken",
+		}, nil)
+
+		searcher = &searchMocks.Searcher{}
+		publisher = &recordingPublisher{}
+		cfg = &config.Config{
+			SavedSearches:     config.SavedSearches{MaxPerUser: 2},
+			MachineAuthAPIKey: "machine-auth-api-key",
+		}
+		ctx = revactx.ContextSetUser(context.Background(), owner)
+
+		ss = search.NewSavedSearches(microstore.NewMemoryStore(), searcher, publisher, gatewaySelector, log.NewLogger(), cfg)
+	})
+
+	Describe("Create", func() {
+		It("saves the search of the user", func() {
+			searcher.On("Search", mock.Anything, mock.MatchedBy(func(req *searchsvc.SearchRequest) bool {
+				return req.Query == "name:foo"
+			})).Return(searchResponse("1"), nil)
+
+			saved, err := ss.Create(ctx, &searchmsg.SavedSearch{Name: "foo", Query: "name:foo", Notify: true})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved.Id).ToNot(BeEmpty())
+			Expect(saved.Name).To(Equal("foo"))
+			Expect(saved.Created).ToNot(BeNil())
+
+			list, err := ss.List(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(list).To(HaveLen(1))
+			Expect(list[0].Id).To(Equal(saved.Id))
+
+			list, err = ss.List(revactx.ContextSetUser(context.Background(), other))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(list).To(BeEmpty())
+		})
+
+		It("rejects empty queries", func() {
+			_, err := ss.Create(ctx, &searchmsg.SavedSearch{Name: "foo"})
+			Expect(err).To(BeAssignableToTypeOf(errtypes.BadRequest("")))
+		})
+
+		It("limits the saved searches per user", func() {
+			searcher.On("Search", mock.Anything, mock.Anything).Return(searchResponse(), nil)
+
+			for i := 0; i < 2; i++ {
+				_, err := ss.Create(ctx, &searchmsg.SavedSearch{Query: "name:foo"})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			_, err := ss.Create(ctx, &searchmsg.SavedSearch{Query: "name:foo"})
+			Expect(err).To(BeAssignableToTypeOf(errtypes.BadRequest("")))
+		})
+	})
+
+	Describe("Delete and Run", func() {
+		var saved *searchmsg.SavedSearch
+
+		BeforeEach(func() {
+			searcher.On("Search", mock.Anything, mock.Anything).Return(searchResponse("1"), nil)
+
+			var err error
+			saved, err = ss.Create(ctx, &searchmsg.SavedSearch{Query: "name:foo"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("runs the saved search", func() {
+			res, err := ss.Run(ctx, saved.Id, 10, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Matches).To(HaveLen(1))
+		})
+
+		It("does not find the saved searches of other users", func() {
+			_, err := ss.Run(revactx.ContextSetUser(context.Background(), other), saved.Id, 10, nil)
+			Expect(err).To(BeAssignableToTypeOf(errtypes.NotFound("")))
+
+			err = ss.Delete(revactx.ContextSetUser(context.Background(), other), saved.Id)
+			Expect(err).To(BeAssignableToTypeOf(errtypes.NotFound("")))
+		})
+
+		It("deletes the saved search", func() {
+			Expect(ss.Delete(ctx, saved.Id)).To(Succeed())
+
+			_, err := ss.Run(ctx, saved.Id, 10, nil)
+			Expect(err).To(BeAssignableToTypeOf(errtypes.NotFound("")))
+		})
+	})
+
+	Describe("Notify", func() {
+		It("notifies the owner about new matches", func() {
+			searcher.On("Search", mock.Anything, mock.Anything).Return(searchResponse(), nil).Once()
+			saved, err := ss.Create(ctx, &searchmsg.SavedSearch{Name: "foo", Query: "name:foo", Notify: true})
+			Expect(err).ToNot(HaveOccurred())
+
+			modified := time.Now().Add(time.Minute).UTC()
+			watermark := `mtime>"` + modified.Format(time.RFC3339Nano) + `"`
+			searcher.On("Search", mock.MatchedBy(func(ctx context.Context) bool {
+				u, ok := revactx.ContextGetUser(ctx)
+				return ok && u.GetId().GetOpaqueId() == "owner"
+			}), mock.MatchedBy(func(req *searchsvc.SearchRequest) bool {
+				return strings.HasPrefix(req.Query, "(name:foo) AND mtime>") && !strings.Contains(req.Query, watermark)
+			})).Return(searchResponseAt(modified, "2", "3"), nil).Once()
+
+			Expect(ss.Notify()).To(Succeed())
+			Expect(publisher.events).To(HaveLen(1))
+
+			e := publisher.events[0].(event.SavedSearchMatched)
+			Expect(e.SavedSearchID).To(Equal(saved.Id))
+			Expect(e.Owner.GetOpaqueId()).To(Equal("owner"))
+			Expect(e.NewMatches).To(Equal(2))
+
+			// the watermark advanced to the latest match
+			searcher.On("Search", mock.Anything, mock.MatchedBy(func(req *searchsvc.SearchRequest) bool {
+				return req.Query == "(name:foo) AND "+watermark
+			})).Return(searchResponse(), nil).Once()
+
+			Expect(ss.Notify()).To(Succeed())
+			Expect(publisher.events).To(HaveLen(1))
+			searcher.AssertExpectations(GinkgoT())
+		})
+
+		It("keeps the scope and the similarity search on the top level", func() {
+			searcher.On("Search", mock.Anything, mock.Anything).Return(searchResponse(), nil).Once()
+			_, err := ss.Create(ctx, &searchmsg.SavedSearch{Query: `name:foo AND similar:"rental agreement" scope:storage$space`, Notify: true})
+			Expect(err).ToNot(HaveOccurred())
+
+			searcher.On("Search", mock.Anything, mock.MatchedBy(func(req *searchsvc.SearchRequest) bool {
+				return strings.HasPrefix(req.Query, "(name:foo) AND mtime>") &&
+					strings.HasSuffix(req.Query, ` AND similar:"rental agreement" scope:storage$space`)
+			})).Return(searchResponse(), nil).Once()
+
+			Expect(ss.Notify()).To(Succeed())
+			Expect(publisher.events).To(BeEmpty())
+			searcher.AssertExpectations(GinkgoT())
+		})
+
+		It("skips saved searches without notifications", func() {
+			searcher.On("Search", mock.Anything, mock.Anything).Return(searchResponse(), nil).Once()
+			_, err := ss.Create(ctx, &searchmsg.SavedSearch{Query: "name:foo"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ss.Notify()).To(Succeed())
+			searcher.AssertNumberOfCalls(GinkgoT(), "Search", 1)
+			Expect(publisher.events).To(BeEmpty())
+		})
+	})
+})
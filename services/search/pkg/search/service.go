@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -25,12 +26,15 @@ import (
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	libregraph "github.com/owncloud/libre-graph-api-go"
+	"github.com/owncloud/ocis/v2/ocis-pkg/kql"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	searchmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
 	searchsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
 	"github.com/owncloud/ocis/v2/services/search/pkg/config"
 	"github.com/owncloud/ocis/v2/services/search/pkg/content"
+	"github.com/owncloud/ocis/v2/services/search/pkg/embedding"
 	"github.com/owncloud/ocis/v2/services/search/pkg/engine"
+	searchQuery "github.com/owncloud/ocis/v2/services/search/pkg/query"
 )
 
 const (
@@ -50,6 +54,8 @@ type Searcher interface {
 	UpsertItem(ref *provider.Reference)
 	RestoreItem(ref *provider.Reference)
 	MoveItem(ref *provider.Reference)
+	ReconcileSpace(spaceID *provider.StorageSpaceId, dryRun bool) (ReconcileReport, error)
+	ReconcileSpaces(dryRun bool) error
 }
 
 // Service is responsible for indexing spaces and pass on a search
@@ -59,6 +65,10 @@ type Service struct {
 	gatewaySelector pool.Selectable[gateway.GatewayAPIClient]
 	engine          engine.Engine
 	extractor       content.Extractor
+	embedder        embedding.Embedder
+
+	maxEmbeddingInput int
+	minSimilarity     float64
 
 	serviceAccountID     string
 	serviceAccountSecret string
@@ -67,12 +77,16 @@ type Service struct {
 var errSkipSpace error
 
 // NewService creates a new Provider instance.
-func NewService(gatewaySelector pool.Selectable[gateway.GatewayAPIClient], eng engine.Engine, extractor content.Extractor, logger log.Logger, cfg *config.Config) *Service {
+func NewService(gatewaySelector pool.Selectable[gateway.GatewayAPIClient], eng engine.Engine, extractor content.Extractor, embedder embedding.Embedder, logger log.Logger, cfg *config.Config) *Service {
 	var s = &Service{
 		gatewaySelector: gatewaySelector,
 		engine:          eng,
 		logger:          logger,
 		extractor:       extractor,
+		embedder:        embedder,
+
+		maxEmbeddingInput: cfg.Embedding.MaxInputLength,
+		minSimilarity:     cfg.Embedding.MinScore,
 
 		serviceAccountID:     cfg.ServiceAccount.ServiceAccountID,
 		serviceAccountSecret: cfg.ServiceAccount.ServiceAccountSecret,
@@ -93,10 +107,32 @@ func (s *Service) Search(ctx context.Context, req *searchsvc.SearchRequest) (*se
 
 	// Extract scope from query if set
 	query, scope := ParseScope(req.Query)
-	if query == "" {
+	// Extract the text of a similarity search if set
+	query, similar, err := kql.Similar(query)
+	switch {
+	case searchQuery.IsValidationError(err):
+		return nil, errtypes.BadRequest(err.Error())
+	case err != nil:
+		return nil, err
+	}
+	if query == "" && similar == "" {
 		return nil, errtypes.BadRequest("empty query provided")
 	}
 	req.Query = query
+
+	var vector []float32
+	if similar != "" {
+		if _, ok := s.engine.(engine.SimilaritySearcher); !ok || s.embedder == nil {
+			return nil, errtypes.BadRequest("similarity search is not enabled")
+		}
+		vectors, err := s.embedder.Embed(ctx, []string{similar})
+		if err != nil {
+			s.logger.Error().Err(err).Msg("failed to embed the similarity search")
+			return nil, err
+		}
+		vector = vectors[0]
+	}
+
 	if len(scope) > 0 {
 		scopedID, err := storagespace.ParseID(scope)
 		if err != nil {
@@ -203,7 +239,7 @@ func (s *Service) Search(ctx context.Context, req *searchsvc.SearchRequest) (*se
 	for i := 0; i < numWorkers; i++ {
 		errg.Go(func() error {
 			for space := range work {
-				res, err := s.searchIndex(ctx, req, space, mountpointMap[space.Id.OpaqueId])
+				res, err := s.searchIndex(ctx, req, vector, space, mountpointMap[space.Id.OpaqueId])
 				if err != nil && err != errSkipSpace {
 					return err
 				}
@@ -235,6 +271,7 @@ func (s *Service) Search(ctx context.Context, req *searchsvc.SearchRequest) (*se
 		return nil, err
 	}
 
+	facets := make([][]*searchmsg.Facet, 0, len(responses))
 	for _, res := range responses {
 		if res == nil {
 			continue
@@ -243,6 +280,7 @@ func (s *Service) Search(ctx context.Context, req *searchsvc.SearchRequest) (*se
 		for _, match := range res.Matches {
 			matches = append(matches, match)
 		}
+		facets = append(facets, res.Facets)
 	}
 
 	// compile one sorted list of matches from all spaces and apply the limit if needed
@@ -258,10 +296,11 @@ func (s *Service) Search(ctx context.Context, req *searchsvc.SearchRequest) (*se
 	return &searchsvc.SearchResponse{
 		Matches:      matches,
 		TotalMatches: total,
+		Facets:       engine.MergeFacets(req.Facets, facets...),
 	}, nil
 }
 
-func (s *Service) searchIndex(ctx context.Context, req *searchsvc.SearchRequest, space *provider.StorageSpace, mountpointID string) (*searchsvc.SearchIndexResponse, error) {
+func (s *Service) searchIndex(ctx context.Context, req *searchsvc.SearchRequest, vector []float32, space *provider.StorageSpace, mountpointID string) (*searchsvc.SearchIndexResponse, error) {
 	if req.Ref != nil &&
 		(req.Ref.ResourceId.StorageId != space.Root.StorageId ||
 			req.Ref.ResourceId.SpaceId != space.Root.SpaceId) {
@@ -364,9 +403,18 @@ func (s *Service) searchIndex(ctx context.Context, req *searchsvc.SearchRequest,
 			Path:       searchPathPrefix,
 		},
 		PageSize: req.PageSize,
+		Facets:   req.Facets,
 	}
 	start := time.Now()
-	res, err := s.engine.Search(ctx, searchRequest)
+	var (
+		res *searchsvc.SearchIndexResponse
+		err error
+	)
+	if vector != nil {
+		res, err = s.engine.(engine.SimilaritySearcher).SearchSimilar(ctx, searchRequest, vector, s.minSimilarity)
+	} else {
+		res, err = s.engine.Search(ctx, searchRequest)
+	}
 	duration := time.Since(start)
 	if err != nil {
 		s.logger.Error().Err(err).Str("duration", fmt.Sprint(duration)).Str("space", space.Id.OpaqueId).Msg("failed to search the index")
@@ -404,6 +452,18 @@ func (s *Service) searchIndex(ctx context.Context, req *searchsvc.SearchRequest,
 
 	res.Matches = matches
 
+	// the matches of shares are counted for the mountpoint the user knows
+	if slices.Contains(req.Facets, engine.FacetSpace) {
+		spaceID := space.GetId().GetOpaqueId()
+		if mountpointID != "" {
+			spaceID = mountpointID
+		}
+		res.Facets = append(res.Facets, &searchmsg.Facet{
+			Name:   engine.FacetSpace,
+			Values: []*searchmsg.FacetValue{{Value: spaceID, Count: int64(res.TotalMatches)}},
+		})
+	}
+
 	return res, nil
 }
 
@@ -414,19 +474,26 @@ func (s *Service) IndexSpace(spaceID *provider.StorageSpaceId) error {
 		return err
 	}
 
-	rootID, err := storagespace.ParseID(spaceID.OpaqueId)
+	rootID, err := spaceRootID(spaceID)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("invalid space id")
 		return err
 	}
-	if rootID.StorageId == "" || rootID.SpaceId == "" {
-		s.logger.Error().Err(err).Msg("invalid space id")
-		return fmt.Errorf("invalid space id")
+
+	// engines supporting it index the resources of the space with few requests
+	upsert := s.engine.Upsert
+	if b, ok := s.engine.(engine.Batcher); ok {
+		batch := b.NewBatch()
+		upsert = batch.Upsert
+		defer func() {
+			if err := batch.Flush(); err != nil {
+				s.logger.Error().Err(err).Msg("error adding updating the resources in the index")
+			}
+		}()
 	}
-	rootID.OpaqueId = rootID.SpaceId
 
 	w := walker.NewWalker(s.gatewaySelector)
-	err = w.Walk(ownerCtx, &rootID, func(wd string, info *provider.ResourceInfo, err error) error {
+	err = w.Walk(ownerCtx, rootID, func(wd string, info *provider.ResourceInfo, err error) error {
 		if err != nil {
 			s.logger.Error().Err(err).Msg("error walking the tree")
 			return err
@@ -438,7 +505,7 @@ func (s *Service) IndexSpace(spaceID *provider.StorageSpaceId) error {
 
 		ref := &provider.Reference{
 			Path:       utils.MakeRelativePath(filepath.Join(wd, info.Path)),
-			ResourceId: &rootID,
+			ResourceId: rootID,
 		}
 		s.logger.Debug().Str("path", ref.Path).Msg("Walking tree")
 
@@ -455,7 +522,7 @@ func (s *Service) IndexSpace(spaceID *provider.StorageSpaceId) error {
 			return nil
 		}
 
-		s.UpsertItem(ref)
+		s.upsertItem(ref, upsert)
 
 		return nil
 	})
@@ -479,15 +546,22 @@ func (s *Service) TrashItem(rID *provider.ResourceId) {
 
 // UpsertItem indexes or stores Resource data fields.
 func (s *Service) UpsertItem(ref *provider.Reference) {
+	if s.upsertItem(ref, s.engine.Upsert) {
+		logDocCount(s.engine, s.logger)
+	}
+}
+
+// upsertItem indexes the resource with the given upsert function and tells if it was indexed.
+func (s *Service) upsertItem(ref *provider.Reference, upsert func(id string, r engine.Resource) error) bool {
 	ctx, stat, path := s.resInfo(ref)
 	if ctx == nil || stat == nil || path == "" {
-		return
+		return false
 	}
 
 	doc, err := s.extractor.Extract(ctx, stat.Info)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("failed to extract resource content")
-		return
+		return false
 	}
 
 	r := engine.Resource{
@@ -503,14 +577,23 @@ func (s *Service) UpsertItem(ref *provider.Reference) {
 	}
 	r.Hidden = strings.HasPrefix(r.Path, ".")
 
+	if s.embedder != nil {
+		vectors, err := s.embedder.Embed(ctx, []string{embedding.Text(doc, s.maxEmbeddingInput)})
+		if err != nil {
+			s.logger.Error().Err(err).Str("id", r.ID).Msg("failed to embed the resource")
+		} else {
+			r.Embedding = vectors[0]
+		}
+	}
+
 	if parentID := stat.GetInfo().GetParentId(); parentID != nil {
 		r.ParentID = storagespace.FormatResourceID(parentID)
 	}
 
-	if err = s.engine.Upsert(r.ID, r); err != nil {
+	indexed := true
+	if err = upsert(r.ID, r); err != nil {
 		s.logger.Error().Err(err).Msg("error adding updating the resource in the index")
-	} else {
-		logDocCount(s.engine, s.logger)
+		indexed = false
 	}
 
 	// determine if metadata needs to be stored in storage as well
@@ -520,7 +603,7 @@ func (s *Service) UpsertItem(ref *provider.Reference) {
 	addLocationMetadata(metadata, doc.Location)
 	addPhotoMetadata(metadata, doc.Photo)
 	if len(metadata) == 0 {
-		return
+		return indexed
 	}
 
 	s.logger.Trace().Str("name", doc.Name).Interface("metadata", metadata).Msg("Storing metadata")
@@ -528,7 +611,7 @@ func (s *Service) UpsertItem(ref *provider.Reference) {
 	gatewayClient, err := s.gatewaySelector.Next()
 	if err != nil {
 		s.logger.Error().Err(err).Msg("could not retrieve client to store metadata")
-		return
+		return indexed
 	}
 
 	resp, err := gatewayClient.SetArbitraryMetadata(ctx, &provider.SetArbitraryMetadataRequest{
@@ -539,8 +622,9 @@ func (s *Service) UpsertItem(ref *provider.Reference) {
 	})
 	if err != nil || resp.GetStatus().GetCode() != rpc.Code_CODE_OK {
 		s.logger.Error().Err(err).Int32("status", int32(resp.GetStatus().GetCode())).Msg("error storing metadata")
-		return
+		return indexed
 	}
+	return indexed
 }
 
 func addAudioMetadata(metadata map[string]string, audio *libregraph.Audio) {
@@ -20,12 +20,24 @@ import (
 	"github.com/owncloud/ocis/v2/services/search/pkg/config"
 	"github.com/owncloud/ocis/v2/services/search/pkg/content"
 	contentMocks "github.com/owncloud/ocis/v2/services/search/pkg/content/mocks"
+	"github.com/owncloud/ocis/v2/services/search/pkg/embedding"
+	"github.com/owncloud/ocis/v2/services/search/pkg/engine"
 	engineMocks "github.com/owncloud/ocis/v2/services/search/pkg/engine/mocks"
 	"github.com/owncloud/ocis/v2/services/search/pkg/search"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
 )
 
+// similarityEngine adds the similarity search to the engine mock
+type similarityEngine struct {
+	*engineMocks.Engine
+}
+
+func (e similarityEngine) SearchSimilar(ctx context.Context, req *searchsvc.SearchIndexRequest, vector []float32, minScore float64) (*searchsvc.SearchIndexResponse, error) {
+	ret := e.Called(ctx, req, vector, minScore)
+	return ret.Get(0).(*searchsvc.SearchIndexResponse), ret.Error(1)
+}
+
 var _ = Describe("Searchprovider", func() {
 	var (
 		s               search.Searcher
@@ -90,7 +102,7 @@ var _ = Describe("Searchprovider", func() {
 		indexClient = &engineMocks.Engine{}
 		extractor = &contentMocks.Extractor{}
 
-		s = search.NewService(gatewaySelector, indexClient, extractor, logger, &config.Config{})
+		s = search.NewService(gatewaySelector, indexClient, extractor, nil, logger, &config.Config{})
 
 		gatewayClient.On("Authenticate", mock.Anything, mock.Anything).Return(&gateway.AuthenticateResponse{
 			Status: status.NewOK(ctx),
@@ -110,7 +122,7 @@ var _ = Describe("Searchprovider", func() {
 
 	Describe("New", func() {
 		It("returns a new instance", func() {
-			s := search.NewService(gatewaySelector, indexClient, extractor, logger, &config.Config{})
+			s := search.NewService(gatewaySelector, indexClient, extractor, nil, logger, &config.Config{})
 			Expect(s).ToNot(BeNil())
 		})
 	})
@@ -133,6 +145,106 @@ var _ = Describe("Searchprovider", func() {
 		})
 	})
 
+	Describe("ReconcileSpace", func() {
+		var (
+			spaceID = &sprovider.StorageSpaceId{OpaqueId: "storageid$spaceid!spaceid"}
+			rootID  = &sprovider.ResourceId{StorageId: "storageid", SpaceId: "spaceid", OpaqueId: "spaceid"}
+			mtime   = &typesv1beta1.Timestamp{Seconds: 4000}
+			indexed = func(id, path string, deleted bool) engine.Resource {
+				r := engine.Resource{ID: "storageid$spaceid!" + id, RootID: "storageid$spaceid!spaceid", Path: path, Deleted: deleted}
+				r.Mtime = "1970-01-01T01:06:40Z"
+				r.Size = 1
+				return r
+			}
+			info = func(id, path string, typ sprovider.ResourceType, seconds uint64) *sprovider.ResourceInfo {
+				return &sprovider.ResourceInfo{
+					Id:       &sprovider.ResourceId{StorageId: "storageid", SpaceId: "spaceid", OpaqueId: id},
+					ParentId: rootID,
+					Path:     path,
+					Type:     typ,
+					Size:     1,
+					Mtime:    &typesv1beta1.Timestamp{Seconds: seconds},
+				}
+			}
+		)
+
+		BeforeEach(func() {
+			gatewayClient.On("GetUserByClaim", mock.Anything, mock.Anything).Return(&userv1beta1.GetUserByClaimResponse{
+				Status: status.NewOK(context.Background()),
+				User:   user,
+			}, nil)
+			gatewayClient.On("Stat", mock.Anything, mock.MatchedBy(func(req *sprovider.StatRequest) bool {
+				return req.GetRef().GetPath() == "."
+			})).Return(&sprovider.StatResponse{
+				Status: status.NewOK(context.Background()),
+				Info: &sprovider.ResourceInfo{
+					Id:    rootID,
+					Path:  ".",
+					Type:  sprovider.ResourceType_RESOURCE_TYPE_CONTAINER,
+					Size:  1,
+					Mtime: mtime,
+				},
+			}, nil)
+			gatewayClient.On("Stat", mock.Anything, mock.Anything).Return(&sprovider.StatResponse{
+				Status: status.NewOK(context.Background()),
+				Info:   ri,
+			}, nil)
+			gatewayClient.On("ListContainer", mock.Anything, mock.MatchedBy(func(req *sprovider.ListContainerRequest) bool {
+				return req.GetRef().GetResourceId().GetOpaqueId() == "spaceid"
+			})).Return(&sprovider.ListContainerResponse{
+				Status: status.NewOK(context.Background()),
+				Infos: []*sprovider.ResourceInfo{
+					info("unchanged", "unchanged.txt", sprovider.ResourceType_RESOURCE_TYPE_FILE, 4000),
+					info("changed", "changed.txt", sprovider.ResourceType_RESOURCE_TYPE_FILE, 5000),
+					info("new", "new.txt", sprovider.ResourceType_RESOURCE_TYPE_FILE, 4000),
+					info("folder", "folder", sprovider.ResourceType_RESOURCE_TYPE_CONTAINER, 4000),
+				},
+			}, nil)
+			gatewayClient.On("ListContainer", mock.Anything, mock.MatchedBy(func(req *sprovider.ListContainerRequest) bool {
+				return req.GetRef().GetResourceId().GetOpaqueId() == "folder"
+			})).Return(&sprovider.ListContainerResponse{
+				Status: status.NewOK(context.Background()),
+				Infos: []*sprovider.ResourceInfo{
+					info("child", "child.txt", sprovider.ResourceType_RESOURCE_TYPE_FILE, 4000),
+				},
+			}, nil)
+			indexClient.On("Resources", "storageid$spaceid!spaceid").Return([]engine.Resource{
+				indexed("spaceid", ".", false),
+				indexed("unchanged", "./unchanged.txt", false),
+				indexed("changed", "./changed.txt", false),
+				indexed("folder", "./old", false),
+				indexed("child", "./old/child.txt", false),
+				indexed("gone", "./gone.txt", false),
+				indexed("trashed", "./trashed.txt", true),
+			}, nil)
+		})
+
+		It("reports the drift without changing the index in dry run mode", func() {
+			report, err := s.ReconcileSpace(spaceID, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(report.Checked).To(Equal(6))
+			Expect(report.Added).To(Equal(1))
+			Expect(report.Updated).To(Equal(1))
+			Expect(report.Moved).To(Equal(1))
+			Expect(report.Purged).To(Equal(1))
+			indexClient.AssertNotCalled(GinkgoT(), "Upsert", mock.Anything, mock.Anything)
+			indexClient.AssertNotCalled(GinkgoT(), "Move", mock.Anything, mock.Anything, mock.Anything)
+			indexClient.AssertNotCalled(GinkgoT(), "Purge", mock.Anything)
+		})
+
+		It("upserts, moves and purges the drifted resources", func() {
+			extractor.On("Extract", mock.Anything, mock.Anything, mock.Anything).Return(content.Document{}, nil)
+			indexClient.On("Upsert", mock.Anything, mock.Anything).Return(nil)
+			indexClient.On("Move", "storageid$spaceid!folder", "storageid$spaceid!spaceid", "./folder").Return(nil).Once()
+			indexClient.On("Purge", "storageid$spaceid!gone").Return(nil).Once()
+
+			_, err := s.ReconcileSpace(spaceID, false)
+			Expect(err).ToNot(HaveOccurred())
+			indexClient.AssertNumberOfCalls(GinkgoT(), "Upsert", 2)
+			indexClient.AssertExpectations(GinkgoT())
+		})
+	})
+
 	Describe("Search", func() {
 		It("fails when an empty query is given", func() {
 			res, err := s.Search(ctx, &searchsvc.SearchRequest{
@@ -203,6 +315,56 @@ var _ = Describe("Searchprovider", func() {
 			})
 		})
 
+		Context("with a similarity search", func() {
+			var cfg *config.Config
+
+			BeforeEach(func() {
+				cfg = &config.Config{Embedding: config.Embedding{Dimension: 16, MinScore: 0.5}}
+				gatewayClient.On("ListStorageSpaces", mock.Anything, mock.Anything).Return(&sprovider.ListStorageSpacesResponse{
+					Status:        status.NewOK(ctx),
+					StorageSpaces: []*sprovider.StorageSpace{personalSpace},
+				}, nil)
+				indexClient.On("SearchSimilar", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&searchsvc.SearchIndexResponse{
+					TotalMatches: 1,
+					Matches: []*searchmsg.Match{
+						{
+							Score: 0.9,
+							Entity: &searchmsg.Entity{
+								Ref:  &searchmsg.Reference{ResourceId: &searchmsg.ResourceID{}, Path: "./lease.pdf"},
+								Id:   &searchmsg.ResourceID{OpaqueId: "lease-id"},
+								Name: "lease.pdf",
+							},
+						},
+					},
+				}, nil)
+			})
+
+			It("fails if the similarity search is not enabled", func() {
+				_, err := s.Search(ctx, &searchsvc.SearchRequest{
+					Query: `similar:"rental agreement"`,
+				})
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("searches similar resources with the minimum score", func() {
+				s = search.NewService(gatewaySelector, similarityEngine{indexClient}, extractor, embedding.NewHashingEmbedder(cfg), logger, cfg)
+
+				res, err := s.Search(ctx, &searchsvc.SearchRequest{
+					Query: `similar:"rental agreement" AND mediatype:document`,
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.TotalMatches).To(Equal(int32(1)))
+				Expect(res.Matches).To(HaveLen(1))
+				Expect(res.Matches[0].Entity.Name).To(Equal("lease.pdf"))
+				indexClient.AssertCalled(GinkgoT(), "SearchSimilar", mock.Anything, mock.MatchedBy(func(req *searchsvc.SearchIndexRequest) bool {
+					return req.Query == "mediatype:document"
+				}), mock.MatchedBy(func(vector []float32) bool {
+					return len(vector) == 16
+				}), 0.5)
+				indexClient.AssertNotCalled(GinkgoT(), "Search", mock.Anything, mock.Anything)
+			})
+		})
+
 		Context("with a personal space with a filter", func() {
 			BeforeEach(func() {
 				gatewayClient.On("ListStorageSpaces", mock.Anything, mock.Anything).Return(&sprovider.ListStorageSpacesResponse{
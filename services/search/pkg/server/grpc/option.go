@@ -4,9 +4,9 @@ import (
 	"context"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	searchsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
 	"github.com/owncloud/ocis/v2/services/search/pkg/config"
 	"github.com/owncloud/ocis/v2/services/search/pkg/metrics"
-	svc "github.com/owncloud/ocis/v2/services/search/pkg/service/grpc/v0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -20,8 +20,7 @@ type Options struct {
 	Context       context.Context
 	Config        *config.Config
 	Metrics       *metrics.Metrics
-	Handler       *svc.Service
-	JWTSecret     string
+	Handler       searchsvc.SearchProviderHandler
 	TraceProvider trace.TracerProvider
 }
 
@@ -72,19 +71,12 @@ func Metrics(val *metrics.Metrics) Option {
 }
 
 // Handler provides a function to set the handler option.
-func Handler(val *svc.Service) Option {
+func Handler(val searchsvc.SearchProviderHandler) Option {
 	return func(o *Options) {
 		o.Handler = val
 	}
 }
 
-// JWTSecret provides a function to set the Config option.
-func JWTSecret(val string) Option {
-	return func(o *Options) {
-		o.JWTSecret = val
-	}
-}
-
 // TraceProvider provides a function to set the trace provider option.
 func TraceProvider(val trace.TracerProvider) Option {
 	return func(o *Options) {
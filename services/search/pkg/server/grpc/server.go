@@ -4,11 +4,10 @@ import (
 	"github.com/owncloud/ocis/v2/ocis-pkg/service/grpc"
 	"github.com/owncloud/ocis/v2/ocis-pkg/version"
 	searchsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
-	svc "github.com/owncloud/ocis/v2/services/search/pkg/service/grpc/v0"
 )
 
 // Server initializes a new go-micro service ready to run
-func Server(opts ...Option) (grpc.Service, func(), error) {
+func Server(opts ...Option) (grpc.Service, error) {
 	options := newOptions(opts...)
 
 	service, err := grpc.NewServiceWithClient(
@@ -28,31 +27,18 @@ func Server(opts ...Option) (grpc.Service, func(), error) {
 	)
 	if err != nil {
 		options.Logger.Fatal().Err(err).Msg("Error creating search service")
-		return grpc.Service{}, func() {}, err
-	}
-
-	handle, teardown, err := svc.NewHandler(
-		svc.Config(options.Config),
-		svc.Logger(options.Logger),
-		svc.JWTSecret(options.JWTSecret),
-		svc.TracerProvider(options.TraceProvider),
-	)
-	if err != nil {
-		options.Logger.Error().
-			Err(err).
-			Msg("Error initializing search service")
-		return grpc.Service{}, teardown, err
+		return grpc.Service{}, err
 	}
 
 	if err := searchsvc.RegisterSearchProviderHandler(
 		service.Server(),
-		handle,
+		options.Handler,
 	); err != nil {
 		options.Logger.Error().
 			Err(err).
 			Msg("Error registering search provider handler")
-		return grpc.Service{}, teardown, err
+		return grpc.Service{}, err
 	}
 
-	return service, teardown, nil
+	return service, nil
 }
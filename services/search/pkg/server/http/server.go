@@ -0,0 +1,110 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	stdhttp "net/http"
+
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/riandyrn/otelchi"
+	"go-micro.dev/v4"
+	merrors "go-micro.dev/v4/errors"
+	"go-micro.dev/v4/metadata"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/account"
+	"github.com/owncloud/ocis/v2/ocis-pkg/middleware"
+	"github.com/owncloud/ocis/v2/ocis-pkg/service/http"
+	"github.com/owncloud/ocis/v2/ocis-pkg/tracing"
+	"github.com/owncloud/ocis/v2/ocis-pkg/version"
+	searchsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
+)
+
+// Server initializes the http service and server.
+func Server(opts ...Option) (http.Service, error) {
+	options := newOptions(opts...)
+
+	service, err := http.NewService(
+		http.TLSConfig(options.Config.HTTP.TLS),
+		http.Logger(options.Logger),
+		http.Namespace(options.Config.HTTP.Namespace),
+		http.Name(options.Config.Service.Name),
+		http.Version(version.GetString()),
+		http.Address(options.Config.HTTP.Addr),
+		http.Context(options.Context),
+		http.TraceProvider(options.TraceProvider),
+	)
+	if err != nil {
+		options.Logger.Error().
+			Err(err).
+			Msg("Error initializing http service")
+		return http.Service{}, fmt.Errorf("could not initialize http service: %w", err)
+	}
+
+	if err := micro.RegisterHandler(service.Server(), NewMux(opts...)); err != nil {
+		return http.Service{}, err
+	}
+
+	return service, nil
+}
+
+// NewMux serves the search provider api, the handler authenticates the user with the token of the request
+func NewMux(opts ...Option) *chi.Mux {
+	options := newOptions(opts...)
+
+	mux := chi.NewMux()
+	mux.Use(
+		chimiddleware.RequestID,
+		middleware.Version(
+			options.Config.Service.Name,
+			version.GetString(),
+		),
+		middleware.Logger(
+			options.Logger,
+		),
+		middleware.ExtractAccountUUID(
+			account.Logger(options.Logger),
+			account.JWTSecret(options.Config.TokenManager.JWTSecret),
+		),
+		forwardToken,
+	)
+
+	mux.Use(
+		otelchi.Middleware(
+			options.Config.Service.Name,
+			otelchi.WithChiRoutes(mux),
+			otelchi.WithTracerProvider(options.TraceProvider),
+			otelchi.WithPropagators(tracing.GetPropagator()),
+		),
+	)
+
+	mux.Route(options.Config.HTTP.Root, func(r chi.Router) {
+		searchsvc.RegisterSearchProviderWeb(r, webHandler{
+			SearchProviderHandler: options.Handler,
+			id:                    options.Config.HTTP.Namespace + "." + options.Config.Service.Name,
+		})
+	})
+	return mux
+}
+
+// forwardToken passes the token of the request to the handler the same way the grpc server does
+func forwardToken(next stdhttp.Handler) stdhttp.Handler {
+	return stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		if t := r.Header.Get(revactx.TokenHeader); t != "" {
+			r = r.WithContext(metadata.Set(r.Context(), revactx.TokenHeader, t))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// webHandler restricts the http api to the calls of the users, spaces are only indexed with the grpc api
+type webHandler struct {
+	searchsvc.SearchProviderHandler
+	id string
+}
+
+// IndexSpace is not available over http
+func (h webHandler) IndexSpace(context.Context, *searchsvc.IndexSpaceRequest, *searchsvc.IndexSpaceResponse) error {
+	return merrors.NotFound(h.id, "not found")
+}
@@ -0,0 +1,115 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+
+	userpb "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/auth/scope"
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/token/manager/jwt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go-micro.dev/v4/metadata"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	searchmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
+	searchsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
+	"github.com/owncloud/ocis/v2/services/search/pkg/config/defaults"
+	"github.com/owncloud/ocis/v2/services/search/pkg/server/http"
+)
+
+// recordingHandler records the token the calls are made with
+type recordingHandler struct {
+	searchsvc.SearchProviderHandler
+	tokens  []string
+	indexed bool
+}
+
+func (h *recordingHandler) CreateSavedSearch(ctx context.Context, in *searchsvc.CreateSavedSearchRequest, out *searchsvc.CreateSavedSearchResponse) error {
+	t, _ := metadata.Get(ctx, revactx.TokenHeader)
+	h.tokens = append(h.tokens, t)
+	out.SavedSearch = &searchmsg.SavedSearch{Id: "1", Name: in.GetSavedSearch().GetName(), Query: in.GetSavedSearch().GetQuery()}
+	return nil
+}
+
+func (h *recordingHandler) RunSavedSearch(ctx context.Context, in *searchsvc.RunSavedSearchRequest, out *searchsvc.SearchResponse) error {
+	t, _ := metadata.Get(ctx, revactx.TokenHeader)
+	h.tokens = append(h.tokens, t)
+	out.TotalMatches = 1
+	out.Facets = []*searchmsg.Facet{{Name: "mediatype", Values: []*searchmsg.FacetValue{{Value: "document", Count: 1}}}}
+	return nil
+}
+
+func (h *recordingHandler) IndexSpace(context.Context, *searchsvc.IndexSpaceRequest, *searchsvc.IndexSpaceResponse) error {
+	h.indexed = true
+	return nil
+}
+
+var _ = Describe("Server", func() {
+	var (
+		handler *recordingHandler
+		mux     stdhttp.Handler
+		token   string
+	)
+
+	BeforeEach(func() {
+		cfg := defaults.FullDefaultConfig()
+		cfg.TokenManager.JWTSecret = "secret"
+		handler = &recordingHandler{}
+		mux = http.NewMux(
+			http.Logger(log.NopLogger()),
+			http.Config(cfg),
+			http.Handler(handler),
+			http.TraceProvider(noop.NewTracerProvider()),
+		)
+
+		tokenManager, err := jwt.New(map[string]interface{}{"secret": "secret", "expires": int64(60)})
+		Expect(err).ToNot(HaveOccurred())
+		s, err := scope.AddOwnerScope(nil)
+		Expect(err).ToNot(HaveOccurred())
+		token, err = tokenManager.MintToken(context.Background(), &userpb.User{Id: &userpb.UserId{OpaqueId: "einstein"}}, s)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	request := func(path, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(stdhttp.MethodPost, path, strings.NewReader(body))
+		r.Header.Set(revactx.TokenHeader, token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	It("creates saved searches with the token of the request", func() {
+		w := request("/api/v0/search/saved-search-create", `{"saved_search": {"name": "reports", "query": "name:*report*"}}`)
+		Expect(w.Code).To(Equal(stdhttp.StatusCreated))
+		Expect(handler.tokens).To(Equal([]string{token}))
+
+		var res struct {
+			SavedSearch struct {
+				ID    string `json:"id"`
+				Query string `json:"query"`
+			} `json:"savedSearch"`
+		}
+		Expect(json.Unmarshal(w.Body.Bytes(), &res)).To(Succeed())
+		Expect(res.SavedSearch.ID).To(Equal("1"))
+		Expect(res.SavedSearch.Query).To(Equal("name:*report*"))
+	})
+
+	It("runs saved searches and returns the facets", func() {
+		w := request("/api/v0/search/saved-search-run", `{"id": "1", "facets": ["mediatype"]}`)
+		Expect(w.Code).To(Equal(stdhttp.StatusCreated))
+		Expect(handler.tokens).To(Equal([]string{token}))
+		Expect(w.Body.String()).To(ContainSubstring(`"facets":[{"name":"mediatype","values":[{"value":"document","count":"1"}]}]`))
+	})
+
+	It("doesn't index spaces", func() {
+		w := request("/api/v0/search/index-space", `{"space_id": "1"}`)
+		Expect(w.Code).To(Equal(stdhttp.StatusNotFound))
+		Expect(handler.indexed).To(BeFalse())
+	})
+})
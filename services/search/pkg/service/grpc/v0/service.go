@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
@@ -14,22 +17,27 @@ import (
 	"github.com/cs3org/reva/v2/pkg/errtypes"
 	"github.com/cs3org/reva/v2/pkg/events/stream"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	"github.com/cs3org/reva/v2/pkg/store"
 	"github.com/cs3org/reva/v2/pkg/token"
 	"github.com/cs3org/reva/v2/pkg/token/manager/jwt"
 	"github.com/cs3org/reva/v2/pkg/utils"
 	"github.com/jellydator/ttlcache/v2"
 	merrors "go-micro.dev/v4/errors"
 	"go-micro.dev/v4/metadata"
+	microstore "go-micro.dev/v4/store"
 	grpcmetadata "google.golang.org/grpc/metadata"
 
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/ocis-pkg/registry"
 	v0 "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
 	searchsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
 	"github.com/owncloud/ocis/v2/services/search/pkg/config"
 	"github.com/owncloud/ocis/v2/services/search/pkg/content"
+	"github.com/owncloud/ocis/v2/services/search/pkg/embedding"
 	"github.com/owncloud/ocis/v2/services/search/pkg/engine"
 	"github.com/owncloud/ocis/v2/services/search/pkg/query/bleve"
+	"github.com/owncloud/ocis/v2/services/search/pkg/query/opensearch"
 	"github.com/owncloud/ocis/v2/services/search/pkg/search"
 )
 
@@ -53,7 +61,30 @@ func NewHandler(opts ...Option) (searchsvc.SearchProviderHandler, func(), error)
 			_ = idx.Close()
 		}
 
-		eng = engine.NewBleveEngine(idx, bleve.DefaultCreator)
+		eng = engine.NewBleveEngine(idx, bleve.DefaultCreator, engine.BleveSimilarityCandidates(cfg.Embedding.MaxCandidates))
+	case "opensearch":
+		// keep the proxy, timeout and connection pool settings of the default transport
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: cfg.Engine.OpenSearch.Insecure} //nolint:gosec
+		client := &http.Client{
+			Timeout:   cfg.Engine.OpenSearch.Timeout,
+			Transport: transport,
+		}
+		osCfg := engine.OpenSearchConfig{
+			Address:  cfg.Engine.OpenSearch.Address,
+			Index:    cfg.Engine.OpenSearch.Index,
+			Username: cfg.Engine.OpenSearch.Username,
+			Password: cfg.Engine.OpenSearch.Password,
+		}
+		if cfg.Embedding.Type != "none" {
+			osCfg.EmbeddingDimension = cfg.Embedding.Dimension
+		}
+		o := engine.NewOpenSearchEngine(client, osCfg, opensearch.DefaultCreator)
+		if err := o.EnsureIndex(context.Background()); err != nil {
+			return nil, teardown, fmt.Errorf("could not prepare the opensearch index: %w", err)
+		}
+
+		eng = o
 	default:
 		return nil, teardown, fmt.Errorf("unknown search engine: %s", cfg.Engine.Type)
 	}
@@ -75,10 +106,26 @@ func NewHandler(opts ...Option) (searchsvc.SearchProviderHandler, func(), error)
 		if extractor, err = content.NewTikaExtractor(selector, logger, cfg); err != nil {
 			return nil, teardown, err
 		}
+	case "native":
+		if extractor, err = content.NewNativeExtractor(selector, logger, cfg); err != nil {
+			return nil, teardown, err
+		}
 	default:
 		return nil, teardown, fmt.Errorf("unknown search extractor: %s", cfg.Extractor.Type)
 	}
 
+	// initialize the embedder for the similarity search
+	var embedder embedding.Embedder
+	switch cfg.Embedding.Type {
+	case "none":
+	case "http":
+		embedder = embedding.NewHTTPEmbedder(cfg)
+	case "hashing":
+		embedder = embedding.NewHashingEmbedder(cfg)
+	default:
+		return nil, teardown, fmt.Errorf("unknown search embedding: %s", cfg.Embedding.Type)
+	}
+
 	bus, err := stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig{
 		Endpoint:             cfg.Events.Endpoint,
 		Cluster:              cfg.Events.Cluster,
@@ -92,13 +139,58 @@ func NewHandler(opts ...Option) (searchsvc.SearchProviderHandler, func(), error)
 		return nil, teardown, err
 	}
 
-	ss := search.NewService(selector, eng, extractor, logger, cfg)
+	ss := search.NewService(selector, eng, extractor, embedder, logger, cfg)
 
 	// setup event handling
 	if err := search.HandleEvents(ss, bus, logger, cfg); err != nil {
 		return nil, teardown, err
 	}
 
+	// the locks elect the instance which runs the schedules
+	locks := kv.New(kv.Options{
+		Type:     cfg.Store.Store,
+		Nodes:    cfg.Store.Nodes,
+		Bucket:   cfg.Store.Database + "-locks",
+		Username: cfg.Store.AuthUsername,
+		Password: cfg.Store.AuthPassword,
+		// the store is served by the same nats servers as the events
+		EnableTLS:            cfg.Events.EnableTLS,
+		TLSInsecure:          cfg.Events.TLSInsecure,
+		TLSRootCACertificate: cfg.Events.TLSRootCACertificate,
+	})
+
+	if cfg.Reconcile.Interval > 0 {
+		stopReconcile := search.ScheduleReconcile(ss, locks, cfg.Reconcile.Interval, logger)
+		closeIndex := teardown
+		teardown = func() {
+			stopReconcile()
+			closeIndex()
+		}
+	}
+
+	st := store.Create(
+		store.Store(cfg.Store.Store),
+		store.TTL(cfg.Store.TTL),
+		microstore.Nodes(cfg.Store.Nodes...),
+		microstore.Database(cfg.Store.Database),
+		microstore.Table(cfg.Store.Table),
+		store.Authentication(cfg.Store.AuthUsername, cfg.Store.AuthPassword),
+	)
+	savedSearches := search.NewSavedSearches(st, ss, bus, selector, logger, cfg)
+
+	switch {
+	case cfg.SavedSearches.NotifyInterval <= 0:
+	case cfg.MachineAuthAPIKey == "":
+		logger.Warn().Msg("saved search notifications are disabled because no machine auth api key is configured, set SEARCH_MACHINE_AUTH_API_KEY to enable them")
+	default:
+		stopNotifications := search.ScheduleSavedSearchNotifications(savedSearches, locks, cfg.SavedSearches.NotifyInterval, logger)
+		stopPrevious := teardown
+		teardown = func() {
+			stopNotifications()
+			stopPrevious()
+		}
+	}
+
 	cache := ttlcache.NewCache()
 	if err := cache.SetTTL(time.Second); err != nil {
 		return nil, teardown, err
@@ -113,45 +205,37 @@ func NewHandler(opts ...Option) (searchsvc.SearchProviderHandler, func(), error)
 	}
 
 	return &Service{
-		id:           cfg.GRPC.Namespace + "." + cfg.Service.Name,
-		log:          logger,
-		searcher:     ss,
-		cache:        cache,
-		tokenManager: tokenManager,
-		gws:          selector,
-		cfg:          cfg,
+		id:            cfg.GRPC.Namespace + "." + cfg.Service.Name,
+		log:           logger,
+		searcher:      ss,
+		savedSearches: savedSearches,
+		cache:         cache,
+		tokenManager:  tokenManager,
+		gws:           selector,
+		cfg:           cfg,
 	}, teardown, nil
 }
 
 // Service implements the searchServiceHandler interface
 type Service struct {
-	id           string
-	log          log.Logger
-	searcher     search.Searcher
-	cache        *ttlcache.Cache
-	tokenManager token.Manager
-	gws          *pool.Selector[gateway.GatewayAPIClient]
-	cfg          *config.Config
+	id            string
+	log           log.Logger
+	searcher      search.Searcher
+	savedSearches *search.SavedSearches
+	cache         *ttlcache.Cache
+	tokenManager  token.Manager
+	gws           *pool.Selector[gateway.GatewayAPIClient]
+	cfg           *config.Config
 }
 
 // Search handles the search
 func (s Service) Search(ctx context.Context, in *searchsvc.SearchRequest, out *searchsvc.SearchResponse) error {
-	// Get token from the context (go-micro) and make it known to the reva client too (grpc)
-	t, ok := metadata.Get(ctx, revactx.TokenHeader)
-	if !ok {
-		s.log.Error().Msg("Could not get token from context")
-		return errors.New("could not get token from context")
-	}
-	ctx = grpcmetadata.AppendToOutgoingContext(ctx, revactx.TokenHeader, t)
-
-	// unpack user
-	u, _, err := s.tokenManager.DismantleToken(ctx, t)
+	ctx, u, err := s.userContext(ctx)
 	if err != nil {
 		return err
 	}
-	ctx = revactx.ContextSetUser(ctx, u)
 
-	key := cacheKey(in.Query, in.PageSize, in.Ref, u)
+	key := cacheKey(in.Query, in.PageSize, in.Ref, in.Facets, u)
 	res, ok := s.FromCache(key)
 	if !ok {
 		var err error
@@ -159,14 +243,10 @@ func (s Service) Search(ctx context.Context, in *searchsvc.SearchRequest, out *s
 			Query:    in.Query,
 			PageSize: in.PageSize,
 			Ref:      in.Ref,
+			Facets:   in.Facets,
 		})
 		if err != nil {
-			switch err.(type) {
-			case errtypes.BadRequest:
-				return merrors.BadRequest(s.id, err.Error())
-			default:
-				return merrors.InternalServerError(s.id, err.Error())
-			}
+			return s.microError(err)
 		}
 
 		s.Cache(key, res)
@@ -175,6 +255,71 @@ func (s Service) Search(ctx context.Context, in *searchsvc.SearchRequest, out *s
 	out.Matches = res.Matches
 	out.TotalMatches = res.TotalMatches
 	out.NextPageToken = res.NextPageToken
+	out.Facets = res.Facets
+	return nil
+}
+
+// CreateSavedSearch saves a search for the current user
+func (s Service) CreateSavedSearch(ctx context.Context, in *searchsvc.CreateSavedSearchRequest, out *searchsvc.CreateSavedSearchResponse) error {
+	ctx, _, err := s.userContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	saved, err := s.savedSearches.Create(ctx, in.GetSavedSearch())
+	if err != nil {
+		return s.microError(err)
+	}
+
+	out.SavedSearch = saved
+	return nil
+}
+
+// ListSavedSearches lists the saved searches of the current user
+func (s Service) ListSavedSearches(ctx context.Context, _ *searchsvc.ListSavedSearchesRequest, out *searchsvc.ListSavedSearchesResponse) error {
+	ctx, _, err := s.userContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	saved, err := s.savedSearches.List(ctx)
+	if err != nil {
+		return s.microError(err)
+	}
+
+	out.SavedSearches = saved
+	return nil
+}
+
+// DeleteSavedSearch deletes a saved search of the current user
+func (s Service) DeleteSavedSearch(ctx context.Context, in *searchsvc.DeleteSavedSearchRequest, _ *searchsvc.DeleteSavedSearchResponse) error {
+	ctx, _, err := s.userContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.savedSearches.Delete(ctx, in.GetId()); err != nil {
+		return s.microError(err)
+	}
+	return nil
+}
+
+// RunSavedSearch runs a saved search of the current user
+func (s Service) RunSavedSearch(ctx context.Context, in *searchsvc.RunSavedSearchRequest, out *searchsvc.SearchResponse) error {
+	ctx, _, err := s.userContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.savedSearches.Run(ctx, in.GetId(), in.GetPageSize(), in.GetFacets())
+	if err != nil {
+		return s.microError(err)
+	}
+
+	out.Matches = res.Matches
+	out.TotalMatches = res.TotalMatches
+	out.NextPageToken = res.NextPageToken
+	out.Facets = res.Facets
 	return nil
 }
 
@@ -230,6 +375,36 @@ func (s Service) Cache(key string, res *searchsvc.SearchResponse) {
 	_ = s.cache.Set(key, res)
 }
 
-func cacheKey(query string, pagesize int32, ref *v0.Reference, user *user.User) string {
-	return fmt.Sprintf("%s|%d|%s$%s!%s/%s|%s", query, pagesize, ref.GetResourceId().GetStorageId(), ref.GetResourceId().GetSpaceId(), ref.GetResourceId().GetOpaqueId(), ref.GetPath(), user.GetId().GetOpaqueId())
+// userContext makes the token of the context (go-micro) known to the reva client too (grpc) and adds the user of the token
+func (s Service) userContext(ctx context.Context) (context.Context, *user.User, error) {
+	t, ok := metadata.Get(ctx, revactx.TokenHeader)
+	if !ok {
+		s.log.Error().Msg("Could not get token from context")
+		return nil, nil, errors.New("could not get token from context")
+	}
+	ctx = grpcmetadata.AppendToOutgoingContext(ctx, revactx.TokenHeader, t)
+
+	// unpack user
+	u, _, err := s.tokenManager.DismantleToken(ctx, t)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return revactx.ContextSetUser(ctx, u), u, nil
+}
+
+// microError converts the errors of the searcher to go-micro errors
+func (s Service) microError(err error) error {
+	switch err.(type) {
+	case errtypes.BadRequest:
+		return merrors.BadRequest(s.id, err.Error())
+	case errtypes.NotFound:
+		return merrors.NotFound(s.id, err.Error())
+	default:
+		return merrors.InternalServerError(s.id, err.Error())
+	}
+}
+
+func cacheKey(query string, pagesize int32, ref *v0.Reference, facets []string, user *user.User) string {
+	return fmt.Sprintf("%s|%d|%s$%s!%s/%s|%s|%s", query, pagesize, ref.GetResourceId().GetStorageId(), ref.GetResourceId().GetSpaceId(), ref.GetResourceId().GetOpaqueId(), ref.GetPath(), strings.Join(facets, ","), user.GetId().GetOpaqueId())
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/events/stream"
 	"github.com/oklog/run"
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
 	ogrpc "github.com/owncloud/ocis/v2/ocis-pkg/service/grpc"
@@ -49,7 +51,16 @@ func Server(cfg *config.Config) *cli.Command {
 			mtrcs := metrics.New()
 			mtrcs.BuildInfo.WithLabelValues(version.GetString()).Set(1)
 
-			handle := svc.NewDefaultLanguageService(cfg, svc.NewService(cfg, logger))
+			var publisher events.Publisher
+			if cfg.Events.Endpoint != "" {
+				publisher, err = stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig(cfg.Events))
+				if err != nil {
+					logger.Error().Err(err).Msg("Error initializing events publisher")
+					return fmt.Errorf("could not initialize events publisher: %w", err)
+				}
+			}
+
+			handle := svc.NewDefaultLanguageService(cfg, svc.NewService(cfg, logger, publisher))
 
 			// prepare an HTTP server and add it to the group run.
 			httpServer, err := http.Server(
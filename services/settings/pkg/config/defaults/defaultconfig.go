@@ -64,6 +64,10 @@ func DefaultConfig() *config.Config {
 		BundlesPath:       "",
 		Bundles:           nil,
 		ServiceAccountIDs: []string{"service-user-id"},
+		Events: config.Events{
+			Endpoint: "127.0.0.1:9233",
+			Cluster:  "ocis-cluster",
+		},
 	}
 }
 
@@ -7,10 +7,13 @@ import (
 	"fmt"
 	"strings"
 
+	userpb "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
 	cs3permissions "github.com/cs3org/go-cs3apis/cs3/permissions/v1beta1"
 	rpcv1beta1 "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
 	ctxpkg "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/status"
+	"github.com/cs3org/reva/v2/pkg/utils"
 	"github.com/leonelquinteros/gotext"
 	"github.com/owncloud/ocis/v2/ocis-pkg/l10n"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
@@ -38,14 +41,16 @@ type Service struct {
 	config  *config.Config
 	logger  log.Logger
 	manager settings.Manager
+	evs     events.Publisher
 }
 
-// NewService returns a service implementation for Service.
-func NewService(cfg *config.Config, logger log.Logger) settings.ServiceHandler {
+// NewService returns a service implementation for Service. Role changes are published if evs is not nil.
+func NewService(cfg *config.Config, logger log.Logger, evs events.Publisher) settings.ServiceHandler {
 	service := Service{
 		id:     "ocis-settings",
 		config: cfg,
 		logger: logger,
+		evs:    evs,
 	}
 
 	service.manager = metastore.New(cfg)
@@ -399,11 +404,14 @@ func (g Service) AssignRoleToUser(ctx context.Context, req *settingssvc.AssignRo
 		return merrors.InternalServerError(g.id, "user not in context")
 	}
 
+	// we can ignore the error, in the worst case the old role will be empty
+	oldAssignments, _ := g.manager.ListRoleAssignments(req.GetAccountUuid())
+
 	switch {
 	case ownAccountUUID == req.GetAccountUuid():
 		// Allow users to assign themself to the user or user light role
 		// deny any other attempt to change	the user's own assignment
-		if r, err := g.manager.ListRoleAssignments(req.GetAccountUuid()); err == nil && len(r) > 0 {
+		if len(oldAssignments) > 0 {
 			return merrors.Forbidden(g.id, "Changing own role assignment forbidden")
 		}
 		if req.GetRoleId() != defaults.BundleUUIDRoleUser && req.GetRoleId() != defaults.BundleUUIDRoleUserLight {
@@ -420,6 +428,14 @@ func (g Service) AssignRoleToUser(ctx context.Context, req *settingssvc.AssignRo
 		return merrors.BadRequest(g.id, err.Error())
 	}
 	res.Assignment = r
+
+	var oldRole string
+	if len(oldAssignments) > 0 {
+		oldRole = oldAssignments[0].GetRoleId()
+	}
+	if oldRole != r.GetRoleId() {
+		g.publishRoleChange(ctx, ownAccountUUID, r.GetAccountUuid(), r.GetRoleId(), oldRole)
+	}
 	return nil
 }
 
@@ -452,12 +468,37 @@ func (g Service) RemoveRoleFromUser(ctx context.Context, req *settingssvc.Remove
 		}
 	}
 
-	if err := g.manager.RemoveRoleAssignment(req.GetId()); err != nil {
+	removed, err := g.manager.RemoveRoleAssignment(req.GetId())
+	if err != nil {
 		return merrors.BadRequest(g.id, err.Error())
 	}
+	g.publishRoleChange(ctx, ownAccountUUID, removed.GetAccountUuid(), "", removed.GetRoleId())
 	return nil
 }
 
+// publishRoleChange publishes the change of the role of a user
+func (g Service) publishRoleChange(ctx context.Context, executant, userID, role, oldRole string) {
+	if g.evs == nil {
+		return
+	}
+
+	ev := events.UserFeatureChanged{
+		Executant: &userpb.UserId{OpaqueId: executant},
+		UserID:    userID,
+		Features: []events.UserFeature{
+			{
+				Name:     "roleChanged",
+				Value:    role,
+				OldValue: &oldRole,
+			},
+		},
+		Timestamp: utils.TSNow(),
+	}
+	if err := events.Publish(ctx, g.evs, ev); err != nil {
+		g.logger.Error().Err(err).Str("userid", userID).Msg("could not publish the role change")
+	}
+}
+
 // ListPermissions implements the PermissionServiceHandler interface
 func (g Service) ListPermissions(ctx context.Context, req *settingssvc.ListPermissionsRequest, res *settingssvc.ListPermissionsResponse) error {
 	ownAccountUUID, ok := metadata.Get(ctx, middleware.AccountID)
@@ -693,15 +734,16 @@ func translateBundle(bundle *settingsmsg.Bundle, t *gotext.Locale) *settingsmsg.
 		switch set.GetId() {
 		default:
 			continue
-		case defaults.SettingUUIDProfileEmailSendingInterval:
-			// translate interval names ('Instant', 'Daily', 'Weekly', 'Never')
+		case defaults.SettingUUIDProfileEmailSendingInterval, defaults.SettingUUIDProfileChatChannel:
+			// translate interval names ('Instant', 'Daily', 'Weekly', 'Never') and chat channel names ('None', ...)
 			value := set.GetSingleChoiceValue()
 			for i, v := range value.GetOptions() {
 				value.Options[i].DisplayValue = t.Get(v.GetDisplayValue())
 			}
 			set.Value = &settingsmsg.Setting_SingleChoiceValue{SingleChoiceValue: value}
 			fallthrough
-		case defaults.SettingUUIDProfileEventShareCreated,
+		case defaults.SettingUUIDProfileChatAddress,
+			defaults.SettingUUIDProfileEventShareCreated,
 			defaults.SettingUUIDProfileEventShareRemoved,
 			defaults.SettingUUIDProfileEventShareExpired,
 			defaults.SettingUUIDProfileEventSpaceShared,
@@ -725,21 +725,33 @@ func (_c *Manager_ReadValueByUniqueIdentifiers_Call) RunAndReturn(run func(strin
 }
 
 // RemoveRoleAssignment provides a mock function with given fields: assignmentID
-func (_m *Manager) RemoveRoleAssignment(assignmentID string) error {
+func (_m *Manager) RemoveRoleAssignment(assignmentID string) (*v0.UserRoleAssignment, error) {
 	ret := _m.Called(assignmentID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RemoveRoleAssignment")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
+	var r0 *v0.UserRoleAssignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*v0.UserRoleAssignment, error)); ok {
+		return rf(assignmentID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *v0.UserRoleAssignment); ok {
 		r0 = rf(assignmentID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v0.UserRoleAssignment)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(assignmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // Manager_RemoveRoleAssignment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveRoleAssignment'
@@ -760,12 +772,12 @@ func (_c *Manager_RemoveRoleAssignment_Call) Run(run func(assignmentID string))
 	return _c
 }
 
-func (_c *Manager_RemoveRoleAssignment_Call) Return(_a0 error) *Manager_RemoveRoleAssignment_Call {
-	_c.Call.Return(_a0)
+func (_c *Manager_RemoveRoleAssignment_Call) Return(_a0 *v0.UserRoleAssignment, _a1 error) *Manager_RemoveRoleAssignment_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Manager_RemoveRoleAssignment_Call) RunAndReturn(run func(string) error) *Manager_RemoveRoleAssignment_Call {
+func (_c *Manager_RemoveRoleAssignment_Call) RunAndReturn(run func(string) (*v0.UserRoleAssignment, error)) *Manager_RemoveRoleAssignment_Call {
 	_c.Call.Return(run)
 	return _c
 }
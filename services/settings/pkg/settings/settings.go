@@ -61,7 +61,7 @@ type RoleAssignmentManager interface {
 	ListRoleAssignments(accountUUID string) ([]*settingsmsg.UserRoleAssignment, error)
 	ListRoleAssignmentsByRole(roleID string) ([]*settingsmsg.UserRoleAssignment, error)
 	WriteRoleAssignment(accountUUID, roleID string) (*settingsmsg.UserRoleAssignment, error)
-	RemoveRoleAssignment(assignmentID string) error
+	RemoveRoleAssignment(assignmentID string) (*settingsmsg.UserRoleAssignment, error)
 }
 
 // PermissionManager is a permissions service interface for abstraction of storage implementations
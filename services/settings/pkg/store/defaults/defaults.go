@@ -28,6 +28,10 @@ const (
 
 	// SettingUUIDProfileEmailSendingInterval is the hardcoded setting UUID for the email sending interval setting
 	SettingUUIDProfileEmailSendingInterval = "08dec2fe-3f97-42a9-9d1b-500855e92f25"
+	// SettingUUIDProfileChatChannel is the hardcoded setting UUID for the chat channel setting
+	SettingUUIDProfileChatChannel = "5b3e0a6c-4f0e-4b8e-9d4a-0f6c3a7e2d11"
+	// SettingUUIDProfileChatAddress is the hardcoded setting UUID for the chat address setting
+	SettingUUIDProfileChatAddress = "c9f1d2a4-6e3b-4a7c-8b5d-2e4f6a8c0b13"
 	// SettingUUIDProfileEventShareCreated it the hardcoded setting UUID for the send in app setting
 	SettingUUIDProfileEventShareCreated = "872d8ef6-6f2a-42ab-af7d-f53cc81d7046"
 	// SettingUUIDProfileEventShareRemoved is the hardcoded setting UUID for the send in app setting
@@ -122,6 +126,8 @@ func generateBundleAdminRole() *settingsmsg.Bundle {
 			DeleteReadOnlyPublicLinkPasswordPermission(All),
 			DisableEmailNotificationsPermission(Own),
 			ProfileEmailSendingIntervalPermission(Own),
+			ProfileChatChannelPermission(Own),
+			ProfileChatAddressPermission(Own),
 			ProfileEventShareCreatedPermission(Own),
 			ProfileEventShareRemovedPermission(Own),
 			ProfileEventShareExpiredPermission(Own),
@@ -165,6 +171,8 @@ func generateBundleSpaceAdminRole() *settingsmsg.Bundle {
 			DeleteReadOnlyPublicLinkPasswordPermission(All),
 			DisableEmailNotificationsPermission(Own),
 			ProfileEmailSendingIntervalPermission(Own),
+			ProfileChatChannelPermission(Own),
+			ProfileChatAddressPermission(Own),
 			ProfileEventShareCreatedPermission(Own),
 			ProfileEventShareRemovedPermission(Own),
 			ProfileEventShareExpiredPermission(Own),
@@ -203,6 +211,8 @@ func generateBundleUserRole() *settingsmsg.Bundle {
 			CreateSpacesPermission(Own),
 			DisableEmailNotificationsPermission(Own),
 			ProfileEmailSendingIntervalPermission(Own),
+			ProfileChatChannelPermission(Own),
+			ProfileChatAddressPermission(Own),
 			ProfileEventShareCreatedPermission(Own),
 			ProfileEventShareRemovedPermission(Own),
 			ProfileEventShareExpiredPermission(Own),
@@ -234,6 +244,8 @@ func generateBundleUserLightRole() *settingsmsg.Bundle {
 			AutoAcceptSharesPermission(Own),
 			DisableEmailNotificationsPermission(Own),
 			ProfileEmailSendingIntervalPermission(Own),
+			ProfileChatChannelPermission(Own),
+			ProfileChatAddressPermission(Own),
 			ProfileEventShareCreatedPermission(Own),
 			ProfileEventShareRemovedPermission(Own),
 			ProfileEventShareExpiredPermission(Own),
@@ -299,6 +311,26 @@ func generateBundleProfileRequest() *settingsmsg.Bundle {
 				},
 				Value: &sendEmailOptions,
 			},
+			{
+				Id:          SettingUUIDProfileChatChannel,
+				Name:        "chat-channel-options",
+				DisplayName: TemplateChatChannel,
+				Description: TemplateChatChannelDescription,
+				Resource: &settingsmsg.Resource{
+					Type: settingsmsg.Resource_TYPE_USER,
+				},
+				Value: &chatChannelOptions,
+			},
+			{
+				Id:          SettingUUIDProfileChatAddress,
+				Name:        "chat-address",
+				DisplayName: TemplateChatAddress,
+				Description: TemplateChatAddressDescription,
+				Resource: &settingsmsg.Resource{
+					Type: settingsmsg.Resource_TYPE_USER,
+				},
+				Value: &settingsmsg.Setting_StringValue{StringValue: &settingsmsg.String{MaxLength: 2048}},
+			},
 			{
 				Id:          SettingUUIDProfileEventShareCreated,
 				Name:        "event-share-created-options",
@@ -312,6 +344,7 @@ func generateBundleProfileRequest() *settingsmsg.Bundle {
 						Options: []*settingsmsg.MultiChoiceCollectionOption{
 							&optionInAppTrue,
 							&optionMailTrue,
+							&optionChatFalse,
 						},
 					},
 				},
@@ -346,6 +379,7 @@ func generateBundleProfileRequest() *settingsmsg.Bundle {
 						Options: []*settingsmsg.MultiChoiceCollectionOption{
 							&optionInAppTrue,
 							&optionMailTrue,
+							&optionChatFalse,
 						},
 					},
 				},
@@ -363,6 +397,7 @@ func generateBundleProfileRequest() *settingsmsg.Bundle {
 						Options: []*settingsmsg.MultiChoiceCollectionOption{
 							&optionInAppTrue,
 							&optionMailTrue,
+							&optionChatFalse,
 						},
 					},
 				},
@@ -380,6 +415,7 @@ func generateBundleProfileRequest() *settingsmsg.Bundle {
 						Options: []*settingsmsg.MultiChoiceCollectionOption{
 							&optionInAppTrue,
 							&optionMailTrue,
+							&optionChatFalse,
 						},
 					},
 				},
@@ -397,6 +433,7 @@ func generateBundleProfileRequest() *settingsmsg.Bundle {
 						Options: []*settingsmsg.MultiChoiceCollectionOption{
 							&optionInAppTrue,
 							&optionMailTrue,
+							&optionChatFalse,
 						},
 					},
 				},
@@ -533,6 +570,66 @@ var optionMailFalseDisabled = settingsmsg.MultiChoiceCollectionOption{
 	},
 }
 
+var optionChatFalse = settingsmsg.MultiChoiceCollectionOption{
+	Key:          "chat",
+	DisplayValue: "Chat",
+	Value: &settingsmsg.MultiChoiceCollectionOptionValue{
+		Option: &settingsmsg.MultiChoiceCollectionOptionValue_BoolValue{
+			BoolValue: &settingsmsg.Bool{
+				Default: false,
+			},
+		},
+	},
+}
+
+var chatChannelOptions = settingsmsg.Setting_SingleChoiceValue{
+	SingleChoiceValue: &settingsmsg.SingleChoiceList{
+		Options: []*settingsmsg.ListOption{
+			{
+				Value: &settingsmsg.ListOptionValue{
+					Option: &settingsmsg.ListOptionValue_StringValue{
+						StringValue: "none",
+					},
+				},
+				DisplayValue: TemplateChatChannelNone,
+				Default:      true,
+			},
+			{
+				Value: &settingsmsg.ListOptionValue{
+					Option: &settingsmsg.ListOptionValue_StringValue{
+						StringValue: "matrix",
+					},
+				},
+				DisplayValue: "Matrix",
+			},
+			{
+				Value: &settingsmsg.ListOptionValue{
+					Option: &settingsmsg.ListOptionValue_StringValue{
+						StringValue: "teams",
+					},
+				},
+				DisplayValue: "Microsoft Teams",
+			},
+			{
+				Value: &settingsmsg.ListOptionValue{
+					Option: &settingsmsg.ListOptionValue_StringValue{
+						StringValue: "slack",
+					},
+				},
+				DisplayValue: "Slack",
+			},
+			{
+				Value: &settingsmsg.ListOptionValue{
+					Option: &settingsmsg.ListOptionValue_StringValue{
+						StringValue: "webhook",
+					},
+				},
+				DisplayValue: TemplateChatChannelWebhook,
+			},
+		},
+	},
+}
+
 // TODO: languageSetting needed?
 var languageSetting = settingsmsg.Setting_SingleChoiceValue{
 	SingleChoiceValue: &settingsmsg.SingleChoiceList{
@@ -219,6 +219,44 @@ func ProfileEmailSendingIntervalPermission(c settingsmsg.Permission_Constraint)
 	}
 }
 
+// ProfileChatChannelPermission is the permission to set the chat channel
+func ProfileChatChannelPermission(c settingsmsg.Permission_Constraint) *settingsmsg.Setting {
+	return &settingsmsg.Setting{
+		Id:          "e3a7c1f5-2b8d-4c6e-9a0f-1d3b5c7e9f24",
+		Name:        "ChatChannel.ReadWrite",
+		DisplayName: "Chat Channel",
+		Resource: &settingsmsg.Resource{
+			Type: settingsmsg.Resource_TYPE_SETTING,
+			Id:   SettingUUIDProfileChatChannel,
+		},
+		Value: &settingsmsg.Setting_PermissionValue{
+			PermissionValue: &settingsmsg.Permission{
+				Operation:  settingsmsg.Permission_OPERATION_READWRITE,
+				Constraint: c,
+			},
+		},
+	}
+}
+
+// ProfileChatAddressPermission is the permission to set the chat address
+func ProfileChatAddressPermission(c settingsmsg.Permission_Constraint) *settingsmsg.Setting {
+	return &settingsmsg.Setting{
+		Id:          "a8d2f4b6-7c1e-4f3a-b5d9-6e8a0c2f4b35",
+		Name:        "ChatAddress.ReadWrite",
+		DisplayName: "Chat Address",
+		Resource: &settingsmsg.Resource{
+			Type: settingsmsg.Resource_TYPE_SETTING,
+			Id:   SettingUUIDProfileChatAddress,
+		},
+		Value: &settingsmsg.Setting_PermissionValue{
+			PermissionValue: &settingsmsg.Permission{
+				Operation:  settingsmsg.Permission_OPERATION_READWRITE,
+				Constraint: c,
+			},
+		},
+	}
+}
+
 // ProfileEventShareCreatedPermission is
 func ProfileEventShareCreatedPermission(c settingsmsg.Permission_Constraint) *settingsmsg.Setting {
 	return &settingsmsg.Setting{
@@ -52,4 +52,16 @@ var (
 	TemplateIntervalWeekly = l10n.Template("Weekly")
 	// translation for the 'never' email interval option
 	TemplateIntervalNever = l10n.Template("Never")
+	// name of the notification option 'Chat Channel'
+	TemplateChatChannel = l10n.Template("Chat notifications")
+	// description of the notification option 'Chat Channel'
+	TemplateChatChannelDescription = l10n.Template("The chat platform notifications are sent to")
+	// translation for the 'none' chat channel option
+	TemplateChatChannelNone = l10n.Template("None")
+	// translation for the 'webhook' chat channel option
+	TemplateChatChannelWebhook = l10n.Template("Organization webhook")
+	// name of the notification option 'Chat Address'
+	TemplateChatAddress = l10n.Template("Chat address")
+	// description of the notification option 'Chat Address'
+	TemplateChatAddressDescription = l10n.Template("The Matrix room ID or the incoming webhook URL of Microsoft Teams or Slack")
 )
@@ -147,8 +147,9 @@ func (s *Store) WriteRoleAssignment(accountUUID, roleID string) (*settingsmsg.Us
 	return ass, s.mdc.SimpleUpload(ctx, assignmentPath(accountUUID, ass.Id), b)
 }
 
-// RemoveRoleAssignment deletes the given role assignment from the existing assignments of the respective account.
-func (s *Store) RemoveRoleAssignment(assignmentID string) error {
+// RemoveRoleAssignment deletes the given role assignment from the existing assignments of the respective account
+// and returns the removed assignment.
+func (s *Store) RemoveRoleAssignment(assignmentID string) (*settingsmsg.UserRoleAssignment, error) {
 	s.Init()
 	ctx := context.TODO()
 	accounts, err := s.mdc.ReadDir(ctx, accountsFolderLocation)
@@ -156,9 +157,9 @@ func (s *Store) RemoveRoleAssignment(assignmentID string) error {
 	case nil:
 		// continue
 	case errtypes.NotFound:
-		return fmt.Errorf("assignmentID '%s' %w", assignmentID, settings.ErrNotFound)
+		return nil, fmt.Errorf("assignmentID '%s' %w", assignmentID, settings.ErrNotFound)
 	default:
-		return err
+		return nil, err
 	}
 
 	// TODO: use indexer to avoid spamming Metadata service
@@ -170,14 +171,20 @@ func (s *Store) RemoveRoleAssignment(assignmentID string) error {
 		}
 
 		for _, assID := range assIDs {
-			if assID == assignmentID {
-				// as per https://github.com/owncloud/product/issues/103 "Each user can have exactly one role"
-				// we also have to delete the cached dir listing
-				return s.mdc.Delete(ctx, accountPath(accID))
+			if assID != assignmentID {
+				continue
+			}
+
+			ass := &settingsmsg.UserRoleAssignment{Id: assignmentID, AccountUuid: accID}
+			if b, err := s.mdc.SimpleDownload(ctx, assignmentPath(accID, assID)); err == nil {
+				_ = json.Unmarshal(b, ass)
 			}
+			// as per https://github.com/owncloud/product/issues/103 "Each user can have exactly one role"
+			// we also have to delete the cached dir listing
+			return ass, s.mdc.Delete(ctx, accountPath(accID))
 		}
 	}
-	return fmt.Errorf("assignmentID '%s' %w", assignmentID, settings.ErrNotFound)
+	return nil, fmt.Errorf("assignmentID '%s' %w", assignmentID, settings.ErrNotFound)
 }
 
 func accountPath(accountUUID string) string {
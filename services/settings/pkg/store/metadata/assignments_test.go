@@ -262,8 +262,10 @@ func TestDeleteAssignment(t *testing.T) {
 			require.Equal(t, 1, len(list))
 			require.Equal(t, assignment.Id, list[0].Id)
 
-			err = s.RemoveRoleAssignment(assignment.Id)
+			removed, err := s.RemoveRoleAssignment(assignment.Id)
 			require.NoError(t, err)
+			require.Equal(t, scenario.userID, removed.AccountUuid)
+			require.Equal(t, scenario.firstRole, removed.RoleId)
 			// TODO: uncomment
 			// require.False(t, mdc.IDExists(assignment.RoleId))
 
@@ -271,7 +273,7 @@ func TestDeleteAssignment(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, 0, len(list))
 
-			err = s.RemoveRoleAssignment(assignment.Id)
+			_, err = s.RemoveRoleAssignment(assignment.Id)
 			require.Error(t, err)
 			// TODO: do we want a custom error message?
 		})
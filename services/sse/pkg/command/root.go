@@ -15,6 +15,7 @@ func GetCommands(cfg *config.Config) cli.Commands {
 		Server(cfg),
 		Health(cfg),
 		Version(cfg),
+		VAPIDKeys(cfg),
 	}
 }
 
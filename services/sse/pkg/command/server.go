@@ -6,10 +6,13 @@ import (
 
 	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/events/stream"
+	"github.com/cs3org/reva/v2/pkg/store"
 	"github.com/oklog/run"
 	"github.com/urfave/cli/v2"
+	microstore "go-micro.dev/v4/store"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/ocis-pkg/tracing"
 	"github.com/owncloud/ocis/v2/services/sse/pkg/config"
@@ -57,6 +60,39 @@ func Server(cfg *config.Config) *cli.Command {
 					return err
 				}
 
+				st := store.Create(
+					store.Store(cfg.Store.Store),
+					microstore.Nodes(cfg.Store.Nodes...),
+					microstore.Database(cfg.Store.Database),
+					microstore.Table(cfg.Store.Table),
+					store.Authentication(cfg.Store.AuthUsername, cfg.Store.AuthPassword),
+				)
+
+				subscriptions := kv.New(kv.Options{
+					Type:     cfg.Store.Store,
+					Nodes:    cfg.Store.Nodes,
+					Bucket:   cfg.Store.Database + "-subscriptions",
+					Username: cfg.Store.AuthUsername,
+					Password: cfg.Store.AuthPassword,
+					// the store is served by the same nats servers as the events
+					EnableTLS:            cfg.Events.EnableTLS,
+					TLSInsecure:          cfg.Events.TLSInsecure,
+					TLSRootCACertificate: cfg.Events.TLSRootCACertificate,
+				})
+
+				replay := kv.New(kv.Options{
+					Type:     cfg.Store.Store,
+					Nodes:    cfg.Store.Nodes,
+					Bucket:   cfg.Store.Database + "-replay",
+					TTL:      cfg.Replay.TTL,
+					Username: cfg.Store.AuthUsername,
+					Password: cfg.Store.AuthPassword,
+					// the store is served by the same nats servers as the events
+					EnableTLS:            cfg.Events.EnableTLS,
+					TLSInsecure:          cfg.Events.TLSInsecure,
+					TLSRootCACertificate: cfg.Events.TLSRootCACertificate,
+				})
+
 				server, err := http.Server(
 					http.Logger(logger),
 					http.Context(ctx),
@@ -64,6 +100,9 @@ func Server(cfg *config.Config) *cli.Command {
 					http.Consumer(natsStream),
 					http.RegisteredEvents(_registeredEvents),
 					http.TracerProvider(tracerProvider),
+					http.Store(st),
+					http.Subscriptions(subscriptions),
+					http.Replay(replay),
 				)
 				if err != nil {
 					return err
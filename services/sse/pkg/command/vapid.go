@@ -0,0 +1,31 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/owncloud/ocis/v2/services/sse/pkg/config"
+	"github.com/owncloud/ocis/v2/services/sse/pkg/webpush"
+)
+
+// VAPIDKeys generates a key pair to identify the server to the web push services.
+func VAPIDKeys(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:     "vapid-keys",
+		Usage:    "generate a VAPID key pair for web push",
+		Category: "maintenance",
+		Action: func(c *cli.Context) error {
+			privateKey, publicKey, err := webpush.GenerateVAPIDKeys()
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Private key: %s\n", privateKey)
+			fmt.Printf("Public key:  %s\n", publicKey)
+			fmt.Println("")
+			fmt.Println("Set the private key as SSE_WEBPUSH_VAPID_PRIVATE_KEY for all sse instances.")
+			return nil
+		},
+	}
+}
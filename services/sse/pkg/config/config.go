@@ -21,6 +21,9 @@ type Config struct {
 	Events       Events
 	HTTP         HTTP          `yaml:"http"`
 	TokenManager *TokenManager `yaml:"token_manager"`
+	Store        Store         `yaml:"store"`
+	WebPush      WebPush       `yaml:"web_push"`
+	Replay       Replay        `yaml:"replay"`
 
 	Context context.Context `yaml:"-" json:"-"`
 }
@@ -78,3 +81,32 @@ type HTTP struct {
 type TokenManager struct {
 	JWTSecret string `yaml:"jwt_secret" env:"OCIS_JWT_SECRET;SSE_JWT_SECRET" desc:"The secret to mint and validate jwt tokens." introductionVersion:"5.0"`
 }
+
+// Store configures the store to use
+type Store struct {
+	Store        string   `yaml:"store" env:"OCIS_PERSISTENT_STORE;SSE_STORE" desc:"The type of the store. Supported values are: 'memory', 'nats-js-kv', 'redis-sentinel', 'noop'. See the text description for details." introductionVersion:"7.1"`
+	Nodes        []string `yaml:"nodes" env:"OCIS_PERSISTENT_STORE_NODES;SSE_STORE_NODES" desc:"A list of nodes to access the configured store. This has no effect when 'memory' store is configured. Note that the behaviour how nodes are used is dependent on the library of the configured store. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	Database     string   `yaml:"database" env:"SSE_STORE_DATABASE" desc:"The database name the configured store should use." introductionVersion:"7.1"`
+	Table        string   `yaml:"table" env:"SSE_STORE_TABLE" desc:"The database table the store should use." introductionVersion:"7.1"`
+	AuthUsername string   `yaml:"username" env:"OCIS_PERSISTENT_STORE_AUTH_USERNAME;SSE_STORE_AUTH_USERNAME" desc:"The username to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"7.1"`
+	AuthPassword string   `yaml:"password" env:"OCIS_PERSISTENT_STORE_AUTH_PASSWORD;SSE_STORE_AUTH_PASSWORD" desc:"The password to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"7.1"`
+}
+
+// WebPush defines the available web push configuration.
+type WebPush struct {
+	Enabled              bool          `yaml:"enabled" env:"SSE_WEBPUSH_ENABLED" desc:"Deliver events to the web push subscriptions of users who are not connected via SSE." introductionVersion:"7.1"`
+	VAPIDPrivateKey      string        `yaml:"vapid_private_key" env:"SSE_WEBPUSH_VAPID_PRIVATE_KEY" desc:"The base64url encoded private P-256 key identifying the server to the push services (VAPID). All instances must use the same key. A key pair can be generated with the 'ocis sse vapid-keys' command. Changing the key invalidates all subscriptions." introductionVersion:"7.1"`
+	VAPIDSubject         string        `yaml:"vapid_subject" env:"SSE_WEBPUSH_VAPID_SUBJECT" desc:"A 'mailto:' or 'https:' URI the push services can use to contact the operator." introductionVersion:"7.1"`
+	TTL                  time.Duration `yaml:"ttl" env:"SSE_WEBPUSH_TTL" desc:"The time the push services keep a message if the device is not reachable. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	Timeout              time.Duration `yaml:"timeout" env:"SSE_WEBPUSH_TIMEOUT" desc:"The timeout of the requests to the push services. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	AllowedEndpointHosts []string      `yaml:"allowed_endpoint_hosts" env:"SSE_WEBPUSH_ALLOWED_ENDPOINT_HOSTS" desc:"The hosts of the push services subscriptions are allowed to point to. Entries starting with a dot also allow all subdomains. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	EventTypes           []string      `yaml:"event_types" env:"SSE_WEBPUSH_EVENT_TYPES" desc:"The SSE event types which are delivered via web push. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	MaxSubscriptions     int           `yaml:"max_subscriptions" env:"SSE_WEBPUSH_MAX_SUBSCRIPTIONS" desc:"The maximum number of devices a user can register. The oldest subscription is removed when a new device is registered." introductionVersion:"7.1"`
+	Workers              int           `yaml:"workers" env:"SSE_WEBPUSH_WORKERS" desc:"The number of workers delivering push messages concurrently." introductionVersion:"7.1"`
+}
+
+// Replay defines the available event replay configuration.
+type Replay struct {
+	BufferSize int           `yaml:"buffer_size" env:"SSE_REPLAY_BUFFER_SIZE" desc:"The number of events kept per user to be replayed to clients reconnecting with a Last-Event-ID. Set to 0 to disable the replay." introductionVersion:"7.1"`
+	TTL        time.Duration `yaml:"ttl" env:"SSE_REPLAY_TTL" desc:"The time events are kept for the replay. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+}
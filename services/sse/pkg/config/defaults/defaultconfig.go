@@ -2,6 +2,7 @@ package defaults
 
 import (
 	"strings"
+	"time"
 
 	"github.com/owncloud/ocis/v2/services/sse/pkg/config"
 )
@@ -34,11 +35,33 @@ func DefaultConfig() *config.Config {
 			Namespace: "com.owncloud.sse",
 			CORS: config.CORS{
 				AllowedOrigins:   []string{"*"},
-				AllowedMethods:   []string{"GET"},
-				AllowedHeaders:   []string{"Authorization", "Origin", "Content-Type", "Accept", "X-Requested-With", "X-Request-Id", "Ocs-Apirequest"},
+				AllowedMethods:   []string{"GET", "PUT", "DELETE"},
+				AllowedHeaders:   []string{"Authorization", "Origin", "Content-Type", "Accept", "X-Requested-With", "X-Request-Id", "Ocs-Apirequest", "Last-Event-ID"},
 				AllowCredentials: true,
 			},
 		},
+		Store: config.Store{
+			Store:    "nats-js-kv",
+			Nodes:    []string{"127.0.0.1:9233"},
+			Database: "sse",
+		},
+		WebPush: config.WebPush{
+			TTL:     24 * time.Hour,
+			Timeout: 10 * time.Second,
+			AllowedEndpointHosts: []string{
+				"fcm.googleapis.com",
+				".push.services.mozilla.com",
+				".notify.windows.com",
+				".push.apple.com",
+			},
+			EventTypes:       []string{"userlog-notification"},
+			MaxSubscriptions: 10,
+			Workers:          10,
+		},
+		Replay: config.Replay{
+			BufferSize: 100,
+			TTL:        5 * time.Minute,
+		},
 	}
 }
 
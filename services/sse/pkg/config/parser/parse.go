@@ -2,10 +2,12 @@ package parser
 
 import (
 	"errors"
+	"fmt"
 
 	ociscfg "github.com/owncloud/ocis/v2/ocis-pkg/config"
 	"github.com/owncloud/ocis/v2/services/sse/pkg/config"
 	"github.com/owncloud/ocis/v2/services/sse/pkg/config/defaults"
+	"github.com/owncloud/ocis/v2/services/sse/pkg/webpush"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/envdecode"
 )
@@ -34,5 +36,13 @@ func ParseConfig(cfg *config.Config) error {
 
 // Validate validates our little config
 func Validate(cfg *config.Config) error {
+	if cfg.WebPush.Enabled {
+		if _, err := webpush.NewVAPID(cfg.WebPush.VAPIDPrivateKey, cfg.WebPush.VAPIDSubject); err != nil {
+			return fmt.Errorf("the sse service needs a valid SSE_WEBPUSH_VAPID_PRIVATE_KEY when web push is enabled: %w", err)
+		}
+		if cfg.WebPush.Workers <= 0 {
+			return fmt.Errorf("the sse service needs a positive SSE_WEBPUSH_WORKERS when web push is enabled")
+		}
+	}
 	return nil
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 
 	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/services/sse/pkg/config"
+	"go-micro.dev/v4/store"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -20,6 +22,9 @@ type Options struct {
 	Consumer         events.Consumer
 	RegisteredEvents []events.Unmarshaller
 	TracerProvider   trace.TracerProvider
+	Store            store.Store
+	Subscriptions    kv.Store
+	Replay           kv.Store
 }
 
 // newOptions initializes the available default options.
@@ -74,3 +79,24 @@ func TracerProvider(val trace.TracerProvider) Option {
 		o.TracerProvider = val
 	}
 }
+
+// Store provides a function to set the store option
+func Store(val store.Store) Option {
+	return func(o *Options) {
+		o.Store = val
+	}
+}
+
+// Subscriptions provides a function to set the store of the web push subscriptions
+func Subscriptions(val kv.Store) Option {
+	return func(o *Options) {
+		o.Subscriptions = val
+	}
+}
+
+// Replay provides a function to set the store of the replay buffers
+func Replay(val kv.Store) Option {
+	return func(o *Options) {
+		o.Replay = val
+	}
+}
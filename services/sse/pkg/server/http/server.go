@@ -78,12 +78,22 @@ func Server(opts ...Option) (http.Service, error) {
 		),
 	)
 
+	// every instance gets all events to serve the users connected to it
 	ch, err := events.Consume(options.Consumer, "sse-"+uuid.New().String(), options.RegisteredEvents...)
 	if err != nil {
 		return http.Service{}, err
 	}
 
-	handle, err := svc.NewSSE(options.Config, options.Logger, ch, mux)
+	var sharedCh <-chan events.Event
+	if options.Config.Replay.BufferSize > 0 || options.Config.WebPush.Enabled {
+		// all instances share the group, every event is buffered and pushed only once
+		sharedCh, err = events.Consume(options.Consumer, "sse-shared", options.RegisteredEvents...)
+		if err != nil {
+			return http.Service{}, err
+		}
+	}
+
+	handle, err := svc.NewSSE(options.Context, options.Config, options.Logger, ch, sharedCh, options.Store, options.Subscriptions, options.Replay, mux)
 	if err != nil {
 		return http.Service{}, err
 	}
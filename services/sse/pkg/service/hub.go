@@ -0,0 +1,98 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// connectionBuffer is the number of events buffered for a connection, slow clients are disconnected
+// when it is full and can catch up with the replay
+const connectionBuffer = 64
+
+// event is a server sent event
+type event struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data []byte `json:"data"`
+}
+
+// writeTo writes the event in the text/event-stream format
+func (e event) writeTo(w io.Writer) error {
+	var b bytes.Buffer
+	if e.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", e.ID)
+	}
+	if e.Type != "" {
+		fmt.Fprintf(&b, "event: %s\n", e.Type)
+	}
+	for _, line := range bytes.Split(e.Data, []byte("\n")) {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// connection is an open SSE connection of a user to this instance
+type connection struct {
+	events chan event
+	closed chan struct{}
+	once   sync.Once
+}
+
+func (c *connection) close() {
+	c.once.Do(func() { close(c.closed) })
+}
+
+// hub distributes the events to the connections of the users to this instance
+type hub struct {
+	mu          sync.RWMutex
+	connections map[string]map[*connection]struct{}
+}
+
+func newHub() *hub {
+	return &hub{connections: make(map[string]map[*connection]struct{})}
+}
+
+// subscribe adds a connection of the user, it receives all events published afterwards
+func (h *hub) subscribe(uid string) *connection {
+	c := &connection{
+		events: make(chan event, connectionBuffer),
+		closed: make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.connections[uid] == nil {
+		h.connections[uid] = make(map[*connection]struct{})
+	}
+	h.connections[uid][c] = struct{}{}
+	return c
+}
+
+// unsubscribe removes a connection of the user
+func (h *hub) unsubscribe(uid string, c *connection) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.connections[uid], c)
+	if len(h.connections[uid]) == 0 {
+		delete(h.connections, uid)
+	}
+	c.close()
+}
+
+// publish sends the event to all connections of the user
+func (h *hub) publish(uid string, e event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for c := range h.connections[uid] {
+		select {
+		case c.events <- e:
+		default:
+			c.close()
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go-micro.dev/v4/store"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+)
+
+// presenceTTL is the time a presence record is kept in the store if the instance doesn't refresh it
+const presenceTTL = time.Minute
+
+// presence tracks the users connected to this instance in the store, so that all instances know
+// whether a user is connected to any of them.
+type presence struct {
+	store    store.Store
+	logger   log.Logger
+	instance string
+
+	mu    sync.Mutex
+	users map[string]int
+}
+
+// newPresence returns the presence of the users of this instance, which is refreshed until the context is done.
+func newPresence(ctx context.Context, st store.Store, logger log.Logger) *presence {
+	p := &presence{
+		store:    st,
+		logger:   logger,
+		instance: uuid.New().String(),
+		users:    make(map[string]int),
+	}
+
+	go func() {
+		ticker := time.NewTicker(presenceTTL / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.refresh()
+			}
+		}
+	}()
+	return p
+}
+
+// join marks the user as connected
+func (p *presence) join(uid string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.users[uid]++
+	if p.users[uid] == 1 {
+		p.write(uid)
+	}
+}
+
+// leave marks the user as disconnected once all connections of the user to this instance are closed
+func (p *presence) leave(uid string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.users[uid]--
+	if p.users[uid] > 0 {
+		return
+	}
+	delete(p.users, uid)
+	if err := p.store.Delete(p.key(uid)); err != nil {
+		p.logger.Error().Err(err).Str("userid", uid).Msg("could not delete the presence of the user")
+	}
+}
+
+// online returns true if the user is connected to any instance
+func (p *presence) online(uid string) bool {
+	records, err := p.store.Read(presencePrefix(uid), store.ReadPrefix())
+	if err != nil {
+		return false
+	}
+	return len(records) > 0
+}
+
+func (p *presence) refresh() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for uid := range p.users {
+		p.write(uid)
+	}
+}
+
+func (p *presence) write(uid string) {
+	err := p.store.Write(&store.Record{
+		Key:    p.key(uid),
+		Value:  []byte(p.instance),
+		Expiry: presenceTTL,
+	})
+	if err != nil {
+		p.logger.Error().Err(err).Str("userid", uid).Msg("could not write the presence of the user")
+	}
+}
+
+func (p *presence) key(uid string) string {
+	return presencePrefix(uid) + p.instance
+}
+
+func presencePrefix(uid string) string {
+	return "presence/" + uid + "/"
+}
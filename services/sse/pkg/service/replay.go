@@ -0,0 +1,115 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/sse/pkg/config"
+)
+
+// replayBuffer keeps the latest events of every user in the store, so that clients reconnecting to
+// any instance can resume with the Last-Event-ID.
+type replayBuffer struct {
+	c      config.Replay
+	store  kv.Store
+	logger log.Logger
+	now    func() time.Time
+}
+
+// bufferedEvent is an event in the replay buffer
+type bufferedEvent struct {
+	event
+	Time time.Time `json:"time"`
+}
+
+// newReplayBuffer returns a replay buffer keeping the events in the store, which should expire keys after the ttl.
+func newReplayBuffer(c config.Replay, st kv.Store, logger log.Logger) *replayBuffer {
+	return &replayBuffer{
+		c:      c,
+		store:  st,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+func (rb *replayBuffer) enabled() bool {
+	return rb.c.BufferSize > 0
+}
+
+// append atomically adds the event to the buffer of the user and drops the oldest events exceeding the size or the ttl.
+func (rb *replayBuffer) append(uid string, e event) {
+	_, err := rb.store.Update(replayKey(uid), func(value []byte) ([]byte, error) {
+		events, err := rb.decode(value)
+		if err != nil {
+			rb.logger.Error().Err(err).Str("userid", uid).Msg("could not decode the replay buffer, discarding it")
+		}
+
+		events = append(events, bufferedEvent{event: e, Time: rb.now()})
+		if len(events) > rb.c.BufferSize {
+			events = events[len(events)-rb.c.BufferSize:]
+		}
+		return json.Marshal(events)
+	})
+	if err != nil {
+		rb.logger.Error().Err(err).Str("userid", uid).Msg("could not write the replay buffer")
+	}
+}
+
+// since returns the events of the user after the event with the given id. All buffered events
+// are returned if the id is not in the buffer anymore.
+func (rb *replayBuffer) since(uid, id string) []event {
+	events, err := rb.load(uid)
+	if err != nil {
+		rb.logger.Error().Err(err).Str("userid", uid).Msg("could not read the replay buffer")
+		return nil
+	}
+
+	start := 0
+	for i, e := range events {
+		if e.ID == id {
+			start = i + 1
+			break
+		}
+	}
+
+	replay := make([]event, 0, len(events)-start)
+	for _, e := range events[start:] {
+		replay = append(replay, e.event)
+	}
+	return replay
+}
+
+// load returns the buffered events of the user which are not expired
+func (rb *replayBuffer) load(uid string) ([]bufferedEvent, error) {
+	value, err := rb.store.Get(replayKey(uid))
+	if err != nil {
+		return nil, err
+	}
+	return rb.decode(value)
+}
+
+// decode returns the buffered events which are not expired
+func (rb *replayBuffer) decode(value []byte) ([]bufferedEvent, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	var events []bufferedEvent
+	if err := json.Unmarshal(value, &events); err != nil {
+		return nil, err
+	}
+
+	if rb.c.TTL > 0 {
+		oldest := rb.now().Add(-rb.c.TTL)
+		for len(events) > 0 && events[0].Time.Before(oldest) {
+			events = events[1:]
+		}
+	}
+	return events, nil
+}
+
+func replayKey(uid string) string {
+	return "replay/" + uid
+}
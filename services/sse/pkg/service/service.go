@@ -1,15 +1,18 @@
 package service
 
 import (
+	"context"
 	"net/http"
 	"time"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/r3labs/sse/v2"
+	"github.com/google/uuid"
+	"go-micro.dev/v4/store"
 
 	revactx "github.com/cs3org/reva/v2/pkg/ctx"
 	"github.com/cs3org/reva/v2/pkg/events"
 
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/services/sse/pkg/config"
 )
@@ -19,24 +22,43 @@ type SSE struct {
 	c         *config.Config
 	l         log.Logger
 	m         *chi.Mux
-	sse       *sse.Server
+	hub       *hub
 	evChannel <-chan events.Event
+	sharedCh  <-chan events.Event
+	presence  *presence
+	replay    *replayBuffer
+	webPush   *WebPush
 }
 
-// NewSSE returns a service implementation for Service.
-func NewSSE(c *config.Config, l log.Logger, ch <-chan events.Event, mux *chi.Mux) (SSE, error) {
+// NewSSE returns a service implementation for Service. Every instance must receive all events on the
+// event channel to serve the users connected to it, while the events of the shared channel are handled
+// by only one instance to fill the replay buffers and to deliver them via web push. The web push subscriptions
+// and the replay buffers are kept in their own stores. The background tasks of the service run until the context is done.
+func NewSSE(ctx context.Context, c *config.Config, l log.Logger, ch <-chan events.Event, sharedCh <-chan events.Event, st store.Store, subscriptions kv.Store, replay kv.Store, mux *chi.Mux) (SSE, error) {
 	s := SSE{
 		c:         c,
 		l:         l,
 		m:         mux,
-		sse:       sse.New(),
+		hub:       newHub(),
 		evChannel: ch,
+		sharedCh:  sharedCh,
+		presence:  newPresence(ctx, st, l),
+		replay:    newReplayBuffer(c.Replay, replay, l),
 	}
 	mux.Route("/ocs/v2.php/apps/notifications/api/v1/notifications", func(r chi.Router) {
 		r.Get("/sse", s.HandleSSE)
 	})
 
+	if c.WebPush.Enabled {
+		wp, err := newWebPush(ctx, c.WebPush, l, subscriptions, s.presence, mux)
+		if err != nil {
+			return SSE{}, err
+		}
+		s.webPush = wp
+	}
+
 	go s.ListenForEvents()
+	go s.ListenForSharedEvents()
 
 	return s, nil
 }
@@ -46,7 +68,7 @@ func (s SSE) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.m.ServeHTTP(w, r)
 }
 
-// ListenForEvents listens for events
+// ListenForEvents sends the events to the users connected to this instance
 func (s SSE) ListenForEvents() {
 	for e := range s.evChannel {
 		switch ev := e.Event.(type) {
@@ -54,50 +76,122 @@ func (s SSE) ListenForEvents() {
 			s.l.Error().Interface("event", ev).Msg("unhandled event")
 		case events.SendSSE:
 			for _, uid := range ev.UserIDs {
-				s.sse.Publish(uid, &sse.Event{
-					Event: []byte(ev.Type),
-					Data:  ev.Message,
-				})
+				s.hub.publish(uid, toEvent(e.ID, ev))
+			}
+		}
+	}
+}
+
+// ListenForSharedEvents adds the events to the replay buffers and delivers them via web push
+func (s SSE) ListenForSharedEvents() {
+	if s.sharedCh == nil {
+		return
+	}
+
+	for e := range s.sharedCh {
+		ev, ok := e.Event.(events.SendSSE)
+		if !ok {
+			continue
+		}
+
+		if s.replay.enabled() {
+			for _, uid := range ev.UserIDs {
+				s.replay.append(uid, toEvent(e.ID, ev))
 			}
 		}
+		if s.webPush != nil {
+			s.webPush.deliver(ev)
+		}
 	}
 }
 
-// HandleSSE is the GET handler for events
+// HandleSSE is the GET handler for events. Clients reconnecting with a Last-Event-ID header receive
+// the buffered events they missed first.
 func (s SSE) HandleSSE(w http.ResponseWriter, r *http.Request) {
-	u, ok := revactx.ContextGetUser(r.Context())
+	uid, ok := userID(r)
 	if !ok {
 		s.l.Error().Msg("sse: no user in context")
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	uid := u.GetId().GetOpaqueId()
-	if uid == "" {
-		s.l.Error().Msg("sse: user in context is broken")
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.l.Error().Msg("sse: streaming unsupported")
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	stream := s.sse.CreateStream(uid)
-	stream.AutoReplay = false
+	// subscribe before reading the replay buffer to not miss events published in between
+	conn := s.hub.subscribe(uid)
+	defer s.hub.unsubscribe(uid, conn)
 
+	s.presence.join(uid)
+	defer s.presence.leave(uid)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	// ids of replayed events which might also be received live
+	replayed := make(map[string]struct{})
+	if lastID := r.Header.Get("Last-Event-ID"); lastID != "" && s.replay.enabled() {
+		for _, e := range s.replay.since(uid, lastID) {
+			if err := e.writeTo(w); err != nil {
+				return
+			}
+			replayed[e.ID] = struct{}{}
+		}
+	}
+	flusher.Flush()
+
+	var keepalive <-chan time.Time
 	if s.c.KeepAliveInterval != 0 {
 		ticker := time.NewTicker(s.c.KeepAliveInterval)
 		defer ticker.Stop()
-		go func() {
-			for range ticker.C {
-				s.sse.Publish(uid, &sse.Event{
-					Comment: []byte("keepalive"),
-				})
+		keepalive = ticker.C
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-conn.closed:
+			return
+		case e := <-conn.events:
+			if _, ok := replayed[e.ID]; ok {
+				delete(replayed, e.ID)
+				continue
+			}
+			if err := e.writeTo(w); err != nil {
+				return
 			}
-		}()
+			flusher.Flush()
+		case <-keepalive:
+			if _, err := w.Write([]byte(": keepalive\n\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
 	}
+}
 
-	// add stream to URL
-	q := r.URL.Query()
-	q.Set("stream", uid)
-	r.URL.RawQuery = q.Encode()
+// toEvent returns the server sent event of the bus event. The id of the bus event is the same on all
+// instances and allows clients to resume on any of them.
+func toEvent(id string, ev events.SendSSE) event {
+	if id == "" {
+		id = uuid.New().String()
+	}
+	return event{ID: id, Type: ev.Type, Data: ev.Message}
+}
 
-	s.sse.ServeHTTP(w, r)
+// userID returns the id of the user in the context
+func userID(r *http.Request) (string, bool) {
+	u, ok := revactx.ContextGetUser(r.Context())
+	if !ok {
+		return "", false
+	}
+	uid := u.GetId().GetOpaqueId()
+	return uid, uid != ""
 }
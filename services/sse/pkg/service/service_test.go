@@ -0,0 +1,174 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	userpb "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/go-chi/chi/v5"
+	"go-micro.dev/v4/store"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/sse/pkg/config"
+)
+
+func TestEvent_WriteTo(t *testing.T) {
+	var b strings.Builder
+	e := event{ID: "1", Type: "userlog-notification", Data: []byte("line1\nline2")}
+	if err := e.writeTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	expected := "id: 1\nevent: userlog-notification\ndata: line1\ndata: line2\n\n"
+	if b.String() != expected {
+		t.Errorf("expected %q, got %q", expected, b.String())
+	}
+}
+
+func TestReplayBuffer(t *testing.T) {
+	now := time.Now()
+	rb := newReplayBuffer(config.Replay{BufferSize: 3, TTL: time.Minute}, kv.New(kv.Options{Type: "memory"}), log.NopLogger())
+	rb.now = func() time.Time { return now }
+
+	for _, id := range []string{"1", "2", "3", "4"} {
+		rb.append("alice", event{ID: id})
+		now = now.Add(20 * time.Second)
+	}
+
+	ids := func(events []event) string {
+		var s []string
+		for _, e := range events {
+			s = append(s, e.ID)
+		}
+		return strings.Join(s, ",")
+	}
+
+	tests := []struct {
+		lastID   string
+		expected string
+	}{
+		{lastID: "3", expected: "4"},
+		{lastID: "4", expected: ""},
+		// evicted by the size, everything is replayed
+		{lastID: "1", expected: "2,3,4"},
+	}
+	for _, tc := range tests {
+		if got := ids(rb.since("alice", tc.lastID)); got != tc.expected {
+			t.Errorf("since %s: expected %q, got %q", tc.lastID, tc.expected, got)
+		}
+	}
+
+	// event 2 is older than the ttl now
+	now = now.Add(time.Second)
+	if got := ids(rb.since("alice", "unknown")); got != "3,4" {
+		t.Errorf("expected expired events to be dropped, got %q", got)
+	}
+	if got := rb.since("bob", "1"); len(got) != 0 {
+		t.Errorf("expected no events, got %v", got)
+	}
+}
+
+func TestHandleSSE_Resume(t *testing.T) {
+	cfg := &config.Config{Replay: config.Replay{BufferSize: 10, TTL: time.Minute}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := NewSSE(ctx, cfg, log.NopLogger(), nil, nil, store.NewMemoryStore(), kv.New(kv.Options{Type: "memory"}), kv.New(kv.Options{Type: "memory"}), chi.NewMux())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1", "2", "3"} {
+		s.replay.append("alice", event{ID: id, Type: "test", Data: []byte(id)})
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := revactx.ContextSetUser(r.Context(), &userpb.User{Id: &userpb.UserId{OpaqueId: "alice"}})
+		s.HandleSSE(w, r.WithContext(ctx))
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("Last-Event-ID", "1")
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("unexpected content type %s", ct)
+	}
+
+	// the replayed event 3 is also received live and must not be sent twice
+	s.hub.publish("alice", event{ID: "3", Type: "test", Data: []byte("3")})
+	s.hub.publish("alice", event{ID: "4", Type: "test", Data: []byte("4")})
+
+	var ids []string
+	scanner := bufio.NewScanner(res.Body)
+	for len(ids) < 3 && scanner.Scan() {
+		if id, ok := strings.CutPrefix(scanner.Text(), "id: "); ok {
+			ids = append(ids, id)
+		}
+	}
+	if got := strings.Join(ids, ","); got != "2,3,4" {
+		t.Errorf("expected events 2,3,4, got %s", got)
+	}
+}
+
+func TestWebPush_Update(t *testing.T) {
+	wp := &WebPush{store: kv.New(kv.Options{Type: "memory"})}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(device string) {
+			defer wg.Done()
+			err := wp.update("alice", func(subs []subscription) []subscription {
+				return append(subs, subscription{Device: device})
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}(fmt.Sprintf("device-%d", i))
+	}
+	wg.Wait()
+
+	subs, err := wp.load("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 10 {
+		t.Errorf("expected 10 subscriptions, got %d", len(subs))
+	}
+
+	if err := wp.update("alice", func([]subscription) []subscription { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if subs, err := wp.load("alice"); err != nil || len(subs) != 0 {
+		t.Errorf("expected the subscriptions to be removed, got %v, %v", subs, err)
+	}
+}
+
+func TestReplayBuffer_ConcurrentAppend(t *testing.T) {
+	rb := newReplayBuffer(config.Replay{BufferSize: 20, TTL: time.Minute}, kv.New(kv.Options{Type: "memory"}), log.NopLogger())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			rb.append("alice", event{ID: id, Type: "test"})
+		}(fmt.Sprint(i))
+	}
+	wg.Wait()
+
+	if got := rb.since("alice", ""); len(got) != 10 {
+		t.Errorf("expected 10 events, got %d", len(got))
+	}
+}
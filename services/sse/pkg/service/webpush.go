@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/cs3org/reva/v2/pkg/events"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/sse/pkg/config"
+	"github.com/owncloud/ocis/v2/services/sse/pkg/webpush"
+)
+
+const (
+	// maxDeviceLength is the maximum length of the device names of the subscriptions
+	maxDeviceLength = 128
+	// pushQueueSize is the number of pushes waiting for a worker, pushes exceeding it are dropped
+	pushQueueSize = 1000
+)
+
+// WebPush delivers events to the push subscriptions of users who are not connected via SSE.
+type WebPush struct {
+	c        config.WebPush
+	l        log.Logger
+	store    kv.Store
+	vapid    *webpush.VAPID
+	sender   *webpush.Sender
+	presence *presence
+	queue    chan pushJob
+}
+
+// pushJob is the push of a message to all subscriptions of a user
+type pushJob struct {
+	uid     string
+	payload []byte
+}
+
+// subscription is a push subscription of a device of the user
+type subscription struct {
+	Device       string               `json:"device"`
+	Subscription webpush.Subscription `json:"subscription"`
+	Created      time.Time            `json:"created"`
+}
+
+// pushMessage is the payload of a push message
+type pushMessage struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// newWebPush returns a web push implementation and registers its endpoints. The workers delivering
+// the pushes run until the context is done.
+func newWebPush(ctx context.Context, c config.WebPush, l log.Logger, st kv.Store, p *presence, mux *chi.Mux) (*WebPush, error) {
+	vapid, err := webpush.NewVAPID(c.VAPIDPrivateKey, c.VAPIDSubject)
+	if err != nil {
+		return nil, err
+	}
+
+	wp := &WebPush{
+		c:        c,
+		l:        l,
+		store:    st,
+		vapid:    vapid,
+		sender:   webpush.NewSender(vapid, c.TTL, c.Timeout),
+		presence: p,
+		queue:    make(chan pushJob, pushQueueSize),
+	}
+	for i := 0; i < c.Workers; i++ {
+		go wp.work(ctx)
+	}
+	mux.Route("/ocs/v2.php/apps/notifications/api/v1/notifications/push", func(r chi.Router) {
+		r.Get("/vapid-key", wp.HandleVAPIDKey)
+		r.Put("/subscriptions/{device}", wp.HandleSubscribe)
+		r.Delete("/subscriptions/{device}", wp.HandleUnsubscribe)
+	})
+	return wp, nil
+}
+
+// deliver queues the pushes of the event to its users, the workers skip the users who are connected to any instance
+func (wp *WebPush) deliver(ev events.SendSSE) {
+	if !slices.Contains(wp.c.EventTypes, ev.Type) {
+		return
+	}
+
+	payload := wp.payload(ev)
+	for _, uid := range ev.UserIDs {
+		select {
+		case wp.queue <- pushJob{uid: uid, payload: payload}:
+		default:
+			wp.l.Error().Str("userid", uid).Str("type", ev.Type).Msg("the push queue is full, dropping the push message")
+		}
+	}
+}
+
+// work delivers the queued pushes until the context is done
+func (wp *WebPush) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-wp.queue:
+			if wp.presence.online(job.uid) {
+				continue
+			}
+			wp.push(job.uid, job.payload)
+		}
+	}
+}
+
+// payload returns the push message of the event, the data is left out if it is too large
+func (wp *WebPush) payload(ev events.SendSSE) []byte {
+	msg := pushMessage{Type: ev.Type}
+	if json.Valid(ev.Message) {
+		msg.Data = ev.Message
+	}
+	b, err := json.Marshal(msg)
+	if err != nil || len(b) > webpush.MaxPayloadSize {
+		b, _ = json.Marshal(pushMessage{Type: ev.Type})
+	}
+	return b
+}
+
+func (wp *WebPush) push(uid string, payload []byte) {
+	subs, err := wp.load(uid)
+	if err != nil {
+		wp.l.Error().Err(err).Str("userid", uid).Msg("could not load the push subscriptions")
+		return
+	}
+
+	for _, sub := range subs {
+		ctx, cancel := context.WithTimeout(context.Background(), wp.c.Timeout)
+		err := wp.sender.Send(ctx, sub.Subscription, payload)
+		cancel()
+		switch {
+		case errors.Is(err, webpush.ErrSubscriptionGone):
+			wp.l.Debug().Str("userid", uid).Str("device", sub.Device).Msg("removing expired push subscription")
+			if err := wp.update(uid, func(subs []subscription) []subscription { return remove(subs, sub.Device) }); err != nil {
+				wp.l.Error().Err(err).Str("userid", uid).Msg("could not remove the push subscription")
+			}
+		case err != nil:
+			wp.l.Error().Err(err).Str("userid", uid).Str("device", sub.Device).Msg("could not send the push message")
+		}
+	}
+}
+
+// HandleVAPIDKey returns the public key browsers need to subscribe
+func (wp *WebPush) HandleVAPIDKey(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"publicKey": wp.vapid.PublicKey()})
+}
+
+// HandleSubscribe registers or replaces the push subscription of a device of the user
+func (wp *WebPush) HandleSubscribe(w http.ResponseWriter, r *http.Request) {
+	uid, ok := userID(r)
+	if !ok {
+		wp.l.Error().Msg("webpush: no user in context")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	device := chi.URLParam(r, "device")
+	if device == "" || len(device) > maxDeviceLength {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var sub webpush.Subscription
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<12)).Decode(&sub); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err := sub.Validate(); err != nil || !wp.allowed(sub.Endpoint) {
+		wp.l.Debug().Err(err).Str("userid", uid).Msg("webpush: invalid subscription")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err := wp.update(uid, func(subs []subscription) []subscription {
+		subs = append(remove(subs, device), subscription{Device: device, Subscription: sub, Created: time.Now()})
+		if wp.c.MaxSubscriptions > 0 && len(subs) > wp.c.MaxSubscriptions {
+			subs = subs[len(subs)-wp.c.MaxSubscriptions:]
+		}
+		return subs
+	})
+	if err != nil {
+		wp.l.Error().Err(err).Str("userid", uid).Msg("could not store the push subscription")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleUnsubscribe removes the push subscription of a device of the user
+func (wp *WebPush) HandleUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	uid, ok := userID(r)
+	if !ok {
+		wp.l.Error().Msg("webpush: no user in context")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	device := chi.URLParam(r, "device")
+	err := wp.update(uid, func(subs []subscription) []subscription {
+		return remove(subs, device)
+	})
+	if err != nil {
+		wp.l.Error().Err(err).Str("userid", uid).Msg("could not remove the push subscription")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// allowed checks that the endpoint points to one of the allowed push services
+func (wp *WebPush) allowed(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.User != nil || (u.Port() != "" && u.Port() != "443") {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range wp.c.AllowedEndpointHosts {
+		allowed = strings.ToLower(allowed)
+		if host == strings.TrimPrefix(allowed, ".") || (strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (wp *WebPush) load(uid string) ([]subscription, error) {
+	value, err := wp.store.Get(subscriptionsKey(uid))
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	var subs []subscription
+	if err := json.Unmarshal(value, &subs); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// update atomically changes the subscriptions of the user
+func (wp *WebPush) update(uid string, f func([]subscription) []subscription) error {
+	_, err := wp.store.Update(subscriptionsKey(uid), func(value []byte) ([]byte, error) {
+		var subs []subscription
+		if value != nil {
+			if err := json.Unmarshal(value, &subs); err != nil {
+				return nil, err
+			}
+		}
+
+		subs = f(subs)
+		if len(subs) == 0 {
+			return nil, nil
+		}
+		return json.Marshal(subs)
+	})
+	return err
+}
+
+func remove(subs []subscription, device string) []subscription {
+	return slices.DeleteFunc(subs, func(s subscription) bool {
+		return s.Device == device
+	})
+}
+
+func subscriptionsKey(uid string) string {
+	return "subscriptions/" + uid
+}
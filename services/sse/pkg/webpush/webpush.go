@@ -0,0 +1,295 @@
+// Package webpush sends encrypted Web Push messages (RFC 8030, RFC 8291) authenticated with VAPID (RFC 8292).
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// MaxPayloadSize is the maximum size of a payload. Push services accept at least 4096 bytes
+	// including the header, the padding delimiter and the authentication tag of the encryption.
+	MaxPayloadSize = 4096 - headerSize - 1 - tagSize
+
+	recordSize = 4096
+	saltSize   = 16
+	keySize    = 65
+	authSize   = 16
+	tagSize    = 16
+	headerSize = saltSize + 4 + 1 + keySize
+)
+
+var (
+	// ErrSubscriptionGone is returned when the push service doesn't know the subscription anymore,
+	// the subscription should be removed.
+	ErrSubscriptionGone = errors.New("push subscription is gone")
+	// ErrPayloadTooLarge is returned when the payload exceeds MaxPayloadSize.
+	ErrPayloadTooLarge = errors.New("push payload is too large")
+)
+
+// Subscription is a push subscription of a browser as returned by PushSubscription.toJSON().
+type Subscription struct {
+	Endpoint string `json:"endpoint"`
+	Keys     Keys   `json:"keys"`
+}
+
+// Keys are the keys of a push subscription used to encrypt the messages.
+type Keys struct {
+	P256dh string `json:"p256dh"`
+	Auth   string `json:"auth"`
+}
+
+// Validate checks that the subscription has an absolute https endpoint and valid keys.
+func (s Subscription) Validate() error {
+	u, err := url.Parse(s.Endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return errors.New("the endpoint must be an absolute https url")
+	}
+	if _, _, err := s.keys(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s Subscription) keys() (*ecdh.PublicKey, []byte, error) {
+	p256dh, err := decode(s.Keys.P256dh)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	public, err := ecdh.P256().NewPublicKey(p256dh)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	auth, err := decode(s.Keys.Auth)
+	if err != nil || len(auth) != authSize {
+		return nil, nil, errors.New("invalid auth secret")
+	}
+	return public, auth, nil
+}
+
+// Encrypt encrypts the payload for the subscription with the "aes128gcm" content coding.
+func Encrypt(s Subscription, payload []byte) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, ErrPayloadTooLarge
+	}
+	uaPublic, auth, err := s.keys()
+	if err != nil {
+		return nil, err
+	}
+
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return encrypt(uaPublic, auth, asPrivate, salt, payload)
+}
+
+func encrypt(uaPublic *ecdh.PublicKey, auth []byte, asPrivate *ecdh.PrivateKey, salt, payload []byte) ([]byte, error) {
+	secret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+	asPublic := asPrivate.PublicKey().Bytes()
+
+	// RFC 8291 section 3.3
+	keyInfo := append(append([]byte("WebPush: info\x00"), uaPublic.Bytes()...), asPublic...)
+	ikm, err := derive(auth, secret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	// RFC 8188 section 2.2 and 2.3
+	cek, err := derive(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := derive(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	body := make([]byte, headerSize, headerSize+len(payload)+1+tagSize)
+	copy(body, salt)
+	binary.BigEndian.PutUint32(body[saltSize:], recordSize)
+	body[saltSize+4] = keySize
+	copy(body[saltSize+5:], asPublic)
+
+	// a single record, terminated by the padding delimiter of the last record
+	plaintext := append(append(make([]byte, 0, len(payload)+1), payload...), 0x02)
+	return gcm.Seal(body, nonce, plaintext, nil), nil
+}
+
+func derive(salt, secret, info []byte, size int) ([]byte, error) {
+	key := make([]byte, size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// VAPID is the identity of the application server.
+type VAPID struct {
+	key       *ecdsa.PrivateKey
+	publicKey string
+	subject   string
+}
+
+// NewVAPID returns the VAPID identity for the base64url encoded private key. The subject is a
+// "mailto:" or "https:" URI push services can use to contact the operator.
+func NewVAPID(privateKey, subject string) (*VAPID, error) {
+	d, err := decode(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+	key, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+
+	public := key.PublicKey().Bytes()
+	return &VAPID{
+		key: &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(public[1:33]),
+				Y:     new(big.Int).SetBytes(public[33:]),
+			},
+			D: new(big.Int).SetBytes(d),
+		},
+		publicKey: base64.RawURLEncoding.EncodeToString(public),
+		subject:   subject,
+	}, nil
+}
+
+// GenerateVAPIDKeys returns a new base64url encoded key pair.
+func GenerateVAPIDKeys() (privateKey, publicKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key.Bytes()), base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()), nil
+}
+
+// PublicKey returns the base64url encoded public key, browsers need it as applicationServerKey to subscribe.
+func (v *VAPID) PublicKey() string {
+	return v.publicKey
+}
+
+// authorization returns the value of the Authorization header for the push service of the endpoint
+func (v *VAPID) authorization(endpoint string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		// push services reject tokens valid for more than 24 hours
+		"exp": now.Add(12 * time.Hour).Unix(),
+		"sub": v.subject,
+	}).SignedString(v.key)
+	if err != nil {
+		return "", err
+	}
+	return "vapid t=" + token + ", k=" + v.publicKey, nil
+}
+
+// Sender sends push messages.
+type Sender struct {
+	vapid  *VAPID
+	client *http.Client
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewSender returns a sender using the VAPID identity. The push services keep undelivered messages for the ttl.
+func NewSender(vapid *VAPID, ttl, timeout time.Duration) *Sender {
+	return &Sender{
+		vapid: vapid,
+		client: &http.Client{
+			Timeout: timeout,
+			// the endpoints are provided by the clients, don't follow them anywhere else
+			CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+// Send encrypts the payload and sends it to the push service of the subscription.
+func (s *Sender) Send(ctx context.Context, sub Subscription, payload []byte) error {
+	body, err := Encrypt(sub, payload)
+	if err != nil {
+		return err
+	}
+	authorization, err := s.vapid.authorization(sub.Endpoint, s.now())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(s.ttl.Seconds())))
+	req.Header.Set("Urgency", "normal")
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return nil
+	case res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone:
+		return ErrSubscriptionGone
+	default:
+		return fmt.Errorf("unexpected status code %d from the push service", res.StatusCode)
+	}
+}
+
+// decode decodes base64url with or without padding, browsers and libraries use both
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
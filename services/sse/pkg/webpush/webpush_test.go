@@ -0,0 +1,138 @@
+package webpush
+
+import (
+	"context"
+	"crypto/ecdh"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// test vector of RFC 8291 Appendix A
+const (
+	rfcPlaintext = "When I grow up, I want to be a watermelon"
+	rfcASPrivate = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+	rfcUAPublic  = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	rfcAuth      = "BTBZMqHH6r4Tts7J_aSIgg"
+	rfcSalt      = "DGv6ra1nlYgDCS1FRnbzlw"
+	rfcBody      = "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+)
+
+func mustDecode(t *testing.T, s string) []byte {
+	b, err := decode(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestEncrypt_RFC8291(t *testing.T) {
+	asPrivate, err := ecdh.P256().NewPrivateKey(mustDecode(t, rfcASPrivate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(mustDecode(t, rfcUAPublic))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := encrypt(uaPublic, mustDecode(t, rfcAuth), asPrivate, mustDecode(t, rfcSalt), []byte(rfcPlaintext))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := base64.RawURLEncoding.EncodeToString(body); got != rfcBody {
+		t.Errorf("unexpected body %s", got)
+	}
+}
+
+func TestEncrypt_Validation(t *testing.T) {
+	sub := Subscription{Endpoint: "https://push.example.com/1", Keys: Keys{P256dh: rfcUAPublic, Auth: rfcAuth + "=="}}
+	if err := sub.Validate(); err != nil {
+		t.Errorf("expected a valid subscription, got %v", err)
+	}
+	if _, err := Encrypt(sub, make([]byte, MaxPayloadSize+1)); !errors.Is(err, ErrPayloadTooLarge) {
+		t.Errorf("expected ErrPayloadTooLarge, got %v", err)
+	}
+
+	invalid := []Subscription{
+		{Endpoint: "http://push.example.com/1", Keys: sub.Keys},
+		{Endpoint: "/1", Keys: sub.Keys},
+		{Endpoint: sub.Endpoint, Keys: Keys{P256dh: rfcAuth, Auth: rfcAuth}},
+		{Endpoint: sub.Endpoint, Keys: Keys{P256dh: rfcUAPublic, Auth: rfcUAPublic}},
+	}
+	for _, s := range invalid {
+		if err := s.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", s)
+		}
+	}
+}
+
+func TestSender_Send(t *testing.T) {
+	privateKey, publicKey, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	vapid, err := NewVAPID(privateKey, "mailto:admin@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vapid.PublicKey() != publicKey {
+		t.Fatalf("expected public key %s, got %s", publicKey, vapid.PublicKey())
+	}
+
+	var status int
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "aes128gcm" || r.Header.Get("TTL") != "3600" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		token, key, ok := strings.Cut(strings.TrimPrefix(r.Header.Get("Authorization"), "vapid t="), ", k=")
+		if !ok || key != publicKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		claims := jwt.MapClaims{}
+		_, err := jwt.ParseWithClaims(token, claims, func(_ *jwt.Token) (interface{}, error) {
+			return &vapid.key.PublicKey, nil
+		}, jwt.WithValidMethods([]string{"ES256"}), jwt.WithAudience("https://"+r.Host))
+		if err != nil || claims["sub"] != "mailto:admin@example.com" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if len(body) != headerSize+len("hello")+1+tagSize {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	sender := NewSender(vapid, time.Hour, time.Second)
+	sender.client.Transport = srv.Client().Transport
+	sub := Subscription{Endpoint: srv.URL + "/push/1", Keys: Keys{P256dh: rfcUAPublic, Auth: rfcAuth}}
+
+	status = http.StatusCreated
+	if err := sender.Send(context.Background(), sub, []byte("hello")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	status = http.StatusGone
+	if err := sender.Send(context.Background(), sub, []byte("hello")); !errors.Is(err, ErrSubscriptionGone) {
+		t.Errorf("expected ErrSubscriptionGone, got %v", err)
+	}
+
+	status = http.StatusInternalServerError
+	if err := sender.Send(context.Background(), sub, []byte("hello")); err == nil || errors.Is(err, ErrSubscriptionGone) {
+		t.Errorf("expected an error, got %v", err)
+	}
+}
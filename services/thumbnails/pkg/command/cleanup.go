@@ -0,0 +1,82 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config/parser"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/logging"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/thumbnail/storage"
+)
+
+// Cleanup is the entrypoint for the cleanup command.
+func Cleanup(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:     "cleanup",
+		Usage:    "evict thumbnails from the storage, the least recently used thumbnails are evicted first",
+		Category: "storage management",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "max-size",
+				Usage: "the maximum total size of the thumbnails, for example 20GB. Defaults to THUMBNAILS_CLEANUP_MAX_SIZE.",
+			},
+			&cli.DurationFlag{
+				Name:  "max-age",
+				Usage: "evict the thumbnails which were not used for the given duration, for example 720h. Defaults to THUMBNAILS_CLEANUP_MAX_AGE.",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "only report the thumbnails which would be evicted",
+			},
+		},
+		Before: func(_ *cli.Context) error {
+			return configlog.ReturnFatal(parser.ParseConfig(cfg))
+		},
+		Action: func(c *cli.Context) error {
+			logger := logging.Configure(cfg.Service.Name, cfg.Log)
+
+			if c.IsSet("max-size") {
+				cfg.Thumbnail.Cleanup.MaxSize = c.String("max-size")
+			}
+			if c.IsSet("max-age") {
+				cfg.Thumbnail.Cleanup.MaxAge = c.Duration("max-age")
+			}
+
+			policy, err := storage.NewCleanupPolicy(cfg.Thumbnail.Cleanup)
+			if err != nil {
+				return err
+			}
+			if policy.MaxSize == 0 && policy.MaxAge == 0 {
+				return fmt.Errorf("either --max-size or --max-age is required")
+			}
+			policy.DryRun = c.Bool("dry-run")
+
+			thumbnailStorage, err := storage.New(cfg.Thumbnail, logger)
+			if err != nil {
+				return err
+			}
+
+			start := time.Now()
+			report, err := storage.Cleanup(thumbnailStorage, policy, logger)
+			if err != nil {
+				return err
+			}
+
+			action := "Evicted"
+			if policy.DryRun {
+				action = "Would evict"
+			}
+			fmt.Printf("Checked %d thumbnails (%d bytes) in %s. %s %d thumbnails (%d bytes).\n",
+				report.Checked, report.TotalSize, time.Since(start).Round(time.Millisecond),
+				action, report.Evicted, report.EvictedSize)
+			if report.Failed > 0 {
+				return fmt.Errorf("%d thumbnails could not be evicted", report.Failed)
+			}
+			return nil
+		},
+	}
+}
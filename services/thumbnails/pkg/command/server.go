@@ -6,6 +6,7 @@ import (
 
 	"github.com/oklog/run"
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
 	ogrpc "github.com/owncloud/ocis/v2/ocis-pkg/service/grpc"
 	"github.com/owncloud/ocis/v2/ocis-pkg/tracing"
 	"github.com/owncloud/ocis/v2/ocis-pkg/version"
@@ -16,6 +17,7 @@ import (
 	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/server/debug"
 	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/server/grpc"
 	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/server/http"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/thumbnail/storage"
 	"github.com/urfave/cli/v2"
 )
 
@@ -126,6 +128,38 @@ func Server(cfg *config.Config) *cli.Command {
 				cancel()
 			})
 
+			if cfg.Thumbnail.Cleanup.Interval > 0 {
+				policy, err := storage.NewCleanupPolicy(cfg.Thumbnail.Cleanup)
+				if err != nil {
+					return err
+				}
+				thumbnailStorage, err := storage.New(cfg.Thumbnail, logger)
+				if err != nil {
+					return err
+				}
+
+				// only a bucket is shared by the instances, a local directory is cleaned up by every instance
+				lockStore := "memory"
+				if cfg.Thumbnail.StorageType == "s3" {
+					lockStore = cfg.Store.Store
+				}
+				locks := kv.New(kv.Options{
+					Type:                 lockStore,
+					Nodes:                cfg.Store.Nodes,
+					Bucket:               cfg.Store.Database + "-locks",
+					Username:             cfg.Store.AuthUsername,
+					Password:             cfg.Store.AuthPassword,
+					EnableTLS:            cfg.Store.EnableTLS,
+					TLSInsecure:          cfg.Store.TLSInsecure,
+					TLSRootCACertificate: cfg.Store.TLSRootCACertificate,
+				})
+
+				janitor := storage.NewJanitor(thumbnailStorage, policy, cfg.Thumbnail.Cleanup.Interval, locks, logger)
+				gr.Add(janitor.Run, func(_ error) {
+					janitor.Stop()
+				})
+			}
+
 			return gr.Run()
 		},
 	}
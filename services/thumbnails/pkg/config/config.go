@@ -3,6 +3,7 @@ package config
 
 import (
 	"context"
+	"time"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
 	"go-micro.dev/v4/client"
@@ -25,19 +26,62 @@ type Config struct {
 	GrpcClient    client.Client         `yaml:"-"`
 
 	Thumbnail Thumbnail `yaml:"thumbnail"`
+	Store     Store     `yaml:"store"`
 
 	Context context.Context `yaml:"-"`
 }
 
+// Store configures the store electing the instance which cleans up a shared thumbnail storage
+type Store struct {
+	Store                string   `yaml:"store" env:"OCIS_PERSISTENT_STORE;THUMBNAILS_STORE" desc:"The type of the store. Supported values are: 'memory' and 'nats-js-kv'. Only 'nats-js-kv' is shared by all instances, with 'memory' every instance cleans up a shared S3 storage. See the text description for details." introductionVersion:"7.1"`
+	Nodes                []string `yaml:"nodes" env:"OCIS_PERSISTENT_STORE_NODES;THUMBNAILS_STORE_NODES" desc:"A list of nodes to access the configured store. This has no effect when 'memory' store is configured. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	Database             string   `yaml:"database" env:"THUMBNAILS_STORE_DATABASE" desc:"The database name the configured store should use." introductionVersion:"7.1"`
+	AuthUsername         string   `yaml:"username" env:"OCIS_PERSISTENT_STORE_AUTH_USERNAME;THUMBNAILS_STORE_AUTH_USERNAME" desc:"The username to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"7.1"`
+	AuthPassword         string   `yaml:"password" env:"OCIS_PERSISTENT_STORE_AUTH_PASSWORD;THUMBNAILS_STORE_AUTH_PASSWORD" desc:"The password to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"7.1"`
+	EnableTLS            bool     `yaml:"enable_tls" env:"OCIS_EVENTS_ENABLE_TLS;THUMBNAILS_STORE_ENABLE_TLS" desc:"Enable TLS for the connection to the store. The store is served by the same NATS servers as the events." introductionVersion:"7.1"`
+	TLSInsecure          bool     `yaml:"tls_insecure" env:"OCIS_INSECURE;THUMBNAILS_STORE_TLS_INSECURE" desc:"Whether to verify the server TLS certificates of the store." introductionVersion:"7.1"`
+	TLSRootCACertificate string   `yaml:"tls_root_ca_certificate" env:"OCIS_EVENTS_TLS_ROOT_CA_CERTIFICATE;THUMBNAILS_STORE_TLS_ROOT_CA_CERTIFICATE" desc:"The root CA certificate used to validate the TLS certificate of the store. If provided THUMBNAILS_STORE_TLS_INSECURE will be seen as false." introductionVersion:"7.1"`
+}
+
 // FileSystemStorage defines the available filesystem storage configuration.
 type FileSystemStorage struct {
 	RootDirectory string `yaml:"root_directory" env:"THUMBNAILS_FILESYSTEMSTORAGE_ROOT" desc:"The directory where the filesystem storage will store the thumbnails. If not defined, the root directory derives from $OCIS_BASE_DATA_PATH/thumbnails." introductionVersion:"pre5.0"`
 }
 
+// S3Storage defines the available S3 storage configuration.
+type S3Storage struct {
+	Endpoint  string `yaml:"endpoint" env:"THUMBNAILS_S3STORAGE_ENDPOINT" desc:"The endpoint of the S3 compatible storage, for example 'https://s3.example.com'. Plain HTTP is used when the scheme is 'http'." introductionVersion:"7.1"`
+	Region    string `yaml:"region" env:"THUMBNAILS_S3STORAGE_REGION" desc:"The region of the S3 bucket." introductionVersion:"7.1"`
+	Bucket    string `yaml:"bucket" env:"THUMBNAILS_S3STORAGE_BUCKET" desc:"The name of the S3 bucket the thumbnails are stored in. The bucket must exist." introductionVersion:"7.1"`
+	AccessKey string `yaml:"access_key" env:"THUMBNAILS_S3STORAGE_ACCESS_KEY" desc:"The access key of the S3 storage." introductionVersion:"7.1"`
+	SecretKey string `yaml:"secret_key" env:"THUMBNAILS_S3STORAGE_SECRET_KEY" desc:"The secret key of the S3 storage." introductionVersion:"7.1"`
+	Prefix    string `yaml:"prefix" env:"THUMBNAILS_S3STORAGE_PREFIX" desc:"A prefix for the keys of the thumbnails. Allows to share a bucket with other applications." introductionVersion:"7.1"`
+}
+
+// Cleanup defines the available configuration for the eviction of thumbnails.
+type Cleanup struct {
+	Interval time.Duration `yaml:"interval" env:"THUMBNAILS_CLEANUP_INTERVAL" desc:"The interval in which the thumbnail storage is cleaned up in the background. Set to 0 to disable the background cleanup. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	MaxSize  string        `yaml:"max_size" env:"THUMBNAILS_CLEANUP_MAX_SIZE" desc:"The maximum total size of the stored thumbnails. The least recently used thumbnails are deleted when the size is exceeded. Usable common abbreviations: [KB, KiB, MB, MiB, GB, GiB, TB, TiB, PB, PiB, EB, EiB], example: 20GB. Leave empty to not limit the size." introductionVersion:"7.1"`
+	MaxAge   time.Duration `yaml:"max_age" env:"THUMBNAILS_CLEANUP_MAX_AGE" desc:"The time after which thumbnails which were not used are deleted. Set to 0 to keep unused thumbnails. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+}
+
+// Renderers defines the available configuration of the renderers for documents and videos.
+type Renderers struct {
+	Timeout          time.Duration `yaml:"timeout" env:"THUMBNAILS_RENDERER_TIMEOUT" desc:"The maximum time a renderer may take to render a document or video. Must be greater than 0 if a renderer is configured. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	PDFCommand       string        `yaml:"pdf_command" env:"THUMBNAILS_PDF_RENDERER_COMMAND" desc:"The path to the pdftoppm command of poppler which renders the first page of PDF files. Leave empty to not render PDF files." introductionVersion:"7.1"`
+	VideoCommand     string        `yaml:"video_command" env:"THUMBNAILS_VIDEO_RENDERER_COMMAND" desc:"The path to the ffmpeg command which takes a frame from video files. Leave empty to not render video files." introductionVersion:"7.1"`
+	VideoFrameOffset time.Duration `yaml:"video_frame_offset" env:"THUMBNAILS_VIDEO_FRAME_OFFSET" desc:"The offset of the frame which is taken from video files. The first frame is taken from shorter videos. See the Environment Variable Types description for more details." introductionVersion:"7.1"`
+	OfficeEndpoint   string        `yaml:"office_endpoint" env:"THUMBNAILS_OFFICE_CONVERTER_ENDPOINT" desc:"The URL of a converter which converts office documents to an image or a PDF file, for example 'https://collabora.example.com/cool/convert-to/png'. The document is posted as multipart form field 'data'. PDF files require THUMBNAILS_PDF_RENDERER_COMMAND. Leave empty to not render office documents." introductionVersion:"7.1"`
+}
+
 // Thumbnail defines the available thumbnail related configuration.
 type Thumbnail struct {
 	Resolutions           []string          `yaml:"resolutions" env:"THUMBNAILS_RESOLUTIONS" desc:"The supported list of target resolutions in the format WidthxHeight like 32x32. You can define any resolution as required. See the Environment Variable Types description for more details." introductionVersion:"pre5.0"`
+	StorageType           string            `yaml:"storage_type" env:"THUMBNAILS_STORAGE_TYPE" desc:"The storage the thumbnails are stored in. Supported values are 'filesystem' and 's3'." introductionVersion:"7.1"`
 	FileSystemStorage     FileSystemStorage `yaml:"filesystem_storage"`
+	S3Storage             S3Storage         `yaml:"s3_storage"`
+	Cleanup               Cleanup           `yaml:"cleanup"`
+	Renderers             Renderers         `yaml:"renderers"`
 	WebdavAllowInsecure   bool              `yaml:"webdav_allow_insecure" env:"OCIS_INSECURE;THUMBNAILS_WEBDAVSOURCE_INSECURE" desc:"Ignore untrusted SSL certificates when connecting to the webdav source." introductionVersion:"pre5.0"`
 	CS3AllowInsecure      bool              `yaml:"cs3_allow_insecure" env:"OCIS_INSECURE;THUMBNAILS_CS3SOURCE_INSECURE" desc:"Ignore untrusted SSL certificates when connecting to the CS3 source." introductionVersion:"pre5.0"`
 	RevaGateway           string            `yaml:"reva_gateway" env:"OCIS_REVA_GATEWAY" desc:"CS3 gateway used to look up user metadata" introductionVersion:"pre5.0"`
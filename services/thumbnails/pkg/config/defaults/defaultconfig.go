@@ -3,6 +3,7 @@ package defaults
 import (
 	"path"
 	"strings"
+	"time"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/defaults"
 	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
@@ -46,11 +47,20 @@ func DefaultConfig() *config.Config {
 		Service: config.Service{
 			Name: "thumbnails",
 		},
+		Store: config.Store{
+			Store:    "nats-js-kv",
+			Nodes:    []string{"127.0.0.1:9233"},
+			Database: "thumbnails",
+		},
 		Thumbnail: config.Thumbnail{
 			Resolutions: []string{"16x16", "32x32", "64x64", "128x128", "1080x1920", "1920x1080", "2160x3840", "3840x2160", "4320x7680", "7680x4320"},
+			StorageType: "filesystem",
 			FileSystemStorage: config.FileSystemStorage{
 				RootDirectory: path.Join(defaults.BaseDataPath(), "thumbnails"),
 			},
+			S3Storage: config.S3Storage{
+				Region: "default",
+			},
 			WebdavAllowInsecure:   false,
 			RevaGateway:           shared.DefaultRevaConfig().Address,
 			CS3AllowInsecure:      false,
@@ -58,6 +68,10 @@ func DefaultConfig() *config.Config {
 			MaxInputWidth:         7680,
 			MaxInputHeight:        7680,
 			MaxInputImageFileSize: "50MB",
+			Renderers: config.Renderers{
+				Timeout:          30 * time.Second,
+				VideoFrameOffset: time.Second,
+			},
 		},
 	}
 }
@@ -34,6 +34,10 @@ func ParseConfig(cfg *config.Config) error {
 }
 
 // Validate can validate the configuration
-func Validate(_ *config.Config) error {
+func Validate(cfg *config.Config) error {
+	r := cfg.Thumbnail.Renderers
+	if (r.PDFCommand != "" || r.VideoCommand != "" || r.OfficeEndpoint != "") && r.Timeout <= 0 {
+		return errors.New("the renderer timeout must be greater than 0 if a renderer is configured")
+	}
 	return nil
 }
@@ -279,6 +279,12 @@ func ForType(mimeType string, opts map[string]interface{}) FileConverter {
 	// We can ignore the error here because we parse it in IsMimeTypeSupported before and if it fails
 	// return the service call. So we should only get here when the mimeType parses fine.
 	mimeType, _, _ = mime.ParseMediaType(mimeType)
+	if renderers, ok := opts["renderers"].(Renderers); ok {
+		if renderer := renderers.ForType(mimeType); renderer != nil {
+			return renderer
+		}
+	}
+
 	switch mimeType {
 	case "text/plain":
 		fontFileMap := ""
@@ -0,0 +1,355 @@
+package preprocessor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cs3org/reva/v2/pkg/bytesize"
+	"github.com/pkg/errors"
+
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config"
+)
+
+const (
+	// _renderSize is the maximum width and height of the images rendered from documents and videos
+	_renderSize = 1920
+	// _maxRenderedImageSize is the maximum size of an image produced by a renderer
+	_maxRenderedImageSize = 64 << 20
+)
+
+var (
+	// PDFMimeTypes are the mime types rendered by the PDFRenderer
+	PDFMimeTypes = []string{"application/pdf"}
+
+	// VideoMimeTypes are the mime types rendered by the VideoRenderer
+	VideoMimeTypes = []string{
+		"video/mp4",
+		"video/quicktime",
+		"video/webm",
+		"video/x-matroska",
+		"video/x-msvideo",
+		"video/mpeg",
+		"video/ogg",
+		"video/3gpp",
+	}
+
+	// _videoDemuxers are the ffmpeg demuxers of the VideoMimeTypes. The demuxer is set explicitly to prevent
+	// ffmpeg from probing playlist formats like hls, which read the files and urls referenced in them.
+	_videoDemuxers = map[string]string{
+		"video/mp4":        "mov",
+		"video/quicktime":  "mov",
+		"video/3gpp":       "mov",
+		"video/webm":       "matroska",
+		"video/x-matroska": "matroska",
+		"video/x-msvideo":  "avi",
+		"video/mpeg":       "mpeg",
+		"video/ogg":        "ogg",
+	}
+
+	// OfficeMimeTypes are the mime types rendered by the OfficeRenderer
+	OfficeMimeTypes = []string{
+		"application/msword",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		"application/vnd.oasis.opendocument.text",
+		"application/rtf",
+		"application/vnd.ms-excel",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		"application/vnd.oasis.opendocument.spreadsheet",
+		"application/vnd.ms-powerpoint",
+		"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+		"application/vnd.oasis.opendocument.presentation",
+	}
+)
+
+// Renderers holds the configured renderers for documents and videos. Renderers which are not
+// configured are nil, the files they would render are not supported.
+type Renderers struct {
+	PDF    *PDFRenderer
+	Video  *VideoRenderer
+	Office *OfficeRenderer
+}
+
+// NewRenderers creates the renderers configured in the given thumbnail configuration. The renderers
+// refuse source files larger than the given maximum input size.
+func NewRenderers(cfg config.Renderers, b bytesize.ByteSize) Renderers {
+	var r Renderers
+	maxInputSize := int64(b.Bytes())
+	if cfg.PDFCommand != "" {
+		r.PDF = &PDFRenderer{command: cfg.PDFCommand, timeout: cfg.Timeout, maxInputSize: maxInputSize}
+	}
+	if cfg.VideoCommand != "" {
+		r.Video = &VideoRenderer{command: cfg.VideoCommand, offset: cfg.VideoFrameOffset, timeout: cfg.Timeout, maxInputSize: maxInputSize}
+	}
+	if cfg.OfficeEndpoint != "" {
+		r.Office = &OfficeRenderer{
+			endpoint:     cfg.OfficeEndpoint,
+			client:       &http.Client{Timeout: cfg.Timeout},
+			pdf:          r.PDF,
+			maxInputSize: maxInputSize,
+		}
+	}
+	return r
+}
+
+// ForType returns the renderer for the given mime type or nil if no renderer is configured for it.
+func (r Renderers) ForType(mimeType string) FileConverter {
+	mimeType, _, _ = mime.ParseMediaType(mimeType)
+	switch {
+	case r.PDF != nil && slices.Contains(PDFMimeTypes, mimeType):
+		return r.PDF
+	case r.Video != nil && slices.Contains(VideoMimeTypes, mimeType):
+		v := *r.Video
+		v.format = _videoDemuxers[mimeType]
+		return &v
+	case r.Office != nil && slices.Contains(OfficeMimeTypes, mimeType):
+		return r.Office
+	default:
+		return nil
+	}
+}
+
+// Supports returns if a renderer is configured for the given mime type.
+func (r Renderers) Supports(mimeType string) bool {
+	return r.ForType(mimeType) != nil
+}
+
+// PDFRenderer renders the first page of pdf files with pdftoppm.
+type PDFRenderer struct {
+	command      string
+	timeout      time.Duration
+	maxInputSize int64
+}
+
+// Convert renders the first page of the pdf file and returns it as thumbnail image
+func (p PDFRenderer) Convert(r io.Reader) (interface{}, error) {
+	return runRenderer(r, p.maxInputSize, p.timeout, func(input string) []string {
+		return []string{p.command,
+			"-png", "-singlefile",
+			"-f", "1", "-l", "1",
+			"-scale-to", strconv.Itoa(_renderSize),
+			input,
+		}
+	})
+}
+
+// VideoRenderer takes a frame of video files with ffmpeg. The video is only read from the local
+// source file with the demuxer of its mime type, see Renderers.ForType.
+type VideoRenderer struct {
+	command      string
+	offset       time.Duration
+	timeout      time.Duration
+	maxInputSize int64
+	format       string
+}
+
+// Convert takes the frame at the configured offset, or the first frame for shorter videos, and returns it as thumbnail image
+func (v VideoRenderer) Convert(r io.Reader) (interface{}, error) {
+	if v.format == "" {
+		return nil, errors.New("the video format is not supported")
+	}
+
+	args := func(offset time.Duration) func(string) []string {
+		return func(input string) []string {
+			return []string{v.command,
+				"-hide_banner", "-loglevel", "error", "-nostdin",
+				"-threads", "1",
+				"-protocol_whitelist", "file",
+				"-ss", strconv.FormatFloat(offset.Seconds(), 'f', 3, 64),
+				"-f", v.format,
+				"-i", "file:" + input,
+				"-frames:v", "1",
+				"-vf", fmt.Sprintf("scale=w=min(iw\\,%d):h=min(ih\\,%d):force_original_aspect_ratio=decrease", _renderSize, _renderSize),
+				"-f", "image2pipe", "-vcodec", "png",
+				"pipe:1",
+			}
+		}
+	}
+
+	input, cleanup, err := tempInput(r, v.maxInputSize)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	img, err := runRendererOnFile(input, v.timeout, args(v.offset))
+	if errors.Is(err, errNoOutput) && v.offset > 0 {
+		// the video is shorter than the offset
+		return runRendererOnFile(input, v.timeout, args(0))
+	}
+	return img, err
+}
+
+// OfficeRenderer converts office documents with a converter endpoint. The document is posted as
+// multipart form field "data", the endpoint responds with an image or a pdf file. The pdf is rendered
+// with the PDFRenderer.
+type OfficeRenderer struct {
+	endpoint     string
+	client       *http.Client
+	pdf          *PDFRenderer
+	maxInputSize int64
+}
+
+// Convert converts the office document and returns the thumbnail image
+func (o OfficeRenderer) Convert(r io.Reader) (interface{}, error) {
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	part, err := form.CreateFormFile("data", "document")
+	if err != nil {
+		return nil, err
+	}
+	if err := copyInput(part, r, o.maxInputSize); err != nil {
+		return nil, err
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	res, err := o.client.Post(o.endpoint, form.FormDataContentType(), body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert the document")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not convert the document, the converter responded with status code %d", res.StatusCode)
+	}
+
+	converted, err := readLimited(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	if mimeType == "application/pdf" || bytes.HasPrefix(converted, []byte("%PDF-")) {
+		if o.pdf == nil {
+			return nil, errors.New("the converter responded with a pdf file but no pdf renderer is configured")
+		}
+		return o.pdf.Convert(bytes.NewReader(converted))
+	}
+
+	return ImageDecoder{}.Convert(bytes.NewReader(converted))
+}
+
+var (
+	errNoOutput      = errors.New("the renderer produced no image")
+	errInputTooLarge = errors.New("the source file exceeds the maximum input size")
+)
+
+// runRenderer writes the input to a temporary file and runs the renderer command on it
+func runRenderer(r io.Reader, maxInputSize int64, timeout time.Duration, args func(input string) []string) (interface{}, error) {
+	input, cleanup, err := tempInput(r, maxInputSize)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	return runRendererOnFile(input, timeout, args)
+}
+
+// runRendererOnFile runs the renderer command which writes a png image to stdout. The command is killed when it
+// exceeds the timeout or the maximum output size.
+func runRendererOnFile(input string, timeout time.Duration, args func(input string) []string) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	a := args(input)
+	cmd := exec.CommandContext(ctx, a[0], a[1:]...)
+	// don't wait for children of the command which keep the output open
+	cmd.WaitDelay = time.Second
+	stdout := &limitedBuffer{limit: _maxRenderedImageSize}
+	stderr := &limitedBuffer{limit: 4096, discard: true}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	switch {
+	case ctx.Err() != nil:
+		return nil, errors.Wrapf(ctx.Err(), "%s did not finish in time", a[0])
+	case stdout.exceeded:
+		return nil, fmt.Errorf("%s exceeded the maximum output size", a[0])
+	case err != nil:
+		return nil, errors.Wrapf(err, "%s failed: %s", a[0], strings.TrimSpace(stderr.String()))
+	case stdout.Len() == 0:
+		return nil, errNoOutput
+	}
+
+	return ImageDecoder{}.Convert(stdout)
+}
+
+// tempInput writes the input to a temporary file, renderers need random access to the files
+func tempInput(r io.Reader, maxInputSize int64) (string, func(), error) {
+	f, err := os.CreateTemp("", "thumbnail-source-")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "could not create a temporary file")
+	}
+	cleanup := func() {
+		_ = os.Remove(f.Name())
+	}
+
+	err = copyInput(f, r, maxInputSize)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, errors.Wrap(err, "could not write the temporary file")
+	}
+	return f.Name(), cleanup, nil
+}
+
+// copyInput copies the input and fails if it exceeds the maximum input size, the size of the source
+// file is also checked by the sources but it is not trusted here.
+func copyInput(w io.Writer, r io.Reader, maxInputSize int64) error {
+	n, err := io.Copy(w, io.LimitReader(r, maxInputSize+1))
+	switch {
+	case err != nil:
+		return err
+	case n > maxInputSize:
+		return errInputTooLarge
+	}
+	return nil
+}
+
+func readLimited(r io.Reader) ([]byte, error) {
+	b, err := io.ReadAll(io.LimitReader(r, _maxRenderedImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > _maxRenderedImageSize {
+		return nil, errors.New("the converted document exceeds the maximum size")
+	}
+	return b, nil
+}
+
+// limitedBuffer is a buffer which fails the writes beyond the limit or, if discard is set, ignores them
+type limitedBuffer struct {
+	bytes.Buffer
+	limit    int
+	discard  bool
+	exceeded bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.Len(); len(p) > remaining {
+		b.exceeded = true
+		if remaining > 0 {
+			b.Buffer.Write(p[:remaining])
+		}
+		if b.discard {
+			return len(p), nil
+		}
+		return 0, errors.New("output limit exceeded")
+	}
+	return b.Buffer.Write(p)
+}
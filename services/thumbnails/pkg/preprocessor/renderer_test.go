@@ -0,0 +1,191 @@
+package preprocessor
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config"
+)
+
+// _maxInputSize is the maximum size of the source files in the tests
+const _maxInputSize = 1 << 20
+
+// fakeCommand writes a shell script which records its arguments and runs the given script
+func fakeCommand(dir, script string) string {
+	cmd := filepath.Join(dir, "renderer")
+	content := fmt.Sprintf("#!/bin/sh\necho \"$@\" >> %s/args\n%s\n", dir, script)
+	Expect(os.WriteFile(cmd, []byte(content), 0700)).To(Succeed())
+	return cmd
+}
+
+var _ = Describe("Renderers", func() {
+	var (
+		dir string
+		png string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "renderer")
+		Expect(err).ToNot(HaveOccurred())
+		png, err = filepath.Abs("test_assets/noise.png")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	args := func() string {
+		b, err := os.ReadFile(filepath.Join(dir, "args"))
+		Expect(err).ToNot(HaveOccurred())
+		return string(b)
+	}
+
+	Describe("NewRenderers", func() {
+		It("only supports the configured renderers", func() {
+			r := NewRenderers(config.Renderers{PDFCommand: "pdftoppm", Timeout: time.Second}, _maxInputSize)
+			Expect(r.Supports("application/pdf")).To(BeTrue())
+			Expect(r.Supports("video/mp4")).To(BeFalse())
+			Expect(r.Supports("application/vnd.oasis.opendocument.text")).To(BeFalse())
+
+			Expect(NewRenderers(config.Renderers{}, _maxInputSize).Supports("application/pdf")).To(BeFalse())
+		})
+
+		It("is used by ForType", func() {
+			r := NewRenderers(config.Renderers{VideoCommand: "ffmpeg", Timeout: time.Second}, _maxInputSize)
+			Expect(ForType("video/mp4", map[string]interface{}{"renderers": r})).To(BeAssignableToTypeOf(&VideoRenderer{}))
+			Expect(ForType("video/mp4", nil)).To(BeAssignableToTypeOf(ImageDecoder{}))
+		})
+	})
+
+	Describe("PDFRenderer", func() {
+		It("renders the first page", func() {
+			r := NewRenderers(config.Renderers{PDFCommand: fakeCommand(dir, "cat "+png), Timeout: 5 * time.Second}, _maxInputSize)
+
+			img, err := r.PDF.Convert(strings.NewReader("%PDF-1.7"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(img).ToNot(BeNil())
+			Expect(args()).To(ContainSubstring("-png -singlefile -f 1 -l 1 -scale-to 1920"))
+		})
+
+		It("kills the renderer after the timeout", func() {
+			r := NewRenderers(config.Renderers{PDFCommand: fakeCommand(dir, "sleep 5"), Timeout: 100 * time.Millisecond}, _maxInputSize)
+
+			start := time.Now()
+			_, err := r.PDF.Convert(strings.NewReader("%PDF-1.7"))
+			Expect(err).To(MatchError(ContainSubstring("did not finish in time")))
+			Expect(time.Since(start)).To(BeNumerically("<", 3*time.Second))
+		})
+
+		It("fails when the renderer fails", func() {
+			r := NewRenderers(config.Renderers{PDFCommand: fakeCommand(dir, "echo broken >&2; exit 1"), Timeout: 5 * time.Second}, _maxInputSize)
+
+			_, err := r.PDF.Convert(strings.NewReader("%PDF-1.7"))
+			Expect(err).To(MatchError(ContainSubstring("broken")))
+		})
+
+		It("refuses source files exceeding the maximum input size", func() {
+			r := NewRenderers(config.Renderers{PDFCommand: fakeCommand(dir, "cat "+png), Timeout: 5 * time.Second}, 4)
+
+			_, err := r.PDF.Convert(strings.NewReader("%PDF-1.7"))
+			Expect(err).To(MatchError(ContainSubstring("maximum input size")))
+			_, err = os.Stat(filepath.Join(dir, "args"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+
+	Describe("VideoRenderer", func() {
+		It("takes the frame at the offset", func() {
+			r := NewRenderers(config.Renderers{VideoCommand: fakeCommand(dir, "cat "+png), VideoFrameOffset: 2 * time.Second, Timeout: 5 * time.Second}, _maxInputSize)
+
+			img, err := r.ForType("video/mp4").Convert(strings.NewReader("video"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(img).ToNot(BeNil())
+			Expect(args()).To(ContainSubstring("-ss 2.000"))
+		})
+
+		It("only reads the local file with the demuxer of the mime type", func() {
+			r := NewRenderers(config.Renderers{VideoCommand: fakeCommand(dir, "cat "+png), Timeout: 5 * time.Second}, _maxInputSize)
+
+			_, err := r.ForType("video/webm").Convert(strings.NewReader("video"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args()).To(ContainSubstring("-protocol_whitelist file"))
+			Expect(args()).To(ContainSubstring("-f matroska -i file:/"))
+
+			_, err = r.Video.Convert(strings.NewReader("video"))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("takes the first frame of shorter videos", func() {
+			// no frame is written for the offset
+			script := fmt.Sprintf("case \"$*\" in *'-ss 0.000'*) cat %s;; esac", png)
+			r := NewRenderers(config.Renderers{VideoCommand: fakeCommand(dir, script), VideoFrameOffset: time.Minute, Timeout: 5 * time.Second}, _maxInputSize)
+
+			img, err := r.ForType("video/mp4").Convert(strings.NewReader("video"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(img).ToNot(BeNil())
+			Expect(args()).To(ContainSubstring("-ss 60.000"))
+		})
+	})
+
+	Describe("OfficeRenderer", func() {
+		var (
+			contentType string
+			body        []byte
+			received    []byte
+			srv         *httptest.Server
+		)
+
+		BeforeEach(func() {
+			srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				f, _, err := r.FormFile("data")
+				Expect(err).ToNot(HaveOccurred())
+				received, _ = io.ReadAll(f)
+
+				w.Header().Set("Content-Type", contentType)
+				_, _ = w.Write(body)
+			}))
+		})
+
+		AfterEach(func() {
+			srv.Close()
+		})
+
+		It("decodes the converted image", func() {
+			var err error
+			contentType = "image/png"
+			body, err = os.ReadFile(png)
+			Expect(err).ToNot(HaveOccurred())
+			r := NewRenderers(config.Renderers{OfficeEndpoint: srv.URL, Timeout: 5 * time.Second}, _maxInputSize)
+
+			img, err := r.Office.Convert(bytes.NewReader([]byte("document")))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(img).ToNot(BeNil())
+			Expect(received).To(Equal([]byte("document")))
+		})
+
+		It("renders converted pdf files", func() {
+			contentType = "application/pdf"
+			body = []byte("%PDF-1.7")
+			r := NewRenderers(config.Renderers{OfficeEndpoint: srv.URL, PDFCommand: fakeCommand(dir, "cat "+png), Timeout: 5 * time.Second}, _maxInputSize)
+
+			img, err := r.Office.Convert(bytes.NewReader([]byte("document")))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(img).ToNot(BeNil())
+
+			_, err = NewRenderers(config.Renderers{OfficeEndpoint: srv.URL, Timeout: 5 * time.Second}, _maxInputSize).Office.Convert(bytes.NewReader([]byte("document")))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -63,18 +63,19 @@ func NewService(opts ...Option) grpc.Service {
 		return grpc.Service{}
 	}
 
+	thumbnailStorage, err := storage.New(tconf, options.Logger)
+	if err != nil {
+		options.Logger.Error().Err(err).Msg("could not initialize the thumbnail storage")
+		return grpc.Service{}
+	}
+
 	var thumbnail decorators.DecoratedService
 	{
 		thumbnail = svc.NewService(
 			svc.Config(options.Config),
 			svc.Logger(options.Logger),
 			svc.ThumbnailSource(imgsource.NewWebDavSource(tconf, b)),
-			svc.ThumbnailStorage(
-				storage.NewFileSystemStorage(
-					tconf.FileSystemStorage,
-					options.Logger,
-				),
-			),
+			svc.ThumbnailStorage(thumbnailStorage),
 			svc.CS3Source(imgsource.NewCS3Source(tconf, gatewaySelector, b)),
 			svc.GatewaySelector(gatewaySelector),
 		)
@@ -11,6 +11,7 @@ import (
 	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
 	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
 	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/bytesize"
 	revactx "github.com/cs3org/reva/v2/pkg/ctx"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	"github.com/cs3org/reva/v2/pkg/storagespace"
@@ -38,6 +39,10 @@ func NewService(opts ...Option) decorators.DecoratedService {
 	if err != nil {
 		logger.Fatal().Err(err).Msg("resolutions not configured correctly")
 	}
+	maxInputSize, err := bytesize.Parse(options.Config.Thumbnail.MaxInputImageFileSize)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("max input image file size not configured correctly")
+	}
 	svc := Thumbnail{
 		serviceID: options.Config.GRPC.Namespace + "." + options.Config.Service.Name,
 		manager: thumbnail.NewSimpleManager(
@@ -53,6 +58,7 @@ func NewService(opts ...Option) decorators.DecoratedService {
 		selector:     options.GatewaySelector,
 		preprocessorOpts: PreprocessorOpts{
 			TxtFontFileMap: options.Config.Thumbnail.FontMapFile,
+			Renderers:      preprocessor.NewRenderers(options.Config.Thumbnail.Renderers, maxInputSize),
 		},
 		dataEndpoint:   options.Config.Thumbnail.DataEndpoint,
 		transferSecret: options.Config.Thumbnail.TransferSecret,
@@ -77,6 +83,7 @@ type Thumbnail struct {
 // PreprocessorOpts holds the options for the preprocessor
 type PreprocessorOpts struct {
 	TxtFontFileMap string
+	Renderers      preprocessor.Renderers
 }
 
 // GetThumbnail retrieves a thumbnail for an image
@@ -131,6 +138,10 @@ func (g Thumbnail) checkThumbnail(req *thumbnailssvc.GetThumbnailRequest, sRes *
 	if err != nil {
 		return "", tr, merrors.BadRequest(g.serviceID, err.Error())
 	}
+	// the thumbnail is generated from the source type but encoded to a modern format if the client accepts it
+	if encoder, ok := thumbnail.NegotiateEncoder(req.GetAccept(), tType); ok {
+		tr.Encoder = encoder
+	}
 
 	if key, exists := g.manager.CheckThumbnail(tr); exists {
 		return key, tr, nil
@@ -166,6 +177,7 @@ func (g Thumbnail) handleCS3Source(ctx context.Context, req *thumbnailssvc.GetTh
 	defer r.Close()
 	ppOpts := map[string]interface{}{
 		"fontFileMap": g.preprocessorOpts.TxtFontFileMap,
+		"renderers":   g.preprocessorOpts.Renderers,
 	}
 	pp := preprocessor.ForType(sRes.GetInfo().GetMimeType(), ppOpts)
 	img, err := pp.Convert(r)
@@ -258,6 +270,7 @@ func (g Thumbnail) handleWebdavSource(ctx context.Context, req *thumbnailssvc.Ge
 	defer r.Close()
 	ppOpts := map[string]interface{}{
 		"fontFileMap": g.preprocessorOpts.TxtFontFileMap,
+		"renderers":   g.preprocessorOpts.Renderers,
 	}
 	pp := preprocessor.ForType(sRes.GetInfo().GetMimeType(), ppOpts)
 	img, err := pp.Convert(r)
@@ -319,7 +332,7 @@ func (g Thumbnail) stat(path, auth string) (*provider.StatResponse, error) {
 		g.logger.Error().Msg("resource info is missing checksum")
 		return nil, merrors.NotFound(g.serviceID, "resource info is missing a checksum")
 	}
-	if !thumbnail.IsMimeTypeSupported(rsp.GetInfo().GetMimeType()) {
+	if !thumbnail.IsMimeTypeSupported(rsp.GetInfo().GetMimeType()) && !g.preprocessorOpts.Renderers.Supports(rsp.GetInfo().GetMimeType()) {
 		return nil, merrors.NotFound(g.serviceID, "Unsupported file type")
 	}
 	return rsp, nil
@@ -3,7 +3,9 @@ package svc
 import (
 	"context"
 	"fmt"
+	"mime"
 	"net/http"
+	"path"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
@@ -104,8 +106,16 @@ func (s Thumbnails) GetThumbnail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	// the key ends with the extension of the thumbnail format
+	if mimeType := mime.TypeByExtension(path.Ext(key)); mimeType != "" {
+		w.Header().Set("Content-Type", mimeType)
+	}
+	if thumbnail.Negotiable() {
+		// the thumbnail format depends on the formats accepted by the client
+		w.Header().Set("Vary", "Accept")
+	}
 	w.Header().Set("Content-Length", strconv.Itoa(len(thumbnailBytes)))
+	w.WriteHeader(http.StatusOK)
 	if _, err = w.Write(thumbnailBytes); err != nil {
 		logger.Error().
 			Err(err).
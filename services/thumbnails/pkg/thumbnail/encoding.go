@@ -15,6 +15,8 @@ const (
 	typeGif  = "gif"
 	typeGgs  = "ggs"
 	typeGgp  = "ggp"
+	typeWebp = "webp"
+	typeAvif = "avif"
 )
 
 // Encoder encodes the thumbnail to a specific format.
@@ -52,7 +54,8 @@ func (e GifEncoder) MimeType() string {
 // EncoderForType returns the encoder for a given file type
 // or nil if the type is not supported.
 func EncoderForType(fileType string) (Encoder, error) {
-	switch strings.ToLower(fileType) {
+	fileType = strings.ToLower(fileType)
+	switch fileType {
 	case typePng, typeGgs, typeGgp:
 		return PngEncoder{}, nil
 	case typeJpg, typeJpeg:
@@ -60,6 +63,9 @@ func EncoderForType(fileType string) (Encoder, error) {
 	case typeGif:
 		return GifEncoder{}, nil
 	default:
+		if e, ok := modernEncoders[fileType]; ok {
+			return e, nil
+		}
 		return nil, errors.ErrNoEncoderForType
 	}
 }
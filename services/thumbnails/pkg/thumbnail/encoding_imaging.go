@@ -54,3 +54,7 @@ func (e JpegEncoder) Types() []string {
 func (e JpegEncoder) MimeType() string {
 	return "image/jpeg"
 }
+
+// modernEncoders contains the encoders for the WebP and AVIF formats. The imaging library
+// can't encode them, the service has to be built with libvips to support them.
+var modernEncoders = map[string]Encoder{}
@@ -3,12 +3,20 @@
 package thumbnail
 
 import (
+	"bytes"
+	"image/gif"
 	"io"
 
 	"github.com/davidbyttow/govips/v2/vips"
 	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/errors"
 )
 
+// modernEncoders contains the encoders for the WebP and AVIF formats.
+var modernEncoders = map[string]Encoder{
+	typeWebp: WebpEncoder{},
+	typeAvif: AvifEncoder{},
+}
+
 // PngEncoder encodes to png
 type PngEncoder struct{}
 
@@ -64,3 +72,81 @@ func (e JpegEncoder) Types() []string {
 func (e JpegEncoder) MimeType() string {
 	return "image/jpeg"
 }
+
+// WebpEncoder encodes to webp
+type WebpEncoder struct{}
+
+// Encode encodes to webp. Animated gifs are encoded to animated webp images.
+func (e WebpEncoder) Encode(w io.Writer, img interface{}) error {
+	var m *vips.ImageRef
+	switch i := img.(type) {
+	case *vips.ImageRef:
+		m = i
+	case *gif.GIF:
+		animated, err := loadAnimation(i)
+		if err != nil {
+			return err
+		}
+		defer animated.Close()
+		m = animated
+	default:
+		return errors.ErrInvalidType
+	}
+
+	buf, _, err := m.ExportWebp(vips.NewWebpExportParams())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+// Types returns the webp suffix
+func (e WebpEncoder) Types() []string {
+	return []string{typeWebp}
+}
+
+// MimeType returns the mimetype for webp files.
+func (e WebpEncoder) MimeType() string {
+	return "image/webp"
+}
+
+// AvifEncoder encodes to avif
+type AvifEncoder struct{}
+
+// Encode encodes to avif
+func (e AvifEncoder) Encode(w io.Writer, img interface{}) error {
+	m, ok := img.(*vips.ImageRef)
+	if !ok {
+		return errors.ErrInvalidType
+	}
+
+	buf, _, err := m.ExportAvif(vips.NewAvifExportParams())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+// Types returns the avif suffix
+func (e AvifEncoder) Types() []string {
+	return []string{typeAvif}
+}
+
+// MimeType returns the mimetype for avif files.
+func (e AvifEncoder) MimeType() string {
+	return "image/avif"
+}
+
+// loadAnimation loads all frames of the gif into vips
+func loadAnimation(g *gif.GIF) (*vips.ImageRef, error) {
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		return nil, err
+	}
+
+	params := vips.NewImportParams()
+	params.NumPages.Set(-1)
+	return vips.LoadImageFromBuffer(buf.Bytes(), params)
+}
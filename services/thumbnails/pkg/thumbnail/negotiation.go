@@ -0,0 +1,73 @@
+package thumbnail
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+)
+
+// negotiableTypes are the formats a thumbnail can be encoded to when the client accepts them,
+// in the order of preference.
+var negotiableTypes = []struct {
+	fileType string
+	mimeType string
+}{
+	{fileType: typeAvif, mimeType: "image/avif"},
+	{fileType: typeWebp, mimeType: "image/webp"},
+}
+
+// Negotiable reports whether an encoder for a modern format is available. Without one the format
+// doesn't depend on the Accept header of the client.
+func Negotiable() bool {
+	return len(modernEncoders) > 0
+}
+
+// NegotiateEncoder returns the encoder for the best modern format accepted by the client
+// according to the given Accept header. Only formats which are listed explicitly are used,
+// wildcards like "image/*" fall back to the encoder of the file type. Animated gifs are only
+// encoded to webp, AVIF doesn't keep the animation.
+func NegotiateEncoder(accept, fileType string) (Encoder, bool) {
+	if accept == "" {
+		return nil, false
+	}
+
+	qualities := parseAccept(accept)
+
+	var (
+		best  Encoder
+		bestQ float64
+		isGif = strings.EqualFold(fileType, typeGif)
+	)
+	for _, t := range negotiableTypes {
+		q, listed := qualities[t.mimeType]
+		if !listed || q <= bestQ || (isGif && t.fileType != typeWebp) {
+			continue
+		}
+		if e, ok := modernEncoders[t.fileType]; ok {
+			best, bestQ = e, q
+		}
+	}
+	return best, best != nil
+}
+
+// parseAccept returns the quality values of the media ranges in the Accept header
+func parseAccept(accept string) map[string]float64 {
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				continue
+			}
+			q = parsed
+		}
+		qualities[mediaType] = q
+	}
+	return qualities
+}
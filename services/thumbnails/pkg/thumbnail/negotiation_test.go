@@ -0,0 +1,88 @@
+package thumbnail
+
+import (
+	"io"
+	"testing"
+)
+
+type fakeEncoder struct {
+	fileType string
+}
+
+func (e fakeEncoder) Encode(_ io.Writer, _ interface{}) error {
+	return nil
+}
+
+func (e fakeEncoder) Types() []string {
+	return []string{e.fileType}
+}
+
+func (e fakeEncoder) MimeType() string {
+	return "image/" + e.fileType
+}
+
+func TestNegotiateEncoder(t *testing.T) {
+	encoders := modernEncoders
+	t.Cleanup(func() { modernEncoders = encoders })
+	modernEncoders = map[string]Encoder{
+		typeWebp: fakeEncoder{fileType: typeWebp},
+		typeAvif: fakeEncoder{fileType: typeAvif},
+	}
+
+	tests := []struct {
+		name     string
+		accept   string
+		fileType string
+		want     string
+	}{
+		{name: "no accept header", accept: "", fileType: typePng, want: ""},
+		{name: "wildcards", accept: "image/*,*/*;q=0.8", fileType: typePng, want: ""},
+		{name: "webp", accept: "image/webp,image/*", fileType: typeJpg, want: typeWebp},
+		{name: "avif is preferred", accept: "image/webp,image/avif,image/*", fileType: typeJpg, want: typeAvif},
+		{name: "quality values", accept: "image/avif;q=0.5, image/webp;q=0.9", fileType: typePng, want: typeWebp},
+		{name: "not acceptable", accept: "image/avif;q=0", fileType: typePng, want: ""},
+		{name: "invalid quality", accept: "image/avif;q=2, image/webp", fileType: typePng, want: typeWebp},
+		{name: "animated gifs", accept: "image/avif,image/webp", fileType: typeGif, want: typeWebp},
+		{name: "animated gifs without webp", accept: "image/avif", fileType: typeGif, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, ok := NegotiateEncoder(tt.accept, tt.fileType)
+			if ok != (tt.want != "") {
+				t.Fatalf("expected a negotiated encoder: %v, got %v", tt.want != "", ok)
+			}
+			if ok && e.Types()[0] != tt.want {
+				t.Errorf("expected encoder for %s, got %s", tt.want, e.Types()[0])
+			}
+		})
+	}
+}
+
+func TestNegotiateEncoder_Unsupported(t *testing.T) {
+	encoders := modernEncoders
+	t.Cleanup(func() { modernEncoders = encoders })
+	modernEncoders = map[string]Encoder{}
+
+	if _, ok := NegotiateEncoder("image/avif,image/webp", typePng); ok {
+		t.Error("expected no encoder when the formats are not supported")
+	}
+}
+
+func TestNegotiable(t *testing.T) {
+	encoders := modernEncoders
+	t.Cleanup(func() { modernEncoders = encoders })
+
+	modernEncoders = map[string]Encoder{}
+	if Negotiable() {
+		t.Error("expected no negotiation without modern encoders")
+	}
+	if _, ok := NegotiateEncoder("image/webp", typePng); ok {
+		t.Error("expected the accept header to be ignored without modern encoders")
+	}
+
+	modernEncoders = map[string]Encoder{typeWebp: fakeEncoder{fileType: typeWebp}}
+	if !Negotiable() {
+		t.Error("expected negotiation with a modern encoder")
+	}
+}
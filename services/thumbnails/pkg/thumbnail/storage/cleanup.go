@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cs3org/reva/v2/pkg/bytesize"
+	"github.com/pkg/errors"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config"
+)
+
+const (
+	// _cleanupLock is the name of the lock electing the janitor which cleans up a shared storage
+	_cleanupLock = "cleanup"
+	// _cleanupLockTTL is the time after which the lock expires when the janitor stops refreshing it
+	_cleanupLockTTL = 30 * time.Second
+)
+
+// CleanupPolicy defines which thumbnails are evicted by a cleanup.
+type CleanupPolicy struct {
+	// MaxSize is the maximum total size of the thumbnails, the least recently used thumbnails are evicted
+	// until the total size is below. Zero doesn't limit the size.
+	MaxSize int64
+	// MaxAge evicts the thumbnails which weren't used for the given duration. Zero doesn't limit the age.
+	MaxAge time.Duration
+	// DryRun only reports the thumbnails which would be evicted
+	DryRun bool
+}
+
+// NewCleanupPolicy creates the CleanupPolicy of the given cleanup configuration
+func NewCleanupPolicy(cfg config.Cleanup) (CleanupPolicy, error) {
+	policy := CleanupPolicy{MaxAge: cfg.MaxAge}
+	if cfg.MaxSize != "" {
+		b, err := bytesize.Parse(cfg.MaxSize)
+		if err != nil {
+			return policy, errors.Wrap(err, "could not parse the maximum size")
+		}
+		policy.MaxSize = int64(b.Bytes())
+	}
+	return policy, nil
+}
+
+// CleanupReport summarizes a cleanup.
+type CleanupReport struct {
+	Checked     int
+	TotalSize   int64
+	Evicted     int
+	EvictedSize int64
+	Failed      int
+}
+
+// Cleanup evicts the thumbnails of the storage according to the policy. First all thumbnails which exceed
+// the maximum age are evicted, then the least recently used thumbnails until the total size is below the maximum size.
+func Cleanup(s Cleanable, policy CleanupPolicy, logger log.Logger) (CleanupReport, error) {
+	var (
+		report  CleanupReport
+		entries []Entry
+		now     = time.Now()
+	)
+
+	err := s.Walk(func(e Entry) error {
+		report.Checked++
+		report.TotalSize += e.Size
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	// least recently used first
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastAccess.Before(entries[j].LastAccess)
+	})
+
+	size := report.TotalSize
+	for _, e := range entries {
+		expired := policy.MaxAge > 0 && now.Sub(e.LastAccess) > policy.MaxAge
+		tooLarge := policy.MaxSize > 0 && size > policy.MaxSize
+		if !expired && !tooLarge {
+			// all remaining thumbnails were used more recently
+			break
+		}
+
+		if !policy.DryRun {
+			if err := s.Delete(e.Key); err != nil {
+				logger.Error().Err(err).Str("key", e.Key).Msg("could not delete the thumbnail")
+				report.Failed++
+				continue
+			}
+		}
+
+		size -= e.Size
+		report.Evicted++
+		report.EvictedSize += e.Size
+	}
+
+	return report, nil
+}
+
+// Janitor cleans up a storage periodically. Only the janitor holding the lock in the store
+// runs the cleanup, so a storage shared by several instances is cleaned up only once.
+type Janitor struct {
+	storage  Cleanable
+	policy   CleanupPolicy
+	interval time.Duration
+	locks    kv.Store
+	logger   log.Logger
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// NewJanitor creates a new Janitor
+func NewJanitor(s Cleanable, policy CleanupPolicy, interval time.Duration, locks kv.Store, logger log.Logger) *Janitor {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Janitor{
+		storage:  s,
+		policy:   policy,
+		interval: interval,
+		locks:    locks,
+		logger:   logger,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Run cleans up the storage in the configured interval while it holds the lock, until Stop is called.
+func (j *Janitor) Run() error {
+	kv.Lead(j.ctx, j.locks, _cleanupLock, _cleanupLockTTL, func(ctx context.Context) {
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				j.cleanup()
+			}
+		}
+	})
+	return nil
+}
+
+func (j *Janitor) cleanup() {
+	report, err := Cleanup(j.storage, j.policy, j.logger)
+	if err != nil {
+		j.logger.Error().Err(err).Msg("could not clean up the thumbnail storage")
+		return
+	}
+	j.logger.Info().
+		Int("checked", report.Checked).
+		Int64("totalSize", report.TotalSize).
+		Int("evicted", report.Evicted).
+		Int64("evictedSize", report.EvictedSize).
+		Int("failed", report.Failed).
+		Msg("cleaned up the thumbnail storage")
+}
+
+// Stop stops the janitor.
+func (j *Janitor) Stop() {
+	j.cancel()
+}
@@ -0,0 +1,160 @@
+package storage_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tAssert "github.com/stretchr/testify/assert"
+	tRequire "github.com/stretchr/testify/require"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/kv"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/thumbnail/storage"
+)
+
+// newFileSystem creates a file system storage with thumbnails of 10 bytes which were last used the given hours ago
+func newFileSystem(t *testing.T, root string, ages map[string]int) storage.FileSystem {
+	s := storage.NewFileSystemStorage(config.FileSystemStorage{RootDirectory: root}, log.NopLogger())
+	for key, hours := range ages {
+		tRequire.NoError(t, s.Put(key, []byte("0123456789"), "image/png"))
+		at := time.Now().Add(-time.Duration(hours) * time.Hour)
+		tRequire.NoError(t, os.Chtimes(filepath.Join(root, "files", key), at, at))
+	}
+	return s
+}
+
+func keys(t *testing.T, s storage.Cleanable) []string {
+	var k []string
+	tRequire.NoError(t, s.Walk(func(e storage.Entry) error {
+		k = append(k, e.Key)
+		return nil
+	}))
+	return k
+}
+
+func TestCleanup(t *testing.T) {
+	ages := map[string]int{
+		"aa/aa/1/32x32.png": 1,
+		"aa/aa/1/64x64.png": 30,
+		"bb/bb/2/32x32.png": 5,
+		"cc/cc/3/32x32.png": 100,
+	}
+
+	tests := []struct {
+		name    string
+		policy  storage.CleanupPolicy
+		evicted int
+		remains []string
+	}{
+		{
+			name:    "max size",
+			policy:  storage.CleanupPolicy{MaxSize: 25},
+			evicted: 2,
+			remains: []string{"aa/aa/1/32x32.png", "bb/bb/2/32x32.png"},
+		},
+		{
+			name:    "max age",
+			policy:  storage.CleanupPolicy{MaxAge: 24 * time.Hour},
+			evicted: 2,
+			remains: []string{"aa/aa/1/32x32.png", "bb/bb/2/32x32.png"},
+		},
+		{
+			name:    "max size and age",
+			policy:  storage.CleanupPolicy{MaxSize: 15, MaxAge: 72 * time.Hour},
+			evicted: 3,
+			remains: []string{"aa/aa/1/32x32.png"},
+		},
+		{
+			name:    "dry run",
+			policy:  storage.CleanupPolicy{MaxSize: 15, DryRun: true},
+			evicted: 3,
+			remains: []string{"aa/aa/1/32x32.png", "aa/aa/1/64x64.png", "bb/bb/2/32x32.png", "cc/cc/3/32x32.png"},
+		},
+		{
+			name:    "nothing to evict",
+			policy:  storage.CleanupPolicy{MaxSize: 100},
+			evicted: 0,
+			remains: []string{"aa/aa/1/32x32.png", "aa/aa/1/64x64.png", "bb/bb/2/32x32.png", "cc/cc/3/32x32.png"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := tAssert.New(t)
+			s := newFileSystem(t, t.TempDir(), ages)
+
+			report, err := storage.Cleanup(s, tt.policy, log.NopLogger())
+			assert.NoError(err)
+			assert.Equal(4, report.Checked)
+			assert.EqualValues(40, report.TotalSize)
+			assert.Equal(tt.evicted, report.Evicted)
+			assert.EqualValues(tt.evicted*10, report.EvictedSize)
+			assert.ElementsMatch(tt.remains, keys(t, s))
+		})
+	}
+}
+
+func TestFileSystem_DeleteRemovesEmptyDirectories(t *testing.T) {
+	assert := tAssert.New(t)
+	root := t.TempDir()
+	s := newFileSystem(t, root, map[string]int{"aa/aa/1/32x32.png": 1, "aa/bb/2/32x32.png": 1})
+
+	assert.NoError(s.Delete("aa/aa/1/32x32.png"))
+	assert.False(s.Stat("aa/aa/1/32x32.png"))
+	assert.True(s.Stat("aa/bb/2/32x32.png"))
+	assert.Equal([]string{"aa/bb/2/32x32.png"}, keys(t, s))
+	assert.NoDirExists(filepath.Join(root, "files", "aa", "aa"))
+	assert.DirExists(filepath.Join(root, "files", "aa", "bb"))
+
+	// deleting missing thumbnails is not an error
+	assert.NoError(s.Delete("aa/aa/1/32x32.png"))
+}
+
+func TestNewCleanupPolicy(t *testing.T) {
+	assert := tAssert.New(t)
+
+	policy, err := storage.NewCleanupPolicy(config.Cleanup{MaxSize: "2KB", MaxAge: time.Hour})
+	assert.NoError(err)
+	assert.EqualValues(2000, policy.MaxSize)
+	assert.Equal(time.Hour, policy.MaxAge)
+
+	_, err = storage.NewCleanupPolicy(config.Cleanup{MaxSize: "a lot"})
+	assert.Error(err)
+}
+
+// countingStorage counts the cleanups of a storage
+type countingStorage struct {
+	storage.Cleanable
+	walks *atomic.Int32
+}
+
+func (s countingStorage) Walk(fn func(storage.Entry) error) error {
+	s.walks.Add(1)
+	return s.Cleanable.Walk(fn)
+}
+
+func TestJanitor_SharedStorage(t *testing.T) {
+	s := newFileSystem(t, t.TempDir(), map[string]int{"aa/aa/1/32x32.png": 1})
+	locks := kv.New(kv.Options{Type: "memory"})
+
+	var walks [2]atomic.Int32
+	janitors := make([]*storage.Janitor, 0, len(walks))
+	for i := range walks {
+		j := storage.NewJanitor(countingStorage{Cleanable: s, walks: &walks[i]}, storage.CleanupPolicy{}, 10*time.Millisecond, locks, log.NopLogger())
+		janitors = append(janitors, j)
+		go func() { _ = j.Run() }()
+	}
+
+	tRequire.Eventually(t, func() bool {
+		return walks[0].Load()+walks[1].Load() >= 3
+	}, time.Second, 10*time.Millisecond)
+	for _, j := range janitors {
+		j.Stop()
+	}
+
+	tAssert.True(t, walks[0].Load() == 0 || walks[1].Load() == 0, "only one janitor cleans up the shared storage")
+}
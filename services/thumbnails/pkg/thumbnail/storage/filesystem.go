@@ -4,8 +4,8 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -15,6 +15,9 @@ import (
 
 const (
 	filesDir = "files"
+
+	// _tmpPrefix is the prefix of the temporary files of thumbnails which are written
+	_tmpPrefix = "tmpthumb"
 )
 
 // NewFileSystemStorage creates a new instance of FileSystem
@@ -50,11 +53,19 @@ func (s FileSystem) Get(key string) ([]byte, error) {
 		}
 		return nil, err
 	}
+
+	// remember the access for the cleanup
+	if info, err := os.Stat(img); err == nil && needsTouch(info.ModTime(), time.Now()) {
+		now := time.Now()
+		if err := os.Chtimes(img, now, now); err != nil {
+			s.logger.Debug().Str("err", err.Error()).Str("key", key).Msg("could not update the last access of the thumbnail")
+		}
+	}
 	return content, nil
 }
 
-// Put stores image data in the file system for the given key
-func (s FileSystem) Put(key string, img []byte) error {
+// Put stores image data in the file system for the given key, the mime type is given by the extension of the key
+func (s FileSystem) Put(key string, img []byte, _ string) error {
 	imgPath := filepath.Join(s.root, filesDir, key)
 	dir := filepath.Dir(imgPath)
 	if err := os.MkdirAll(dir, 0700); err != nil {
@@ -62,7 +73,7 @@ func (s FileSystem) Put(key string, img []byte) error {
 	}
 
 	if _, err := os.Stat(imgPath); os.IsNotExist(err) {
-		f, err := os.CreateTemp(dir, "tmpthumb")
+		f, err := os.CreateTemp(dir, _tmpPrefix)
 		if err != nil {
 			return errors.Wrapf(err, "could not create temporary file for \"%s\"", key)
 		}
@@ -103,16 +114,56 @@ func (s FileSystem) Put(key string, img []byte) error {
 //
 // The key also represents the path to the thumbnail in the filesystem under the configured root directory.
 func (s FileSystem) BuildKey(r Request) string {
-	checksum := r.Checksum
-	filetype := r.Types[0]
+	return filepath.FromSlash(buildKey(r))
+}
+
+// Walk calls fn for every thumbnail in the file system, the modification time of the files is their last access
+func (s FileSystem) Walk(fn func(Entry) error) error {
+	root := filepath.Join(s.root, filesDir)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case d.IsDir(), strings.HasPrefix(d.Name(), _tmpPrefix):
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				// the thumbnail was evicted in the meantime
+				return nil
+			}
+			return err
+		}
 
-	parts := []string{strconv.Itoa(r.Resolution.Dx()), "x", strconv.Itoa(r.Resolution.Dy())}
+		key, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
 
-	if r.Characteristic != "" {
-		parts = append(parts, "-", r.Characteristic)
+		return fn(Entry{Key: key, Size: info.Size(), LastAccess: info.ModTime()})
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		// no thumbnail was stored yet
+		return nil
 	}
+	return err
+}
 
-	parts = append(parts, ".", filetype)
+// Delete removes the thumbnail for the given key and the directories which became empty
+func (s FileSystem) Delete(key string) error {
+	root := filepath.Join(s.root, filesDir)
+	img := filepath.Join(root, key)
+	if err := os.Remove(img); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
 
-	return filepath.Join(checksum[:2], checksum[2:4], checksum[4:], strings.Join(parts, ""))
+	for dir := filepath.Dir(img); dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		// fails for directories which still contain thumbnails
+		if err := os.Remove(dir); err != nil {
+			break
+		}
+	}
+	return nil
 }
@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/pkg/errors"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config"
+)
+
+// _lastAccessMetadata is the user metadata which is changed to update the last modification time of an object
+const _lastAccessMetadata = "Last-Access"
+
+// NewS3Storage creates a new instance of S3
+func NewS3Storage(cfg config.S3Storage, logger log.Logger) (S3, error) {
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return S3{}, errors.Wrap(err, "failed to parse s3 endpoint")
+	}
+
+	client, err := minio.New(u.Host, &minio.Options{
+		Region: cfg.Region,
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: u.Scheme != "http",
+	})
+	if err != nil {
+		return S3{}, errors.Wrap(err, "failed to setup s3 client")
+	}
+
+	return S3{
+		client: client,
+		bucket: cfg.Bucket,
+		prefix: strings.Trim(cfg.Prefix, "/"),
+		logger: logger,
+	}, nil
+}
+
+// S3 represents a storage for the thumbnails using an S3 compatible object storage.
+// The storage can be shared by several instances of the thumbnails service.
+type S3 struct {
+	client *minio.Client
+	bucket string
+	prefix string
+	logger log.Logger
+}
+
+// Stat returns if an object for the given key exists in the bucket
+func (s S3) Stat(key string) bool {
+	_, err := s.client.StatObject(context.Background(), s.bucket, s.objectName(key), minio.StatObjectOptions{})
+	return err == nil
+}
+
+// Get returns the object content for the given key
+func (s S3) Get(key string) ([]byte, error) {
+	obj, err := s.client.GetObject(context.Background(), s.bucket, s.objectName(key), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	defer obj.Close()
+
+	content, err := io.ReadAll(obj)
+	if err != nil {
+		err = s.mapError(err)
+		if !errors.Is(err, fs.ErrNotExist) {
+			s.logger.Debug().Str("err", err.Error()).Str("key", key).Msg("could not load thumbnail from store")
+		}
+		return nil, err
+	}
+
+	// remember the access for the cleanup, objects are immutable so they are copied onto themselves
+	if info, err := obj.Stat(); err == nil && needsTouch(info.LastModified, time.Now()) {
+		if err := s.touch(key, info.ContentType); err != nil {
+			s.logger.Debug().Str("err", err.Error()).Str("key", key).Msg("could not update the last access of the thumbnail")
+		}
+	}
+	return content, nil
+}
+
+// Put stores image data of the given mime type in the bucket for the given key
+func (s S3) Put(key string, img []byte, mimeType string) error {
+	_, err := s.client.PutObject(context.Background(), s.bucket, s.objectName(key), bytes.NewReader(img), int64(len(img)), minio.PutObjectOptions{
+		ContentType: mimeType,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "could not store the thumbnail \"%s\"", key)
+	}
+	return nil
+}
+
+// BuildKey generates the unique key for a thumbnail, see FileSystem.BuildKey for the structure.
+func (s S3) BuildKey(r Request) string {
+	return buildKey(r)
+}
+
+// Walk calls fn for every thumbnail in the bucket, the modification time of the objects is their last access
+func (s S3) Walk(fn func(Entry) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opts := minio.ListObjectsOptions{Recursive: true}
+	if s.prefix != "" {
+		opts.Prefix = s.prefix + "/"
+	}
+
+	for obj := range s.client.ListObjects(ctx, s.bucket, opts) {
+		if obj.Err != nil {
+			return obj.Err
+		}
+
+		err := fn(Entry{
+			Key:        strings.TrimPrefix(obj.Key, opts.Prefix),
+			Size:       obj.Size,
+			LastAccess: obj.LastModified,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete removes the object for the given key
+func (s S3) Delete(key string) error {
+	return s.client.RemoveObject(context.Background(), s.bucket, s.objectName(key), minio.RemoveObjectOptions{})
+}
+
+// touch copies the object onto itself, replacing the metadata also replaces the content type so it is passed on
+func (s S3) touch(key, contentType string) error {
+	_, err := s.client.CopyObject(context.Background(),
+		minio.CopyDestOptions{
+			Bucket:          s.bucket,
+			Object:          s.objectName(key),
+			ReplaceMetadata: true,
+			UserMetadata: map[string]string{
+				"Content-Type":      contentType,
+				_lastAccessMetadata: time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+		minio.CopySrcOptions{
+			Bucket: s.bucket,
+			Object: s.objectName(key),
+		},
+	)
+	return err
+}
+
+func (s S3) objectName(key string) string {
+	return path.Join(s.prefix, key)
+}
+
+// mapError maps missing objects to fs.ErrNotExist like the file system storage
+func (s S3) mapError(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return errors.Wrap(fs.ErrNotExist, err.Error())
+	}
+	return err
+}
@@ -0,0 +1,245 @@
+package storage_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tAssert "github.com/stretchr/testify/assert"
+	tRequire "github.com/stretchr/testify/require"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/thumbnail/storage"
+)
+
+type fakeObject struct {
+	data        []byte
+	modTime     time.Time
+	contentType string
+}
+
+// fakeS3 is a minimal stand-in for an S3 compatible storage with a single bucket
+type fakeS3 struct {
+	bucket  string
+	mu      sync.Mutex
+	objects map[string]fakeObject
+}
+
+func newFakeS3(t *testing.T, bucket string) (*fakeS3, *httptest.Server) {
+	f := &fakeS3{bucket: bucket, objects: make(map[string]fakeObject)}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeS3) setModTime(key string, t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	o := f.objects[key]
+	o.modTime = t
+	f.objects[key] = o
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != f.bucket {
+		f.error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		f.list(w, r.URL.Query().Get("prefix"))
+	case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		src, _ := url.PathUnescape(r.Header.Get("X-Amz-Copy-Source"))
+		src = strings.TrimPrefix(strings.TrimPrefix(src, "/"), f.bucket+"/")
+		o, ok := f.objects[src]
+		if !ok {
+			f.error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		o.modTime = time.Now()
+		if r.Header.Get("X-Amz-Metadata-Directive") == "REPLACE" {
+			o.contentType = r.Header.Get("Content-Type")
+		}
+		f.objects[key] = o
+		fmt.Fprintf(w, `<CopyObjectResult><LastModified>%s</LastModified><ETag>"etag"</ETag></CopyObjectResult>`, o.modTime.UTC().Format(time.RFC3339))
+	case r.Method == http.MethodPut:
+		data, err := readPayload(r)
+		if err != nil {
+			f.error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		f.objects[key] = fakeObject{data: data, modTime: time.Now(), contentType: r.Header.Get("Content-Type")}
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodHead, r.Method == http.MethodGet:
+		o, ok := f.objects[key]
+		if !ok {
+			f.error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(o.data)))
+		w.Header().Set("Last-Modified", o.modTime.UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Type", o.contentType)
+		w.Header().Set("ETag", `"etag"`)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(o.data)
+		}
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		f.error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, prefix string) {
+	type content struct {
+		Key          string
+		LastModified string
+		ETag         string
+		Size         int
+	}
+	res := struct {
+		XMLName     xml.Name `xml:"ListBucketResult"`
+		Name        string
+		Prefix      string
+		KeyCount    int
+		MaxKeys     int
+		IsTruncated bool
+		Contents    []content
+	}{Name: f.bucket, Prefix: prefix, MaxKeys: 1000}
+
+	for key, o := range f.objects {
+		if strings.HasPrefix(key, prefix) {
+			res.Contents = append(res.Contents, content{Key: key, LastModified: o.modTime.UTC().Format(time.RFC3339), ETag: `"etag"`, Size: len(o.data)})
+		}
+	}
+	sort.Slice(res.Contents, func(i, j int) bool { return res.Contents[i].Key < res.Contents[j].Key })
+	res.KeyCount = len(res.Contents)
+
+	_ = xml.NewEncoder(w).Encode(res)
+}
+
+func (f *fakeS3) error(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<Error><Code>%s</Code><Message>%s</Message></Error>`, code, code)
+}
+
+// readPayload reads the request body, payloads with a streaming signature are sent in chunks
+func readPayload(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+
+	var data []byte
+	br := bufio.NewReader(r.Body)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		hexSize, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(hexSize, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return data, nil
+		}
+
+		chunk := make([]byte, size)
+		if _, err := io.ReadFull(br, chunk); err != nil {
+			return nil, err
+		}
+		data = append(data, chunk...)
+		if _, err := br.Discard(2); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func newS3Storage(t *testing.T, f *fakeS3, srv *httptest.Server, prefix string) storage.S3 {
+	s, err := storage.NewS3Storage(config.S3Storage{
+		Endpoint:  srv.URL,
+		Region:    "default",
+		Bucket:    f.bucket,
+		AccessKey: "access",
+		SecretKey: "secret",
+		Prefix:    prefix,
+	}, log.NopLogger())
+	tRequire.NoError(t, err)
+	return s
+}
+
+func TestS3(t *testing.T) {
+	assert := tAssert.New(t)
+	f, srv := newFakeS3(t, "thumbnails")
+	s := newS3Storage(t, f, srv, "ocis")
+
+	key := "12/0E/A8A25E5D487BF68B5F7096440019/2x2.png"
+	assert.False(s.Stat(key))
+
+	_, err := s.Get(key)
+	assert.ErrorIs(err, fs.ErrNotExist)
+
+	assert.NoError(s.Put(key, []byte("thumbnail"), "image/png"))
+	assert.True(s.Stat(key))
+	assert.Contains(f.objects, "ocis/"+key)
+	assert.Equal("image/png", f.objects["ocis/"+key].contentType)
+
+	content, err := s.Get(key)
+	assert.NoError(err)
+	assert.Equal([]byte("thumbnail"), content)
+
+	var entries []storage.Entry
+	assert.NoError(s.Walk(func(e storage.Entry) error {
+		entries = append(entries, e)
+		return nil
+	}))
+	assert.Len(entries, 1)
+	assert.Equal(key, entries[0].Key)
+	assert.EqualValues(len("thumbnail"), entries[0].Size)
+
+	assert.NoError(s.Delete(key))
+	assert.False(s.Stat(key))
+}
+
+func TestS3_GetUpdatesLastAccess(t *testing.T) {
+	assert := tAssert.New(t)
+	f, srv := newFakeS3(t, "thumbnails")
+	s := newS3Storage(t, f, srv, "")
+
+	assert.NoError(s.Put("recent.png", []byte("recent"), "image/png"))
+	assert.NoError(s.Put("old.jpg", []byte("old"), "image/jpeg"))
+
+	old := time.Now().Add(-48 * time.Hour)
+	f.setModTime("recent.png", time.Now().Add(-time.Minute))
+	f.setModTime("old.jpg", old)
+
+	_, err := s.Get("recent.png")
+	assert.NoError(err)
+	_, err = s.Get("old.jpg")
+	assert.NoError(err)
+
+	assert.True(f.objects["old.jpg"].modTime.After(old.Add(time.Hour)))
+	assert.True(f.objects["recent.png"].modTime.Before(time.Now().Add(-30 * time.Second)))
+	assert.True(bytes.Equal([]byte("old"), f.objects["old.jpg"].data))
+	assert.Equal("image/jpeg", f.objects["old.jpg"].contentType, "the content type is kept")
+}
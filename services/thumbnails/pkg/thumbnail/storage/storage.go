@@ -1,9 +1,21 @@
 package storage
 
 import (
+	"fmt"
 	"image"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config"
 )
 
+// _touchInterval is the minimum time between two updates of the last access of a thumbnail.
+// It limits the writes caused by reading thumbnails, the eviction is accurate to this interval.
+const _touchInterval = time.Hour
+
 // Request combines different attributes needed for storage operations.
 type Request struct {
 	// The checksum of the source file
@@ -27,6 +39,56 @@ type Request struct {
 type Storage interface {
 	Stat(key string) bool
 	Get(key string) ([]byte, error)
-	Put(key string, img []byte) error
+	// Put stores the image data of the given mime type
+	Put(key string, img []byte, mimeType string) error
 	BuildKey(r Request) string
 }
+
+// Entry describes a stored thumbnail.
+type Entry struct {
+	Key  string
+	Size int64
+	// LastAccess is the time the thumbnail was last created or read
+	LastAccess time.Time
+}
+
+// Cleanable is a Storage whose thumbnails can be listed and evicted.
+type Cleanable interface {
+	Storage
+	// Walk calls fn for every stored thumbnail
+	Walk(fn func(Entry) error) error
+	Delete(key string) error
+}
+
+// New creates the storage configured in the given thumbnail configuration.
+func New(cfg config.Thumbnail, logger log.Logger) (Cleanable, error) {
+	switch cfg.StorageType {
+	case "", "filesystem":
+		return NewFileSystemStorage(cfg.FileSystemStorage, logger), nil
+	case "s3":
+		return NewS3Storage(cfg.S3Storage, logger)
+	default:
+		return nil, fmt.Errorf("unknown thumbnail storage: %s", cfg.StorageType)
+	}
+}
+
+// buildKey generates the unique key for a thumbnail, see FileSystem.BuildKey for the structure.
+func buildKey(r Request) string {
+	checksum := r.Checksum
+	filetype := r.Types[0]
+
+	parts := []string{strconv.Itoa(r.Resolution.Dx()), "x", strconv.Itoa(r.Resolution.Dy())}
+
+	if r.Characteristic != "" {
+		parts = append(parts, "-", r.Characteristic)
+	}
+
+	parts = append(parts, ".", filetype)
+
+	return path.Join(checksum[:2], checksum[2:4], checksum[4:], strings.Join(parts, ""))
+}
+
+// needsTouch returns if the last access of a thumbnail needs to be updated
+func needsTouch(lastAccess, now time.Time) bool {
+	return now.Sub(lastAccess) >= _touchInterval
+}
@@ -93,7 +93,7 @@ func (s SimpleManager) Generate(r Request, img interface{}) (string, error) {
 	}
 
 	k := s.storage.BuildKey(mapToStorageRequest(r))
-	if err := s.storage.Put(k, buf.Bytes()); err != nil {
+	if err := s.storage.Put(k, buf.Bytes(), r.Encoder.MimeType()); err != nil {
 		s.logger.Error().Err(err).Msg("could not store thumbnail")
 		return "", err
 	}
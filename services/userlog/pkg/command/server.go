@@ -19,6 +19,8 @@ import (
 	"github.com/owncloud/ocis/v2/ocis-pkg/version"
 	ehsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/eventhistory/v0"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	avevent "github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
+	searchevent "github.com/owncloud/ocis/v2/services/search/pkg/event"
 	"github.com/owncloud/ocis/v2/services/userlog/pkg/config"
 	"github.com/owncloud/ocis/v2/services/userlog/pkg/config/parser"
 	"github.com/owncloud/ocis/v2/services/userlog/pkg/logging"
@@ -31,6 +33,7 @@ import (
 var _registeredEvents = []events.Unmarshaller{
 	// file related
 	events.PostprocessingStepFinished{},
+	avevent.FileQuarantined{},
 
 	// space related
 	events.SpaceDisabled{},
@@ -43,6 +46,9 @@ var _registeredEvents = []events.Unmarshaller{
 	events.ShareCreated{},
 	events.ShareRemoved{},
 	events.ShareExpired{},
+
+	// search related
+	searchevent.SavedSearchMatched{},
 }
 
 // Server is the entrypoint for the server command.
@@ -19,6 +19,8 @@ import (
 	"github.com/cs3org/reva/v2/pkg/storagespace"
 	"github.com/cs3org/reva/v2/pkg/utils"
 	"github.com/owncloud/ocis/v2/ocis-pkg/l10n"
+	avevent "github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
+	searchevent "github.com/owncloud/ocis/v2/services/search/pkg/event"
 )
 
 //go:embed l10n/locale
@@ -95,6 +97,8 @@ func (c *Converter) ConvertEvent(eventid string, event interface{}) (OC10Notific
 		default:
 			return OC10Notification{}, fmt.Errorf("unknown postprocessing step: %s", ev.FinishedStep)
 		}
+	case avevent.FileQuarantined:
+		return c.virusMessage(eventid, FileQuarantined, ev.ExecutingUser, ev.ResourceID, ev.Filename, ev.Virus, utils.TSToTime(ev.Timestamp))
 
 	// space related
 	case events.SpaceDisabled:
@@ -115,6 +119,10 @@ func (c *Converter) ConvertEvent(eventid string, event interface{}) (OC10Notific
 		return c.shareMessage(eventid, ShareExpired, ev.ShareOwner, ev.ItemID, ev.ShareID, ev.ExpiredAt)
 	case events.ShareRemoved:
 		return c.shareMessage(eventid, ShareRemoved, ev.Executant, ev.ItemID, ev.ShareID, ev.Timestamp)
+
+	// search related
+	case searchevent.SavedSearchMatched:
+		return c.savedSearchMessage(eventid, SavedSearchMatched, ev.SavedSearchID, ev.Name, ev.Query, ev.NewMatches, utils.TSToTime(ev.Timestamp))
 	}
 }
 
@@ -267,6 +275,36 @@ func (c *Converter) virusMessage(eventid string, nt NotificationTemplate, execut
 	}, nil
 }
 
+func (c *Converter) savedSearchMessage(eventid string, nt NotificationTemplate, id string, name string, query string, count int, ts time.Time) (OC10Notification, error) {
+	subj, subjraw, msg, msgraw, err := composeMessage(nt, c.locale, c.defaultLanguage, c.translationPath, map[string]interface{}{
+		"searchname": name,
+		"count":      count,
+	})
+	if err != nil {
+		return OC10Notification{}, err
+	}
+
+	dets := map[string]interface{}{
+		"search": map[string]interface{}{
+			"id":         id,
+			"name":       name,
+			"query":      query,
+			"newmatches": count,
+		},
+	}
+
+	return OC10Notification{
+		EventID:        eventid,
+		Service:        c.serviceName,
+		Timestamp:      ts.Format(time.RFC3339Nano),
+		Subject:        subj,
+		SubjectRaw:     subjraw,
+		Message:        msg,
+		MessageRaw:     msgraw,
+		MessageDetails: dets,
+	}, nil
+}
+
 func (c *Converter) policiesMessage(eventid string, nt NotificationTemplate, executant *user.User, filename string, ts time.Time) (OC10Notification, error) {
 	subj, subjraw, msg, msgraw, err := composeMessage(nt, c.locale, c.defaultLanguage, c.translationPath, map[string]interface{}{
 		"resourcename": filename,
@@ -8,6 +8,7 @@ import (
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/ocis-pkg/middleware"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	avevent "github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
 	"github.com/owncloud/ocis/v2/services/settings/pkg/store/defaults"
 	micrometadata "go-micro.dev/v4/metadata"
 )
@@ -60,7 +61,7 @@ func (ulf userlogFilter) filterUsersBySettings(ctx context.Context, users []stri
 		settingId = defaults.SettingUUIDProfileEventSpaceDisabled
 	case events.SpaceDeleted:
 		settingId = defaults.SettingUUIDProfileEventSpaceDeleted
-	case events.PostprocessingStepFinished:
+	case events.PostprocessingStepFinished, avevent.FileQuarantined:
 		settingId = defaults.SettingUUIDProfileEventPostprocessingStepFinished
 	default:
 		// event that cannot be disabled
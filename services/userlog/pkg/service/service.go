@@ -23,6 +23,8 @@ import (
 	ehmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/eventhistory/v0"
 	ehsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/eventhistory/v0"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	avevent "github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
+	searchevent "github.com/owncloud/ocis/v2/services/search/pkg/event"
 	"github.com/owncloud/ocis/v2/services/userlog/pkg/config"
 )
 
@@ -141,7 +143,8 @@ func (ul *UserlogService) processEvent(event events.Event) {
 		switch e.FinishedStep {
 		case events.PPStepAntivirus:
 			result := e.Result.(events.VirusscanResult)
-			if !result.Infected {
+			if !result.Infected || e.Outcome == avevent.PPOutcomeQuarantine {
+				// quarantined files are reported with the FileQuarantined event
 				return
 			}
 
@@ -155,6 +158,12 @@ func (ul *UserlogService) processEvent(event events.Event) {
 		default:
 			return
 		}
+	case avevent.FileQuarantined:
+		users = append(users, e.ExecutingUser.GetId().GetOpaqueId())
+
+	// search related
+	case searchevent.SavedSearchMatched:
+		users = append(users, e.Owner.GetOpaqueId())
 
 	// space related // TODO: how to find spaceadmins?
 	case events.SpaceDisabled:
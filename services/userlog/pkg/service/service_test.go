@@ -32,6 +32,7 @@ import (
 	ehsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/eventhistory/v0"
 	"github.com/owncloud/ocis/v2/protogen/gen/ocis/services/eventhistory/v0/mocks"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	avevent "github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
 	"github.com/owncloud/ocis/v2/services/userlog/pkg/config"
 	"github.com/owncloud/ocis/v2/services/userlog/pkg/service"
 )
@@ -107,6 +108,8 @@ var _ = Describe("UserlogService", func() {
 			service.ValueClient(&vc),
 			service.RegisteredEvents([]events.Unmarshaller{
 				events.SpaceDisabled{},
+				events.PostprocessingStepFinished{},
+				avevent.FileQuarantined{},
 			}),
 			service.TraceProvider(trace.NewNoopTracerProvider()),
 		)
@@ -151,6 +154,43 @@ var _ = Describe("UserlogService", func() {
 		Expect(len(evs)).To(Equal(0))
 	})
 
+	It("informs the uploader of a quarantined file only once", func() {
+		uploader := &user.User{Id: &user.UserId{OpaqueId: "uploader"}}
+		quarantined := bus.publish(avevent.FileQuarantined{ExecutingUser: uploader, Filename: "eicar.com", Virus: "Eicar-Test-Signature"})
+		bus.publish(events.PostprocessingStepFinished{
+			ExecutingUser: uploader,
+			Filename:      "eicar.com",
+			FinishedStep:  events.PPStepAntivirus,
+			Outcome:       avevent.PPOutcomeQuarantine,
+			Result:        events.VirusscanResult{Infected: true, Description: "Eicar-Test-Signature"},
+		})
+		deleted := bus.publish(events.PostprocessingStepFinished{
+			ExecutingUser: uploader,
+			Filename:      "other.com",
+			FinishedStep:  events.PPStepAntivirus,
+			Outcome:       events.PPOutcomeDelete,
+			Result:        events.VirusscanResult{Infected: true, Description: "Eicar-Test-Signature"},
+		})
+
+		var ids []string
+		Eventually(func() []string {
+			recs, err := sto.Read("uploader")
+			if err != nil || len(recs) == 0 {
+				return nil
+			}
+			ids = nil
+			_ = json.Unmarshal(recs[0].Value, &ids)
+			return ids
+		}).Should(HaveLen(2))
+
+		Consistently(func() []string {
+			recs, _ := sto.Read("uploader")
+			ids = nil
+			_ = json.Unmarshal(recs[0].Value, &ids)
+			return ids
+		}, 500*time.Millisecond).Should(ConsistOf(quarantined, deleted))
+	})
+
 	AfterEach(func() {
 		close(bus)
 	})
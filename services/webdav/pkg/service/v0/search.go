@@ -54,6 +54,7 @@ func (g Webdav) Search(w http.ResponseWriter, r *http.Request) {
 	req := &searchsvc.SearchRequest{
 		Query:    rep.SearchFiles.Search.Pattern,
 		PageSize: int32(rep.SearchFiles.Search.Limit),
+		Facets:   rep.SearchFiles.Search.Facets,
 	}
 
 	// Limit search to the according space when searching /dav/spaces/
@@ -91,7 +92,7 @@ func (g Webdav) Search(w http.ResponseWriter, r *http.Request) {
 
 func (g Webdav) sendSearchResponse(rsp *searchsvc.SearchResponse, w http.ResponseWriter, r *http.Request) {
 	logger := g.log.SubloggerWithRequestID(r.Context())
-	responsesXML, err := multistatusResponse(r.Context(), rsp.Matches)
+	responsesXML, err := multistatusResponse(r.Context(), rsp.Matches, rsp.Facets)
 	if err != nil {
 		logger.Error().Err(err).Msg("error formatting propfind")
 		w.WriteHeader(http.StatusInternalServerError)
@@ -108,8 +109,8 @@ func (g Webdav) sendSearchResponse(rsp *searchsvc.SearchResponse, w http.Respons
 	}
 }
 
-// multistatusResponse converts a list of matches into a multistatus response string
-func multistatusResponse(ctx context.Context, matches []*searchmsg.Match) ([]byte, error) {
+// multistatusResponse converts a list of matches and the requested facets into a multistatus response string
+func multistatusResponse(ctx context.Context, matches []*searchmsg.Match, facets []*searchmsg.Facet) ([]byte, error) {
 	responses := make([]*propfind.ResponseXML, 0, len(matches))
 	for i := range matches {
 		res, err := matchToPropResponse(ctx, matches[i])
@@ -119,8 +120,18 @@ func multistatusResponse(ctx context.Context, matches []*searchmsg.Match) ([]byt
 		responses = append(responses, res)
 	}
 
-	msr := propfind.NewMultiStatusResponseXML()
+	msr := searchMultiStatusXML{MultiStatusResponseXML: *propfind.NewMultiStatusResponseXML()}
 	msr.Responses = responses
+	if len(facets) > 0 {
+		msr.Facets = &facetsXML{}
+	}
+	for _, f := range facets {
+		facet := facetXML{Name: f.GetName()}
+		for _, v := range f.GetValues() {
+			facet.Values = append(facet.Values, facetValueXML{Count: v.GetCount(), Value: v.GetValue()})
+		}
+		msr.Facets.Facets = append(msr.Facets.Facets, facet)
+	}
 	msg, err := xml.Marshal(msr)
 	if err != nil {
 		return nil, err
@@ -235,9 +246,30 @@ type reportSearchFiles struct {
 	Search  reportSearchFilesSearch `xml:"search"`
 }
 type reportSearchFilesSearch struct {
-	Pattern string `xml:"pattern"`
-	Limit   int    `xml:"limit"`
-	Offset  int    `xml:"offset"`
+	Pattern string   `xml:"pattern"`
+	Limit   int      `xml:"limit"`
+	Offset  int      `xml:"offset"`
+	Facets  []string `xml:"facet"`
+}
+
+// searchMultiStatusXML is the multistatus response of a search, the facets follow the responses
+type searchMultiStatusXML struct {
+	propfind.MultiStatusResponseXML
+	Facets *facetsXML `xml:"oc:facets,omitempty"`
+}
+
+type facetsXML struct {
+	Facets []facetXML `xml:"oc:facet"`
+}
+
+type facetXML struct {
+	Name   string          `xml:"name,attr"`
+	Values []facetValueXML `xml:"oc:value"`
+}
+
+type facetValueXML struct {
+	Count int64  `xml:"count,attr"`
+	Value string `xml:",chardata"`
 }
 
 type reportFilterFiles struct {
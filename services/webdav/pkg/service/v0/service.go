@@ -242,6 +242,7 @@ func (g Webdav) SpacesThumbnail(w http.ResponseWriter, r *http.Request) {
 		Width:         tr.Width,
 		Height:        tr.Height,
 		Processor:     tr.Processor,
+		Accept:        r.Header.Get("Accept"),
 		Source: &thumbnailssvc.GetThumbnailRequest_Cs3Source{
 			Cs3Source: &thumbnailsmsg.CS3Source{
 				Path:          fullPath,
@@ -340,6 +341,7 @@ func (g Webdav) Thumbnail(w http.ResponseWriter, r *http.Request) {
 		Width:         tr.Width,
 		Height:        tr.Height,
 		Processor:     tr.Processor,
+		Accept:        r.Header.Get("Accept"),
 		Source: &thumbnailssvc.GetThumbnailRequest_Cs3Source{
 			Cs3Source: &thumbnailsmsg.CS3Source{
 				Path:          fullPath,
@@ -390,6 +392,7 @@ func (g Webdav) PublicThumbnail(w http.ResponseWriter, r *http.Request) {
 		Width:         tr.Width,
 		Height:        tr.Height,
 		Processor:     tr.Processor,
+		Accept:        r.Header.Get("Accept"),
 		Source: &thumbnailssvc.GetThumbnailRequest_WebdavSource{
 			WebdavSource: &thumbnailsmsg.WebdavSource{
 				Url:             g.config.OcisPublicURL + r.URL.RequestURI(),
@@ -435,6 +438,7 @@ func (g Webdav) PublicThumbnailHead(w http.ResponseWriter, r *http.Request) {
 		Width:         tr.Width,
 		Height:        tr.Height,
 		Processor:     tr.Processor,
+		Accept:        r.Header.Get("Accept"),
 		Source: &thumbnailssvc.GetThumbnailRequest_WebdavSource{
 			WebdavSource: &thumbnailsmsg.WebdavSource{
 				Url:             g.config.OcisPublicURL + r.URL.RequestURI(),
@@ -497,8 +501,12 @@ func (g Webdav) sendThumbnailResponse(rsp *thumbnailssvc.GetThumbnailResponse, w
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", dlRsp.Header.Get("Content-Type"))
+	// the thumbnails service tells if the format depends on the formats accepted by the client
+	if vary := dlRsp.Header.Get("Vary"); vary != "" {
+		w.Header().Set("Vary", vary)
+	}
+	w.WriteHeader(http.StatusOK)
 	_, err = io.Copy(w, dlRsp.Body)
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to write thumbnail to response writer")